@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/kennyp/speedrun/internal/ui"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/speedrun"
+	"github.com/urfave/cli/v3"
+)
+
+// actionsCommand returns the `speedrun actions` command
+func actionsCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "actions",
+		Usage:     "Analyze the PR of a GitHub Actions pull_request event and post a sticky summary comment",
+		ArgsUsage: "[url|owner/repo#number]",
+		Action:    runActions,
+	}
+}
+
+func runActions(ctx context.Context, cmd *cli.Command) error {
+	// Authenticate with the workflow token unless a token is configured
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" && cmd.String("github-token") == "" {
+		if err := cmd.Set("github-token", token); err != nil {
+			return fmt.Errorf("failed to use GITHUB_TOKEN: %w", err)
+		}
+	}
+
+	ref := cmd.Args().First()
+	if ref == "" {
+		var err error
+		if ref, err = eventPRRef(os.Getenv("GITHUB_EVENT_PATH"), os.Getenv("GITHUB_REPOSITORY")); err != nil {
+			return err
+		}
+	}
+
+	action, err := forPR(ref, "speedrun actions [url|owner/repo#number]",
+		func(ctx context.Context, e *speedrun.Engine, pr *github.PullRequest) error {
+			return ui.PostAnalysisComment(ctx, os.Stdout, e.Config(), e.GitHub(), e.AI(), e.Tracker(), e.Username(), pr)
+		})
+	if err != nil {
+		return err
+	}
+	return run(ctx, cmd, action)
+}
+
+// eventPRRef reads the PR being built from a GitHub Actions event payload
+func eventPRRef(eventPath, repository string) (string, error) {
+	if eventPath == "" || repository == "" {
+		return "", fmt.Errorf("not running in GitHub Actions (GITHUB_EVENT_PATH and GITHUB_REPOSITORY unset); pass a PR URL or owner/repo#number")
+	}
+
+	data, err := os.ReadFile(eventPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read event payload: %w", err)
+	}
+	var event struct {
+		PullRequest struct {
+			Number int `json:"number"`
+		} `json:"pull_request"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return "", fmt.Errorf("failed to parse event payload: %w", err)
+	}
+	if event.PullRequest.Number == 0 {
+		return "", fmt.Errorf("event has no pull request; run on pull_request or pull_request_target events")
+	}
+	return fmt.Sprintf("%s#%d", repository, event.PullRequest.Number), nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/kennyp/speedrun/internal/ui"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/speedrun"
+	"github.com/urfave/cli/v3"
+)
+
+// analyzeCommand returns the `speedrun analyze` command
+func analyzeCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "analyze",
+		Usage:     "Fetch and analyze a single PR and print the recommendation",
+		ArgsUsage: "<url|owner/repo#number>",
+		Action:    analyzePR,
+	}
+}
+
+func analyzePR(ctx context.Context, cmd *cli.Command) error {
+	action, err := forPR(cmd.Args().First(), "speedrun analyze <url|owner/repo#number>",
+		func(ctx context.Context, e *speedrun.Engine, pr *github.PullRequest) error {
+			return ui.RenderPR(ctx, os.Stdout, e.Config(), e.GitHub(), e.AI(), e.Tracker(), e.Username(), pr)
+		})
+	if err != nil {
+		return err
+	}
+	return run(ctx, cmd, action)
+}
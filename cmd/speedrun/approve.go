@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/history"
+	"github.com/kennyp/speedrun/pkg/speedrun"
+	"github.com/urfave/cli/v3"
+)
+
+// approveCommand returns the `speedrun approve` command
+func approveCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "approve",
+		Usage:     "Approve a single PR, and optionally merge it, from scripts and chat-ops bots",
+		ArgsUsage: "<url|owner/repo#number>",
+		Action:    approvePR,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "body",
+				Usage: "review body",
+				Value: "LGTM",
+			},
+			&cli.BoolFlag{
+				Name:  "merge",
+				Usage: "enable auto-merge after approving, or merge now if nothing is pending (implied by auto_merge_on_approval = true); refused during freeze windows",
+			},
+			&cli.StringFlag{
+				Name:  "merge-method",
+				Usage: "merge method (merge, squash, rebase)",
+				Value: "squash",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "don't ask before merging when auto_merge_on_approval = ask",
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "approve even if checks are failing, required signatures are missing or a review checklist is required",
+			},
+		},
+	}
+}
+
+func approvePR(ctx context.Context, cmd *cli.Command) error {
+	method := strings.ToUpper(cmd.String("merge-method"))
+	switch method {
+	case "MERGE", "SQUASH", "REBASE":
+	default:
+		return fmt.Errorf("invalid --merge-method %q (want merge, squash or rebase)", cmd.String("merge-method"))
+	}
+
+	action, err := forPR(cmd.Args().First(), "speedrun approve [flags] <url|owner/repo#number>",
+		func(ctx context.Context, e *speedrun.Engine, pr *github.PullRequest) error {
+			return approveAndMerge(ctx, cmd, e, pr, method)
+		})
+	if err != nil {
+		return err
+	}
+	return run(ctx, cmd, action)
+}
+
+func approveAndMerge(ctx context.Context, cmd *cli.Command, e *speedrun.Engine, pr *github.PullRequest, method string) error {
+	opts := speedrun.ApproveOptions{
+		Body:        cmd.String("body"),
+		Merge:       cmd.Bool("merge"),
+		MergeMethod: method,
+		Force:       cmd.Bool("force"),
+	}
+	if !cmd.Bool("yes") {
+		opts.Confirm = confirm
+	}
+
+	done, err := e.Approve(ctx, pr, opts)
+	ref := fmt.Sprintf("%s/%s#%d", pr.Owner, pr.Repo, pr.Number)
+	for _, outcome := range done {
+		switch outcome {
+		case history.Approved:
+			report(e, "Approved %s\n", "Would approve %s\n", ref)
+		case history.AutoMergeEnabled:
+			report(e, "Auto-merge enabled for %s\n", "Would enable auto-merge for %s\n", ref)
+		case history.Merged:
+			report(e, "Merged %s\n", "Would merge %s\n", ref)
+		}
+	}
+	return err
+}
+
+// confirm asks a yes/no question on the terminal
+func confirm(question string) (bool, error) {
+	if !isTerminal(os.Stdin) {
+		return false, errors.New("confirmation needed but stdin is not a terminal; pass --yes to merge without asking")
+	}
+
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// report prints the outcome of a write, or what it would have been in a dry run
+func report(e *speedrun.Engine, done, dryRun string, ref string) {
+	if e.GitHub().DryRun() {
+		fmt.Printf("[dry run] "+dryRun, ref)
+		return
+	}
+	fmt.Printf(done, ref)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kennyp/speedrun/pkg/cache"
+	"github.com/urfave/cli/v3"
+)
+
+// cacheCommand returns the `speedrun cache` command tree
+func cacheCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "cache",
+		Usage: "Manage the speedrun cache",
+		Commands: []*cli.Command{
+			{
+				Name:      "export",
+				Usage:     "Export cached AI analyses and PR metadata to a JSON snapshot",
+				ArgsUsage: "<file|->",
+				Action:    cacheExport,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "prefix",
+						Usage: "only export keys with this prefix (e.g., ai:)",
+					},
+				},
+			},
+			{
+				Name:      "import",
+				Usage:     "Import a JSON snapshot produced by `speedrun cache export`",
+				ArgsUsage: "<file|->",
+				Action:    cacheImport,
+			},
+		},
+	}
+}
+
+// openSQLiteCache opens the configured cache database for maintenance commands
+func openSQLiteCache(cmd *cli.Command) (*cache.SQLiteCache, error) {
+	c, err := cache.New(cmd.String("cache-path"), cmd.Duration("cache-max-age"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache: %w", err)
+	}
+	return c.(*cache.SQLiteCache), nil
+}
+
+func cacheExport(ctx context.Context, cmd *cli.Command) error {
+	path := cmd.Args().First()
+	if path == "" {
+		return fmt.Errorf("usage: speedrun cache export <file|->")
+	}
+
+	c, err := openSQLiteCache(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = c.Close()
+	}()
+
+	var w io.Writer = os.Stdout
+	if path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		w = f
+	}
+
+	count, err := c.Export(w, cmd.String("prefix"))
+	if err != nil {
+		return err
+	}
+
+	if path != "-" {
+		fmt.Printf("💾 Exported %d cache entries to %s\n", count, path)
+	}
+	return nil
+}
+
+func cacheImport(ctx context.Context, cmd *cli.Command) error {
+	path := cmd.Args().First()
+	if path == "" {
+		return fmt.Errorf("usage: speedrun cache import <file|->")
+	}
+
+	c, err := openSQLiteCache(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = c.Close()
+	}()
+
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		r = f
+	}
+
+	count, err := c.Import(r)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("💾 Imported %d cache entries into %s\n", count, cmd.String("cache-path"))
+	return nil
+}
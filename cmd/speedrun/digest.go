@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kennyp/speedrun/internal/ui"
+	"github.com/kennyp/speedrun/pkg/digest"
+	"github.com/kennyp/speedrun/pkg/speedrun"
+	"github.com/urfave/cli/v3"
+)
+
+// digestCommand returns the `speedrun digest` command
+func digestCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "digest",
+		Usage:  "Render the PR queue and AI analyses as a markdown or HTML digest, e.g. for the on-call handoff email",
+		Action: sendDigest,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "digest format for --output: markdown or html",
+				Value: "markdown",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "write the digest to this file (- for stdout)",
+			},
+			&cli.BoolFlag{
+				Name:  "email",
+				Usage: "email the digest using the digest.* SMTP settings",
+			},
+			&cli.StringFlag{
+				Name:  "subject",
+				Usage: "email subject (default \"PR queue for <user>: <n> open (<date>)\")",
+			},
+		},
+	}
+}
+
+func sendDigest(ctx context.Context, cmd *cli.Command) error {
+	format := cmd.String("format")
+	if format != "markdown" && format != "html" {
+		return fmt.Errorf("--format must be markdown or html, got %q", format)
+	}
+
+	// Print to stdout unless told to go somewhere else
+	output := cmd.String("output")
+	if output == "" && !cmd.Bool("email") {
+		output = "-"
+	}
+
+	return run(ctx, cmd, func(ctx context.Context, e *speedrun.Engine) error {
+		d, err := ui.LoadDigest(ctx, e.Config(), e.GitHub(), e.AI(), e.Tracker(), e.Username())
+		if err != nil {
+			return err
+		}
+
+		if output != "" {
+			if err := writeDigest(output, format, d); err != nil {
+				return err
+			}
+		}
+
+		if cmd.Bool("email") {
+			subject := cmd.String("subject")
+			if subject == "" {
+				subject = d.Subject()
+			}
+			cfg := e.Config().Digest
+			mail := digest.SMTPConfig{
+				Addr:     cfg.SMTPAddr,
+				Username: cfg.SMTPUsername,
+				Password: cfg.SMTPPassword,
+				From:     cfg.From,
+				To:       cfg.To,
+			}
+			if err := digest.Send(mail, subject, d); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "📧 Sent digest of %d PRs to %d recipients\n", len(d.Entries), len(mail.To))
+		}
+		return nil
+	})
+}
+
+// writeDigest renders the digest to a file, or stdout for "-"
+func writeDigest(path, format string, d digest.Digest) error {
+	content := d.Markdown()
+	if format == "html" {
+		var err error
+		if content, err = d.HTML(); err != nil {
+			return err
+		}
+	}
+
+	if path == "-" {
+		_, err := io.WriteString(os.Stdout, content)
+		return err
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write digest: %w", err)
+	}
+	return nil
+}
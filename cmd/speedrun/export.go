@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kennyp/speedrun/internal/ui"
+	"github.com/kennyp/speedrun/pkg/speedrun"
+	"github.com/urfave/cli/v3"
+)
+
+// exportCommand returns the `speedrun export` command
+func exportCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "export",
+		Usage:  "Export the PR queue with stats and AI recommendations as CSV or a markdown table",
+		Action: exportQueue,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "export format: csv or markdown",
+				Value: ui.ExportCSV,
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "write to this file instead of stdout",
+			},
+		},
+	}
+}
+
+func exportQueue(ctx context.Context, cmd *cli.Command) error {
+	format := cmd.String("format")
+	if format != ui.ExportCSV && format != ui.ExportMarkdown {
+		return fmt.Errorf("--format must be csv or markdown, got %q", format)
+	}
+
+	return run(ctx, cmd, func(ctx context.Context, e *speedrun.Engine) error {
+		var w io.Writer = os.Stdout
+		if path := cmd.String("output"); path != "" {
+			f, err := os.Create(path)
+			if err != nil {
+				return fmt.Errorf("failed to create export: %w", err)
+			}
+			defer func() {
+				_ = f.Close()
+			}()
+			w = f
+		}
+		return ui.Export(ctx, w, format, e.Config(), e.GitHub(), e.AI(), e.Tracker(), e.Username())
+	})
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/history"
+	"github.com/urfave/cli/v3"
+)
+
+// historyCommand returns the `speedrun history` command
+func historyCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "history",
+		Usage:  "Query recorded review activity for on-call handoff and retros",
+		Action: showHistory,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "repo",
+				Usage: "only show activity for this repository (owner/repo)",
+			},
+			&cli.StringFlag{
+				Name:  "outcome",
+				Usage: "only show this outcome (approved, auto_merge_enabled, merged, dismissed, ai_recommendation, session)",
+			},
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "start of range: a date (2006-01-02) or a duration ago (e.g., 72h)",
+			},
+			&cli.StringFlag{
+				Name:  "until",
+				Usage: "end of range: a date (2006-01-02) or a duration ago (e.g., 24h)",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "maximum number of events to show (0 for all)",
+				Value: 50,
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "print events as JSON",
+			},
+		},
+	}
+}
+
+func showHistory(ctx context.Context, cmd *cli.Command) error {
+	since, err := parseHistoryTime(cmd.String("since"))
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	until, err := parseHistoryTime(cmd.String("until"))
+	if err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+
+	store, err := history.Open(cmd.String("history-path"))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	events, err := store.Query(ctx, history.Query{
+		Repo:    cmd.String("repo"),
+		Outcome: history.Outcome(cmd.String("outcome")),
+		Since:   since,
+		Until:   until,
+		Limit:   cmd.Int("limit"),
+	})
+	if err != nil {
+		return err
+	}
+
+	if cmd.Bool("json") {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(events)
+	}
+
+	if len(events) == 0 {
+		fmt.Println("No matching history.")
+		return nil
+	}
+
+	counts := make(map[history.Outcome]int)
+	var reviewing, sessions time.Duration
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TIME\tOUTCOME\tPR\tSPENT\tDETAIL\tTITLE")
+	for _, e := range events {
+		counts[e.Outcome]++
+		pr, spent := fmt.Sprintf("%s#%d", e.Repo, e.Number), "-"
+		if e.Outcome == history.Session {
+			pr = "-"
+			sessions += e.Duration
+		} else {
+			reviewing += e.Duration
+		}
+		if e.Duration > 0 {
+			spent = e.Duration.Round(time.Second).String()
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.Timestamp.Format("2006-01-02 15:04"), e.Outcome, pr, spent, e.Detail, e.Title)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d events:", len(events))
+	for _, outcome := range []history.Outcome{history.Approved, history.AutoMergeEnabled, history.Merged, history.Dismissed, history.AIRecommendation, history.Session} {
+		if counts[outcome] > 0 {
+			fmt.Printf(" %d %s", counts[outcome], outcome)
+		}
+	}
+	fmt.Println()
+	if reviewing > 0 || sessions > 0 {
+		fmt.Printf("Time: %s on PRs acted on, %s in sessions\n", reviewing.Round(time.Second), sessions.Round(time.Second))
+	}
+
+	return nil
+}
+
+// parseHistoryTime accepts either a calendar date or a duration ago
+func parseHistoryTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither a date (2006-01-02) nor a duration", value)
+	}
+	return time.Now().Add(-d), nil
+}
@@ -6,17 +6,25 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/kennyp/speedrun/internal/ui"
-	"github.com/kennyp/speedrun/pkg/agent"
-	"github.com/kennyp/speedrun/pkg/cache"
+	"github.com/kennyp/speedrun/pkg/classify"
 	"github.com/kennyp/speedrun/pkg/config"
-	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/demo"
+	"github.com/kennyp/speedrun/pkg/fixture"
+	"github.com/kennyp/speedrun/pkg/history"
+	"github.com/kennyp/speedrun/pkg/logbuffer"
+	"github.com/kennyp/speedrun/pkg/metrics"
+	"github.com/kennyp/speedrun/pkg/policy"
+	"github.com/kennyp/speedrun/pkg/speedrun"
+	"github.com/kennyp/speedrun/pkg/tracing"
 	"github.com/kennyp/speedrun/pkg/version"
 	gap "github.com/muesli/go-app-paths"
 	"github.com/urfave/cli-altsrc/v3"
@@ -26,6 +34,9 @@ import (
 //go:embed example-config.toml
 var defaultConfigTemplate string
 
+// logPaneLines is how many recent log lines the in-app log pane keeps
+const logPaneLines = 1000
+
 func main() {
 	ctx := context.Background()
 
@@ -42,6 +53,21 @@ func main() {
 		log.Fatalf("cannot get cache path: %v", err)
 	}
 
+	historyPath, err := scope.DataPath("history.db")
+	if err != nil {
+		log.Fatalf("cannot get history path: %v", err)
+	}
+
+	remindPath, err := scope.DataPath("reminders")
+	if err != nil {
+		log.Fatalf("cannot get reminders path: %v", err)
+	}
+
+	uiStatePath, err := scope.DataPath("ui.json")
+	if err != nil {
+		log.Fatalf("cannot get UI state path: %v", err)
+	}
+
 	logPath, err := scope.LogPath("speedrun.log")
 	if err != nil {
 		log.Fatalf("cannot get log path: %v", err)
@@ -54,6 +80,12 @@ func main() {
 		Description: "All string configuration values support 1Password references (op://vault/item/field).\n\n1Password settings are controlled via environment variables:\n  SPEEDRUN_OP_DISABLE - disable 1Password integration (any truthy value)\n  SPEEDRUN_OP_ACCOUNT or OP_ACCOUNT - specify 1Password account",
 		Version:     version.Get(),
 		Authors:     []any{"Kenny Parnell <k.parnell@gmail.com>"},
+		// speedrun completion bash|zsh|fish prints a completion script for
+		// every subcommand and flag
+		EnableShellCompletion: true,
+		ConfigureShellCompletionCommand: func(cmd *cli.Command) {
+			cmd.Hidden = false
+		},
 		Flags: []cli.Flag{
 			// Configuration
 			&cli.StringFlag{
@@ -64,6 +96,34 @@ func main() {
 					cli.EnvVar("SPEEDRUN_CONFIG"),
 				),
 			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "log approvals, merges and comments as \"would do\" instead of sending them to GitHub",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_DRY_RUN"),
+				),
+			},
+			&cli.BoolFlag{
+				Name:  "demo",
+				Usage: "try speedrun on built-in demo PRs, with no token or AI key needed",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_DEMO"),
+				),
+			},
+			&cli.StringFlag{
+				Name:  "record",
+				Usage: "save every GitHub and AI response as a fixture in this directory",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_RECORD"),
+				),
+			},
+			&cli.StringFlag{
+				Name:  "replay",
+				Usage: "serve responses from fixtures saved with --record instead of the network",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_REPLAY"),
+				),
+			},
 
 			// GitHub settings
 			&cli.StringFlag{
@@ -85,6 +145,89 @@ func main() {
 					config.OpTOMLValueSource("github.search_query", configFile),
 				),
 			},
+			&cli.StringFlag{
+				Name:     "github-search-api",
+				Usage:    "API PR searches use: auto (REST, switching to GraphQL while the REST search rate limit is hit), rest or graphql",
+				Category: "GitHub",
+				Value:    "auto",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_GITHUB_SEARCH_API"),
+					config.OpTOMLValueSource("github.search_api", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "github-queues",
+				Usage:    "Extra queues shown as tabs after the search query, as name=query (e.g., \"Mine=is:open is:pr author:@me\")",
+				Category: "GitHub",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_GITHUB_QUEUES"),
+					config.OpTOMLValueSource("github.queues", configFile),
+				),
+			},
+			&cli.BoolFlag{
+				Name:     "github-my-prs",
+				Usage:    "Add a Mine queue of the PRs you opened, with reviewer status, re-request (e) and nudge (n)",
+				Category: "GitHub",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_GITHUB_MY_PRS"),
+					config.OpTOMLValueSource("github.my_prs", configFile),
+				),
+			},
+			&cli.DurationFlag{
+				Name:     "github-auto-refresh",
+				Usage:    "Refresh PRs on this interval while idle (0 disables)",
+				Category: "GitHub",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_GITHUB_AUTO_REFRESH"),
+					config.OpTOMLValueSource("github.auto_refresh", configFile),
+				),
+			},
+			&cli.DurationFlag{
+				Name:     "review-sla",
+				Usage:    "Highlight PRs waiting for review longer than this (0 disables)",
+				Category: "GitHub",
+				Value:    24 * time.Hour,
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_REVIEW_SLA"),
+					config.OpTOMLValueSource("github.review_sla", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "require-signed-repos",
+				Usage:    "Repos (owner/repo or owner/*) where unsigned commits always need review",
+				Category: "GitHub",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_REQUIRE_SIGNED_REPOS"),
+					config.OpTOMLValueSource("github.require_signed", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "github-teams",
+				Usage:    "Teams (org/team) you review for, matched against CODEOWNERS",
+				Category: "GitHub",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_GITHUB_TEAMS"),
+					config.OpTOMLValueSource("github.teams", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "github-allowed-repos",
+				Usage:    "Only approve, merge or comment in these repos (org, owner/repo or owner/*); empty allows all",
+				Category: "GitHub",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_GITHUB_ALLOWED_REPOS"),
+					config.OpTOMLValueSource("github.allowed_repos", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "github-denied-repos",
+				Usage:    "Never approve, merge or comment in these repos (org, owner/repo or owner/*)",
+				Category: "GitHub",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_GITHUB_DENIED_REPOS"),
+					config.OpTOMLValueSource("github.denied_repos", configFile),
+				),
+			},
 
 			// AI settings
 			&cli.BoolWithInverseFlag{
@@ -96,18 +239,47 @@ func main() {
 					config.OpTOMLValueSource("ai.enabled", configFile),
 				),
 			},
+			&cli.StringFlag{
+				Name:     "ai-provider",
+				Usage:    "AI provider (openai for OpenAI and compatible gateways, azure for Azure OpenAI, gemini for Google Gemini)",
+				Category: "AI",
+				Value:    "openai",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_AI_PROVIDER"),
+					config.OpTOMLValueSource("ai.provider", configFile),
+				),
+			},
 			&cli.StringFlag{
 				Name:     "ai-base-url",
-				Usage:    "AI API base URL (e.g., LLM gateway)",
+				Usage:    "AI API base URL (e.g., LLM gateway, or the Azure OpenAI resource endpoint)",
 				Category: "AI",
 				Sources: cli.NewValueSourceChain(
 					cli.EnvVar("SPEEDRUN_AI_BASE_URL"),
 					config.OpTOMLValueSource("ai.base_url", configFile),
 				),
 			},
+			&cli.StringFlag{
+				Name:     "ai-deployment",
+				Usage:    "Azure OpenAI model deployment name",
+				Category: "AI",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_AI_DEPLOYMENT"),
+					config.OpTOMLValueSource("ai.deployment", configFile),
+				),
+			},
+			&cli.StringFlag{
+				Name:     "ai-api-version",
+				Usage:    "Azure OpenAI API version",
+				Category: "AI",
+				Value:    "2024-10-21",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_AI_API_VERSION"),
+					config.OpTOMLValueSource("ai.api_version", configFile),
+				),
+			},
 			&cli.StringFlag{
 				Name:     "ai-api-key",
-				Usage:    "AI API key",
+				Usage:    "AI API key (for gemini, leave empty to use Google application default credentials)",
 				Category: "AI",
 				Sources: cli.NewValueSourceChain(
 					cli.EnvVar("SPEEDRUN_AI_API_KEY"),
@@ -124,6 +296,78 @@ func main() {
 					config.OpTOMLValueSource("ai.model", configFile),
 				),
 			},
+			&cli.StringFlag{
+				Name:     "ai-reasoning-effort",
+				Usage:    "how hard reasoning models (o-series, Gemini 2.5) think: low, medium or high (default: the model's own)",
+				Category: "AI",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_AI_REASONING_EFFORT"),
+					config.OpTOMLValueSource("ai.reasoning_effort", configFile),
+				),
+			},
+			&cli.Float64Flag{
+				Name:     "ai-temperature",
+				Usage:    "sampling temperature, 0-2 (default: the model's own)",
+				Category: "AI",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_AI_TEMPERATURE"),
+					config.OpTOMLValueSource("ai.temperature", configFile),
+				),
+			},
+			&cli.Float64Flag{
+				Name:     "ai-top-p",
+				Usage:    "nucleus sampling probability mass, 0-1 (default: the model's own)",
+				Category: "AI",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_AI_TOP_P"),
+					config.OpTOMLValueSource("ai.top_p", configFile),
+				),
+			},
+			&cli.IntFlag{
+				Name:     "ai-max-tokens",
+				Usage:    "most tokens a response can use, reasoning included (0 for the model's limit)",
+				Category: "AI",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_AI_MAX_TOKENS"),
+					config.OpTOMLValueSource("ai.max_tokens", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "ai-extra-params",
+				Usage:    "extra fields for the request body, as name=value; JSON values like 42 or true are sent as such (e.g., \"route=review\")",
+				Category: "AI",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_AI_EXTRA_PARAMS"),
+					config.OpTOMLValueSource("ai.extra_params", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "ai-headers",
+				Usage:    "headers sent with every AI request, as name=value, for gateways that want a tenant ID or routing key (e.g., \"X-Tenant-ID=acme\")",
+				Category: "AI",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_AI_HEADERS"),
+					config.OpTOMLValueSource("ai.headers", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "ai-allowed-repos",
+				Usage:    "Only send PRs from these repos to the AI (org, owner/repo or owner/*); empty allows all",
+				Category: "AI",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_AI_ALLOWED_REPOS"),
+					config.OpTOMLValueSource("ai.allowed_repos", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "ai-denied-repos",
+				Usage:    "Never send PRs from these repos to the AI (org, owner/repo or owner/*)",
+				Category: "AI",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_AI_DENIED_REPOS"),
+					config.OpTOMLValueSource("ai.denied_repos", configFile),
+				),
+			},
 			&cli.DurationFlag{
 				Name:     "ai-analysis-timeout",
 				Usage:    "Timeout for entire AI analysis conversation",
@@ -144,6 +388,46 @@ func main() {
 					config.OpTOMLValueSource("ai.tool_timeout", configFile),
 				),
 			},
+			&cli.IntFlag{
+				Name:     "ai-fetch-max-chars",
+				Usage:    "Longest page text, after converting HTML, the AI's web_fetch tool returns (0 for no limit)",
+				Category: "AI",
+				Value:    10000,
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_AI_FETCH_MAX_CHARS"),
+					config.OpTOMLValueSource("ai.fetch_max_chars", configFile),
+				),
+			},
+			&cli.IntFlag{
+				Name:     "ai-circuit-threshold",
+				Usage:    "Consecutive AI failures before analysis is paused (0 disables)",
+				Category: "AI",
+				Value:    3,
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_AI_CIRCUIT_THRESHOLD"),
+					config.OpTOMLValueSource("ai.circuit_threshold", configFile),
+				),
+			},
+			&cli.DurationFlag{
+				Name:     "ai-circuit-cooldown",
+				Usage:    "How long AI analysis stays paused before a trial request",
+				Category: "AI",
+				Value:    2 * time.Minute,
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_AI_CIRCUIT_COOLDOWN"),
+					config.OpTOMLValueSource("ai.circuit_cooldown", configFile),
+				),
+			},
+			&cli.StringFlag{
+				Name:     "ai-trigger",
+				Usage:    "When PRs are analyzed: eager (as they load) or on_select (when first selected)",
+				Category: "AI",
+				Value:    config.AITriggerEager,
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_AI_TRIGGER"),
+					config.OpTOMLValueSource("ai.trigger", configFile),
+				),
+			},
 
 			// Check filtering
 			&cli.StringSliceFlag{
@@ -164,6 +448,34 @@ func main() {
 					config.OpTOMLValueSource("checks.required", configFile),
 				),
 			},
+			&cli.BoolFlag{
+				Name:     "checks-from-branch-protection",
+				Usage:    "Without checks-required, only count the checks the PR's base branch requires",
+				Category: "Checks",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_CHECKS_FROM_BRANCH_PROTECTION"),
+					config.OpTOMLValueSource("checks.from_branch_protection", configFile),
+				),
+			},
+			&cli.IntFlag{
+				Name:     "checks-flaky-reruns",
+				Usage:    "Tag a check likely flaky once it has passed on rerun this many times in 30 days (0 disables)",
+				Value:    3,
+				Category: "Checks",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_CHECKS_FLAKY_RERUNS"),
+					config.OpTOMLValueSource("checks.flaky_reruns", configFile),
+				),
+			},
+			&cli.BoolFlag{
+				Name:     "checks-exclude-flaky",
+				Usage:    "Leave failures of likely flaky checks out of a PR's overall check state",
+				Category: "Checks",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_CHECKS_EXCLUDE_FLAKY"),
+					config.OpTOMLValueSource("checks.exclude_flaky", configFile),
+				),
+			},
 
 			// Cache settings
 			&cli.BoolWithInverseFlag{
@@ -196,6 +508,567 @@ func main() {
 					config.OpTOMLValueSource("cache.max_age", configFile),
 				),
 			},
+			&cli.IntFlag{
+				Name:     "cache-memory-entries",
+				Usage:    "number of hot entries kept in memory in front of the cache database (0 disables)",
+				Category: "Cache",
+				Value:    512,
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_CACHE_MEMORY_ENTRIES"),
+					config.OpTOMLValueSource("cache.memory_entries", configFile),
+				),
+			},
+			&cli.DurationFlag{
+				Name:     "cache-content-max-age",
+				Usage:    "how long release notes and other pages of a fixed version fetched by the AI are kept (e.g., 2160h)",
+				Category: "Cache",
+				Value:    90 * 24 * time.Hour, // 90 days
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_CACHE_CONTENT_MAX_AGE"),
+					config.OpTOMLValueSource("cache.content_max_age", configFile),
+				),
+			},
+			&cli.IntFlag{
+				Name:     "cache-content-max-mb",
+				Usage:    "size limit in MB of kept release notes and other fixed-version pages (0 disables)",
+				Category: "Cache",
+				Value:    64,
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_CACHE_CONTENT_MAX_MB"),
+					config.OpTOMLValueSource("cache.content_max_mb", configFile),
+				),
+			},
+			&cli.StringFlag{
+				Name:     "cache-remote-url",
+				Usage:    "team cache service to share AI analyses through, by repo and head commit (empty disables)",
+				Category: "Cache",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_CACHE_REMOTE_URL"),
+					config.OpTOMLValueSource("cache.remote_url", configFile),
+				),
+			},
+			&cli.StringFlag{
+				Name:     "cache-remote-token",
+				Usage:    "bearer token for the team cache service (supports op:// references)",
+				Category: "Cache",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_CACHE_REMOTE_TOKEN"),
+					config.OpTOMLValueSource("cache.remote_token", configFile),
+				),
+			},
+
+			// History settings
+			&cli.BoolWithInverseFlag{
+				Name:     "history-enabled",
+				Usage:    "Record approvals, merges, dismissals and AI recommendations",
+				Category: "History",
+				Value:    true,
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_HISTORY_ENABLED"),
+					config.OpTOMLValueSource("history.enabled", configFile),
+				),
+			},
+			&cli.StringFlag{
+				Name:     "history-path",
+				Usage:    "history database file path",
+				Category: "History",
+				Value:    historyPath,
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_HISTORY_PATH"),
+					config.OpTOMLValueSource("history.path", configFile),
+				),
+			},
+
+			// Metrics settings
+			&cli.StringFlag{
+				Name:     "metrics-listen",
+				Usage:    "address to serve Prometheus metrics on (e.g., :9090); empty disables",
+				Category: "Metrics",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_METRICS_LISTEN"),
+					config.OpTOMLValueSource("metrics.listen", configFile),
+				),
+			},
+
+			// Serve settings
+			&cli.StringFlag{
+				Name:     "serve-listen",
+				Usage:    "address speedrun serve listens on for its REST API",
+				Value:    "127.0.0.1:8421",
+				Category: "Serve",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_SERVE_LISTEN"),
+					config.OpTOMLValueSource("serve.listen", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "serve-tokens",
+				Usage:    "bearer tokens REST API clients authenticate with (speedrun serve needs at least one)",
+				Category: "Serve",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_SERVE_TOKENS"),
+					config.OpTOMLValueSource("serve.tokens", configFile),
+				),
+			},
+			&cli.DurationFlag{
+				Name:     "serve-interval",
+				Usage:    "time between queue refreshes in speedrun serve",
+				Value:    5 * time.Minute,
+				Category: "Serve",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_SERVE_INTERVAL"),
+					config.OpTOMLValueSource("serve.interval", configFile),
+				),
+			},
+			&cli.StringFlag{
+				Name:     "serve-slack-signing-secret",
+				Usage:    "signing secret of the Slack app sending /speedrun slash commands; empty disables them",
+				Category: "Serve",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_SERVE_SLACK_SIGNING_SECRET"),
+					config.OpTOMLValueSource("serve.slack_signing_secret", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "serve-slack-approvers",
+				Usage:    "Slack user IDs allowed to approve with /speedrun approve; empty turns the command off",
+				Category: "Serve",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_SERVE_SLACK_APPROVERS"),
+					config.OpTOMLValueSource("serve.slack_approvers", configFile),
+				),
+			},
+
+			// Issue tracker settings
+			&cli.StringFlag{
+				Name:     "tracker-type",
+				Usage:    "Issue tracker for linked issues (jira, github); empty disables",
+				Category: "Tracker",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_TRACKER_TYPE"),
+					config.OpTOMLValueSource("tracker.type", configFile),
+				),
+			},
+			&cli.StringFlag{
+				Name:     "tracker-url",
+				Usage:    "Jira base URL (e.g., https://yourcompany.atlassian.net)",
+				Category: "Tracker",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_TRACKER_URL"),
+					config.OpTOMLValueSource("tracker.url", configFile),
+				),
+			},
+			&cli.StringFlag{
+				Name:     "tracker-user",
+				Usage:    "Jira user for basic auth; empty sends the token as a bearer token",
+				Category: "Tracker",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_TRACKER_USER"),
+					config.OpTOMLValueSource("tracker.user", configFile),
+				),
+			},
+			&cli.StringFlag{
+				Name:     "tracker-token",
+				Usage:    "Jira API token (supports op:// references)",
+				Category: "Tracker",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_TRACKER_TOKEN"),
+					config.OpTOMLValueSource("tracker.token", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "tracker-projects",
+				Usage:    "Jira project keys to recognise in PR text (empty matches any)",
+				Category: "Tracker",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_TRACKER_PROJECTS"),
+					config.OpTOMLValueSource("tracker.projects", configFile),
+				),
+			},
+
+			// Review policy settings
+			&cli.IntFlag{
+				Name:     "review-max-lines",
+				Usage:    "Tag PRs changing more lines than this (0 disables)",
+				Category: "Review",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_REVIEW_MAX_LINES"),
+					config.OpTOMLValueSource("review.max_lines", configFile),
+				),
+			},
+			&cli.IntFlag{
+				Name:     "review-max-files",
+				Usage:    "Tag PRs touching more files than this (0 disables)",
+				Category: "Review",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_REVIEW_MAX_FILES"),
+					config.OpTOMLValueSource("review.max_files", configFile),
+				),
+			},
+			&cli.BoolFlag{
+				Name:     "review-enforce-size",
+				Usage:    "Never let the AI recommend approving PRs over the size budget",
+				Category: "Review",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_REVIEW_ENFORCE_SIZE"),
+					config.OpTOMLValueSource("review.enforce_size", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "review-checklist",
+				Usage:    "Review checklist questions, optionally scoped to repos (owner/repo or owner/*): \"owner/repo: question\"",
+				Category: "Review",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_REVIEW_CHECKLIST"),
+					config.OpTOMLValueSource("review.checklist", configFile),
+				),
+			},
+			&cli.BoolFlag{
+				Name:     "review-require-checklist",
+				Usage:    "Only allow approving once the review checklist is complete",
+				Category: "Review",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_REVIEW_REQUIRE_CHECKLIST"),
+					config.OpTOMLValueSource("review.require_checklist", configFile),
+				),
+			},
+			&cli.BoolFlag{
+				Name:     "review-rereview-changed",
+				Usage:    "Treat PRs pushed to since my approval as unreviewed in the review filters",
+				Category: "Review",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_REVIEW_REREVIEW_CHANGED"),
+					config.OpTOMLValueSource("review.rereview_changed", configFile),
+				),
+			},
+			&cli.BoolFlag{
+				Name:     "review-template-check",
+				Usage:    "Flag PRs whose description leaves sections of the repo's PR template empty or its checklist unticked",
+				Value:    true,
+				Category: "Review",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_REVIEW_TEMPLATE_CHECK"),
+					config.OpTOMLValueSource("review.template_check", configFile),
+				),
+			},
+			&cli.BoolFlag{
+				Name:     "review-template-prompt",
+				Usage:    "Tell the AI which PR template sections and checklist items the description leaves out",
+				Category: "Review",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_REVIEW_TEMPLATE_PROMPT"),
+					config.OpTOMLValueSource("review.template_prompt", configFile),
+				),
+			},
+			// List order
+			&cli.StringFlag{
+				Name:     "priority-sort",
+				Usage:    "Order of the PR list: attention (highest attention score first) or search (as the search returns them)",
+				Value:    config.SortAttention,
+				Category: "Priority",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_PRIORITY_SORT"),
+					config.OpTOMLValueSource("priority.sort", configFile),
+				),
+			},
+			&cli.FloatFlag{
+				Name:     "priority-risk",
+				Usage:    "Attention score weight of the AI's risk level",
+				Value:    3,
+				Category: "Priority",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_PRIORITY_RISK"),
+					config.OpTOMLValueSource("priority.risk", configFile),
+				),
+			},
+			&cli.FloatFlag{
+				Name:     "priority-checks",
+				Usage:    "Attention score weight of failing checks",
+				Value:    2,
+				Category: "Priority",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_PRIORITY_CHECKS"),
+					config.OpTOMLValueSource("priority.checks", configFile),
+				),
+			},
+			&cli.FloatFlag{
+				Name:     "priority-staleness",
+				Usage:    "Attention score weight of time waiting for review, up to the review SLA (24h if unset)",
+				Value:    2,
+				Category: "Priority",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_PRIORITY_STALENESS"),
+					config.OpTOMLValueSource("priority.staleness", configFile),
+				),
+			},
+			&cli.FloatFlag{
+				Name:     "priority-size",
+				Usage:    "Attention score weight of PR size, up to review.max_lines (1000 lines if unset)",
+				Value:    1,
+				Category: "Priority",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_PRIORITY_SIZE"),
+					config.OpTOMLValueSource("priority.size", configFile),
+				),
+			},
+			// PR type detection
+			&cli.StringSliceFlag{
+				Name:     "classify-order",
+				Usage:    "PR type classifiers in order of precedence (ai, files, author, keywords, size); leave one out to turn it off",
+				Value:    classify.DefaultOrder,
+				Category: "Classify",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_CLASSIFY_ORDER"),
+					config.OpTOMLValueSource("classify.order", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "classify-dependency-keywords",
+				Usage:    "PR title fragments that mark dependency updates",
+				Value:    classify.DefaultDependencyKeywords,
+				Category: "Classify",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_CLASSIFY_DEPENDENCY_KEYWORDS"),
+					config.OpTOMLValueSource("classify.dependency_keywords", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "classify-docs-keywords",
+				Usage:    "PR title fragments that mark documentation changes",
+				Value:    classify.DefaultDocsKeywords,
+				Category: "Classify",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_CLASSIFY_DOCS_KEYWORDS"),
+					config.OpTOMLValueSource("classify.docs_keywords", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "classify-dependency-authors",
+				Usage:    "Logins whose PRs are dependency updates",
+				Value:    classify.DefaultDependencyAuthors,
+				Category: "Classify",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_CLASSIFY_DEPENDENCY_AUTHORS"),
+					config.OpTOMLValueSource("classify.dependency_authors", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "policy-rules",
+				Usage:    "Rules gating approve and merge, e.g. \"block merge if files ~ db/migrations/** and not business_hours\"",
+				Category: "Policy",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_POLICY_RULES"),
+					config.OpTOMLValueSource("policy.rules", configFile),
+				),
+			},
+			&cli.StringFlag{
+				Name:     "policy-business-hours",
+				Usage:    "Weekday hours (local time) matched by business_hours in policy rules",
+				Value:    policy.DefaultBusinessHours,
+				Category: "Policy",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_POLICY_BUSINESS_HOURS"),
+					config.OpTOMLValueSource("policy.business_hours", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "freeze-windows",
+				Usage:    "Deploy freezes when merging is disabled, e.g. \"Fri; No merges on Fridays\" or \"owner/*: 2025-12-20..2026-01-04\"",
+				Category: "Policy",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_FREEZE_WINDOWS"),
+					config.OpTOMLValueSource("freeze.windows", configFile),
+				),
+			},
+
+			// Digest settings
+			&cli.StringFlag{
+				Name:     "digest-smtp-addr",
+				Usage:    "SMTP server (host:port) for emailing the digest",
+				Category: "Digest",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_DIGEST_SMTP_ADDR"),
+					config.OpTOMLValueSource("digest.smtp_addr", configFile),
+				),
+			},
+			&cli.StringFlag{
+				Name:     "digest-smtp-username",
+				Usage:    "SMTP username; empty for servers without authentication",
+				Category: "Digest",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_DIGEST_SMTP_USERNAME"),
+					config.OpTOMLValueSource("digest.smtp_username", configFile),
+				),
+			},
+			&cli.StringFlag{
+				Name:     "digest-smtp-password",
+				Usage:    "SMTP password (supports op:// references)",
+				Category: "Digest",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_DIGEST_SMTP_PASSWORD"),
+					config.OpTOMLValueSource("digest.smtp_password", configFile),
+				),
+			},
+			&cli.StringFlag{
+				Name:     "digest-from",
+				Usage:    "Sender address for the digest email",
+				Category: "Digest",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_DIGEST_FROM"),
+					config.OpTOMLValueSource("digest.from", configFile),
+				),
+			},
+			&cli.StringSliceFlag{
+				Name:     "digest-to",
+				Usage:    "Recipients of the digest email",
+				Category: "Digest",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_DIGEST_TO"),
+					config.OpTOMLValueSource("digest.to", configFile),
+				),
+			},
+
+			// Reminder settings
+			&cli.StringFlag{
+				Name:     "remind-method",
+				Usage:    "How \"remind me\" creates reminders: ics (calendar file) or remind (remind(1))",
+				Category: "Reminders",
+				Value:    "ics",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_REMIND_METHOD"),
+					config.OpTOMLValueSource("remind.method", configFile),
+				),
+			},
+			&cli.StringFlag{
+				Name:     "remind-dir",
+				Usage:    "directory for .ics reminder files",
+				Category: "Reminders",
+				Value:    remindPath,
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_REMIND_DIR"),
+					config.OpTOMLValueSource("remind.dir", configFile),
+				),
+			},
+			&cli.StringFlag{
+				Name:     "remind-file",
+				Usage:    "reminders file for remind(1) (default ~/.reminders)",
+				Category: "Reminders",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_REMIND_FILE"),
+					config.OpTOMLValueSource("remind.file", configFile),
+				),
+			},
+			&cli.BoolWithInverseFlag{
+				Name:     "remind-open",
+				Usage:    "Open .ics reminders with the system calendar to import them",
+				Category: "Reminders",
+				Value:    true,
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_REMIND_OPEN"),
+					config.OpTOMLValueSource("remind.open", configFile),
+				),
+			},
+
+			// Plugin settings
+			&cli.BoolWithInverseFlag{
+				Name:     "plugins",
+				Usage:    "Offer speedrun-<name> executables on the PATH as custom actions in the actions menu",
+				Category: "Plugins",
+				Value:    true,
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_PLUGINS"),
+					config.OpTOMLValueSource("plugins.enabled", configFile),
+				),
+			},
+			&cli.DurationFlag{
+				Name:     "plugins-timeout",
+				Usage:    "how long a plugin may run before it is stopped",
+				Category: "Plugins",
+				Value:    30 * time.Second,
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_PLUGINS_TIMEOUT"),
+					config.OpTOMLValueSource("plugins.timeout", configFile),
+				),
+			},
+
+			// Hook settings
+			&cli.StringFlag{
+				Name:     "hooks-on-approve",
+				Usage:    "shell command run after approving a PR, with the PR in SPEEDRUN_* environment variables",
+				Category: "Hooks",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_HOOKS_ON_APPROVE"),
+					config.OpTOMLValueSource("hooks.on_approve", configFile),
+				),
+			},
+			&cli.StringFlag{
+				Name:     "hooks-on-merge",
+				Usage:    "shell command run after merging a PR or enabling auto-merge",
+				Category: "Hooks",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_HOOKS_ON_MERGE"),
+					config.OpTOMLValueSource("hooks.on_merge", configFile),
+				),
+			},
+			&cli.StringFlag{
+				Name:     "hooks-on-analysis-complete",
+				Usage:    "shell command run after a fresh AI analysis of a PR",
+				Category: "Hooks",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_HOOKS_ON_ANALYSIS_COMPLETE"),
+					config.OpTOMLValueSource("hooks.on_analysis_complete", configFile),
+				),
+			},
+			&cli.DurationFlag{
+				Name:     "hooks-timeout",
+				Usage:    "how long a hook may run before it is stopped",
+				Category: "Hooks",
+				Value:    30 * time.Second,
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_HOOKS_TIMEOUT"),
+					config.OpTOMLValueSource("hooks.timeout", configFile),
+				),
+			},
+
+			// Display settings
+			&cli.BoolFlag{
+				Name:     "no-emoji",
+				Aliases:  []string{"accessible"},
+				Usage:    "Screen-reader-friendly display: text labels instead of emoji and color-only cues (also enabled by NO_COLOR)",
+				Category: "Display",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_NO_EMOJI"),
+					config.OpTOMLValueSource("ui.no_emoji", configFile),
+				),
+			},
+			&cli.BoolFlag{
+				Name:     "reduced-motion",
+				Usage:    "no spinner animation, and redraw the screen at most 4 times a second (useful over slow SSH links)",
+				Category: "Display",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_REDUCED_MOTION"),
+					config.OpTOMLValueSource("ui.reduced_motion", configFile),
+				),
+			},
+			&cli.BoolFlag{
+				Name:     "ui-show-reasoning",
+				Usage:    "show reasoning models' summaries of their thinking in the detail popup (t toggles)",
+				Category: "Display",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_UI_SHOW_REASONING"),
+					config.OpTOMLValueSource("ui.show_reasoning", configFile),
+				),
+			},
+			&cli.StringFlag{
+				Name:     "ui-state-path",
+				Usage:    "file where layout preferences, like the detail pane size, are saved",
+				Value:    uiStatePath,
+				Category: "Display",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_UI_STATE_PATH"),
+					config.OpTOMLValueSource("ui.state_path", configFile),
+				),
+			},
 
 			// Logging settings
 			&cli.StringFlag{
@@ -376,6 +1249,24 @@ func main() {
 					config.OpTOMLValueSource("client.timeout", configFile),
 				),
 			},
+			&cli.StringFlag{
+				Name:     "client-ca-bundle",
+				Usage:    "PEM file of extra CA certificates to trust, e.g. a corporate proxy's",
+				Category: "Client Timeouts",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_CLIENT_CA_BUNDLE"),
+					config.OpTOMLValueSource("client.ca_bundle", configFile),
+				),
+			},
+			&cli.BoolFlag{
+				Name:     "client-insecure-skip-verify",
+				Usage:    "Skip TLS certificate verification (insecure; prefer client-ca-bundle)",
+				Category: "Client Timeouts",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("SPEEDRUN_CLIENT_INSECURE_SKIP_VERIFY"),
+					config.OpTOMLValueSource("client.insecure_skip_verify", configFile),
+				),
+			},
 			&cli.DurationFlag{
 				Name:     "github-client-timeout",
 				Usage:    "GitHub-specific client timeout (overrides global)",
@@ -421,14 +1312,68 @@ func main() {
 					},
 				},
 			},
+			analyzeCommand(),
+			approveCommand(),
+			watchCommand(),
+			serveCommand(),
+			actionsCommand(),
+			cacheCommand(),
+			historyCommand(),
+			digestCommand(),
+			exportCommand(),
+			profileCommand(),
+			manCommand(),
 		},
 	}
+	app.Flags = append(app.Flags, aiToolFlags(configFile)...)
+	// The reference is built from the flags above, so it goes in last
+	app.Commands = append(app.Commands, configCommand(app.Flags, configPath))
 
 	if err := app.Run(ctx, os.Args); err != nil {
 		log.Fatal(err)
 	}
 }
 
+// aiToolFlags returns the flags for each AI tool's [ai.tools.<name>] table
+func aiToolFlags(configFile altsrc.Sourcer) []cli.Flag {
+	var flags []cli.Flag
+	for _, name := range config.AITools {
+		key := "ai.tools." + name
+		env := "SPEEDRUN_AI_TOOLS_" + strings.ToUpper(name)
+		flags = append(flags,
+			&cli.BoolFlag{
+				Name:     config.AIToolFlag(name, "enabled"),
+				Usage:    "Offer the AI the " + name + " tool",
+				Category: "AI Tools",
+				Value:    true,
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar(env+"_ENABLED"),
+					config.OpTOMLValueSource(key+".enabled", configFile),
+				),
+			},
+			&cli.DurationFlag{
+				Name:     config.AIToolFlag(name, "timeout"),
+				Usage:    "Timeout for " + name + " calls (0 for --ai-tool-timeout)",
+				Category: "AI Tools",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar(env+"_TIMEOUT"),
+					config.OpTOMLValueSource(key+".timeout", configFile),
+				),
+			},
+			&cli.IntFlag{
+				Name:     config.AIToolFlag(name, "max-bytes"),
+				Usage:    "Longest " + name + " result returned to the AI, in bytes (0 for no limit)",
+				Category: "AI Tools",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar(env+"_MAX_BYTES"),
+					config.OpTOMLValueSource(key+".max_bytes", configFile),
+				),
+			},
+		)
+	}
+	return flags
+}
+
 // maskToken masks sensitive tokens for logging, showing only first 8 and last 4 characters
 func maskToken(token string) string {
 	if token == "" {
@@ -440,7 +1385,19 @@ func maskToken(token string) string {
 	return token[:8] + "..." + token[len(token)-4:]
 }
 
+// isTerminal reports whether f is attached to a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
 func runSpeedrun(ctx context.Context, cmd *cli.Command) error {
+	return run(ctx, cmd, nil)
+}
+
+// run sets up clients and starts the TUI, or prints plain text when stdout
+// isn't a terminal. Subcommands pass an action to run instead.
+func run(ctx context.Context, cmd *cli.Command, action sessionAction) error {
 	// Load configuration from CLI first to get cache path for default log path
 	cfg := config.LoadFromCLI(cmd)
 
@@ -490,7 +1447,9 @@ func runSpeedrun(ctx context.Context, cmd *cli.Command) error {
 		}
 	}
 
-	handler := slog.NewTextHandler(logWriter, &slog.HandlerOptions{Level: level})
+	// Also keep recent lines in memory for the in-app log pane
+	logs := logbuffer.New(logPaneLines)
+	handler := logs.Wrap(slog.NewTextHandler(logWriter, &slog.HandlerOptions{Level: level}))
 	logger := slog.New(handler)
 	slog.SetDefault(logger)
 
@@ -505,15 +1464,21 @@ func runSpeedrun(ctx context.Context, cmd *cli.Command) error {
 			"github.token", maskToken(cfg.GitHub.Token),
 			"github.search_query", cfg.GitHub.SearchQuery,
 			"ai.enabled", cfg.AI.Enabled,
+			"ai.provider", cfg.AI.Provider,
 			"ai.base_url", cfg.AI.BaseURL,
 			"ai.api_key", maskToken(cfg.AI.APIKey),
 			"ai.model", cfg.AI.Model,
+			"ai.reasoning_effort", cfg.AI.ReasoningEffort,
 			"cache.path", cfg.Cache.Path,
 			"log.level", cfg.Log.Level,
 			"log.path", cfg.Log.Path,
 		)
 	}
 
+	if cfg.Client.Demo {
+		cfg.UseDemo()
+	}
+
 	// Validate configuration
 	slog.Debug("Validating configuration...")
 	if err := cfg.Validate(); err != nil {
@@ -521,88 +1486,96 @@ func runSpeedrun(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	// Initialize cache
-	var cacheInstance cache.Cache
-	if cfg.Cache.Enabled {
-		slog.Debug("Initializing cache", "path", cfg.Cache.Path, "max_age", cfg.Cache.MaxAge)
-		c, err := cache.New(cfg.Cache.Path, cfg.Cache.MaxAge)
-		if err != nil {
-			slog.Error("Failed to initialize cache", "error", err)
-			return fmt.Errorf("failed to initialize cache: %w", err)
-		}
-		cacheInstance = c
-		defer func() {
-			if err := cacheInstance.Close(); err != nil {
-				slog.Error("Failed to close cache", slog.Any("error", err))
-			}
-		}()
-
-		// Cleanup expired cache entries on startup
-		slog.Debug("Cleaning up expired cache entries...")
-		if err := cacheInstance.Cleanup(); err != nil {
-			slog.Warn("Failed to cleanup cache", "error", err)
-			fmt.Printf("Warning: failed to cleanup cache: %v\n", err)
-		}
-		fmt.Printf("💾 Cache enabled at %s\n", cfg.Cache.Path)
-	} else {
-		slog.Debug("Cache disabled")
-		fmt.Printf("💾 Cache disabled\n")
-		cacheInstance = cache.NewNoOpCache()
+	// Every HTTP client speedrun makes (GitHub, GraphQL, AI, web_fetch, the
+	// remote cache and the trace exporter) is handed this transport rather
+	// than the process-wide default, so they share its connection pool and
+	// its proxy and TLS settings
+	base, err := cfg.Client.Transport()
+	if err != nil {
+		return fmt.Errorf("invalid client configuration: %w", err)
 	}
-
-	// Create GitHub client
-	slog.Debug("Creating GitHub client", "search_query", cfg.GitHub.SearchQuery)
-	githubChecksConfig := github.ChecksConfig{
-		Ignored:  cfg.Checks.Ignored,
-		Required: cfg.Checks.Required,
+	if cfg.Client.InsecureSkipVerify {
+		slog.Warn("TLS certificate verification is disabled")
 	}
-	slog.Debug("GitHub checks configuration",
-		slog.Any("ignored", githubChecksConfig.Ignored),
-		slog.Any("required", githubChecksConfig.Required),
-		slog.Int("ignored_len", len(githubChecksConfig.Ignored)),
-	)
-	githubClient, err := github.NewClient(ctx, cfg.GitHub.Token, cfg.GitHub.SearchQuery, cacheInstance, cfg.GitHub.Backoff, githubChecksConfig)
-	if err != nil {
-		slog.Error("Failed to create GitHub client", "error", err)
-		return fmt.Errorf("failed to create GitHub client: %w", err)
+	var transport http.RoundTripper = base
+	switch {
+	case cfg.Client.Record != "":
+		slog.Info("Recording fixtures", slog.String("dir", cfg.Client.Record))
+		transport = fixture.NewRecorder(base, cfg.Client.Record)
+	case cfg.Client.Replay != "":
+		slog.Info("Replaying fixtures", slog.String("dir", cfg.Client.Replay))
+		transport = fixture.NewReplayer(cfg.Client.Replay)
+	case cfg.Client.Demo:
+		slog.Info("Serving demo PRs")
+		transport = demo.NewTransport()
 	}
 
-	// Get authenticated user
-	slog.Debug("Getting authenticated user...")
-	username, err := githubClient.AuthenticatedUser(ctx)
-	if err != nil {
-		slog.Error("Failed to get authenticated user", "error", err)
-		return fmt.Errorf("failed to get authenticated user: %w", err)
+	// Piped output gets a plain text listing instead of the TUI, so progress
+	// messages go to stderr to keep stdout clean
+	interactive := action == nil && isTerminal(os.Stdout)
+	progress := os.Stdout
+	if !interactive {
+		progress = os.Stderr
 	}
-	slog.Info("Successfully authenticated with GitHub", "username", username)
 
-	fmt.Printf("🚀 Starting speedrun for %s...\n", username)
-	fmt.Printf("📍 Search query: %s\n", cfg.GitHub.SearchQuery)
+	// Export trace spans when an OTLP endpoint is configured. Spans go to the
+	// real collector even when the demo or fixtures stand in for the APIs.
+	shutdownTracing := tracing.Init(base)
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			slog.Warn("Failed to flush trace spans", slog.Any("error", err))
+		}
+	}()
 
-	// Create AI agent if configured
-	var aiAgent *agent.Agent
-	if cfg.AI.Enabled {
-		slog.Debug("Creating AI agent", "model", cfg.AI.Model, "base_url", cfg.AI.BaseURL)
+	engine, err := speedrun.New(ctx, cfg, transport, progress)
+	if err != nil {
+		return err
+	}
+	defer engine.Close()
 
-		// Create tool registry for agent
-		toolRegistry := agent.NewToolRegistry(githubClient, cacheInstance)
+	// Serve metrics for scraping
+	if cfg.Metrics.Listen != "" {
+		go func() {
+			if err := metrics.Serve(cfg.Metrics.Listen); err != nil {
+				slog.Error("Metrics server stopped", "addr", cfg.Metrics.Listen, "error", err)
+			}
+		}()
+	}
 
-		aiAgent = agent.NewAgent(cfg.AI.BaseURL, cfg.AI.APIKey, cfg.AI.Model, cfg.AI.Backoff, toolRegistry, cfg.AI.ToolTimeout)
-		fmt.Printf("🤖 AI analysis enabled with model: %s\n", cfg.AI.Model)
-		slog.Info("AI agent initialized", "model", cfg.AI.Model)
-	} else {
-		fmt.Printf("🤖 AI analysis disabled\n")
-		slog.Debug("AI analysis disabled")
+	if action != nil {
+		return action(ctx, engine)
+	}
+	if !interactive {
+		slog.Info("Stdout is not a terminal, writing plain text")
+		return ui.RenderPlain(ctx, os.Stdout, cfg, engine.GitHub(), engine.AI(), engine.Tracker(), engine.Username())
 	}
 
 	// Create and run the TUI
-	model := ui.NewModel(ctx, cfg, githubClient, aiAgent, username)
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	model := ui.NewModel(ctx, cfg, engine.GitHub(), engine.AI(), engine.Tracker(), engine.History(), logs, engine.Username())
+	p := tea.NewProgram(model, model.ProgramOptions()...)
 
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	if err != nil {
 		return fmt.Errorf("error running program: %w", err)
 	}
 
+	if m, ok := final.(ui.Model); ok {
+		report := m.SessionReport()
+		if err := report.Write(os.Stdout); err != nil {
+			return err
+		}
+		if err := engine.History().Record(ctx, history.Event{
+			User:     engine.Username(),
+			Outcome:  history.Session,
+			Detail:   fmt.Sprintf("%d PRs, %d acted on", len(report.PRs), report.Acted()),
+			Duration: report.Duration,
+		}); err != nil {
+			slog.Warn("Failed to record session", slog.Any("error", err))
+		}
+	}
+
 	return nil
 }
 
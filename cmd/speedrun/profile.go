@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kennyp/speedrun/internal/ui"
+	"github.com/kennyp/speedrun/pkg/config"
+	"github.com/kennyp/speedrun/pkg/demo"
+	"github.com/kennyp/speedrun/pkg/speedrun"
+	"github.com/urfave/cli/v3"
+)
+
+// profileCommand returns the `speedrun profile` command
+func profileCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "profile",
+		Usage:  "Time loading a synthetic queue of demo PRs, stage by stage, and optionally write pprof profiles",
+		Action: profileQueue,
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "prs",
+				Usage: "number of synthetic PRs to load",
+				Value: 100,
+			},
+			&cli.StringFlag{
+				Name:  "cpu-profile",
+				Usage: "write a pprof CPU profile to this file",
+			},
+			&cli.StringFlag{
+				Name:  "mem-profile",
+				Usage: "write a pprof heap profile to this file",
+			},
+		},
+	}
+}
+
+func profileQueue(ctx context.Context, cmd *cli.Command) error {
+	n := cmd.Int("prs")
+	if n < 1 {
+		return fmt.Errorf("--prs must be at least 1, got %d", n)
+	}
+
+	// Logging costs as much as it would in a session, but only warnings are
+	// worth reading
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn})))
+
+	cfg := config.LoadFromCLI(cmd)
+	cfg.UseDemo()
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	engine, err := speedrun.New(ctx, cfg, demo.NewTransportFor(demo.Synthetic(n)), nil)
+	if err != nil {
+		return err
+	}
+	defer engine.Close()
+
+	if path := cmd.String("cpu-profile"); path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create CPU profile: %w", err)
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		if err := pprof.StartCPUProfile(f); err != nil {
+			return fmt.Errorf("failed to start CPU profile: %w", err)
+		}
+		defer pprof.StopCPUProfile()
+	}
+
+	stages, err := ui.Profile(ctx, cfg, engine.GitHub(), engine.AI(), engine.Username())
+	if err != nil {
+		return err
+	}
+
+	if path := cmd.String("mem-profile"); path != "" {
+		if err := writeHeapProfile(path); err != nil {
+			return err
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STAGE\tTOTAL\tCOUNT\tEACH")
+	var total time.Duration
+	for _, stage := range stages {
+		total += stage.Duration
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", stage.Name, stage.Duration.Round(time.Microsecond),
+			stage.Count, (stage.Duration / time.Duration(max(stage.Count, 1))).Round(time.Microsecond))
+	}
+	fmt.Fprintf(w, "total\t%s\t\t\n", total.Round(time.Microsecond))
+	return w.Flush()
+}
+
+// writeHeapProfile writes what's live on the heap after a garbage collection
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create heap profile: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		return fmt.Errorf("failed to write heap profile: %w", err)
+	}
+	return nil
+}
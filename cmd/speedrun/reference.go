@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/kennyp/speedrun/pkg/config"
+	"github.com/kennyp/speedrun/pkg/version"
+	"github.com/urfave/cli/v3"
+)
+
+// setting is one configurable value: its flag, environment variables and
+// config file key, all read from the flag's definition
+type setting struct {
+	Category string
+	Flag     string
+	Key      string // Empty for flags that can't be set in the config file
+	Env      []string
+	Default  string
+	Usage    string
+}
+
+// settings lists the visible flags as settings, in the order they're defined
+func settings(flags []cli.Flag) []setting {
+	var all []setting
+	for _, f := range flags {
+		if v, ok := f.(cli.VisibleFlag); ok && !v.IsVisible() {
+			continue
+		}
+		s := setting{Flag: "--" + f.Names()[0], Key: configKey(f)}
+		if c, ok := f.(cli.CategorizableFlag); ok {
+			s.Category = c.GetCategory()
+		}
+		if d, ok := f.(cli.DocGenerationFlag); ok {
+			s.Env = d.GetEnvVars()
+			s.Usage = d.GetUsage()
+			if d.TakesValue() && d.IsDefaultVisible() {
+				s.Default = d.GetDefaultText()
+			}
+		}
+		all = append(all, s)
+	}
+	return all
+}
+
+// configKey returns the config file key a flag reads, if any. Flag types are
+// generic, so their value sources are found by field name.
+func configKey(f cli.Flag) string {
+	v := reflect.ValueOf(f)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	field := v.FieldByName("Sources")
+	if !field.IsValid() || !field.CanInterface() {
+		return ""
+	}
+	chain, ok := field.Interface().(cli.ValueSourceChain)
+	if !ok {
+		return ""
+	}
+	for _, src := range chain.Chain {
+		if toml, ok := src.(*config.OpValueSource); ok {
+			return toml.Key()
+		}
+	}
+	return ""
+}
+
+// byCategory groups settings by category, keeping the categories in the
+// order they first appear. Uncategorized settings come first.
+func byCategory(all []setting) (names []string, groups map[string][]setting) {
+	groups = make(map[string][]setting)
+	for _, s := range all {
+		if _, ok := groups[s.Category]; !ok {
+			names = append(names, s.Category)
+		}
+		groups[s.Category] = append(groups[s.Category], s)
+	}
+	return names, groups
+}
+
+// writeConfigReference writes every setting with its flag, environment
+// variables, config file key and default
+func writeConfigReference(w io.Writer, flags []cli.Flag, configPath string) {
+	fmt.Fprintf(w, "Every setting can be passed as a flag or set in the environment and, where it\n")
+	fmt.Fprintf(w, "has a key, in the config file:\n\n    %s\n\n", configPath)
+	fmt.Fprintf(w, "Flags win over the environment, which wins over the file. String values may\n")
+	fmt.Fprintf(w, "be 1Password references (op://vault/item/field).\n")
+
+	names, groups := byCategory(settings(flags))
+	for _, name := range names {
+		heading := name
+		if heading == "" {
+			heading = "General"
+		}
+		fmt.Fprintf(w, "\n%s\n", strings.ToUpper(heading))
+		for _, s := range groups[name] {
+			where := []string{s.Flag}
+			for _, env := range s.Env {
+				where = append(where, "$"+env)
+			}
+			title := s.Key
+			if title == "" {
+				title = s.Flag
+				where = where[1:]
+			}
+			fmt.Fprintf(w, "  %s", title)
+			if len(where) > 0 {
+				fmt.Fprintf(w, " (%s)", strings.Join(where, ", "))
+			}
+			fmt.Fprintln(w)
+
+			usage := s.Usage
+			if s.Default != "" {
+				usage += fmt.Sprintf(" [default: %s]", s.Default)
+			}
+			fmt.Fprintf(w, "      %s\n", usage)
+		}
+	}
+}
+
+// configCommand returns `speedrun config`, which `speedrun help config` also
+// shows. The reference is built from the flags, so it can't fall behind them.
+func configCommand(flags []cli.Flag, configPath string) *cli.Command {
+	var reference strings.Builder
+	writeConfigReference(&reference, flags, configPath)
+	return &cli.Command{
+		Name:  "config",
+		Usage: "Show every setting with its flag, environment variables and config file key",
+		// Help templates read {{ }} as actions, so the reference is escaped
+		CustomHelpTemplate: strings.NewReplacer("{{", `{{"{{"}}`).Replace(reference.String()),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			_, err := io.WriteString(cmd.Root().Writer, reference.String())
+			return err
+		},
+	}
+}
+
+// manCommand returns `speedrun man`, which writes the man page. Builds
+// generate speedrun.1 with it.
+func manCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "man",
+		Usage:  "Write the speedrun(1) man page",
+		Hidden: true,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "write the man page to this file (- for stdout)",
+				Value:   "-",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			var page strings.Builder
+			if err := writeManPage(&page, cmd.Root()); err != nil {
+				return err
+			}
+			if path := cmd.String("output"); path != "-" {
+				if err := os.WriteFile(path, []byte(page.String()), 0644); err != nil {
+					return fmt.Errorf("failed to write man page: %w", err)
+				}
+				return nil
+			}
+			_, err := io.WriteString(cmd.Root().Writer, page.String())
+			return err
+		},
+	}
+}
+
+// roffEscaper escapes text for roff: backslashes and hyphens, which would
+// otherwise become escapes and typographic dashes
+var roffEscaper = strings.NewReplacer(`\`, `\e`, "-", `\-`)
+
+// roff escapes text, including lines that would otherwise start a request
+func roff(text string) string {
+	lines := strings.Split(roffEscaper.Replace(text), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// writeManPage writes the root command's man page: its commands, then every
+// setting by category
+func writeManPage(w io.Writer, root *cli.Command) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, ".TH SPEEDRUN 1 \"\" %q \"User Commands\"\n", "speedrun "+version.Get())
+	fmt.Fprintf(&b, ".SH NAME\nspeedrun \\- %s\n", roff(root.Usage))
+	fmt.Fprintf(&b, ".SH SYNOPSIS\n.B speedrun\n[\\fIoptions\\fR] [\\fIcommand\\fR] [\\fIarguments\\fR]\n")
+	fmt.Fprintf(&b, ".SH DESCRIPTION\n%s\n", roff(root.Description))
+
+	b.WriteString(".SH COMMANDS\n")
+	var commands func(prefix string, cmds []*cli.Command)
+	commands = func(prefix string, cmds []*cli.Command) {
+		for _, c := range cmds {
+			if c.Hidden || c.Name == "help" {
+				continue
+			}
+			fmt.Fprintf(&b, ".TP\n.B %s\n%s\n", roff(strings.TrimSpace(prefix+c.Name+" "+c.ArgsUsage)), roff(c.Usage))
+			commands(prefix+c.Name+" ", c.Commands)
+		}
+	}
+	commands("", root.Commands)
+
+	b.WriteString(".SH OPTIONS\n")
+	b.WriteString("Each option can also be set in the environment and, where a key is given, in the config file.\n")
+	names, groups := byCategory(settings(root.Flags))
+	for _, name := range names {
+		if name != "" {
+			fmt.Fprintf(&b, ".SS %s\n", roff(name))
+		}
+		for _, s := range groups[name] {
+			fmt.Fprintf(&b, ".TP\n.B %s\n%s\n", roff(s.Flag), roff(s.Usage))
+			if s.Default != "" {
+				fmt.Fprintf(&b, "Default: %s.\n", roff(s.Default))
+			}
+			if len(s.Env) > 0 {
+				fmt.Fprintf(&b, ".br\nEnvironment: %s\n", roff(strings.Join(s.Env, ", ")))
+			}
+			if s.Key != "" {
+				fmt.Fprintf(&b, ".br\nConfig file: %s\n", roff(s.Key))
+			}
+		}
+	}
+
+	b.WriteString(".SH SEE ALSO\nRun \\fBspeedrun help config\\fR for the same settings as a reference, and \\fBspeedrun init\\fR to create a config file.\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
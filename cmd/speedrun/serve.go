@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/kennyp/speedrun/pkg/speedrun"
+	"github.com/urfave/cli/v3"
+)
+
+// serveCommand returns the `speedrun serve` command
+func serveCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "serve",
+		Usage:  "Keep the PR queue analyzed and serve it, and approvals, over a REST API",
+		Action: serveQueue,
+	}
+}
+
+func serveQueue(ctx context.Context, cmd *cli.Command) error {
+	// Stop cleanly on Ctrl-C or when the service manager stops us
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return run(ctx, cmd, func(ctx context.Context, e *speedrun.Engine) error {
+		server, err := speedrun.NewServer(e)
+		if err != nil {
+			return err
+		}
+		addr := e.Config().Serve.Listen
+		fmt.Fprintf(os.Stderr, "🛰️ Serving the review queue API on http://%s\n", addr)
+		return server.ListenAndServe(ctx, addr)
+	})
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/speedrun"
+)
+
+// sessionAction is a subcommand's work, run in place of the TUI with the
+// engine run sets up
+type sessionAction func(ctx context.Context, e *speedrun.Engine) error
+
+// forPR parses a PR URL or owner/repo#number and returns an action that
+// fetches that PR and hands it to action
+func forPR(ref, usage string, action func(ctx context.Context, e *speedrun.Engine, pr *github.PullRequest) error) (sessionAction, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("usage: %s", usage)
+	}
+
+	owner, repo, number, err := github.ParsePRRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, e *speedrun.Engine) error {
+		pr, err := e.GitHub().GetPullRequest(ctx, owner, repo, number)
+		if err != nil {
+			return err
+		}
+		return action(ctx, e, pr)
+	}, nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/kennyp/speedrun/internal/ui"
+	"github.com/kennyp/speedrun/pkg/speedrun"
+	"github.com/urfave/cli/v3"
+)
+
+// watchCommand returns the `speedrun watch` command
+func watchCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "watch",
+		Usage:  "Print a plain-text table of the PR queue, refreshed on an interval",
+		Action: watchQueue,
+		Flags: []cli.Flag{
+			&cli.DurationFlag{
+				Name:    "interval",
+				Aliases: []string{"n"},
+				Usage:   "time between refreshes",
+				Value:   time.Minute,
+			},
+		},
+	}
+}
+
+func watchQueue(ctx context.Context, cmd *cli.Command) error {
+	interval := cmd.Duration("interval")
+	if interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	// Stop cleanly on Ctrl-C
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	return run(ctx, cmd, func(ctx context.Context, e *speedrun.Engine) error {
+		return ui.Watch(ctx, os.Stdout, e.Config(), e.GitHub(), e.AI(), e.Tracker(), e.Username(), interval, isTerminal(os.Stdout))
+	})
+}
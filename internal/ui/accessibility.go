@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kennyp/speedrun/pkg/github"
+)
+
+// textLabels swaps emoji for words in accessible mode. Longer keys come first
+// so an emoji is labelled by what it sits next to, e.g. a ✅ after 🔧 is a
+// passing check, while decorative emoji before text are dropped.
+var textLabels = strings.NewReplacer(
+	// Popup headings
+	"## ✅ ", "## ",
+	"## 🔥 ", "## ",
+
+	// List descriptions
+	"🔧 ✅ ", "checks: [passed] ",
+	"🔧 ❌ ", "checks: [failed] ",
+	"🔧 🟡 ", "checks: [pending] ",
+	"🔧 ❓ ", "checks: [unknown] ",
+	"🔧 ⚠️ ", "checks: [error] ",
+	"🔧 ", "checks: ",
+	"🤖 ✅ ", "AI: ",
+	"🤖 👀 ", "AI: ",
+	"🤖 🔍 ", "AI: ",
+	"🤖 ❓ ", "AI: ",
+	"(🟢 ", "(",
+	"(🟡 ", "(",
+	"(🔴 ", "(",
+	"(⚪ ", "(",
+	"| 📦 ×", "| group of ",
+	"| 📝 ", "| ",
+	"| 💻 ", "| ",
+	"| 📦 ", "| ",
+	"| 🔀 ", "| ",
+	"| ❓ ", "| ",
+	"📦 ×", "group of ",
+	" 📏 over budget", " [over budget]",
+	"⏱ ", "waiting ",
+
+	// Indicators that carry meaning
+	"✅", "[ok]",
+	"❌", "[failed]",
+	"⏳", "[pending]",
+	"🟡 ", "[pending] ",
+	"⚠️", "[warning]",
+	"⚠", "[warning]",
+	"❓", "[unknown]",
+	"🛑", "[changes requested]",
+	"🗨", "[commented]",
+	"🔥 ", "[security] ",
+	"☑ ", "[x] ",
+	"☐ ", "[ ] ",
+	"● ", "(*) ",
+	"○ ", "( ) ",
+	"█", "#",
+
+	// Decoration in front of text
+	"🤖 ", "",
+	"📊 ", "",
+	"📋 ", "",
+	"👥 ", "",
+	"🔏 ", "",
+	"🎫 ", "",
+	"📦 ", "",
+	"📏 ", "",
+	"🎯 ", "",
+	"🔓 ", "",
+	"✍️ ", "",
+	"🚧 ", "",
+	"💬 ", "",
+	"👀 ", "",
+	"⏸️ ", "",
+	"⏸ ", "",
+	"🔄 ", "",
+	"📍 ", "",
+	"💭 ", "",
+	"👋 ", "",
+	"🔍 ", "",
+	"🧪 ", "",
+	"🔁 ", "",
+	"🕒 ", "",
+	"📄 ", "",
+	"⏰ ", "",
+	"🥞 ", "",
+	"🧾 ", "",
+	"🎲 ", "",
+	"🧱 ", "",
+	"🔤 ", "",
+	"🔌 ", "",
+	"⟳ ", "",
+)
+
+// label returns s with emoji replaced by text when accessible mode is on
+func (m Model) label(s string) string {
+	if !m.config.UI.Accessible {
+		return s
+	}
+	return textLabels.Replace(s)
+}
+
+// textItem renders a PR for accessible mode, stating in words what the
+// emoji and colors of the regular list convey
+type textItem struct {
+	PRItem
+	overdue bool
+}
+
+// Title implements list.DefaultItem
+func (i textItem) Title() string {
+	title := fmt.Sprintf("PR #%d: %s", i.PR.Number, i.PR.Title)
+	if labels := i.labels(); len(labels) > 0 {
+		title = "[" + strings.Join(labels, ", ") + "] " + title
+	}
+	return title
+}
+
+// labels states the PR's flags and review state in words
+func (i textItem) labels() []string {
+	var labels []string
+	if len(i.FixesAlerts) > 0 {
+		labels = append(labels, "security")
+	}
+	if i.overdue {
+		labels = append(labels, "overdue")
+	}
+	switch authored := i.authoredStatus(); {
+	case i.Authored && authored != "":
+		labels = append(labels, reviewStateLabels[authored])
+	case i.ChangedSinceApproval():
+		labels = append(labels, "changed since approval")
+	case i.Approved:
+		labels = append(labels, "approved")
+	case i.Dismissed:
+		labels = append(labels, "dismissed")
+	case i.Reviewed:
+		labels = append(labels, "reviewed")
+	case i.AIAnalysis != nil:
+		labels = append(labels, "AI: "+string(i.AIAnalysis.Recommendation))
+	}
+	if i.AIAnalysis != nil && i.AIAnalysis.PRType != "" && i.AIAnalysis.PRType != "CODE" {
+		labels = append(labels, strings.ToLower(i.AIAnalysis.PRType))
+	}
+	return labels
+}
+
+// reviewStateLabels words the overall review state of my own PRs
+var reviewStateLabels = map[string]string{
+	"APPROVED":           "approved",
+	"CHANGES_REQUESTED":  "changes requested",
+	github.ReviewPending: "awaiting review",
+	"COMMENTED":          "commented",
+}
+
+// Description implements list.DefaultItem
+func (i textItem) Description() string {
+	return textLabels.Replace(i.PRItem.Description())
+}
@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/github"
+)
+
+// SecurityAlertsLoadedMsg is sent when a repository's open Dependabot alerts
+// have been fetched
+type SecurityAlertsLoadedMsg struct {
+	Repo   string // owner/repo
+	Alerts []*github.SecurityAlert
+	Err    error
+}
+
+// FetchSecurityAlertsCmd fetches the open Dependabot alerts for a repository
+func FetchSecurityAlertsCmd(ctx context.Context, client *github.Client, owner, repo string) tea.Cmd {
+	return func() tea.Msg {
+		alerts, err := client.GetSecurityAlerts(ctx, owner, repo)
+		return SecurityAlertsLoadedMsg{Repo: owner + "/" + repo, Alerts: alerts, Err: err}
+	}
+}
+
+// fetchSecurityAlerts fetches alerts for every repository with a dependency
+// bump PR. Other PRs can't fix an alert, so their repos are skipped.
+func (m Model) fetchSecurityAlerts() tea.Cmd {
+	var cmds []tea.Cmd
+	seen := make(map[string]bool)
+	for _, item := range m.items {
+		repo := item.PR.Owner + "/" + item.PR.Repo
+		if _, ok := item.Bump(); !ok || seen[repo] {
+			continue
+		}
+		seen[repo] = true
+		cmds = append(cmds, FetchSecurityAlertsCmd(m.ctx, m.github, item.PR.Owner, item.PR.Repo))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m Model) handleSecurityAlertsLoaded(msg SecurityAlertsLoadedMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		// Usually alerts are disabled or the token lacks the security_events scope
+		slog.Debug("Security alerts unavailable", slog.String("repo", msg.Repo), slog.Any("error", msg.Err))
+		return m, nil
+	}
+
+	if m.securityAlerts == nil {
+		m.securityAlerts = make(map[string][]*github.SecurityAlert)
+	}
+	m.securityAlerts[msg.Repo] = msg.Alerts
+
+	m = m.updateVisibleItems()
+	return m, nil
+}
+
+// annotateAlerts records on each item the open alerts its bump fixes
+func (m Model) annotateAlerts() {
+	for i := range m.items {
+		item := &m.items[i]
+		item.FixesAlerts = nil
+		if bump, ok := item.Bump(); ok {
+			item.FixesAlerts = github.FixedAlerts(m.securityAlerts[item.PR.Owner+"/"+item.PR.Repo], bump.Package, bump.Version)
+		}
+	}
+}
+
+// prioritizeAlertFixes moves PRs that fix security alerts to the top, keeping
+// the order of everything else
+func prioritizeAlertFixes(items []list.Item) []list.Item {
+	var fixes, rest []list.Item
+	for _, listItem := range items {
+		if item, ok := listItem.(PRItem); ok && len(item.FixesAlerts) > 0 {
+			fixes = append(fixes, listItem)
+		} else {
+			rest = append(rest, listItem)
+		}
+	}
+	if len(fixes) == 0 {
+		return items
+	}
+	return append(fixes, rest...)
+}
+
+// alertDetailContent lists the alerts a PR fixes for the details popup
+func alertDetailContent(item PRItem) string {
+	if len(item.FixesAlerts) == 0 {
+		return ""
+	}
+
+	var content strings.Builder
+	content.WriteString("## 🔥 Fixes Security Alerts\n\n")
+	for _, alert := range item.FixesAlerts {
+		content.WriteString(fmt.Sprintf("- **%s** [%s] %s (fixed in %s)\n", alert.GHSAID, alert.Severity, alert.Summary, alert.PatchedVersion))
+	}
+	content.WriteString("\n")
+	return content.String()
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/kennyp/speedrun/pkg/config"
+)
+
+// Where staleness and size count fully when there's no review SLA or size
+// budget to measure them against
+const (
+	defaultStaleWindow = 24 * time.Hour
+	defaultSizeWindow  = 1000
+)
+
+// attentionParts are the parts of a PR's attention score, each from 0 to 1
+// before weighting. Parts that haven't loaded yet are 0.
+type attentionParts struct {
+	Risk, Checks, Staleness, Size float64
+}
+
+// attentionParts rates what's known about the PR
+func (i PRItem) attentionParts(sla time.Duration, maxLines int) attentionParts {
+	var parts attentionParts
+	if i.AIAnalysis != nil {
+		switch i.AIAnalysis.RiskLevel {
+		case "HIGH":
+			parts.Risk = 1
+		case "MEDIUM":
+			parts.Risk = 0.5
+		}
+	}
+	if i.CheckStatus != nil && (i.CheckStatus.State == "failure" || i.CheckStatus.State == "error") {
+		parts.Checks = 1
+	}
+	if sla <= 0 {
+		sla = defaultStaleWindow
+	}
+	parts.Staleness = math.Min(1, float64(i.Age())/float64(sla))
+	if i.DiffStats != nil {
+		if maxLines <= 0 {
+			maxLines = defaultSizeWindow
+		}
+		lines, _ := i.DiffStats.Reviewable()
+		parts.Size = math.Min(1, float64(lines)/float64(maxLines))
+	}
+	return parts
+}
+
+// score weighs the parts
+func (p attentionParts) score(w config.PriorityConfig) float64 {
+	return p.Risk*w.Risk + p.Checks*w.Checks + p.Staleness*w.Staleness + p.Size*w.Size
+}
+
+// attention returns the PR's attention score, and its parts, under the
+// configured weights
+func (m Model) attention(item PRItem) (float64, attentionParts) {
+	parts := item.attentionParts(m.config.GitHub.ReviewSLA, m.config.Review.MaxLines)
+	return parts.score(m.config.Priority), parts
+}
+
+// sortByAttention orders PRs by attention score, highest first, keeping the
+// search order between equal scores. With the search order configured, the
+// items are left as they are.
+func (m Model) sortByAttention(items []list.Item) []list.Item {
+	if m.config.Priority.Sort == config.SortSearch {
+		return items
+	}
+
+	scores := make(map[int64]float64, len(items))
+	for _, listItem := range items {
+		if item, ok := listItem.(PRItem); ok {
+			scores[item.ID], _ = m.attention(item)
+		}
+	}
+	slices.SortStableFunc(items, func(a, b list.Item) int {
+		return cmp.Compare(scores[itemID(b)], scores[itemID(a)])
+	})
+	return items
+}
+
+// itemID returns a list item's PR ID, or 0 for other items
+func itemID(listItem list.Item) int64 {
+	if item, ok := listItem.(PRItem); ok {
+		return item.ID
+	}
+	return 0
+}
+
+// attentionDetailContent explains the PR's attention score for the details
+// popup
+func (m Model) attentionDetailContent(item PRItem) string {
+	score, parts := m.attention(item)
+	w := m.config.Priority
+	return fmt.Sprintf("**Attention:** %.1f (risk %.1f, checks %.1f, waiting %.1f, size %.1f)\n",
+		score, parts.Risk*w.Risk, parts.Checks*w.Checks, parts.Staleness*w.Staleness, parts.Size*w.Size)
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// autoRefreshIdle is how long since the last key press before an automatic
+// refresh may run, so the list doesn't reshuffle under the user's cursor
+const autoRefreshIdle = 10 * time.Second
+
+// AutoRefreshTickMsg drives the auto-refresh countdown
+type AutoRefreshTickMsg struct{}
+
+// AutoRefreshTickCmd schedules the next countdown tick
+func AutoRefreshTickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return AutoRefreshTickMsg{}
+	})
+}
+
+// autoRefreshSuppressed reports why an automatic refresh can't run right now,
+// or "" if it can
+func (m Model) autoRefreshSuppressed() string {
+	switch {
+	case m.showPopup || m.showAdvancedFilter || m.showBotMenu || m.workload != nil:
+		return "dialog open"
+	case m.loadingPRs:
+		return "refreshing"
+	case m.stale:
+		return "GitHub unavailable"
+	case time.Since(m.lastInput) < autoRefreshIdle:
+		return "not idle"
+	}
+	return ""
+}
+
+// handleAutoRefreshTick refreshes once the interval has passed and the UI is idle
+func (m Model) handleAutoRefreshTick() (Model, tea.Cmd) {
+	if time.Now().Before(m.nextRefresh) || m.autoRefreshSuppressed() != "" {
+		return m, AutoRefreshTickCmd(m.tickInterval(time.Second))
+	}
+
+	slog.Info("Auto-refreshing PRs", slog.Duration("interval", m.config.GitHub.AutoRefresh))
+	m, cmd := m.handleRefresh()
+	return m, tea.Batch(cmd, AutoRefreshTickCmd(m.tickInterval(time.Second)))
+}
+
+// autoRefreshStatus renders the countdown shown in the status bar
+func (m Model) autoRefreshStatus() string {
+	if m.config.GitHub.AutoRefresh <= 0 || m.loadingPRs {
+		return ""
+	}
+
+	remaining := time.Until(m.nextRefresh).Round(m.tickInterval(time.Second))
+	if remaining <= 0 {
+		if reason := m.autoRefreshSuppressed(); reason != "" {
+			return "⟳ paused: " + reason
+		}
+		remaining = 0
+	}
+	return fmt.Sprintf("⟳ %d:%02d", int(remaining.Minutes()), int(remaining.Seconds())%60)
+}
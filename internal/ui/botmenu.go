@@ -0,0 +1,222 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kennyp/speedrun/pkg/deps"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/plugin"
+)
+
+// maxMenuOptions is how many options the actions menu can number
+const maxMenuOptions = 9
+
+// botMenuOption is one entry in the actions menu: a bot action or a plugin
+type botMenuOption struct {
+	label   string
+	action  github.BotAction
+	plugin  *plugin.Plugin // Set for plugin actions
+	targets []PRItem
+}
+
+// BotActionDoneMsg is sent when a bot action has been requested
+type BotActionDoneMsg struct {
+	PRID   int64
+	Action github.BotAction
+	Err    error
+}
+
+// BotActionCmd asks a PR's bot to perform an action
+func BotActionCmd(ctx context.Context, pr *github.PullRequest, action github.BotAction, prID int64) tea.Cmd {
+	return func() tea.Msg {
+		err := pr.RunBotAction(ctx, action)
+		return BotActionDoneMsg{PRID: prID, Action: action, Err: err}
+	}
+}
+
+// supersededPRs returns the other open PRs from the same bot in the same
+// repo that bump the item's dependency to an older version
+func (m Model) supersededPRs(item PRItem) []PRItem {
+	bump, ok := item.Bump()
+	if !ok {
+		return nil
+	}
+
+	var superseded []PRItem
+	for _, other := range m.items {
+		if other.ID == item.ID || other.PR.Owner != item.PR.Owner || other.PR.Repo != item.PR.Repo || other.PR.Bot() != item.PR.Bot() {
+			continue
+		}
+		otherBump, ok := other.Bump()
+		if ok && otherBump.Package == bump.Package && deps.CompareVersions(otherBump.Version, bump.Version) < 0 {
+			superseded = append(superseded, other)
+		}
+	}
+	return superseded
+}
+
+// handleBotMenu opens the actions menu for the selected PR: bot actions for
+// Dependabot and Renovate PRs, then any plugins
+func (m Model) handleBotMenu() (Model, tea.Cmd) {
+	prItem, ok := m.list.SelectedItem().(PRItem)
+	if !ok {
+		slog.Debug("Bot menu: no PR selected")
+		return m, nil
+	}
+
+	options := m.botMenuOptions(prItem)
+	for i := range m.plugins {
+		options = append(options, botMenuOption{label: "🔌 " + m.plugins[i].Name, plugin: &m.plugins[i], targets: []PRItem{prItem}})
+	}
+	if len(options) == 0 {
+		m.setStatus(statusInfo, "Bot actions are only available for Dependabot and Renovate PRs; add speedrun-<name> plugins for custom actions")
+		return m, nil
+	}
+	if len(options) > maxMenuOptions {
+		slog.Warn("Too many actions for the menu, dropping the rest", slog.Int("actions", len(options)))
+		options = options[:maxMenuOptions]
+	}
+
+	slog.Info("User opened actions menu", slog.Any("pr", prItem.PR), slog.String("bot", prItem.PR.Bot()), slog.Int("plugins", len(m.plugins)))
+	m.botMenu = options
+	m.showBotMenu = true
+	return m, nil
+}
+
+// botMenuOptions returns the bot actions for a PR, if it's from a bot and
+// GitHub is available
+func (m Model) botMenuOptions(prItem PRItem) []botMenuOption {
+	bot := prItem.PR.Bot()
+	if bot == "" {
+		return nil
+	}
+	if m.github.Health().Degraded {
+		slog.Debug("Bot actions are disabled while GitHub is unavailable")
+		return nil
+	}
+
+	var options []botMenuOption
+	actions := prItem.PR.BotActions()
+	for _, action := range actions {
+		label := "@dependabot " + string(action)
+		if bot == github.BotRenovate {
+			label = map[github.BotAction]string{
+				github.BotRebase: "Rebase (ticks Renovate's rebase checkbox)",
+				github.BotClose:  "Close (Renovate won't recreate it)",
+			}[action]
+		}
+		options = append(options, botMenuOption{label: label, action: action, targets: []PRItem{prItem}})
+	}
+
+	if superseded := m.supersededPRs(prItem); len(superseded) > 0 && slices.Contains(actions, github.BotClose) {
+		options = append(options, botMenuOption{
+			label:   fmt.Sprintf("Close %d superseded %s", len(superseded), pluralPRs(len(superseded))),
+			action:  github.BotClose,
+			targets: superseded,
+		})
+	}
+	return options
+}
+
+// handleBotMenuKey runs the chosen bot action or closes the menu
+func (m Model) handleBotMenuKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if msg.String() == "esc" || msg.String() == "B" {
+		m.showBotMenu = false
+		return m, nil
+	}
+
+	choice, err := strconv.Atoi(msg.String())
+	if err != nil || choice < 1 || choice > len(m.botMenu) {
+		return m, nil
+	}
+
+	option := m.botMenu[choice-1]
+	m.showBotMenu = false
+
+	if option.plugin != nil {
+		target := option.targets[0]
+		slog.Info("User ran plugin", slog.String("plugin", option.plugin.Name), slog.Any("pr", target.PR))
+		m.setStatus(statusInfo, fmt.Sprintf("Running %s for #%d...", option.plugin.Name, target.PR.Number))
+		return m, RunPluginCmd(m.ctx, *option.plugin, m.pluginInput(target), m.config.Plugins.Timeout, target.ID)
+	}
+
+	if option.action == github.BotMerge || option.action == github.BotSquashMerge {
+		for _, target := range option.targets {
+			if reason := m.mergeBlocked(target); reason != "" {
+				m.setStatus(statusError, reason)
+				return m, nil
+			}
+		}
+	}
+
+	var cmds []tea.Cmd
+	var numbers []string
+	for _, target := range option.targets {
+		cmds = append(cmds, BotActionCmd(m.ctx, target.PR, option.action, target.ID))
+		numbers = append(numbers, fmt.Sprintf("#%d", target.PR.Number))
+	}
+
+	slog.Info("User requested bot action", slog.String("action", string(option.action)), slog.Int("prs", len(option.targets)))
+	m.setStatus(statusInfo, fmt.Sprintf("Requesting %s for %s...", option.action, strings.Join(numbers, ", ")))
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) handleBotActionDone(msg BotActionDoneMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		slog.Error("Bot action failed in UI", slog.Int64("prID", msg.PRID), slog.String("action", string(msg.Action)), slog.Any("error", msg.Err))
+		m.setStatus(statusError, "Bot action failed: "+msg.Err.Error())
+		return m, nil
+	}
+
+	item := m.findPRByID(msg.PRID)
+	if item == nil {
+		return m, nil
+	}
+	m.setStatus(statusSuccess, m.actionStatus(fmt.Sprintf("🤖 Requested %s for PR #%d", msg.Action, item.PR.Number), fmt.Sprintf("would request %s for PR #%d", msg.Action, item.PR.Number)))
+
+	switch msg.Action {
+	case github.BotMerge, github.BotSquashMerge:
+		m = m.updatePRByID(msg.PRID, func(item *PRItem) {
+			item.Merging = true
+		})
+	case github.BotClose:
+		// Drop it now rather than reporting it as closed elsewhere on the next refresh
+		m.cancelPRWork(msg.PRID, "closed")
+		m.items = slices.DeleteFunc(m.items, func(item PRItem) bool {
+			return item.ID == msg.PRID
+		})
+		m = m.updateVisibleItems()
+	}
+
+	return m, nil
+}
+
+// renderBotMenu renders the actions menu overlay
+func (m Model) renderBotMenu() string {
+	width, height := m.width, m.height
+
+	var content strings.Builder
+	content.WriteString("Actions\n\n")
+	for i, option := range m.botMenu {
+		content.WriteString(fmt.Sprintf("  %d %s\n", i+1, m.label(option.label)))
+	}
+	content.WriteString("\nPress a number to run an action or Esc to cancel")
+
+	dialog := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("75")).
+		Background(lipgloss.Color("235")).
+		Foreground(lipgloss.Color("255")).
+		Padding(1).
+		Width(min(width*8/10, 60) - 4).
+		Render(content.String())
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, dialog)
+}
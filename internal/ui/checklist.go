@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/checklist"
+)
+
+// checklistQuestions returns the configured review questions for a PR
+func (m Model) checklistQuestions(item PRItem) []string {
+	return checklist.For(m.reviewChecklist, item.PR.Owner, item.PR.Repo)
+}
+
+// checklistComplete reports whether every review question for a PR is ticked
+func (m Model) checklistComplete(item PRItem) bool {
+	ticked := m.checklistTicks[item.ID]
+	for i := range m.checklistQuestions(item) {
+		if i >= len(ticked) || !ticked[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// approvalBlocked explains why a PR can't be approved yet, or returns ""
+func (m Model) approvalBlocked(item PRItem) string {
+	if !m.config.Review.RequireChecklist || m.checklistComplete(item) {
+		return ""
+	}
+	return fmt.Sprintf("Complete the review checklist for PR #%d (enter) before approving", item.PR.Number)
+}
+
+// handleToggleChecklist ticks or unticks question n (1-based) of the PR in
+// the details popup
+func (m Model) handleToggleChecklist(n int) (Model, tea.Cmd) {
+	prItem, ok := m.list.SelectedItem().(PRItem)
+	if !ok {
+		return m, nil
+	}
+
+	questions := m.checklistQuestions(prItem)
+	if n < 1 || n > len(questions) {
+		return m, nil
+	}
+
+	ticked := m.checklistTicks[prItem.ID]
+	if len(ticked) < len(questions) {
+		ticked = append(ticked, make([]bool, len(questions)-len(ticked))...)
+	}
+	ticked[n-1] = !ticked[n-1]
+	m.checklistTicks[prItem.ID] = ticked
+	slog.Debug("Toggled checklist item", slog.Any("pr", prItem.PR), slog.Int("item", n), slog.Bool("checked", ticked[n-1]))
+
+	m.popupContent = m.generateDetailContent(prItem)
+	return m, nil
+}
+
+// checklistDetailContent renders a PR's review checklist for the details popup
+func (m Model) checklistDetailContent(item PRItem) string {
+	questions := m.checklistQuestions(item)
+	if len(questions) == 0 {
+		return ""
+	}
+
+	ticked := m.checklistTicks[item.ID]
+	var content strings.Builder
+	content.WriteString("## 📋 Review Checklist\n\n")
+	for i, question := range questions {
+		box := "☐"
+		if i < len(ticked) && ticked[i] {
+			box = "☑"
+		}
+		content.WriteString(fmt.Sprintf("%s %d. %s\n", box, i+1, question))
+	}
+	content.WriteString("\nPress **1-9** to toggle an item.")
+	if m.config.Review.RequireChecklist {
+		content.WriteString(" Approving is enabled once every item is ticked.")
+	}
+	content.WriteString("\n\n")
+	return content.String()
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/github"
+)
+
+// checkLogState holds the ends of a PR's failing check logs for the details
+// popup
+type checkLogState struct {
+	loading bool
+	sha     string // Head commit the logs belong to
+	logs    []github.CheckLog
+	err     error
+}
+
+// CheckLogsLoadedMsg is sent when a PR's failing check logs have been fetched
+type CheckLogsLoadedMsg struct {
+	PRID int64
+	SHA  string
+	Logs []github.CheckLog
+	Err  error
+}
+
+// FetchCheckLogsCmd fetches the end of the log of each failing check
+func FetchCheckLogsCmd(ctx context.Context, pr *github.PullRequest, status *github.CheckStatus, prID int64) tea.Cmd {
+	sha := pr.HeadSHA
+	return func() tea.Msg {
+		logs, err := pr.GetFailedCheckLogs(ctx, status)
+		return CheckLogsLoadedMsg{PRID: prID, SHA: sha, Logs: logs, Err: err}
+	}
+}
+
+// hasFailedJobs reports whether any failing check has an Actions log to show
+func hasFailedJobs(status *github.CheckStatus) bool {
+	if status == nil {
+		return false
+	}
+	for _, detail := range status.Details {
+		if detail.Status == "failure" && detail.JobID != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchCheckLogs starts fetching a PR's failing check logs, unless they're
+// already loaded for its head commit
+func (m Model) fetchCheckLogs(item PRItem) tea.Cmd {
+	if !hasFailedJobs(item.CheckStatus) {
+		return nil
+	}
+	if state := m.checkLogs[item.ID]; state != nil && (state.loading || (state.sha == item.PR.HeadSHA && state.err == nil)) {
+		return nil
+	}
+
+	slog.Debug("Fetching failing check logs", slog.Any("pr", item.PR))
+	m.checkLogs[item.ID] = &checkLogState{loading: true, sha: item.PR.HeadSHA}
+	return FetchCheckLogsCmd(m.prContext(item.ID), item.PR, item.CheckStatus, item.ID)
+}
+
+func (m Model) handleCheckLogsLoaded(msg CheckLogsLoadedMsg) (Model, tea.Cmd) {
+	state := m.checkLogs[msg.PRID]
+	if state == nil || state.sha != msg.SHA {
+		return m, nil
+	}
+	state.loading = false
+	state.logs, state.err = msg.Logs, msg.Err
+	if msg.Err != nil {
+		slog.Error("Failed to load check logs", slog.Int64("pr_id", msg.PRID), slog.Any("error", msg.Err))
+	}
+
+	if prItem, ok := m.list.SelectedItem().(PRItem); ok && m.showPopup && prItem.ID == msg.PRID {
+		m.popupContent = m.popupContentFor(prItem)
+	}
+	return m, nil
+}
+
+// checkLogContent shows the end of each failing check's log for the details
+// popup
+func (m Model) checkLogContent(item PRItem) string {
+	if !hasFailedJobs(item.CheckStatus) {
+		return ""
+	}
+
+	var content strings.Builder
+	content.WriteString("## 🧾 Failing Check Logs\n\n")
+	state := m.checkLogs[item.ID]
+	switch {
+	case state == nil || state.loading:
+		content.WriteString("*Loading logs...*\n\n")
+		return content.String()
+	case state.err != nil:
+		content.WriteString(fmt.Sprintf("*Failed to load: %s*\n\n", state.err))
+	}
+
+	for _, log := range state.logs {
+		content.WriteString(fmt.Sprintf("**%s** (last %d lines)\n\n```\n%s\n```\n\n", log.Name, github.LogTailLines, log.Tail))
+	}
+	return content.String()
+}
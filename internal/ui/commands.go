@@ -9,14 +9,19 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/kennyp/speedrun/pkg/agent"
 	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/history"
+	"github.com/kennyp/speedrun/pkg/hooks"
+	"github.com/kennyp/speedrun/pkg/metrics"
+	"github.com/kennyp/speedrun/pkg/tracker"
 )
 
 // Messages
 
 // PRsLoadedMsg is sent when PRs have been loaded from GitHub
 type PRsLoadedMsg struct {
-	PRs []*github.PullRequest
-	Err error
+	Queue int
+	PRs   []*github.PullRequest
+	Err   error
 }
 
 // DiffStatsLoadedMsg is sent when diff stats have been loaded for a PR
@@ -31,6 +36,11 @@ type CheckStatusLoadedMsg struct {
 	PRID   int64
 	Status *github.CheckStatus
 	Err    error
+
+	// The PR's head and the branches it merges between, as the load found them
+	HeadSHA string
+	BaseRef string
+	HeadRef string
 }
 
 // ReviewsLoadedMsg is sent when reviews have been loaded for a PR
@@ -40,10 +50,25 @@ type ReviewsLoadedMsg struct {
 	Err     error
 }
 
+// SignaturesLoadedMsg is sent when commit signatures have been checked for a PR
+type SignaturesLoadedMsg struct {
+	PRID       int64
+	Signatures *github.CommitSignatures
+	Err        error
+}
+
+// LinkedIssuesLoadedMsg is sent when issues referenced from a PR have been looked up
+type LinkedIssuesLoadedMsg struct {
+	PRID   int64
+	Issues []*tracker.Issue
+	Err    error
+}
+
 // AIAnalysisLoadedMsg is sent when AI analysis has been completed for a PR
 type AIAnalysisLoadedMsg struct {
 	PRID     int64
 	Analysis *agent.Analysis
+	Cached   bool // Analysis came from cache rather than a fresh model run
 	Err      error
 }
 
@@ -70,18 +95,20 @@ type StatusMsg string
 
 // SmartRefreshLoadedMsg is sent when smart refresh has completed
 type SmartRefreshLoadedMsg struct {
-	PRs []*github.PullRequest
-	Err error
+	Queue       int
+	PRs         []*github.PullRequest
+	RefreshedAt time.Time // When the search started; zero if results came from the stale cache
+	Err         error
 }
 
 // Commands
 
-// FetchPRsCmd fetches PRs from GitHub
-func FetchPRsCmd(client *github.Client) tea.Cmd {
+// FetchPRsCmd fetches a queue's PRs from GitHub
+func FetchPRsCmd(ctx context.Context, client *github.Client, queue int) tea.Cmd {
 	return func() tea.Msg {
 		slog.Debug("Starting PR search")
 		start := time.Now()
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := client.CallContext(ctx, 30*time.Second)
 		defer cancel()
 
 		prs, err := client.SearchPullRequests(ctx)
@@ -93,16 +120,16 @@ func FetchPRsCmd(client *github.Client) tea.Cmd {
 			slog.Info("PR search completed", slog.Int("count", len(prs)), slog.Duration("duration", duration))
 		}
 
-		return PRsLoadedMsg{PRs: prs, Err: err}
+		return PRsLoadedMsg{Queue: queue, PRs: prs, Err: err}
 	}
 }
 
 // FetchDiffStatsCmd fetches diff stats for a PR
-func FetchDiffStatsCmd(client *github.Client, pr *github.PullRequest, prID int64) tea.Cmd {
+func FetchDiffStatsCmd(ctx context.Context, client *github.Client, pr *github.PullRequest, prID int64) tea.Cmd {
 	return func() tea.Msg {
 		slog.Debug("Fetching diff stats", slog.Any("pr", pr))
 		start := time.Now()
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := pr.CallContext(ctx, 10*time.Second)
 		defer cancel()
 
 		stats, err := pr.GetDiffStats(ctx)
@@ -123,11 +150,15 @@ func FetchDiffStatsCmd(client *github.Client, pr *github.PullRequest, prID int64
 }
 
 // FetchCheckStatusCmd fetches check status for a PR
-func FetchCheckStatusCmd(client *github.Client, pr *github.PullRequest, prID int64) tea.Cmd {
+func FetchCheckStatusCmd(ctx context.Context, client *github.Client, pr *github.PullRequest, prID int64) tea.Cmd {
+	// Loading check status records the PR's head on it, so it loads with a
+	// copy the UI isn't reading, and the head goes back in the message
+	loaded := *pr
+	pr = &loaded
 	return func() tea.Msg {
 		slog.Debug("Fetching check status", slog.Any("pr", pr))
 		start := time.Now()
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := pr.CallContext(ctx, 10*time.Second)
 		defer cancel()
 
 		status, err := pr.GetCheckStatus(ctx)
@@ -140,19 +171,65 @@ func FetchCheckStatusCmd(client *github.Client, pr *github.PullRequest, prID int
 		}
 
 		return CheckStatusLoadedMsg{
+			PRID:    prID,
+			Status:  status,
+			Err:     err,
+			HeadSHA: pr.HeadSHA,
+			BaseRef: pr.BaseRef,
+			HeadRef: pr.HeadRef,
+		}
+	}
+}
+
+// FetchSignaturesCmd checks commit signing and DCO sign-off for a PR
+func FetchSignaturesCmd(ctx context.Context, pr *github.PullRequest, prID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := pr.CallContext(ctx, 10*time.Second)
+		defer cancel()
+
+		sigs, err := pr.GetCommitSignatures(ctx)
+		if err != nil {
+			slog.Debug("Commit signatures failed", slog.Any("pr", pr), slog.Any("error", err))
+		}
+
+		return SignaturesLoadedMsg{
+			PRID:       prID,
+			Signatures: sigs,
+			Err:        err,
+		}
+	}
+}
+
+// FetchLinkedIssuesCmd looks up the tracker issues referenced from a PR
+func FetchLinkedIssuesCmd(ctx context.Context, issueTracker tracker.Tracker, pr *github.PullRequest, prID int64) tea.Cmd {
+	return func() tea.Msg {
+		start := time.Now()
+		ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+		defer cancel()
+
+		issues, err := tracker.LinkedIssues(ctx, issueTracker, pr.Owner, pr.Repo, pr.Title, pr.GetBody())
+		duration := time.Since(start)
+
+		if err != nil {
+			slog.Debug("Linked issues failed", slog.Any("pr", pr), slog.Duration("duration", duration), slog.Any("error", err))
+		} else {
+			slog.Debug("Linked issues loaded", slog.Any("pr", pr), slog.Duration("duration", duration), slog.Int("count", len(issues)))
+		}
+
+		return LinkedIssuesLoadedMsg{
 			PRID:   prID,
-			Status: status,
+			Issues: issues,
 			Err:    err,
 		}
 	}
 }
 
 // FetchReviewsCmd fetches reviews for a PR
-func FetchReviewsCmd(client *github.Client, pr *github.PullRequest, username string, prID int64) tea.Cmd {
+func FetchReviewsCmd(ctx context.Context, client *github.Client, pr *github.PullRequest, username string, prID int64) tea.Cmd {
 	return func() tea.Msg {
 		slog.Debug("Fetching reviews", slog.Any("pr", pr), slog.String("username", username))
 		start := time.Now()
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := pr.CallContext(ctx, 10*time.Second)
 		defer cancel()
 
 		reviews, err := pr.GetReviews(ctx)
@@ -185,11 +262,11 @@ func FetchReviewsCmd(client *github.Client, pr *github.PullRequest, username str
 }
 
 // ApprovePRCmd approves a PR
-func ApprovePRCmd(pr *github.PullRequest, prID int64) tea.Cmd {
+func ApprovePRCmd(ctx context.Context, pr *github.PullRequest, prID int64) tea.Cmd {
 	return func() tea.Msg {
 		slog.Info("Approving PR", slog.Any("pr", pr))
 		start := time.Now()
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := pr.CallContext(ctx, 10*time.Second)
 		defer cancel()
 
 		err := pr.Approve(ctx)
@@ -209,11 +286,11 @@ func ApprovePRCmd(pr *github.PullRequest, prID int64) tea.Cmd {
 }
 
 // EnableAutoMergeCmd enables auto-merge for a PR
-func EnableAutoMergeCmd(pr *github.PullRequest, mergeMethod string, prID int64) tea.Cmd {
+func EnableAutoMergeCmd(ctx context.Context, pr *github.PullRequest, mergeMethod string, prID int64) tea.Cmd {
 	return func() tea.Msg {
 		slog.Info("Enabling auto-merge for PR", slog.Any("pr", pr), slog.String("merge_method", mergeMethod))
 		start := time.Now()
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		ctx, cancel := pr.CallContext(ctx, 15*time.Second)
 		defer cancel()
 
 		err := pr.EnableAutoMerge(ctx, mergeMethod)
@@ -233,11 +310,11 @@ func EnableAutoMergeCmd(pr *github.PullRequest, mergeMethod string, prID int64)
 }
 
 // MergeCmd merges a PR directly
-func MergeCmd(pr *github.PullRequest, mergeMethod string, prID int64) tea.Cmd {
+func MergeCmd(ctx context.Context, pr *github.PullRequest, mergeMethod string, prID int64) tea.Cmd {
 	return func() tea.Msg {
 		slog.Info("Merging PR", slog.Any("pr", pr), slog.String("merge_method", mergeMethod))
 		start := time.Now()
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		ctx, cancel := pr.CallContext(ctx, 15*time.Second)
 		defer cancel()
 
 		err := pr.Merge(ctx, mergeMethod)
@@ -257,7 +334,8 @@ func MergeCmd(pr *github.PullRequest, mergeMethod string, prID int64) tea.Cmd {
 }
 
 // FetchAIAnalysisCmd runs AI analysis for a PR
-func FetchAIAnalysisCmd(aiAgent *agent.Agent, pr *github.PullRequest, diffStats *github.DiffStats, checkStatus *github.CheckStatus, reviews []*github.Review, prID int64, analysisTimeout time.Duration) tea.Cmd {
+func FetchAIAnalysisCmd(ctx context.Context, aiAgent *agent.Agent, snapshot github.Snapshot, issues []*tracker.Issue, signaturesRequired bool, group []agent.GroupMember, budget agent.SizeBudget, templateSignal bool, prID int64, analysisTimeout time.Duration) tea.Cmd {
+	pr := snapshot.PR
 	return func() tea.Msg {
 		// Skip AI analysis if HeadSHA is not yet available
 		if pr.HeadSHA == "" {
@@ -271,7 +349,7 @@ func FetchAIAnalysisCmd(aiAgent *agent.Agent, pr *github.PullRequest, diffStats
 
 		slog.Debug("Starting AI analysis", slog.Any("pr", pr))
 		start := time.Now()
-		ctx, cancel := context.WithTimeout(context.Background(), analysisTimeout)
+		ctx, cancel := context.WithTimeout(ctx, analysisTimeout)
 		defer cancel()
 
 		// Check for cached AI analysis first
@@ -283,47 +361,44 @@ func FetchAIAnalysisCmd(aiAgent *agent.Agent, pr *github.PullRequest, diffStats
 			return AIAnalysisLoadedMsg{
 				PRID:     prID,
 				Analysis: &cachedAnalysis,
+				Cached:   true,
 				Err:      nil,
 			}
 		}
 
-		// Convert github reviews to agent reviews
-		var agentReviews []agent.ReviewInfo
-		for _, review := range reviews {
-			agentReviews = append(agentReviews, agent.ReviewInfo{
-				State: review.State,
-				User:  review.User,
-			})
-		}
-
-		// Convert check details to agent format
-		var checkDetails []agent.CheckInfo
-		if checkStatus != nil && checkStatus.Details != nil {
-			for _, detail := range checkStatus.Details {
-				checkDetails = append(checkDetails, agent.CheckInfo{
-					Name:        detail.Name,
-					Status:      detail.Status,
-					Description: detail.Description,
-				})
-			}
+		// Another speedrun sharing the cache may be analyzing the PR already,
+		// in which case its analysis is used once it's cached
+		waited, err := pr.ClaimAIAnalysis(ctx, analysisTimeout, &cachedAnalysis)
+		if err != nil {
+			return AIAnalysisLoadedMsg{PRID: prID, Err: err}
 		}
+		if waited {
+			slog.Debug("AI analysis loaded from another instance", slog.Any("pr", pr), slog.Duration("duration", time.Since(start)))
+			return AIAnalysisLoadedMsg{PRID: prID, Analysis: &cachedAnalysis, Cached: true}
+		}
+		defer pr.ReleaseAIAnalysis()
 
-		// Build PR data
 		prData := agent.PRData{
-			Title:              pr.Title,
-			Number:             pr.Number,
-			Author:             pr.GetAuthor(),
-			Labels:             pr.GetLabels(),
-			RequestedReviewers: []string{}, // TODO: Implement GetRequestedReviewers
-			Description:        pr.GetBody(),
-			Additions:          diffStats.Additions,
-			Deletions:          diffStats.Deletions,
-			ChangedFiles:       diffStats.Files,
-			CIStatus:           checkStatus.State, // Keep for backward compatibility
-			CheckDetails:       checkDetails,
-			Reviews:            agentReviews,
-			HasConflicts:       false, // TODO: Fetch merge conflict status
-			PRURL:              fmt.Sprintf("https://github.com/%s/%s/pull/%d", pr.Owner, pr.Repo, pr.Number),
+			Snapshot:           snapshot,
+			LinkedIssues:       issues,
+			SignaturesRequired: signaturesRequired,
+			GroupMembers:       group,
+			SizeBudget:         budget,
+			TemplateSignal:     templateSignal,
+		}
+		if prData.ChangedFiles == nil {
+			if files, err := pr.GetChangedFiles(ctx); err != nil {
+				slog.Debug("Changed files unavailable for AI analysis", slog.Any("pr", pr), slog.Any("error", err))
+			} else {
+				prData.ChangedFiles = files
+			}
+		}
+		if templateSignal && prData.Template == nil {
+			if compliance, err := pr.GetTemplateCompliance(ctx); err != nil {
+				slog.Debug("PR template unavailable for AI analysis", slog.Any("pr", pr), slog.Any("error", err))
+			} else {
+				prData.Template = compliance
+			}
 		}
 
 		slog.Debug("Running AI analysis (not cached)", slog.Any("pr", pr))
@@ -349,6 +424,25 @@ func FetchAIAnalysisCmd(aiAgent *agent.Agent, pr *github.PullRequest, diffStats
 	}
 }
 
+// aiRetryMinDelay spaces out retries of analyses paused by the circuit breaker
+const aiRetryMinDelay = 10 * time.Second
+
+// RetryAIAnalysisMsg requests another analysis of a PR paused by the circuit breaker
+type RetryAIAnalysisMsg struct {
+	PRID int64
+}
+
+// RetryAIAnalysisCmd retries a paused analysis once the circuit may allow it
+func RetryAIAnalysisCmd(prID int64, at time.Time) tea.Cmd {
+	delay := time.Until(at)
+	if delay < aiRetryMinDelay {
+		delay = aiRetryMinDelay
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return RetryAIAnalysisMsg{PRID: prID}
+	})
+}
+
 // FetchCachedAIAnalysisCmd loads cached AI analysis for a PR
 func FetchCachedAIAnalysisCmd(pr *github.PullRequest, prID int64) tea.Cmd {
 	return func() tea.Msg {
@@ -362,6 +456,7 @@ func FetchCachedAIAnalysisCmd(pr *github.PullRequest, prID int64) tea.Cmd {
 			return AIAnalysisLoadedMsg{
 				PRID:     prID,
 				Analysis: &cachedAnalysis,
+				Cached:   true,
 				Err:      nil,
 			}
 		}
@@ -391,24 +486,98 @@ type TriggerAIAnalysisMsg struct {
 	PRID int64
 }
 
-// SmartRefreshCmd fetches fresh PRs for smart refresh
-func SmartRefreshCmd(client *github.Client) tea.Cmd {
+// SmartRefreshCmd fetches a queue's fresh PRs for smart refresh. After a
+// successful refresh only PRs updated since then are fetched and merged into
+// existing.
+func SmartRefreshCmd(ctx context.Context, client *github.Client, existing []*github.PullRequest, since time.Time, queue int) tea.Cmd {
 	return func() tea.Msg {
-		slog.Info("Starting smart refresh")
+		delta := !since.IsZero() && len(existing) > 0
+		slog.Info("Starting smart refresh", slog.Bool("delta", delta))
 		start := time.Now()
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := client.CallContext(ctx, 30*time.Second)
 		defer cancel()
 
-		prs, err := client.SearchPullRequestsFresh(ctx)
+		var prs []*github.PullRequest
+		var err error
+		if delta {
+			prs, err = client.SearchPullRequestsSince(ctx, existing, since)
+		} else {
+			prs, err = client.SearchPullRequestsFresh(ctx)
+		}
 		duration := time.Since(start)
 
 		if err != nil {
 			slog.Error("Smart refresh failed", slog.Duration("duration", duration), slog.Any("error", err))
-		} else {
-			slog.Info("Smart refresh completed", slog.Int("count", len(prs)), slog.Duration("duration", duration))
+			return SmartRefreshLoadedMsg{Queue: queue, Err: err}
+		}
+		slog.Info("Smart refresh completed", slog.Int("count", len(prs)), slog.Duration("duration", duration))
+
+		msg := SmartRefreshLoadedMsg{Queue: queue, PRs: prs}
+		if !client.Health().Degraded {
+			msg.RefreshedAt = start
 		}
+		return msg
+	}
+}
 
-		return SmartRefreshLoadedMsg{PRs: prs, Err: err}
+// RecordHistoryCmd records review activity in the history database
+func RecordHistoryCmd(recorder history.Recorder, event history.Event) tea.Cmd {
+	return func() tea.Msg {
+		metrics.PRsProcessed.Inc(string(event.Outcome))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := recorder.Record(ctx, event); err != nil {
+			slog.Warn("Failed to record history event", slog.Any("event", event), slog.Any("error", err))
+		}
+		return nil
+	}
+}
+
+// RunHookCmd runs the hook for an event, logging rather than reporting a
+// failure, as hooks run after the fact
+func RunHookCmd(h hooks.Hooks, event hooks.Event, pr hooks.PR) tea.Cmd {
+	if h.Commands[event] == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := h.Run(context.Background(), event, pr); err != nil {
+			slog.Warn("Hook failed", slog.String("event", string(event)), slog.Any("error", err))
+		}
+		return nil
+	}
+}
+
+// healthProbeInterval is how often GitHub is probed while in stale mode
+const healthProbeInterval = 30 * time.Second
+
+// HealthTickMsg triggers a GitHub availability check
+type HealthTickMsg struct{}
+
+// HealthProbedMsg is sent when a stale-mode probe of GitHub completes
+type HealthProbedMsg struct {
+	Err error
+}
+
+// HealthTickCmd schedules the next availability check
+func HealthTickCmd() tea.Cmd {
+	return tea.Tick(healthProbeInterval, func(time.Time) tea.Msg {
+		return HealthTickMsg{}
+	})
+}
+
+// ProbeGitHubCmd makes a single cheap request to see whether GitHub is back
+func ProbeGitHubCmd(ctx context.Context, client *github.Client) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := client.CallContext(ctx, 10*time.Second)
+		defer cancel()
+
+		_, err := client.AuthenticatedUser(ctx)
+		if err != nil {
+			slog.Debug("GitHub still unavailable", slog.Any("error", err))
+		}
+		return HealthProbedMsg{Err: err}
 	}
 }
 
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kennyp/speedrun/pkg/agent"
+	"github.com/kennyp/speedrun/pkg/config"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/tracker"
+)
+
+// commentMarker identifies speedrun's sticky comment on a PR
+const commentMarker = "<!-- speedrun-analysis -->"
+
+// PostAnalysisComment analyzes one PR and posts, or updates, a sticky comment
+// with the AI summary, risk level and a review checklist. In dry-run mode the
+// comment is written to w instead.
+func PostAnalysisComment(ctx context.Context, w io.Writer, cfg *config.Config, githubClient *github.Client, aiAgent *agent.Agent, issueTracker tracker.Tracker, username string, pr *github.PullRequest) error {
+	item := loadPlainItem(ctx, cfg, githubClient, issueTracker, username, pr)
+	analyzePlainItem(ctx, cfg, aiAgent, &item)
+
+	body := analysisComment(item, aiAgent != nil, issueTracker != nil)
+	if err := pr.UpsertComment(ctx, commentMarker, body); err != nil {
+		return err
+	}
+	if githubClient.DryRun() {
+		if _, err := fmt.Fprintf(w, "Would post on %s/%s#%d:\n\n%s", pr.Owner, pr.Repo, pr.Number, body); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+	if item.AIError != nil {
+		return fmt.Errorf("failed to analyze PR: %w", item.AIError)
+	}
+	return nil
+}
+
+// analysisComment renders the sticky comment body
+func analysisComment(item PRItem, aiEnabled, trackerEnabled bool) string {
+	var content strings.Builder
+	content.WriteString(commentMarker + "\n")
+	content.WriteString("## 🤖 speedrun review\n\n")
+
+	switch {
+	case item.AIAnalysis != nil:
+		analysis := item.AIAnalysis
+		content.WriteString("| Recommendation | Risk | Type |\n|---|---|---|\n")
+		content.WriteString(fmt.Sprintf("| %s %s | %s %s | %s %s |\n\n",
+			getRecommendationEmoji(analysis.Recommendation), analysis.Recommendation,
+			getRiskEmoji(analysis.RiskLevel), analysis.RiskLevel,
+			getPRTypeEmoji(analysis.PRType), analysis.PRType))
+		if analysis.Reasoning != "" {
+			content.WriteString(strings.TrimSpace(analysis.Reasoning) + "\n\n")
+		}
+	case item.AIError != nil:
+		content.WriteString(fmt.Sprintf("⚠️ AI analysis failed: %v\n\n", item.AIError))
+	case !aiEnabled:
+		content.WriteString("AI analysis is disabled.\n\n")
+	}
+
+	content.WriteString("### Checklist\n\n")
+	for _, line := range commentChecklist(item, trackerEnabled) {
+		content.WriteString(line + "\n")
+	}
+
+	if sha := item.PR.HeadSHA; sha != "" {
+		content.WriteString(fmt.Sprintf("\n<sub>Analyzed at %s</sub>\n", sha[:min(len(sha), 7)]))
+	}
+	return content.String()
+}
+
+// commentChecklist lists what a reviewer would check, ticked when satisfied
+func commentChecklist(item PRItem, trackerEnabled bool) []string {
+	check := func(ok bool, text string) string {
+		if ok {
+			return "- [x] " + text
+		}
+		return "- [ ] " + text
+	}
+
+	var lines []string
+	switch {
+	case item.CheckStatus != nil:
+		text := "CI checks passing"
+		if item.CheckStatus.State != "success" {
+			text = fmt.Sprintf("CI checks passing (%s: %s)", item.CheckStatus.State, item.CheckStatus.Description)
+		}
+		lines = append(lines, check(item.CheckStatus.State == "success", text))
+	case item.CheckError != nil:
+		lines = append(lines, check(false, "CI checks passing (status unavailable)"))
+	}
+
+	if stats := item.DiffStats; stats != nil {
+		text := fmt.Sprintf("Within size budget (+%d/-%d lines, %d files)", stats.Additions, stats.Deletions, stats.Files)
+		if item.OverBudget != "" {
+			text = fmt.Sprintf("Within size budget (over: %s)", item.OverBudget)
+		}
+		lines = append(lines, check(item.OverBudget == "", text))
+	}
+
+	if sigs := item.Signatures; sigs != nil && sigs.Commits > 0 {
+		lines = append(lines, check(sigs.Unverified == 0,
+			fmt.Sprintf("Commits signed (%d/%d verified)", sigs.Commits-sigs.Unverified, sigs.Commits)))
+	}
+	if dco := github.DCOStatus(item.CheckStatus, item.Signatures); dco != "" {
+		lines = append(lines, check(dco != github.DCOFailed, fmt.Sprintf("DCO sign-off (%s)", dco)))
+	}
+
+	if trackerEnabled {
+		var keys []string
+		for _, issue := range item.Issues {
+			keys = append(keys, issue.Key)
+		}
+		text := "Linked issue"
+		if len(keys) > 0 {
+			text += " (" + strings.Join(keys, ", ") + ")"
+		}
+		lines = append(lines, check(len(keys) > 0, text))
+	}
+
+	return lines
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"io"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// slaBreachColor marks PRs that have waited longer than the review SLA
+var slaBreachColor = lipgloss.Color("196")
+
+// prDelegate renders PR items, highlighting those that breach the review SLA
+type prDelegate struct {
+	list.DefaultDelegate
+	sla        time.Duration
+	accessible bool
+}
+
+// newPRDelegate creates the list delegate; an sla of zero disables
+// highlighting. Accessible delegates render text labels in a compact list.
+func newPRDelegate(sla time.Duration, accessible bool) prDelegate {
+	d := prDelegate{DefaultDelegate: list.NewDefaultDelegate(), sla: sla, accessible: accessible}
+	if accessible {
+		d.SetSpacing(0)
+	}
+	return d
+}
+
+// Render implements list.ItemDelegate
+func (d prDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	prItem, ok := item.(PRItem)
+	if !ok {
+		d.DefaultDelegate.Render(w, m, index, item)
+		return
+	}
+
+	overdue := prItem.BreachesSLA(d.sla)
+	if overdue {
+		d.Styles.NormalTitle = d.Styles.NormalTitle.Foreground(slaBreachColor)
+		d.Styles.SelectedTitle = d.Styles.SelectedTitle.Foreground(slaBreachColor).BorderForeground(slaBreachColor)
+	}
+	if d.accessible {
+		d.DefaultDelegate.Render(w, m, index, textItem{PRItem: prItem, overdue: overdue})
+		return
+	}
+	d.DefaultDelegate.Render(w, m, index, item)
+}
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/agent"
+	"github.com/kennyp/speedrun/pkg/config"
+	"github.com/kennyp/speedrun/pkg/digest"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/tracker"
+)
+
+// LoadDigest fetches the queue and its AI analyses for a digest, labelling
+// PRs the way accessible mode does
+func LoadDigest(ctx context.Context, cfg *config.Config, githubClient *github.Client, aiAgent *agent.Agent, issueTracker tracker.Tracker, username string) (digest.Digest, error) {
+	prs, err := githubClient.SearchPullRequestsFresh(ctx)
+	if err != nil {
+		return digest.Digest{}, fmt.Errorf("failed to search pull requests: %w", err)
+	}
+
+	items := loadPlainItems(ctx, cfg, githubClient, issueTracker, username, prs)
+	eachItem(len(items), func(i int) {
+		analyzePlainItem(ctx, cfg, aiAgent, &items[i])
+	})
+
+	d := digest.Digest{User: username, Generated: time.Now()}
+	for _, item := range items {
+		text := textItem{PRItem: item, overdue: item.BreachesSLA(cfg.GitHub.ReviewSLA)}
+		entry := digest.Entry{
+			Ref:     fmt.Sprintf("%s/%s#%d", item.PR.Owner, item.PR.Repo, item.PR.Number),
+			Title:   item.PR.Title,
+			URL:     fmt.Sprintf("https://github.com/%s/%s/pull/%d", item.PR.Owner, item.PR.Repo, item.PR.Number),
+			Labels:  text.labels(),
+			Summary: text.Description(),
+		}
+		if item.AIAnalysis != nil {
+			entry.Reasoning = item.AIAnalysis.Reasoning
+		}
+		d.Entries = append(d.Entries, entry)
+	}
+	return d, nil
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kennyp/speedrun/pkg/history"
+	"github.com/kennyp/speedrun/pkg/hooks"
+)
+
+var dryRunBannerStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("#000000")).
+	Background(lipgloss.Color("#00BFFF")).
+	Padding(0, 1)
+
+// actionStatus reports a completed write action, or in dry-run mode what
+// would have been done
+func (m Model) actionStatus(done, wouldDo string) string {
+	if m.github.DryRun() {
+		return "🧪 Dry run: " + wouldDo
+	}
+	return done
+}
+
+// recordAction records an approval or merge, with the time spent on the PR,
+// in the history and runs its hook, unless it was only a dry run
+func (m Model) recordAction(item *PRItem, outcome history.Outcome) tea.Cmd {
+	if m.github.DryRun() {
+		return nil
+	}
+	event := hooks.Approve
+	if outcome != history.Approved {
+		event = hooks.Merge
+	}
+	record := m.historyEvent(item, outcome, "")
+	record.Duration = m.session.acted(item.ID, outcome, m.lastInput, time.Now())
+	return tea.Batch(
+		RecordHistoryCmd(m.history, record),
+		RunHookCmd(m.hooks, event, m.hookPR(item)),
+	)
+}
@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/agent"
+)
+
+// errorLogLines is how many of a PR's most recent log lines the error popup
+// shows
+const errorLogLines = 15
+
+// prError is one thing that failed to load for a PR
+type prError struct {
+	what string
+	err  error
+}
+
+// loadErrors lists what failed to load for the PR. A paused AI isn't an error;
+// its analysis is retried when the pause ends.
+func (i PRItem) loadErrors() []prError {
+	var errs []prError
+	for _, e := range []prError{
+		{"Diff", i.DiffError},
+		{"Checks", i.CheckError},
+		{"Reviews", i.ReviewError},
+		{"Commit signatures", i.SigError},
+		{"Linked issues", i.IssueError},
+		{"AI analysis", i.AIError},
+	} {
+		if e.err != nil && !errors.Is(e.err, agent.ErrCircuitOpen) {
+			errs = append(errs, e)
+		}
+	}
+	return errs
+}
+
+// handleShowErrors opens the selected PR's errors in the popup
+func (m Model) handleShowErrors() (Model, tea.Cmd) {
+	prItem, ok := m.list.SelectedItem().(PRItem)
+	if !ok {
+		return m, nil
+	}
+	if len(prItem.loadErrors()) == 0 {
+		m.setStatus(statusInfo, fmt.Sprintf("No errors on PR #%d", prItem.PR.Number))
+		return m, nil
+	}
+
+	slog.Info("User opened PR errors", slog.Any("pr", prItem.PR))
+	m.showPopup = true
+	m.showErrors = true
+	m.showTimeline = false
+	m.popupScrollPos = 0
+	m.popupContent = m.generateErrorContent(prItem)
+	return m, nil
+}
+
+// generateErrorContent renders a PR's errors, in full, and its log lines for
+// the popup
+func (m Model) generateErrorContent(item PRItem) string {
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("# %s\n\n", item.PR.Title))
+	content.WriteString(fmt.Sprintf("**Repository:** %s/%s\n", item.PR.Owner, item.PR.Repo))
+	content.WriteString(fmt.Sprintf("**PR Number:** #%d\n", item.PR.Number))
+	content.WriteString("\n---\n\n")
+	content.WriteString("## ⚠️ Errors\n\n")
+
+	errs := item.loadErrors()
+	if len(errs) == 0 {
+		content.WriteString("*Nothing failed to load*\n\n")
+	}
+	for _, e := range errs {
+		content.WriteString(fmt.Sprintf("**%s**\n", e.what))
+		content.WriteString(e.err.Error() + "\n\n")
+	}
+
+	if lines := m.prLogLines(item); len(lines) > 0 {
+		content.WriteString("## 📜 Log\n\n")
+		for _, line := range lines {
+			content.WriteString(fmt.Sprintf("`%s`\n", line))
+		}
+		content.WriteString("\n")
+	}
+
+	content.WriteString("---\n\n")
+	content.WriteString("*Press **r** to retry, **Enter** or **Esc** to close*")
+	return content.String()
+}
+
+// prLogLines returns the most recent captured log lines about a PR
+func (m Model) prLogLines(item PRItem) []string {
+	if m.logs == nil {
+		return nil
+	}
+
+	// Handlers log PRs by item ID or, through its LogValue, by number and repo
+	keys := []string{
+		fmt.Sprintf("prID=%d", item.ID),
+		fmt.Sprintf("pr_id=%d", item.ID),
+		fmt.Sprintf("pr.number=%d pr.repo=%s/%s", item.PR.Number, item.PR.Owner, item.PR.Repo),
+	}
+
+	var lines []string
+	for _, entry := range m.logs.Entries(slog.LevelDebug) {
+		for _, key := range keys {
+			if strings.Contains(entry.Line+" ", key+" ") {
+				lines = append(lines, entry.Line)
+				break
+			}
+		}
+	}
+	if len(lines) > errorLogLines {
+		lines = lines[len(lines)-errorLogLines:]
+	}
+	return lines
+}
+
+// handleRetryErrors reloads whatever failed to load for the selected PR, then
+// analyzes it again if the analysis failed
+func (m Model) handleRetryErrors() (Model, tea.Cmd) {
+	prItem, ok := m.list.SelectedItem().(PRItem)
+	if !ok {
+		return m, nil
+	}
+	m.closePopup()
+
+	errs := prItem.loadErrors()
+	if len(errs) == 0 {
+		return m, nil
+	}
+	slog.Info("User retried PR errors", slog.Any("pr", prItem.PR), slog.Int("errors", len(errs)))
+
+	// A manual retry starts the automatic ones afresh
+	m = m.updatePRByID(prItem.ID, func(item *PRItem) {
+		item.Retries = 0
+		if item.AIError != nil && !errors.Is(item.AIError, agent.ErrCircuitOpen) {
+			item.LoadingAI, item.AIError = true, nil
+		}
+	})
+	m, cmds := m.reloadFailed(prItem.ID, func(error) bool { return true })
+	m = m.updateVisibleItems()
+	m.setStatus(statusInfo, fmt.Sprintf("Retrying PR #%d...", prItem.PR.Number))
+
+	// With nothing else to reload, the analysis can start now; otherwise it
+	// starts when the last reload lands
+	cmds = append(cmds, m.triggerAIAnalysisIfReadyByID(prItem.ID))
+	return m, tea.Batch(cmds...)
+}
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/agent"
+	"github.com/kennyp/speedrun/pkg/config"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/tracker"
+)
+
+// Export formats
+const (
+	ExportCSV      = "csv"
+	ExportMarkdown = "markdown"
+)
+
+// exportColumns heads the exported table
+var exportColumns = []string{"PR", "Title", "URL", "Author", "Age", "Status", "Checks", "Additions", "Deletions", "Files", "Reviews", "AI", "Risk"}
+
+// ExportDoneMsg is sent when the visible list has been written to a file
+type ExportDoneMsg struct {
+	Path  string
+	Count int
+	Err   error
+}
+
+// Export loads the queue and writes it as CSV or a markdown table
+func Export(ctx context.Context, w io.Writer, format string, cfg *config.Config, githubClient *github.Client, aiAgent *agent.Agent, issueTracker tracker.Tracker, username string) error {
+	prs, err := githubClient.SearchPullRequests(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to search pull requests: %w", err)
+	}
+
+	items := loadPlainItems(ctx, cfg, githubClient, issueTracker, username, prs)
+	eachItem(len(items), func(i int) {
+		analyzePlainItem(ctx, cfg, aiAgent, &items[i])
+	})
+	return writeExport(w, format, items, cfg)
+}
+
+// ExportCmd writes PRs as a markdown table to a timestamped file in the
+// current directory
+func ExportCmd(items []PRItem, cfg *config.Config) tea.Cmd {
+	return func() tea.Msg {
+		path := fmt.Sprintf("speedrun-queue-%s.md", time.Now().Format("20060102-150405"))
+		f, err := os.Create(path)
+		if err != nil {
+			return ExportDoneMsg{Err: fmt.Errorf("failed to create export: %w", err)}
+		}
+		err = writeExport(f, ExportMarkdown, items, cfg)
+		if closeErr := f.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to write export: %w", closeErr)
+		}
+		return ExportDoneMsg{Path: path, Count: len(items), Err: err}
+	}
+}
+
+// handleExport writes the PRs currently shown, after filtering
+func (m Model) handleExport() (Model, tea.Cmd) {
+	items := visiblePRs(m.list.Items())
+	slog.Info("User exported PR list", slog.Int("count", len(items)))
+	m.setStatus(statusInfo, fmt.Sprintf("Exporting %d PRs...", len(items)))
+	return m, ExportCmd(items, m.config)
+}
+
+func (m Model) handleExportDone(msg ExportDoneMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		slog.Error("Failed to export PR list", slog.Any("error", msg.Err))
+		m.setStatus(statusError, "Export failed: "+msg.Err.Error())
+		return m, nil
+	}
+	return m.showNotice(fmt.Sprintf("📄 Exported %d PRs to %s", msg.Count, msg.Path))
+}
+
+// visiblePRs returns the PRs among list items
+func visiblePRs(listItems []list.Item) []PRItem {
+	var items []PRItem
+	for _, listItem := range listItems {
+		if item, ok := listItem.(PRItem); ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// writeExport writes one row per PR in the given format
+func writeExport(w io.Writer, format string, items []PRItem, cfg *config.Config) error {
+	switch format {
+	case ExportCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(exportColumns); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+		for _, item := range items {
+			if err := cw.Write(exportRow(item, cfg)); err != nil {
+				return fmt.Errorf("failed to write export: %w", err)
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+		return nil
+
+	case ExportMarkdown:
+		var out strings.Builder
+		out.WriteString("| " + strings.Join(exportColumns, " | ") + " |\n")
+		out.WriteString(strings.Repeat("| --- ", len(exportColumns)) + "|\n")
+		for _, item := range items {
+			row := exportRow(item, cfg)
+			for i, cell := range row {
+				row[i] = strings.ReplaceAll(cell, "|", `\|`)
+			}
+			out.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		}
+		if _, err := io.WriteString(w, out.String()); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown export format %q (want csv or markdown)", format)
+}
+
+// exportRow returns the cells for one PR, "-" where data is missing
+func exportRow(item PRItem, cfg *config.Config) []string {
+	additions, deletions, files := "-", "-", "-"
+	if stats := item.DiffStats; stats != nil {
+		additions, deletions, files = strconv.Itoa(stats.Additions), strconv.Itoa(stats.Deletions), strconv.Itoa(stats.Files)
+	}
+	reviews := "-"
+	if item.Reviews != nil {
+		reviews = strconv.Itoa(len(item.Reviews))
+	}
+	age := "-"
+	if d := item.Age(); d > 0 {
+		age = formatAge(d)
+	}
+	recommendation, risk := "-", "-"
+	if item.AIAnalysis != nil {
+		recommendation, risk = string(item.AIAnalysis.Recommendation), item.AIAnalysis.RiskLevel
+	}
+
+	return []string{
+		fmt.Sprintf("%s/%s#%d", item.PR.Owner, item.PR.Repo, item.PR.Number),
+		item.PR.Title,
+		fmt.Sprintf("https://github.com/%s/%s/pull/%d", item.PR.Owner, item.PR.Repo, item.PR.Number),
+		item.PR.GetAuthor(),
+		age,
+		watchStatus(item, cfg),
+		watchChecks(item),
+		additions, deletions, files,
+		reviews,
+		recommendation, risk,
+	}
+}
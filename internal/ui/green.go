@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/policy"
+)
+
+// greenPollInterval is how often checks are refetched for PRs waiting to be
+// approved when green
+const greenPollInterval = 30 * time.Second
+
+// GreenTickMsg triggers a check status refresh for PRs waiting to be approved
+// when green
+type GreenTickMsg struct{}
+
+// GreenTickCmd schedules the next check status poll
+func GreenTickCmd() tea.Cmd {
+	return tea.Tick(greenPollInterval, func(time.Time) tea.Msg {
+		return GreenTickMsg{}
+	})
+}
+
+// RefreshCheckStatusCmd fetches a PR's check status, bypassing the cache
+func RefreshCheckStatusCmd(ctx context.Context, pr *github.PullRequest, prID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := pr.CallContext(ctx, 10*time.Second)
+		defer cancel()
+
+		status, err := pr.RefreshCheckStatus(ctx)
+		return CheckStatusLoadedMsg{PRID: prID, Status: status, Err: err}
+	}
+}
+
+// waitingForGreen reports whether any PR is waiting to be approved when green
+func (m Model) waitingForGreen() bool {
+	for _, item := range m.items {
+		if item.ApproveWhenGreen {
+			return true
+		}
+	}
+	return false
+}
+
+// handleApproveWhenGreen records that the selected PR should be approved once
+// its checks pass, or cancels that if it was already recorded
+func (m Model) handleApproveWhenGreen() (Model, tea.Cmd) {
+	prItem, ok := m.list.SelectedItem().(PRItem)
+	if !ok {
+		return m, nil
+	}
+
+	if prItem.ApproveWhenGreen {
+		slog.Info("User cancelled approve when green", slog.Any("pr", prItem.PR))
+		m = m.updatePRByID(prItem.ID, func(item *PRItem) { item.ApproveWhenGreen = false })
+		m = m.updateVisibleItems()
+		m.setStatus(statusInfo, fmt.Sprintf("No longer approving PR #%d when green", prItem.PR.Number))
+		return m, nil
+	}
+
+	if m.github.Health().Degraded {
+		m.setStatus(statusInfo, "Approvals are disabled while GitHub is unavailable")
+		return m, nil
+	}
+	if prItem.Approved {
+		m.setStatus(statusInfo, "PR already approved")
+		return m, nil
+	}
+	if reason := m.approvalBlocked(prItem); reason != "" {
+		m.setStatus(statusInfo, reason)
+		return m, nil
+	}
+	if reason := m.policyBlocked(policy.Approve, prItem); reason != "" {
+		m.setStatus(statusError, reason)
+		return m, nil
+	}
+
+	slog.Info("User chose approve when green", slog.Any("pr", prItem.PR))
+	polling := m.waitingForGreen()
+	m = m.updatePRByID(prItem.ID, func(item *PRItem) { item.ApproveWhenGreen = true })
+	m = m.updateVisibleItems()
+	m.setStatus(statusInfo, fmt.Sprintf("⏳ Will approve PR #%d once its checks pass", prItem.PR.Number))
+
+	cmds := []tea.Cmd{RefreshCheckStatusCmd(m.prContext(prItem.ID), prItem.PR, prItem.ID)}
+	if !polling {
+		cmds = append(cmds, GreenTickCmd())
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handleGreenTick refetches checks for PRs waiting to be approved when green,
+// and stops polling once none are
+func (m Model) handleGreenTick() (Model, tea.Cmd) {
+	if !m.waitingForGreen() {
+		return m, nil
+	}
+
+	cmds := []tea.Cmd{GreenTickCmd()}
+	if !m.github.Health().Degraded {
+		for _, item := range m.items {
+			if item.ApproveWhenGreen {
+				cmds = append(cmds, RefreshCheckStatusCmd(m.prContext(item.ID), item.PR, item.ID))
+			}
+		}
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// approveIfGreen approves a PR waiting to be approved when green once its
+// checks pass, and gives up if they fail
+func (m Model) approveIfGreen(prID int64) (Model, tea.Cmd) {
+	item := m.findPRByID(prID)
+	if item == nil || !item.ApproveWhenGreen || item.CheckStatus == nil {
+		return m, nil
+	}
+
+	var reason string
+	switch item.CheckStatus.State {
+	case "success":
+		reason = m.approvalBlocked(*item)
+		if reason == "" {
+			reason = m.policyBlocked(policy.Approve, *item)
+		}
+	case "failure", "error":
+		reason = "checks failed"
+	default:
+		return m, nil // Still running
+	}
+	if item.Approved {
+		reason = "already approved"
+	}
+
+	m = m.updatePRByID(prID, func(item *PRItem) { item.ApproveWhenGreen = false })
+	m = m.updateVisibleItems()
+	if reason != "" {
+		slog.Info("Not approving when green", slog.Any("pr", item.PR), slog.String("reason", reason))
+		return m.showNotice(fmt.Sprintf("❌ Not approving PR #%d: %s", item.PR.Number, reason))
+	}
+
+	slog.Info("Checks passed, approving", slog.Any("pr", item.PR))
+	m, cmd := m.showNotice(fmt.Sprintf("✅ Checks passed on PR #%d, approving", item.PR.Number))
+	return m, tea.Batch(cmd, ApprovePRCmd(m.ctx, item.PR, item.ID))
+}
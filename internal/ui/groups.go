@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/agent"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/policy"
+)
+
+// bumpKey returns the dependency group key for a PR, or "" if its title
+// isn't a dependency bump. Groups don't span queues.
+func bumpKey(item PRItem) string {
+	if bump, ok := item.Bump(); ok {
+		return fmt.Sprintf("%d:%s", item.Queue, bump.Key())
+	}
+	return ""
+}
+
+// annotateGroups records on each item how many PRs make the same bump
+func (m Model) annotateGroups() {
+	counts := make(map[string]int)
+	for _, item := range m.items {
+		if key := bumpKey(item); key != "" {
+			counts[key]++
+		}
+	}
+
+	for i := range m.items {
+		m.items[i].GroupSize = counts[bumpKey(m.items[i])]
+	}
+}
+
+// dependencyGroup returns the indices of the PRs making the same bump as the
+// given PR, including it, or nil if it isn't part of a group
+func (m Model) dependencyGroup(id int64) []int {
+	item := m.findPRByID(id)
+	if item == nil {
+		return nil
+	}
+	key := bumpKey(*item)
+	if key == "" {
+		return nil
+	}
+
+	var members []int
+	for i := range m.items {
+		if bumpKey(m.items[i]) == key {
+			members = append(members, i)
+		}
+	}
+	if len(members) < 2 {
+		return nil
+	}
+	return members
+}
+
+// groupAdjacent moves the members of each dependency group next to the first
+// of them, keeping the order of everything else
+func groupAdjacent(items []list.Item) []list.Item {
+	byKey := make(map[string][]list.Item)
+	for _, listItem := range items {
+		if item, ok := listItem.(PRItem); ok && item.GroupSize > 1 {
+			key := bumpKey(item)
+			byKey[key] = append(byKey[key], listItem)
+		}
+	}
+	if len(byKey) == 0 {
+		return items
+	}
+
+	ordered := make([]list.Item, 0, len(items))
+	placed := make(map[string]bool)
+	for _, listItem := range items {
+		item, ok := listItem.(PRItem)
+		if !ok || item.GroupSize < 2 {
+			ordered = append(ordered, listItem)
+			continue
+		}
+
+		key := bumpKey(item)
+		if !placed[key] {
+			ordered = append(ordered, byKey[key]...)
+			placed[key] = true
+		}
+	}
+	return ordered
+}
+
+// analysisVisible reports whether the PR, or any PR sharing its group
+// analysis, is currently shown in the list
+func (m Model) analysisVisible(id int64) bool {
+	members := m.dependencyGroup(id)
+	if members == nil {
+		return m.isVisible(id)
+	}
+
+	for _, i := range members {
+		if m.isVisible(m.items[i].ID) {
+			return true
+		}
+	}
+	return false
+}
+
+// analysisGroupIDs returns the IDs of the PRs sharing the PR's AI analysis
+func (m Model) analysisGroupIDs(id int64) []int64 {
+	members := m.dependencyGroup(id)
+	if members == nil {
+		return []int64{id}
+	}
+
+	ids := make([]int64, 0, len(members))
+	for _, i := range members {
+		ids = append(ids, m.items[i].ID)
+	}
+	return ids
+}
+
+// triggerGroupAnalysis runs one AI analysis for a dependency group once every
+// member has finished loading. The first member with complete data leads;
+// the others are passed along as context and receive the same result.
+func (m Model) triggerGroupAnalysis(members []int) tea.Cmd {
+	leader := -1
+	for _, i := range members {
+		item := m.items[i]
+		if !item.dataSettled() {
+			slog.Debug("Group analysis waiting for member data", slog.Any("pr", item.PR))
+			return nil
+		}
+		if leader < 0 && item.readyForAnalysis() {
+			leader = i
+		}
+	}
+	if leader < 0 {
+		return nil
+	}
+
+	item := &m.items[leader]
+	if !m.analysisVisible(item.ID) {
+		slog.Debug("Skipping AI analysis for hidden group", slog.Any("pr", item.PR))
+		return skippedAnalysisCmd(item.ID)
+	}
+
+	var group []agent.GroupMember
+	for _, i := range members {
+		if i == leader {
+			continue
+		}
+		member := m.items[i]
+		// Members from repos the AI may not see aren't mentioned to it
+		if !m.aiAgent.RepoAllowed(member.PR.Owner, member.PR.Repo) {
+			continue
+		}
+		ciStatus := "unknown"
+		if member.CheckStatus != nil {
+			ciStatus = member.CheckStatus.State
+		}
+		group = append(group, agent.GroupMember{
+			Repo:     member.PR.Owner + "/" + member.PR.Repo,
+			Number:   member.PR.Number,
+			CIStatus: ciStatus,
+		})
+	}
+
+	slog.Debug("Triggering group AI analysis", slog.Any("pr", item.PR), slog.Int("members", len(members)))
+	return FetchAIAnalysisCmd(m.prContext(item.ID), m.aiAgent, item.Snapshot, item.Issues,
+		github.RequiresSignedCommits(item.PR.Owner, item.PR.Repo, m.config.GitHub.RequireSigned), group, m.sizeBudget(), m.config.Review.TemplatePrompt, item.ID, m.config.AI.AnalysisTimeout)
+}
+
+// handleApproveGroup approves every PR in the selected PR's dependency group
+func (m Model) handleApproveGroup() (Model, tea.Cmd) {
+	if m.github.Health().Degraded {
+		m.setStatus(statusInfo, "Approvals are disabled while GitHub is unavailable")
+		return m, nil
+	}
+
+	prItem, ok := m.list.SelectedItem().(PRItem)
+	if !ok {
+		slog.Debug("Approve group action: no PR selected")
+		return m, nil
+	}
+
+	members := m.dependencyGroup(prItem.ID)
+	if members == nil {
+		m.setStatus(statusInfo, "PR is not part of a dependency update group")
+		return m, nil
+	}
+
+	var cmds []tea.Cmd
+	for _, i := range members {
+		member := m.items[i]
+		if member.Approved {
+			continue
+		}
+		if reason := m.approvalBlocked(member); reason != "" {
+			m.setStatus(statusInfo, reason)
+			return m, nil
+		}
+		if reason := m.policyBlocked(policy.Approve, member); reason != "" {
+			m.setStatus(statusError, reason)
+			return m, nil
+		}
+		cmds = append(cmds, ApprovePRCmd(m.ctx, member.PR, member.ID))
+	}
+	if len(cmds) == 0 {
+		m.setStatus(statusInfo, "Group already approved")
+		return m, nil
+	}
+
+	bump, _ := prItem.Bump()
+	slog.Info("User initiated group approval", slog.String("bump", bump.Key()), slog.Int("prs", len(cmds)))
+	m.setStatus(statusInfo, fmt.Sprintf("Approving %d %s for %s...", len(cmds), pluralPRs(len(cmds)), bump.Key()))
+	return m, tea.Batch(cmds...)
+}
+
+// groupDetailContent lists the other members of a PR's dependency group for
+// the details popup
+func (m Model) groupDetailContent(item PRItem) string {
+	members := m.dependencyGroup(item.ID)
+	if members == nil {
+		return ""
+	}
+
+	var content strings.Builder
+	bump, _ := item.Bump()
+	content.WriteString(fmt.Sprintf("## 📦 Dependency Group: %s\n\n", bump.Key()))
+	for _, i := range members {
+		member := m.items[i]
+		if member.ID == item.ID {
+			continue
+		}
+		status := ""
+		if member.Approved {
+			status = " ✅"
+		}
+		content.WriteString(fmt.Sprintf("- %s/%s #%d%s\n", member.PR.Owner, member.PR.Repo, member.PR.Number, status))
+	}
+	content.WriteString("\nPress **A** to approve the whole group.\n\n")
+	return content.String()
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/agent"
+	"github.com/kennyp/speedrun/pkg/github"
+)
+
+// cachedPR is what's cached about one PR
+type cachedPR struct {
+	PRID     int64
+	Data     github.CachedData
+	Analysis *agent.Analysis // nil unless the PR is waiting on one
+}
+
+// CachedDataLoadedMsg is sent when whatever's cached about a queue's PRs has
+// been read
+type CachedDataLoadedMsg struct {
+	PRs []cachedPR
+}
+
+// LoadCachedDataCmd reads what's cached about items, however old, so the list
+// can show it while fresh data loads
+func LoadCachedDataCmd(items []PRItem) tea.Cmd {
+	type load struct {
+		pr     *github.PullRequest
+		id     int64
+		withAI bool
+	}
+	loads := make([]load, len(items))
+	for i, item := range items {
+		loads[i] = load{item.PR, item.ID, item.LoadingAI}
+	}
+
+	return func() tea.Msg {
+		start := time.Now()
+		var msg CachedDataLoadedMsg
+		for _, l := range loads {
+			cached := cachedPR{PRID: l.id, Data: l.pr.GetStaleData()}
+			if l.withAI {
+				var analysis agent.Analysis
+				if _, err := l.pr.GetStaleAIAnalysis(&analysis); err == nil {
+					cached.Analysis = &analysis
+				}
+			}
+			msg.PRs = append(msg.PRs, cached)
+		}
+		slog.Debug("Cached PR data loaded", slog.Int("count", len(msg.PRs)), slog.Duration("duration", time.Since(start)))
+		return msg
+	}
+}
+
+// handleCachedDataLoaded shows cached data, marked stale, for whatever is
+// still loading
+func (m Model) handleCachedDataLoaded(msg CachedDataLoadedMsg) Model {
+	hydrated := 0
+	for _, cached := range msg.PRs {
+		data := cached.Data
+		m = m.updatePRByID(cached.PRID, func(item *PRItem) {
+			if item.LoadingDiff && item.DiffStats == nil && data.DiffStats != nil {
+				item.DiffStats, item.StaleDiff = data.DiffStats, true
+				item.OverBudget = m.sizeBudget().Exceeded(data.DiffStats.Additions+data.DiffStats.Deletions, data.DiffStats.Files)
+			}
+			if item.LoadingChecks && item.CheckStatus == nil && data.CheckStatus != nil {
+				item.CheckStatus, item.StaleChecks = data.CheckStatus, true
+			}
+			if item.LoadingReviews && item.Reviews == nil && data.Reviews != nil {
+				item.setReviews(data.Reviews, m.username)
+				item.StaleReviews = true
+			}
+			if item.LoadingAI && item.AIAnalysis == nil && cached.Analysis != nil {
+				item.AIAnalysis, item.StaleAI = cached.Analysis, true
+			}
+			if item.StaleDiff || item.StaleChecks || item.StaleReviews || item.StaleAI {
+				hydrated++
+			}
+		})
+	}
+	if hydrated > 0 {
+		slog.Info("Showing cached data while PRs load", slog.Int("count", hydrated))
+	}
+	return m.updateVisibleItems()
+}
+
+// staleMark flags data shown from the cache until it's refreshed
+func staleMark(stale bool) string {
+	if stale {
+		return " ⏱"
+	}
+	return ""
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/cache"
+	"github.com/kennyp/speedrun/pkg/github"
+)
+
+// InstancesMsg lists the other speedrun instances sharing the cache
+type InstancesMsg struct {
+	Others []cache.Instance
+	Err    error
+}
+
+// HeartbeatCmd tells other instances sharing the cache, after delay, that
+// this one is still running
+func HeartbeatCmd(client *github.Client, delay time.Duration) tea.Cmd {
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		others, err := client.Heartbeat()
+		return InstancesMsg{Others: others, Err: err}
+	})
+}
+
+// handleInstances warns when another instance starts sharing the cache, and
+// says when the last one has gone
+func (m Model) handleInstances(msg InstancesMsg) (Model, tea.Cmd) {
+	next := HeartbeatCmd(m.github, cache.InstanceHeartbeat)
+	if msg.Err != nil {
+		slog.Debug("Cache heartbeat failed", slog.Any("error", msg.Err))
+		return m, next
+	}
+
+	before := m.otherInstances
+	m.otherInstances = len(msg.Others)
+	switch {
+	case m.otherInstances > before:
+		other := msg.Others[len(msg.Others)-1]
+		slog.Warn("Another speedrun instance is sharing the cache", slog.Int("pid", other.PID), slog.String("host", other.Host), slog.Int("instances", m.otherInstances))
+		m.setStatus(statusError, fmt.Sprintf("⚠️ Another speedrun (pid %d on %s) shares this cache; AI analyses are split between them", other.PID, other.Host))
+	case m.otherInstances == 0 && before > 0:
+		slog.Info("No other speedrun instances share the cache")
+		m.setStatus(statusInfo, "No other speedrun shares this cache")
+	}
+	return m, next
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+)
+
+// maxLanguageBadges is how many languages a list row names
+const maxLanguageBadges = 3
+
+// languageBadges names a PR's main languages for its list row
+func languageBadges(languages []string) string {
+	if len(languages) <= maxLanguageBadges {
+		return strings.Join(languages, ", ")
+	}
+	return strings.Join(languages[:maxLanguageBadges], ", ") + ", …"
+}
+
+// nextLanguageFilter cycles the language filter through "all" and the
+// languages the loaded PRs change, in order of how many PRs change them
+func (m Model) nextLanguageFilter() string {
+	counts := make(map[string]int)
+	var languages []string
+	for _, item := range m.items {
+		for _, language := range item.Languages() {
+			if counts[language] == 0 {
+				languages = append(languages, language)
+			}
+			counts[language]++
+		}
+	}
+	slices.SortStableFunc(languages, func(a, b string) int { return counts[b] - counts[a] })
+
+	options := append([]string{"all"}, languages...)
+	i := slices.Index(options, m.filterLanguage)
+	return options[(i+1)%len(options)]
+}
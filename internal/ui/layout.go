@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Rows of PR details shown under the list. Two fit the location and size;
+// more add checks, labels and the AI's reasoning.
+const (
+	defaultDetailRows = 2
+	minDetailRows     = 1
+)
+
+// layoutSaveDelay lets a run of resizes settle before the layout is saved
+const layoutSaveDelay = time.Second
+
+// LayoutChangedMsg saves the layout unless it changed again since
+type LayoutChangedMsg struct {
+	Seq int
+}
+
+// layoutPrefs are the layout choices kept between sessions
+type layoutPrefs struct {
+	DetailRows int `json:"detail_rows"`
+}
+
+// loadLayout reads saved layout preferences, falling back to the defaults
+func loadLayout(path string) layoutPrefs {
+	prefs := layoutPrefs{DetailRows: defaultDetailRows}
+	if path == "" {
+		return prefs
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Warn("Failed to read UI state", slog.String("path", path), slog.Any("error", err))
+		}
+		return prefs
+	}
+	if err := json.Unmarshal(data, &prefs); err != nil {
+		slog.Warn("Failed to parse UI state", slog.String("path", path), slog.Any("error", err))
+		return layoutPrefs{DetailRows: defaultDetailRows}
+	}
+	prefs.DetailRows = max(prefs.DetailRows, minDetailRows)
+	return prefs
+}
+
+// saveLayoutCmd writes layout preferences for the next session
+func saveLayoutCmd(path string, prefs layoutPrefs) tea.Cmd {
+	if path == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		data, err := json.Marshal(prefs)
+		if err == nil {
+			if err = os.MkdirAll(filepath.Dir(path), 0755); err == nil {
+				err = os.WriteFile(path, data, 0644)
+			}
+		}
+		if err != nil {
+			slog.Warn("Failed to save UI state", slog.String("path", path), slog.Any("error", err))
+		}
+		return nil
+	}
+}
+
+// maxDetailRows leaves at least half of what's left after the status, help
+// and log and message panes to the list
+func (m Model) maxDetailRows() int {
+	return max(minDetailRows, (m.height-2-m.logPaneHeight()-m.statusHistoryHeight())/2)
+}
+
+// detailPaneRows returns the rows the detail pane gets, which is the preferred
+// size unless the terminal is too short for it
+func (m Model) detailPaneRows() int {
+	return min(m.detailRows, m.maxDetailRows())
+}
+
+// handleResizeDetails grows or shrinks the detail pane by delta rows and
+// saves the new size
+func (m Model) handleResizeDetails(delta int) (Model, tea.Cmd) {
+	rows := min(max(m.detailPaneRows()+delta, minDetailRows), m.maxDetailRows())
+	if rows == m.detailPaneRows() {
+		return m, nil
+	}
+
+	m.detailRows = rows
+	m.resizeList()
+	slog.Debug("Detail pane resized", slog.Int("rows", rows))
+
+	m.layoutSeq++
+	seq := m.layoutSeq
+	return m, tea.Tick(layoutSaveDelay, func(time.Time) tea.Msg {
+		return LayoutChangedMsg{Seq: seq}
+	})
+}
+
+// handleLayoutChanged saves the layout once resizing has stopped
+func (m Model) handleLayoutChanged(msg LayoutChangedMsg) (Model, tea.Cmd) {
+	if msg.Seq != m.layoutSeq {
+		return m, nil
+	}
+	return m, saveLayoutCmd(m.config.UI.StatePath, layoutPrefs{DetailRows: m.detailRows})
+}
+
+// renderDetailPane renders the selected PR's details into exactly the detail
+// pane's rows
+func (m Model) renderDetailPane() string {
+	var lines []string
+	if item, ok := m.list.SelectedItem().(PRItem); ok {
+		width := m.width
+		lines = m.detailLines(item, width)
+	}
+
+	rows := make([]string, m.detailRows)
+	copy(rows, lines)
+	return "\n" + strings.Join(rows, "\n")
+}
+
+// detailLines lists what's known about a PR, most important first, each line
+// cut to width
+func (m Model) detailLines(item PRItem, width int) []string {
+	if item.LoadingDiff || item.LoadingChecks || item.LoadingReviews || item.LoadingAI {
+		return []string{m.label("💭 Loading PR details...")}
+	}
+
+	lines := []string{fmt.Sprintf("📍 %s/%s#%d", item.PR.Owner, item.PR.Repo, item.PR.Number)}
+	if item.DiffStats != nil && item.CheckStatus != nil {
+		changes := fmt.Sprintf("💬 %d additions, %d deletions across %d files",
+			item.DiffStats.Additions, item.DiffStats.Deletions, item.DiffStats.Files)
+		if excluded := len(item.DiffStats.Excluded); excluded > 0 {
+			changes += fmt.Sprintf(" (🧱 %d binary or generated)", excluded)
+		}
+		lines = append(lines, changes)
+	}
+	if item.CheckStatus != nil && item.CheckStatus.Description != "" {
+		lines = append(lines, "🔧 "+item.CheckStatus.Description)
+	}
+	if labels := item.PR.GetLabels(); len(labels) > 0 {
+		lines = append(lines, "📋 "+strings.Join(labels, ", "))
+	}
+
+	cut := lipgloss.NewStyle().MaxWidth(max(width, 1))
+	for i, line := range lines {
+		lines[i] = cut.Render(m.label(line))
+	}
+
+	// The reasoning wraps over whatever rows are left
+	if item.AIAnalysis != nil && item.AIAnalysis.Reasoning != "" {
+		reasoning := m.label(fmt.Sprintf("🤖 %s: %s", item.AIAnalysis.Recommendation, item.AIAnalysis.Reasoning))
+		wrapped := lipgloss.NewStyle().Width(max(width, 1)).Render(reasoning)
+		lines = append(lines, strings.Split(wrapped, "\n")...)
+	}
+	return lines
+}
+
+// closePopup closes the popup and lets go of what was loaded for it. Check
+// logs and timelines can be long, and the popup fetches them again, mostly
+// from the cache, when it's next opened.
+func (m *Model) closePopup() {
+	m.showPopup = false
+	m.showTimeline = false
+	m.showErrors = false
+	m.popupScrollPos = 0
+	m.popupContent = ""
+	clear(m.checkLogs)
+	clear(m.timelines)
+}
+
+// popupSize returns the popup's width and the rows of content it shows
+func (m Model) popupSize() (width, rows int) {
+	// 80% of the screen, to leave some background visible
+	width = min(m.width*8/10, 100)
+	height := min(m.height*8/10, 35)
+	return width, max(height-4, 1) // Border (2) and padding (2)
+}
+
+// popupLines returns the popup content formatted to the popup's width
+func (m Model) popupLines() []string {
+	width, _ := m.popupSize()
+	return strings.Split(m.formatPopupContent(m.label(m.popupContent), max(width-6, 1)), "\n")
+}
+
+// maxPopupScroll returns the scroll position that shows the popup's last line
+func (m Model) maxPopupScroll() int {
+	_, rows := m.popupSize()
+	return max(0, len(m.popupLines())-rows)
+}
+
+// scrollPopup scrolls the popup to pos, kept within its content
+func (m *Model) scrollPopup(pos int) {
+	m.popupScrollPos = min(max(pos, 0), m.maxPopupScroll())
+}
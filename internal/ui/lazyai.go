@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/config"
+	"github.com/kennyp/speedrun/pkg/github"
+)
+
+// aiLoadState returns whether a PR's AI analysis runs as soon as its data
+// loads, or is deferred until the PR is selected. My own PRs aren't mine to
+// review, and PRs from repos the AI may not see aren't sent to it, so they
+// get neither.
+func (m Model) aiLoadState(pr *github.PullRequest, authored bool) (loading, deferred bool) {
+	if m.aiAgent == nil || authored || !m.aiAgent.RepoAllowed(pr.Owner, pr.Repo) {
+		return false, false
+	}
+	if m.config.AI.Trigger == config.AITriggerOnSelect {
+		return false, true
+	}
+	return true, false
+}
+
+// analyzeSelected starts the deferred analysis of the selected PR, along with
+// the rest of its dependency group, which shares it
+func (m Model) analyzeSelected() (Model, tea.Cmd) {
+	item, ok := m.list.SelectedItem().(PRItem)
+	if !ok {
+		return m, nil
+	}
+	if current := m.findPRByID(item.ID); current == nil || !current.AIDeferred {
+		return m, nil
+	}
+
+	slog.Debug("Analyzing selected PR", slog.Any("pr", item.PR))
+	for _, id := range m.analysisGroupIDs(item.ID) {
+		m = m.updatePRByID(id, func(item *PRItem) {
+			if item.AIDeferred {
+				item.AIDeferred = false
+				item.LoadingAI = item.AIAnalysis == nil
+			}
+		})
+	}
+	m = m.updateVisibleItems()
+	return m, m.triggerAIAnalysisIfReadyByID(item.ID)
+}
@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// logPaneRefresh is how often the log pane re-renders while open
+const logPaneRefresh = 500 * time.Millisecond
+
+// logLevels is the cycle order for the log pane level filter
+var logLevels = []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError}
+
+var (
+	logPaneStyle = lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder(), true, false, false, false).
+			BorderForeground(lipgloss.Color("240"))
+
+	logLevelStyles = map[slog.Level]lipgloss.Style{
+		slog.LevelDebug: lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+		slog.LevelInfo:  lipgloss.NewStyle().Foreground(lipgloss.Color("252")),
+		slog.LevelWarn:  lipgloss.NewStyle().Foreground(lipgloss.Color("214")),
+		slog.LevelError: errorStyle,
+	}
+)
+
+// LogTickMsg triggers a log pane refresh
+type LogTickMsg struct{}
+
+// LogTickCmd schedules the next log pane refresh
+func LogTickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return LogTickMsg{}
+	})
+}
+
+// handleToggleLogs shows or hides the log pane
+func (m Model) handleToggleLogs() (Model, tea.Cmd) {
+	if m.logs == nil {
+		m.setStatus(statusInfo, "Log capture unavailable")
+		return m, nil
+	}
+
+	m.showLogs = !m.showLogs
+	m.resizeList()
+	slog.Debug("Log pane toggled", slog.Bool("visible", m.showLogs))
+
+	if m.showLogs {
+		return m, LogTickCmd(m.tickInterval(logPaneRefresh))
+	}
+	return m, nil
+}
+
+// handleCycleLogLevel moves the log pane filter to the next level
+func (m Model) handleCycleLogLevel() (Model, tea.Cmd) {
+	for i, level := range logLevels {
+		if level == m.logLevel {
+			m.logLevel = logLevels[(i+1)%len(logLevels)]
+			return m, nil
+		}
+	}
+	m.logLevel = slog.LevelDebug
+	return m, nil
+}
+
+// handleLogTick keeps refreshing the pane while it is visible
+func (m Model) handleLogTick() (Model, tea.Cmd) {
+	if !m.showLogs {
+		return m, nil
+	}
+	return m, LogTickCmd(m.tickInterval(logPaneRefresh))
+}
+
+// logPaneHeight returns the rows reserved for the log pane, including its border
+func (m Model) logPaneHeight() int {
+	if !m.showLogs {
+		return 0
+	}
+	return max(6, m.height/3)
+}
+
+// resizeList fits the PR list around the detail pane, status bar, help and
+// log pane
+func (m *Model) resizeList() {
+	m.list.SetHeight(max(1, m.height-2-m.detailPaneRows()-m.logPaneHeight()-m.statusHistoryHeight()))
+}
+
+// renderLogPane renders the most recent captured log lines that fit
+func (m Model) renderLogPane() string {
+	width := m.width
+	rows := m.logPaneHeight() - 2 // Border and header
+
+	header := helpStyle.Render(fmt.Sprintf("Logs ≥ %s • tab: level • L: close", m.logLevel))
+
+	entries := m.logs.Entries(m.logLevel)
+	if len(entries) > rows {
+		entries = entries[len(entries)-rows:]
+	}
+
+	lines := make([]string, 0, rows)
+	for _, entry := range entries {
+		line := entry.Line
+		if width > 0 && len(line) > width {
+			line = line[:max(0, width-1)] + "…"
+		}
+		style, ok := logLevelStyles[entry.Level]
+		if !ok {
+			style = logLevelStyles[slog.LevelInfo]
+		}
+		lines = append(lines, style.Render(line))
+	}
+	for len(lines) < rows {
+		lines = append(lines, "")
+	}
+
+	return logPaneStyle.Width(width).Render(header + "\n" + strings.Join(lines, "\n"))
+}
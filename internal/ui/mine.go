@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/github"
+)
+
+// mineQuery finds the open PRs the user opened, for the My PRs queue
+const mineQuery = "is:open is:pr author:@me"
+
+// RequestedReviewersLoadedMsg is sent when the reviewers still requested on
+// one of my PRs have been fetched
+type RequestedReviewersLoadedMsg struct {
+	PRID      int64
+	Reviewers []string
+	Err       error
+}
+
+// ReviewRequestedMsg is sent when reviews have been re-requested on my PR
+type ReviewRequestedMsg struct {
+	PRID  int64
+	Users []string
+	Err   error
+}
+
+// ReviewersNudgedMsg is sent when a nudge comment has been posted on my PR
+type ReviewersNudgedMsg struct {
+	PRID  int64
+	Users []string
+	Err   error
+}
+
+// FetchRequestedReviewersCmd fetches who still owes a PR a review
+func FetchRequestedReviewersCmd(ctx context.Context, pr *github.PullRequest, prID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := pr.CallContext(ctx, 10*time.Second)
+		defer cancel()
+
+		reviewers, err := pr.GetRequestedReviewers(ctx)
+		return RequestedReviewersLoadedMsg{PRID: prID, Reviewers: reviewers, Err: err}
+	}
+}
+
+// RequestReviewsCmd asks users to review a PR again
+func RequestReviewsCmd(ctx context.Context, pr *github.PullRequest, users []string, prID int64) tea.Cmd {
+	return func() tea.Msg {
+		err := pr.RequestReviews(ctx, users)
+		return ReviewRequestedMsg{PRID: prID, Users: users, Err: err}
+	}
+}
+
+// NudgeReviewersCmd mentions pending reviewers in a comment on a PR
+func NudgeReviewersCmd(ctx context.Context, pr *github.PullRequest, users []string, prID int64) tea.Cmd {
+	return func() tea.Msg {
+		err := pr.AddComment(ctx, nudgeComment(users))
+		return ReviewersNudgedMsg{PRID: prID, Users: users, Err: err}
+	}
+}
+
+// nudgeComment is the comment that reminds reviewers about a PR
+func nudgeComment(users []string) string {
+	mentions := make([]string, len(users))
+	for i, user := range users {
+		mentions[i] = "@" + user
+	}
+	return fmt.Sprintf("Friendly ping %s: this is waiting on your review when you have a moment. Thanks!", strings.Join(mentions, " "))
+}
+
+// reviewerStates is where each reviewer stands on the PR
+func (i PRItem) reviewerStates() []github.ReviewerState {
+	return github.ReviewerStates(i.Reviews, i.RequestedReviewers, i.PR.GetAuthor())
+}
+
+// reviewersIn returns the reviewers in any of the given states
+func (i PRItem) reviewersIn(states ...string) []string {
+	var users []string
+	for _, reviewer := range i.reviewerStates() {
+		for _, state := range states {
+			if reviewer.State == state {
+				users = append(users, reviewer.User)
+			}
+		}
+	}
+	return users
+}
+
+// authoredStatus sums up the reviews of my PR. Requested changes come
+// first, then reviews still pending, then approval. It's "" before anyone is
+// asked to review.
+func (i PRItem) authoredStatus() string {
+	states := i.reviewerStates()
+	for _, want := range []string{"CHANGES_REQUESTED", github.ReviewPending, "APPROVED"} {
+		for _, reviewer := range states {
+			if reviewer.State == want {
+				return want
+			}
+		}
+	}
+	if len(states) > 0 {
+		return "COMMENTED"
+	}
+	return ""
+}
+
+// reviewerSummary lists each reviewer with their state, e.g. "✅ alice, ⏳ bob"
+func (i PRItem) reviewerSummary() string {
+	var parts []string
+	for _, reviewer := range i.reviewerStates() {
+		parts = append(parts, getReviewStateEmoji(reviewer.State)+" "+reviewer.User)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func getReviewStateEmoji(state string) string {
+	switch state {
+	case "APPROVED":
+		return "✅"
+	case "CHANGES_REQUESTED":
+		return "🛑"
+	case github.ReviewPending:
+		return "⏳"
+	case "DISMISSED":
+		return "⚠️"
+	default:
+		return "🗨"
+	}
+}
+
+// handleRerequestReview asks reviewers who requested changes or only
+// commented to review my PR again
+func (m Model) handleRerequestReview() (Model, tea.Cmd) {
+	prItem, ok := m.list.SelectedItem().(PRItem)
+	if !ok {
+		return m, nil
+	}
+	if !prItem.Authored {
+		m.setStatus(statusInfo, "Re-requesting review is only available for your own PRs")
+		return m, nil
+	}
+	if m.github.Health().Degraded {
+		m.setStatus(statusInfo, "Re-requesting review is disabled while GitHub is unavailable")
+		return m, nil
+	}
+
+	users := prItem.reviewersIn("CHANGES_REQUESTED", "COMMENTED")
+	if len(users) == 0 {
+		m.setStatus(statusInfo, fmt.Sprintf("No reviewers to re-request on PR #%d", prItem.PR.Number))
+		return m, nil
+	}
+
+	slog.Info("User re-requested review", slog.Any("pr", prItem.PR), slog.Any("users", users))
+	m.setStatus(statusInfo, fmt.Sprintf("Re-requesting review from %s...", strings.Join(users, ", ")))
+	return m, RequestReviewsCmd(m.ctx, prItem.PR, users, prItem.ID)
+}
+
+// handleNudgeReviewers comments on my PR to remind pending reviewers
+func (m Model) handleNudgeReviewers() (Model, tea.Cmd) {
+	prItem, ok := m.list.SelectedItem().(PRItem)
+	if !ok {
+		return m, nil
+	}
+	if !prItem.Authored {
+		m.setStatus(statusInfo, "Nudging reviewers is only available for your own PRs")
+		return m, nil
+	}
+	if m.github.Health().Degraded {
+		m.setStatus(statusInfo, "Nudging reviewers is disabled while GitHub is unavailable")
+		return m, nil
+	}
+
+	users := prItem.reviewersIn(github.ReviewPending)
+	if len(users) == 0 {
+		m.setStatus(statusInfo, fmt.Sprintf("No pending reviewers to nudge on PR #%d", prItem.PR.Number))
+		return m, nil
+	}
+
+	slog.Info("User nudged reviewers", slog.Any("pr", prItem.PR), slog.Any("users", users))
+	m.setStatus(statusInfo, fmt.Sprintf("Nudging %s...", strings.Join(users, ", ")))
+	return m, NudgeReviewersCmd(m.ctx, prItem.PR, users, prItem.ID)
+}
+
+func (m Model) handleRequestedReviewersLoaded(msg RequestedReviewersLoadedMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		// The reviews alone still show who has weighed in
+		slog.Debug("Requested reviewers failed", slog.Int64("prID", msg.PRID), slog.Any("error", msg.Err))
+		return m, nil
+	}
+	m = m.updatePRByID(msg.PRID, func(item *PRItem) {
+		item.RequestedReviewers = msg.Reviewers
+	})
+	return m.updateVisibleItems(), nil
+}
+
+func (m Model) handleReviewRequested(msg ReviewRequestedMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		slog.Error("Failed to re-request review", slog.Int64("prID", msg.PRID), slog.Any("error", msg.Err))
+		m.setError("Failed to re-request review", msg.Err)
+		return m, nil
+	}
+
+	item := m.findPRByID(msg.PRID)
+	if item == nil {
+		return m, nil
+	}
+	users := strings.Join(msg.Users, ", ")
+	m.setStatus(statusSuccess, m.actionStatus(fmt.Sprintf("🔄 PR #%d: review re-requested from %s", item.PR.Number, users),
+		fmt.Sprintf("would re-request review of PR #%d from %s", item.PR.Number, users)))
+	return m.refetchReviews(msg.PRID)
+}
+
+func (m Model) handleReviewersNudged(msg ReviewersNudgedMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		slog.Error("Failed to nudge reviewers", slog.Int64("prID", msg.PRID), slog.Any("error", msg.Err))
+		m.setError("Failed to nudge reviewers", msg.Err)
+		return m, nil
+	}
+
+	number := 0
+	if item := m.findPRByID(msg.PRID); item != nil {
+		number = item.PR.Number
+	}
+	users := strings.Join(msg.Users, ", ")
+	m.setStatus(statusSuccess, m.actionStatus(fmt.Sprintf("👋 PR #%d: nudged %s", number, users),
+		fmt.Sprintf("would comment on PR #%d to nudge %s", number, users)))
+	return m, nil
+}
+
+// refetchReviews reloads a PR's reviews, and with them its requested reviewers
+func (m Model) refetchReviews(prID int64) (Model, tea.Cmd) {
+	item := m.findPRByID(prID)
+	if item == nil {
+		return m, nil
+	}
+	pr := item.PR
+	m = m.updatePRByID(prID, func(item *PRItem) {
+		item.LoadingReviews = true
+	})
+	return m, FetchReviewsCmd(m.prContext(prID), m.github, pr, m.username, prID)
+}
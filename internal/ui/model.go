@@ -2,8 +2,10 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
 	"slices"
 	"strings"
 	"sync/atomic"
@@ -16,8 +18,21 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/kennyp/speedrun/pkg/agent"
+	"github.com/kennyp/speedrun/pkg/checklist"
+	"github.com/kennyp/speedrun/pkg/classify"
+	"github.com/kennyp/speedrun/pkg/codeowners"
 	"github.com/kennyp/speedrun/pkg/config"
+	"github.com/kennyp/speedrun/pkg/freeze"
 	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/history"
+	"github.com/kennyp/speedrun/pkg/hooks"
+	"github.com/kennyp/speedrun/pkg/logbuffer"
+	"github.com/kennyp/speedrun/pkg/metrics"
+	"github.com/kennyp/speedrun/pkg/plugin"
+	"github.com/kennyp/speedrun/pkg/policy"
+	"github.com/kennyp/speedrun/pkg/prtemplate"
+	"github.com/kennyp/speedrun/pkg/remind"
+	"github.com/kennyp/speedrun/pkg/tracker"
 )
 
 // Styles
@@ -40,17 +55,17 @@ var (
 
 	successStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#00FF00"))
+
+	staleBannerStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("#000000")).
+				Background(lipgloss.Color("#FFA500")).
+				Padding(0, 1)
 )
 
 // Global atomic counter for generating unique PR IDs
 var nextPRID atomic.Int64
 
-// PR type detection keywords
-var (
-	dependencyKeywords    = []string{"bump ", "update ", "upgrade ", "dependencies", "snyk", "dependabot"}
-	documentationKeywords = []string{"readme", "doc", "documentation", "guide", "rfc"}
-)
-
 // Helper functions for atomic ID-based lookups
 
 // findPRByID finds a PR item by its atomic ID
@@ -65,6 +80,7 @@ func (m Model) findPRByID(id int64) *PRItem {
 
 // updatePRByID updates a PR item by its atomic ID using the provided update function
 func (m Model) updatePRByID(id int64, updateFunc func(*PRItem)) Model {
+	m.loadGen++
 	for i := range m.items {
 		if m.items[i].ID == id {
 			updateFunc(&m.items[i])
@@ -91,23 +107,90 @@ func (m Model) triggerAIAnalysisIfReadyByID(id int64) tea.Cmd {
 	return nil
 }
 
+// historyEvent builds a history event for a PR item
+func (m Model) historyEvent(item *PRItem, outcome history.Outcome, detail string) history.Event {
+	return history.Event{
+		User:    m.username,
+		Repo:    item.PR.Owner + "/" + item.PR.Repo,
+		Number:  item.PR.Number,
+		Title:   item.PR.Title,
+		Outcome: outcome,
+		Detail:  detail,
+	}
+}
+
+// hookPR describes a PR for a hook
+func (m Model) hookPR(item *PRItem) hooks.PR {
+	pr := hooks.PR{
+		Owner:   item.PR.Owner,
+		Repo:    item.PR.Repo,
+		Number:  item.PR.Number,
+		Title:   item.PR.Title,
+		URL:     item.HTMLURL(),
+		Author:  item.PR.GetAuthor(),
+		HeadSHA: item.PR.HeadSHA,
+		User:    m.username,
+	}
+	if item.AIAnalysis != nil {
+		pr.Recommendation, pr.Risk = string(item.AIAnalysis.Recommendation), item.AIAnalysis.RiskLevel
+	}
+	return pr
+}
+
 // Model represents the TUI application state
 type Model struct {
 	ctx      context.Context
+	cancel   context.CancelFunc // Cancels ctx and all in-flight work on quit
 	config   *config.Config
 	github   *github.Client
 	aiAgent  *agent.Agent
+	tracker  tracker.Tracker // Nil when issue linking is disabled
+	history  history.Recorder
+	hooks    hooks.Hooks
 	username string
 
+	// Per-PR contexts, cancelled when a PR is filtered out or removed
+	prWork map[int64]prWork
+
 	list     list.Model
-	items    []PRItem
+	items    []PRItem // PRs in every queue
 	status   string
+	notice   string // Transient message shown ahead of status
 	quitting bool
 	spinner  spinner.Model
 	help     help.Model
 
+	noticeSeq int // Identifies the current notice so stale expiries are ignored
+
+	// Status messages held until they've been shown long enough, oldest first,
+	// and the recent ones for the history pane
+	heldStatus        []statusMessage
+	heldSince         time.Time // When the first held message was first shown
+	statusTicking     bool      // A StatusTickMsg is on its way
+	statusHistory     []statusMessage
+	showStatusHistory bool
+
+	// Queues shown as tabs; the filter and loading fields below are the
+	// active queue's
+	queues      []queue
+	activeQueue int
+
 	// Loading states
-	loadingPRs bool
+	loadingPRs  bool
+	lastRefresh time.Time // Start of the last complete refresh, for delta searches
+
+	// Auto-refresh state
+	nextRefresh time.Time // When the next automatic refresh is due
+	lastInput   time.Time // Last key press, to only auto-refresh while idle
+
+	// Time spent on each PR and in the session
+	session *session
+
+	// Stale mode: GitHub is unavailable, serve cached data and block writes
+	stale bool
+
+	// Other speedrun instances sharing the cache, as of the last heartbeat
+	otherInstances int
 
 	// Filter state
 	showOnlyUnreviewed bool
@@ -118,18 +201,85 @@ type Model struct {
 	// Popup state
 	showPopup      bool
 	popupContent   string
-	popupScrollPos int // Current scroll position in popup
+	popupScrollPos int  // Current scroll position in popup
+	showTimeline   bool // Popup shows the timeline tab instead of details
+	showErrors     bool // Popup shows what failed to load instead of details
+	showReasoning  bool // Popup shows reasoning models' summaries of their thinking
+
+	// PR timelines by PR ID, fetched when the timeline tab is opened
+	timelines map[int64]*timelineState
+
+	// Ends of failing check logs by PR ID, fetched when the details popup opens
+	checkLogs map[int64]*checkLogState
 
 	// Advanced filter dialog state
 	showAdvancedFilter bool
 	filterReviewStatus string // "all", "reviewed", "unreviewed"
 	filterRepo         string
 	filterType         string // "all", "docs", "code", "dependencies", "mixed"
+	filterOwned        bool   // Only PRs touching code I own
+	filterLanguage     string // "all" or a language the PR changes, e.g. "Go"
+
+	// Parsed CODEOWNERS by owner/repo
+	codeowners map[string]codeowners.Ruleset
+
+	// PR templates by owner/repo, for checking descriptions against
+	prTemplates map[string]prtemplate.Template
+
+	// Open Dependabot alerts by owner/repo
+	securityAlerts map[string][]*github.SecurityAlert
+
+	// Review checklist questions, and which are ticked by PR ID
+	reviewChecklist []checklist.Item
+	checklistTicks  map[int64][]bool
+
+	// Rules gating approve and merge, and deploy freezes
+	policy  *policy.Policy
+	freezes []freeze.Window
+
+	// Decides each PR's type for the type filter
+	classifier classify.Chain
+
+	// Team workload view, nil when closed
+	workload *workloadState
+
+	// Search query builder, nil when closed
+	queryBuilder *queryBuilderState
+
+	// Bumped when the list or a PR's loading state may have changed, and
+	// what prefetch last looked at, so it only looks again when one has
+	loadGen    int
+	prefetched prefetchKey
+
+	// PR last warned about approving or merging out of stack order
+	stackWarned int64
+
+	// Actions menu state, and the plugins it offers
+	showBotMenu bool
+	botMenu     []botMenuOption
+	plugins     []plugin.Plugin
+
+	// Reminder time menu state
+	showRemindMenu bool
+	remindMenu     []remind.Preset
+	remindPR       int64
+
+	// Log pane state
+	logs     *logbuffer.Buffer
+	showLogs bool
+	logLevel slog.Level
+
+	// Layout state
+	width      int // Terminal size, for laying out the panes and sizing dialogs
+	height     int
+	detailRows int // Rows of the detail pane under the list
+	layoutSeq  int // Identifies the latest layout change so stale saves are skipped
 }
 
 // KeyMap defines key bindings for speedrun-specific actions
 type KeyMap struct {
 	Approve        key.Binding
+	ApproveGroup   key.Binding
 	View           key.Binding
 	AutoMerge      key.Binding
 	Filter         key.Binding
@@ -138,6 +288,22 @@ type KeyMap struct {
 	Help           key.Binding
 	Quit           key.Binding
 	Refresh        key.Binding
+	Logs           key.Binding
+	Messages       key.Binding
+	Errors         key.Binding
+	GrowDetails    key.Binding
+	ShrinkDetails  key.Binding
+	BotActions     key.Binding
+	Workload       key.Binding
+	ApproveGreen   key.Binding
+	Export         key.Binding
+	Remind         key.Binding
+	Query          key.Binding
+	NextQueue      key.Binding
+	PrevQueue      key.Binding
+	Rerequest      key.Binding
+	Nudge          key.Binding
+	ApproveStack   key.Binding
 }
 
 // DefaultKeyMap returns the default key bindings
@@ -147,6 +313,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("a"),
 			key.WithHelp("a", "approve"),
 		),
+		ApproveGroup: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "approve dependency group"),
+		),
 		View: key.NewBinding(
 			key.WithKeys("v"),
 			key.WithHelp("v", "view in browser"),
@@ -179,6 +349,70 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh"),
 		),
+		Logs: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "toggle logs"),
+		),
+		Errors: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "show errors"),
+		),
+		Messages: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "recent messages"),
+		),
+		GrowDetails: key.NewBinding(
+			key.WithKeys("+", "="),
+			key.WithHelp("+", "grow details"),
+		),
+		ShrinkDetails: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "shrink details"),
+		),
+		BotActions: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "actions"),
+		),
+		Workload: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "team review load"),
+		),
+		ApproveGreen: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "approve when green"),
+		),
+		Export: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "export list"),
+		),
+		Remind: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "remind me"),
+		),
+		Query: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "search query"),
+		),
+		NextQueue: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next queue"),
+		),
+		PrevQueue: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "previous queue"),
+		),
+		ApproveStack: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "approve stack"),
+		),
+		Rerequest: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "re-request review (my PRs)"),
+		),
+		Nudge: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "nudge reviewers (my PRs)"),
+		),
 	}
 }
 
@@ -202,19 +436,18 @@ func (k CombinedKeyMap) ShortHelp() []key.Binding {
 // key.Map interface.
 func (k CombinedKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.ListKeys.CursorUp, k.ListKeys.CursorDown, k.ListKeys.PrevPage, k.ListKeys.NextPage},          // Navigation
-		{k.ListKeys.GoToStart, k.ListKeys.GoToEnd},                                                      // Navigation (jump)
-		{k.SpeedrunKeys.Approve, k.SpeedrunKeys.View, k.SpeedrunKeys.AutoMerge, k.SpeedrunKeys.Details}, // Actions
-		{k.SpeedrunKeys.Filter, k.SpeedrunKeys.FilterAdvanced, k.SpeedrunKeys.Refresh},                  // Filtering & Refresh
-		{k.SpeedrunKeys.Help, k.SpeedrunKeys.Quit},                                                      // Other
+		{k.ListKeys.CursorUp, k.ListKeys.CursorDown, k.ListKeys.PrevPage, k.ListKeys.NextPage}, // Navigation
+		{k.ListKeys.GoToStart, k.ListKeys.GoToEnd},                                             // Navigation (jump)
+		{k.SpeedrunKeys.Approve, k.SpeedrunKeys.ApproveGroup, k.SpeedrunKeys.ApproveStack, k.SpeedrunKeys.ApproveGreen, k.SpeedrunKeys.View, k.SpeedrunKeys.AutoMerge, k.SpeedrunKeys.BotActions, k.SpeedrunKeys.Remind, k.SpeedrunKeys.Rerequest, k.SpeedrunKeys.Nudge, k.SpeedrunKeys.Details, k.SpeedrunKeys.Errors}, // Actions
+		{k.SpeedrunKeys.Filter, k.SpeedrunKeys.FilterAdvanced, k.SpeedrunKeys.Query, k.SpeedrunKeys.Refresh, k.SpeedrunKeys.NextQueue, k.SpeedrunKeys.PrevQueue},                                                                                                                                                        // Filtering & Refresh
+		{k.SpeedrunKeys.Workload, k.SpeedrunKeys.Export, k.SpeedrunKeys.Logs, k.SpeedrunKeys.Messages, k.SpeedrunKeys.GrowDetails, k.SpeedrunKeys.ShrinkDetails, k.SpeedrunKeys.Help, k.SpeedrunKeys.Quit},                                                                                                              // Other
 	}
 }
 
 // NewModel creates a new TUI model
-func NewModel(ctx context.Context, cfg *config.Config, githubClient *github.Client, aiAgent *agent.Agent, username string) Model {
+func NewModel(ctx context.Context, cfg *config.Config, githubClient *github.Client, aiAgent *agent.Agent, issueTracker tracker.Tracker, recorder history.Recorder, logs *logbuffer.Buffer, username string) Model {
 	// Create list
-	l := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
-	l.Title = fmt.Sprintf("🔍 Pull Requests for %s", username)
+	l := list.New([]list.Item{}, newPRDelegate(cfg.GitHub.ReviewSLA, cfg.UI.Accessible), 0, 0)
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(false)
 	l.SetShowHelp(false) // Disable built-in help to prevent ? key conflicts
@@ -223,6 +456,12 @@ func NewModel(ctx context.Context, cfg *config.Config, githubClient *github.Clie
 	// Create spinner
 	s := spinner.New()
 	s.Spinner = spinner.Dot
+	if cfg.UI.Accessible {
+		s.Spinner = spinner.Ellipsis
+	}
+	if cfg.UI.ReducedMotion {
+		s.Spinner = staticSpinner
+	}
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
 
 	// Create help model
@@ -232,11 +471,45 @@ func NewModel(ctx context.Context, cfg *config.Config, githubClient *github.Clie
 	// Create combined key map
 	speedrunKeys := DefaultKeyMap()
 
-	return Model{
+	ctx, cancel := context.WithCancel(ctx)
+
+	// Config.Validate has already rejected rules and windows that don't parse
+	gate, err := policy.New(cfg.Policy.Rules, cfg.Policy.BusinessHours)
+	if err != nil {
+		slog.Error("Invalid policy", slog.Any("error", err))
+	}
+	freezes, err := freeze.Parse(cfg.Freeze.Windows)
+	if err != nil {
+		slog.Error("Invalid freeze windows", slog.Any("error", err))
+	}
+	classifier, err := cfg.Classify.Classifier()
+	if err != nil {
+		slog.Error("Invalid PR classifiers", slog.Any("error", err))
+	}
+	var plugins []plugin.Plugin
+	if cfg.Plugins.Enabled {
+		plugins = plugin.Discover(os.Getenv("PATH"))
+		slog.Debug("Discovered plugins", slog.Int("count", len(plugins)))
+	}
+
+	m := Model{
 		ctx:                ctx,
+		cancel:             cancel,
+		prWork:             make(map[int64]prWork),
+		reviewChecklist:    checklist.Parse(cfg.Review.Checklist),
+		checklistTicks:     make(map[int64][]bool),
+		timelines:          make(map[int64]*timelineState),
+		checkLogs:          make(map[int64]*checkLogState),
+		policy:             gate,
+		freezes:            freezes,
+		classifier:         classifier,
+		plugins:            plugins,
 		config:             cfg,
 		github:             githubClient,
 		aiAgent:            aiAgent,
+		tracker:            issueTracker,
+		history:            recorder,
+		hooks:              cfg.Hooks.Hooks(),
 		username:           username,
 		list:               l,
 		items:              []PRItem{},
@@ -244,31 +517,79 @@ func NewModel(ctx context.Context, cfg *config.Config, githubClient *github.Clie
 		spinner:            s,
 		help:               h,
 		keys:               speedrunKeys,
+		showReasoning:      cfg.UI.ShowReasoning,
 		loadingPRs:         true,
 		showOnlyUnreviewed: true,         // Default to showing only unreviewed PRs
 		filterReviewStatus: "unreviewed", // Default filter
 		filterType:         "all",
 		filterRepo:         "all",
-	}
+		filterLanguage:     "all",
+		logs:               logs,
+		logLevel:           slog.LevelInfo,
+		detailRows:         loadLayout(cfg.UI.StatePath).DetailRows,
+		nextRefresh:        time.Now().Add(cfg.GitHub.AutoRefresh),
+		session:            newSession(),
+		notice:             tokenNotice(githubClient.TokenReport()),
+		queues:             newQueues(cfg, githubClient),
+	}
+	return m.updateQueueTitle()
 }
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
-		m.spinner.Tick,
-		FetchPRsCmd(m.github),
-	)
+	cmds := []tea.Cmd{
+		m.spinnerTick(),
+		HealthTickCmd(),
+		HeartbeatCmd(m.github, 0),
+	}
+	for i, q := range m.queues {
+		cmds = append(cmds, FetchPRsCmd(m.ctx, q.github, i))
+	}
+	if m.config.GitHub.AutoRefresh > 0 {
+		cmds = append(cmds, AutoRefreshTickCmd(m.tickInterval(time.Second)))
+	}
+	if m.notice != "" {
+		cmds = append(cmds, tea.Tick(noticeDuration, func(time.Time) tea.Msg {
+			return NoticeExpiredMsg{Seq: m.noticeSeq}
+		}))
+	}
+	return tea.Batch(cmds...)
 }
 
 // Update handles messages
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.update(msg)
+	m, analyze := next.(Model).analyzeSelected()
+	m, prefetch := m.prefetch()
+	m.trackSelected()
+	return m.scheduleStatus(tea.Batch(cmd, analyze, prefetch))
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.list.SetWidth(msg.Width)
-		m.list.SetHeight(msg.Height - 4) // Reserve space for status and help
+		m.width, m.height = msg.Width, msg.Height
+		m.resizeList() // Reserve space for details, status, help and the log pane
+		m.scrollPopup(m.popupScrollPos)
 		return m, nil
 
 	case tea.KeyMsg:
+		m.lastInput = time.Now()
+
+		if m.showBotMenu {
+			return m.handleBotMenuKey(msg)
+		}
+		if m.showRemindMenu {
+			return m.handleRemindMenuKey(msg)
+		}
+		if m.workload != nil {
+			return m.handleWorkloadKey(msg)
+		}
+		if m.queryBuilder != nil {
+			return m.handleQueryBuilderKey(msg)
+		}
+
 		// Handle advanced filter dialog keys first
 		if m.showAdvancedFilter {
 			slog.Debug("Advanced filter dialog key pressed", slog.String("key", msg.String()))
@@ -282,7 +603,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Apply filters and close dialog
 				m.showAdvancedFilter = false
 				m = m.applyAdvancedFilters()
-				return m, nil
+				return m.resumeCancelledAnalyses()
 			// Review Status options
 			case key.Matches(msg, key.NewBinding(key.WithKeys("1"))):
 				slog.Debug("Advanced filter: review status changed to all")
@@ -326,6 +647,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				slog.Debug("Advanced filter: repo filter changed to current")
 				m.filterRepo = "current"
 				return m, nil
+			// Ownership option
+			case key.Matches(msg, key.NewBinding(key.WithKeys("o"))):
+				m.filterOwned = !m.filterOwned
+				slog.Debug("Advanced filter: owned by me toggled", slog.Bool("owned", m.filterOwned))
+				return m, nil
+			// Language option
+			case key.Matches(msg, key.NewBinding(key.WithKeys("l"))):
+				m.filterLanguage = m.nextLanguageFilter()
+				slog.Debug("Advanced filter: language changed", slog.String("language", m.filterLanguage))
+				return m, nil
 			default:
 				slog.Debug("Advanced filter: unhandled key", slog.String("key", msg.String()))
 			}
@@ -336,53 +667,113 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.showPopup {
 			switch {
 			case key.Matches(msg, m.keys.Details) || key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
-				m.showPopup = false
-				m.popupScrollPos = 0 // Reset scroll position
+				m.closePopup()
 				slog.Debug("Popup closed by user")
 				return m, nil
-			case key.Matches(msg, key.NewBinding(key.WithKeys("up"), key.WithKeys("k"))):
-				if m.popupScrollPos > 0 {
-					m.popupScrollPos--
-				}
+			case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
+				m.scrollPopup(m.popupScrollPos - 1)
 				return m, nil
-			case key.Matches(msg, key.NewBinding(key.WithKeys("down"), key.WithKeys("j"))):
-				m.popupScrollPos++
+			case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
+				m.scrollPopup(m.popupScrollPos + 1)
 				return m, nil
 			case key.Matches(msg, key.NewBinding(key.WithKeys("pgup"))):
-				m.popupScrollPos = max(0, m.popupScrollPos-10)
+				_, page := m.popupSize()
+				m.scrollPopup(m.popupScrollPos - page)
 				return m, nil
 			case key.Matches(msg, key.NewBinding(key.WithKeys("pgdown"))):
-				m.popupScrollPos += 10
+				_, page := m.popupSize()
+				m.scrollPopup(m.popupScrollPos + page)
 				return m, nil
 			case key.Matches(msg, key.NewBinding(key.WithKeys("home"))):
 				m.popupScrollPos = 0
 				return m, nil
 			case key.Matches(msg, key.NewBinding(key.WithKeys("end"))):
-				// Will be handled in rendering to set to max scroll
-				m.popupScrollPos = 999999
+				m.scrollPopup(m.maxPopupScroll())
 				return m, nil
 			case key.Matches(msg, m.keys.Approve):
 				// Handle approve from popup
 				return m.handleApprove()
+			case key.Matches(msg, m.keys.ApproveGroup):
+				return m.handleApproveGroup()
 			case key.Matches(msg, m.keys.View):
 				// Handle view from popup
 				return m.handleView()
 			case key.Matches(msg, m.keys.AutoMerge):
 				// Handle auto-merge from popup
 				return m.handleAutoMerge()
+			case m.showErrors && key.Matches(msg, m.keys.Refresh):
+				return m.handleRetryErrors()
+			case !m.showErrors && key.Matches(msg, key.NewBinding(key.WithKeys("tab"))):
+				return m.handleToggleTimeline()
+			case !m.showTimeline && !m.showErrors && key.Matches(msg, key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"))):
+				return m.handleToggleChecklist(int(msg.String()[0] - '0'))
+			case !m.showTimeline && !m.showErrors && key.Matches(msg, key.NewBinding(key.WithKeys("t"))):
+				return m.handleToggleReasoning()
 			}
 			return m, nil // Consume all other keys when popup is open
 		}
 
 		// Allow navigation even when loading
 		switch {
+		case key.Matches(msg, m.keys.Logs):
+			return m.handleToggleLogs()
+
+		case key.Matches(msg, m.keys.Messages):
+			return m.handleToggleStatusHistory()
+
+		case key.Matches(msg, m.keys.GrowDetails):
+			return m.handleResizeDetails(1)
+
+		case key.Matches(msg, m.keys.ShrinkDetails):
+			return m.handleResizeDetails(-1)
+
+		case m.showLogs && key.Matches(msg, key.NewBinding(key.WithKeys("tab"))):
+			return m.handleCycleLogLevel()
+
+		case key.Matches(msg, m.keys.NextQueue):
+			return m.handleSwitchQueue(1)
+
+		case key.Matches(msg, m.keys.PrevQueue):
+			return m.handleSwitchQueue(-1)
+
 		case key.Matches(msg, m.keys.Quit):
 			m.quitting = true
+			m.cancel()
 			return m, tea.Quit
 
 		case key.Matches(msg, m.keys.Approve):
 			return m.handleApprove()
 
+		case key.Matches(msg, m.keys.ApproveGroup):
+			return m.handleApproveGroup()
+
+		case key.Matches(msg, m.keys.ApproveStack):
+			return m.handleApproveStack()
+
+		case key.Matches(msg, m.keys.ApproveGreen):
+			return m.handleApproveWhenGreen()
+
+		case key.Matches(msg, m.keys.BotActions):
+			return m.handleBotMenu()
+
+		case key.Matches(msg, m.keys.Workload):
+			return m.handleWorkload()
+
+		case key.Matches(msg, m.keys.Export):
+			return m.handleExport()
+
+		case key.Matches(msg, m.keys.Remind):
+			return m.handleRemindMenu()
+
+		case key.Matches(msg, m.keys.Query):
+			return m.handleQueryBuilder()
+
+		case key.Matches(msg, m.keys.Rerequest):
+			return m.handleRerequestReview()
+
+		case key.Matches(msg, m.keys.Nudge):
+			return m.handleNudgeReviewers()
+
 		case key.Matches(msg, m.keys.View):
 			return m.handleView()
 
@@ -395,6 +786,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.Details):
 			return m.handleDetails()
 
+		case key.Matches(msg, m.keys.Errors):
+			return m.handleShowErrors()
+
 		case key.Matches(msg, m.keys.Help):
 			return m.handleHelp()
 
@@ -411,7 +805,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 
 	case PRsLoadedMsg:
-		return m.handlePRsLoaded(msg)
+		return m.inQueue(msg.Queue, func(m Model) (Model, tea.Cmd) { return m.handlePRsLoaded(msg) })
 
 	case DiffStatsLoadedMsg:
 		return m.handleDiffStatsLoaded(msg)
@@ -419,29 +813,122 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case CheckStatusLoadedMsg:
 		return m.handleCheckStatusLoaded(msg)
 
+	case GreenTickMsg:
+		return m.handleGreenTick()
+
+	case ExportDoneMsg:
+		return m.handleExportDone(msg)
+
+	case ReminderCreatedMsg:
+		return m.handleReminderCreated(msg)
+
+	case QueryOptionsLoadedMsg:
+		return m.handleQueryOptionsLoaded(msg)
+
+	case RequestedReviewersLoadedMsg:
+		return m.handleRequestedReviewersLoaded(msg)
+
+	case ReviewRequestedMsg:
+		return m.handleReviewRequested(msg)
+
+	case ReviewersNudgedMsg:
+		return m.handleReviewersNudged(msg)
+
 	case ReviewsLoadedMsg:
 		return m.handleReviewsLoaded(msg)
 
+	case SignaturesLoadedMsg:
+		return m.handleSignaturesLoaded(msg)
+
+	case LinkedIssuesLoadedMsg:
+		return m.handleLinkedIssuesLoaded(msg)
+
 	case AIAnalysisLoadedMsg:
 		return m.handleAIAnalysisLoaded(msg)
 
 	case TriggerAIAnalysisMsg:
 		return m.handleTriggerAIAnalysis(msg)
 
+	case RetryAIAnalysisMsg:
+		return m.handleRetryAIAnalysis(msg)
+
 	case SmartRefreshLoadedMsg:
-		return m.handleSmartRefreshLoaded(msg)
+		return m.inQueue(msg.Queue, func(m Model) (Model, tea.Cmd) { return m.handleSmartRefreshLoaded(msg) })
+
+	case RemovedPRsCheckedMsg:
+		return m.handleRemovedPRsChecked(msg)
+
+	case NoticeExpiredMsg:
+		return m.handleNoticeExpired(msg)
+
+	case StatusTickMsg:
+		return m.handleStatusTick()
+
+	case RetryLoadsMsg:
+		return m.handleRetryLoads(msg)
+
+	case CachedDataLoadedMsg:
+		return m.handleCachedDataLoaded(msg), nil
+
+	case LayoutChangedMsg:
+		return m.handleLayoutChanged(msg)
+
+	case AutoRefreshTickMsg:
+		return m.handleAutoRefreshTick()
 
 	case PRApprovedMsg:
 		return m.handlePRApproved(msg)
 
+	case PluginDoneMsg:
+		return m.handlePluginDone(msg)
+
+	case BotActionDoneMsg:
+		return m.handleBotActionDone(msg)
+
+	case SecurityAlertsLoadedMsg:
+		return m.handleSecurityAlertsLoaded(msg)
+
+	case WorkloadLoadedMsg:
+		return m.handleWorkloadLoaded(msg)
+
+	case ReviewerReassignedMsg:
+		return m.handleReviewerReassigned(msg)
+
+	case CodeownersLoadedMsg:
+		return m.handleCodeownersLoaded(msg)
+
+	case PRTemplateLoadedMsg:
+		return m.handlePRTemplateLoaded(msg)
+
+	case ChangedFilesLoadedMsg:
+		return m.handleChangedFilesLoaded(msg)
+
+	case TimelineLoadedMsg:
+		return m.handleTimelineLoaded(msg)
+
+	case CheckLogsLoadedMsg:
+		return m.handleCheckLogsLoaded(msg)
+
 	case AutoMergeEnabledMsg:
 		return m.handleAutoMergeEnabled(msg)
 
 	case PRMergedMsg:
 		return m.handlePRMerged(msg)
 
+	case LogTickMsg:
+		return m.handleLogTick()
+
+	case HealthTickMsg:
+		return m.handleHealthTick()
+
+	case HealthProbedMsg:
+		return m.handleHealthProbed(msg)
+
+	case InstancesMsg:
+		return m.handleInstances(msg)
+
 	case StatusMsg:
-		m.status = string(msg)
+		m.setStatus(statusInfo, string(msg))
 		return m, nil
 	}
 
@@ -454,26 +941,31 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // View renders the UI
 func (m Model) View() string {
 	if m.quitting {
-		return "👋 Goodbye!\n"
+		return m.label("👋 Goodbye!\n")
 	}
 
 	// Show detailed info for selected PR
-	details := ""
-	if selected := m.list.SelectedItem(); selected != nil {
-		if prItem, ok := selected.(PRItem); ok {
-			details = m.renderPRDetails(prItem)
-		}
-	}
+	details := m.renderDetailPane()
 
 	// Help text
 	var helpText string
-	if m.showAdvancedFilter {
-		helpText = helpStyle.Render("1-3: review • 4-8: type • 9-0: repo • enter: apply • esc: cancel")
+	if m.workload != nil {
+		helpText = helpStyle.Render("1-9: apply suggestion • a: apply all • esc: close")
+	} else if m.showBotMenu {
+		helpText = helpStyle.Render(fmt.Sprintf("1-%d: run action • esc: cancel", len(m.botMenu)))
+	} else if m.queryBuilder != nil {
+		helpText = helpStyle.Render("↑/↓: move • space: toggle • enter: search • esc: cancel")
+	} else if m.showRemindMenu {
+		helpText = helpStyle.Render(fmt.Sprintf("1-%d: set reminder • esc: cancel", len(m.remindMenu)))
+	} else if m.showAdvancedFilter {
+		helpText = helpStyle.Render("1-3: review • 4-8: type • 9-0: repo • o: owned by me • enter: apply • esc: cancel")
+	} else if m.showPopup && m.showErrors {
+		helpText = helpStyle.Render("r: retry • ↑/j: scroll • pgup/pgdown: page • enter/esc: close")
 	} else if m.showPopup {
-		helpText = helpStyle.Render("a: approve • v: view • m: auto-merge • ↑/j: scroll • pgup/pgdown: page • enter/esc: close")
+		helpText = helpStyle.Render("a: approve • 1-9: checklist • tab: timeline • t: reasoning • v: view • m: auto-merge • ↑/j: scroll • pgup/pgdown: page • enter/esc: close")
 	} else {
 		// Use the bubbles help system with combined keys
-		m.help.Width = m.list.Width()
+		m.help.Width = m.width
 		combinedKeys := CombinedKeyMap{
 			SpeedrunKeys: m.keys,
 			ListKeys:     m.list.KeyMap,
@@ -482,19 +974,60 @@ func (m Model) View() string {
 	}
 
 	// Status with spinner if loading
-	status := m.status
+	status := m.currentStatus()
+	if m.notice != "" {
+		status = m.notice + " • " + status
+	}
+	if countdown := m.autoRefreshStatus(); countdown != "" {
+		status = status + " • " + countdown
+	}
 	if m.loadingPRs {
 		status = m.spinner.View() + " " + status
 	}
+	if m.aiAgent != nil {
+		if until := m.aiAgent.PausedUntil(); !until.IsZero() {
+			status = fmt.Sprintf("🤖 AI paused until %s • %s", until.Format("15:04:05"), status)
+		}
+	}
+	statusLine := statusStyle.Render(m.label(status))
+	if health := m.github.Health(); health.Degraded {
+		banner := fmt.Sprintf("⚠ GitHub unavailable since %s — showing cached data, write actions disabled", health.Since.Format("15:04"))
+		statusLine = staleBannerStyle.Render(m.label(banner)) + " " + statusLine
+	}
+	if m.github.DryRun() {
+		statusLine = dryRunBannerStyle.Render(m.label("🧪 DRY RUN")) + " " + statusLine
+	}
+
+	logPane := ""
+	if m.showStatusHistory {
+		logPane += "\n" + m.renderStatusHistory()
+	}
+	if m.showLogs {
+		logPane += "\n" + m.renderLogPane()
+	}
 
 	baseView := fmt.Sprintf(
-		"%s%s\n%s\n%s",
+		"%s%s%s\n%s\n%s",
 		m.list.View(),
 		details,
-		statusStyle.Render(status),
+		logPane,
+		statusLine,
 		helpText,
 	)
 
+	if m.showBotMenu {
+		return m.renderBotMenu()
+	}
+	if m.showRemindMenu {
+		return m.renderRemindMenu()
+	}
+	if m.workload != nil {
+		return m.renderWorkload()
+	}
+	if m.queryBuilder != nil {
+		return m.renderQueryBuilder()
+	}
+
 	// Overlay advanced filter dialog if shown
 	if m.showAdvancedFilter {
 		return m.renderAdvancedFilterDialog(baseView)
@@ -508,25 +1041,6 @@ func (m Model) View() string {
 	return baseView
 }
 
-// renderPRDetails renders detailed information about a PR
-func (m Model) renderPRDetails(item PRItem) string {
-	// Only show loading if there are actual loading operations
-	stillLoading := item.LoadingDiff || item.LoadingChecks || item.LoadingReviews || item.LoadingAI
-	if stillLoading {
-		return "\n💭 Loading PR details..."
-	}
-
-	details := fmt.Sprintf("\n📍 %s/%s#%d", item.PR.Owner, item.PR.Repo, item.PR.Number)
-
-	// Add more details as they become available
-	if item.DiffStats != nil && item.CheckStatus != nil {
-		details += fmt.Sprintf("\n💬 %d additions, %d deletions across %d files",
-			item.DiffStats.Additions, item.DiffStats.Deletions, item.DiffStats.Files)
-	}
-
-	return details
-}
-
 // Message handlers
 
 func (m Model) handlePRsLoaded(msg PRsLoadedMsg) (Model, tea.Cmd) {
@@ -534,13 +1048,19 @@ func (m Model) handlePRsLoaded(msg PRsLoadedMsg) (Model, tea.Cmd) {
 
 	if msg.Err != nil {
 		slog.Error("Failed to load PRs in UI", slog.Any("error", msg.Err))
-		m.status = errorStyle.Render("Failed to load PRs: " + msg.Err.Error())
+		m.setError("Failed to load PRs", msg.Err)
 		return m, nil
 	}
 
+	metrics.PRsProcessed.Add(float64(len(msg.PRs)), "loaded")
 	slog.Info("PRs loaded in UI", slog.Int("pr_count", len(msg.PRs)),
 		slog.Bool("show_only_unreviewed", m.showOnlyUnreviewed))
 
+	// Any work for a previous load belongs to items that are about to be replaced
+	for _, item := range m.items {
+		m.cancelPRWork(item.ID, "reloaded")
+	}
+
 	// Create list items for all PRs (filtering will happen dynamically as review data loads)
 	m.items = make([]PRItem, len(msg.PRs))
 
@@ -548,15 +1068,22 @@ func (m Model) handlePRsLoaded(msg PRsLoadedMsg) (Model, tea.Cmd) {
 		// Check if AI analysis is already cached
 		// Note: Skip cache check during startup since HeadSHA is not available yet
 		// AI analysis will check cache properly when HeadSHA is populated
-		loadingAI := m.aiAgent != nil
+		authored := pr.GetAuthor() == m.username
+		loadingAI, deferAI := m.aiLoadState(pr, authored)
 
 		m.items[i] = PRItem{
 			ID:             nextPRID.Add(1),
-			PR:             pr,
+			Snapshot:       github.Snapshot{PR: pr},
+			Queue:          m.activeQueue,
+			Authored:       authored,
 			LoadingDiff:    true,
 			LoadingChecks:  true,
 			LoadingReviews: true,
 			LoadingAI:      loadingAI,
+			LoadingIssues:  m.tracker != nil,
+			LoadingSigs:    true,
+			AIDeferred:     deferAI,
+			Pending:        true,
 		}
 	}
 
@@ -568,98 +1095,77 @@ func (m Model) handlePRsLoaded(msg PRsLoadedMsg) (Model, tea.Cmd) {
 	if m.showOnlyUnreviewed {
 		filterText = " (unreviewed only)"
 	}
-	m.status = fmt.Sprintf("Found %d pull requests%s", len(msg.PRs), filterText)
+	m.setStatus(statusInfo, fmt.Sprintf("Found %d pull requests%s", len(msg.PRs), filterText))
 
-	// Start loading details for each PR sequentially
-	var sequences []tea.Cmd
-	for i, pr := range msg.PRs {
-		prID := m.items[i].ID
-		// Create a sequence for each PR: diff → checks → reviews → AI
-		prSequence := []tea.Cmd{
-			FetchDiffStatsCmd(m.github, pr, prID),
-			FetchCheckStatusCmd(m.github, pr, prID),
-			FetchReviewsCmd(m.github, pr, m.username, prID),
-		}
+	// Details load from the selection out as it moves (see prefetch), and
+	// cached data fills the list in meanwhile
+	return m, tea.Batch(LoadCachedDataCmd(m.items), m.fetchSecurityAlerts(), m.fetchCodeowners(), m.fetchPRTemplates())
+}
 
-		// Add AI analysis to the sequence
-		if !m.items[i].LoadingAI {
-			// Load cached AI analysis immediately if available
-			prSequence = append(prSequence, FetchCachedAIAnalysisCmd(pr, prID))
-		}
-		// Note: For LoadingAI=true, AI analysis will be triggered by the message handlers
-		// when all prerequisites (diff, checks, reviews) are loaded
-
-		// Add small delay between PR sequences to avoid overwhelming the API
-		delay := time.Duration(i*100) * time.Millisecond
-		if delay > 0 {
-			sequences = append(sequences, tea.Tick(delay, func(t time.Time) tea.Msg {
-				return tea.Sequence(prSequence...)()
-			}))
-		} else {
-			sequences = append(sequences, tea.Sequence(prSequence...))
-		}
-	}
+// sizeBudget returns the configured PR size limits
+func (m Model) sizeBudget() agent.SizeBudget {
+	return configuredBudget(m.config)
+}
 
-	return m, tea.Batch(sequences...)
+// configuredBudget is the size budget from the review settings
+func configuredBudget(cfg *config.Config) agent.SizeBudget {
+	return agent.SizeBudget{
+		MaxLines: cfg.Review.MaxLines,
+		MaxFiles: cfg.Review.MaxFiles,
+		Enforce:  cfg.Review.EnforceSize,
+	}
 }
 
 func (m Model) handleDiffStatsLoaded(msg DiffStatsLoadedMsg) (Model, tea.Cmd) {
 	m = m.updatePRByID(msg.PRID, func(item *PRItem) {
 		item.LoadingDiff = false
+		item.StaleDiff = false
 		item.DiffStats = msg.Stats
 		item.DiffError = msg.Err
+		item.OverBudget = ""
+		if msg.Stats != nil {
+			item.OverBudget = m.sizeBudget().Exceeded(msg.Stats.Additions+msg.Stats.Deletions, msg.Stats.Files)
+		}
 	})
 
 	// Re-apply filter to update the visible list
 	m = m.updateVisibleItems()
+	m, retry := m.scheduleLoadRetry(msg.PRID, msg.Err)
 
 	// Trigger AI analysis if we have all required data and AI agent is available
-	return m, m.triggerAIAnalysisIfReadyByID(msg.PRID)
+	return m, tea.Batch(retry, m.triggerAIAnalysisIfReadyByID(msg.PRID))
 }
 
 func (m Model) handleCheckStatusLoaded(msg CheckStatusLoadedMsg) (Model, tea.Cmd) {
 	m = m.updatePRByID(msg.PRID, func(item *PRItem) {
 		item.LoadingChecks = false
+		item.StaleChecks = false
 		item.CheckStatus = msg.Status
 		item.CheckError = msg.Err
+		item.setHead(msg.HeadSHA, msg.BaseRef, msg.HeadRef)
 	})
 
 	// Re-apply filter to update the visible list
 	m = m.updateVisibleItems()
+	m, retry := m.scheduleLoadRetry(msg.PRID, msg.Err)
+
+	m, cmd := m.approveIfGreen(msg.PRID)
 
 	// Trigger AI analysis if we have all required data and AI agent is available
-	return m, m.triggerAIAnalysisIfReadyByID(msg.PRID)
+	return m, tea.Batch(retry, cmd, m.triggerAIAnalysisIfReadyByID(msg.PRID))
 }
 
 func (m Model) handleReviewsLoaded(msg ReviewsLoadedMsg) (Model, tea.Cmd) {
 	var prItem *PRItem
+	newlyDismissed := false
 	m = m.updatePRByID(msg.PRID, func(item *PRItem) {
 		prItem = item // Capture for logging
+		wasDismissed := item.Dismissed
 		item.LoadingReviews = false
-		item.Reviews = msg.Reviews
+		item.StaleReviews = false
+		item.setReviews(msg.Reviews, m.username)
 		item.ReviewError = msg.Err
-
-		// Check if current user has reviewed and determine review type
-		userReviewed := false
-		userApproved := false
-		userDismissed := false
-		for _, review := range msg.Reviews {
-			if review.User == m.username {
-				userReviewed = true
-				switch review.State {
-				case "APPROVED":
-					userApproved = true
-				case "DISMISSED":
-					userDismissed = true
-				}
-				// Note: We don't break here because there might be multiple reviews
-				// and we want to find the most recent status
-			}
-		}
-
-		item.Reviewed = userReviewed
-		item.Approved = userApproved
-		item.Dismissed = userDismissed
+		newlyDismissed = item.Dismissed && !wasDismissed
 	})
 
 	if prItem != nil {
@@ -672,24 +1178,103 @@ func (m Model) handleReviewsLoaded(msg ReviewsLoadedMsg) (Model, tea.Cmd) {
 
 	// Re-apply filter since review status may have changed
 	m = m.updateVisibleItems()
+	m, retry := m.scheduleLoadRetry(msg.PRID, msg.Err)
 
 	// Trigger AI analysis if we have all required data and AI agent is available
+	cmd := tea.Batch(retry, m.triggerAIAnalysisIfReadyByID(msg.PRID))
+	if prItem != nil && prItem.Authored {
+		// Who still owes my PR a review changes along with the reviews
+		cmd = tea.Batch(cmd, FetchRequestedReviewersCmd(m.prContext(msg.PRID), prItem.PR, msg.PRID))
+	}
+	if newlyDismissed && prItem != nil {
+		cmd = tea.Batch(cmd, RecordHistoryCmd(m.history, m.historyEvent(prItem, history.Dismissed, "")))
+	}
+	return m, cmd
+}
+
+func (m Model) handleSignaturesLoaded(msg SignaturesLoadedMsg) (Model, tea.Cmd) {
+	m = m.updatePRByID(msg.PRID, func(item *PRItem) {
+		item.LoadingSigs = false
+		item.Signatures = msg.Signatures
+		item.SigError = msg.Err
+	})
+
+	// Signing status feeds the AI risk assessment
 	return m, m.triggerAIAnalysisIfReadyByID(msg.PRID)
 }
 
-func (m Model) handleAIAnalysisLoaded(msg AIAnalysisLoadedMsg) (Model, tea.Cmd) {
+func (m Model) handleLinkedIssuesLoaded(msg LinkedIssuesLoadedMsg) (Model, tea.Cmd) {
 	m = m.updatePRByID(msg.PRID, func(item *PRItem) {
-		item.LoadingAI = false
-		item.AIAnalysis = msg.Analysis
-		item.AIError = msg.Err
+		item.LoadingIssues = false
+		item.Issues = msg.Issues
+		item.IssueError = msg.Err
 	})
 
+	// Linked issues are AI context, so analysis waits for them
+	return m, m.triggerAIAnalysisIfReadyByID(msg.PRID)
+}
+
+func (m Model) handleAIAnalysisLoaded(msg AIAnalysisLoadedMsg) (Model, tea.Cmd) {
+	// Cancelled analyses of PRs that are visible again are restarted; hidden
+	// ones wait for resumeCancelledAnalyses
+	if errors.Is(msg.Err, context.Canceled) {
+		if m.ctx.Err() != nil {
+			return m, nil
+		}
+		if m.analysisVisible(msg.PRID) {
+			return m, m.triggerAIAnalysisIfReadyByID(msg.PRID)
+		}
+	}
+
+	// Group analyses also apply to the other members still waiting on them
+	var analyzed *PRItem
+	for _, id := range m.analysisGroupIDs(msg.PRID) {
+		m = m.updatePRByID(id, func(item *PRItem) {
+			if id == msg.PRID {
+				analyzed = item
+			} else if !item.LoadingAI {
+				return
+			}
+			item.LoadingAI = false
+			item.StaleAI = false
+			item.AIAnalysis = msg.Analysis
+			item.AIError = msg.Err
+		})
+	}
+
 	// Re-apply filter to update the visible list
 	m = m.updateVisibleItems()
 
+	// Analyses paused by the circuit breaker are retried once it lets requests through
+	if analyzed != nil && errors.Is(msg.Err, agent.ErrCircuitOpen) {
+		return m, RetryAIAnalysisCmd(msg.PRID, m.aiAgent.PausedUntil())
+	}
+
+	// Only fresh analyses are history and run hooks; cached ones did when first run
+	if analyzed != nil && msg.Analysis != nil && msg.Err == nil && !msg.Cached {
+		detail := fmt.Sprintf("%s (%s risk)", msg.Analysis.Recommendation, msg.Analysis.RiskLevel)
+		return m, tea.Batch(
+			RecordHistoryCmd(m.history, m.historyEvent(analyzed, history.AIRecommendation, detail)),
+			RunHookCmd(m.hooks, hooks.AnalysisComplete, m.hookPR(analyzed)),
+		)
+	}
+
 	return m, nil
 }
 
+func (m Model) handleRetryAIAnalysis(msg RetryAIAnalysisMsg) (Model, tea.Cmd) {
+	for _, id := range m.analysisGroupIDs(msg.PRID) {
+		m = m.updatePRByID(id, func(item *PRItem) {
+			if errors.Is(item.AIError, agent.ErrCircuitOpen) {
+				item.LoadingAI = true
+				item.AIError = nil
+			}
+		})
+	}
+	m = m.updateVisibleItems()
+	return m, m.triggerAIAnalysisIfReadyByID(msg.PRID)
+}
+
 func (m Model) handleTriggerAIAnalysis(msg TriggerAIAnalysisMsg) (Model, tea.Cmd) {
 	// Use the existing triggerAIAnalysisIfReadyByID method
 	return m, m.triggerAIAnalysisIfReadyByID(msg.PRID)
@@ -699,9 +1284,12 @@ func (m Model) handleSmartRefreshLoaded(msg SmartRefreshLoadedMsg) (Model, tea.C
 	m.loadingPRs = false
 
 	if msg.Err != nil {
-		m.status = errorStyle.Render("Failed to refresh PRs: " + msg.Err.Error())
+		m.setError("Failed to refresh PRs", msg.Err)
 		return m, nil
 	}
+	if !msg.RefreshedAt.IsZero() {
+		m.lastRefresh = msg.RefreshedAt
+	}
 
 	// Create maps for efficient lookups
 	existingPRs := make(map[int]*PRItem)
@@ -743,7 +1331,10 @@ func (m Model) handleSmartRefreshLoaded(msg SmartRefreshLoadedMsg) (Model, tea.C
 			if needsAIUpdate {
 				updatedItem.LoadingDiff = true
 				updatedItem.LoadingChecks = true
-				updatedItem.LoadingAI = m.aiAgent != nil
+				updatedItem.LoadingAI, updatedItem.AIDeferred = m.aiLoadState(freshPR, updatedItem.Authored)
+				updatedItem.LoadingIssues = m.tracker != nil
+				updatedItem.LoadingSigs = true
+				updatedItem.Signatures = nil
 				updatedItem.DiffStats = nil
 				updatedItem.CheckStatus = nil
 				updatedItem.AIAnalysis = nil
@@ -754,18 +1345,38 @@ func (m Model) handleSmartRefreshLoaded(msg SmartRefreshLoadedMsg) (Model, tea.C
 		} else {
 			// New PR - add with full loading state
 			newPRCount++
+			authored := freshPR.GetAuthor() == m.username
+			loadingAI, deferAI := m.aiLoadState(freshPR, authored)
 			newItem := PRItem{
 				ID:             nextPRID.Add(1),
-				PR:             freshPR,
+				Snapshot:       github.Snapshot{PR: freshPR},
+				Queue:          m.activeQueue,
+				Authored:       authored,
 				LoadingDiff:    true,
 				LoadingChecks:  true,
 				LoadingReviews: true,
-				LoadingAI:      m.aiAgent != nil,
+				LoadingAI:      loadingAI,
+				LoadingIssues:  m.tracker != nil,
+				LoadingSigs:    true,
+				AIDeferred:     deferAI,
+				Pending:        true,
 			}
 			newItems = append(newItems, newItem)
 		}
 	}
 
+	// Stop work for PRs that are no longer open, and find out whether someone
+	// else merged or closed them. PRs merged from here aren't news.
+	var removedPRs []*github.PullRequest
+	for number, item := range existingPRs {
+		if _, ok := freshPRMap[number]; !ok {
+			m.cancelPRWork(item.ID, "removed")
+			if !item.Merging {
+				removedPRs = append(removedPRs, item.PR)
+			}
+		}
+	}
+
 	// Update items list
 	m.items = newItems
 
@@ -785,44 +1396,68 @@ func (m Model) handleSmartRefreshLoaded(msg SmartRefreshLoadedMsg) (Model, tea.C
 	if m.showOnlyUnreviewed {
 		filterText = " (unreviewed only)"
 	}
-	m.status = fmt.Sprintf("%s%s", strings.Join(statusParts, ", "), filterText)
+	m.setStatus(statusInfo, fmt.Sprintf("%s%s", strings.Join(statusParts, ", "), filterText))
 
-	// Start loading data for new and updated PRs
+	// Start loading data for updated PRs. New ones, and any not loaded yet,
+	// wait their turn in the prefetch window.
 	cmds := []tea.Cmd{}
 	for i, item := range m.items {
+		if item.Pending {
+			continue
+		}
 		pr := item.PR
 		prID := item.ID
+		ctx := m.prContext(prID)
 		delay := time.Duration(i*50) * time.Millisecond
 
 		// Load diff stats if needed
 		if item.LoadingDiff {
 			cmds = append(cmds, tea.Tick(delay, func(t time.Time) tea.Msg {
-				return FetchDiffStatsCmd(m.github, pr, prID)()
+				return FetchDiffStatsCmd(ctx, m.github, pr, prID)()
 			}))
 		}
 
 		// Load check status if needed
 		if item.LoadingChecks {
 			cmds = append(cmds, tea.Tick(delay+20*time.Millisecond, func(t time.Time) tea.Msg {
-				return FetchCheckStatusCmd(m.github, pr, prID)()
+				return FetchCheckStatusCmd(ctx, m.github, pr, prID)()
 			}))
 		}
 
 		// Always refresh reviews (user might have reviewed)
 		if item.LoadingReviews {
 			cmds = append(cmds, tea.Tick(delay+40*time.Millisecond, func(t time.Time) tea.Msg {
-				return FetchReviewsCmd(m.github, pr, m.username, prID)()
+				return FetchReviewsCmd(ctx, m.github, pr, m.username, prID)()
+			}))
+		}
+
+		// New commits need their signatures checked, and may touch other files
+		if item.LoadingSigs {
+			cmds = append(cmds, tea.Tick(delay+50*time.Millisecond, func(t time.Time) tea.Msg {
+				return tea.Batch(FetchSignaturesCmd(ctx, pr, prID), FetchChangedFilesCmd(ctx, pr, prID))()
+			}))
+		}
+
+		// Linked issues may have changed along with the PR
+		if item.LoadingIssues {
+			cmds = append(cmds, tea.Tick(delay+60*time.Millisecond, func(t time.Time) tea.Msg {
+				return FetchLinkedIssuesCmd(ctx, m.tracker, pr, prID)()
 			}))
 		}
 	}
 
+	if len(removedPRs) > 0 {
+		cmds = append(cmds, CheckRemovedPRsCmd(m.ctx, removedPRs))
+	}
+	cmds = append(cmds, m.fetchSecurityAlerts(), m.fetchCodeowners(), m.fetchPRTemplates())
+
 	return m, tea.Batch(cmds...)
 }
 
 func (m Model) handlePRApproved(msg PRApprovedMsg) (Model, tea.Cmd) {
 	if msg.Err != nil {
 		slog.Error("PR approval failed in UI", slog.Int64("prID", msg.PRID), slog.Any("error", msg.Err))
-		m.status = errorStyle.Render("Failed to approve PR: " + msg.Err.Error())
+		m.setStatus(statusError, "Failed to approve PR: "+msg.Err.Error())
 		return m, nil
 	}
 
@@ -831,21 +1466,34 @@ func (m Model) handlePRApproved(msg PRApprovedMsg) (Model, tea.Cmd) {
 		approvedPR = item // Capture for auto-merge logic
 		item.Approved = true
 		item.Reviewed = true
+		item.ApprovedSHA = item.PR.HeadSHA
 	})
 
 	if approvedPR != nil {
 		slog.Info("PR approved successfully in UI", slog.Any("pr", approvedPR.PR))
-		m.status = successStyle.Render(fmt.Sprintf("✅ Approved PR #%d", approvedPR.PR.Number))
+		m.setStatus(statusSuccess, m.actionStatus(fmt.Sprintf("✅ Approved PR #%d", approvedPR.PR.Number), fmt.Sprintf("would approve PR #%d", approvedPR.PR.Number)))
 	}
 
 	// Re-apply filter since review status changed
 	m = m.updateVisibleItems()
 
+	// Only advance past the selected PR; group approvals finish in any order
+	var nextCmd tea.Cmd
+	if selected, ok := m.list.SelectedItem().(PRItem); ok && selected.ID == msg.PRID {
+		nextCmd = m.moveToNext()
+	}
+
 	// Check if auto-merge should be triggered after approval
-	nextCmd := m.moveToNext()
 	if m.config.GitHub.AutoMergeOnApproval == "true" && approvedPR != nil {
-		slog.Info("Auto-triggering auto-merge after approval", slog.Any("pr", approvedPR.PR))
-		nextCmd = tea.Batch(m.moveToNext(), EnableAutoMergeCmd(approvedPR.PR, "SQUASH", approvedPR.ID))
+		if reason := m.mergeBlocked(*approvedPR); reason != "" {
+			m.setStatus(statusError, "Approved, but not merging: "+reason)
+		} else {
+			slog.Info("Auto-triggering auto-merge after approval", slog.Any("pr", approvedPR.PR))
+			nextCmd = tea.Batch(nextCmd, EnableAutoMergeCmd(m.ctx, approvedPR.PR, "SQUASH", approvedPR.ID))
+		}
+	}
+	if approvedPR != nil {
+		nextCmd = tea.Batch(nextCmd, m.recordAction(approvedPR, history.Approved))
 	}
 
 	return m, nextCmd
@@ -860,22 +1508,26 @@ func (m Model) handleAutoMergeEnabled(msg AutoMergeEnabledMsg) (Model, tea.Cmd)
 			item := m.findPRByID(msg.PRID)
 			if item != nil {
 				slog.Info("Auto-merge not needed, falling back to direct merge", slog.Any("pr", item.PR))
-				m.status = fmt.Sprintf("PR #%d ready for immediate merge...", item.PR.Number)
-				return m, MergeCmd(item.PR, "SQUASH", item.ID)
+				m.setStatus(statusInfo, fmt.Sprintf("PR #%d ready for immediate merge...", item.PR.Number))
+				return m, MergeCmd(m.ctx, item.PR, "SQUASH", item.ID)
 			}
 		}
 
 		// For any other auto-merge error, show the error to the user
 		slog.Error("Auto-merge enabling failed in UI", slog.Int64("prID", msg.PRID), slog.Any("error", msg.Err))
-		m.status = errorStyle.Render("Failed to enable auto-merge: " + msg.Err.Error())
+		m.setStatus(statusError, "Failed to enable auto-merge: "+msg.Err.Error())
 		return m, nil
 	}
 
 	// Auto-merge enabled successfully
+	m = m.updatePRByID(msg.PRID, func(item *PRItem) {
+		item.Merging = true
+	})
 	item := m.findPRByID(msg.PRID)
 	if item != nil {
 		slog.Info("Auto-merge enabled successfully in UI", slog.Any("pr", item.PR))
-		m.status = successStyle.Render(fmt.Sprintf("🔄 Auto-merge enabled for PR #%d", item.PR.Number))
+		m.setStatus(statusSuccess, m.actionStatus(fmt.Sprintf("🔄 Auto-merge enabled for PR #%d", item.PR.Number), fmt.Sprintf("would enable auto-merge for PR #%d", item.PR.Number)))
+		return m, m.recordAction(item, history.AutoMergeEnabled)
 	}
 
 	return m, nil
@@ -884,15 +1536,19 @@ func (m Model) handleAutoMergeEnabled(msg AutoMergeEnabledMsg) (Model, tea.Cmd)
 func (m Model) handlePRMerged(msg PRMergedMsg) (Model, tea.Cmd) {
 	if msg.Err != nil {
 		slog.Error("PR merging failed in UI", slog.Int64("prID", msg.PRID), slog.Any("error", msg.Err))
-		m.status = errorStyle.Render("Failed to merge PR: " + msg.Err.Error())
+		m.setStatus(statusError, "Failed to merge PR: "+msg.Err.Error())
 		return m, nil
 	}
 
 	// Find the PR item for status update
+	m = m.updatePRByID(msg.PRID, func(item *PRItem) {
+		item.Merging = true
+	})
 	item := m.findPRByID(msg.PRID)
 	if item != nil {
 		slog.Info("PR merged successfully in UI", slog.Any("pr", item.PR))
-		m.status = successStyle.Render(fmt.Sprintf("✅ Merged PR #%d", item.PR.Number))
+		m.setStatus(statusSuccess, m.actionStatus(fmt.Sprintf("✅ Merged PR #%d", item.PR.Number), fmt.Sprintf("would merge PR #%d", item.PR.Number)))
+		return m, m.recordAction(item, history.Merged)
 	}
 
 	return m, nil
@@ -901,6 +1557,11 @@ func (m Model) handlePRMerged(msg PRMergedMsg) (Model, tea.Cmd) {
 // Action handlers
 
 func (m Model) handleApprove() (Model, tea.Cmd) {
+	if m.github.Health().Degraded {
+		m.setStatus(statusInfo, "Approvals are disabled while GitHub is unavailable")
+		return m, nil
+	}
+
 	selected := m.list.SelectedItem()
 	if selected == nil {
 		slog.Debug("Approve action: no PR selected")
@@ -915,14 +1576,27 @@ func (m Model) handleApprove() (Model, tea.Cmd) {
 
 	if prItem.Approved {
 		slog.Debug("Approve action: PR already approved", slog.Any("pr", prItem.PR))
-		m.status = "PR already approved"
+		m.setStatus(statusInfo, "PR already approved")
+		return m, nil
+	}
+
+	if reason := m.approvalBlocked(prItem); reason != "" {
+		m.setStatus(statusInfo, reason)
+		return m, nil
+	}
+	if reason := m.policyBlocked(policy.Approve, prItem); reason != "" {
+		m.setStatus(statusError, reason)
+		return m, nil
+	}
+	m, ok = m.confirmStackOrder(prItem, false, "a")
+	if !ok {
 		return m, nil
 	}
 
 	slog.Info("User initiated PR approval", slog.Any("pr", prItem.PR),
 		slog.Bool("reviewed", prItem.Reviewed), slog.Bool("approved", prItem.Approved))
-	m.status = fmt.Sprintf("Approving PR #%d...", prItem.PR.Number)
-	return m, ApprovePRCmd(prItem.PR, prItem.ID)
+	m.setStatus(statusInfo, fmt.Sprintf("Approving PR #%d...", prItem.PR.Number))
+	return m, ApprovePRCmd(m.ctx, prItem.PR, prItem.ID)
 }
 
 func (m Model) handleView() (Model, tea.Cmd) {
@@ -943,6 +1617,11 @@ func (m Model) handleView() (Model, tea.Cmd) {
 }
 
 func (m Model) handleAutoMerge() (Model, tea.Cmd) {
+	if m.github.Health().Degraded {
+		m.setStatus(statusInfo, "Merging is disabled while GitHub is unavailable")
+		return m, nil
+	}
+
 	selected := m.list.SelectedItem()
 	if selected == nil {
 		slog.Debug("Auto-merge action: no PR selected")
@@ -957,20 +1636,29 @@ func (m Model) handleAutoMerge() (Model, tea.Cmd) {
 
 	slog.Info("User requested auto-merge", slog.Any("pr", prItem.PR))
 
+	if reason := m.mergeBlocked(prItem); reason != "" {
+		m.setStatus(statusError, reason)
+		return m, nil
+	}
+	m, ok = m.confirmStackOrder(prItem, true, "m")
+	if !ok {
+		return m, nil
+	}
+
 	// Check auto-merge configuration
 	switch m.config.GitHub.AutoMergeOnApproval {
 	case "false":
 		// Auto-merge disabled
-		m.status = "Auto-merge is disabled in configuration"
+		m.setStatus(statusInfo, "Auto-merge is disabled in configuration")
 		return m, nil
 	case "true", "ask", "":
 		// Always try auto-merge first - GitHub will tell us if it's not needed
-		m.status = fmt.Sprintf("Enabling auto-merge for PR #%d...", prItem.PR.Number)
-		return m, EnableAutoMergeCmd(prItem.PR, "SQUASH", prItem.ID)
+		m.setStatus(statusInfo, fmt.Sprintf("Enabling auto-merge for PR #%d...", prItem.PR.Number))
+		return m, EnableAutoMergeCmd(m.ctx, prItem.PR, "SQUASH", prItem.ID)
 	default:
 		// Default to auto-merge attempt
-		m.status = fmt.Sprintf("Enabling auto-merge for PR #%d...", prItem.PR.Number)
-		return m, EnableAutoMergeCmd(prItem.PR, "SQUASH", prItem.ID)
+		m.setStatus(statusInfo, fmt.Sprintf("Enabling auto-merge for PR #%d...", prItem.PR.Number))
+		return m, EnableAutoMergeCmd(m.ctx, prItem.PR, "SQUASH", prItem.ID)
 	}
 }
 
@@ -989,9 +1677,38 @@ func (m Model) handleDetails() (Model, tea.Cmd) {
 
 	slog.Info("User opened PR details popup", slog.Any("pr", prItem.PR))
 	m.showPopup = true
+	m.showTimeline = false
+	m.showErrors = false
 	m.popupScrollPos = 0 // Reset scroll position for new popup
 	m.popupContent = m.generateDetailContent(prItem)
-	return m, nil
+	return m, m.fetchCheckLogs(prItem)
+}
+
+// handleHealthTick probes GitHub while it is unavailable
+func (m Model) handleHealthTick() (Model, tea.Cmd) {
+	if !m.github.Health().Degraded {
+		m.stale = false
+		return m, HealthTickCmd()
+	}
+
+	if !m.stale {
+		slog.Warn("Entering stale mode")
+		m.stale = true
+	}
+	return m, tea.Batch(ProbeGitHubCmd(m.ctx, m.github), HealthTickCmd())
+}
+
+// handleHealthProbed leaves stale mode and refreshes once GitHub answers again
+func (m Model) handleHealthProbed(msg HealthProbedMsg) (Model, tea.Cmd) {
+	if msg.Err != nil || !m.stale || m.github.Health().Degraded {
+		return m, nil
+	}
+
+	slog.Info("Leaving stale mode")
+	m.stale = false
+	m, cmd := m.handleRefresh()
+	m.setStatus(statusInfo, "GitHub is reachable again, refreshing...")
+	return m, cmd
 }
 
 func (m Model) handleRefresh() (Model, tea.Cmd) {
@@ -999,25 +1716,31 @@ func (m Model) handleRefresh() (Model, tea.Cmd) {
 		slog.Bool("show_only_unreviewed", m.showOnlyUnreviewed))
 
 	m.loadingPRs = true
-	m.status = "Checking for updates..."
+	m.setStatus(statusInfo, "Checking for updates...")
+	m.nextRefresh = time.Now().Add(m.config.GitHub.AutoRefresh)
 
 	// Mark all existing reviews as loading to re-check review status
+	var prs []*github.PullRequest
 	for i := range m.items {
+		if m.items[i].Queue != m.activeQueue {
+			continue
+		}
 		m.items[i].LoadingReviews = true
+		prs = append(prs, m.items[i].PR)
 	}
 
 	// Re-apply filter to show loading state
 	m = m.updateVisibleItems()
 
 	return m, tea.Batch(
-		m.spinner.Tick,
-		SmartRefreshCmd(m.github),
+		m.spinnerTick(),
+		SmartRefreshCmd(m.ctx, m.github, prs, m.lastRefresh, m.activeQueue),
 	)
 }
 
 func (m Model) handleFilter() (Model, tea.Cmd) {
 	// Check if advanced filters are active (non-default values)
-	advancedFiltersActive := m.filterType != "all" || m.filterRepo != "all"
+	advancedFiltersActive := m.filterType != "all" || m.filterRepo != "all" || m.filterLanguage != "all"
 
 	slog.Info("User pressed f key",
 		slog.Bool("advanced_filters_active", advancedFiltersActive),
@@ -1044,7 +1767,7 @@ func (m Model) handleFilter() (Model, tea.Cmd) {
 		// Update legacy flag for consistency
 		m.showOnlyUnreviewed = (m.filterReviewStatus == "unreviewed")
 
-		m.status = fmt.Sprintf("Review filter: %s (advanced filters active - use F to modify)", m.filterReviewStatus)
+		m.setStatus(statusInfo, fmt.Sprintf("Review filter: %s (advanced filters active - use F to modify)", m.filterReviewStatus))
 	} else {
 		// Simple toggle when no advanced filters are active
 		oldFilter := m.showOnlyUnreviewed
@@ -1066,7 +1789,7 @@ func (m Model) handleFilter() (Model, tea.Cmd) {
 		if m.showOnlyUnreviewed {
 			filterStatus = "unreviewed only"
 		}
-		m.status = fmt.Sprintf("Filter toggled: showing %s PRs", filterStatus)
+		m.setStatus(statusInfo, fmt.Sprintf("Filter toggled: showing %s PRs", filterStatus))
 	}
 
 	// Update visible items based on new filter state (don't preserve selection for user-initiated filter)
@@ -1080,7 +1803,7 @@ func (m Model) handleFilter() (Model, tea.Cmd) {
 		slog.Int("visible_items", len(m.list.Items())),
 		slog.Int("total_items", len(m.items)))
 
-	return m, nil
+	return m.resumeCancelledAnalyses()
 }
 
 func (m Model) handleFilterAdvanced() (Model, tea.Cmd) {
@@ -1129,12 +1852,20 @@ func (m Model) applyAdvancedFilters() Model {
 		statusParts = append(statusParts, m.filterRepo+" repo")
 	}
 
+	if m.filterOwned {
+		statusParts = append(statusParts, "owned by me")
+	}
+
+	if m.filterLanguage != "all" {
+		statusParts = append(statusParts, m.filterLanguage+" changes")
+	}
+
 	if len(statusParts) > 1 {
-		m.status = fmt.Sprintf("Showing %s", strings.Join(statusParts, ", "))
+		m.setStatus(statusInfo, fmt.Sprintf("Showing %s", strings.Join(statusParts, ", ")))
 	} else if len(statusParts) == 1 {
-		m.status = fmt.Sprintf("Showing %s", statusParts[0])
+		m.setStatus(statusInfo, fmt.Sprintf("Showing %s", statusParts[0]))
 	} else {
-		m.status = "Showing all PRs"
+		m.setStatus(statusInfo, "Showing all PRs")
 	}
 
 	slog.Info("Advanced filters applied",
@@ -1152,12 +1883,18 @@ func (m Model) updateVisibleItems() Model {
 }
 
 func (m Model) updateVisibleItemsWithPreserveSelection(preserveSelection bool) Model {
+	m.loadGen++
 	if len(m.items) == 0 {
 		slog.Debug("No items to filter", slog.Bool("preserve_selection", preserveSelection))
 		return m
 	}
 
 	start := time.Now()
+	m.annotateGroups()
+	m.annotateStacks()
+	m.annotateAlerts()
+	m.annotateOwnership()
+	m.annotateTemplates()
 
 	slog.Debug("Starting filter operation",
 		slog.String("review_status_filter", m.filterReviewStatus),
@@ -1182,8 +1919,12 @@ func (m Model) updateVisibleItemsWithPreserveSelection(preserveSelection bool) M
 	loadingCount := 0
 	typeFilteredCount := 0
 	repoFilteredCount := 0
+	rereview := m.config.Review.RereviewChanged
 
 	for _, item := range m.items {
+		if item.Queue != m.activeQueue {
+			continue
+		}
 		shouldShow := true
 
 		// Count review states for logging
@@ -1207,12 +1948,13 @@ func (m Model) updateVisibleItemsWithPreserveSelection(preserveSelection bool) M
 			// - Not reviewed AND not approved yet, OR
 			// - Review was dismissed (needs re-review), OR
 			// - Review status is still being loaded, OR
+			// - It changed since my approval and re-reviews are configured, OR
 			// - It's the currently selected PR (prevent jarring disappearance)
 			shouldShow = shouldShow && ((!item.Reviewed && !item.Approved) || item.Dismissed || item.LoadingReviews ||
-				(selectedPRNumber > 0 && item.PR.Number == selectedPRNumber))
+				(rereview && item.ChangedSinceApproval()) || (selectedPRNumber > 0 && item.PR.Number == selectedPRNumber))
 		case "reviewed":
 			// Show only reviewed PRs (approved or other review states)
-			shouldShow = shouldShow && (item.Reviewed || item.Approved)
+			shouldShow = shouldShow && (item.Reviewed || item.Approved) && !(rereview && item.ChangedSinceApproval())
 		}
 		// "all" - no review status filtering
 
@@ -1244,6 +1986,16 @@ func (m Model) updateVisibleItemsWithPreserveSelection(preserveSelection bool) M
 			}
 		}
 
+		// Apply ownership filter
+		if shouldShow && m.filterOwned {
+			shouldShow = item.OwnedFiles > 0
+		}
+
+		// Apply language filter
+		if shouldShow && m.filterLanguage != "all" {
+			shouldShow = slices.Contains(item.Languages(), m.filterLanguage)
+		}
+
 		if shouldShow {
 			visibleItems = append(visibleItems, item)
 		} else {
@@ -1276,61 +2028,46 @@ func (m Model) updateVisibleItemsWithPreserveSelection(preserveSelection bool) M
 		slog.Int("loading_count", loadingCount),
 		slog.Duration("duration", duration))
 
-	// Update the list with filtered items
-	m.list.SetItems(visibleItems)
+	// Update the list with filtered items by attention score: security fixes
+	// first, then dependency groups kept together
+	m.list.SetItems(groupAdjacent(prioritizeAlertFixes(m.sortByAttention(visibleItems))))
+
+	// Scores change as PRs load, so the selection follows its PR rather than
+	// staying at its index
+	if preserveSelection && selectedPRNumber > 0 {
+		selectedID := itemID(currentSelection)
+		if i := slices.IndexFunc(m.list.Items(), func(li list.Item) bool { return itemID(li) == selectedID }); i >= 0 {
+			m.list.Select(i)
+		}
+	}
+	m.cancelHiddenWork()
 
 	return m
 }
 
-// determinePRType analyzes a PR to determine its type based on file changes
+// determinePRType decides a PR's type from whatever is loaded so far: the AI
+// analysis, changed files, author, title and size
 func (m Model) determinePRType(item PRItem) string {
-	// If diff stats aren't loaded yet, return "mixed" as default
-	if item.DiffStats == nil {
-		slog.Debug("PR type detection: no diff stats available", slog.Any("pr", item.PR.Number))
-		return "mixed"
-	}
-
-	// Analyze PR title and content for type hints
-	title := strings.ToLower(item.PR.Title)
-
-	// Check for dependency updates in title
-	if slices.ContainsFunc(dependencyKeywords, func(keyword string) bool {
-		return strings.Contains(title, keyword)
-	}) {
-		slog.Debug("PR type detection: detected dependencies from title", slog.String("title", item.PR.Title))
-		return "dependencies"
+	facts := classify.PR{
+		Title:  item.PR.Title,
+		Author: item.PR.GetAuthor(),
+		Files:  item.ChangedFiles,
 	}
-
-	// Check for documentation keywords in title
-	if slices.ContainsFunc(documentationKeywords, func(keyword string) bool {
-		return strings.Contains(title, keyword)
-	}) {
-		slog.Debug("PR type detection: detected docs from title", slog.String("title", item.PR.Title))
-		return "docs"
-	}
-
-	// Use file count and change size as heuristics
-	// Small changes with few files often indicate docs or config
-	if item.DiffStats.Files <= 2 && item.DiffStats.Additions+item.DiffStats.Deletions < 100 {
-		slog.Debug("PR type detection: small change, assuming docs",
-			slog.Int("files", item.DiffStats.Files),
-			slog.Int("total_changes", item.DiffStats.Additions+item.DiffStats.Deletions))
-		return "docs"
+	if item.AIAnalysis != nil {
+		facts.AIType = item.AIAnalysis.PRType
 	}
-
-	// Large changes with many files suggest mixed or significant code changes
-	if item.DiffStats.Files > 20 || item.DiffStats.Additions+item.DiffStats.Deletions > 1000 {
-		slog.Debug("PR type detection: large change, assuming mixed",
-			slog.Int("files", item.DiffStats.Files),
-			slog.Int("total_changes", item.DiffStats.Additions+item.DiffStats.Deletions))
-		return "mixed"
+	if item.DiffStats != nil {
+		facts.Sized = true
+		facts.Lines, facts.FileCount = item.DiffStats.Reviewable()
 	}
 
-	// Medium-sized changes default to code
-	slog.Debug("PR type detection: medium change, assuming code",
-		slog.Int("files", item.DiffStats.Files),
-		slog.Int("total_changes", item.DiffStats.Additions+item.DiffStats.Deletions))
-	return "code"
+	result := m.classifier.Classify(facts)
+	slog.Debug("PR type detected",
+		slog.Int("pr_number", item.PR.Number),
+		slog.String("type", result.Type),
+		slog.String("source", result.Source),
+		slog.Float64("confidence", result.Confidence))
+	return result.Type
 }
 
 func (m Model) moveToNext() tea.Cmd {
@@ -1364,20 +2101,42 @@ func (m Model) triggerAIAnalysisIfReady(itemIndex int) tea.Cmd {
 		slog.Bool("HasReviewError", item.ReviewError != nil),
 		slog.String("HeadSHA", item.PR.HeadSHA))
 
+	// Dependency bump groups share one analysis
+	if members := m.dependencyGroup(item.ID); len(members) > 1 {
+		return m.triggerGroupAnalysis(members)
+	}
+
 	// Check if we have all required data and haven't started AI analysis yet
-	if !item.LoadingDiff && !item.LoadingChecks && !item.LoadingReviews &&
-		item.LoadingAI && item.DiffStats != nil && item.CheckStatus != nil &&
-		item.Reviews != nil && item.DiffError == nil && item.CheckError == nil && item.ReviewError == nil &&
-		item.PR.HeadSHA != "" {
+	if item.readyForAnalysis() {
+
+		// Don't spend AI time on PRs the filters hide
+		if !m.isVisible(item.ID) {
+			slog.Debug("Skipping AI analysis for hidden PR", slog.Any("pr", item.PR))
+			return skippedAnalysisCmd(item.ID)
+		}
 
 		slog.Debug("All conditions met, triggering AI analysis", slog.Any("pr", item.PR))
-		return FetchAIAnalysisCmd(m.aiAgent, item.PR, item.DiffStats, item.CheckStatus, item.Reviews, item.ID, m.config.AI.AnalysisTimeout)
+		return FetchAIAnalysisCmd(m.prContext(item.ID), m.aiAgent, item.Snapshot, item.Issues,
+			github.RequiresSignedCommits(item.PR.Owner, item.PR.Repo, m.config.GitHub.RequireSigned), nil, m.sizeBudget(), m.config.Review.TemplatePrompt, item.ID, m.config.AI.AnalysisTimeout)
 	}
 
 	slog.Debug("AI analysis conditions not met", slog.Any("pr", item.PR))
 	return nil
 }
 
+// handleToggleReasoning shows or hides the reasoning summary in the details
+// popup, for every PR until it's toggled again
+func (m Model) handleToggleReasoning() (Model, tea.Cmd) {
+	prItem, ok := m.list.SelectedItem().(PRItem)
+	if !ok {
+		return m, nil
+	}
+	m.showReasoning = !m.showReasoning
+	slog.Debug("Toggled reasoning summary", slog.Bool("shown", m.showReasoning))
+	m.popupContent = m.generateDetailContent(prItem)
+	return m, nil
+}
+
 // generateDetailContent creates detailed content for a PR popup
 func (m Model) generateDetailContent(item PRItem) string {
 	var content strings.Builder
@@ -1398,15 +2157,31 @@ func (m Model) generateDetailContent(item PRItem) string {
 		}
 		content.WriteString(fmt.Sprintf("**Head SHA:** `%s`\n", sha))
 	}
+	if m.config.Priority.Sort != config.SortSearch {
+		content.WriteString(m.attentionDetailContent(item))
+	}
 
 	content.WriteString("\n---\n\n")
 
+	content.WriteString(alertDetailContent(item))
+	content.WriteString(m.groupDetailContent(item))
+	content.WriteString(m.stackDetailContent(item))
+
 	// Diff Stats
 	if item.DiffStats != nil {
 		content.WriteString("## 📊 Changes\n\n")
 		content.WriteString(fmt.Sprintf("- **%d** additions\n", item.DiffStats.Additions))
 		content.WriteString(fmt.Sprintf("- **%d** deletions\n", item.DiffStats.Deletions))
 		content.WriteString(fmt.Sprintf("- **%d** files changed\n", item.DiffStats.Files))
+		if excluded := item.DiffStats.Excluded; len(excluded) > 0 {
+			content.WriteString(fmt.Sprintf("- **%d** not reviewed as source:\n", len(excluded)))
+			for _, file := range excluded {
+				content.WriteString(fmt.Sprintf("  - 🧱 `%s` (%s)\n", file.Path, file.Kind))
+			}
+		}
+		if item.OverBudget != "" {
+			content.WriteString(fmt.Sprintf("- 📏 **Over size budget:** %s\n", item.OverBudget))
+		}
 		content.WriteString("\n")
 	} else if item.LoadingDiff {
 		content.WriteString("## 📊 Changes\n\n*Loading diff statistics...*\n\n")
@@ -1432,13 +2207,18 @@ func (m Model) generateDetailContent(item PRItem) string {
 				case "pending", "in_progress":
 					status = "⏳"
 				}
-				content.WriteString(fmt.Sprintf("- %s %s\n", status, detail.Name))
+				flaky := ""
+				if detail.Flaky {
+					flaky = " 🎲 likely flaky"
+				}
+				content.WriteString(fmt.Sprintf("- %s %s%s\n", status, detail.Name, flaky))
 			}
 		}
 		content.WriteString("\n")
 	} else if item.LoadingChecks {
 		content.WriteString("## ✅ Checks\n\n*Loading check status...*\n\n")
 	}
+	content.WriteString(m.checkLogContent(item))
 
 	// Reviews
 	if item.Reviews != nil {
@@ -1471,7 +2251,9 @@ func (m Model) generateDetailContent(item PRItem) string {
 			}
 
 			content.WriteString("\n**Your Status:** ")
-			if userApproved {
+			if item.ChangedSinceApproval() {
+				content.WriteString(fmt.Sprintf("🔁 Changed since your approval of `%.8s`, re-review needed", item.ApprovedSHA))
+			} else if userApproved {
 				content.WriteString("✅ Approved")
 			} else if userReviewed {
 				content.WriteString("👀 Reviewed")
@@ -1484,6 +2266,37 @@ func (m Model) generateDetailContent(item PRItem) string {
 		content.WriteString("## 👥 Reviews\n\n*Loading reviews...*\n\n")
 	}
 
+	// Commit Signing
+	if sigs := item.Signatures; sigs != nil {
+		content.WriteString("## 🔏 Commit Signing\n\n")
+		content.WriteString(fmt.Sprintf("- **%d/%d** commits have verified signatures\n", sigs.Commits-sigs.Unverified, sigs.Commits))
+		if dco := github.DCOStatus(item.CheckStatus, sigs); dco != "" {
+			content.WriteString(fmt.Sprintf("- **DCO:** %s\n", dco))
+		}
+		if github.RequiresSignedCommits(item.PR.Owner, item.PR.Repo, m.config.GitHub.RequireSigned) {
+			content.WriteString("- This repository requires signed commits\n")
+		}
+		content.WriteString("\n")
+	} else if item.LoadingSigs {
+		content.WriteString("## 🔏 Commit Signing\n\n*Checking commit signatures...*\n\n")
+	}
+
+	// Linked Issues
+	if len(item.Issues) > 0 {
+		content.WriteString("## 🎫 Linked Issues\n\n")
+		for _, issue := range item.Issues {
+			content.WriteString(fmt.Sprintf("- **%s** [%s] %s\n", issue.Key, issue.Status, issue.Summary))
+		}
+		content.WriteString("\n")
+	} else if item.LoadingIssues {
+		content.WriteString("## 🎫 Linked Issues\n\n*Loading linked issues...*\n\n")
+	} else if item.IssueError != nil {
+		content.WriteString(fmt.Sprintf("## 🎫 Linked Issues\n\n*Failed to load: %s*\n\n", item.IssueError))
+	}
+
+	content.WriteString(templateDetailContent(item))
+	content.WriteString(m.checklistDetailContent(item))
+
 	// AI Analysis
 	if item.AIAnalysis != nil {
 		content.WriteString("## 🤖 AI Analysis\n\n")
@@ -1492,25 +2305,57 @@ func (m Model) generateDetailContent(item PRItem) string {
 		if item.AIAnalysis.Reasoning != "" {
 			content.WriteString(fmt.Sprintf("\n**Reasoning:**\n%s\n", item.AIAnalysis.Reasoning))
 		}
+		if summary := item.AIAnalysis.ReasoningSummary; summary != "" {
+			if m.showReasoning {
+				content.WriteString(fmt.Sprintf("\n**Reasoning summary:**\n%s\n", summary))
+			} else {
+				content.WriteString("\n*Press **t** for the model's reasoning summary*\n")
+			}
+		}
+		content.WriteString(provenanceDetailContent(item.AIAnalysis.Provenance))
 		content.WriteString("\n")
 	} else if item.LoadingAI {
 		content.WriteString("## 🤖 AI Analysis\n\n*Running AI analysis...*\n\n")
+	} else if m.aiAgent != nil && !m.aiAgent.RepoAllowed(item.PR.Owner, item.PR.Repo) {
+		content.WriteString("## 🤖 AI Analysis\n\n*PRs from this repository aren't sent to the AI*\n\n")
 	} else if m.aiAgent != nil {
 		content.WriteString("## 🤖 AI Analysis\n\n*AI analysis will run when all data is loaded*\n\n")
 	}
 
 	// Footer
 	content.WriteString("---\n\n")
-	content.WriteString("*Press **Enter** or **Esc** to close*")
+	content.WriteString("*Press **Tab** for the timeline, **Enter** or **Esc** to close*")
 
 	return content.String()
 }
 
+// provenanceDetailContent says what produced an AI analysis, for analyses
+// that recorded it
+func provenanceDetailContent(p *agent.Provenance) string {
+	if p == nil {
+		return ""
+	}
+
+	model := p.Model
+	if p.Deployment != "" {
+		model += " via " + p.Deployment
+	}
+	if p.ReasoningEffort != "" {
+		model += ", " + p.ReasoningEffort + " effort"
+	}
+
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("\n**Analyzed:** %s by %s (%s)\n", p.AnalyzedAt.Local().Format("Jan 2, 2006 at 3:04 PM"), model, p.Provider))
+	content.WriteString(fmt.Sprintf("**Prompt version:** %s • speedrun %s\n", p.PromptVersion, p.Speedrun))
+	if len(p.Tools) > 0 {
+		content.WriteString(fmt.Sprintf("**Tools:** %s\n", strings.Join(p.Tools, ", ")))
+	}
+	return content.String()
+}
+
 // renderAdvancedFilterDialog renders the advanced filter dialog
 func (m Model) renderAdvancedFilterDialog(baseView string) string {
-	// Get terminal dimensions from the list widget
-	width := m.list.Width()
-	height := m.list.Height() + 4 // Account for status and help
+	width, height := m.width, m.height
 
 	// Define dialog dimensions
 	dialogWidth := min(width*8/10, 80)
@@ -1584,6 +2429,26 @@ func (m Model) renderAdvancedFilterDialog(baseView string) string {
 		content.WriteString(fmt.Sprintf("  %s%s %s\n", indicator, option.key, option.label))
 	}
 
+	content.WriteString("\n")
+
+	// Ownership Section
+	content.WriteString("Ownership:\n")
+	indicator := "☐ "
+	if m.filterOwned {
+		indicator = "☑ "
+	}
+	content.WriteString(fmt.Sprintf("  %so Owned by me (CODEOWNERS)\n", indicator))
+
+	content.WriteString("\n")
+
+	// Language Section
+	content.WriteString("Language:\n")
+	language := "All languages"
+	if m.filterLanguage != "all" {
+		language = m.filterLanguage + " changes"
+	}
+	content.WriteString(fmt.Sprintf("  l %s (press to cycle)\n", language))
+
 	content.WriteString("\nPress Enter to apply filters or Esc to cancel")
 
 	// Create dialog border style
@@ -1595,7 +2460,7 @@ func (m Model) renderAdvancedFilterDialog(baseView string) string {
 		Padding(1).
 		Width(dialogWidth - 4) // Account for border and padding
 
-	dialog := borderStyle.Render(content.String())
+	dialog := borderStyle.Render(m.label(content.String()))
 
 	// Center the dialog on screen
 	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, dialog)
@@ -1603,20 +2468,9 @@ func (m Model) renderAdvancedFilterDialog(baseView string) string {
 
 // renderPopup renders the popup overlay
 func (m Model) renderPopup(baseView string) string {
-	// Get terminal dimensions from the list widget
-	width := m.list.Width()
-	height := m.list.Height() + 4 // Account for status and help
-
-	// Define popup dimensions (80% of screen to leave more background visible)
-	popupWidth := min(width*8/10, 100)
-	popupHeight := min(height*8/10, 35)
-
-	// Format content and handle scrolling
-	formattedContent := m.formatPopupContent(m.popupContent, popupWidth-6)
-	contentLines := strings.Split(formattedContent, "\n")
-
-	// Calculate visible area (reserve space for border and padding)
-	visibleHeight := popupHeight - 4 // Account for border (2) + padding (2)
+	width, height := m.width, m.height
+	popupWidth, visibleHeight := m.popupSize()
+	contentLines := m.popupLines()
 
 	// Ensure scroll position is within bounds
 	maxScroll := max(0, len(contentLines)-visibleHeight)
@@ -1654,7 +2508,7 @@ func (m Model) renderPopup(baseView string) string {
 		Background(lipgloss.Color("235")). // Slightly lighter background for contrast
 		Foreground(lipgloss.Color("255")). // Bright white text
 		Padding(1).
-		Width(popupWidth - 4) // Account for border and padding
+		Width(max(popupWidth-4, 1)) // Account for border and padding
 
 	popup := borderStyle.Render(content)
 
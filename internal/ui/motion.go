@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reducedMotionFPS caps how often the screen is redrawn in reduced-motion
+// mode, so updates arriving together are drawn once
+const reducedMotionFPS = 4
+
+// reducedMotionTick is the slowest a timer redraws the screen in
+// reduced-motion mode
+const reducedMotionTick = 5 * time.Second
+
+// staticSpinner stands in for the spinner animation in reduced-motion mode
+var staticSpinner = spinner.Spinner{Frames: []string{"⏳"}, FPS: time.Hour}
+
+// ProgramOptions returns the Bubble Tea options for running the TUI as
+// configured
+func (m Model) ProgramOptions() []tea.ProgramOption {
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+	if m.config.UI.ReducedMotion {
+		opts = append(opts, tea.WithFPS(reducedMotionFPS))
+	}
+	return opts
+}
+
+// spinnerTick starts the spinner, unless it doesn't animate
+func (m Model) spinnerTick() tea.Cmd {
+	if m.config.UI.ReducedMotion {
+		return nil
+	}
+	return m.spinner.Tick
+}
+
+// tickInterval slows a timer that only redraws the screen in reduced-motion
+// mode
+func (m Model) tickInterval(d time.Duration) time.Duration {
+	if m.config.UI.ReducedMotion && d < reducedMotionTick {
+		return reducedMotionTick
+	}
+	return d
+}
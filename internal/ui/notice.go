@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/github"
+)
+
+// noticeDuration is how long a transient notice stays in the status line
+const noticeDuration = 8 * time.Second
+
+// NoticeExpiredMsg clears a notice unless a newer one replaced it
+type NoticeExpiredMsg struct {
+	Seq int
+}
+
+// RemovedPRsCheckedMsg reports what happened to PRs that dropped out of a refresh
+type RemovedPRsCheckedMsg struct {
+	Merged int
+	Closed int
+}
+
+// CheckRemovedPRsCmd looks up the state of PRs that disappeared from the
+// search results, so the user can be told they were merged or closed elsewhere
+func CheckRemovedPRsCmd(ctx context.Context, prs []*github.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		var msg RemovedPRsCheckedMsg
+		for _, pr := range prs {
+			state, err := pr.GetState(ctx)
+			if err != nil {
+				slog.Debug("Failed to check removed PR state", slog.Any("pr", pr), slog.Any("error", err))
+				continue
+			}
+
+			slog.Info("PR removed from list", slog.Any("pr", pr), slog.String("state", string(state)))
+			switch state {
+			case github.PRStateMerged:
+				msg.Merged++
+			case github.PRStateClosed:
+				msg.Closed++
+			}
+		}
+		return msg
+	}
+}
+
+// showNotice puts a message in front of the status line for a few seconds
+func (m Model) showNotice(text string) (Model, tea.Cmd) {
+	m.noticeSeq++
+	m.notice = text
+	m.recordStatus(statusMessage{Level: statusInfo, Text: text, At: time.Now()})
+
+	seq := m.noticeSeq
+	return m, tea.Tick(noticeDuration, func(time.Time) tea.Msg {
+		return NoticeExpiredMsg{Seq: seq}
+	})
+}
+
+// handleNoticeExpired clears the notice it was scheduled for
+func (m Model) handleNoticeExpired(msg NoticeExpiredMsg) (Model, tea.Cmd) {
+	if msg.Seq == m.noticeSeq {
+		m.notice = ""
+	}
+	return m, nil
+}
+
+// handleRemovedPRsChecked tells the user about PRs merged or closed by someone else
+func (m Model) handleRemovedPRsChecked(msg RemovedPRsCheckedMsg) (Model, tea.Cmd) {
+	var parts []string
+	if msg.Merged > 0 {
+		parts = append(parts, fmt.Sprintf("%d %s merged elsewhere", msg.Merged, pluralPRs(msg.Merged)))
+	}
+	if msg.Closed > 0 {
+		parts = append(parts, fmt.Sprintf("%d %s closed elsewhere", msg.Closed, pluralPRs(msg.Closed)))
+	}
+	if len(parts) == 0 {
+		return m, nil
+	}
+	return m.showNotice("🔀 " + strings.Join(parts, ", "))
+}
+
+// tokenNotice summarizes what the GitHub token can't do, for a startup notice
+func tokenNotice(report *github.TokenReport) string {
+	if report == nil {
+		return ""
+	}
+
+	var problems []string
+	for _, capability := range report.Unavailable {
+		problems = append(problems, capability.Name)
+	}
+	for _, org := range slices.Sorted(maps.Keys(report.SSO)) {
+		problems = append(problems, "SSO for "+org)
+	}
+	if len(problems) == 0 {
+		return ""
+	}
+	return "⚠️ Token missing: " + strings.Join(problems, ", ") + " (see log)"
+}
+
+// pluralPRs returns "PR" or "PRs" to match n
+func pluralPRs(n int) string {
+	if n == 1 {
+		return "PR"
+	}
+	return "PRs"
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/codeowners"
+	"github.com/kennyp/speedrun/pkg/github"
+)
+
+// CodeownersLoadedMsg is sent when a repository's CODEOWNERS file has been fetched
+type CodeownersLoadedMsg struct {
+	Repo  string // owner/repo
+	Rules codeowners.Ruleset
+	Err   error
+}
+
+// ChangedFilesLoadedMsg is sent when a PR's changed paths have been fetched
+type ChangedFilesLoadedMsg struct {
+	PRID  int64
+	Files []string
+	Err   error
+}
+
+// FetchCodeownersCmd fetches a repository's CODEOWNERS file
+func FetchCodeownersCmd(ctx context.Context, client *github.Client, owner, repo string) tea.Cmd {
+	return func() tea.Msg {
+		rules, err := client.GetCodeowners(ctx, owner, repo)
+		return CodeownersLoadedMsg{Repo: owner + "/" + repo, Rules: rules, Err: err}
+	}
+}
+
+// FetchChangedFilesCmd fetches the paths a PR changes
+func FetchChangedFilesCmd(ctx context.Context, pr *github.PullRequest, prID int64) tea.Cmd {
+	return func() tea.Msg {
+		files, err := pr.GetChangedFiles(ctx)
+		return ChangedFilesLoadedMsg{PRID: prID, Files: files, Err: err}
+	}
+}
+
+// fetchCodeowners fetches CODEOWNERS for every repository in the list
+func (m Model) fetchCodeowners() tea.Cmd {
+	var cmds []tea.Cmd
+	seen := make(map[string]bool)
+	for _, item := range m.items {
+		repo := item.PR.Owner + "/" + item.PR.Repo
+		if seen[repo] {
+			continue
+		}
+		seen[repo] = true
+		cmds = append(cmds, FetchCodeownersCmd(m.ctx, m.github, item.PR.Owner, item.PR.Repo))
+	}
+	return tea.Batch(cmds...)
+}
+
+// ownerHandles returns the CODEOWNERS handles that count as me: my login
+// and my configured teams
+func (m Model) ownerHandles() []string {
+	handles := []string{"@" + m.username}
+	for _, team := range m.config.GitHub.Teams {
+		handles = append(handles, "@"+strings.TrimPrefix(team, "@"))
+	}
+	return handles
+}
+
+// annotateOwnership records on each item how many of its files I own
+func (m Model) annotateOwnership() {
+	handles := m.ownerHandles()
+	for i := range m.items {
+		item := &m.items[i]
+		item.OwnedFiles = m.codeowners[item.PR.Owner+"/"+item.PR.Repo].OwnedBy(item.ChangedFiles, handles)
+	}
+}
+
+func (m Model) handleCodeownersLoaded(msg CodeownersLoadedMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		slog.Debug("CODEOWNERS unavailable", slog.String("repo", msg.Repo), slog.Any("error", msg.Err))
+		return m, nil
+	}
+
+	if m.codeowners == nil {
+		m.codeowners = make(map[string]codeowners.Ruleset)
+	}
+	m.codeowners[msg.Repo] = msg.Rules
+
+	m = m.updateVisibleItems()
+	return m, nil
+}
+
+func (m Model) handleChangedFilesLoaded(msg ChangedFilesLoadedMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		slog.Debug("Changed files unavailable", slog.Int64("prID", msg.PRID), slog.Any("error", msg.Err))
+		return m, nil
+	}
+
+	m = m.updatePRByID(msg.PRID, func(item *PRItem) {
+		item.ChangedFiles = append([]string{}, msg.Files...) // Non-nil: loaded, even if empty
+	})
+
+	m = m.updateVisibleItems()
+	return m, nil
+}
@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/kennyp/speedrun/pkg/agent"
+	"github.com/kennyp/speedrun/pkg/config"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/tracker"
+)
+
+// plainFetchers bounds how many PRs are loaded at once for plain output
+const plainFetchers = 8
+
+// RenderPlain writes the PR queue and its analyses as plain text. It is used
+// instead of the TUI when stdout is not a terminal, so it loads everything up
+// front and prints once.
+func RenderPlain(ctx context.Context, w io.Writer, cfg *config.Config, githubClient *github.Client, aiAgent *agent.Agent, issueTracker tracker.Tracker, username string) error {
+	prs, err := githubClient.SearchPullRequests(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to search pull requests: %w", err)
+	}
+
+	items := loadPlainItems(ctx, cfg, githubClient, issueTracker, username, prs)
+	eachItem(len(items), func(i int) {
+		analyzePlainItem(ctx, cfg, aiAgent, &items[i])
+	})
+
+	if _, err := fmt.Fprintf(w, "Pull Requests for %s (%d)\n", username, len(items)); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	for _, item := range items {
+		if _, err := io.WriteString(w, plainEntry(item, cfg)); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+	return nil
+}
+
+// RenderPR runs the full detail fetch and AI analysis for one PR and writes
+// the result as plain text
+func RenderPR(ctx context.Context, w io.Writer, cfg *config.Config, githubClient *github.Client, aiAgent *agent.Agent, issueTracker tracker.Tracker, username string, pr *github.PullRequest) error {
+	item := loadPlainItem(ctx, cfg, githubClient, issueTracker, username, pr)
+	analyzePlainItem(ctx, cfg, aiAgent, &item)
+	if _, err := io.WriteString(w, strings.TrimPrefix(plainEntry(item, cfg), "\n")); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	if item.AIError != nil {
+		return fmt.Errorf("failed to analyze PR: %w", item.AIError)
+	}
+	return nil
+}
+
+// eachItem calls fn for indexes 0..n-1, at most plainFetchers at a time
+func eachItem(n int, fn func(i int)) {
+	sem := make(chan struct{}, plainFetchers)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			fn(i)
+		}()
+	}
+	wg.Wait()
+}
+
+// loadPlainItems loads the details of every PR, without AI analysis
+func loadPlainItems(ctx context.Context, cfg *config.Config, githubClient *github.Client, issueTracker tracker.Tracker, username string, prs []*github.PullRequest) []PRItem {
+	items := make([]PRItem, len(prs))
+	eachItem(len(prs), func(i int) {
+		items[i] = loadPlainItem(ctx, cfg, githubClient, issueTracker, username, prs[i])
+	})
+	return items
+}
+
+// loadPlainItem fetches what the TUI would show for a PR, running the same
+// commands synchronously
+func loadPlainItem(ctx context.Context, cfg *config.Config, githubClient *github.Client, issueTracker tracker.Tracker, username string, pr *github.PullRequest) PRItem {
+	item := PRItem{Snapshot: github.Snapshot{PR: pr}}
+
+	diff := FetchDiffStatsCmd(ctx, githubClient, pr, 0)().(DiffStatsLoadedMsg)
+	item.DiffStats, item.DiffError = diff.Stats, diff.Err
+	if diff.Stats != nil {
+		item.OverBudget = configuredBudget(cfg).Exceeded(diff.Stats.Additions+diff.Stats.Deletions, diff.Stats.Files)
+	}
+
+	checks := FetchCheckStatusCmd(ctx, githubClient, pr, 0)().(CheckStatusLoadedMsg)
+	item.CheckStatus, item.CheckError = checks.Status, checks.Err
+
+	reviews := FetchReviewsCmd(ctx, githubClient, pr, username, 0)().(ReviewsLoadedMsg)
+	item.Reviews, item.ReviewError = reviews.Reviews, reviews.Err
+	item.ApprovedSHA = github.ApprovedCommit(reviews.Reviews, username)
+	for _, review := range reviews.Reviews {
+		if review.User == username {
+			item.Reviewed = true
+			switch review.State {
+			case "APPROVED":
+				item.Approved = true
+			case "DISMISSED":
+				item.Dismissed = true
+			}
+		}
+	}
+
+	sigs := FetchSignaturesCmd(ctx, pr, 0)().(SignaturesLoadedMsg)
+	item.Signatures, item.SigError = sigs.Signatures, sigs.Err
+
+	if issueTracker != nil {
+		issues := FetchLinkedIssuesCmd(ctx, issueTracker, pr, 0)().(LinkedIssuesLoadedMsg)
+		item.Issues, item.IssueError = issues.Issues, issues.Err
+	}
+
+	return item
+}
+
+// analyzePlainItem runs the AI analysis once the item's details are loaded
+func analyzePlainItem(ctx context.Context, cfg *config.Config, aiAgent *agent.Agent, item *PRItem) {
+	if aiAgent == nil || item.DiffStats == nil || item.CheckStatus == nil || item.ReviewError != nil {
+		return
+	}
+
+	pr := item.PR
+	signaturesRequired := github.RequiresSignedCommits(pr.Owner, pr.Repo, cfg.GitHub.RequireSigned)
+	analysis := FetchAIAnalysisCmd(ctx, aiAgent, item.Snapshot, item.Issues, signaturesRequired, nil, configuredBudget(cfg), cfg.Review.TemplatePrompt, 0, cfg.AI.AnalysisTimeout)().(AIAnalysisLoadedMsg)
+	item.AIAnalysis, item.AIError = analysis.Analysis, analysis.Err
+}
+
+// plainEntry renders one PR with text labels, its URL and the AI reasoning
+func plainEntry(item PRItem, cfg *config.Config) string {
+	text := textItem{PRItem: item, overdue: item.BreachesSLA(cfg.GitHub.ReviewSLA)}
+
+	var entry strings.Builder
+	entry.WriteString("\n" + text.Title() + "\n")
+	entry.WriteString(fmt.Sprintf("  https://github.com/%s/%s/pull/%d\n", item.PR.Owner, item.PR.Repo, item.PR.Number))
+	entry.WriteString("  " + text.Description() + "\n")
+	if item.AIAnalysis != nil && item.AIAnalysis.Reasoning != "" {
+		for line := range strings.SplitSeq(strings.TrimSpace(item.AIAnalysis.Reasoning), "\n") {
+			entry.WriteString("    " + line + "\n")
+		}
+	}
+	return entry.String()
+}
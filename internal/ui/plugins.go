@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/plugin"
+)
+
+// PluginDoneMsg is sent when a plugin has run on a PR
+type PluginDoneMsg struct {
+	PRID   int64
+	Plugin string
+	Output string
+	Err    error
+}
+
+// RunPluginCmd runs a plugin on a PR
+func RunPluginCmd(ctx context.Context, p plugin.Plugin, pr plugin.PR, timeout time.Duration, prID int64) tea.Cmd {
+	return func() tea.Msg {
+		output, err := p.Run(ctx, pr, timeout)
+		return PluginDoneMsg{PRID: prID, Plugin: p.Name, Output: output, Err: err}
+	}
+}
+
+// pluginInput describes a PR the way plugins receive it
+func (m Model) pluginInput(item PRItem) plugin.PR {
+	pr := plugin.PR{
+		Owner:        item.PR.Owner,
+		Repo:         item.PR.Repo,
+		Number:       item.PR.Number,
+		Title:        item.PR.Title,
+		URL:          item.HTMLURL(),
+		Author:       item.PR.GetAuthor(),
+		Labels:       item.PR.GetLabels(),
+		Body:         item.PR.GetBody(),
+		HeadSHA:      item.PR.HeadSHA,
+		BaseRef:      item.PR.BaseRef,
+		HeadRef:      item.PR.HeadRef,
+		ChangedFiles: item.ChangedFiles,
+		User:         m.username,
+		DryRun:       m.github.DryRun(),
+	}
+	if item.DiffStats != nil {
+		pr.Additions, pr.Deletions = item.DiffStats.Additions, item.DiffStats.Deletions
+	}
+	if item.CheckStatus != nil {
+		pr.Checks = item.CheckStatus.State
+	}
+	if item.AIAnalysis != nil {
+		pr.Recommendation, pr.Risk = string(item.AIAnalysis.Recommendation), item.AIAnalysis.RiskLevel
+	}
+	return pr
+}
+
+func (m Model) handlePluginDone(msg PluginDoneMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		slog.Error("Plugin failed", slog.Int64("prID", msg.PRID), slog.String("plugin", msg.Plugin), slog.Any("error", msg.Err))
+		m.setStatus(statusError, msg.Err.Error())
+		return m, nil
+	}
+
+	number := 0
+	if item := m.findPRByID(msg.PRID); item != nil {
+		number = item.PR.Number
+	}
+	slog.Info("Plugin ran", slog.Int64("prID", msg.PRID), slog.String("plugin", msg.Plugin))
+
+	// A plugin's last line is its summary
+	text := fmt.Sprintf("🔌 Ran %s for PR #%d", msg.Plugin, number)
+	if lines := strings.Split(msg.Output, "\n"); msg.Output != "" {
+		text += ": " + lines[len(lines)-1]
+	}
+	return m.showNotice(text)
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/freeze"
+	"github.com/kennyp/speedrun/pkg/policy"
+)
+
+// policyFacts describes a PR for the merge policy
+func policyFacts(item PRItem) policy.Facts {
+	facts := policy.Facts{
+		Repo:   item.PR.Owner + "/" + item.PR.Repo,
+		Author: item.PR.GetAuthor(),
+		Labels: item.PR.GetLabels(),
+		Files:  item.ChangedFiles,
+	}
+	if item.DiffStats != nil {
+		facts.Lines = item.DiffStats.Additions + item.DiffStats.Deletions
+		facts.FileCount = item.DiffStats.Files
+	}
+	if item.AIAnalysis != nil {
+		facts.Risk = string(item.AIAnalysis.RiskLevel)
+	}
+	if item.CheckStatus != nil {
+		facts.Checks = item.CheckStatus.State
+	}
+	return facts
+}
+
+// mergeBlocked explains why a PR can't be merged right now, because of a
+// deploy freeze or the merge policy, or returns "" if it can
+func (m Model) mergeBlocked(item PRItem) string {
+	if w, ok := freeze.Active(m.freezes, item.PR.Owner, item.PR.Repo, time.Now()); ok {
+		slog.Info("Merge refused during freeze", slog.Any("pr", item.PR), slog.String("window", w.String()))
+		return fmt.Sprintf("PR #%d: %s", item.PR.Number, w.Message())
+	}
+	return m.policyBlocked(policy.Merge, item)
+}
+
+// policyBlocked explains why the merge policy stops action on a PR, or
+// returns "" if it is allowed
+func (m Model) policyBlocked(action policy.Action, item PRItem) string {
+	err := m.policy.Check(action, policyFacts(item))
+	if err == nil {
+		return ""
+	}
+
+	slog.Info("Action stopped by policy", slog.String("action", string(action)), slog.Any("pr", item.PR), slog.Any("error", err))
+	var blocked *policy.BlockedError
+	if errors.As(err, &blocked) {
+		return fmt.Sprintf("PR #%d: %s", item.PR.Number, err)
+	}
+	return fmt.Sprintf("PR #%d: %s; try again once it has loaded", item.PR.Number, err)
+}
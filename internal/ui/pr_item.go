@@ -1,43 +1,123 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/kennyp/speedrun/pkg/agent"
+	"github.com/kennyp/speedrun/pkg/deps"
 	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/tracker"
 )
 
-// PRItem represents a PR in the list
+// PRItem represents a PR in the list. It holds what the list, the filters
+// and the detail pane read, and is copied on every update, so it stays lean:
+// diff text and the AI's tool results never reach the UI, staying with the
+// agent for the analysis and in the cache after it, and popup content is
+// built when the popup opens and let go of when it closes.
 type PRItem struct {
-	ID          int64 // Unique atomic ID for this PR item
-	PR          *github.PullRequest
-	DiffStats   *github.DiffStats
-	CheckStatus *github.CheckStatus
-	Reviews     []*github.Review
-	AIAnalysis  *agent.Analysis
+	github.Snapshot
+
+	ID         int64 // Unique atomic ID for this PR item
+	Queue      int   // Index of the queue (tab) the PR was found by
+	AIAnalysis *agent.Analysis
+	Issues     []*tracker.Issue // Linked tracker issues
 
 	// Loading states
 	LoadingDiff    bool
 	LoadingChecks  bool
 	LoadingReviews bool
 	LoadingAI      bool
+	LoadingIssues  bool
+	LoadingSigs    bool
+	AIDeferred     bool // AI analysis waits until the PR is selected
+	Pending        bool // Loading waits for the PR to come near the selection
 
 	// Completion states
 	Approved  bool
 	Reviewed  bool // Has the current user reviewed this PR?
 	Dismissed bool // Has the current user's review been dismissed?
+	Merging   bool // Merged or queued for auto-merge from speedrun
+	Authored  bool // Did the current user open this PR?
+
+	RequestedReviewers []string // Reviewers still requested, fetched for my own PRs
+
+	ApproveWhenGreen bool // Approve once the checks pass
+
+	ApprovedSHA string // Head commit when I approved, "" if unknown
+
+	GroupSize   int                     // PRs making the same dependency bump, including this one
+	Stack       []int64                 // IDs of the stacked PRs this one belongs to, bottom first
+	StackedOn   int                     // Number of the PR this one is stacked on, 0 if none
+	FixesAlerts []*github.SecurityAlert // Open Dependabot alerts this PR resolves
+	OverBudget  string                  // Size limits the PR exceeds, "" if within budget
+	OwnedFiles  int                     // Changed paths CODEOWNERS assigns to me or my teams
 
 	// Errors
 	DiffError   error
 	CheckError  error
 	ReviewError error
 	AIError     error
+	IssueError  error
+	SigError    error
+
+	Retries      int  // Automatic retries of failed loads so far
+	RetryPending bool // An automatic retry is scheduled
+
+	// Data shown from the cache, however old, until its load finishes
+	StaleDiff    bool
+	StaleChecks  bool
+	StaleReviews bool
+	StaleAI      bool
+}
+
+// setReviews records the PR's reviews and where my own review stands
+func (i *PRItem) setReviews(reviews []*github.Review, username string) {
+	// Review bodies aren't shown, so they're dropped rather than kept for
+	// every PR in the queue
+	i.Reviews = make([]*github.Review, len(reviews))
+	for n, review := range reviews {
+		i.Reviews[n] = &github.Review{State: review.State, User: review.User, CommitID: review.CommitID}
+	}
+	i.Reviewed, i.Approved, i.Dismissed = false, false, false
+	for _, review := range reviews {
+		if review.User == username {
+			i.Reviewed = true
+			switch review.State {
+			case "APPROVED":
+				i.Approved = true
+			case "DISMISSED":
+				i.Dismissed = true
+			}
+			// Note: We don't break here because there might be multiple reviews
+			// and we want to find the most recent status
+		}
+	}
+	i.ApprovedSHA = github.ApprovedCommit(reviews, username)
+}
+
+// setHead records the head and base a check status load found. Loads still
+// running may be reading the PR, so it's replaced with an updated copy
+// rather than written to.
+func (i *PRItem) setHead(sha, baseRef, headRef string) {
+	if sha == "" || (sha == i.PR.HeadSHA && baseRef == i.PR.BaseRef && headRef == i.PR.HeadRef) {
+		return
+	}
+	pr := *i.PR
+	pr.HeadSHA, pr.BaseRef, pr.HeadRef = sha, baseRef, headRef
+	i.PR = &pr
 }
 
 // Title implements list.Item
 func (i PRItem) Title() string {
 	status := "📊"
-	if i.Approved {
+	if authored := i.authoredStatus(); i.Authored && authored != "" {
+		status = getReviewStateEmoji(authored)
+	} else if i.ChangedSinceApproval() {
+		status = "🔁"
+	} else if i.Approved {
 		status = "✅"
 	} else if i.Dismissed {
 		status = "⚠️" // Warning for dismissed reviews
@@ -54,22 +134,133 @@ func (i PRItem) Title() string {
 		title = fmt.Sprintf("%s %s PR #%d: %s", status, typeEmoji, i.PR.Number, i.PR.Title)
 	}
 
+	// Security fixes are flagged regardless of status
+	if len(i.FixesAlerts) > 0 {
+		title = "🔥 " + title
+	}
+
 	return title
 }
 
+// Age returns how long the PR has been open, or zero if unknown
+func (i PRItem) Age() time.Duration {
+	if i.PR.CreatedAt.IsZero() {
+		return 0
+	}
+	return time.Since(i.PR.CreatedAt)
+}
+
+// Bump returns the dependency update the PR makes, if its title names one
+func (i PRItem) Bump() (deps.Bump, bool) {
+	return deps.ParseTitle(i.PR.Title)
+}
+
+// dataSettled reports whether every fetch feeding AI analysis has finished
+func (i PRItem) dataSettled() bool {
+	return !i.LoadingDiff && !i.LoadingChecks && !i.LoadingReviews && !i.LoadingIssues && !i.LoadingSigs
+}
+
+// readyForAnalysis reports whether the PR is waiting for AI analysis and has
+// everything the analysis needs
+func (i PRItem) readyForAnalysis() bool {
+	return i.dataSettled() && i.LoadingAI && i.DiffStats != nil && i.CheckStatus != nil &&
+		i.Reviews != nil && i.DiffError == nil && i.CheckError == nil && i.ReviewError == nil &&
+		i.PR.HeadSHA != ""
+}
+
+// ChangedSinceApproval reports whether the PR's head moved after I approved it,
+// e.g. by a force-push, so my approval covers code that's no longer there
+func (i PRItem) ChangedSinceApproval() bool {
+	return i.Approved && i.ApprovedSHA != "" && i.PR.HeadSHA != "" && i.ApprovedSHA != i.PR.HeadSHA
+}
+
+// BreachesSLA reports whether the PR is still waiting for our review after sla
+func (i PRItem) BreachesSLA(sla time.Duration) bool {
+	return sla > 0 && !i.Reviewed && i.Age() > sla
+}
+
 // Description implements list.Item
 func (i PRItem) Description() string {
 	// Build description from available data immediately
 	desc := ""
 
+	// Pushed to since I approved
+	if i.ChangedSinceApproval() {
+		desc += "🔁 changed since your approval"
+	}
+
+	// Waiting on checks to approve
+	if i.ApproveWhenGreen {
+		if desc != "" {
+			desc += " | "
+		}
+		desc += "⏳ approving when green"
+	}
+
+	// Time waiting for review
+	if age := i.Age(); age > 0 {
+		if desc != "" {
+			desc += " | "
+		}
+		desc += "⏱ " + formatAge(age)
+	}
+
+	// Code I own
+	if i.OwnedFiles > 0 {
+		if desc != "" {
+			desc += " | "
+		}
+		desc += fmt.Sprintf("🎯 owner (%d files)", i.OwnedFiles)
+	}
+
+	// Languages touched
+	if languages := i.Languages(); len(languages) > 0 {
+		if desc != "" {
+			desc += " | "
+		}
+		desc += "🔤 " + languageBadges(languages)
+	}
+
+	// Stacked PRs
+	if len(i.Stack) > 1 {
+		if desc != "" {
+			desc += " | "
+		}
+		desc += fmt.Sprintf("🥞 stack %d/%d", slices.Index(i.Stack, i.ID)+1, len(i.Stack))
+		if i.StackedOn > 0 {
+			desc += fmt.Sprintf(" on #%d", i.StackedOn)
+		}
+	}
+
+	// Dependency update group
+	if i.GroupSize > 1 {
+		if desc != "" {
+			desc += " | "
+		}
+		desc += fmt.Sprintf("📦 ×%d repos", i.GroupSize)
+	}
+
 	// Diff stats
 	if i.DiffStats != nil {
+		if desc != "" {
+			desc += " | "
+		}
 		desc += fmt.Sprintf("📊 +%d/-%d lines, %d files",
 			i.DiffStats.Additions, i.DiffStats.Deletions, i.DiffStats.Files)
+		if i.OverBudget != "" {
+			desc += " 📏 over budget"
+		}
+		desc += staleMark(i.StaleDiff)
 	} else if i.LoadingDiff {
+		if desc != "" {
+			desc += " | "
+		}
 		desc += "📊 Loading diff..."
 	} else if i.DiffError != nil {
-		desc += "📊 ⚠️ Diff error"
+		if desc != "" {
+			desc += " | "
+		}
+		desc += "📊 ⚠️ Diff error" + i.retryNote()
 	}
 
 	// Check status
@@ -78,7 +269,7 @@ func (i PRItem) Description() string {
 			desc += " | "
 		}
 		emoji := getStatusEmoji(i.CheckStatus.State)
-		desc += fmt.Sprintf("🔧 %s%s", emoji, i.CheckStatus.Description)
+		desc += fmt.Sprintf("🔧 %s%s", emoji, i.CheckStatus.Description) + staleMark(i.StaleChecks)
 	} else if i.LoadingChecks {
 		if desc != "" {
 			desc += " | "
@@ -88,15 +279,42 @@ func (i PRItem) Description() string {
 		if desc != "" {
 			desc += " | "
 		}
-		desc += "🔧 ⚠️ Check error"
+		desc += "🔧 ⚠️ Check error" + i.retryNote()
+	}
+
+	// Commit signing, only called out when something is missing
+	if i.Signatures != nil && i.Signatures.Unverified > 0 {
+		if desc != "" {
+			desc += " | "
+		}
+		desc += fmt.Sprintf("🔓 %d unsigned", i.Signatures.Unverified)
+	}
+	if github.DCOStatus(i.CheckStatus, i.Signatures) == github.DCOFailed {
+		if desc != "" {
+			desc += " | "
+		}
+		desc += "✍️ DCO failed"
 	}
 
-	// Reviews
-	if len(i.Reviews) > 0 {
+	// PR template, only called out when the description leaves some out
+	if i.Template != nil && !i.Template.Complete() {
 		if desc != "" {
 			desc += " | "
 		}
-		desc += fmt.Sprintf("👥 %d reviews", len(i.Reviews))
+		desc += "🚧 template incomplete"
+	}
+
+	// Reviews, by reviewer on my own PRs
+	if i.Authored && !i.LoadingReviews && len(i.reviewerStates()) > 0 {
+		if desc != "" {
+			desc += " | "
+		}
+		desc += "👥 " + i.reviewerSummary()
+	} else if len(i.Reviews) > 0 {
+		if desc != "" {
+			desc += " | "
+		}
+		desc += fmt.Sprintf("👥 %d reviews", len(i.Reviews)) + staleMark(i.StaleReviews)
 	} else if i.LoadingReviews {
 		if desc != "" {
 			desc += " | "
@@ -106,7 +324,7 @@ func (i PRItem) Description() string {
 		if desc != "" {
 			desc += " | "
 		}
-		desc += "👥 ⚠️ Review error"
+		desc += "👥 ⚠️ Review error" + i.retryNote()
 	}
 
 	// AI Analysis
@@ -130,12 +348,22 @@ func (i PRItem) Description() string {
 			}
 		}
 
-		desc += aiDesc
+		desc += aiDesc + staleMark(i.StaleAI)
 	} else if i.LoadingAI {
 		if desc != "" {
 			desc += " | "
 		}
 		desc += "🤖 AI analyzing..."
+	} else if i.AIDeferred {
+		if desc != "" {
+			desc += " | "
+		}
+		desc += "🤖 AI on select"
+	} else if errors.Is(i.AIError, agent.ErrCircuitOpen) {
+		if desc != "" {
+			desc += " | "
+		}
+		desc += "🤖 ⏸ AI paused"
 	} else if i.AIError != nil {
 		if desc != "" {
 			desc += " | "
@@ -208,3 +436,15 @@ func getPRTypeEmoji(prType string) string {
 		return "❓"
 	}
 }
+
+// formatAge renders a duration compactly, e.g. 45m, 5h or 3d
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
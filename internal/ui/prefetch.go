@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"cmp"
+	"log/slog"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// prefetchWindow is how many rows either side of the selection load before
+// the rest. Outside it, PRs load a window's worth at a time, nearest first.
+const prefetchWindow = 5
+
+// prefetchKey is the selection and list state prefetch last worked from
+type prefetchKey struct {
+	selected int
+	loadGen  int
+}
+
+// prefetch starts loading the pending PRs near the selection, so the PR
+// being looked at hydrates first, and keeps the rest trickling in from the
+// nearest out. It runs after every message, but only looks again once the
+// selection, the list or a PR's loading state has changed, so the window
+// slides with the selection and refills as loads finish.
+func (m Model) prefetch() (Model, tea.Cmd) {
+	key := prefetchKey{selected: m.list.Index(), loadGen: m.loadGen}
+	if key == m.prefetched {
+		return m, nil
+	}
+	m.prefetched = key
+
+	distance := make(map[int64]int)
+	for i, listItem := range m.list.Items() {
+		if item, ok := listItem.(PRItem); ok {
+			distance[item.ID] = max(i-m.list.Index(), m.list.Index()-i)
+		}
+	}
+
+	var pending []int
+	inFlight := 0
+	for i, item := range m.items {
+		switch {
+		case item.Pending:
+			pending = append(pending, i)
+		case item.LoadingDiff || item.LoadingChecks || item.LoadingReviews || item.LoadingIssues || item.LoadingSigs:
+			inFlight++
+		}
+	}
+	if len(pending) == 0 {
+		return m, nil
+	}
+
+	// Hidden PRs, including those of queues in the background, go last
+	rank := func(i int) int {
+		if d, ok := distance[m.items[i].ID]; ok {
+			return d
+		}
+		return len(m.items) + i
+	}
+	slices.SortStableFunc(pending, func(a, b int) int { return cmp.Compare(rank(a), rank(b)) })
+
+	var cmds []tea.Cmd
+	for _, i := range pending {
+		if rank(i) > prefetchWindow && inFlight >= 2*prefetchWindow+1 {
+			break
+		}
+		m.items[i].Pending = false
+		inFlight++
+
+		// Add small delay between PR sequences to avoid overwhelming the API
+		delay := time.Duration(len(cmds)*100) * time.Millisecond
+		load := m.loadPRCmd(m.items[i])
+		if delay > 0 {
+			cmds = append(cmds, tea.Tick(delay, func(time.Time) tea.Msg { return load() }))
+		} else {
+			cmds = append(cmds, load)
+		}
+	}
+	if len(cmds) > 0 {
+		slog.Debug("Prefetching PRs", slog.Int("started", len(cmds)), slog.Int("pending", len(pending)-len(cmds)))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// loadPRCmd loads a PR's details one after another, then its cached AI
+// analysis unless a fresh one is due. PRs of a queue in the
+// background load with that queue's client.
+func (m Model) loadPRCmd(item PRItem) tea.Cmd {
+	pr, prID := item.PR, item.ID
+	ctx := m.prContext(prID)
+	client := m.github
+	if item.Queue != m.activeQueue {
+		client = m.queues[item.Queue].github
+	}
+	prSequence := []tea.Cmd{
+		FetchDiffStatsCmd(ctx, client, pr, prID),
+		FetchCheckStatusCmd(ctx, client, pr, prID),
+		FetchReviewsCmd(ctx, client, pr, m.username, prID),
+		FetchSignaturesCmd(ctx, pr, prID),
+		FetchChangedFilesCmd(ctx, pr, prID),
+	}
+	if m.tracker != nil {
+		prSequence = append(prSequence, FetchLinkedIssuesCmd(ctx, m.tracker, pr, prID))
+	}
+
+	// Add AI analysis to the sequence
+	if !item.LoadingAI {
+		// Load cached AI analysis immediately if available
+		prSequence = append(prSequence, FetchCachedAIAnalysisCmd(pr, prID))
+	}
+	// Note: For LoadingAI=true, AI analysis will be triggered by the message handlers
+	// when all prerequisites (diff, checks, reviews) are loaded
+	return tea.Sequence(prSequence...)
+}
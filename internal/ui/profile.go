@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/agent"
+	"github.com/kennyp/speedrun/pkg/config"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/history"
+)
+
+// Terminal size and number of frames the render stage draws, moving the
+// selection down a PR each frame
+const (
+	profileWidth  = 160
+	profileHeight = 50
+	profileFrames = 100
+)
+
+// ProfileStage is how long one stage of loading the queue took
+type ProfileStage struct {
+	Name     string
+	Duration time.Duration
+	Count    int // PRs, or frames for the render stage
+}
+
+// Profile loads the queue stage by stage, the way the plain listing does, and
+// times each stage: searching for the PRs, loading their details, analyzing
+// them, and drawing the list in the TUI
+func Profile(ctx context.Context, cfg *config.Config, githubClient *github.Client, aiAgent *agent.Agent, username string) ([]ProfileStage, error) {
+	var stages []ProfileStage
+	timed := func(name string, count func() int, fn func()) {
+		start := time.Now()
+		fn()
+		stages = append(stages, ProfileStage{Name: name, Duration: time.Since(start), Count: count()})
+	}
+
+	var prs []*github.PullRequest
+	var err error
+	timed("search", func() int { return len(prs) }, func() {
+		prs, err = githubClient.SearchPullRequests(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search pull requests: %w", err)
+	}
+
+	var items []PRItem
+	timed("hydrate", func() int { return len(items) }, func() {
+		items = loadPlainItems(ctx, cfg, githubClient, nil, username, prs)
+	})
+
+	if aiAgent != nil {
+		timed("ai", func() int { return len(items) }, func() {
+			eachItem(len(items), func(i int) {
+				analyzePlainItem(ctx, cfg, aiAgent, &items[i])
+			})
+		})
+	}
+
+	timed("render", func() int { return profileFrames }, func() {
+		renderFrames(ctx, cfg, githubClient, aiAgent, username, items)
+	})
+	return stages, nil
+}
+
+// renderFrames draws the loaded queue profileFrames times
+func renderFrames(ctx context.Context, cfg *config.Config, githubClient *github.Client, aiAgent *agent.Agent, username string, items []PRItem) {
+	m := NewModel(ctx, cfg, githubClient, aiAgent, nil, history.NewNoOpRecorder(), nil, username)
+	defer m.cancel()
+
+	m.loadingPRs = false
+	m.items = make([]PRItem, len(items))
+	for i, item := range items {
+		item.ID = nextPRID.Add(1)
+		m.items[i] = item
+	}
+	m = m.updateVisibleItems()
+	next, _ := m.Update(tea.WindowSizeMsg{Width: profileWidth, Height: profileHeight})
+	m = next.(Model)
+
+	for range profileFrames {
+		_ = m.View()
+		m.list.CursorDown()
+	}
+}
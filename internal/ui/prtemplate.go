@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/prtemplate"
+)
+
+// PRTemplateLoadedMsg is sent when a repository's PR template has been fetched
+type PRTemplateLoadedMsg struct {
+	Repo     string // owner/repo
+	Template prtemplate.Template
+	Err      error
+}
+
+// FetchPRTemplateCmd fetches a repository's PR template
+func FetchPRTemplateCmd(ctx context.Context, client *github.Client, owner, repo string) tea.Cmd {
+	return func() tea.Msg {
+		tmpl, err := client.GetPRTemplate(ctx, owner, repo)
+		return PRTemplateLoadedMsg{Repo: owner + "/" + repo, Template: tmpl, Err: err}
+	}
+}
+
+// fetchPRTemplates fetches the PR template of every repository in the list,
+// unless template checks are off
+func (m Model) fetchPRTemplates() tea.Cmd {
+	if !m.config.Review.TemplateCheck {
+		return nil
+	}
+	var cmds []tea.Cmd
+	seen := make(map[string]bool)
+	for _, item := range m.items {
+		repo := item.PR.Owner + "/" + item.PR.Repo
+		if seen[repo] {
+			continue
+		}
+		seen[repo] = true
+		cmds = append(cmds, FetchPRTemplateCmd(m.ctx, m.github, item.PR.Owner, item.PR.Repo))
+	}
+	return tea.Batch(cmds...)
+}
+
+// annotateTemplates records on each item how well its description fills out
+// its repository's PR template
+func (m Model) annotateTemplates() {
+	for i := range m.items {
+		item := &m.items[i]
+		tmpl, ok := m.prTemplates[item.PR.Owner+"/"+item.PR.Repo]
+		if !ok || tmpl.IsZero() {
+			item.Template = nil
+			continue
+		}
+		compliance := prtemplate.Check(tmpl, item.PR.GetBody())
+		item.Template = &compliance
+	}
+}
+
+func (m Model) handlePRTemplateLoaded(msg PRTemplateLoadedMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		slog.Debug("PR template unavailable", slog.String("repo", msg.Repo), slog.Any("error", msg.Err))
+		return m, nil
+	}
+
+	if m.prTemplates == nil {
+		m.prTemplates = make(map[string]prtemplate.Template)
+	}
+	m.prTemplates[msg.Repo] = msg.Template
+
+	m = m.updateVisibleItems()
+	return m, nil
+}
+
+// templateDetailContent lists what the description leaves out of the PR
+// template for the details popup
+func templateDetailContent(item PRItem) string {
+	c := item.Template
+	if c == nil {
+		return ""
+	}
+
+	var content strings.Builder
+	content.WriteString("## 🚧 PR Template\n\n")
+	if c.Complete() {
+		content.WriteString("- ✅ The description fills out the template\n\n")
+		return content.String()
+	}
+	for _, section := range c.Empty {
+		content.WriteString(fmt.Sprintf("- ⚠️ **%s** left empty\n", section))
+	}
+	for _, entry := range c.Unticked {
+		content.WriteString(fmt.Sprintf("- ☐ %s\n", entry))
+	}
+	content.WriteString("\n")
+	return content.String()
+}
@@ -0,0 +1,259 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kennyp/speedrun/pkg/github"
+)
+
+// Kinds of row in the query builder
+const (
+	queryRowOrg = iota
+	queryRowTeam
+	queryRowLabel
+	queryRowDrafts
+	queryRowReviewRequested
+)
+
+// queryRow is one pickable qualifier in the query builder
+type queryRow struct {
+	kind  int
+	value string
+}
+
+// queryBuilderState holds the search query builder dialog
+type queryBuilderState struct {
+	loading bool
+	query   github.SearchQuery
+	orgs    []string
+	teams   []string
+	labels  []string
+	cursor  int
+}
+
+// QueryOptionsLoadedMsg is sent when the user's orgs and teams have been
+// fetched for the query builder
+type QueryOptionsLoadedMsg struct {
+	Orgs  []string
+	Teams []string
+	Err   error
+}
+
+// LoadQueryOptionsCmd fetches the orgs and teams the user belongs to
+func LoadQueryOptionsCmd(ctx context.Context, client *github.Client) tea.Cmd {
+	return func() tea.Msg {
+		orgs, err := client.ListMyOrgs(ctx)
+		if err != nil {
+			return QueryOptionsLoadedMsg{Err: err}
+		}
+		teams, err := client.ListMyTeams(ctx)
+		if err != nil {
+			return QueryOptionsLoadedMsg{Orgs: orgs, Err: err}
+		}
+		return QueryOptionsLoadedMsg{Orgs: orgs, Teams: teams}
+	}
+}
+
+// mergeOptions returns the sorted union of picked and options
+func mergeOptions(picked []string, options ...[]string) []string {
+	merged := slices.Clone(picked)
+	for _, opts := range options {
+		for _, opt := range opts {
+			if opt != "" && !slices.Contains(merged, opt) {
+				merged = append(merged, opt)
+			}
+		}
+	}
+	slices.Sort(merged)
+	return merged
+}
+
+// rows lists the qualifiers in display order
+func (s *queryBuilderState) rows() []queryRow {
+	var rows []queryRow
+	for _, org := range s.orgs {
+		rows = append(rows, queryRow{kind: queryRowOrg, value: org})
+	}
+	for _, team := range s.teams {
+		rows = append(rows, queryRow{kind: queryRowTeam, value: team})
+	}
+	for _, label := range s.labels {
+		rows = append(rows, queryRow{kind: queryRowLabel, value: label})
+	}
+	return append(rows, queryRow{kind: queryRowDrafts}, queryRow{kind: queryRowReviewRequested})
+}
+
+// picked reports whether a row's qualifier is in the query
+func (s *queryBuilderState) picked(row queryRow) bool {
+	switch row.kind {
+	case queryRowOrg:
+		return slices.Contains(s.query.Orgs, row.value)
+	case queryRowTeam:
+		return s.query.Team == row.value
+	case queryRowLabel:
+		return slices.Contains(s.query.Labels, row.value)
+	case queryRowDrafts:
+		return s.query.ExcludeDrafts
+	case queryRowReviewRequested:
+		return s.query.ReviewRequested
+	}
+	return false
+}
+
+// toggle adds or removes a row's qualifier. Only one team can be picked, as
+// team-review-requested: qualifiers must all match.
+func (s *queryBuilderState) toggle(row queryRow) {
+	toggleValue := func(values []string) []string {
+		if slices.Contains(values, row.value) {
+			return slices.DeleteFunc(values, func(v string) bool { return v == row.value })
+		}
+		return append(values, row.value)
+	}
+
+	switch row.kind {
+	case queryRowOrg:
+		s.query.Orgs = toggleValue(s.query.Orgs)
+	case queryRowTeam:
+		if s.query.Team == row.value {
+			s.query.Team = ""
+		} else {
+			s.query.Team = row.value
+		}
+	case queryRowLabel:
+		s.query.Labels = toggleValue(s.query.Labels)
+	case queryRowDrafts:
+		s.query.ExcludeDrafts = !s.query.ExcludeDrafts
+	case queryRowReviewRequested:
+		s.query.ReviewRequested = !s.query.ReviewRequested
+	}
+}
+
+// handleQueryBuilder opens the search query builder, starting from the
+// current query
+func (m Model) handleQueryBuilder() (Model, tea.Cmd) {
+	query := github.ParseSearchQuery(m.github.Query())
+
+	var labels []string
+	for _, item := range m.items {
+		labels = append(labels, item.PR.GetLabels()...)
+	}
+
+	slog.Info("User opened search query builder", slog.String("query", m.github.Query()))
+	m.queryBuilder = &queryBuilderState{
+		loading: true,
+		query:   query,
+		orgs:    mergeOptions(query.Orgs),
+		teams:   mergeOptions([]string{query.Team}, m.config.GitHub.Teams),
+		labels:  mergeOptions(query.Labels, labels),
+	}
+	return m, LoadQueryOptionsCmd(m.ctx, m.github)
+}
+
+func (m Model) handleQueryOptionsLoaded(msg QueryOptionsLoadedMsg) (Model, tea.Cmd) {
+	if m.queryBuilder == nil {
+		return m, nil // Closed while loading
+	}
+	m.queryBuilder.loading = false
+	if msg.Err != nil {
+		// The configured teams and current query still give something to pick
+		slog.Warn("Failed to load orgs and teams for query builder", slog.Any("error", msg.Err))
+		m.setError("Failed to load your orgs and teams", msg.Err)
+	}
+	m.queryBuilder.orgs = mergeOptions(m.queryBuilder.orgs, msg.Orgs)
+	m.queryBuilder.teams = mergeOptions(m.queryBuilder.teams, msg.Teams)
+	return m, nil
+}
+
+// handleQueryBuilderKey moves, toggles qualifiers, applies the query or
+// closes the builder
+func (m Model) handleQueryBuilderKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	rows := m.queryBuilder.rows()
+	switch msg.String() {
+	case "esc", "S":
+		m.queryBuilder = nil
+		return m, nil
+	case "up", "k":
+		m.queryBuilder.cursor = max(m.queryBuilder.cursor-1, 0)
+	case "down", "j":
+		m.queryBuilder.cursor = min(m.queryBuilder.cursor+1, len(rows)-1)
+	case " ", "x":
+		m.queryBuilder.toggle(rows[m.queryBuilder.cursor])
+	case "enter":
+		return m.applySearchQuery(m.queryBuilder.query.String())
+	}
+	return m, nil
+}
+
+// applySearchQuery switches the session to a new search query and reloads
+func (m Model) applySearchQuery(query string) (Model, tea.Cmd) {
+	m.queryBuilder = nil
+	if query == m.github.Query() {
+		return m, nil
+	}
+
+	slog.Info("User changed search query", slog.String("from", m.github.Query()), slog.String("to", query))
+	m.github.SetQuery(query)
+	m.loadingPRs = true
+	m.setStatus(statusInfo, fmt.Sprintf("Searching %s (add it to your config to keep it)", query))
+	return m, tea.Batch(m.spinnerTick(), FetchPRsCmd(m.ctx, m.github, m.activeQueue))
+}
+
+// renderQueryBuilder renders the search query builder overlay
+func (m Model) renderQueryBuilder() string {
+	width, height := m.width, m.height
+
+	var content strings.Builder
+	content.WriteString("Search Query\n")
+
+	headings := map[int]string{
+		queryRowOrg:    "Organizations (any of)",
+		queryRowTeam:   "Review requested from team (one)",
+		queryRowLabel:  "Labels (any of)",
+		queryRowDrafts: "Options",
+	}
+	for i, row := range m.queryBuilder.rows() {
+		if heading, ok := headings[row.kind]; ok {
+			content.WriteString("\n" + heading + "\n")
+			delete(headings, row.kind)
+		}
+
+		text := row.value
+		switch row.kind {
+		case queryRowDrafts:
+			text = "Hide drafts"
+		case queryRowReviewRequested:
+			text = "Review requested from me"
+		}
+		check := "[ ]"
+		if m.queryBuilder.picked(row) {
+			check = "[x]"
+		}
+		cursor := "  "
+		if i == m.queryBuilder.cursor {
+			cursor = "> "
+		}
+		content.WriteString(fmt.Sprintf("%s%s %s\n", cursor, check, text))
+	}
+	if m.queryBuilder.loading {
+		content.WriteString("\n⏳ Loading your orgs and teams...\n")
+	}
+
+	content.WriteString("\n" + m.queryBuilder.query.String() + "\n")
+
+	dialog := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("75")).
+		Background(lipgloss.Color("235")).
+		Foreground(lipgloss.Color("255")).
+		Padding(1).
+		Width(min(width*8/10, 80) - 4).
+		Render(m.label(content.String()))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, dialog)
+}
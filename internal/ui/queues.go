@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/config"
+	"github.com/kennyp/speedrun/pkg/github"
+)
+
+// queue is one tab of PRs with its own search query. The model's filter and
+// loading fields belong to the active queue; the others keep theirs here.
+type queue struct {
+	name   string
+	github *github.Client
+
+	status             string
+	loadingPRs         bool
+	lastRefresh        time.Time
+	nextRefresh        time.Time
+	showOnlyUnreviewed bool
+	filterReviewStatus string
+	filterRepo         string
+	filterType         string
+	filterOwned        bool
+	filterLanguage     string
+	selected           int
+}
+
+// newQueues returns the configured search query as the first queue, followed
+// by the github.queues entries ("name=query") and, with github.my_prs, the
+// PRs I opened
+func newQueues(cfg *config.Config, githubClient *github.Client) []queue {
+	fresh := func(name string, client *github.Client) queue {
+		return queue{
+			name:               name,
+			github:             client,
+			status:             "Loading pull requests...",
+			loadingPRs:         true,
+			nextRefresh:        time.Now().Add(cfg.GitHub.AutoRefresh),
+			showOnlyUnreviewed: true,
+			filterReviewStatus: "unreviewed",
+			filterType:         "all",
+			filterRepo:         "all",
+			filterLanguage:     "all",
+		}
+	}
+
+	queues := []queue{fresh("Review", githubClient)}
+	for i, entry := range cfg.GitHub.Queues {
+		name, query, ok := strings.Cut(entry, "=")
+		if !ok {
+			name, query = fmt.Sprintf("Queue %d", i+2), entry
+		}
+		queues = append(queues, fresh(strings.TrimSpace(name), githubClient.WithQuery(strings.TrimSpace(query))))
+	}
+	if cfg.GitHub.MyPRs {
+		// Nobody expects me to review my own PRs, so show them all
+		mine := fresh("Mine", githubClient.WithQuery(mineQuery))
+		mine.showOnlyUnreviewed = false
+		mine.filterReviewStatus = "all"
+		queues = append(queues, mine)
+	}
+	return queues
+}
+
+// saveQueue stores the active queue's state
+func (m Model) saveQueue() Model {
+	q := &m.queues[m.activeQueue]
+	q.github = m.github
+	q.status = m.status
+	q.loadingPRs = m.loadingPRs
+	q.lastRefresh = m.lastRefresh
+	q.nextRefresh = m.nextRefresh
+	q.showOnlyUnreviewed = m.showOnlyUnreviewed
+	q.filterReviewStatus = m.filterReviewStatus
+	q.filterRepo = m.filterRepo
+	q.filterType = m.filterType
+	q.filterOwned = m.filterOwned
+	q.filterLanguage = m.filterLanguage
+	q.selected = m.list.Index()
+	return m
+}
+
+// loadQueue makes a queue active, restoring its state
+func (m Model) loadQueue(i int) Model {
+	q := m.queues[i]
+	m.activeQueue = i
+	m.github = q.github
+	m.status = q.status
+	m.loadingPRs = q.loadingPRs
+	m.lastRefresh = q.lastRefresh
+	m.nextRefresh = q.nextRefresh
+	m.showOnlyUnreviewed = q.showOnlyUnreviewed
+	m.filterReviewStatus = q.filterReviewStatus
+	m.filterRepo = q.filterRepo
+	m.filterType = q.filterType
+	m.filterOwned = q.filterOwned
+	m.filterLanguage = q.filterLanguage
+	return m
+}
+
+// handleSwitchQueue shows the next (or previous) queue
+func (m Model) handleSwitchQueue(step int) (Model, tea.Cmd) {
+	if len(m.queues) < 2 {
+		return m, nil
+	}
+
+	next := (m.activeQueue + step + len(m.queues)) % len(m.queues)
+	slog.Info("User switched queue", slog.String("from", m.queues[m.activeQueue].name), slog.String("to", m.queues[next].name))
+	m = m.saveQueue().loadQueue(next)
+	m = m.updateVisibleItemsWithPreserveSelection(false)
+	m.list.Select(m.queues[next].selected)
+	m = m.updateQueueTitle()
+	return m.resumeCancelledAnalyses()
+}
+
+// inQueue runs a handler for results that belong to a queue, with only that
+// queue's items and state in place. Results for a queue in the background
+// leave the list and status line alone.
+func (m Model) inQueue(i int, handle func(Model) (Model, tea.Cmd)) (Model, tea.Cmd) {
+	active := m.activeQueue
+	shown, status, held := m.list, m.status, len(m.heldStatus)
+	if i != active {
+		m = m.saveQueue().loadQueue(i)
+	}
+
+	var items, others []PRItem
+	for _, item := range m.items {
+		if item.Queue == i {
+			items = append(items, item)
+		} else {
+			others = append(others, item)
+		}
+	}
+	m.items = items
+
+	m, cmd := handle(m)
+	m.items = append(m.items, others...)
+
+	if i != active {
+		m = m.saveQueue().loadQueue(active)
+		m.list, m.status = shown, status
+		m.heldStatus = m.heldStatus[:held]
+	}
+	return m.updateQueueTitle(), cmd
+}
+
+// updateQueueTitle lists the queues in the title, marking the active one
+func (m Model) updateQueueTitle() Model {
+	title := fmt.Sprintf("🔍 Pull Requests for %s", m.username)
+	if m.config.UI.Accessible {
+		title = "Pull Requests for " + m.username
+	}
+	if len(m.queues) < 2 {
+		m.list.Title = title
+		return m
+	}
+
+	counts := make([]int, len(m.queues))
+	for _, item := range m.items {
+		counts[item.Queue]++
+	}
+
+	tabs := make([]string, len(m.queues))
+	for i, q := range m.queues {
+		count := fmt.Sprint(counts[i])
+		if (i == m.activeQueue && m.loadingPRs) || (i != m.activeQueue && q.loadingPRs) {
+			count = "…"
+		}
+		tabs[i] = fmt.Sprintf("%s %s", q.name, count)
+		if i == m.activeQueue {
+			tabs[i] = "[" + tabs[i] + "]"
+		}
+	}
+	m.list.Title = title + " · " + strings.Join(tabs, "  ")
+	return m
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kennyp/speedrun/pkg/remind"
+)
+
+// ReminderCreatedMsg is sent when a reminder has been created
+type ReminderCreatedMsg struct {
+	PRID  int64
+	At    time.Time
+	Where string
+	Err   error
+}
+
+// CreateReminderCmd records a reminder to revisit a PR
+func CreateReminderCmd(cfg remind.Config, r remind.Reminder, prID int64) tea.Cmd {
+	return func() tea.Msg {
+		where, err := remind.Create(cfg, r)
+		return ReminderCreatedMsg{PRID: prID, At: r.At, Where: where, Err: err}
+	}
+}
+
+// handleRemindMenu opens the reminder time menu for the selected PR
+func (m Model) handleRemindMenu() (Model, tea.Cmd) {
+	prItem, ok := m.list.SelectedItem().(PRItem)
+	if !ok {
+		return m, nil
+	}
+
+	m.remindMenu = remind.Presets(time.Now())
+	m.remindPR = prItem.ID
+	m.showRemindMenu = true
+	return m, nil
+}
+
+// handleRemindMenuKey creates a reminder at the chosen time or closes the menu
+func (m Model) handleRemindMenuKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if msg.String() == "esc" || msg.String() == "z" {
+		m.showRemindMenu = false
+		return m, nil
+	}
+
+	choice, err := strconv.Atoi(msg.String())
+	if err != nil || choice < 1 || choice > len(m.remindMenu) {
+		return m, nil
+	}
+	m.showRemindMenu = false
+
+	item := m.findPRByID(m.remindPR)
+	if item == nil {
+		return m, nil
+	}
+
+	preset := m.remindMenu[choice-1]
+	cfg := remind.Config{
+		Method:     m.config.Remind.Method,
+		Dir:        m.config.Remind.Dir,
+		RemindFile: m.config.Remind.RemindFile,
+		Open:       m.config.Remind.Open,
+	}
+	r := remind.Reminder{
+		Ref:   fmt.Sprintf("%s/%s#%d", item.PR.Owner, item.PR.Repo, item.PR.Number),
+		Title: item.PR.Title,
+		URL:   fmt.Sprintf("https://github.com/%s/%s/pull/%d", item.PR.Owner, item.PR.Repo, item.PR.Number),
+		At:    preset.At,
+	}
+
+	slog.Info("User set a reminder", slog.Any("pr", item.PR), slog.Time("at", preset.At), slog.String("method", cfg.Method))
+	return m, CreateReminderCmd(cfg, r, item.ID)
+}
+
+func (m Model) handleReminderCreated(msg ReminderCreatedMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		slog.Error("Failed to create reminder", slog.Int64("prID", msg.PRID), slog.Any("error", msg.Err))
+		m.setStatus(statusError, "Reminder failed: "+msg.Err.Error())
+		return m, nil
+	}
+
+	number := 0
+	if item := m.findPRByID(msg.PRID); item != nil {
+		number = item.PR.Number
+	}
+	return m.showNotice(fmt.Sprintf("⏰ Reminder for PR #%d at %s (%s)", number, msg.At.Format("Mon 15:04"), msg.Where))
+}
+
+// renderRemindMenu renders the reminder time menu overlay
+func (m Model) renderRemindMenu() string {
+	width, height := m.width, m.height
+
+	var content strings.Builder
+	content.WriteString("Remind Me\n\n")
+	for i, preset := range m.remindMenu {
+		content.WriteString(fmt.Sprintf("  %d %s (%s)\n", i+1, preset.Label, preset.At.Format("Mon Jan 2 15:04")))
+	}
+	content.WriteString("\nPress a number to set a reminder or Esc to cancel")
+
+	dialog := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("75")).
+		Background(lipgloss.Color("235")).
+		Foreground(lipgloss.Color("255")).
+		Padding(1).
+		Width(min(width*8/10, 60) - 4).
+		Render(content.String())
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, dialog)
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/github"
+)
+
+// Failed loads that may succeed later are retried automatically, waiting
+// loadRetryDelay and then twice as long each time, up to maxLoadRetries times
+const (
+	loadRetryDelay = 5 * time.Second
+	maxLoadRetries = 4
+)
+
+// RetryLoadsMsg retries a PR's loads that failed with transient errors
+type RetryLoadsMsg struct {
+	PRID int64
+}
+
+// RetryLoadsCmd schedules a retry of a PR's failed loads
+func RetryLoadsCmd(prID int64, delay time.Duration) tea.Cmd {
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return RetryLoadsMsg{PRID: prID}
+	})
+}
+
+// retryNote shows that a failed load will be retried
+func (i PRItem) retryNote() string {
+	if !i.RetryPending {
+		return ""
+	}
+	return fmt.Sprintf(" (retry %d/%d)", i.Retries+1, maxLoadRetries)
+}
+
+// scheduleLoadRetry schedules a retry of a PR's failed loads if err may be
+// transient and the PR has retries left
+func (m Model) scheduleLoadRetry(prID int64, err error) (Model, tea.Cmd) {
+	if !github.IsTransient(err) {
+		return m, nil
+	}
+	item := m.findPRByID(prID)
+	if item == nil || item.RetryPending || item.Retries >= maxLoadRetries {
+		return m, nil
+	}
+
+	delay := loadRetryDelay << item.Retries
+	slog.Info("Retrying failed PR loads later", slog.Any("pr", item.PR), slog.Int("attempt", item.Retries+1),
+		slog.Duration("delay", delay), slog.Any("error", err))
+	m = m.updatePRByID(prID, func(item *PRItem) { item.RetryPending = true })
+	m = m.updateVisibleItems()
+	return m, RetryLoadsCmd(prID, delay)
+}
+
+func (m Model) handleRetryLoads(msg RetryLoadsMsg) (Model, tea.Cmd) {
+	if m.findPRByID(msg.PRID) == nil {
+		return m, nil
+	}
+	m = m.updatePRByID(msg.PRID, func(item *PRItem) {
+		item.RetryPending = false
+		item.Retries++
+	})
+	m, cmds := m.reloadFailed(msg.PRID, github.IsTransient)
+	m = m.updateVisibleItems()
+	return m, tea.Batch(cmds...)
+}
+
+// reloadFailed reloads a PR's data whose last load failed with an error
+// retry accepts
+func (m Model) reloadFailed(prID int64, retry func(error) bool) (Model, []tea.Cmd) {
+	item := m.findPRByID(prID)
+	if item == nil {
+		return m, nil
+	}
+
+	pr, ctx := item.PR, m.prContext(prID)
+	var cmds []tea.Cmd
+	m = m.updatePRByID(prID, func(item *PRItem) {
+		if item.DiffError != nil && retry(item.DiffError) {
+			item.LoadingDiff, item.DiffError = true, nil
+			cmds = append(cmds, FetchDiffStatsCmd(ctx, m.github, pr, prID))
+		}
+		if item.CheckError != nil && retry(item.CheckError) {
+			item.LoadingChecks, item.CheckError = true, nil
+			cmds = append(cmds, FetchCheckStatusCmd(ctx, m.github, pr, prID))
+		}
+		if item.ReviewError != nil && retry(item.ReviewError) {
+			item.LoadingReviews, item.ReviewError = true, nil
+			cmds = append(cmds, FetchReviewsCmd(ctx, m.github, pr, m.username, prID))
+		}
+		if item.SigError != nil && retry(item.SigError) {
+			item.LoadingSigs, item.SigError = true, nil
+			cmds = append(cmds, FetchSignaturesCmd(ctx, pr, prID))
+		}
+		if item.IssueError != nil && retry(item.IssueError) {
+			item.LoadingIssues, item.IssueError = true, nil
+			cmds = append(cmds, FetchLinkedIssuesCmd(ctx, m.tracker, pr, prID))
+		}
+	})
+	return m, cmds
+}
@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/history"
+)
+
+// idleAfter is how long after the last key press time stops counting towards
+// the selected PR, so walking away from the terminal isn't counted as review
+const idleAfter = 5 * time.Minute
+
+// session tracks the time spent on each PR and what was done to it. Model
+// copies share it.
+type session struct {
+	started time.Time
+	prs     map[int64]*prTime
+	order   []int64 // PRs in the order they were first selected
+
+	selected   int64 // Currently selected PR, 0 for none
+	selectedAt time.Time
+}
+
+// prTime is the time spent on one PR
+type prTime struct {
+	label   string // owner/repo#number
+	title   string
+	spent   time.Duration // Including time spent before earlier actions
+	pending time.Duration // Since the last action
+	actions []history.Outcome
+}
+
+func newSession() *session {
+	return &session{started: time.Now(), prs: make(map[int64]*prTime)}
+}
+
+// track starts counting time for item, if it's not already selected. Time on
+// the previous PR counts until now or until idleAfter past the last key
+// press, whichever is sooner.
+func (s *session) track(item *PRItem, lastInput, now time.Time) {
+	var id int64
+	if item != nil {
+		id = item.ID
+	}
+	if id == s.selected {
+		return
+	}
+
+	s.stop(lastInput, now)
+	s.selected, s.selectedAt = id, now
+	if item != nil && s.prs[id] == nil {
+		s.prs[id] = &prTime{
+			label: fmt.Sprintf("%s/%s#%d", item.PR.Owner, item.PR.Repo, item.PR.Number),
+			title: item.PR.Title,
+		}
+		s.order = append(s.order, id)
+	}
+}
+
+// trackSelected counts time towards the selected PR
+func (m Model) trackSelected() {
+	var item *PRItem
+	if selected, ok := m.list.SelectedItem().(PRItem); ok {
+		item = &selected
+	}
+	m.session.track(item, m.lastInput, time.Now())
+}
+
+// stop adds the time since the selection to the selected PR
+func (s *session) stop(lastInput, now time.Time) {
+	pr := s.prs[s.selected]
+	if pr == nil {
+		return
+	}
+	active := lastInput
+	if s.selectedAt.After(active) {
+		active = s.selectedAt
+	}
+	end := now
+	if idle := active.Add(idleAfter); idle.Before(end) {
+		end = idle
+	}
+	if spent := end.Sub(s.selectedAt); spent > 0 {
+		pr.spent += spent
+		pr.pending += spent
+	}
+	s.selectedAt = now
+}
+
+// acted records an action on a PR and returns the time spent on it since it
+// was first selected or last acted on
+func (s *session) acted(id int64, outcome history.Outcome, lastInput, now time.Time) time.Duration {
+	if id == s.selected {
+		s.stop(lastInput, now)
+	}
+	pr := s.prs[id]
+	if pr == nil {
+		return 0
+	}
+	spent := pr.pending
+	pr.pending = 0
+	pr.actions = append(pr.actions, outcome)
+	return spent
+}
+
+// SessionReport sums up a TUI session: how long it ran and the time spent on
+// each PR
+type SessionReport struct {
+	Started  time.Time
+	Duration time.Duration
+	PRs      []PRTime // Most time first
+}
+
+// PRTime is the time spent on one PR in a session
+type PRTime struct {
+	PR      string // owner/repo#number
+	Title   string
+	Spent   time.Duration
+	Actions []history.Outcome
+}
+
+// SessionReport reports the session so far. PRs only glanced at, for less
+// than a second, are left out.
+func (m Model) SessionReport() SessionReport {
+	now := time.Now()
+	m.session.stop(m.lastInput, now)
+
+	report := SessionReport{Started: m.session.started, Duration: now.Sub(m.session.started)}
+	for _, id := range m.session.order {
+		pr := m.session.prs[id]
+		if pr.spent < time.Second && len(pr.actions) == 0 {
+			continue
+		}
+		report.PRs = append(report.PRs, PRTime{PR: pr.label, Title: pr.title, Spent: pr.spent, Actions: slices.Clone(pr.actions)})
+	}
+	slices.SortStableFunc(report.PRs, func(a, b PRTime) int { return int(b.Spent - a.Spent) })
+	return report
+}
+
+// Acted returns the number of PRs acted on in the session
+func (r SessionReport) Acted() int {
+	n := 0
+	for _, pr := range r.PRs {
+		if len(pr.Actions) > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Write prints the report for the terminal once the TUI has exited
+func (r SessionReport) Write(w io.Writer) error {
+	var total time.Duration
+	for _, pr := range r.PRs {
+		total += pr.Spent
+	}
+	if _, err := fmt.Fprintf(w, "⏱️ Session: %s, %s on %d PRs, %d acted on\n",
+		r.Duration.Round(time.Second), total.Round(time.Second), len(r.PRs), r.Acted()); err != nil {
+		return err
+	}
+	for _, pr := range r.PRs {
+		line := fmt.Sprintf("  %8s  %s %s", pr.Spent.Round(time.Second), pr.PR, pr.Title)
+		for _, action := range pr.Actions {
+			line += fmt.Sprintf(" [%s]", action)
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/policy"
+)
+
+// annotateStacks records on each item the stack of PRs it belongs to and the
+// PR it's stacked on. Stacks don't span queues.
+func (m Model) annotateStacks() {
+	byQueue := make(map[int][]*github.PullRequest)
+	index := make(map[*github.PullRequest]int)
+	for i := range m.items {
+		m.items[i].Stack, m.items[i].StackedOn = nil, 0
+		byQueue[m.items[i].Queue] = append(byQueue[m.items[i].Queue], m.items[i].PR)
+		index[m.items[i].PR] = i
+	}
+
+	for _, prs := range byQueue {
+		for _, stack := range github.Stacks(prs) {
+			ids := make([]int64, len(stack))
+			for j, pr := range stack {
+				ids[j] = m.items[index[pr]].ID
+			}
+			for j, pr := range stack {
+				item := &m.items[index[pr]]
+				// A PR that several others build on shows the first stack found
+				if item.Stack == nil {
+					item.Stack = ids
+				}
+				if j > 0 {
+					item.StackedOn = stack[j-1].Number
+				}
+			}
+		}
+	}
+}
+
+// stackOutOfOrder explains why approving (or merging) a PR now would jump
+// ahead of the PRs it's stacked on, or returns "" if it wouldn't
+func (m Model) stackOutOfOrder(item PRItem, merging bool) string {
+	for _, id := range item.Stack {
+		if id == item.ID {
+			break
+		}
+		below := m.findPRByID(id)
+		if below == nil {
+			continue
+		}
+		if merging {
+			return fmt.Sprintf("PR #%d is stacked on #%d, which is still open, so it would merge into #%d's branch",
+				item.PR.Number, below.PR.Number, below.PR.Number)
+		}
+		if !below.Approved {
+			return fmt.Sprintf("PR #%d is stacked on #%d, which you haven't approved", item.PR.Number, below.PR.Number)
+		}
+	}
+	return ""
+}
+
+// confirmStackOrder warns the first time an action would go out of stack
+// order and reports whether to go ahead. Repeating the action on the same PR
+// confirms it.
+func (m Model) confirmStackOrder(item PRItem, merging bool, keyName string) (Model, bool) {
+	warning := m.stackOutOfOrder(item, merging)
+	if warning == "" || m.stackWarned == item.ID {
+		m.stackWarned = 0
+		return m, true
+	}
+
+	slog.Info("Warned about out-of-order stack action", slog.Any("pr", item.PR), slog.Bool("merging", merging))
+	m.stackWarned = item.ID
+	m.setStatus(statusError, fmt.Sprintf("🥞 %s; press %s again to go ahead", warning, keyName))
+	return m, false
+}
+
+// handleApproveStack approves every PR in the selected PR's stack, from the
+// bottom up
+func (m Model) handleApproveStack() (Model, tea.Cmd) {
+	if m.github.Health().Degraded {
+		m.setStatus(statusInfo, "Approvals are disabled while GitHub is unavailable")
+		return m, nil
+	}
+
+	prItem, ok := m.list.SelectedItem().(PRItem)
+	if !ok {
+		slog.Debug("Approve stack action: no PR selected")
+		return m, nil
+	}
+	if len(prItem.Stack) == 0 {
+		m.setStatus(statusInfo, "PR is not part of a stack")
+		return m, nil
+	}
+
+	var cmds []tea.Cmd
+	for _, id := range prItem.Stack {
+		member := m.findPRByID(id)
+		if member == nil || member.Approved {
+			continue
+		}
+		if reason := m.approvalBlocked(*member); reason != "" {
+			m.setStatus(statusInfo, reason)
+			return m, nil
+		}
+		if reason := m.policyBlocked(policy.Approve, *member); reason != "" {
+			m.setStatus(statusError, reason)
+			return m, nil
+		}
+		cmds = append(cmds, ApprovePRCmd(m.ctx, member.PR, member.ID))
+	}
+	if len(cmds) == 0 {
+		m.setStatus(statusInfo, "Stack already approved")
+		return m, nil
+	}
+
+	slog.Info("User initiated stack approval", slog.Any("pr", prItem.PR), slog.Int("prs", len(cmds)))
+	m.setStatus(statusInfo, fmt.Sprintf("Approving %d stacked %s from the bottom up...", len(cmds), pluralPRs(len(cmds))))
+	return m, tea.Sequence(cmds...)
+}
+
+// stackDetailContent lists the PRs in a PR's stack for the details popup
+func (m Model) stackDetailContent(item PRItem) string {
+	if len(item.Stack) == 0 {
+		return ""
+	}
+
+	var content strings.Builder
+	content.WriteString("## 🥞 Stack (bottom first)\n\n")
+	for _, id := range item.Stack {
+		member := m.findPRByID(id)
+		if member == nil {
+			continue
+		}
+		status := ""
+		if member.Approved {
+			status = " ✅"
+		}
+		marker := ""
+		if member.ID == item.ID {
+			marker = " ← this PR"
+		}
+		content.WriteString(fmt.Sprintf("- #%d %s (%s)%s%s\n", member.PR.Number, member.PR.Title, member.PR.HeadRef, status, marker))
+	}
+	content.WriteString("\nPress **s** to approve the whole stack from the bottom up.\n\n")
+	return content.String()
+}
@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// statusLevel is how important a status message is
+type statusLevel int
+
+const (
+	statusInfo statusLevel = iota
+	statusSuccess
+	statusError
+)
+
+// minStatusDisplay is how long a message stays in the status line before a
+// later one may replace it. Info messages, like progress, are replaced at once.
+var minStatusDisplay = map[statusLevel]time.Duration{
+	statusSuccess: 3 * time.Second,
+	statusError:   6 * time.Second,
+}
+
+// statusHistorySize is how many messages the history pane keeps
+const statusHistorySize = 100
+
+// statusMessage is one message shown in the status line
+type statusMessage struct {
+	Level statusLevel
+	Text  string
+	At    time.Time
+}
+
+// StatusTickMsg lets the status line move on to the next held message
+type StatusTickMsg struct{}
+
+// style returns how messages of a level are shown
+func (l statusLevel) style() lipgloss.Style {
+	switch l {
+	case statusSuccess:
+		return successStyle
+	case statusError:
+		return errorStyle
+	}
+	return lipgloss.NewStyle()
+}
+
+func (l statusLevel) String() string {
+	switch l {
+	case statusSuccess:
+		return "ok"
+	case statusError:
+		return "error"
+	}
+	return "info"
+}
+
+// setStatus shows a message in the status line and keeps it in the history.
+// Success and error messages are held for a while, so that progress reported
+// right after them doesn't hide them before they can be read.
+func (m *Model) setStatus(level statusLevel, text string) {
+	msg := statusMessage{Level: level, Text: text, At: time.Now()}
+	m.status = level.style().Render(text)
+	m.recordStatus(msg)
+
+	if minStatusDisplay[level] > 0 {
+		if len(m.heldStatus) == 0 {
+			m.heldSince = msg.At
+		}
+		m.heldStatus = append(m.heldStatus, msg)
+	}
+}
+
+// setError reports a failure. In stale mode the banner already explains the
+// outage, so repeating each error is just noise.
+func (m *Model) setError(prefix string, err error) {
+	if m.github.Health().Degraded {
+		m.setStatus(statusInfo, "Showing cached data")
+		return
+	}
+	m.setStatus(statusError, prefix+": "+err.Error())
+}
+
+// recordStatus adds a message to the history
+func (m *Model) recordStatus(msg statusMessage) {
+	m.statusHistory = append(m.statusHistory, msg)
+	if over := len(m.statusHistory) - statusHistorySize; over > 0 {
+		m.statusHistory = slices.Clone(m.statusHistory[over:])
+	}
+}
+
+// currentStatus returns the status line text: the oldest held message, or
+// the latest status once every held message has had its time
+func (m Model) currentStatus() string {
+	if len(m.heldStatus) > 0 {
+		held := m.heldStatus[0]
+		return held.Level.style().Render(held.Text)
+	}
+	return m.status
+}
+
+// scheduleStatus makes sure a tick is coming to release the held message
+func (m Model) scheduleStatus(cmd tea.Cmd) (Model, tea.Cmd) {
+	if len(m.heldStatus) == 0 || m.statusTicking {
+		return m, cmd
+	}
+
+	m.statusTicking = true
+	wait := time.Until(m.heldSince.Add(minStatusDisplay[m.heldStatus[0].Level]))
+	return m, tea.Batch(cmd, tea.Tick(wait, func(time.Time) tea.Msg {
+		return StatusTickMsg{}
+	}))
+}
+
+// handleStatusTick releases held messages that have been shown long enough
+func (m Model) handleStatusTick() (Model, tea.Cmd) {
+	m.statusTicking = false
+	now := time.Now()
+	for len(m.heldStatus) > 0 && now.Sub(m.heldSince) >= minStatusDisplay[m.heldStatus[0].Level] {
+		m.heldStatus = m.heldStatus[1:]
+		m.heldSince = now
+	}
+	return m, nil
+}
+
+// handleToggleStatusHistory shows or hides the message history pane
+func (m Model) handleToggleStatusHistory() (Model, tea.Cmd) {
+	m.showStatusHistory = !m.showStatusHistory
+	m.resizeList()
+	return m, nil
+}
+
+// statusHistoryHeight returns the rows reserved for the message history pane,
+// including its border
+func (m Model) statusHistoryHeight() int {
+	if !m.showStatusHistory {
+		return 0
+	}
+	return max(6, m.height/4)
+}
+
+// renderStatusHistory renders the most recent messages that fit, newest last
+func (m Model) renderStatusHistory() string {
+	width := m.width
+	rows := m.statusHistoryHeight() - 2 // Border and header
+
+	header := helpStyle.Render("Recent messages • M: close")
+
+	msgs := m.statusHistory
+	if len(msgs) > rows {
+		msgs = msgs[len(msgs)-rows:]
+	}
+
+	lines := make([]string, 0, rows)
+	for _, msg := range msgs {
+		line := m.label(fmt.Sprintf("%s %-5s %s", msg.At.Format("15:04:05"), msg.Level, msg.Text))
+		if runes := []rune(line); width > 0 && len(runes) > width {
+			line = string(runes[:max(0, width-1)]) + "…"
+		}
+		lines = append(lines, msg.Level.style().Render(line))
+	}
+	for len(lines) < rows {
+		lines = append(lines, "")
+	}
+
+	return logPaneStyle.Width(width).Render(header + "\n" + strings.Join(lines, "\n"))
+}
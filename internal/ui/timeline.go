@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/pkg/github"
+)
+
+// timelineState holds a PR's timeline for the details popup
+type timelineState struct {
+	loading bool
+	events  []github.TimelineEvent
+	err     error
+}
+
+// TimelineLoadedMsg is sent when a PR's timeline has been fetched
+type TimelineLoadedMsg struct {
+	PRID   int64
+	Events []github.TimelineEvent
+	Err    error
+}
+
+// FetchTimelineCmd fetches a PR's key events
+func FetchTimelineCmd(ctx context.Context, pr *github.PullRequest, prID int64) tea.Cmd {
+	return func() tea.Msg {
+		events, err := pr.GetTimeline(ctx)
+		return TimelineLoadedMsg{PRID: prID, Events: events, Err: err}
+	}
+}
+
+// handleToggleTimeline switches the details popup between the details and
+// timeline tabs, refetching the timeline each time it's shown
+func (m Model) handleToggleTimeline() (Model, tea.Cmd) {
+	prItem, ok := m.list.SelectedItem().(PRItem)
+	if !ok {
+		return m, nil
+	}
+
+	m.showTimeline = !m.showTimeline
+	m.popupScrollPos = 0
+
+	var cmd tea.Cmd
+	if m.showTimeline {
+		slog.Debug("User opened PR timeline", slog.Any("pr", prItem.PR))
+		state := m.timelines[prItem.ID]
+		if state == nil {
+			state = &timelineState{}
+			m.timelines[prItem.ID] = state
+		}
+		state.loading = true
+		cmd = FetchTimelineCmd(m.prContext(prItem.ID), prItem.PR, prItem.ID)
+	}
+	m.popupContent = m.popupContentFor(prItem)
+	return m, cmd
+}
+
+func (m Model) handleTimelineLoaded(msg TimelineLoadedMsg) (Model, tea.Cmd) {
+	state := m.timelines[msg.PRID]
+	if state == nil {
+		return m, nil
+	}
+	state.loading = false
+	if msg.Err != nil {
+		slog.Error("Failed to load PR timeline", slog.Int64("pr_id", msg.PRID), slog.Any("error", msg.Err))
+		state.err = msg.Err
+	} else {
+		state.events, state.err = msg.Events, nil
+	}
+
+	if prItem, ok := m.list.SelectedItem().(PRItem); ok && m.showPopup && prItem.ID == msg.PRID {
+		m.popupContent = m.popupContentFor(prItem)
+	}
+	return m, nil
+}
+
+// popupContentFor renders whichever tab of the details popup is open
+func (m Model) popupContentFor(item PRItem) string {
+	if m.showErrors {
+		return m.generateErrorContent(item)
+	}
+	if m.showTimeline {
+		return m.generateTimelineContent(item)
+	}
+	return m.generateDetailContent(item)
+}
+
+// generateTimelineContent renders a PR's key events for the details popup
+func (m Model) generateTimelineContent(item PRItem) string {
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("# %s\n\n", item.PR.Title))
+	content.WriteString(fmt.Sprintf("**Repository:** %s/%s\n", item.PR.Owner, item.PR.Repo))
+	content.WriteString(fmt.Sprintf("**PR Number:** #%d\n", item.PR.Number))
+	content.WriteString("\n---\n\n")
+	content.WriteString("## 🕒 Timeline\n\n")
+
+	state := m.timelines[item.ID]
+	switch {
+	case state == nil || (state.loading && state.events == nil):
+		content.WriteString("*Loading timeline...*\n\n")
+	case state.err != nil && state.events == nil:
+		content.WriteString(fmt.Sprintf("*Failed to load: %s*\n\n", state.err))
+	default:
+		if github.ForcePushedSinceApproval(state.events) {
+			content.WriteString("⚠️ **Force-pushed since the last approval**\n\n")
+		}
+		for _, event := range state.events {
+			content.WriteString(fmt.Sprintf("- %s **%s** %s\n", event.At.Local().Format("Jan 2 15:04"), event.Actor, describeEvent(event)))
+		}
+		content.WriteString("\n")
+	}
+
+	content.WriteString("---\n\n")
+	content.WriteString("*Press **Tab** for details, **Enter** or **Esc** to close*")
+	return content.String()
+}
+
+// describeEvent says what happened in a timeline event
+func describeEvent(event github.TimelineEvent) string {
+	switch event.Kind {
+	case github.EventOpened:
+		return "opened the PR"
+	case github.EventCommitted:
+		return "committed: " + event.Detail
+	case github.EventForcePushed:
+		return "⚠️ force-pushed"
+	case github.EventReviewed:
+		switch event.Detail {
+		case "approved":
+			return "✅ approved"
+		case "changes_requested":
+			return "❌ requested changes"
+		case "dismissed":
+			return "had a review dismissed"
+		}
+		return "💬 reviewed"
+	case github.EventLabeled:
+		return fmt.Sprintf("added label `%s`", event.Detail)
+	case github.EventUnlabeled:
+		return fmt.Sprintf("removed label `%s`", event.Detail)
+	case github.EventReady:
+		return "marked ready for review"
+	case github.EventDraft:
+		return "converted to draft"
+	}
+	return event.Kind
+}
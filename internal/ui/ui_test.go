@@ -0,0 +1,402 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kennyp/speedrun/internal/ui/uitest"
+	"github.com/kennyp/speedrun/pkg/agent"
+	"github.com/kennyp/speedrun/pkg/cache"
+	"github.com/kennyp/speedrun/pkg/config"
+	"github.com/kennyp/speedrun/pkg/demo"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/history"
+	"github.com/kennyp/speedrun/pkg/logbuffer"
+	"github.com/kennyp/speedrun/pkg/tracker"
+)
+
+// startDemo runs the TUI on the demo PRs, with any changes to the demo
+// configuration applied
+func startDemo(t *testing.T, configure ...func(*config.Config)) *uitest.Program {
+	t.Helper()
+	return startDemoWith(t, demo.NewTransport(), configure...)
+}
+
+// startDemoWith runs the TUI on the demo PRs served by transport, which can
+// wrap the demo transport to inject failures
+func startDemoWith(t *testing.T, transport http.RoundTripper, configure ...func(*config.Config)) *uitest.Program {
+	t.Helper()
+	return startDemoCached(t, transport, cache.NewNoOpCache(), configure...)
+}
+
+// startDemoCached runs the TUI on the demo PRs served by transport, with
+// GitHub data cached in store
+func startDemoCached(t *testing.T, transport http.RoundTripper, store cache.Cache, configure ...func(*config.Config)) *uitest.Program {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.UseDemo()
+	cfg.GitHub.SearchQuery = "is:open is:pr"
+	cfg.AI.AnalysisTimeout = 5 * time.Second
+	cfg.GitHub.Backoff.MaxElapsedTime = time.Second
+	cfg.AI.Backoff.MaxElapsedTime = time.Second
+	for _, f := range configure {
+		f(cfg)
+	}
+
+	ctx := context.Background()
+	client, err := github.NewClient(ctx, cfg.GitHub.Token, cfg.GitHub.SearchQuery, store, cfg.GitHub.Backoff, github.ChecksConfig{}, 0, transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetDryRun(true)
+	ai := agent.NewAgent(agent.Endpoint{APIKey: cfg.AI.APIKey, Transport: transport}, "demo", cfg.AI.Backoff, nil, time.Second, 0, nil)
+	ai.SetRepoAccess(cfg.AI.AllowedRepos, cfg.AI.DeniedRepos)
+
+	m := NewModel(ctx, cfg, client, ai, tracker.NewGitHub(client), history.NewNoOpRecorder(), logbuffer.New(10), demo.User)
+	return uitest.New(t, m, 160, 60)
+}
+
+// analyzed waits for the demo queue to load and every PR to be analyzed
+func analyzed(t *testing.T, p *uitest.Program) string {
+	t.Helper()
+	return p.WaitFor(func(frame string) bool {
+		for _, pr := range demo.PRs {
+			if !strings.Contains(frame, pr.Title) {
+				return false
+			}
+		}
+		return !strings.Contains(frame, "AI analyzing") && !strings.Contains(frame, "Loading")
+	})
+}
+
+func TestQueueLoads(t *testing.T) {
+	p := startDemo(t)
+	frame := analyzed(t, p)
+	for _, want := range []string{"Pull Requests for demo-user", "DEEP_REVIEW", "1 failing", "2 pending"} {
+		if !strings.Contains(frame, want) {
+			t.Errorf("queue doesn't show %q:\n%s", want, frame)
+		}
+	}
+}
+
+func TestSortByAttention(t *testing.T) {
+	p := startDemo(t, func(cfg *config.Config) { cfg.Priority.Checks = 10 })
+	frame := analyzed(t, p)
+
+	// Only #1293 has a failing check, so it rises to the top
+	var failing string
+	for _, pr := range demo.PRs {
+		if pr.Number == 1293 {
+			failing = pr.Title
+		}
+	}
+	for _, pr := range demo.PRs {
+		if pr.Title != failing && strings.Index(frame, pr.Title) < strings.Index(frame, failing) {
+			t.Errorf("%q listed before the PR with failing checks:\n%s", pr.Title, frame)
+		}
+	}
+}
+
+func TestAdvancedFilterByType(t *testing.T) {
+	p := startDemo(t)
+	analyzed(t, p)
+
+	p.Press("F")
+	p.WaitForText("Advanced Filter Options", "5 Documentation")
+	p.Press("5", "enter")
+	frame := p.WaitFor(func(frame string) bool {
+		return !strings.Contains(frame, "Advanced Filter Options") && !strings.Contains(frame, "Rewrite session token storage")
+	})
+	for _, want := range []string{"docs: fix typos in the contributing guide", "RFC: move the frontend build to Vite"} {
+		if !strings.Contains(frame, want) {
+			t.Errorf("documentation filter hides %q:\n%s", want, frame)
+		}
+	}
+}
+
+func TestAdvancedFilterCancel(t *testing.T) {
+	p := startDemo(t)
+	analyzed(t, p)
+
+	p.Press("F")
+	p.WaitForText("Advanced Filter Options")
+	p.Press("5", "esc")
+	frame := p.WaitFor(func(frame string) bool { return !strings.Contains(frame, "Advanced Filter Options") })
+	if !strings.Contains(frame, "Rewrite session token storage") {
+		t.Errorf("cancelled filter was applied:\n%s", frame)
+	}
+}
+
+func TestDetailsPopup(t *testing.T) {
+	p := startDemo(t)
+	analyzed(t, p)
+
+	p.Press("enter")
+	p.WaitForText("**Repository:**", "**PR Number:**", "Checks", "Reviews")
+
+	p.Press("esc")
+	p.WaitFor(func(frame string) bool { return !strings.Contains(frame, "**PR Number:**") })
+}
+
+func TestReasoningSummaryToggle(t *testing.T) {
+	p := startDemo(t)
+	analyzed(t, p)
+
+	// The generated client PR's demo analysis comes with a reasoning summary
+	p.Press("enter")
+	p.WaitForText("**PR Number:** #1295")
+	p.Press("end")
+	p.WaitForText("for the model's reasoning summary", "Prompt version:")
+	p.Press("t")
+	p.Press("end")
+	p.WaitForText("Reasoning summary:", "existing callers keep working")
+	p.Press("t")
+	p.WaitFor(func(frame string) bool { return !strings.Contains(frame, "existing callers keep working") })
+}
+
+func TestAIDeniedRepos(t *testing.T) {
+	p := startDemo(t, func(cfg *config.Config) { cfg.AI.DeniedRepos = []string{"acme/api"} })
+	analyzed(t, p)
+
+	m := p.Quit().(Model)
+	for _, item := range m.items {
+		if analyzed := item.AIAnalysis != nil; analyzed == (item.PR.Repo == "api") {
+			t.Errorf("%s#%d analyzed = %v", item.PR.Repo, item.PR.Number, analyzed)
+		}
+	}
+}
+
+func TestTemplateCompliance(t *testing.T) {
+	p := startDemo(t, func(cfg *config.Config) { cfg.Review.TemplateCheck = true })
+	analyzed(t, p)
+	p.WaitForText("template incomplete")
+
+	// Of the acme/api PRs, only #1290 fills out the demo template
+	m := p.Quit().(Model)
+	for _, item := range m.items {
+		if (item.Template != nil) != (item.PR.Repo == "api") {
+			t.Errorf("#%d checked against a template: %v", item.PR.Number, item.Template != nil)
+		}
+		incomplete := item.Template != nil && !item.Template.Complete()
+		if want := item.PR.Repo == "api" && item.PR.Number != 1290; incomplete != want {
+			t.Errorf("#%d template incomplete = %v, want %v (%+v)", item.PR.Number, incomplete, want, item.Template)
+		}
+	}
+}
+
+func TestPopupReleasedOnClose(t *testing.T) {
+	p := startDemo(t)
+	analyzed(t, p)
+
+	p.Press("enter")
+	p.WaitForText("**PR Number:**")
+	p.Press("esc")
+	p.WaitFor(func(frame string) bool { return !strings.Contains(frame, "**PR Number:**") })
+
+	m := p.Quit().(Model)
+	if m.popupContent != "" || len(m.checkLogs) != 0 || len(m.timelines) != 0 {
+		t.Errorf("closed popup kept %d bytes of content, %d check logs and %d timelines",
+			len(m.popupContent), len(m.checkLogs), len(m.timelines))
+	}
+}
+
+func TestPopupScrollsAfterResize(t *testing.T) {
+	p := startDemo(t)
+	analyzed(t, p)
+
+	p.Press("enter")
+	p.WaitForText("**PR Number:**")
+	p.Send(tea.WindowSizeMsg{Width: 60, Height: 20})
+	p.Press("end")
+	p.WaitFor(func(frame string) bool {
+		return strings.Contains(frame, "more above") && !strings.Contains(frame, "more below")
+	})
+
+	// From the end, one step up is enough to show there's more below
+	p.Press("up")
+	p.WaitForText("more above", "more below")
+}
+
+func TestResizeDetailsSaved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ui.json")
+	p := startDemo(t, func(cfg *config.Config) { cfg.UI.StatePath = path })
+	analyzed(t, p)
+
+	p.Press("+", "+", "-", "+")
+	p.WaitFor(func(string) bool { return loadLayout(path).DetailRows == 4 })
+	p.Quit()
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	}
+	p = startDemo(t, func(cfg *config.Config) { cfg.UI.StatePath = path })
+	p.Press("-")
+	p.WaitFor(func(string) bool { return loadLayout(path).DetailRows == 3 })
+}
+
+func TestStatusHistory(t *testing.T) {
+	p := startDemo(t)
+	analyzed(t, p)
+
+	p.Press("a")
+	p.WaitForText("Dry run: would approve PR #")
+
+	p.Press("M")
+	frame := p.WaitForText("Recent messages")
+	if !strings.Contains(frame, "ok    🧪 Dry run: would approve PR #") {
+		t.Errorf("history doesn't list the approval:\n%s", frame)
+	}
+
+	p.Press("M")
+	p.WaitFor(func(frame string) bool { return !strings.Contains(frame, "Recent messages") })
+}
+
+// failPath answers requests for a path with a 404 until fixed, and lets the
+// demo transport serve everything else
+type failPath struct {
+	path  string
+	fixed atomic.Bool
+}
+
+func (f *failPath) RoundTrip(req *http.Request) (*http.Response, error) {
+	if strings.HasSuffix(req.URL.Path, f.path) && !f.fixed.Load() {
+		rec := httptest.NewRecorder()
+		http.Error(rec, `{"message":"Not Found"}`, http.StatusNotFound)
+		return rec.Result(), nil
+	}
+	return demo.NewTransport().RoundTrip(req)
+}
+
+func TestErrorDetailsRetry(t *testing.T) {
+	// The newest PR is listed, and selected, first
+	failing := &failPath{path: "/repos/acme/api/pulls/1295/reviews"}
+	p := startDemoWith(t, failing)
+	p.WaitForText("│ 📊 PR #1295", "Review error")
+
+	p.Press("E")
+	frame := p.WaitForText("Errors", "Reviews", "404")
+	if !strings.Contains(frame, "to retry") {
+		t.Errorf("error popup doesn't offer a retry:\n%s", frame)
+	}
+
+	failing.fixed.Store(true)
+	p.Press("r")
+	p.WaitFor(func(frame string) bool {
+		return !strings.Contains(frame, "Review error") && !strings.Contains(frame, "AI analyzing") && !strings.Contains(frame, "Loading")
+	})
+}
+
+// holdPath holds requests for a path until released, and lets the demo
+// transport serve everything else
+type holdPath struct {
+	path    string
+	release chan struct{}
+}
+
+func (h *holdPath) RoundTrip(req *http.Request) (*http.Response, error) {
+	if strings.HasSuffix(req.URL.Path, h.path) {
+		select {
+		case <-h.release:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+	return demo.NewTransport().RoundTrip(req)
+}
+
+func TestCachedDataShownWhileLoading(t *testing.T) {
+	// Reviews an earlier session cached, long since expired
+	store, err := cache.New(filepath.Join(t.TempDir(), "cache.db"), -time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	reviews := []*github.Review{{User: "a", State: "COMMENTED"}, {User: "b", State: "COMMENTED"}, {User: "c", State: "COMMENTED"}}
+	if err := store.Set("reviews:acme/api#1295", reviews); err != nil {
+		t.Fatal(err)
+	}
+
+	holding := &holdPath{path: "/repos/acme/api/pulls/1295/reviews", release: make(chan struct{})}
+	p := startDemoCached(t, holding, store)
+	p.WaitForText("│ 📊 PR #1295", "👥 3 reviews ⏱")
+
+	close(holding.release)
+	p.WaitFor(func(frame string) bool { return !strings.Contains(frame, "3 reviews") })
+}
+
+func TestAIOnSelect(t *testing.T) {
+	p := startDemo(t, func(cfg *config.Config) { cfg.AI.Trigger = config.AITriggerOnSelect })
+	frame := p.WaitFor(func(frame string) bool {
+		return strings.Contains(frame, "AI on select") && !strings.Contains(frame, "AI analyzing") && !strings.Contains(frame, "Loading")
+	})
+	// Only the selected PR has been analyzed
+	if !strings.Contains(frame, "APPROVE") {
+		t.Errorf("selected PR wasn't analyzed:\n%s", frame)
+	}
+	waiting := strings.Count(frame, "AI on select")
+
+	// Moving down selects, and analyzes, the next PR
+	p.Press("down")
+	p.WaitFor(func(frame string) bool {
+		return strings.Count(frame, "AI on select") == waiting-1 && !strings.Contains(frame, "AI analyzing")
+	})
+}
+
+func TestPrefetchNearSelection(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.UseDemo()
+	client, err := github.NewClient(context.Background(), cfg.GitHub.Token, "is:open is:pr", cache.NewNoOpCache(), cfg.GitHub.Backoff, github.ChecksConfig{}, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(context.Background(), cfg, client, nil, nil, history.NewNoOpRecorder(), logbuffer.New(10), demo.User)
+	next, _ := m.update(tea.WindowSizeMsg{Width: 160, Height: 60})
+	m = next.(Model)
+
+	prs := make([]*github.PullRequest, 30)
+	for i := range prs {
+		prs[i] = &github.PullRequest{Number: i + 1, Owner: "acme", Repo: "app"}
+	}
+	m, _ = m.handlePRsLoaded(PRsLoadedMsg{PRs: prs})
+	m.list.Select(20)
+	started := func(from, to int) bool {
+		for i, item := range m.items {
+			if (i >= from && i <= to) == item.Pending {
+				return false
+			}
+		}
+		return true
+	}
+
+	// The window around the selection loads first
+	m, _ = m.prefetch()
+	if !started(15, 25) {
+		t.Fatalf("prefetch() didn't start just rows 15-25 around the selection")
+	}
+
+	// Nothing starts again until something changes
+	if _, cmd := m.prefetch(); cmd != nil {
+		t.Errorf("prefetch() looked again with nothing changed")
+	}
+
+	// and as it finishes, the nearest of the rest
+	for _, item := range m.items {
+		if !item.Pending {
+			m = m.updatePRByID(item.ID, func(item *PRItem) {
+				item.LoadingDiff, item.LoadingChecks, item.LoadingReviews, item.LoadingSigs = false, false, false, false
+			})
+		}
+	}
+	m, _ = m.prefetch()
+	if !started(8, 29) {
+		t.Errorf("prefetch() didn't go on to rows 8-29")
+	}
+}
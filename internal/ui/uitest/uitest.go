@@ -0,0 +1,193 @@
+// Package uitest drives a Bubble Tea model in a real program loop, as a
+// terminal would, so tests can script key presses and wait for what the model
+// renders. Commands run for real, so the model's clients should be pointed at
+// something fake, such as the demo transport.
+package uitest
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultTimeout is how long WaitFor waits for a frame before failing
+const DefaultTimeout = 5 * time.Second
+
+// Program is a model running under test
+type Program struct {
+	Timeout time.Duration // How long WaitFor waits; DefaultTimeout if zero
+
+	t       testing.TB
+	program *tea.Program
+	done    chan struct{}
+	final   tea.Model
+	err     error
+
+	mu    sync.Mutex
+	frame string
+}
+
+// recorder keeps the last frame its model rendered
+type recorder struct {
+	tea.Model
+	p *Program
+}
+
+func (r *recorder) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	r.Model, cmd = r.Model.Update(msg)
+	return r, cmd
+}
+
+func (r *recorder) View() string {
+	view := r.Model.View()
+	r.p.mu.Lock()
+	r.p.frame = stripANSI(view)
+	r.p.mu.Unlock()
+	return view
+}
+
+// escapes matches terminal escape sequences
+var escapes = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+func stripANSI(s string) string {
+	return escapes.ReplaceAllString(s, "")
+}
+
+// New starts model in a terminal of the given size. The program is killed
+// when the test ends, if it hasn't quit.
+func New(t testing.TB, model tea.Model, width, height int) *Program {
+	t.Helper()
+	p := &Program{t: t, done: make(chan struct{})}
+	p.program = tea.NewProgram(&recorder{Model: model, p: p},
+		tea.WithInput(nil),
+		tea.WithOutput(io.Discard),
+		tea.WithoutRenderer(),
+		tea.WithoutSignalHandler(),
+	)
+	go func() {
+		defer close(p.done)
+		p.final, p.err = p.program.Run()
+	}()
+	t.Cleanup(func() {
+		p.program.Kill()
+		<-p.done
+	})
+
+	p.Send(tea.WindowSizeMsg{Width: width, Height: height})
+	return p
+}
+
+// Send delivers messages to the model in order
+func (p *Program) Send(msgs ...tea.Msg) {
+	for _, msg := range msgs {
+		p.program.Send(msg)
+	}
+}
+
+// Type presses a key for each character of text
+func (p *Program) Type(text string) {
+	for _, r := range text {
+		p.Send(keyMsg(string(r)))
+	}
+}
+
+// Press presses keys by name, as the key bindings spell them: "enter", "esc",
+// "tab", "shift+tab", "up", "ctrl+c", "F" and so on
+func (p *Program) Press(keys ...string) {
+	for _, k := range keys {
+		p.Send(keyMsg(k))
+	}
+}
+
+// namedKeys are the keys Press knows by name
+var namedKeys = map[string]tea.KeyType{
+	"enter":     tea.KeyEnter,
+	"esc":       tea.KeyEsc,
+	"tab":       tea.KeyTab,
+	"shift+tab": tea.KeyShiftTab,
+	"backspace": tea.KeyBackspace,
+	"up":        tea.KeyUp,
+	"down":      tea.KeyDown,
+	"left":      tea.KeyLeft,
+	"right":     tea.KeyRight,
+	"pgup":      tea.KeyPgUp,
+	"pgdown":    tea.KeyPgDown,
+	"home":      tea.KeyHome,
+	"end":       tea.KeyEnd,
+	"ctrl+c":    tea.KeyCtrlC,
+	" ":         tea.KeySpace,
+}
+
+func keyMsg(name string) tea.KeyMsg {
+	if k, ok := namedKeys[name]; ok {
+		msg := tea.KeyMsg{Type: k}
+		if k == tea.KeySpace {
+			msg.Runes = []rune(" ")
+		}
+		return msg
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name)}
+}
+
+// Frame returns the last frame the model rendered, without escape sequences
+func (p *Program) Frame() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.frame
+}
+
+// WaitFor waits for a frame cond accepts and returns it. The test fails with
+// the last frame if none does in time or the program exits.
+func (p *Program) WaitFor(cond func(frame string) bool) string {
+	p.t.Helper()
+	timeout := p.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	deadline := time.After(timeout)
+	tick := time.NewTicker(10 * time.Millisecond)
+	defer tick.Stop()
+	for {
+		if frame := p.Frame(); cond(frame) {
+			return frame
+		}
+		select {
+		case <-tick.C:
+		case <-p.done:
+			p.t.Fatalf("program exited (%v) while waiting; last frame:\n%s", p.err, p.Frame())
+		case <-deadline:
+			p.t.Fatalf("timed out after %s waiting; last frame:\n%s", timeout, p.Frame())
+		}
+	}
+}
+
+// WaitForText waits for a frame containing all of texts
+func (p *Program) WaitForText(texts ...string) string {
+	p.t.Helper()
+	return p.WaitFor(func(frame string) bool {
+		for _, text := range texts {
+			if !strings.Contains(frame, text) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Quit asks the program to quit and returns the final model
+func (p *Program) Quit() tea.Model {
+	p.t.Helper()
+	p.program.Quit()
+	<-p.done
+	if p.err != nil {
+		p.t.Fatalf("program failed: %v", p.err)
+	}
+	return p.final.(*recorder).Model
+}
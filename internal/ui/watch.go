@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/agent"
+	"github.com/kennyp/speedrun/pkg/config"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/tracker"
+)
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// watchTitleWidth is how much of each PR title the watch table shows
+const watchTitleWidth = 60
+
+// Watch prints a table of the PR queue every interval until ctx is done,
+// like `watch kubectl get pods`. With clear set the screen is redrawn in
+// place; otherwise each refresh is appended.
+func Watch(ctx context.Context, w io.Writer, cfg *config.Config, githubClient *github.Client, aiAgent *agent.Agent, issueTracker tracker.Tracker, username string, interval time.Duration, clear bool) error {
+	// Analyses by head SHA, so PRs are only re-analyzed when they change
+	analyses := make(map[string]*agent.Analysis)
+
+	for {
+		var out strings.Builder
+		if clear {
+			out.WriteString(clearScreen)
+		}
+		out.WriteString(fmt.Sprintf("Every %s: pull requests for %s    %s\n\n", interval, username, time.Now().Format("15:04:05")))
+
+		prs, err := githubClient.SearchPullRequestsFresh(ctx)
+		if err != nil {
+			out.WriteString(fmt.Sprintf("Failed to refresh: %v\n", err))
+		} else {
+			items := loadPlainItems(ctx, cfg, githubClient, issueTracker, username, prs)
+			analyses = analyzeWatchItems(ctx, cfg, aiAgent, items, analyses)
+			writeWatchTable(&out, items, cfg)
+		}
+
+		if _, err := io.WriteString(w, out.String()); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return nil
+			}
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// analyzeWatchItems fills in AI analyses, reusing those from the previous
+// refresh for unchanged PRs. It returns the analyses of the current queue.
+func analyzeWatchItems(ctx context.Context, cfg *config.Config, aiAgent *agent.Agent, items []PRItem, previous map[string]*agent.Analysis) map[string]*agent.Analysis {
+	current := make(map[string]*agent.Analysis)
+	var mu sync.Mutex
+	eachItem(len(items), func(i int) {
+		item := &items[i]
+		sha := item.PR.HeadSHA
+		if analysis, ok := previous[sha]; ok && sha != "" {
+			item.AIAnalysis = analysis
+		} else {
+			analyzePlainItem(ctx, cfg, aiAgent, item)
+		}
+
+		if item.AIAnalysis != nil && sha != "" {
+			mu.Lock()
+			current[sha] = item.AIAnalysis
+			mu.Unlock()
+		}
+	})
+	return current
+}
+
+// writeWatchTable writes one row per PR
+func writeWatchTable(w io.Writer, items []PRItem, cfg *config.Config) {
+	if len(items) == 0 {
+		_, _ = fmt.Fprintln(w, "No pull requests.")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "PR\tAGE\tSTATUS\tCHECKS\tAI\tTITLE")
+	for _, item := range items {
+		_, _ = fmt.Fprintf(tw, "%s/%s#%d\t%s\t%s\t%s\t%s\t%s\n",
+			item.PR.Owner, item.PR.Repo, item.PR.Number,
+			formatAge(item.Age()),
+			watchStatus(item, cfg),
+			watchChecks(item),
+			watchAI(item),
+			truncateTitle(item.PR.Title, watchTitleWidth))
+	}
+	_ = tw.Flush()
+}
+
+// watchStatus is my review state, flagging PRs past the review SLA
+func watchStatus(item PRItem, cfg *config.Config) string {
+	status := "-"
+	switch {
+	case item.ChangedSinceApproval():
+		status = "changed"
+	case item.Approved:
+		status = "approved"
+	case item.Dismissed:
+		status = "dismissed"
+	case item.Reviewed:
+		status = "reviewed"
+	}
+	if item.BreachesSLA(cfg.GitHub.ReviewSLA) {
+		status += ",overdue"
+	}
+	return status
+}
+
+func watchChecks(item PRItem) string {
+	switch {
+	case item.CheckStatus != nil:
+		return item.CheckStatus.State
+	case item.CheckError != nil:
+		return "error"
+	default:
+		return "-"
+	}
+}
+
+func watchAI(item PRItem) string {
+	switch {
+	case item.AIAnalysis != nil:
+		return fmt.Sprintf("%s/%s", item.AIAnalysis.Recommendation, item.AIAnalysis.RiskLevel)
+	case errors.Is(item.AIError, agent.ErrCircuitOpen):
+		return "paused"
+	case item.AIError != nil:
+		return "error"
+	default:
+		return "-"
+	}
+}
+
+// truncateTitle shortens a title to at most width characters
+func truncateTitle(title string, width int) string {
+	runes := []rune(title)
+	if len(runes) <= width {
+		return title
+	}
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// prWork holds the cancellable context for a PR's background fetches
+type prWork struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// prContext returns the context for a PR's background fetches, creating it
+// on first use. It is a child of the app context, so quitting cancels it too.
+func (m Model) prContext(id int64) context.Context {
+	if work, ok := m.prWork[id]; ok {
+		return work.ctx
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.prWork[id] = prWork{ctx: ctx, cancel: cancel}
+	return ctx
+}
+
+// cancelPRWork cancels any in-flight fetches for a PR. The next prContext
+// call for the PR starts a fresh context.
+func (m Model) cancelPRWork(id int64, reason string) {
+	work, ok := m.prWork[id]
+	if !ok {
+		return
+	}
+
+	slog.Debug("Cancelling PR work", slog.Int64("prID", id), slog.String("reason", reason))
+	work.cancel()
+	delete(m.prWork, id)
+}
+
+// isVisible reports whether a PR is currently shown in the list
+func (m Model) isVisible(id int64) bool {
+	for _, listItem := range m.list.Items() {
+		if item, ok := listItem.(PRItem); ok && item.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// cancelHiddenWork stops AI analysis for PRs the current filters hide. Only
+// PRs whose other data has finished loading are considered, since the
+// filters can't make a final decision before that.
+func (m Model) cancelHiddenWork() {
+	for _, item := range m.items {
+		if !item.LoadingAI || item.LoadingDiff || item.LoadingChecks || item.LoadingReviews || item.LoadingIssues || item.LoadingSigs {
+			continue
+		}
+		if !m.analysisVisible(item.ID) {
+			m.cancelPRWork(item.ID, "filtered out")
+		}
+	}
+}
+
+// skippedAnalysisCmd reports an analysis that was never started because the
+// PR is hidden, so it can be resumed like a cancelled one
+func skippedAnalysisCmd(prID int64) tea.Cmd {
+	return func() tea.Msg {
+		return AIAnalysisLoadedMsg{PRID: prID, Err: context.Canceled}
+	}
+}
+
+// resumeCancelledAnalyses restarts AI analysis for visible PRs whose analysis
+// was cancelled while they were filtered out
+func (m Model) resumeCancelledAnalyses() (Model, tea.Cmd) {
+	var resumed []int
+	for i := range m.items {
+		item := &m.items[i]
+		if !errors.Is(item.AIError, context.Canceled) || !m.analysisVisible(item.ID) {
+			continue
+		}
+
+		item.LoadingAI = true
+		item.AIError = nil
+		resumed = append(resumed, i)
+	}
+
+	// Dependency groups share one analysis, so trigger each group once
+	var cmds []tea.Cmd
+	triggered := make(map[string]bool)
+	for _, i := range resumed {
+		if key := bumpKey(m.items[i]); m.items[i].GroupSize > 1 {
+			if triggered[key] {
+				continue
+			}
+			triggered[key] = true
+		}
+		cmds = append(cmds, m.triggerAIAnalysisIfReady(i))
+	}
+	return m, tea.Batch(cmds...)
+}
@@ -0,0 +1,244 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/workload"
+)
+
+// workloadFetchers bounds concurrent reviewer lookups when loading team workload
+const workloadFetchers = 8
+
+// workloadState holds the team workload view
+type workloadState struct {
+	loading     bool
+	team        []string
+	prs         []workload.PR
+	suggestions []workload.Reassignment
+}
+
+// WorkloadLoadedMsg is sent when team members and the requested reviewers of
+// every listed PR have been fetched
+type WorkloadLoadedMsg struct {
+	Team []string
+	PRs  []workload.PR
+	Err  error
+}
+
+// ReviewerReassignedMsg is sent when a review request has been moved
+type ReviewerReassignedMsg struct {
+	PRID int64
+	From string
+	To   string
+	Err  error
+}
+
+// LoadWorkloadCmd fetches the members of the given teams and who is requested
+// to review each PR
+func LoadWorkloadCmd(ctx context.Context, client *github.Client, teams []string, items []PRItem) tea.Cmd {
+	return func() tea.Msg {
+		var team []string
+		for _, t := range teams {
+			members, err := client.GetTeamMembers(ctx, t)
+			if err != nil {
+				return WorkloadLoadedMsg{Err: err}
+			}
+			for _, member := range members {
+				if !slices.Contains(team, member) {
+					team = append(team, member)
+				}
+			}
+		}
+
+		prs := make([]workload.PR, len(items))
+		errs := make([]error, len(items))
+		sem := make(chan struct{}, workloadFetchers)
+		var wg sync.WaitGroup
+		for i, item := range items {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				reviewers, err := item.PR.GetRequestedReviewers(ctx)
+				prs[i] = workload.PR{ID: item.ID, Author: item.PR.GetAuthor(), Reviewers: reviewers}
+				errs[i] = err
+			}()
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			if err != nil {
+				return WorkloadLoadedMsg{Err: err}
+			}
+		}
+		return WorkloadLoadedMsg{Team: team, PRs: prs}
+	}
+}
+
+// ReassignReviewerCmd moves a review request from one teammate to another
+func ReassignReviewerCmd(ctx context.Context, pr *github.PullRequest, from, to string, prID int64) tea.Cmd {
+	return func() tea.Msg {
+		err := pr.ReassignReviewer(ctx, from, to)
+		return ReviewerReassignedMsg{PRID: prID, From: from, To: to, Err: err}
+	}
+}
+
+// handleWorkload opens the team workload view
+func (m Model) handleWorkload() (Model, tea.Cmd) {
+	if len(m.config.GitHub.Teams) == 0 {
+		m.setStatus(statusInfo, "Set github.teams to see your team's review load")
+		return m, nil
+	}
+
+	slog.Info("User opened team workload", slog.Any("teams", m.config.GitHub.Teams))
+	m.workload = &workloadState{loading: true}
+	return m, LoadWorkloadCmd(m.ctx, m.github, m.config.GitHub.Teams, m.items)
+}
+
+func (m Model) handleWorkloadLoaded(msg WorkloadLoadedMsg) (Model, tea.Cmd) {
+	if m.workload == nil {
+		return m, nil // Closed while loading
+	}
+	if msg.Err != nil {
+		slog.Error("Failed to load team workload", slog.Any("error", msg.Err))
+		m.workload = nil
+		m.setError("Failed to load team workload", msg.Err)
+		return m, nil
+	}
+
+	m.workload = &workloadState{
+		team:        msg.Team,
+		prs:         msg.PRs,
+		suggestions: workload.Suggest(msg.PRs, msg.Team),
+	}
+	return m, nil
+}
+
+// handleWorkloadKey applies suggestions or closes the workload view
+func (m Model) handleWorkloadKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "W":
+		m.workload = nil
+		return m, nil
+	case "a":
+		return m.applyReassignments(m.workload.suggestions)
+	}
+
+	choice, err := strconv.Atoi(msg.String())
+	if err != nil || choice < 1 || choice > len(m.workload.suggestions) {
+		return m, nil
+	}
+	return m.applyReassignments(m.workload.suggestions[choice-1 : choice])
+}
+
+func (m Model) applyReassignments(moves []workload.Reassignment) (Model, tea.Cmd) {
+	if m.github.Health().Degraded {
+		m.setStatus(statusInfo, "Reassignments are disabled while GitHub is unavailable")
+		return m, nil
+	}
+
+	var cmds []tea.Cmd
+	for _, move := range moves {
+		if item := m.findPRByID(move.PRID); item != nil {
+			cmds = append(cmds, ReassignReviewerCmd(m.ctx, item.PR, move.From, move.To, move.PRID))
+		}
+	}
+	if len(cmds) == 0 {
+		return m, nil
+	}
+
+	slog.Info("User applied reviewer reassignments", slog.Int("count", len(cmds)))
+	m.setStatus(statusInfo, fmt.Sprintf("Reassigning %d %s...", len(cmds), pluralPRs(len(cmds))))
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) handleReviewerReassigned(msg ReviewerReassignedMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		slog.Error("Reviewer reassignment failed in UI", slog.Int64("prID", msg.PRID), slog.Any("error", msg.Err))
+		m.setStatus(statusError, "Failed to reassign reviewer: "+msg.Err.Error())
+		return m, nil
+	}
+
+	number := 0
+	if item := m.findPRByID(msg.PRID); item != nil {
+		number = item.PR.Number
+	}
+	m.setStatus(statusSuccess, m.actionStatus(fmt.Sprintf("👥 PR #%d: %s → %s", number, msg.From, msg.To), fmt.Sprintf("would reassign PR #%d from %s to %s", number, msg.From, msg.To)))
+
+	// Reflect the move and re-plan from the new state
+	if m.workload != nil && !m.workload.loading {
+		prs := slices.Clone(m.workload.prs)
+		for i := range prs {
+			if prs[i].ID == msg.PRID {
+				reviewers := slices.DeleteFunc(slices.Clone(prs[i].Reviewers), func(r string) bool { return r == msg.From })
+				prs[i].Reviewers = append(reviewers, msg.To)
+			}
+		}
+		m.workload = &workloadState{
+			team:        m.workload.team,
+			prs:         prs,
+			suggestions: workload.Suggest(prs, m.workload.team),
+		}
+	}
+	return m, nil
+}
+
+// renderWorkload renders the team workload overlay
+func (m Model) renderWorkload() string {
+	width, height := m.width, m.height
+
+	var content strings.Builder
+	content.WriteString("Team Review Load\n\n")
+
+	if m.workload.loading {
+		content.WriteString("⏳ Loading requested reviewers...")
+	} else {
+		counts := workload.Counts(m.workload.prs, m.workload.team)
+		team := slices.Clone(m.workload.team)
+		slices.SortFunc(team, func(a, b string) int {
+			if counts[a] != counts[b] {
+				return counts[b] - counts[a]
+			}
+			return strings.Compare(a, b)
+		})
+		for _, member := range team {
+			content.WriteString(fmt.Sprintf("  %-20s %3d %s\n", member, counts[member], strings.Repeat("█", counts[member])))
+		}
+
+		content.WriteString("\nSuggested reassignments:\n")
+		if len(m.workload.suggestions) == 0 {
+			content.WriteString("  Load is balanced\n")
+		}
+		for i, move := range m.workload.suggestions {
+			label := fmt.Sprintf("PR %d", move.PRID)
+			if item := m.findPRByID(move.PRID); item != nil {
+				label = fmt.Sprintf("%s/%s#%d", item.PR.Owner, item.PR.Repo, item.PR.Number)
+			}
+			content.WriteString(fmt.Sprintf("  %d %s: %s → %s\n", i+1, label, move.From, move.To))
+		}
+	}
+
+	content.WriteString("\nPress a number to apply one, a to apply all, or Esc to close")
+
+	dialog := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("75")).
+		Background(lipgloss.Color("235")).
+		Foreground(lipgloss.Color("255")).
+		Padding(1).
+		Width(min(width*8/10, 80) - 4).
+		Render(m.label(content.String()))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, dialog)
+}
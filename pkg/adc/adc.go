@@ -0,0 +1,294 @@
+// Package adc mints Google OAuth access tokens from Application Default
+// Credentials: the file GOOGLE_APPLICATION_CREDENTIALS names, gcloud's
+// application default credentials, or the metadata server on Google Cloud.
+package adc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// googleTokenURL exchanges refresh tokens and signed assertions for tokens
+const googleTokenURL = "https://oauth2.googleapis.com/token"
+
+// refreshEarly is how long before expiry a token is replaced, so requests
+// don't go out with a token that expires on the way
+const refreshEarly = time.Minute
+
+// ErrNoCredentials is returned when none of the places ADC looks have
+// credentials
+var ErrNoCredentials = errors.New("no Google application default credentials found (run `gcloud auth application-default login` or set GOOGLE_APPLICATION_CREDENTIALS)")
+
+// TokenSource returns access tokens for the scopes, reading the credentials
+// the first time a token is needed and renewing the token as it expires
+type TokenSource struct {
+	scopes []string
+	client *http.Client
+
+	mu      sync.Mutex
+	creds   *credentials // Nil until found
+	token   string
+	expires time.Time
+}
+
+// credentials is an ADC JSON file, or the metadata server when Type is
+// "metadata"
+type credentials struct {
+	Type           string `json:"type"` // authorized_user, service_account or metadata
+	ClientID       string `json:"client_id"`
+	ClientSecret   string `json:"client_secret"`
+	RefreshToken   string `json:"refresh_token"`
+	ClientEmail    string `json:"client_email"`
+	PrivateKey     string `json:"private_key"`
+	PrivateKeyID   string `json:"private_key_id"`
+	TokenURI       string `json:"token_uri"`
+	QuotaProjectID string `json:"quota_project_id"`
+}
+
+// New returns a token source for the scopes, requesting tokens over
+// transport (http.DefaultTransport if nil)
+func New(transport http.RoundTripper, scopes ...string) *TokenSource {
+	return &TokenSource{scopes: scopes, client: &http.Client{Transport: transport, Timeout: 30 * time.Second}}
+}
+
+// Token returns a valid access token
+func (ts *TokenSource) Token(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != "" && time.Now().Add(refreshEarly).Before(ts.expires) {
+		return ts.token, nil
+	}
+	if ts.creds == nil {
+		creds, err := find(ctx, ts.client)
+		if err != nil {
+			return "", err
+		}
+		ts.creds = creds
+	}
+
+	token, expiresIn, err := ts.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	ts.token, ts.expires = token, time.Now().Add(expiresIn)
+	return token, nil
+}
+
+// QuotaProject returns the project user credentials bill API calls to, if
+// they name one. Call it after Token.
+func (ts *TokenSource) QuotaProject() string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.creds == nil {
+		return ""
+	}
+	return ts.creds.QuotaProjectID
+}
+
+// find looks for credentials where ADC does, in order
+func find(ctx context.Context, client *http.Client) (*credentials, error) {
+	if path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); path != "" {
+		return readFile(path)
+	}
+	if path := wellKnownFile(); path != "" {
+		if _, err := os.Stat(path); err == nil {
+			return readFile(path)
+		}
+	}
+	if onGCE(ctx, client) {
+		return &credentials{Type: "metadata"}, nil
+	}
+	return nil, ErrNoCredentials
+}
+
+// wellKnownFile is where `gcloud auth application-default login` writes
+func wellKnownFile() string {
+	dir := os.Getenv("CLOUDSDK_CONFIG")
+	if dir == "" {
+		if runtime.GOOS == "windows" {
+			dir = filepath.Join(os.Getenv("APPDATA"), "gcloud")
+		} else if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, ".config", "gcloud")
+		}
+	}
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "application_default_credentials.json")
+}
+
+func readFile(path string) (*credentials, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Google credentials: %w", err)
+	}
+	var creds credentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("failed to parse Google credentials %s: %w", path, err)
+	}
+	switch creds.Type {
+	case "authorized_user", "service_account":
+		return &creds, nil
+	default:
+		return nil, fmt.Errorf("unsupported Google credentials type %q in %s (want authorized_user or service_account)", creds.Type, path)
+	}
+}
+
+// metadataHost returns the metadata server's host
+func metadataHost() string {
+	if host := os.Getenv("GCE_METADATA_HOST"); host != "" {
+		return host
+	}
+	return "metadata.google.internal"
+}
+
+// onGCE reports whether the metadata server answers, waiting briefly
+func onGCE(ctx context.Context, client *http.Client) bool {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+metadataHost()+"/computeMetadata/v1/", nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+	return resp.Header.Get("Metadata-Flavor") == "Google"
+}
+
+// fetch gets a new token for the credentials
+func (ts *TokenSource) fetch(ctx context.Context) (string, time.Duration, error) {
+	switch ts.creds.Type {
+	case "authorized_user":
+		return ts.exchange(ctx, ts.creds.tokenURL(), url.Values{
+			"grant_type":    {"refresh_token"},
+			"client_id":     {ts.creds.ClientID},
+			"client_secret": {ts.creds.ClientSecret},
+			"refresh_token": {ts.creds.RefreshToken},
+		})
+	case "service_account":
+		assertion, err := ts.creds.assertion(ts.scopes, time.Now())
+		if err != nil {
+			return "", 0, err
+		}
+		return ts.exchange(ctx, ts.creds.tokenURL(), url.Values{
+			"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+			"assertion":  {assertion},
+		})
+	default:
+		return ts.metadataToken(ctx)
+	}
+}
+
+func (c *credentials) tokenURL() string {
+	if c.TokenURI != "" {
+		return c.TokenURI
+	}
+	return googleTokenURL
+}
+
+// tokenResponse is how token endpoints and the metadata server answer
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"` // Seconds
+	Error       string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (ts *TokenSource) exchange(ctx context.Context, tokenURL string, form url.Values) (string, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get Google access token: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return ts.do(req)
+}
+
+func (ts *TokenSource) metadataToken(ctx context.Context) (string, time.Duration, error) {
+	u := "http://" + metadataHost() + "/computeMetadata/v1/instance/service-accounts/default/token"
+	if len(ts.scopes) > 0 {
+		u += "?scopes=" + url.QueryEscape(strings.Join(ts.scopes, ","))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get Google access token: %w", err)
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	return ts.do(req)
+}
+
+func (ts *TokenSource) do(req *http.Request) (string, time.Duration, error) {
+	resp, err := ts.client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get Google access token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var token tokenResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&token); err != nil && resp.StatusCode == http.StatusOK {
+		return "", 0, fmt.Errorf("failed to parse Google access token: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || token.AccessToken == "" {
+		if token.Error != "" {
+			return "", 0, fmt.Errorf("failed to get Google access token: %s: %s", token.Error, token.Description)
+		}
+		return "", 0, fmt.Errorf("failed to get Google access token: %s", resp.Status)
+	}
+	return token.AccessToken, time.Duration(token.ExpiresIn) * time.Second, nil
+}
+
+// assertion returns a service account's signed JWT asking for the scopes
+func (c *credentials) assertion(scopes []string, now time.Time) (string, error) {
+	block, _ := pem.Decode([]byte(c.PrivateKey))
+	if block == nil {
+		return "", fmt.Errorf("invalid service account private key")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		if parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+			return "", fmt.Errorf("invalid service account private key: %w", err)
+		}
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return "", fmt.Errorf("service account private key isn't an RSA key")
+	}
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": c.PrivateKeyID})
+	claims, _ := json.Marshal(map[string]any{
+		"iss":   c.ClientEmail,
+		"scope": strings.Join(scopes, " "),
+		"aud":   c.tokenURL(),
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(nil, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign service account assertion: %w", err)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,141 @@
+package adc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeCredentials writes an ADC file and points GOOGLE_APPLICATION_CREDENTIALS at it
+func writeCredentials(t *testing.T, creds map[string]string) {
+	t.Helper()
+	data, _ := json.Marshal(creds)
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", path)
+}
+
+func TestAuthorizedUser(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if r.Form.Get("grant_type") != "refresh_token" || r.Form.Get("refresh_token") != "refresh-me" {
+			t.Errorf("token request = %v", r.Form)
+		}
+		_, _ = w.Write([]byte(`{"access_token": "ya29.user", "expires_in": 3600}`))
+	}))
+	defer server.Close()
+	writeCredentials(t, map[string]string{
+		"type": "authorized_user", "client_id": "id", "client_secret": "secret",
+		"refresh_token": "refresh-me", "quota_project_id": "acme-ai", "token_uri": server.URL,
+	})
+
+	ts := New(nil, "https://www.googleapis.com/auth/cloud-platform")
+	for range 2 {
+		token, err := ts.Token(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "ya29.user" {
+			t.Errorf("Token() = %q", token)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("token requests = %d, want 1 for an unexpired token", requests)
+	}
+	if project := ts.QuotaProject(); project != "acme-ai" {
+		t.Errorf("QuotaProject() = %q", project)
+	}
+}
+
+func TestServiceAccount(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	pemKey := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		parts := strings.Split(r.Form.Get("assertion"), ".")
+		if len(parts) != 3 {
+			t.Fatalf("assertion = %q", r.Form.Get("assertion"))
+		}
+		signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+			t.Errorf("assertion signature: %v", err)
+		}
+		var claims map[string]any
+		payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		_ = json.Unmarshal(payload, &claims)
+		if claims["iss"] != "bot@acme.iam.gserviceaccount.com" || claims["scope"] != "scope-a scope-b" {
+			t.Errorf("claims = %v", claims)
+		}
+		_, _ = w.Write([]byte(`{"access_token": "ya29.sa", "expires_in": 3600}`))
+	}))
+	defer server.Close()
+	writeCredentials(t, map[string]string{
+		"type": "service_account", "client_email": "bot@acme.iam.gserviceaccount.com",
+		"private_key": pemKey, "private_key_id": "k1", "token_uri": server.URL,
+	})
+
+	token, err := New(nil, "scope-a", "scope-b").Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "ya29.sa" {
+		t.Errorf("Token() = %q", token)
+	}
+}
+
+func TestMetadataServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			t.Errorf("request without Metadata-Flavor: %s", r.URL)
+		}
+		w.Header().Set("Metadata-Flavor", "Google")
+		if strings.HasSuffix(r.URL.Path, "/token") {
+			_, _ = w.Write([]byte(`{"access_token": "ya29.gce", "expires_in": 3600}`))
+		}
+	}))
+	defer server.Close()
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+	t.Setenv("CLOUDSDK_CONFIG", t.TempDir())
+	t.Setenv("GCE_METADATA_HOST", strings.TrimPrefix(server.URL, "http://"))
+
+	token, err := New(nil, "scope-a").Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "ya29.gce" {
+		t.Errorf("Token() = %q", token)
+	}
+}
+
+func TestUnsupportedCredentials(t *testing.T) {
+	writeCredentials(t, map[string]string{"type": "external_account"})
+	if _, err := New(nil).Token(context.Background()); err == nil || !strings.Contains(err.Error(), "external_account") {
+		t.Errorf("Token() error = %v, want unsupported type", err)
+	}
+}
@@ -2,21 +2,30 @@ package agent
 
 import (
 	"bytes"
+	"cmp"
 	"context"
+	"embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"net/url"
 	"strings"
+	"sync"
 	"text/template"
 	"time"
 
-	_ "embed"
-
 	"github.com/cenkalti/backoff/v4"
 	backoffconfig "github.com/kennyp/speedrun/pkg/backoff"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/metrics"
+	"github.com/kennyp/speedrun/pkg/tracing"
+	"github.com/kennyp/speedrun/pkg/tracker"
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/option"
 	"github.com/openai/openai-go/packages/param"
+	"github.com/openai/openai-go/shared"
 )
 
 //go:embed prompts/developer.md
@@ -25,6 +34,12 @@ var DeveloperMessage string
 //go:embed prompts/review.tmpl.md
 var ReviewMessageTemplate string
 
+// languagePrompts hold review notes for a language, named after it in lower
+// case, e.g. prompts/languages/go.md
+//
+//go:embed prompts/languages/*.md
+var languagePrompts embed.FS
+
 // Recommendation represents the AI's recommendation for a PR
 type Recommendation string
 
@@ -41,6 +56,14 @@ type Analysis struct {
 	RiskLevel      string
 	PRType         string // DOCUMENTATION/CODE/DEPENDENCY/MIXED
 	DocType        string // GENERAL/RFC/DECISION_RECORD/API_DOCS (only for DOCUMENTATION type)
+
+	// ReasoningSummary is what a reasoning model said about its thinking, if
+	// the provider returns it
+	ReasoningSummary string `json:",omitempty"`
+
+	// Provenance records what produced the analysis. It's nil for analyses
+	// cached before it was recorded.
+	Provenance *Provenance `json:",omitempty"`
 }
 
 // Implement AIAnalysis interface
@@ -64,68 +87,265 @@ func (a *Analysis) GetDocType() string {
 	return a.DocType
 }
 
-// Agent wraps the OpenAI client for PR analysis
+// Agent wraps the OpenAI client, or Gemini's, for PR analysis
 type Agent struct {
 	client        *openai.Client
+	gemini        *geminiClient // Used instead of client for the Gemini provider
 	model         string
+	provider      string
+	deployment    string // Azure only
 	backoffConfig backoffconfig.Config
 	toolRegistry  *ToolRegistry
 	toolTimeout   time.Duration
+	breaker       *CircuitBreaker
+	effort        string // Reasoning effort for reasoning models, empty for the model's default
+	params        Params
+	allowedRepos  []string // Repo patterns whose PRs may be sent to the model (empty allows all)
+	deniedRepos   []string // Repo patterns whose PRs are never sent to the model
 }
 
-// NewAgent creates a new AI agent
-func NewAgent(baseURL, apiKey, model string, backoffConfig backoffconfig.Config, toolRegistry *ToolRegistry, toolTimeout time.Duration) *Agent {
-	var opts []option.RequestOption
+// Params tunes each completion request. Zero values leave the model's
+// defaults.
+type Params struct {
+	Temperature *float64
+	TopP        *float64
+	MaxTokens   int64          // Most tokens to generate, reasoning included
+	Extra       map[string]any // Added to the request body as-is, e.g. for gateway routing
+}
+
+// Reasoning efforts a reasoning model can be asked for
+const (
+	EffortLow    = "low"
+	EffortMedium = "medium"
+	EffortHigh   = "high"
+)
+
+// AI providers
+const (
+	ProviderOpenAI = "openai" // OpenAI, or any gateway speaking its API
+	ProviderAzure  = "azure"  // Azure OpenAI
+	ProviderGemini = "gemini" // Google Gemini, with an API key or application default credentials
+)
 
-	if baseURL != "" {
-		opts = append(opts, option.WithBaseURL(baseURL))
+// Endpoint is where the agent sends its chat completions
+type Endpoint struct {
+	Provider string // ProviderOpenAI (the default), ProviderAzure or ProviderGemini
+	BaseURL  string // API base URL; for Azure, the resource endpoint (https://<resource>.openai.azure.com)
+	APIKey   string // For Gemini, empty to use application default credentials
+
+	Deployment string // Azure model deployment to use
+	APIVersion string // Azure API version, e.g. 2024-10-21
+
+	Headers map[string]string // Sent with every request, e.g. for gateway routing
+
+	Transport http.RoundTripper // Underneath every request, http.DefaultTransport if nil
+}
+
+// options returns the client options for the endpoint. Azure puts the
+// deployment in the path, takes the API version as a query parameter and
+// the key in an api-key header instead of a bearer token.
+func (e Endpoint) options() []option.RequestOption {
+	var opts []option.RequestOption
+	if e.Provider != ProviderAzure {
+		if e.BaseURL != "" {
+			opts = append(opts, option.WithBaseURL(e.BaseURL))
+		}
+		opts = append(opts, option.WithAPIKey(e.APIKey))
+	} else {
+		base := strings.TrimSuffix(e.BaseURL, "/") + "/openai/deployments/" + url.PathEscape(e.Deployment) + "/"
+		opts = append(opts,
+			option.WithBaseURL(base),
+			option.WithQuery("api-version", e.APIVersion),
+			option.WithHeader("api-key", e.APIKey),
+			// Don't send Azure an OPENAI_API_KEY from the environment
+			option.WithHeaderDel("authorization"),
+		)
 	}
 
-	client := openai.NewClient(append(opts, option.WithAPIKey(apiKey))...)
+	for name, value := range e.Headers {
+		opts = append(opts, option.WithHeader(name, value))
+	}
+	return opts
+}
 
-	return &Agent{
-		client:        &client,
+// NewAgent creates a new AI agent
+func NewAgent(endpoint Endpoint, model string, backoffConfig backoffconfig.Config, toolRegistry *ToolRegistry, toolTimeout, clientTimeout time.Duration, breaker *CircuitBreaker) *Agent {
+	a := &Agent{
 		model:         model,
+		provider:      cmp.Or(endpoint.Provider, ProviderOpenAI),
+		deployment:    endpoint.Deployment,
 		backoffConfig: backoffConfig,
 		toolRegistry:  toolRegistry,
 		toolTimeout:   toolTimeout,
+		breaker:       breaker,
 	}
+
+	httpClient := &http.Client{Transport: tracing.NewTransport(endpoint.Transport), Timeout: clientTimeout}
+	if endpoint.Provider == ProviderGemini {
+		a.gemini = newGeminiClient(endpoint, httpClient)
+		return a
+	}
+	client := openai.NewClient(append([]option.RequestOption{option.WithHTTPClient(httpClient)}, endpoint.options()...)...)
+	a.client = &client
+	return a
+}
+
+// SetReasoningEffort asks reasoning models (OpenAI's o-series, Gemini's
+// thinking models) to think for longer or shorter: EffortLow, EffortMedium or
+// EffortHigh. Empty leaves it to the model, and other models ignore it.
+func (a *Agent) SetReasoningEffort(effort string) {
+	a.effort = effort
+}
+
+// SetParams sets the sampling parameters, token limit and extra body
+// fields sent with each request
+func (a *Agent) SetParams(params Params) {
+	a.params = params
+}
+
+// ErrRepoNotAllowed is returned without calling the model for PRs from
+// repositories outside ai.allowed_repos or in ai.denied_repos
+var ErrRepoNotAllowed = errors.New("AI analysis is not allowed for this repository")
+
+// SetRepoAccess limits analysis to PRs from repositories matching allowed
+// (all when empty) and not matching denied, so code that mustn't leave
+// for the model's provider never does. Patterns are as for
+// github.Client.SetRepoAccess.
+func (a *Agent) SetRepoAccess(allowed, denied []string) {
+	a.allowedRepos, a.deniedRepos = allowed, denied
+}
+
+// RepoAllowed reports whether PRs from owner/repo may be analyzed
+func (a *Agent) RepoAllowed(owner, repo string) bool {
+	return github.RepoAccessible(owner, repo, a.allowedRepos, a.deniedRepos)
+}
+
+// PausedUntil returns when AI analysis resumes if the circuit breaker is
+// open, or the zero time if analysis is available
+func (a *Agent) PausedUntil() time.Time {
+	return a.breaker.OpenUntil()
 }
 
 // AnalyzePR analyzes a PR and returns a recommendation
 func (a *Agent) AnalyzePR(ctx context.Context, prData PRData) (*Analysis, error) {
+	ctx, span := tracing.Start(ctx, "ai.AnalyzePR", tracing.String("ai.model", a.model), tracing.Int("github.pr", prData.PR.Number))
+	defer span.End()
+
+	if err := a.checkRepoAccess(prData); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
 	prompt, err := a.buildPrompt(prData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate prompt (%w)", err)
 	}
+	prompt, redacted := redact(prompt)
+	reportRedactions(span, "prompt", redacted)
 
-	// Initialize the conversation
-	messages := []openai.ChatCompletionMessageParamUnion{
-		openai.DeveloperMessage(DeveloperMessage),
-		openai.UserMessage(prompt),
+	if !a.breaker.Allow() {
+		span.RecordError(ErrCircuitOpen)
+		return nil, ErrCircuitOpen
 	}
 
 	// Execute conversation with tool support
-	finalResponse, err := a.executeConversation(ctx, messages)
+	var finalResponse, reasoning string
+	if a.gemini != nil {
+		finalResponse, reasoning, err = a.executeGeminiConversation(ctx, prompt)
+	} else {
+		finalResponse, reasoning, err = a.executeConversation(ctx, []openai.ChatCompletionMessageParamUnion{
+			openai.DeveloperMessage(DeveloperMessage),
+			openai.UserMessage(prompt),
+		})
+	}
+	a.breaker.Done(err)
 	if err != nil {
+		span.RecordError(err)
 		return nil, fmt.Errorf("failed to execute conversation: %w", err)
 	}
 
-	return a.parseResponse(finalResponse), nil
+	analysis := a.parseResponse(finalResponse)
+	analysis.ReasoningSummary = reasoning
+	enforceSignatures(analysis, prData)
+	enforceSizeBudget(analysis, prData)
+	analysis.Provenance = a.provenance()
+	return analysis, nil
 }
 
-// executeConversation handles the conversation loop with tool calling support
-func (a *Agent) executeConversation(ctx context.Context, messages []openai.ChatCompletionMessageParamUnion) (string, error) {
+// enforceSignatures downgrades an approval when the repo requires signed
+// commits and some aren't verified, whatever the model concluded
+func enforceSignatures(analysis *Analysis, pr PRData) {
+	if !pr.SignaturesRequired || pr.UnverifiedCommits() == 0 || analysis.Recommendation != Approve {
+		return
+	}
+
+	slog.Info("Downgrading AI approval for unsigned commits", slog.Int("pr", pr.PR.Number), slog.Int("unverified", pr.UnverifiedCommits()))
+	analysis.Recommendation = Review
+	analysis.Reasoning = fmt.Sprintf("%d of %d commits are not signed in a repo that requires signing. %s",
+		pr.UnverifiedCommits(), pr.Signatures.Commits, analysis.Reasoning)
+}
+
+// enforceSizeBudget downgrades an approval of a PR over the size budget when
+// the budget is enforced
+func enforceSizeBudget(analysis *Analysis, pr PRData) {
+	exceeded := pr.SizeBudgetExceeded()
+	if !pr.SizeBudget.Enforce || exceeded == "" || analysis.Recommendation != Approve {
+		return
+	}
+
+	slog.Info("Downgrading AI approval for PR over size budget", slog.Int("pr", pr.PR.Number), slog.String("exceeded", exceeded))
+	analysis.Recommendation = Review
+	analysis.Reasoning = fmt.Sprintf("PR is over the size budget (%s). %s", exceeded, analysis.Reasoning)
+}
+
+// checkRepoAccess returns ErrRepoNotAllowed if the PR, or a PR grouped with
+// it, is from a repository that mustn't be sent to the model
+func (a *Agent) checkRepoAccess(pr PRData) error {
+	if !a.RepoAllowed(pr.PR.Owner, pr.PR.Repo) {
+		return fmt.Errorf("%w: %s/%s", ErrRepoNotAllowed, pr.PR.Owner, pr.PR.Repo)
+	}
+	for _, member := range pr.GroupMembers {
+		owner, repo, _ := strings.Cut(member.Repo, "/")
+		if !a.RepoAllowed(owner, repo) {
+			return fmt.Errorf("%w: %s", ErrRepoNotAllowed, member.Repo)
+		}
+	}
+	return nil
+}
+
+// executeConversation handles the conversation loop with tool calling
+// support. It returns the final answer and any reasoning the model shared
+// along the way.
+func (a *Agent) executeConversation(ctx context.Context, messages []openai.ChatCompletionMessageParamUnion) (string, string, error) {
 	const maxIterations = 10 // Prevent infinite loops
+	var reasoning []string
 
 	for iteration := 0; iteration < maxIterations; iteration++ {
 		slog.Debug("Executing conversation iteration", slog.Int("iteration", iteration))
 
+		iterCtx, span := tracing.Start(ctx, "ai.chat_completion", tracing.Int("ai.iteration", iteration))
+
 		// Prepare chat completion parameters
 		params := openai.ChatCompletionNewParams{
 			Messages: messages,
 			Model:    a.model,
 		}
+		if a.effort != "" {
+			params.ReasoningEffort = shared.ReasoningEffort(a.effort)
+		}
+		if a.params.Temperature != nil {
+			params.Temperature = openai.Float(*a.params.Temperature)
+		}
+		if a.params.TopP != nil {
+			params.TopP = openai.Float(*a.params.TopP)
+		}
+		if a.params.MaxTokens > 0 {
+			// max_tokens is deprecated, and reasoning models reject it
+			params.MaxCompletionTokens = openai.Int(a.params.MaxTokens)
+		}
+		if len(a.params.Extra) > 0 {
+			params.SetExtraFields(a.params.Extra)
+		}
 
 		// Add tools if available
 		if a.toolRegistry != nil {
@@ -138,20 +358,41 @@ func (a *Agent) executeConversation(ctx context.Context, messages []openai.ChatC
 		var response *openai.ChatCompletion
 		operation := func() error {
 			var apiErr error
-			response, apiErr = a.client.Chat.Completions.New(ctx, params)
+			start := time.Now()
+			response, apiErr = a.client.Chat.Completions.New(iterCtx, params)
+			metrics.AIRequestDuration.ObserveSince(start)
+			if apiErr != nil {
+				metrics.AIRequests.Inc("error")
+			} else {
+				metrics.AIRequests.Inc("success")
+			}
 			return apiErr
 		}
 
 		exponentialBackoff := a.backoffConfig.ToExponentialBackoff()
 		if err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx)); err != nil {
-			return "", fmt.Errorf("failed to get AI response: %w", err)
+			span.RecordError(err)
+			span.End()
+			return "", "", fmt.Errorf("failed to get AI response: %w", err)
 		}
 
+		reasoningTokens := response.Usage.CompletionTokensDetails.ReasoningTokens
+		recordTokens(response.Usage.PromptTokens, response.Usage.CompletionTokens, reasoningTokens)
+		span.SetAttributes(
+			tracing.Int64("ai.usage.prompt_tokens", response.Usage.PromptTokens),
+			tracing.Int64("ai.usage.completion_tokens", response.Usage.CompletionTokens),
+			tracing.Int64("ai.usage.reasoning_tokens", reasoningTokens),
+		)
+		span.End()
+
 		if len(response.Choices) == 0 {
-			return "", fmt.Errorf("no response from AI model")
+			return "", "", fmt.Errorf("no response from AI model")
 		}
 
 		choice := response.Choices[0]
+		if summary := messageReasoning(choice.Message); summary != "" {
+			reasoning = append(reasoning, summary)
+		}
 
 		// Check if the assistant wants to use tools
 		if len(choice.Message.ToolCalls) > 0 {
@@ -179,15 +420,13 @@ func (a *Agent) executeConversation(ctx context.Context, messages []openai.ChatC
 			messages = append(messages, openai.ChatCompletionMessageParamUnion{OfAssistant: &assistant})
 
 			// Execute tool calls
-			for _, toolCall := range choice.Message.ToolCalls {
-				result, err := a.executeToolCall(ctx, toolCall)
-				if err != nil {
-					slog.Error("Tool call failed", slog.String("tool", toolCall.Function.Name), slog.Any("error", err))
-					result = fmt.Sprintf("Error: %v", err)
-				}
-
+			calls := make([]toolCall, len(choice.Message.ToolCalls))
+			for i, call := range choice.Message.ToolCalls {
+				calls[i] = toolCall{name: call.Function.Name, arguments: call.Function.Arguments}
+			}
+			for i, result := range a.executeToolCalls(ctx, calls) {
 				// Add tool result to conversation
-				messages = append(messages, openai.ToolMessage(result, toolCall.ID))
+				messages = append(messages, openai.ToolMessage(result, choice.Message.ToolCalls[i].ID))
 			}
 
 			// Continue the conversation to get the final response
@@ -195,69 +434,191 @@ func (a *Agent) executeConversation(ctx context.Context, messages []openai.ChatC
 		}
 
 		// No tool calls, return final response
-		return choice.Message.Content, nil
+		return choice.Message.Content, strings.Join(reasoning, "\n\n"), nil
 	}
 
-	return "", fmt.Errorf("conversation exceeded maximum iterations (%d)", maxIterations)
+	return "", "", fmt.Errorf("conversation exceeded maximum iterations (%d)", maxIterations)
 }
 
-// executeToolCall executes a single tool call
-func (a *Agent) executeToolCall(ctx context.Context, toolCall openai.ChatCompletionMessageToolCall) (string, error) {
+// recordTokens counts a response's tokens. Reasoning tokens are counted
+// among the completion tokens too, as they're billed that way.
+func recordTokens(prompt, completion, reasoning int64) {
+	metrics.AITokens.Add(float64(prompt), "prompt")
+	metrics.AITokens.Add(float64(completion), "completion")
+	if reasoning > 0 {
+		metrics.AITokens.Add(float64(reasoning), "reasoning")
+	}
+}
+
+// reasoningFields are where gateways serving reasoning models through the
+// chat completions API put the model's reasoning
+var reasoningFields = []string{"reasoning_content", "reasoning"}
+
+// messageReasoning returns the reasoning a gateway returned alongside the
+// message, if any. OpenAI's own API keeps o-series reasoning hidden.
+func messageReasoning(message openai.ChatCompletionMessage) string {
+	for _, name := range reasoningFields {
+		// Extra fields are never Valid, as the SDK has no type for them
+		field, ok := message.JSON.ExtraFields[name]
+		if !ok || field.Raw() == "" {
+			continue
+		}
+		var text string
+		if err := json.Unmarshal([]byte(field.Raw()), &text); err == nil && strings.TrimSpace(text) != "" {
+			return strings.TrimSpace(text)
+		}
+	}
+	return ""
+}
+
+// maxParallelTools bounds how many of a turn's tool calls run at once
+const maxParallelTools = 4
+
+// toolCall is a tool the model asked for, with its JSON arguments
+type toolCall struct {
+	name      string
+	arguments string
+}
+
+// executeToolCalls runs a turn's tool calls concurrently, at most
+// maxParallelTools at a time, and returns their results in order. A failed
+// call's result is its error, for the model to see.
+func (a *Agent) executeToolCalls(ctx context.Context, calls []toolCall) []string {
+	results := make([]string, len(calls))
+	sem := make(chan struct{}, maxParallelTools)
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			result, err := a.executeToolCall(ctx, call.name, call.arguments)
+			if err != nil {
+				slog.Error("Tool call failed", slog.String("tool", call.name), slog.Any("error", err))
+				result = fmt.Sprintf("Error: %v", err)
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// executeToolCall executes a single tool call with its JSON arguments
+func (a *Agent) executeToolCall(ctx context.Context, name, arguments string) (string, error) {
 	if a.toolRegistry == nil {
 		return "", fmt.Errorf("no tool registry available")
 	}
 
-	tool, exists := a.toolRegistry.Get(toolCall.Function.Name)
+	tool, exists := a.toolRegistry.Get(name)
 	if !exists {
-		return "", fmt.Errorf("unknown tool: %s", toolCall.Function.Name)
+		return "", fmt.Errorf("unknown tool: %s", name)
 	}
 
-	slog.Debug("Executing tool", slog.String("name", toolCall.Function.Name), slog.String("args", toolCall.Function.Arguments))
+	slog.Debug("Executing tool", slog.String("name", name), slog.String("args", arguments))
+
+	ctx, span := tracing.Start(ctx, "ai.tool", tracing.String("ai.tool.name", name))
+	defer span.End()
 
 	// Parse arguments as JSON
 	var args json.RawMessage
-	if err := json.Unmarshal([]byte(toolCall.Function.Arguments), &args); err != nil {
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		span.RecordError(err)
 		return "", fmt.Errorf("invalid tool arguments: %w", err)
 	}
 
+	// The model can ask tools about other repositories, which mustn't
+	// include ones it may not see
+	var target struct{ Owner, Repo string }
+	if json.Unmarshal(args, &target) == nil && target.Owner != "" && !a.RepoAllowed(target.Owner, target.Repo) {
+		err := fmt.Errorf("%w: %s/%s", ErrRepoNotAllowed, target.Owner, target.Repo)
+		span.RecordError(err)
+		return "", err
+	}
+
 	// Create a new context with a configurable timeout for tool execution
-	// This should be longer than the GitHub backoff MaxElapsedTime (60s) to allow retries
-	toolCtx, cancel := context.WithTimeout(context.Background(), a.toolTimeout)
+	// This should be longer than the GitHub backoff MaxElapsedTime (60s) to allow retries.
+	// It is detached from the caller's cancellation but keeps the trace span.
+	config := a.toolRegistry.config(name)
+	toolCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cmp.Or(config.Timeout, a.toolTimeout))
 	defer cancel()
+	toolCtx = withRepoAccess(toolCtx, a.RepoAllowed)
 
 	// Execute the tool with the dedicated context
-	return tool.Execute(toolCtx, args)
+	result, err := tool.Execute(toolCtx, args)
+	span.RecordError(err)
+	result, redacted := redact(result)
+	reportRedactions(span, name, redacted)
+	// Cut after redacting, so no part of a secret is left behind
+	fit := truncator{limit: config.MaxBytes, hint: "Call the tool again with narrower parameters for the omitted parts."}
+	return fit.truncate(result), err
 }
 
-// PRData represents the data about a PR for analysis
+// PRData represents the data about a PR for analysis: what's been loaded
+// from GitHub plus the context speedrun adds around it
 type PRData struct {
-	Title              string
-	Number             int
-	Author             string
-	Labels             []string
-	RequestedReviewers []string
-	Description        string
-	Additions          int
-	Deletions          int
-	ChangedFiles       int
-	CIStatus           string // Deprecated: Use CheckDetails instead
-	CheckDetails       []CheckInfo
-	Reviews            []ReviewInfo
-	HasConflicts       bool
-	PRURL              string
-}
-
-// CheckInfo represents information about a CI check
-type CheckInfo struct {
-	Name        string
-	Status      string // success, failure, pending, error
-	Description string
-}
-
-// ReviewInfo represents information about a review
-type ReviewInfo struct {
-	State string
-	User  string
+	github.Snapshot
+	LinkedIssues       []*tracker.Issue
+	SignaturesRequired bool          // The repo requires signed commits
+	GroupMembers       []GroupMember // Other PRs making the same dependency bump
+	SizeBudget         SizeBudget
+	TemplateSignal     bool // Tell the AI how well the description fills out the PR template
+}
+
+// SizeBudget limits how large a PR may be before it always needs a careful
+// human review
+type SizeBudget struct {
+	MaxLines int  // 0 disables
+	MaxFiles int  // 0 disables
+	Enforce  bool // Oversized PRs are never recommended for approval
+}
+
+// Exceeded describes which limits a PR breaks, or returns "" if it fits
+func (b SizeBudget) Exceeded(lines, files int) string {
+	var over []string
+	if b.MaxLines > 0 && lines > b.MaxLines {
+		over = append(over, fmt.Sprintf("%d lines > %d", lines, b.MaxLines))
+	}
+	if b.MaxFiles > 0 && files > b.MaxFiles {
+		over = append(over, fmt.Sprintf("%d files > %d", files, b.MaxFiles))
+	}
+	return strings.Join(over, ", ")
+}
+
+// LanguageNotes returns the review notes for the PR's languages that have any
+func (p PRData) LanguageNotes() []string {
+	var notes []string
+	for _, language := range p.Languages() {
+		note, err := languagePrompts.ReadFile("prompts/languages/" + strings.ToLower(language) + ".md")
+		if err == nil {
+			notes = append(notes, strings.TrimSpace(string(note)))
+		}
+	}
+	return notes
+}
+
+// SizeBudgetExceeded describes which size limits the PR breaks, if any
+func (p PRData) SizeBudgetExceeded() string {
+	if p.DiffStats == nil {
+		return ""
+	}
+	return p.SizeBudget.Exceeded(p.DiffStats.Additions+p.DiffStats.Deletions, p.DiffStats.Files)
+}
+
+// VerifiedCommits returns how many commits have a verified signature
+func (p PRData) VerifiedCommits() int {
+	if p.Signatures == nil {
+		return 0
+	}
+	return p.Signatures.Commits - p.Signatures.Unverified
+}
+
+// GroupMember represents another PR bumping the same dependency in a
+// different repository
+type GroupMember struct {
+	Repo     string
+	Number   int
+	CIStatus string
 }
 
 func (a *Agent) buildPrompt(pr PRData) (string, error) {
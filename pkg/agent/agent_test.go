@@ -0,0 +1,529 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	backoffconfig "github.com/kennyp/speedrun/pkg/backoff"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/prtemplate"
+)
+
+func TestEnforceSignatures(t *testing.T) {
+	pr := PRData{Snapshot: github.Snapshot{
+		PR:         &github.PullRequest{Number: 7},
+		Signatures: &github.CommitSignatures{Commits: 3, Unverified: 1},
+	}, SignaturesRequired: true}
+
+	analysis := &Analysis{Recommendation: Approve, Reasoning: "Small fix."}
+	enforceSignatures(analysis, pr)
+	if analysis.Recommendation != Review {
+		t.Errorf("recommendation = %s, want %s", analysis.Recommendation, Review)
+	}
+	if !strings.HasPrefix(analysis.Reasoning, "1 of 3 commits are not signed") {
+		t.Errorf("reasoning = %q", analysis.Reasoning)
+	}
+
+	pr.SignaturesRequired = false
+	analysis = &Analysis{Recommendation: Approve}
+	enforceSignatures(analysis, pr)
+	if analysis.Recommendation != Approve {
+		t.Errorf("downgraded approval in a repo without the requirement")
+	}
+}
+
+func TestBuildPromptIncludesSigning(t *testing.T) {
+	a := &Agent{}
+	prompt, err := a.buildPrompt(PRData{Snapshot: github.Snapshot{
+		PR:         &github.PullRequest{Title: "Fix", Number: 1},
+		Signatures: &github.CommitSignatures{Commits: 2, Unverified: 1, NoSignoff: 1},
+	}, SignaturesRequired: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"Verified signatures: 1/2", "DCO: failed", "requires signed commits"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
+
+func TestBuildPromptIncludesGroup(t *testing.T) {
+	a := &Agent{}
+	prompt, err := a.buildPrompt(PRData{Snapshot: github.Snapshot{PR: &github.PullRequest{Title: "Bump lodash from 4.17.19 to 4.17.21", Number: 1}},
+		GroupMembers: []GroupMember{{Repo: "acme/web", Number: 12, CIStatus: "success"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"Dependency Update Group", "acme/web#12 (CI: success)"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
+
+func TestEnforceSizeBudget(t *testing.T) {
+	pr := PRData{Snapshot: github.Snapshot{
+		PR:        &github.PullRequest{Number: 3},
+		DiffStats: &github.DiffStats{Additions: 1800, Deletions: 400, Files: 12},
+	}, SizeBudget: SizeBudget{MaxLines: 2000, Enforce: true}}
+
+	analysis := &Analysis{Recommendation: Approve, Reasoning: "Mechanical rename."}
+	enforceSizeBudget(analysis, pr)
+	if analysis.Recommendation != Review {
+		t.Errorf("recommendation = %s, want %s", analysis.Recommendation, Review)
+	}
+	if !strings.HasPrefix(analysis.Reasoning, "PR is over the size budget (2200 lines > 2000)") {
+		t.Errorf("reasoning = %q", analysis.Reasoning)
+	}
+
+	pr.SizeBudget.Enforce = false
+	analysis = &Analysis{Recommendation: Approve}
+	enforceSizeBudget(analysis, pr)
+	if analysis.Recommendation != Approve {
+		t.Errorf("downgraded approval without enforcement")
+	}
+}
+
+func TestSizeBudgetExceeded(t *testing.T) {
+	budget := SizeBudget{MaxLines: 2000, MaxFiles: 50}
+	if got := budget.Exceeded(100, 3); got != "" {
+		t.Errorf("small PR exceeded budget: %q", got)
+	}
+	if got := budget.Exceeded(2500, 60); got != "2500 lines > 2000, 60 files > 50" {
+		t.Errorf("Exceeded() = %q", got)
+	}
+	if got := (SizeBudget{}).Exceeded(100000, 1000); got != "" {
+		t.Errorf("disabled budget exceeded: %q", got)
+	}
+}
+
+func TestBuildPromptIncludesSizeBudget(t *testing.T) {
+	a := &Agent{}
+	prompt, err := a.buildPrompt(PRData{Snapshot: github.Snapshot{
+		PR:        &github.PullRequest{Title: "Big refactor", Number: 1},
+		DiffStats: &github.DiffStats{Additions: 2400, Files: 10},
+	}, SizeBudget: SizeBudget{MaxLines: 2000, Enforce: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"Over the team's size budget (2400 lines > 2000)", "REVIEW at minimum"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
+
+func TestBuildPromptIncludesTemplate(t *testing.T) {
+	a := &Agent{}
+	pr := PRData{Snapshot: github.Snapshot{
+		PR:       &github.PullRequest{Title: "Add retries", Number: 3},
+		Template: &prtemplate.Compliance{Sections: 2, Empty: []string{"Testing done"}, Checklist: 1},
+	}}
+
+	prompt, err := a.buildPrompt(pr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(prompt, "PR Template") {
+		t.Error("prompt mentions the PR template without the signal enabled")
+	}
+
+	pr.TemplateSignal = true
+	if prompt, err = a.buildPrompt(pr); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(prompt, "Section left empty: Testing done") {
+		t.Error("prompt missing the empty template section")
+	}
+}
+
+func TestBuildPromptIncludesLanguageNotes(t *testing.T) {
+	a := &Agent{}
+	prompt, err := a.buildPrompt(PRData{Snapshot: github.Snapshot{
+		PR:           &github.PullRequest{Title: "Add retries", Number: 2},
+		ChangedFiles: []string{"retry.go", "retry_test.go", "Makefile"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"Languages: Go, Makefile", "**Go:**", "context.Background()"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+	if strings.Contains(prompt, "**Makefile:**") {
+		t.Error("prompt has notes for a language without any")
+	}
+}
+
+func TestAzureEndpoint(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "sk-not-for-azure")
+
+	var got *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":     "chatcmpl-1",
+			"object": "chat.completion",
+			"model":  "gpt-4o",
+			"choices": []any{map[string]any{
+				"index":         0,
+				"finish_reason": "stop",
+				"message":       map[string]any{"role": "assistant", "content": "RECOMMENDATION: APPROVE\nRISK_LEVEL: LOW\nPR_TYPE: CODE\nREASONING: Small fix.\n"},
+			}},
+		})
+	}))
+	defer server.Close()
+
+	endpoint := Endpoint{Provider: ProviderAzure, BaseURL: server.URL + "/", APIKey: "azure-key", Deployment: "review-gpt", APIVersion: "2024-10-21"}
+	a := NewAgent(endpoint, "gpt-4o", backoffconfig.Config{MaxElapsedTime: time.Second}, nil, time.Second, 0, nil)
+	analysis, err := a.AnalyzePR(context.Background(), PRData{Snapshot: github.Snapshot{PR: &github.PullRequest{Owner: "acme", Repo: "api", Number: 1}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.Recommendation != Approve {
+		t.Errorf("Recommendation = %s, want %s", analysis.Recommendation, Approve)
+	}
+
+	if got.URL.Path != "/openai/deployments/review-gpt/chat/completions" {
+		t.Errorf("path = %s", got.URL.Path)
+	}
+	if v := got.URL.Query().Get("api-version"); v != "2024-10-21" {
+		t.Errorf("api-version = %q", v)
+	}
+	if key := got.Header.Get("api-key"); key != "azure-key" {
+		t.Errorf("api-key = %q", key)
+	}
+	if auth := got.Header.Get("Authorization"); auth != "" {
+		t.Errorf("Authorization = %q, want none", auth)
+	}
+}
+
+// echoTool answers with the arguments it was called with
+type echoTool struct{}
+
+func (echoTool) Name() string        { return "echo" }
+func (echoTool) Description() string { return "Echo the arguments" }
+func (echoTool) Parameters() json.RawMessage {
+	return json.RawMessage(`{"type":"object","properties":{"text":{"type":"string"}}}`)
+}
+func (echoTool) Execute(ctx context.Context, params json.RawMessage) (string, error) {
+	return "echo " + string(params), nil
+}
+
+func TestGeminiFunctionCalling(t *testing.T) {
+	var requests []geminiRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/models/gemini-2.5-flash:generateContent" || r.Header.Get("X-Goog-Api-Key") != "gemini-key" {
+			t.Errorf("request to %s with key %q", r.URL.Path, r.Header.Get("X-Goog-Api-Key"))
+		}
+		var req geminiRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		requests = append(requests, req)
+
+		w.Header().Set("Content-Type", "application/json")
+		if len(requests) == 1 {
+			_, _ = w.Write([]byte(`{"candidates": [{"content": {"role": "model", "parts": [{"functionCall": {"name": "echo", "args": {"text": "hi"}}, "thoughtSignature": "sig"}]}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"candidates": [{"content": {"role": "model", "parts": [{"text": "Worth echoing.", "thought": true}, {"text": "RECOMMENDATION: APPROVE\nRISK_LEVEL: LOW\nPR_TYPE: CODE\nREASONING: Echoed.\n"}]}}]}`))
+	}))
+	defer server.Close()
+
+	tools := &ToolRegistry{tools: map[string]Tool{}}
+	tools.Register(echoTool{})
+	endpoint := Endpoint{Provider: ProviderGemini, BaseURL: server.URL, APIKey: "gemini-key"}
+	a := NewAgent(endpoint, "gemini-2.5-flash", backoffconfig.Config{MaxElapsedTime: time.Second}, tools, time.Second, 0, nil)
+	a.SetReasoningEffort(EffortLow)
+
+	analysis, err := a.AnalyzePR(context.Background(), PRData{Snapshot: github.Snapshot{PR: &github.PullRequest{Owner: "acme", Repo: "api", Number: 1}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.Recommendation != Approve || analysis.Reasoning != "Echoed." || analysis.ReasoningSummary != "Worth echoing." {
+		t.Errorf("analysis = %+v", analysis)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("requests = %d, want 2", len(requests))
+	}
+	first := requests[0]
+	if first.SystemInstruction == nil || len(first.Tools) != 1 || first.Tools[0].FunctionDeclarations[0].Parameters["type"] != "OBJECT" {
+		t.Errorf("first request = %+v", first)
+	}
+	if first.GenerationConfig == nil || first.GenerationConfig.ThinkingConfig.ThinkingBudget != 1024 {
+		t.Errorf("first request generation config = %+v", first.GenerationConfig)
+	}
+	second := requests[1].Contents
+	if len(second) != 3 || second[1].Role != "model" || second[1].Parts[0].ThoughtSignature != "sig" {
+		t.Fatalf("second request contents = %+v", second)
+	}
+	response := second[2].Parts[0].FunctionResponse
+	if response == nil || response.Name != "echo" || response.Response["result"] != `echo {"text": "hi"}` {
+		t.Errorf("function response = %+v", response)
+	}
+}
+
+func TestReasoningEffort(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":     "chatcmpl-1",
+			"object": "chat.completion",
+			"model":  "o4-mini",
+			"choices": []any{map[string]any{
+				"index":         0,
+				"finish_reason": "stop",
+				"message": map[string]any{
+					"role":              "assistant",
+					"content":           "RECOMMENDATION: APPROVE\nRISK_LEVEL: LOW\nPR_TYPE: CODE\nREASONING: Small fix.\n",
+					"reasoning_content": " Checked the diff for behaviour changes. ",
+				},
+			}},
+			"usage": map[string]any{
+				"prompt_tokens":             100,
+				"completion_tokens":         60,
+				"total_tokens":              160,
+				"completion_tokens_details": map[string]any{"reasoning_tokens": 40},
+			},
+		})
+	}))
+	defer server.Close()
+
+	endpoint := Endpoint{Provider: ProviderOpenAI, BaseURL: server.URL, APIKey: "sk-test"}
+	a := NewAgent(endpoint, "o4-mini", backoffconfig.Config{MaxElapsedTime: time.Second}, nil, time.Second, 0, nil)
+	a.SetReasoningEffort(EffortHigh)
+	analysis, err := a.AnalyzePR(context.Background(), PRData{Snapshot: github.Snapshot{PR: &github.PullRequest{Owner: "acme", Repo: "api", Number: 1}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got["reasoning_effort"] != "high" {
+		t.Errorf("reasoning_effort = %v, want high", got["reasoning_effort"])
+	}
+	if analysis.ReasoningSummary != "Checked the diff for behaviour changes." {
+		t.Errorf("ReasoningSummary = %q", analysis.ReasoningSummary)
+	}
+
+	p := analysis.Provenance
+	if p == nil {
+		t.Fatal("Provenance not recorded")
+	}
+	if p.Provider != ProviderOpenAI || p.Model != "o4-mini" || p.ReasoningEffort != EffortHigh {
+		t.Errorf("Provenance = %+v", p)
+	}
+	if p.PromptVersion != PromptVersion() || len(p.PromptVersion) != 12 {
+		t.Errorf("PromptVersion = %q", p.PromptVersion)
+	}
+	if p.Speedrun == "" || time.Since(p.AnalyzedAt) > time.Minute {
+		t.Errorf("Provenance = %+v", p)
+	}
+}
+
+func TestRequestParams(t *testing.T) {
+	var body map[string]any
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":     "chatcmpl-1",
+			"object": "chat.completion",
+			"model":  "gpt-4o",
+			"choices": []any{map[string]any{
+				"index":         0,
+				"finish_reason": "stop",
+				"message":       map[string]any{"role": "assistant", "content": "RECOMMENDATION: APPROVE\nRISK_LEVEL: LOW\nPR_TYPE: CODE\nREASONING: Small fix.\n"},
+			}},
+		})
+	}))
+	defer server.Close()
+
+	temperature, topP := 0.0, 0.9
+	endpoint := Endpoint{BaseURL: server.URL, APIKey: "sk-test", Headers: map[string]string{"X-Gateway-Route": "review"}}
+	a := NewAgent(endpoint, "gpt-4o", backoffconfig.Config{MaxElapsedTime: time.Second}, nil, time.Second, 0, nil)
+	a.SetParams(Params{Temperature: &temperature, TopP: &topP, MaxTokens: 2000, Extra: map[string]any{"route": "review", "seed": 42.0}})
+	if _, err := a.AnalyzePR(context.Background(), PRData{Snapshot: github.Snapshot{PR: &github.PullRequest{Owner: "acme", Repo: "api", Number: 1}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]any{"temperature": 0.0, "top_p": 0.9, "max_completion_tokens": 2000.0, "route": "review", "seed": 42.0}
+	for name, value := range want {
+		if got, ok := body[name]; !ok || got != value {
+			t.Errorf("%s = %v, want %v", name, got, value)
+		}
+	}
+	if route := header.Get("X-Gateway-Route"); route != "review" {
+		t.Errorf("X-Gateway-Route = %q", route)
+	}
+}
+
+func TestGeminiRequestParams(t *testing.T) {
+	var body map[string]any
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"candidates": [{"content": {"role": "model", "parts": [{"text": "RECOMMENDATION: APPROVE\nRISK_LEVEL: LOW\nPR_TYPE: CODE\nREASONING: Fine.\n"}]}}]}`))
+	}))
+	defer server.Close()
+
+	temperature := 0.2
+	endpoint := Endpoint{Provider: ProviderGemini, BaseURL: server.URL, APIKey: "gemini-key", Headers: map[string]string{"X-Gateway-Route": "review"}}
+	a := NewAgent(endpoint, "gemini-2.5-flash", backoffconfig.Config{MaxElapsedTime: time.Second}, nil, time.Second, 0, nil)
+	a.SetParams(Params{Temperature: &temperature, MaxTokens: 2000, Extra: map[string]any{"labels": map[string]any{"team": "platform"}}})
+	if _, err := a.AnalyzePR(context.Background(), PRData{Snapshot: github.Snapshot{PR: &github.PullRequest{Owner: "acme", Repo: "api", Number: 1}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	config, _ := body["generationConfig"].(map[string]any)
+	if config["temperature"] != 0.2 || config["maxOutputTokens"] != 2000.0 || config["topP"] != nil {
+		t.Errorf("generationConfig = %v", config)
+	}
+	if labels, _ := body["labels"].(map[string]any); labels["team"] != "platform" {
+		t.Errorf("labels = %v", body["labels"])
+	}
+	if route := header.Get("X-Gateway-Route"); route != "review" {
+		t.Errorf("X-Gateway-Route = %q", route)
+	}
+}
+
+func TestDeniedRepoNotSent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request sent to the model: %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	a := NewAgent(Endpoint{BaseURL: server.URL, APIKey: "sk-test"}, "gpt-4o", backoffconfig.Config{MaxElapsedTime: time.Second}, nil, time.Second, 0, nil)
+	a.SetRepoAccess([]string{"acme"}, []string{"acme/secrets"})
+
+	for _, pr := range []PRData{
+		{Snapshot: github.Snapshot{PR: &github.PullRequest{Owner: "acme", Repo: "secrets", Number: 1}}},
+		{Snapshot: github.Snapshot{PR: &github.PullRequest{Owner: "other", Repo: "api", Number: 2}}},
+		{
+			Snapshot:     github.Snapshot{PR: &github.PullRequest{Owner: "acme", Repo: "api", Number: 3}},
+			GroupMembers: []GroupMember{{Repo: "acme/secrets", Number: 4}},
+		},
+	} {
+		if _, err := a.AnalyzePR(context.Background(), pr); !errors.Is(err, ErrRepoNotAllowed) {
+			t.Errorf("AnalyzePR(%s/%s#%d) error = %v, want ErrRepoNotAllowed", pr.PR.Owner, pr.PR.Repo, pr.PR.Number, err)
+		}
+	}
+}
+
+func TestToolRefusesDeniedRepo(t *testing.T) {
+	tools := &ToolRegistry{tools: map[string]Tool{}}
+	tools.Register(echoTool{})
+	a := NewAgent(Endpoint{APIKey: "sk-test"}, "gpt-4o", backoffconfig.Config{}, tools, time.Second, 0, nil)
+	a.SetRepoAccess(nil, []string{"acme/secrets"})
+
+	if _, err := a.executeToolCall(context.Background(), "echo", `{"owner": "acme", "repo": "secrets"}`); !errors.Is(err, ErrRepoNotAllowed) {
+		t.Errorf("tool call on a denied repo error = %v, want ErrRepoNotAllowed", err)
+	}
+	if _, err := a.executeToolCall(context.Background(), "echo", `{"owner": "acme", "repo": "api"}`); err != nil {
+		t.Errorf("tool call on an allowed repo failed: %v", err)
+	}
+}
+
+// slowTool takes a while to answer and counts how many calls run at once
+type slowTool struct {
+	mu            sync.Mutex
+	running, peak int
+}
+
+func (*slowTool) Name() string                { return "slow" }
+func (*slowTool) Description() string         { return "Answer slowly" }
+func (*slowTool) Parameters() json.RawMessage { return json.RawMessage(`{"type":"object"}`) }
+func (t *slowTool) Execute(ctx context.Context, params json.RawMessage) (string, error) {
+	t.mu.Lock()
+	t.running++
+	t.peak = max(t.peak, t.running)
+	t.mu.Unlock()
+
+	defer func() {
+		t.mu.Lock()
+		t.running--
+		t.mu.Unlock()
+	}()
+
+	select {
+	case <-time.After(50 * time.Millisecond):
+		return "slow " + string(params), nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func TestParallelToolCalls(t *testing.T) {
+	slow := &slowTool{}
+	tools := &ToolRegistry{tools: map[string]Tool{}}
+	tools.Register(slow)
+	a := NewAgent(Endpoint{APIKey: "sk-test"}, "gpt-4o", backoffconfig.Config{}, tools, time.Second, 0, nil)
+
+	calls := make([]toolCall, 2*maxParallelTools)
+	for i := range calls {
+		calls[i] = toolCall{name: "slow", arguments: fmt.Sprintf(`{"n": %d}`, i)}
+	}
+	calls = append(calls, toolCall{name: "missing", arguments: "{}"})
+
+	results := a.executeToolCalls(context.Background(), calls)
+	for i, result := range results[:len(results)-1] {
+		if want := fmt.Sprintf(`slow {"n": %d}`, i); result != want {
+			t.Errorf("result %d = %q, want %q", i, result, want)
+		}
+	}
+	if last := results[len(results)-1]; last != "Error: unknown tool: missing" {
+		t.Errorf("failed call's result = %q", last)
+	}
+	if slow.peak != maxParallelTools {
+		t.Errorf("%d calls ran at once, want %d", slow.peak, maxParallelTools)
+	}
+}
+
+func TestToolConfig(t *testing.T) {
+	tools := &ToolRegistry{tools: map[string]Tool{}}
+	tools.Register(echoTool{})
+	tools.Register(&slowTool{})
+	a := NewAgent(Endpoint{APIKey: "sk-test"}, "gpt-4o", backoffconfig.Config{}, tools, time.Second, 0, nil)
+
+	tools.Configure("echo", ToolConfig{MaxBytes: 10})
+	if result, err := a.executeToolCall(context.Background(), "echo", `{"text": "a long answer"}`); err != nil || !strings.HasPrefix(result, "echo {\"te\n") || !strings.Contains(result, "line 1 cut short") {
+		t.Errorf("echo = %q, %v; want it cut at 10 bytes", result, err)
+	}
+
+	tools.Configure("slow", ToolConfig{Timeout: 10 * time.Millisecond})
+	if _, err := a.executeToolCall(context.Background(), "slow", `{}`); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("slow error = %v, want its own timeout", err)
+	}
+
+	tools.Disable("slow")
+	if _, ok := tools.Get("slow"); ok || len(tools.GetOpenAITools()) != 1 {
+		t.Error("disabled tool still offered to the model")
+	}
+}
@@ -0,0 +1,99 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the AI gateway while the circuit
+// breaker is open
+var ErrCircuitOpen = errors.New("AI analysis paused after repeated failures")
+
+// CircuitBreaker stops calling the AI gateway after repeated failures so each
+// PR doesn't have to wait out the full backoff and timeout. After the cooldown
+// a single trial request is let through; success closes the circuit, failure
+// opens it for another cooldown.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool // A half-open trial request is in flight
+}
+
+// NewCircuitBreaker opens after threshold consecutive failures and stays open
+// for cooldown. A threshold of zero or less disables the breaker.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// Allow reports whether a request may be made now
+func (b *CircuitBreaker) Allow() bool {
+	if b == nil || b.threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if time.Now().Before(b.openUntil) || b.trial {
+		return false
+	}
+
+	// Half-open: let one request through to test the gateway
+	b.trial = true
+	slog.Info("AI circuit half-open, sending trial request")
+	return true
+}
+
+// Done records the outcome of a request admitted by Allow. Cancellation by
+// the caller says nothing about the gateway and only releases a trial slot.
+func (b *CircuitBreaker) Done(err error) {
+	if b == nil || b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case err == nil:
+		if b.failures >= b.threshold {
+			slog.Info("AI circuit closed")
+		}
+		b.failures = 0
+	case errors.Is(err, context.Canceled):
+		// The caller gave up; that says nothing about the gateway
+	default:
+		b.failures++
+		if b.failures >= b.threshold {
+			b.openUntil = time.Now().Add(b.cooldown)
+			slog.Warn("AI circuit open", slog.Int("failures", b.failures), slog.Time("until", b.openUntil), slog.Any("error", err))
+		}
+	}
+	b.trial = false
+}
+
+// OpenUntil returns when the circuit will next allow a trial request, or the
+// zero time if the circuit is closed
+func (b *CircuitBreaker) OpenUntil() time.Time {
+	if b == nil || b.threshold <= 0 {
+		return time.Time{}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return time.Time{}
+	}
+	return b.openUntil
+}
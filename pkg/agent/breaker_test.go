@@ -0,0 +1,71 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	b := NewCircuitBreaker(2, 10*time.Millisecond)
+	boom := errors.New("gateway timeout")
+
+	for range 2 {
+		if !b.Allow() {
+			t.Fatal("closed circuit rejected a request")
+		}
+		b.Done(boom)
+	}
+	if b.Allow() {
+		t.Fatal("open circuit allowed a request")
+	}
+	if b.OpenUntil().IsZero() {
+		t.Error("OpenUntil is zero while open")
+	}
+
+	time.Sleep(15 * time.Millisecond)
+	if !b.Allow() {
+		t.Fatal("half-open circuit rejected the trial request")
+	}
+	if b.Allow() {
+		t.Error("second request allowed while trial in flight")
+	}
+
+	b.Done(nil)
+	if !b.Allow() || !b.OpenUntil().IsZero() {
+		t.Error("circuit did not close after a successful trial")
+	}
+}
+
+func TestCircuitBreakerFailedTrialReopens(t *testing.T) {
+	b := NewCircuitBreaker(1, 10*time.Millisecond)
+	b.Done(errors.New("boom"))
+
+	time.Sleep(15 * time.Millisecond)
+	if !b.Allow() {
+		t.Fatal("trial rejected")
+	}
+	b.Done(errors.New("still down"))
+	if b.Allow() {
+		t.Error("circuit allowed a request after a failed trial")
+	}
+}
+
+func TestCircuitBreakerIgnoresCancellation(t *testing.T) {
+	b := NewCircuitBreaker(1, time.Hour)
+	b.Done(context.Canceled)
+	if !b.Allow() {
+		t.Error("cancellation opened the circuit")
+	}
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	var nilBreaker *CircuitBreaker
+	for _, b := range []*CircuitBreaker{nilBreaker, NewCircuitBreaker(0, time.Minute)} {
+		b.Done(errors.New("boom"))
+		if !b.Allow() {
+			t.Error("disabled breaker rejected a request")
+		}
+	}
+}
@@ -0,0 +1,390 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/kennyp/speedrun/pkg/adc"
+	"github.com/kennyp/speedrun/pkg/metrics"
+	"github.com/kennyp/speedrun/pkg/tracing"
+)
+
+// geminiBaseURL is the Gemini API. Vertex AI's publisher models path
+// (.../projects/<p>/locations/<l>/publishers/google) works as a base URL too.
+const geminiBaseURL = "https://generativelanguage.googleapis.com/v1beta"
+
+// geminiScopes are what application default credentials are asked for
+var geminiScopes = []string{
+	"https://www.googleapis.com/auth/cloud-platform",
+	"https://www.googleapis.com/auth/generative-language",
+}
+
+// geminiThinkingBudgets are the tokens Gemini may think for at each
+// reasoning effort
+var geminiThinkingBudgets = map[string]int{
+	EffortLow:    1024,
+	EffortMedium: 8192,
+	EffortHigh:   24576,
+}
+
+// geminiClient calls Gemini's generateContent, authenticating with an API
+// key or application default credentials
+type geminiClient struct {
+	baseURL string
+	apiKey  string
+	adc     *adc.TokenSource // Nil with an API key
+	headers map[string]string
+	http    *http.Client
+}
+
+func newGeminiClient(endpoint Endpoint, httpClient *http.Client) *geminiClient {
+	c := &geminiClient{
+		baseURL: strings.TrimSuffix(endpoint.BaseURL, "/"),
+		apiKey:  endpoint.APIKey,
+		headers: endpoint.Headers,
+		http:    httpClient,
+	}
+	if c.baseURL == "" {
+		c.baseURL = geminiBaseURL
+	}
+	if c.apiKey == "" {
+		c.adc = adc.New(endpoint.Transport, geminiScopes...)
+	}
+	return c
+}
+
+// geminiContent is one turn of a Gemini conversation
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"` // user or model
+	Parts []geminiPart `json:"parts"`
+}
+
+// geminiPart is text, a function call the model makes, or a function's
+// response. Model parts are sent back as received, so fields Gemini adds,
+// like thought signatures, survive the round trip.
+type geminiPart struct {
+	Text             string                  `json:"text,omitempty"`
+	FunctionCall     *geminiFunctionCall     `json:"functionCall,omitempty"`
+	FunctionResponse *geminiFunctionResponse `json:"functionResponse,omitempty"`
+	ThoughtSignature string                  `json:"thoughtSignature,omitempty"`
+	Thought          bool                    `json:"thought,omitempty"`
+}
+
+type geminiFunctionCall struct {
+	ID   string          `json:"id,omitempty"`
+	Name string          `json:"name"`
+	Args json.RawMessage `json:"args,omitempty"`
+}
+
+type geminiFunctionResponse struct {
+	ID       string         `json:"id,omitempty"`
+	Name     string         `json:"name"`
+	Response map[string]any `json:"response"`
+}
+
+type geminiFunctionDeclaration struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+}
+
+type geminiRequest struct {
+	SystemInstruction *geminiContent  `json:"systemInstruction,omitempty"`
+	Contents          []geminiContent `json:"contents"`
+	Tools             []geminiTools   `json:"tools,omitempty"`
+
+	GenerationConfig *geminiGenerationConfig `json:"generationConfig,omitempty"`
+
+	extra map[string]any // Params.Extra, merged into the request body
+}
+
+type geminiGenerationConfig struct {
+	Temperature     *float64              `json:"temperature,omitempty"`
+	TopP            *float64              `json:"topP,omitempty"`
+	MaxOutputTokens int64                 `json:"maxOutputTokens,omitempty"`
+	ThinkingConfig  *geminiThinkingConfig `json:"thinkingConfig,omitempty"`
+}
+
+// geminiThinkingConfig sets how long a thinking model thinks, and asks for
+// summaries of its thoughts
+type geminiThinkingConfig struct {
+	IncludeThoughts bool `json:"includeThoughts"`
+	ThinkingBudget  int  `json:"thinkingBudget,omitempty"`
+}
+
+type geminiTools struct {
+	FunctionDeclarations []geminiFunctionDeclaration `json:"functionDeclarations"`
+}
+
+type geminiResponse struct {
+	Candidates []struct {
+		Content      geminiContent `json:"content"`
+		FinishReason string        `json:"finishReason"`
+	} `json:"candidates"`
+	UsageMetadata struct {
+		PromptTokenCount     int64 `json:"promptTokenCount"`
+		CandidatesTokenCount int64 `json:"candidatesTokenCount"`
+		ThoughtsTokenCount   int64 `json:"thoughtsTokenCount"`
+	} `json:"usageMetadata"`
+	PromptFeedback struct {
+		BlockReason string `json:"blockReason"`
+	} `json:"promptFeedback"`
+}
+
+// geminiError is how the API describes a failed request
+type geminiError struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	} `json:"error"`
+}
+
+// generate sends one generateContent request
+func (c *geminiClient) generate(ctx context.Context, model string, req geminiRequest) (*geminiResponse, error) {
+	data, err := json.Marshal(req)
+	if err == nil && len(req.extra) > 0 {
+		var body map[string]any
+		if err = json.Unmarshal(data, &body); err == nil {
+			maps.Copy(body, req.extra)
+			data, err = json.Marshal(body)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Gemini request: %w", err)
+	}
+	u := fmt.Sprintf("%s/models/%s:generateContent", c.baseURL, url.PathEscape(model))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for name, value := range c.headers {
+		httpReq.Header.Set(name, value)
+	}
+	if c.adc != nil {
+		token, err := c.adc.Token(ctx)
+		if err != nil {
+			return nil, backoff.Permanent(err)
+		}
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+		if project := c.adc.QuotaProject(); project != "" {
+			httpReq.Header.Set("X-Goog-User-Project", project)
+		}
+	} else {
+		httpReq.Header.Set("X-Goog-Api-Key", c.apiKey)
+	}
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Gemini response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr geminiError
+		message := resp.Status
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Error.Message != "" {
+			message = fmt.Sprintf("%s: %s", resp.Status, apiErr.Error.Message)
+		}
+		err := fmt.Errorf("gemini request failed: %s", message)
+		// Only rate limits and server errors are worth retrying
+		if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+			return nil, backoff.Permanent(err)
+		}
+		return nil, err
+	}
+
+	var out geminiResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, fmt.Errorf("failed to parse Gemini response: %w", err)
+	}
+	return &out, nil
+}
+
+// geminiTools declares the registered tools as Gemini functions
+func (r *ToolRegistry) geminiTools() []geminiTools {
+	if r == nil || len(r.tools) == 0 {
+		return nil
+	}
+	var declarations []geminiFunctionDeclaration
+	for _, tool := range r.tools {
+		var params map[string]any
+		if err := json.Unmarshal(tool.Parameters(), &params); err != nil {
+			slog.Error("Failed to unmarshal tool parameters", slog.String("tool", tool.Name()), slog.Any("error", err))
+		}
+		declarations = append(declarations, geminiFunctionDeclaration{
+			Name:        tool.Name(),
+			Description: tool.Description(),
+			Parameters:  geminiSchema(params),
+		})
+	}
+	return []geminiTools{{FunctionDeclarations: declarations}}
+}
+
+// geminiSchema converts a tool's JSON schema to Gemini's OpenAPI subset,
+// which names types in upper case
+func geminiSchema(schema map[string]any) map[string]any {
+	if schema == nil {
+		return nil
+	}
+	out := make(map[string]any, len(schema))
+	for key, value := range schema {
+		switch key {
+		case "type":
+			if t, ok := value.(string); ok {
+				value = strings.ToUpper(t)
+			}
+		case "properties":
+			if props, ok := value.(map[string]any); ok {
+				converted := make(map[string]any, len(props))
+				for name, prop := range props {
+					if p, ok := prop.(map[string]any); ok {
+						converted[name] = geminiSchema(p)
+					}
+				}
+				value = converted
+			}
+		case "items":
+			if items, ok := value.(map[string]any); ok {
+				value = geminiSchema(items)
+			}
+		case "additionalProperties", "$schema":
+			continue
+		}
+		out[key] = value
+	}
+	return out
+}
+
+// executeGeminiConversation runs the conversation loop on Gemini: function
+// calls are run as tool calls and their results sent back as function
+// responses until the model answers with text. With a reasoning effort set,
+// the model's thought summaries are returned too.
+func (a *Agent) executeGeminiConversation(ctx context.Context, prompt string) (string, string, error) {
+	const maxIterations = 10 // Prevent infinite loops
+	var reasoning []string
+
+	req := geminiRequest{
+		SystemInstruction: &geminiContent{Parts: []geminiPart{{Text: DeveloperMessage}}},
+		Contents:          []geminiContent{{Role: "user", Parts: []geminiPart{{Text: prompt}}}},
+		Tools:             a.toolRegistry.geminiTools(),
+		extra:             a.params.Extra,
+	}
+	config := geminiGenerationConfig{
+		Temperature:     a.params.Temperature,
+		TopP:            a.params.TopP,
+		MaxOutputTokens: a.params.MaxTokens,
+	}
+	if budget, ok := geminiThinkingBudgets[a.effort]; ok {
+		config.ThinkingConfig = &geminiThinkingConfig{IncludeThoughts: true, ThinkingBudget: budget}
+	}
+	if config != (geminiGenerationConfig{}) {
+		req.GenerationConfig = &config
+	}
+
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		slog.Debug("Executing Gemini conversation iteration", slog.Int("iteration", iteration))
+
+		iterCtx, span := tracing.Start(ctx, "ai.chat_completion", tracing.Int("ai.iteration", iteration))
+
+		var response *geminiResponse
+		operation := func() error {
+			var apiErr error
+			start := time.Now()
+			response, apiErr = a.gemini.generate(iterCtx, a.model, req)
+			metrics.AIRequestDuration.ObserveSince(start)
+			if apiErr != nil {
+				metrics.AIRequests.Inc("error")
+			} else {
+				metrics.AIRequests.Inc("success")
+			}
+			return apiErr
+		}
+
+		exponentialBackoff := a.backoffConfig.ToExponentialBackoff()
+		if err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx)); err != nil {
+			span.RecordError(err)
+			span.End()
+			return "", "", fmt.Errorf("failed to get AI response: %w", err)
+		}
+
+		// Gemini counts thoughts apart from the answer; both are billed as output
+		usage := response.UsageMetadata
+		completion := usage.CandidatesTokenCount + usage.ThoughtsTokenCount
+		recordTokens(usage.PromptTokenCount, completion, usage.ThoughtsTokenCount)
+		span.SetAttributes(
+			tracing.Int64("ai.usage.prompt_tokens", usage.PromptTokenCount),
+			tracing.Int64("ai.usage.completion_tokens", completion),
+			tracing.Int64("ai.usage.reasoning_tokens", usage.ThoughtsTokenCount),
+		)
+		span.End()
+
+		if len(response.Candidates) == 0 {
+			if reason := response.PromptFeedback.BlockReason; reason != "" {
+				return "", "", fmt.Errorf("gemini blocked the prompt: %s", reason)
+			}
+			return "", "", fmt.Errorf("no response from AI model")
+		}
+
+		content := response.Candidates[0].Content
+		var calls []geminiFunctionCall
+		var text strings.Builder
+		for _, part := range content.Parts {
+			switch {
+			case part.FunctionCall != nil:
+				calls = append(calls, *part.FunctionCall)
+			case part.Thought:
+				if thought := strings.TrimSpace(part.Text); thought != "" {
+					reasoning = append(reasoning, thought)
+				}
+			default:
+				text.WriteString(part.Text)
+			}
+		}
+
+		// No function calls, return final response
+		if len(calls) == 0 {
+			return text.String(), strings.Join(reasoning, "\n\n"), nil
+		}
+
+		slog.Debug("Processing function calls", slog.Int("count", len(calls)))
+		content.Role = "model"
+		req.Contents = append(req.Contents, content)
+
+		toolCalls := make([]toolCall, len(calls))
+		for i, call := range calls {
+			args := string(call.Args)
+			if args == "" {
+				args = "{}"
+			}
+			toolCalls[i] = toolCall{name: call.Name, arguments: args}
+		}
+		results := geminiContent{Role: "user"}
+		for i, result := range a.executeToolCalls(ctx, toolCalls) {
+			call := calls[i]
+			results.Parts = append(results.Parts, geminiPart{FunctionResponse: &geminiFunctionResponse{
+				ID:       call.ID,
+				Name:     call.Name,
+				Response: map[string]any{"result": result},
+			}})
+		}
+		req.Contents = append(req.Contents, results)
+	}
+
+	return "", "", fmt.Errorf("conversation exceeded maximum iterations (%d)", maxIterations)
+}
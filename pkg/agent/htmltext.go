@@ -0,0 +1,144 @@
+package agent
+
+import (
+	"encoding/xml"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// skippedElements hold page chrome and code rather than content
+var skippedElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true,
+	"head": true, "nav": true, "header": true, "footer": true, "aside": true,
+	"form": true, "button": true, "iframe": true, "svg": true,
+}
+
+// blockElements start on a new line
+var blockElements = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "main": true,
+	"table": true, "tr": true, "ul": true, "ol": true, "dl": true, "dt": true,
+	"dd": true, "blockquote": true, "hr": true, "details": true, "summary": true,
+}
+
+var (
+	spaceRun   = regexp.MustCompile(`[ \t\r\n]+`)
+	blankLines = regexp.MustCompile(`\n{3,}`)
+	lineSpace  = regexp.MustCompile(`[ \t]+\n`)
+)
+
+// isHTML reports whether a response is an HTML page, from its content type
+// or, without one, its first bytes
+func isHTML(contentType string, body []byte) bool {
+	if contentType != "" {
+		return strings.Contains(contentType, "text/html") || strings.Contains(contentType, "xhtml")
+	}
+	start := strings.ToLower(strings.TrimSpace(string(body[:min(len(body), 512)])))
+	return strings.HasPrefix(start, "<!doctype html") || strings.HasPrefix(start, "<html")
+}
+
+// htmlToText converts an HTML page to markdown-like text for the model. It
+// drops scripts, styles and navigation and keeps headings, lists, links and
+// preformatted blocks. Malformed markup ends the conversion early rather than
+// failing it.
+func htmlToText(r io.Reader) string {
+	d := xml.NewDecoder(r)
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+
+	var out strings.Builder
+	var links []string // hrefs of the open links
+	skipping, pre := 0, 0
+	for {
+		token, err := d.Token()
+		if err != nil {
+			break
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			name := strings.ToLower(t.Name.Local)
+			if skipping > 0 || skippedElements[name] {
+				skipping++
+				continue
+			}
+			switch {
+			case len(name) == 2 && name[0] == 'h' && name[1] >= '1' && name[1] <= '6':
+				out.WriteString("\n\n" + strings.Repeat("#", int(name[1]-'0')) + " ")
+			case name == "li":
+				out.WriteString("\n- ")
+			case name == "br":
+				out.WriteString("\n")
+			case name == "pre":
+				pre++
+				out.WriteString("\n\n```\n")
+			case name == "code" && pre == 0:
+				out.WriteString("`")
+			case name == "td" || name == "th":
+				out.WriteString(" | ")
+			case name == "a":
+				href := attr(t, "href")
+				if strings.HasPrefix(href, "#") || strings.HasPrefix(href, "javascript:") {
+					href = ""
+				}
+				links = append(links, href)
+				if href != "" {
+					out.WriteString("[")
+				}
+			case blockElements[name]:
+				out.WriteString("\n\n")
+			}
+
+		case xml.EndElement:
+			name := strings.ToLower(t.Name.Local)
+			if skipping > 0 {
+				skipping--
+				continue
+			}
+			switch {
+			case len(name) == 2 && name[0] == 'h' && name[1] >= '1' && name[1] <= '6':
+				out.WriteString("\n\n")
+			case name == "pre":
+				pre = max(pre-1, 0)
+				out.WriteString("\n```\n\n")
+			case name == "code" && pre == 0:
+				out.WriteString("`")
+			case name == "a" && len(links) > 0:
+				if href := links[len(links)-1]; href != "" {
+					out.WriteString("](" + href + ")")
+				}
+				links = links[:len(links)-1]
+			case blockElements[name]:
+				out.WriteString("\n\n")
+			}
+
+		case xml.CharData:
+			if skipping > 0 {
+				continue
+			}
+			if pre > 0 {
+				out.Write(t)
+				continue
+			}
+			text := spaceRun.ReplaceAllString(string(t), " ")
+			if strings.HasSuffix(out.String(), "\n") {
+				text = strings.TrimLeft(text, " ")
+			}
+			out.WriteString(text)
+		}
+	}
+
+	text := lineSpace.ReplaceAllString(out.String(), "\n")
+	return strings.TrimSpace(blankLines.ReplaceAllString(text, "\n\n"))
+}
+
+// attr returns an element's attribute value, or ""
+func attr(e xml.StartElement, name string) string {
+	for _, a := range e.Attr {
+		if strings.EqualFold(a.Name.Local, name) {
+			return a.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTMLToText(t *testing.T) {
+	page := `<!DOCTYPE html>
+<html><head><title>v1.2.3</title><style>body { color: red }</style></head>
+<body>
+<nav><a href="/">Home</a> <a href="/docs">Docs</a></nav>
+<h1>Release v1.2.3</h1>
+<p>Fixes a <b>crash</b> &amp; adds   <code>--fast</code>.<br>See <a href="https://example.com/pr/7">PR 7</a>.</p>
+<ul><li>Breaking: drop Go 1.21<li>New flag</ul>
+<pre>go get example.com/lib@v1.2.3
+  indented</pre>
+<script>alert("hi")</script>
+<footer>© Example</footer>
+</body></html>`
+
+	got := htmlToText(strings.NewReader(page))
+	want := "# Release v1.2.3\n\n" +
+		"Fixes a crash & adds `--fast`.\nSee [PR 7](https://example.com/pr/7).\n\n" +
+		"- Breaking: drop Go 1.21\n- New flag\n\n" +
+		"```\ngo get example.com/lib@v1.2.3\n  indented\n```"
+	if got != want {
+		t.Errorf("htmlToText() =\n%s\n\nwant\n%s", got, want)
+	}
+}
+
+func TestIsHTML(t *testing.T) {
+	if !isHTML("text/html; charset=utf-8", nil) || isHTML("text/markdown", []byte("<html>")) {
+		t.Error("isHTML() should trust the content type")
+	}
+	if !isHTML("", []byte("  <!DOCTYPE html><html>")) || isHTML("", []byte("# Changelog")) {
+		t.Error("isHTML() should sniff the body without a content type")
+	}
+}
@@ -0,0 +1,86 @@
+package agent
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/version"
+)
+
+// Provenance records what produced an analysis, so why the AI recommended
+// what it did can still be answered weeks later
+type Provenance struct {
+	Provider        string
+	Model           string
+	Deployment      string   `json:",omitempty"` // Azure only
+	ReasoningEffort string   `json:",omitempty"`
+	PromptVersion   string   // Hash of the prompts the model was given
+	Tools           []string `json:",omitempty"` // Tools offered, as name@hash of their description and parameters
+	Speedrun        string   // speedrun's version
+	AnalyzedAt      time.Time
+}
+
+// PromptVersion hashes the developer message, the review template and the
+// language notes, so analyses made with different prompts can be told apart
+// and cached apart
+func PromptVersion() string {
+	h := sha256.New()
+	h.Write([]byte(DeveloperMessage))
+	h.Write([]byte(ReviewMessageTemplate))
+	_ = fs.WalkDir(languagePrompts, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := languagePrompts.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		h.Write([]byte(path))
+		h.Write(data)
+		return nil
+	})
+	return shortHash(h.Sum(nil))
+}
+
+// provenance describes the agent as it analyzes a PR now
+func (a *Agent) provenance() *Provenance {
+	return &Provenance{
+		Provider:        a.provider,
+		Model:           a.model,
+		Deployment:      a.deployment,
+		ReasoningEffort: a.effort,
+		PromptVersion:   PromptVersion(),
+		Tools:           a.toolRegistry.versions(),
+		Speedrun:        version.Get(),
+		AnalyzedAt:      time.Now(),
+	}
+}
+
+// versions returns the registered tools as name@hash, sorted by name. The
+// hash changes whenever what the model is told about a tool does.
+func (r *ToolRegistry) versions() []string {
+	if r == nil {
+		return nil
+	}
+	tools := slices.SortedFunc(maps.Values(r.tools), func(a, b Tool) int {
+		return cmp.Compare(a.Name(), b.Name())
+	})
+
+	versions := make([]string, len(tools))
+	for i, tool := range tools {
+		h := sha256.New()
+		h.Write([]byte(tool.Description()))
+		h.Write(tool.Parameters())
+		versions[i] = tool.Name() + "@" + shortHash(h.Sum(nil))[:8]
+	}
+	return versions
+}
+
+func shortHash(sum []byte) string {
+	return hex.EncodeToString(sum)[:12]
+}
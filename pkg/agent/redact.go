@@ -0,0 +1,105 @@
+package agent
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/kennyp/speedrun/pkg/tracing"
+)
+
+// secretPattern finds one kind of secret. With a group, only that submatch
+// is the secret, so the key name around it stays readable.
+type secretPattern struct {
+	kind  string
+	re    *regexp.Regexp
+	group int
+}
+
+// secretPatterns are the secret-looking strings kept from the model. Diffs,
+// comments and fetched pages can all carry credentials someone pasted by
+// mistake, and the model's provider shouldn't see them.
+var secretPatterns = []secretPattern{
+	{kind: "private_key", re: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY( BLOCK)?-----[\s\S]*?(-----END [A-Z ]*PRIVATE KEY( BLOCK)?-----|\z)`)},
+	{kind: "aws_access_key", re: regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{kind: "aws_secret_key", re: regexp.MustCompile(`(?i)aws_?secret_?(access_?)?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})\b`), group: 2},
+	{kind: "github_token", re: regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{kind: "slack_token", re: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{kind: "openai_key", re: regexp.MustCompile(`\bsk-(proj-|svcacct-)?[A-Za-z0-9_-]{32,}`)},
+	{kind: "google_api_key", re: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{kind: "stripe_key", re: regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{20,}\b`)},
+	{kind: "jwt", re: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{kind: "password", re: regexp.MustCompile(`(?i)(password|passwd|secret|api_?key|access_?token)["']?\s*[:=]\s*["']([^"'\s]{8,})["']`), group: 2},
+}
+
+// Redactions counts the secrets redacted from text, by kind
+type Redactions map[string]int
+
+// Add counts other's redactions in r
+func (r Redactions) Add(other Redactions) {
+	for kind, n := range other {
+		r[kind] += n
+	}
+}
+
+// Total returns how many secrets were redacted
+func (r Redactions) Total() int {
+	total := 0
+	for _, n := range r {
+		total += n
+	}
+	return total
+}
+
+// String lists the redactions by kind, e.g. "aws_access_key=1, github_token=2"
+func (r Redactions) String() string {
+	kinds := slices.Sorted(maps.Keys(r))
+	parts := make([]string, len(kinds))
+	for i, kind := range kinds {
+		parts[i] = fmt.Sprintf("%s=%d", kind, r[kind])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// redact replaces secret-looking strings in text with [REDACTED <kind>]
+func redact(text string) (string, Redactions) {
+	found := Redactions{}
+	for _, p := range secretPatterns {
+		matches := p.re.FindAllStringSubmatchIndex(text, -1)
+		if len(matches) == 0 {
+			continue
+		}
+
+		var b strings.Builder
+		last := 0
+		for _, m := range matches {
+			start, end := m[2*p.group], m[2*p.group+1]
+			if start < 0 || strings.HasPrefix(text[start:end], "[REDACTED") {
+				continue
+			}
+			b.WriteString(text[last:start])
+			fmt.Fprintf(&b, "[REDACTED %s]", p.kind)
+			last = end
+			found[p.kind]++
+		}
+		b.WriteString(text[last:])
+		text = b.String()
+	}
+	return text, found
+}
+
+// reportRedactions records what was redacted from content bound for the
+// model, from source (the prompt or a tool), on the span and in the log
+func reportRedactions(span *tracing.Span, source string, r Redactions) {
+	if r.Total() == 0 {
+		return
+	}
+	span.SetAttributes(
+		tracing.Int("ai.redacted", r.Total()),
+		tracing.String("ai.redactions", r.String()),
+	)
+	slog.Warn("Redacted secrets before sending to the AI", slog.String("source", source), slog.String("redactions", r.String()))
+}
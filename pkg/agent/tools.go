@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/json"
@@ -8,10 +9,14 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/kennyp/speedrun/pkg/cache"
 	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/tracing"
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/packages/param"
 )
@@ -26,13 +31,28 @@ type Tool interface {
 
 // ToolRegistry holds all available tools
 type ToolRegistry struct {
-	tools  map[string]Tool
-	client *github.Client
-	cache  cache.Cache
+	tools   map[string]Tool
+	configs map[string]ToolConfig // By tool name, for tools tuned with Configure
+	client  *github.Client
+	cache   cache.Cache
+}
+
+// ToolConfig tunes how one tool runs
+type ToolConfig struct {
+	Timeout  time.Duration // 0 for the agent's tool timeout
+	MaxBytes int           // Longest result returned to the model (0 for no limit)
+}
+
+// WebFetchConfig controls what the web_fetch tool returns and keeps
+type WebFetchConfig struct {
+	MaxChars int                 // Longest page text returned to the model (0 for no limit)
+	Content  cache.ContentLimits // Store for pages of a fixed version, if the cache has one
+
+	Transport http.RoundTripper // Underneath every fetch, http.DefaultTransport if nil
 }
 
 // NewToolRegistry creates a new tool registry
-func NewToolRegistry(githubClient *github.Client, cache cache.Cache) *ToolRegistry {
+func NewToolRegistry(githubClient *github.Client, cache cache.Cache, webFetch WebFetchConfig) *ToolRegistry {
 	registry := &ToolRegistry{
 		tools:  make(map[string]Tool),
 		client: githubClient,
@@ -41,8 +61,16 @@ func NewToolRegistry(githubClient *github.Client, cache cache.Cache) *ToolRegist
 
 	// Register all tools
 	registry.Register(&GitHubTool{client: githubClient, cache: cache})
-	registry.Register(&WebFetchTool{cache: cache})
+	registry.Register(&WebFetchTool{
+		cache:  cache,
+		config: webFetch,
+		client: &http.Client{Transport: tracing.NewTransport(webFetch.Transport)},
+	})
 	registry.Register(&DiffAnalyzerTool{cache: cache})
+	registry.Register(&DependencyTool{client: githubClient, cache: cache})
+	registry.Register(&CodeSearchTool{client: githubClient, cache: cache})
+	registry.Register(&WorkflowTool{client: githubClient, cache: cache})
+	registry.Register(&InfraTool{client: githubClient, cache: cache})
 
 	return registry
 }
@@ -58,6 +86,24 @@ func (r *ToolRegistry) Get(name string) (Tool, bool) {
 	return tool, ok
 }
 
+// Configure sets the named tool's timeout and result size limit
+func (r *ToolRegistry) Configure(name string, config ToolConfig) {
+	if r.configs == nil {
+		r.configs = make(map[string]ToolConfig)
+	}
+	r.configs[name] = config
+}
+
+// Disable removes the named tool, so the model isn't offered it
+func (r *ToolRegistry) Disable(name string) {
+	delete(r.tools, name)
+}
+
+// config returns how the named tool is tuned
+func (r *ToolRegistry) config(name string) ToolConfig {
+	return r.configs[name]
+}
+
 // GetOpenAITools returns tool definitions for OpenAI API
 func (r *ToolRegistry) GetOpenAITools() []openai.ChatCompletionToolParam {
 	var tools []openai.ChatCompletionToolParam
@@ -93,7 +139,7 @@ func (t *GitHubTool) Name() string {
 }
 
 func (t *GitHubTool) Description() string {
-	return "Access GitHub API to get PR details, diffs, file contents, and comments. Essential for dependency updates: check PR comments for links to release notes, changelogs, and security advisories. Use get_pr_comments to find upstream information that explains what changed between versions."
+	return "Access GitHub API to get PR details, diffs, file contents, comments, and failing check logs. Essential for dependency updates: check PR comments for links to release notes, changelogs, and security advisories. Use get_pr_comments to find upstream information that explains what changed between versions. Use get_check_logs to read the end of each failing GitHub Actions job's log and tell a flake from a real failure."
 }
 
 func (t *GitHubTool) Parameters() json.RawMessage {
@@ -102,8 +148,8 @@ func (t *GitHubTool) Parameters() json.RawMessage {
 		"properties": map[string]interface{}{
 			"action": map[string]interface{}{
 				"type":        "string",
-				"enum":        []string{"get_pr_details", "get_pr_diff", "get_file_content", "get_pr_comments"},
-				"description": "The action to perform: get_pr_details for basic info, get_pr_diff for code changes, get_file_content for specific files, get_pr_comments for links to release notes/changelogs",
+				"enum":        []string{"get_pr_details", "get_pr_diff", "get_file_content", "get_pr_comments", "get_check_logs"},
+				"description": "The action to perform: get_pr_details for basic info, get_pr_diff for code changes (large diffs show only hunk positions for some files; pass path to see one file's whole diff), get_file_content for specific files, get_pr_comments for links to release notes/changelogs, get_check_logs for the end of failing CI job logs",
 			},
 			"owner": map[string]interface{}{
 				"type":        "string",
@@ -119,7 +165,7 @@ func (t *GitHubTool) Parameters() json.RawMessage {
 			},
 			"path": map[string]interface{}{
 				"type":        "string",
-				"description": "File path (for get_file_content)",
+				"description": "File path (for get_file_content, or get_pr_diff to see one file's whole diff)",
 			},
 			"ref": map[string]interface{}{
 				"type":        "string",
@@ -148,6 +194,11 @@ func (t *GitHubTool) Execute(ctx context.Context, params json.RawMessage) (strin
 		return "", fmt.Errorf("invalid parameters: %w", err)
 	}
 
+	// Check logs follow the PR's latest commit, so they skip the cache
+	if p.Action == "get_check_logs" {
+		return t.client.GetCheckLogs(ctx, p.Owner, p.Repo, p.PRNumber)
+	}
+
 	// Generate cache key based on tool name and parameters
 	cacheKey := t.generateCacheKey(params)
 
@@ -170,7 +221,13 @@ func (t *GitHubTool) Execute(ctx context.Context, params json.RawMessage) (strin
 		return result, nil
 
 	case "get_pr_diff":
-		result, err := t.client.GetPRDiff(ctx, p.Owner, p.Repo, p.PRNumber)
+		var result string
+		var err error
+		if p.Path != "" {
+			result, err = t.client.GetPRFileDiff(ctx, p.Owner, p.Repo, p.PRNumber, p.Path)
+		} else {
+			result, err = t.client.GetPRDiff(ctx, p.Owner, p.Repo, p.PRNumber)
+		}
 		if err != nil {
 			return "", err
 		}
@@ -214,7 +271,40 @@ func (t *GitHubTool) generateCacheKey(params json.RawMessage) string {
 
 // WebFetchTool fetches content from URLs
 type WebFetchTool struct {
-	cache cache.Cache
+	cache  cache.Cache
+	config WebFetchConfig
+	client *http.Client
+}
+
+// versionSegment matches a URL path segment naming a release, like v1.2.3,
+// name@1.2.3 or v1.2.2...v1.2.3
+var versionSegment = regexp.MustCompile(`(^|@)v?\d+\.\d+(\.\d+)?([-+.][0-9A-Za-z.-]*)?$`)
+
+// commitSegment matches a URL path segment that's a full commit SHA
+var commitSegment = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// immutableURL reports whether a URL names a released version or commit, so
+// what it serves won't change
+func immutableURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.RawQuery != "" {
+		return false
+	}
+	for _, segment := range strings.Split(u.Path, "/") {
+		if versionSegment.MatchString(segment) || commitSegment.MatchString(segment) {
+			return true
+		}
+	}
+	return false
+}
+
+// contentStore returns where immutable content goes, or nil to use the cache
+func (t *WebFetchTool) contentStore(rawURL string) cache.ContentStore {
+	if t.config.Content.MaxBytes <= 0 || !immutableURL(rawURL) {
+		return nil
+	}
+	store, _ := t.cache.(cache.ContentStore)
+	return store
 }
 
 func (t *WebFetchTool) Name() string {
@@ -222,7 +312,7 @@ func (t *WebFetchTool) Name() string {
 }
 
 func (t *WebFetchTool) Description() string {
-	return "Fetch content from URLs including release notes, changelogs, security advisories, and documentation. Critical for dependency analysis: fetch upstream project information to understand what actually changed, not just the diff size. Look for links in PR descriptions and comments."
+	return "Fetch content from URLs including release notes, changelogs, security advisories, and documentation. Critical for dependency analysis: fetch upstream project information to understand what actually changed, not just the diff size. Look for links in PR descriptions and comments. Long pages are cut to fit, noting the omitted lines; fetch again with start_line to read on from one."
 }
 
 func (t *WebFetchTool) Parameters() json.RawMessage {
@@ -233,6 +323,10 @@ func (t *WebFetchTool) Parameters() json.RawMessage {
 				"type":        "string",
 				"description": "The URL to fetch",
 			},
+			"start_line": map[string]interface{}{
+				"type":        "integer",
+				"description": "Line of the page to start from, to read lines omitted from a long page (default 1)",
+			},
 		},
 		"required": []string{"url"},
 	}
@@ -242,7 +336,8 @@ func (t *WebFetchTool) Parameters() json.RawMessage {
 }
 
 type webFetchParams struct {
-	URL string `json:"url"`
+	URL       string `json:"url"`
+	StartLine int    `json:"start_line"`
 }
 
 func (t *WebFetchTool) Execute(ctx context.Context, params json.RawMessage) (string, error) {
@@ -251,13 +346,21 @@ func (t *WebFetchTool) Execute(ctx context.Context, params json.RawMessage) (str
 		return "", fmt.Errorf("invalid parameters: %w", err)
 	}
 
-	// Generate cache key based on tool name and parameters
-	cacheKey := t.generateCacheKey(params)
+	// Generate cache key based on the URL, so every part of a page is read
+	// from one fetch
+	cacheKey := t.generateCacheKey(p.URL)
+	store := t.contentStore(p.URL)
 
-	// Try to get from cache first
-	var result string
-	if err := t.cache.Get(cacheKey, &result); err == nil {
-		return result, nil
+	// Try to get from the content store or cache first
+	if store != nil {
+		if result, err := store.Content(p.URL, t.config.Content.MaxAge); err == nil {
+			return t.page(result, p.StartLine), nil
+		}
+	} else {
+		var result string
+		if err := t.cache.Get(cacheKey, &result); err == nil {
+			return t.page(result, p.StartLine), nil
+		}
 	}
 
 	// Cache miss, fetch from URL
@@ -267,9 +370,8 @@ func (t *WebFetchTool) Execute(ctx context.Context, params json.RawMessage) (str
 		return "", fmt.Errorf("creating request: %w", err)
 	}
 
-	// Make the request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	// Make the request on the shared transport's pooled connections
+	resp, err := t.client.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("fetching URL: %w", err)
 	}
@@ -290,22 +392,46 @@ func (t *WebFetchTool) Execute(ctx context.Context, params json.RawMessage) (str
 		return "", fmt.Errorf("reading response: %w", err)
 	}
 
-	// Return first 5000 characters to avoid overwhelming the model
+	// Pages go to the model as text, which takes a fraction of the context
+	// the markup would. The whole text is cached so the limit can change.
 	content := string(body)
-	if len(content) > 5000 {
-		content = content[:5000] + "\n... (truncated)"
+	if isHTML(resp.Header.Get("Content-Type"), body) {
+		content = htmlToText(bytes.NewReader(body))
 	}
 
 	// Cache the successful result
-	if err := t.cache.Set(cacheKey, content); err != nil {
+	if store != nil {
+		if err := store.StoreContent(p.URL, content, t.config.Content); err != nil {
+			slog.Error("Failed to store web fetch content", slog.String("url", p.URL), slog.Any("error", err))
+		}
+	} else if err := t.cache.Set(cacheKey, content); err != nil {
 		slog.Error("Failed to cache web fetch result", slog.String("key", cacheKey), slog.Any("error", err))
 	}
 
-	return content, nil
+	return t.page(content, p.StartLine), nil
+}
+
+// page returns the page text from startLine on, cut to fit
+func (t *WebFetchTool) page(content string, startLine int) string {
+	fit := truncator{limit: t.config.MaxChars, chars: true, hint: "Fetch again with start_line to read omitted lines."}
+	if startLine <= 1 {
+		return fit.truncate(content)
+	}
+
+	lines := strings.Split(content, "\n")
+	if startLine > len(lines) {
+		return fmt.Sprintf("[The page has only %d lines]", len(lines))
+	}
+	fit.skip = startLine - 1
+	skipped := "[Line 1 skipped]\n"
+	if fit.skip > 1 {
+		skipped = fmt.Sprintf("[Lines 1-%d skipped]\n", fit.skip)
+	}
+	return skipped + fit.truncate(strings.Join(lines[fit.skip:], "\n"))
 }
 
-func (t *WebFetchTool) generateCacheKey(params json.RawMessage) string {
-	hash := sha256.Sum256([]byte(fmt.Sprintf("web_fetch:%s", string(params))))
+func (t *WebFetchTool) generateCacheKey(rawURL string) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("web_fetch:%s", rawURL)))
 	return fmt.Sprintf("tool:web:%x", hash)
 }
 
@@ -439,3 +565,406 @@ func (t *DiffAnalyzerTool) getModifiedPaths(diff string) string {
 
 	return "Modified files:\n" + strings.Join(paths, "\n")
 }
+
+// DependencyTool lists the package changes in a PR's lockfiles
+type DependencyTool struct {
+	client *github.Client
+	cache  cache.Cache
+}
+
+func (t *DependencyTool) Name() string {
+	return "dependency_changes"
+}
+
+func (t *DependencyTool) Description() string {
+	return "List the exact packages and versions a PR adds, removes and updates, read from its go.sum, package-lock.json and poetry.lock changes. Use for dependency updates instead of reading lockfile diffs, including to spot transitive updates the PR title doesn't mention."
+}
+
+func (t *DependencyTool) Parameters() json.RawMessage {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"owner": map[string]interface{}{
+				"type":        "string",
+				"description": "Repository owner",
+			},
+			"repo": map[string]interface{}{
+				"type":        "string",
+				"description": "Repository name",
+			},
+			"pr_number": map[string]interface{}{
+				"type":        "integer",
+				"description": "Pull request number",
+			},
+		},
+		"required": []string{"owner", "repo", "pr_number"},
+	}
+
+	data, _ := json.Marshal(schema)
+	return data
+}
+
+type dependencyParams struct {
+	Owner    string `json:"owner"`
+	Repo     string `json:"repo"`
+	PRNumber int    `json:"pr_number"`
+}
+
+func (t *DependencyTool) Execute(ctx context.Context, params json.RawMessage) (string, error) {
+	var p dependencyParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return "", fmt.Errorf("invalid parameters: %w", err)
+	}
+
+	cacheKey := t.generateCacheKey(params)
+	var result string
+	if err := t.cache.Get(cacheKey, &result); err == nil {
+		return result, nil
+	}
+
+	result, err := t.client.GetDependencyChanges(ctx, p.Owner, p.Repo, p.PRNumber)
+	if err != nil {
+		return "", err
+	}
+	if err := t.cache.Set(cacheKey, result); err != nil {
+		slog.Error("Failed to cache dependency changes", slog.String("key", cacheKey), slog.Any("error", err))
+	}
+	return result, nil
+}
+
+func (t *DependencyTool) generateCacheKey(params json.RawMessage) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("dependency_changes:%s", string(params))))
+	return fmt.Sprintf("tool:deps:%x", hash)
+}
+
+// WorkflowTool shows how a PR changes its repository's GitHub Actions
+// workflows and what the changes let CI do
+type WorkflowTool struct {
+	client *github.Client
+	cache  cache.Cache
+}
+
+func (t *WorkflowTool) Name() string {
+	return "workflow_changes"
+}
+
+func (t *WorkflowTool) Description() string {
+	return "For PRs that change .github/workflows, show each changed workflow's diff and flag what it newly allows: pull_request_target or workflow_run triggers, wider token permissions, new secrets, the PR's own code running in a privileged workflow (HIGH), untrusted PR or issue text in scripts, unpinned third-party actions and self-hosted runners (MEDIUM)."
+}
+
+func (t *WorkflowTool) Parameters() json.RawMessage {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"owner": map[string]interface{}{
+				"type":        "string",
+				"description": "Repository owner",
+			},
+			"repo": map[string]interface{}{
+				"type":        "string",
+				"description": "Repository name",
+			},
+			"pr_number": map[string]interface{}{
+				"type":        "integer",
+				"description": "Pull request number",
+			},
+		},
+		"required": []string{"owner", "repo", "pr_number"},
+	}
+
+	data, _ := json.Marshal(schema)
+	return data
+}
+
+type workflowParams struct {
+	Owner    string `json:"owner"`
+	Repo     string `json:"repo"`
+	PRNumber int    `json:"pr_number"`
+}
+
+func (t *WorkflowTool) Execute(ctx context.Context, params json.RawMessage) (string, error) {
+	var p workflowParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return "", fmt.Errorf("invalid parameters: %w", err)
+	}
+
+	cacheKey := t.generateCacheKey(params)
+	var result string
+	if err := t.cache.Get(cacheKey, &result); err == nil {
+		return result, nil
+	}
+
+	changes, err := t.client.GetWorkflowChanges(ctx, p.Owner, p.Repo, p.PRNumber)
+	if err != nil {
+		return "", err
+	}
+	result = formatWorkflowChanges(changes)
+	if err := t.cache.Set(cacheKey, result); err != nil {
+		slog.Error("Failed to cache workflow changes", slog.String("key", cacheKey), slog.Any("error", err))
+	}
+	return result, nil
+}
+
+// formatWorkflowChanges lists each workflow's risks, then its diff
+func formatWorkflowChanges(changes []github.WorkflowChange) string {
+	if len(changes) == 0 {
+		return "This PR doesn't change any workflows in .github/workflows."
+	}
+
+	var out strings.Builder
+	for _, change := range changes {
+		switch {
+		case change.Before == "":
+			fmt.Fprintf(&out, "%s (new workflow)\n", change.Path)
+		case change.After == "":
+			fmt.Fprintf(&out, "%s (removed)\n", change.Path)
+		default:
+			fmt.Fprintf(&out, "%s\n", change.Path)
+		}
+
+		risks := change.Risks()
+		if len(risks) == 0 {
+			out.WriteString("No privilege changes found.\n")
+		}
+		for _, risk := range risks {
+			fmt.Fprintf(&out, "%s: %s\n", risk.Level, risk.Reason)
+		}
+		fmt.Fprintf(&out, "\n%s\n", change.Diff)
+	}
+	return out.String()
+}
+
+func (t *WorkflowTool) generateCacheKey(params json.RawMessage) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("workflow_changes:%s", string(params))))
+	return fmt.Sprintf("tool:workflows:%x", hash)
+}
+
+// InfraTool summarizes what a PR does to Terraform and CloudFormation
+// resources
+type InfraTool struct {
+	client *github.Client
+	cache  cache.Cache
+}
+
+func (t *InfraTool) Name() string {
+	return "infra_changes"
+}
+
+func (t *InfraTool) Description() string {
+	return "For PRs that change Terraform or CloudFormation, list the resources the diff creates, updates and destroys, and the plan summaries CI posted as checks or comments (Atlantis, Terraform Cloud and the like), including the resources plans say must be replaced."
+}
+
+func (t *InfraTool) Parameters() json.RawMessage {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"owner": map[string]interface{}{
+				"type":        "string",
+				"description": "Repository owner",
+			},
+			"repo": map[string]interface{}{
+				"type":        "string",
+				"description": "Repository name",
+			},
+			"pr_number": map[string]interface{}{
+				"type":        "integer",
+				"description": "Pull request number",
+			},
+		},
+		"required": []string{"owner", "repo", "pr_number"},
+	}
+
+	data, _ := json.Marshal(schema)
+	return data
+}
+
+type infraParams struct {
+	Owner    string `json:"owner"`
+	Repo     string `json:"repo"`
+	PRNumber int    `json:"pr_number"`
+}
+
+func (t *InfraTool) Execute(ctx context.Context, params json.RawMessage) (string, error) {
+	var p infraParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return "", fmt.Errorf("invalid parameters: %w", err)
+	}
+
+	cacheKey := t.generateCacheKey(params)
+	var result string
+	if err := t.cache.Get(cacheKey, &result); err == nil {
+		return result, nil
+	}
+
+	changes, err := t.client.GetInfraChanges(ctx, p.Owner, p.Repo, p.PRNumber)
+	if err != nil {
+		return "", err
+	}
+	result = formatInfraChanges(changes)
+	if err := t.cache.Set(cacheKey, result); err != nil {
+		slog.Error("Failed to cache infra changes", slog.String("key", cacheKey), slog.Any("error", err))
+	}
+	return result, nil
+}
+
+// formatInfraChanges lists each file's resources by what happens to them,
+// then CI's plans, which alone can tell an update from a replacement
+func formatInfraChanges(changes *github.InfraChanges) string {
+	if len(changes.Files) == 0 {
+		return "This PR doesn't change any Terraform or CloudFormation files."
+	}
+
+	var out strings.Builder
+	out.WriteString("From the diff:\n")
+	for _, file := range changes.Files {
+		fmt.Fprintf(&out, "%s (%s)\n", file.Path, file.Kind)
+		if len(file.Resources) == 0 {
+			out.WriteString("  no resources changed, only variables, outputs or other settings\n")
+		}
+		for _, resource := range file.Resources {
+			fmt.Fprintf(&out, "  %s %s\n", resource.Action, resource.Address)
+		}
+	}
+
+	out.WriteString("\n")
+	if len(changes.Plans) == 0 {
+		out.WriteString("No plan found in the PR's checks or comments, so which updates force a replacement is unknown.\n")
+		return out.String()
+	}
+	out.WriteString("Plans posted by CI:\n")
+	for _, plan := range changes.Plans {
+		fmt.Fprintf(&out, "%s: %d to add, %d to change, %d to destroy", plan.Source, plan.Add, plan.Change, plan.Destroy)
+		if plan.URL != "" {
+			fmt.Fprintf(&out, " (%s)", plan.URL)
+		}
+		out.WriteString("\n")
+		for _, resource := range plan.Resources {
+			fmt.Fprintf(&out, "  %s %s\n", resource.Action, resource.Address)
+		}
+	}
+	return out.String()
+}
+
+func (t *InfraTool) generateCacheKey(params json.RawMessage) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("infra_changes:%s", string(params))))
+	return fmt.Sprintf("tool:infra:%x", hash)
+}
+
+// repoAccessKey holds, on a tool call's context, which repositories the
+// model may see
+type repoAccessKey struct{}
+
+// withRepoAccess has tools check allowed before returning anything from a
+// repository other than the one they were asked about
+func withRepoAccess(ctx context.Context, allowed func(owner, repo string) bool) context.Context {
+	return context.WithValue(ctx, repoAccessKey{}, allowed)
+}
+
+// repoAllowed reports whether a tool may return what it found in owner/repo
+func repoAllowed(ctx context.Context, owner, repo string) bool {
+	allowed, ok := ctx.Value(repoAccessKey{}).(func(owner, repo string) bool)
+	return !ok || allowed(owner, repo)
+}
+
+// codeSearchResults is how many files a code search returns
+const codeSearchResults = 20
+
+// CodeSearchTool searches the code of a PR's repository or its owner's
+// repositories, e.g. for callers of a function the PR changes
+type CodeSearchTool struct {
+	client *github.Client
+	cache  cache.Cache
+}
+
+func (t *CodeSearchTool) Name() string {
+	return "code_search"
+}
+
+func (t *CodeSearchTool) Description() string {
+	return "Search code on the default branches of a repository, or of all the owner's repositories when repo is left out. Use it to judge the blast radius of API changes: find other callers of a function, type or endpoint the PR changes or removes, and whether they'd break. Supports GitHub code search syntax such as quoted phrases, language: and path:, but not repo:, org: or user:. Returns up to 20 files with the matching lines."
+}
+
+func (t *CodeSearchTool) Parameters() json.RawMessage {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"owner": map[string]interface{}{
+				"type":        "string",
+				"description": "Repository owner (user or organization)",
+			},
+			"repo": map[string]interface{}{
+				"type":        "string",
+				"description": "Repository name; leave out to search all the owner's repositories",
+			},
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": "What to search for, e.g. \"ParseConfig(\" language:go",
+			},
+		},
+		"required": []string{"owner", "query"},
+	}
+
+	data, _ := json.Marshal(schema)
+	return data
+}
+
+type codeSearchParams struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+	Query string `json:"query"`
+}
+
+// codeSearchResult is a cached code search
+type codeSearchResult struct {
+	Matches []github.CodeMatch
+	Total   int
+}
+
+func (t *CodeSearchTool) Execute(ctx context.Context, params json.RawMessage) (string, error) {
+	var p codeSearchParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return "", fmt.Errorf("invalid parameters: %w", err)
+	}
+	if p.Owner == "" || strings.TrimSpace(p.Query) == "" {
+		return "", fmt.Errorf("owner and query parameters are required")
+	}
+
+	// Code search allows few requests a minute, so results are cached
+	cacheKey := t.generateCacheKey(params)
+	var result codeSearchResult
+	if err := t.cache.Get(cacheKey, &result); err != nil {
+		matches, total, err := t.client.SearchCode(ctx, p.Owner, p.Repo, p.Query, codeSearchResults)
+		if err != nil {
+			return "", err
+		}
+		result = codeSearchResult{Matches: matches, Total: total}
+		if err := t.cache.Set(cacheKey, result); err != nil {
+			slog.Error("Failed to cache code search", slog.String("key", cacheKey), slog.Any("error", err))
+		}
+	}
+
+	var out strings.Builder
+	shown := 0
+	for _, match := range result.Matches {
+		owner, repo, _ := strings.Cut(match.Repo, "/")
+		if !repoAllowed(ctx, owner, repo) {
+			continue
+		}
+		shown++
+		fmt.Fprintf(&out, "\n%s %s\n", match.Repo, match.Path)
+		for _, fragment := range match.Fragments {
+			for _, line := range strings.Split(strings.TrimSpace(fragment), "\n") {
+				out.WriteString("    " + line + "\n")
+			}
+		}
+	}
+	if shown == 0 {
+		return fmt.Sprintf("No code matching %q found.", p.Query), nil
+	}
+	return fmt.Sprintf("%d files match %q, showing %d:\n", result.Total, p.Query, shown) + out.String(), nil
+}
+
+func (t *CodeSearchTool) generateCacheKey(params json.RawMessage) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("code_search:%s", string(params))))
+	return fmt.Sprintf("tool:code:%x", hash)
+}
@@ -0,0 +1,69 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/cache"
+	"github.com/kennyp/speedrun/pkg/github"
+)
+
+func TestImmutableURL(t *testing.T) {
+	tests := map[string]bool{
+		"https://github.com/acme/lib/releases/tag/v1.2.3":                                true,
+		"https://github.com/acme/lib/blob/v2.0.0-rc.1/CHANGELOG.md":                      true,
+		"https://github.com/acme/lib/compare/v1.2.2...v1.2.3":                            true,
+		"https://pkg.go.dev/golang.org/x/net@v0.23.0":                                    true,
+		"https://github.com/acme/lib/blob/0123456789abcdef0123456789abcdef01234567/NEWS": true,
+		"https://github.com/acme/lib/releases/latest":                                    false,
+		"https://github.com/acme/lib/blob/main/CHANGELOG.md":                             false,
+		"https://example.com/changelog?version=1.2.3":                                    false,
+	}
+	for rawURL, want := range tests {
+		if got := immutableURL(rawURL); got != want {
+			t.Errorf("immutableURL(%q) = %v, want %v", rawURL, got, want)
+		}
+	}
+}
+
+func TestWebFetchPage(t *testing.T) {
+	tool := &WebFetchTool{config: WebFetchConfig{MaxChars: 20}}
+	page := "line 1\nline 2\nline 3\nline 4\nline 5\nline 6"
+
+	first := tool.page(page, 0)
+	if !strings.HasPrefix(first, "line 1\n") || !strings.Contains(first, "start_line") {
+		t.Errorf("page() = %q, want the start and how to read on", first)
+	}
+	if got := tool.page(page, 5); got != "[Lines 1-4 skipped]\nline 5\nline 6" {
+		t.Errorf("page() from line 5 = %q", got)
+	}
+	if got := tool.page(page, 2); !strings.HasPrefix(got, "[Line 1 skipped]\nline 2\n[... lines 3-5 omitted ...]\nline 6\n") {
+		t.Errorf("page() from line 2 = %q, want omitted lines numbered from the top", got)
+	}
+	if got := tool.page(page, 9); got != "[The page has only 6 lines]" {
+		t.Errorf("page() past the end = %q", got)
+	}
+}
+
+func TestCodeSearchHidesDeniedRepos(t *testing.T) {
+	tool := &CodeSearchTool{cache: cache.NewMemoryCache(cache.NewNoOpCache(), 10, time.Hour)}
+	params := json.RawMessage(`{"owner": "acme", "query": "Login("}`)
+	if err := tool.cache.Set(tool.generateCacheKey(params), codeSearchResult{Total: 2, Matches: []github.CodeMatch{
+		{Repo: "acme/web", Path: "auth.go", Fragments: []string{"api.Login(user)"}},
+		{Repo: "acme/payments", Path: "pay.go", Fragments: []string{"api.Login(merchant)"}},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := withRepoAccess(context.Background(), func(owner, repo string) bool { return repo != "payments" })
+	result, err := tool.Execute(ctx, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(result, "acme/web auth.go\n    api.Login(user)") || strings.Contains(result, "payments") {
+		t.Errorf("result = %q, want only acme/web's match", result)
+	}
+}
@@ -0,0 +1,150 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// truncator fits tool results into a limit without losing their shape:
+// headings are kept to show what was left out, then as much of the beginning,
+// which usually says what the result is, and the end as fits. Omitted lines
+// are noted where they were, and a closing note tells the model how much it
+// saw and how to get the rest. The notes come on top of the limit.
+type truncator struct {
+	limit int    // 0 for no limit
+	chars bool   // The limit counts characters rather than bytes
+	hint  string // How to get the omitted lines, for the closing note
+	skip  int    // Lines before s, for numbering omitted lines from the top of a page
+}
+
+// truncate returns s, or the parts of it that fit
+func (t truncator) truncate(s string) string {
+	if t.limit <= 0 || t.size(s) <= t.limit {
+		return s
+	}
+
+	lines := strings.Split(s, "\n")
+	keep := make([]bool, len(lines))
+	budget := t.limit
+	take := func(i int) bool {
+		n := t.size(lines[i]) + 1
+		if n > budget {
+			return false
+		}
+		keep[i] = true
+		budget -= n
+		return true
+	}
+
+	// Headings get up to a quarter of the limit
+	headings := t.limit / 4
+	for i, line := range lines {
+		if n := t.size(line) + 1; isHeading(line) && n <= headings && take(i) {
+			headings -= n
+		}
+	}
+
+	// Then the beginning gets two thirds of what's left. A first line too long
+	// to fit, like a minified page, is cut short, to the whole limit if it's
+	// the only one.
+	head := budget * 2 / 3
+	cutShort := false
+	for i, line := range lines {
+		if keep[i] {
+			continue
+		}
+		if n := t.size(line) + 1; n > head {
+			if i == 0 {
+				if len(lines) == 1 {
+					head = budget
+				}
+				lines[0] = t.prefix(line, head-1)
+				cutShort = take(0)
+			}
+			break
+		}
+		head -= t.size(line) + 1
+		take(i)
+	}
+
+	// and the end the rest
+	for i := len(lines) - 1; i >= 0; i-- {
+		if !keep[i] && !take(i) {
+			break
+		}
+	}
+
+	var out strings.Builder
+	shown := 0
+	for i := 0; i < len(lines); {
+		if keep[i] {
+			out.WriteString(lines[i])
+			out.WriteByte('\n')
+			shown++
+			i++
+			continue
+		}
+		j := i
+		for j < len(lines) && !keep[j] {
+			j++
+		}
+		if j == i+1 {
+			fmt.Fprintf(&out, "[... line %d omitted ...]\n", t.skip+i+1)
+		} else {
+			fmt.Fprintf(&out, "[... lines %d-%d omitted ...]\n", t.skip+i+1, t.skip+j)
+		}
+		i = j
+	}
+	fmt.Fprintf(&out, "[Truncated to fit: %d of %d lines shown", shown, len(lines))
+	if cutShort {
+		fmt.Fprintf(&out, ", line %d cut short", t.skip+1)
+	}
+	out.WriteString(".")
+	if t.hint != "" {
+		out.WriteString(" " + t.hint)
+	}
+	out.WriteString("]")
+	return out.String()
+}
+
+// size measures s in the limit's unit
+func (t truncator) size(s string) int {
+	if t.chars {
+		return utf8.RuneCountInString(s)
+	}
+	return len(s)
+}
+
+// prefix returns the longest start of s that's at most n in the limit's
+// unit, ending on a character boundary
+func (t truncator) prefix(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	if t.chars {
+		for i := range s {
+			if n == 0 {
+				return s[:i]
+			}
+			n--
+		}
+		return s
+	}
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// isHeading reports whether a line starts a section: a markdown heading, or
+// a file or hunk of a diff
+func isHeading(line string) bool {
+	if rest := strings.TrimLeft(line, "#"); len(rest) < len(line) && len(line)-len(rest) <= 6 {
+		return strings.HasPrefix(rest, " ")
+	}
+	return strings.HasPrefix(line, "diff --git ") || strings.HasPrefix(line, "@@ ")
+}
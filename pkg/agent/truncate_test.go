@@ -0,0 +1,76 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	var page []string
+	page = append(page, "# Changelog", "")
+	for v := 10; v >= 1; v-- {
+		page = append(page, fmt.Sprintf("## v1.%d.0", v))
+		for i := range 5 {
+			page = append(page, fmt.Sprintf("- change %d in v1.%d", i, v))
+		}
+	}
+	text := strings.Join(page, "\n")
+
+	fit := truncator{limit: 500, hint: "Ask for more."}
+	got := fit.truncate(text)
+
+	// Every version's heading is kept, with the start and end of the page
+	for v := 1; v <= 10; v++ {
+		if !strings.Contains(got, fmt.Sprintf("## v1.%d.0\n", v)) {
+			t.Errorf("heading of v1.%d.0 dropped:\n%s", v, got)
+		}
+	}
+	if !strings.HasPrefix(got, "# Changelog\n\n## v1.10.0\n- change 0 in v1.10\n") {
+		t.Errorf("beginning not kept:\n%s", got)
+	}
+	if !strings.Contains(got, "- change 4 in v1.1\n[Truncated") {
+		t.Errorf("end not kept:\n%s", got)
+	}
+	if !strings.Contains(got, "omitted ...]") || !strings.HasSuffix(got, "lines shown. Ask for more.]") {
+		t.Errorf("truncation not reported:\n%s", got)
+	}
+	if len(got) >= len(text) {
+		t.Errorf("truncated to %d bytes of %d", len(got), len(text))
+	}
+
+	if got := fit.truncate("short"); got != "short" {
+		t.Errorf("truncate() of a result that fits = %q", got)
+	}
+	if got := (truncator{}).truncate(text); got != text {
+		t.Error("truncate() without a limit changed the result")
+	}
+}
+
+func TestTruncateLongLine(t *testing.T) {
+	// Characters, not bytes, and never through the middle of one
+	got := truncator{limit: 4, chars: true}.truncate("héllo wörld")
+	if got != "hél\n[Truncated to fit: 1 of 1 lines shown, line 1 cut short.]" {
+		t.Errorf("truncate() by characters = %q", got)
+	}
+	got = truncator{limit: 3}.truncate("héllo")
+	if got != "h\n[Truncated to fit: 1 of 1 lines shown, line 1 cut short.]" {
+		t.Errorf("truncate() by bytes = %q", got)
+	}
+}
+
+func TestIsHeading(t *testing.T) {
+	for line, want := range map[string]bool{
+		"# Title":                      true,
+		"### Fixes":                    true,
+		"#hashtag":                     false,
+		"####### Too deep":             false,
+		"diff --git a/go.mod b/go.mod": true,
+		"@@ -1,3 +1,4 @@":              true,
+		"- item":                       false,
+	} {
+		if got := isHeading(line); got != want {
+			t.Errorf("isHeading(%q) = %v, want %v", line, got, want)
+		}
+	}
+}
@@ -9,17 +9,31 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/kennyp/speedrun/pkg/metrics"
 	_ "modernc.org/sqlite"
 )
 
 // Ensure SQLiteCache implements Cache interface
 var _ Cache = (*SQLiteCache)(nil)
 
+// busyTimeout is how long a connection waits on a locked database before
+// giving up. Fetch goroutines (and a second speedrun instance sharing the same
+// cache file) routinely contend for the write lock for a few milliseconds.
+const busyTimeout = 5 * time.Second
+
 // SQLiteCache provides SQLite-based caching for GitHub data
 type SQLiteCache struct {
 	db     *sql.DB
 	maxAge time.Duration
 	dbPath string
+
+	// Identifies this connection to other speedrun instances sharing the file
+	instanceID string
+
+	// Prepared statements for the hot paths
+	getStmt    *sql.Stmt
+	setStmt    *sql.Stmt
+	deleteStmt *sql.Stmt
 }
 
 // CacheEntry represents a cached item
@@ -40,26 +54,36 @@ func New(dbPath string, maxAge time.Duration) (Cache, error) {
 		slog.Error("Failed to create cache directory", "dir", cacheDir, "error", err)
 		return nil, fmt.Errorf("failed to create cache directory: %w", err)
 	}
-	db, err := sql.Open("sqlite", dbPath)
+	db, err := sql.Open("sqlite", sqliteDSN(dbPath))
 	if err != nil {
 		slog.Error("Failed to open cache database", "path", dbPath, "error", err)
 		return nil, fmt.Errorf("failed to open cache database: %w", err)
 	}
 
 	cache := &SQLiteCache{
-		db:     db,
-		maxAge: maxAge,
-		dbPath: dbPath,
+		db:         db,
+		maxAge:     maxAge,
+		dbPath:     dbPath,
+		instanceID: newInstanceID(),
 	}
 
 	if err := cache.initialize(); err != nil {
-		_ = db.Close() // Ignore close error since we're already in error state
+		_ = cache.Close() // Ignore close error since we're already in error state
 		return nil, fmt.Errorf("failed to initialize cache: %w", err)
 	}
 
 	return cache, nil
 }
 
+// sqliteDSN builds a connection string that enables WAL journaling and a busy
+// timeout on every pooled connection. WAL lets readers proceed while a writer
+// holds the lock, and immediate transactions take the write lock up front so
+// the busy timeout applies instead of failing mid-transaction.
+func sqliteDSN(dbPath string) string {
+	return fmt.Sprintf("%s?_pragma=journal_mode(WAL)&_pragma=busy_timeout(%d)&_pragma=synchronous(NORMAL)&_txlock=immediate",
+		dbPath, busyTimeout.Milliseconds())
+}
+
 // initialize creates the cache table if it doesn't exist
 func (c *SQLiteCache) initialize() error {
 	query := `
@@ -76,6 +100,44 @@ func (c *SQLiteCache) initialize() error {
 	if _, err := c.db.Exec(query); err != nil {
 		return fmt.Errorf("failed to create cache table: %w", err)
 	}
+	if err := c.initializeCheckRuns(); err != nil {
+		return err
+	}
+	if err := c.initializeContent(); err != nil {
+		return err
+	}
+	if err := c.initializeInstances(); err != nil {
+		return err
+	}
+
+	return c.prepare()
+}
+
+// prepare compiles the statements used on every cache read and write
+func (c *SQLiteCache) prepare() error {
+	var err error
+
+	c.getStmt, err = c.db.Prepare(`
+		SELECT data, expires_at 
+		FROM cache_entries 
+		WHERE key = ? AND expires_at > datetime('now')
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare get statement: %w", err)
+	}
+
+	c.setStmt, err = c.db.Prepare(`
+		INSERT OR REPLACE INTO cache_entries (key, data, created_at, expires_at)
+		VALUES (?, ?, ?, ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare set statement: %w", err)
+	}
+
+	c.deleteStmt, err = c.db.Prepare(`DELETE FROM cache_entries WHERE key = ?`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare delete statement: %w", err)
+	}
 
 	return nil
 }
@@ -83,20 +145,16 @@ func (c *SQLiteCache) initialize() error {
 // Get retrieves a cached value by key
 func (c *SQLiteCache) Get(key string, dest interface{}) error {
 	start := time.Now()
-	query := `
-		SELECT data, expires_at 
-		FROM cache_entries 
-		WHERE key = ? AND expires_at > datetime('now')
-	`
 
 	var data []byte
 	var expiresAt string
 
-	err := c.db.QueryRow(query, key).Scan(&data, &expiresAt)
+	err := c.getStmt.QueryRow(key).Scan(&data, &expiresAt)
 	duration := time.Since(start)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
+			metrics.CacheLookups.Inc("sqlite", "miss")
 			slog.Debug("Cache miss", slog.String("key", key), slog.Duration("duration", duration))
 			return ErrCacheMiss
 		}
@@ -109,6 +167,7 @@ func (c *SQLiteCache) Get(key string, dest interface{}) error {
 		return fmt.Errorf("failed to unmarshal cached data: %w", err)
 	}
 
+	metrics.CacheLookups.Inc("sqlite", "hit")
 	slog.Debug("Cache hit", slog.String("key", key), slog.Duration("duration", duration), slog.Int("data_size", len(data)))
 	return nil
 }
@@ -125,12 +184,7 @@ func (c *SQLiteCache) Set(key string, value interface{}) error {
 	now := time.Now()
 	expiresAt := now.Add(c.maxAge)
 
-	query := `
-		INSERT OR REPLACE INTO cache_entries (key, data, created_at, expires_at)
-		VALUES (?, ?, ?, ?)
-	`
-
-	if _, err := c.db.Exec(query, key, data, now, expiresAt); err != nil {
+	if _, err := c.setStmt.Exec(key, data, now, expiresAt); err != nil {
 		duration := time.Since(start)
 		slog.Debug("Cache set failed", slog.String("key", key), slog.Duration("duration", duration), slog.Any("error", err))
 		return fmt.Errorf("failed to set cache entry: %w", err)
@@ -144,9 +198,8 @@ func (c *SQLiteCache) Set(key string, value interface{}) error {
 // Delete removes a cache entry by key
 func (c *SQLiteCache) Delete(key string) error {
 	start := time.Now()
-	query := `DELETE FROM cache_entries WHERE key = ?`
 
-	if _, err := c.db.Exec(query, key); err != nil {
+	if _, err := c.deleteStmt.Exec(key); err != nil {
 		duration := time.Since(start)
 		slog.Debug("Cache delete failed", slog.String("key", key), slog.Duration("duration", duration), slog.Any("error", err))
 		return fmt.Errorf("failed to delete cache entry: %w", err)
@@ -168,9 +221,10 @@ func (c *SQLiteCache) Clear() error {
 	return nil
 }
 
-// Cleanup removes expired entries from the cache
+// Cleanup removes entries that expired over a day ago. Recently expired ones
+// are kept for GetStale, to show at startup while fresh data loads.
 func (c *SQLiteCache) Cleanup() error {
-	query := `DELETE FROM cache_entries WHERE expires_at <= datetime('now')`
+	query := `DELETE FROM cache_entries WHERE expires_at <= datetime('now', '-1 day')`
 
 	result, err := c.db.Exec(query)
 	if err != nil {
@@ -213,8 +267,19 @@ func (c *SQLiteCache) Stats() (*CacheStats, error) {
 	}, nil
 }
 
-// Close closes the cache database connection
+// Close removes this instance from those sharing the cache, then closes the
+// prepared statements and the cache database connection
 func (c *SQLiteCache) Close() error {
+	if c.db != nil {
+		if err := c.leave(); err != nil {
+			slog.Debug("Failed to leave cache", "error", err)
+		}
+	}
+	for _, stmt := range []*sql.Stmt{c.getStmt, c.setStmt, c.deleteStmt} {
+		if stmt != nil {
+			_ = stmt.Close() // The connection close below reports anything that matters
+		}
+	}
 	if c.db != nil {
 		return c.db.Close()
 	}
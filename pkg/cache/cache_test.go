@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSQLiteCacheConcurrentAccess(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+
+	// Two handles on the same file stand in for two speedrun instances
+	first, err := New(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+	second, err := New(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Close()
+
+	var journal string
+	if err := first.(*SQLiteCache).db.QueryRow("PRAGMA journal_mode").Scan(&journal); err != nil {
+		t.Fatal(err)
+	}
+	if journal != "wal" {
+		t.Errorf("journal_mode = %q, want wal", journal)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 200)
+	for i := range 100 {
+		c := first
+		if i%2 == 1 {
+			c = second
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			key := fmt.Sprintf("k%d", i%10)
+			if err := c.Set(key, i); err != nil {
+				errs <- err
+				return
+			}
+			var v int
+			if err := c.Get(key, &v); err != nil && err != ErrCacheMiss {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("concurrent access: %v", err)
+	}
+}
+
+func TestSQLiteCacheGetStale(t *testing.T) {
+	c, err := New(filepath.Join(t.TempDir(), "cache.db"), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	sqlite := c.(*SQLiteCache)
+
+	var value string
+	if _, err := sqlite.GetStale("missing", &value); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("GetStale(missing) error = %v, want ErrCacheMiss", err)
+	}
+
+	// An entry cached two hours ago has expired, but is still there
+	cachedAt := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	if _, err := sqlite.setStmt.Exec("old", []byte(`"value"`), cachedAt, cachedAt.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get("old", &value); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Get(old) error = %v, want ErrCacheMiss", err)
+	}
+
+	at, err := sqlite.GetStale("old", &value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "value" || !at.Equal(cachedAt) {
+		t.Errorf("GetStale(old) = %q at %v, want %q at %v", value, at, "value", cachedAt)
+	}
+
+	// The memory cache reads stale entries through to its backend
+	memory := NewMemoryCache(c, 10, time.Hour).(StaleReader)
+	value = ""
+	if _, err := memory.GetStale("old", &value); err != nil || value != "value" {
+		t.Errorf("memory GetStale(old) = %q, %v", value, err)
+	}
+}
+
+func TestSQLiteCacheCleanupKeepsRecentlyExpired(t *testing.T) {
+	c, err := New(filepath.Join(t.TempDir(), "cache.db"), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	sqlite := c.(*SQLiteCache)
+
+	now := time.Now()
+	for key, expired := range map[string]time.Duration{"recent": 2 * time.Hour, "old": 48 * time.Hour} {
+		if _, err := sqlite.setStmt.Exec(key, []byte(`"value"`), now.Add(-expired-time.Hour), now.Add(-expired)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.Cleanup(); err != nil {
+		t.Fatal(err)
+	}
+
+	var value string
+	if _, err := sqlite.GetStale("recent", &value); err != nil {
+		t.Errorf("Cleanup removed an entry that expired 2h ago: %v", err)
+	}
+	if _, err := sqlite.GetStale("old", &value); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("GetStale(old) after Cleanup error = %v, want ErrCacheMiss", err)
+	}
+}
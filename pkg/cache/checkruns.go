@@ -0,0 +1,166 @@
+package cache
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// Ensure the SQLite and memory caches keep check history
+var (
+	_ CheckHistory = (*SQLiteCache)(nil)
+	_ CheckHistory = (*MemoryCache)(nil)
+)
+
+// CheckRun is one outcome of a CI check on a commit. Reruns of a check on
+// the same commit are separate runs.
+type CheckRun struct {
+	ID     int64 // Check run ID, so seeing a run again doesn't count it twice
+	Repo   string
+	Name   string
+	SHA    string
+	Passed bool
+	At     time.Time
+}
+
+// CheckStats sums up a check's history in a repository
+type CheckStats struct {
+	Failures      int // Commits where the check failed at least once
+	PassedOnRerun int // Of those, commits where a later run passed
+}
+
+// CheckHistory records CI check outcomes across sessions. Unlike cache
+// entries, runs don't expire with the cache's max age.
+type CheckHistory interface {
+	RecordCheckRuns(runs []CheckRun) error
+	CheckStats(repo string, since time.Time) (map[string]CheckStats, error)
+}
+
+// initializeCheckRuns creates the check history table
+func (c *SQLiteCache) initializeCheckRuns() error {
+	query := `
+		CREATE TABLE IF NOT EXISTS check_runs (
+			id INTEGER PRIMARY KEY,
+			repo TEXT NOT NULL,
+			name TEXT NOT NULL,
+			sha TEXT NOT NULL,
+			passed INTEGER NOT NULL,
+			at INTEGER NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_check_runs_repo_at ON check_runs(repo, at);
+	`
+	if _, err := c.db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create check runs table: %w", err)
+	}
+	return nil
+}
+
+// RecordCheckRuns stores finished check runs, ignoring ones already stored
+func (c *SQLiteCache) RecordCheckRuns(runs []CheckRun) error {
+	if len(runs) == 0 {
+		return nil
+	}
+
+	tx, err := c.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin check runs transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback() // No-op once committed
+	}()
+
+	for _, run := range runs {
+		if _, err := tx.Exec(`
+			INSERT OR IGNORE INTO check_runs (id, repo, name, sha, passed, at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, run.ID, run.Repo, run.Name, run.SHA, run.Passed, run.At.UnixMilli()); err != nil {
+			return fmt.Errorf("failed to record check run: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit check runs: %w", err)
+	}
+
+	slog.Debug("Recorded check runs", slog.Int("count", len(runs)))
+	return nil
+}
+
+// CheckStats returns each check's history in repo since the given time
+func (c *SQLiteCache) CheckStats(repo string, since time.Time) (map[string]CheckStats, error) {
+	rows, err := c.db.Query(`
+		SELECT id, name, sha, passed, at FROM check_runs
+		WHERE repo = ? AND at >= ?
+	`, repo, since.UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query check runs: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Debug("Failed to close check run rows", slog.Any("error", err))
+		}
+	}()
+
+	var runs []CheckRun
+	for rows.Next() {
+		run := CheckRun{Repo: repo}
+		var at int64
+		if err := rows.Scan(&run.ID, &run.Name, &run.SHA, &run.Passed, &at); err != nil {
+			return nil, fmt.Errorf("failed to read check run: %w", err)
+		}
+		run.At = time.UnixMilli(at)
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read check runs: %w", err)
+	}
+
+	return SummarizeCheckRuns(runs), nil
+}
+
+// SummarizeCheckRuns counts, per check name, the commits where it failed and
+// those where it then passed on a rerun
+func SummarizeCheckRuns(runs []CheckRun) map[string]CheckStats {
+	runs = append([]CheckRun(nil), runs...)
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].At.Before(runs[j].At) })
+
+	type commit struct{ name, sha string }
+	failed := make(map[commit]bool)
+	rerunPassed := make(map[commit]bool)
+	for _, run := range runs {
+		key := commit{run.Name, run.SHA}
+		if !run.Passed {
+			failed[key] = true
+		} else if failed[key] {
+			rerunPassed[key] = true
+		}
+	}
+
+	stats := make(map[string]CheckStats)
+	for key := range failed {
+		s := stats[key.name]
+		s.Failures++
+		if rerunPassed[key] {
+			s.PassedOnRerun++
+		}
+		stats[key.name] = s
+	}
+	return stats
+}
+
+// RecordCheckRuns passes check runs through to the backend, if it keeps them
+func (c *MemoryCache) RecordCheckRuns(runs []CheckRun) error {
+	if history, ok := c.backend.(CheckHistory); ok {
+		return history.RecordCheckRuns(runs)
+	}
+	return nil
+}
+
+// CheckStats reads check history from the backend, if it keeps it
+func (c *MemoryCache) CheckStats(repo string, since time.Time) (map[string]CheckStats, error) {
+	if history, ok := c.backend.(CheckHistory); ok {
+		return history.CheckStats(repo, since)
+	}
+	return nil, nil
+}
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSummarizeCheckRuns(t *testing.T) {
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	runs := []CheckRun{
+		// Passed on rerun, listed out of order
+		{ID: 2, Name: "test", SHA: "a", Passed: true, At: at.Add(time.Minute)},
+		{ID: 1, Name: "test", SHA: "a", Passed: false, At: at},
+		// Failed twice, never passed
+		{ID: 3, Name: "test", SHA: "b", Passed: false, At: at},
+		{ID: 4, Name: "test", SHA: "b", Passed: false, At: at.Add(time.Minute)},
+		// Passed first, so the later failure isn't a rerun pass
+		{ID: 5, Name: "lint", SHA: "a", Passed: true, At: at},
+		{ID: 6, Name: "lint", SHA: "a", Passed: false, At: at.Add(time.Minute)},
+		{ID: 7, Name: "build", SHA: "a", Passed: true, At: at},
+	}
+
+	stats := SummarizeCheckRuns(runs)
+	if got := stats["test"]; got != (CheckStats{Failures: 2, PassedOnRerun: 1}) {
+		t.Errorf("test = %+v", got)
+	}
+	if got := stats["lint"]; got != (CheckStats{Failures: 1}) {
+		t.Errorf("lint = %+v", got)
+	}
+	if _, ok := stats["build"]; ok {
+		t.Error("build never failed but has stats")
+	}
+}
+
+func TestSQLiteCheckHistory(t *testing.T) {
+	c, err := New(filepath.Join(t.TempDir(), "cache.db"), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	history := c.(CheckHistory)
+
+	now := time.Now()
+	runs := []CheckRun{
+		{ID: 1, Repo: "acme/app", Name: "test", SHA: "a", Passed: false, At: now.Add(-2 * time.Minute)},
+		{ID: 2, Repo: "acme/app", Name: "test", SHA: "a", Passed: true, At: now.Add(-time.Minute)},
+		{ID: 3, Repo: "acme/api", Name: "test", SHA: "c", Passed: false, At: now},
+		{ID: 4, Repo: "acme/app", Name: "test", SHA: "old", Passed: false, At: now.Add(-48 * time.Hour)},
+	}
+	// Seeing the same runs again doesn't count them twice
+	for range 2 {
+		if err := history.RecordCheckRuns(runs); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stats, err := history.CheckStats("acme/app", now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := stats["test"]; got != (CheckStats{Failures: 1, PassedOnRerun: 1}) {
+		t.Errorf("stats = %+v", got)
+	}
+
+	// The memory cache reads through to the database
+	memory := NewMemoryCache(c, 10, time.Hour).(CheckHistory)
+	if stats, err := memory.CheckStats("acme/api", time.Time{}); err != nil || stats["test"].Failures != 1 {
+		t.Errorf("memory CheckStats() = %+v, %v", stats, err)
+	}
+}
@@ -0,0 +1,175 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Ensure the SQLite and memory caches keep fetched content
+var (
+	_ ContentStore = (*SQLiteCache)(nil)
+	_ ContentStore = (*MemoryCache)(nil)
+)
+
+// ContentLimits bounds the content store
+type ContentLimits struct {
+	MaxAge   time.Duration // How long stored content is served
+	MaxBytes int64         // Total size of stored content, least recently used goes first
+}
+
+// ContentStore keeps fetched upstream content that doesn't change, such as
+// the release notes of a tagged version, for longer than cache entries live.
+// Content is stored once per hash however many URLs serve it.
+type ContentStore interface {
+	Content(url string, maxAge time.Duration) (string, error)
+	StoreContent(url, content string, limits ContentLimits) error
+}
+
+// initializeContent creates the content store tables
+func (c *SQLiteCache) initializeContent() error {
+	query := `
+		CREATE TABLE IF NOT EXISTS content_blobs (
+			hash TEXT PRIMARY KEY,
+			data TEXT NOT NULL,
+			size INTEGER NOT NULL,
+			used_at INTEGER NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS content_urls (
+			url TEXT PRIMARY KEY,
+			hash TEXT NOT NULL,
+			stored_at INTEGER NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_content_blobs_used_at ON content_blobs(used_at);
+	`
+	if _, err := c.db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create content tables: %w", err)
+	}
+	return nil
+}
+
+// Content returns what was stored for url within maxAge, or ErrCacheMiss
+func (c *SQLiteCache) Content(url string, maxAge time.Duration) (string, error) {
+	var hash, data string
+	err := c.db.QueryRow(`
+		SELECT b.hash, b.data FROM content_urls u
+		JOIN content_blobs b ON b.hash = u.hash
+		WHERE u.url = ? AND u.stored_at >= ?
+	`, url, time.Now().Add(-maxAge).UnixMilli()).Scan(&hash, &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrCacheMiss
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read content: %w", err)
+	}
+
+	// Recently read content is the last to be evicted
+	if _, err := c.db.Exec(`UPDATE content_blobs SET used_at = ? WHERE hash = ?`, time.Now().UnixMilli(), hash); err != nil {
+		slog.Debug("Failed to mark content used", slog.String("url", url), slog.Any("error", err))
+	}
+	return data, nil
+}
+
+// StoreContent stores content fetched from url, then drops expired URLs and
+// the least recently used content beyond limits.MaxBytes
+func (c *SQLiteCache) StoreContent(url, content string, limits ContentLimits) error {
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+	now := time.Now()
+
+	tx, err := c.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin content transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback() // No-op once committed
+	}()
+
+	if _, err := tx.Exec(`
+		INSERT INTO content_blobs (hash, data, size, used_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT(hash) DO UPDATE SET used_at = excluded.used_at
+	`, hash, content, len(content), now.UnixMilli()); err != nil {
+		return fmt.Errorf("failed to store content: %w", err)
+	}
+	if _, err := tx.Exec(`
+		INSERT OR REPLACE INTO content_urls (url, hash, stored_at) VALUES (?, ?, ?)
+	`, url, hash, now.UnixMilli()); err != nil {
+		return fmt.Errorf("failed to store content URL: %w", err)
+	}
+
+	if err := evictContent(tx, now, limits); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit content: %w", err)
+	}
+	return nil
+}
+
+// evictContent enforces limits on the content store
+func evictContent(tx *sql.Tx, now time.Time, limits ContentLimits) error {
+	if _, err := tx.Exec(`DELETE FROM content_urls WHERE stored_at < ?`, now.Add(-limits.MaxAge).UnixMilli()); err != nil {
+		return fmt.Errorf("failed to expire content URLs: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM content_blobs WHERE hash NOT IN (SELECT hash FROM content_urls)`); err != nil {
+		return fmt.Errorf("failed to drop unreferenced content: %w", err)
+	}
+
+	var total int64
+	if err := tx.QueryRow(`SELECT COALESCE(SUM(size), 0) FROM content_blobs`).Scan(&total); err != nil {
+		return fmt.Errorf("failed to size content store: %w", err)
+	}
+	if total <= limits.MaxBytes {
+		return nil
+	}
+
+	rows, err := tx.Query(`SELECT hash, size FROM content_blobs ORDER BY used_at`)
+	if err != nil {
+		return fmt.Errorf("failed to list content: %w", err)
+	}
+	var evict []string
+	for rows.Next() && total > limits.MaxBytes {
+		var hash string
+		var size int64
+		if err := rows.Scan(&hash, &size); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to read content: %w", err)
+		}
+		evict = append(evict, hash)
+		total -= size
+	}
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("failed to list content: %w", err)
+	}
+
+	for _, hash := range evict {
+		if _, err := tx.Exec(`DELETE FROM content_blobs WHERE hash = ?`, hash); err != nil {
+			return fmt.Errorf("failed to evict content: %w", err)
+		}
+		if _, err := tx.Exec(`DELETE FROM content_urls WHERE hash = ?`, hash); err != nil {
+			return fmt.Errorf("failed to evict content URLs: %w", err)
+		}
+	}
+	slog.Debug("Evicted content", slog.Int("count", len(evict)))
+	return nil
+}
+
+// Content reads from the backend's content store, if it keeps one
+func (c *MemoryCache) Content(url string, maxAge time.Duration) (string, error) {
+	if store, ok := c.backend.(ContentStore); ok {
+		return store.Content(url, maxAge)
+	}
+	return "", ErrCacheMiss
+}
+
+// StoreContent passes content through to the backend, if it keeps it
+func (c *MemoryCache) StoreContent(url, content string, limits ContentLimits) error {
+	if store, ok := c.backend.(ContentStore); ok {
+		return store.StoreContent(url, content, limits)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSQLiteContentStore(t *testing.T) {
+	c, err := New(filepath.Join(t.TempDir(), "cache.db"), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	store := c.(ContentStore)
+	limits := ContentLimits{MaxAge: time.Hour, MaxBytes: 10}
+
+	put := func(url, content string) {
+		t.Helper()
+		if err := store.StoreContent(url, content, limits); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(2 * time.Millisecond) // Distinct use times
+	}
+	get := func(url string) string {
+		t.Helper()
+		got, err := store.Content(url, time.Hour)
+		if errors.Is(err, ErrCacheMiss) {
+			return ""
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(2 * time.Millisecond)
+		return got
+	}
+
+	// The same content from two URLs is stored once
+	put("https://example.com/a", "12345")
+	put("https://mirror.example.com/a", "12345")
+	put("https://example.com/b", "67890")
+	if got := get("https://mirror.example.com/a"); got != "12345" {
+		t.Errorf("Content(mirror) = %q", got)
+	}
+
+	// Over the size limit, b goes first since a was read more recently
+	put("https://example.com/c", "xyz")
+	if got := get("https://example.com/b"); got != "" {
+		t.Errorf("Content(b) = %q, want it evicted", got)
+	}
+	if get("https://example.com/a") != "12345" || get("https://example.com/c") != "xyz" {
+		t.Error("recently used content was evicted")
+	}
+
+	if _, err := store.Content("https://example.com/c", time.Millisecond); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Content() past max age = %v, want a miss", err)
+	}
+}
@@ -0,0 +1,174 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Ensure the SQLite and memory caches coordinate the instances sharing them
+var (
+	_ Coordinator = (*SQLiteCache)(nil)
+	_ Coordinator = (*MemoryCache)(nil)
+)
+
+// InstanceHeartbeat is how often a running instance should call Heartbeat.
+// An instance that hasn't for InstanceTTL is taken to have gone.
+const (
+	InstanceHeartbeat = 20 * time.Second
+	InstanceTTL       = time.Minute
+)
+
+// Instance is a speedrun process sharing the cache
+type Instance struct {
+	PID       int
+	Host      string
+	StartedAt time.Time
+}
+
+// Coordinator lets speedrun processes sharing a cache see each other and
+// split up work, such as AI analyses, that shouldn't be done twice
+type Coordinator interface {
+	// Heartbeat records that this instance is still running and returns the
+	// other instances that are
+	Heartbeat() ([]Instance, error)
+	// Claim takes key for this instance for ttl and reports whether it did.
+	// It fails while another instance holds an unexpired claim.
+	Claim(key string, ttl time.Duration) (bool, error)
+	// Release gives up this instance's claim on key
+	Release(key string) error
+}
+
+// newInstanceID identifies this cache connection among those sharing the file
+func newInstanceID() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d:%d", host, os.Getpid(), time.Now().UnixNano())
+}
+
+// initializeInstances creates the instance and claim tables
+func (c *SQLiteCache) initializeInstances() error {
+	query := `
+		CREATE TABLE IF NOT EXISTS instances (
+			id TEXT PRIMARY KEY,
+			pid INTEGER NOT NULL,
+			host TEXT NOT NULL,
+			started_at INTEGER NOT NULL,
+			heartbeat_at INTEGER NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS claims (
+			key TEXT PRIMARY KEY,
+			owner TEXT NOT NULL,
+			expires_at INTEGER NOT NULL
+		);
+	`
+	if _, err := c.db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create instance tables: %w", err)
+	}
+	return nil
+}
+
+// Heartbeat records this instance and lists the others seen within InstanceTTL
+func (c *SQLiteCache) Heartbeat() ([]Instance, error) {
+	now := time.Now()
+	host, _ := os.Hostname()
+	if _, err := c.db.Exec(`
+		INSERT INTO instances (id, pid, host, started_at, heartbeat_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET heartbeat_at = excluded.heartbeat_at
+	`, c.instanceID, os.Getpid(), host, now.Unix(), now.Unix()); err != nil {
+		return nil, fmt.Errorf("failed to record heartbeat: %w", err)
+	}
+
+	// Instances that stopped without saying so are forgotten once they're stale
+	cutoff := now.Add(-InstanceTTL).Unix()
+	if _, err := c.db.Exec(`DELETE FROM instances WHERE heartbeat_at < ?`, cutoff); err != nil {
+		return nil, fmt.Errorf("failed to remove stale instances: %w", err)
+	}
+
+	rows, err := c.db.Query(`
+		SELECT pid, host, started_at FROM instances
+		WHERE id != ?
+		ORDER BY started_at
+	`, c.instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list instances: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var others []Instance
+	for rows.Next() {
+		var inst Instance
+		var started int64
+		if err := rows.Scan(&inst.PID, &inst.Host, &started); err != nil {
+			return nil, fmt.Errorf("failed to scan instance: %w", err)
+		}
+		inst.StartedAt = time.Unix(started, 0)
+		others = append(others, inst)
+	}
+	return others, rows.Err()
+}
+
+// Claim takes key unless another instance holds it. Taking a key this
+// instance already holds extends its claim.
+func (c *SQLiteCache) Claim(key string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	result, err := c.db.Exec(`
+		INSERT INTO claims (key, owner, expires_at) VALUES (?, ?, ?)
+		ON CONFLICT(key) DO UPDATE SET owner = excluded.owner, expires_at = excluded.expires_at
+		WHERE claims.owner = excluded.owner OR claims.expires_at <= ?
+	`, key, c.instanceID, now.Add(ttl).Unix(), now.Unix())
+	if err != nil {
+		return false, fmt.Errorf("failed to claim %s: %w", key, err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim %s: %w", key, err)
+	}
+	return n > 0, nil
+}
+
+// Release drops this instance's claim on key
+func (c *SQLiteCache) Release(key string) error {
+	if _, err := c.db.Exec(`DELETE FROM claims WHERE key = ? AND owner = ?`, key, c.instanceID); err != nil {
+		return fmt.Errorf("failed to release %s: %w", key, err)
+	}
+	return nil
+}
+
+// leave removes this instance and its claims, so others needn't wait for them
+// to expire
+func (c *SQLiteCache) leave() error {
+	if _, err := c.db.Exec(`DELETE FROM instances WHERE id = ?`, c.instanceID); err != nil {
+		return fmt.Errorf("failed to remove instance: %w", err)
+	}
+	if _, err := c.db.Exec(`DELETE FROM claims WHERE owner = ?`, c.instanceID); err != nil {
+		return fmt.Errorf("failed to release claims: %w", err)
+	}
+	return nil
+}
+
+// Heartbeat passes through to the backend, if it coordinates instances
+func (c *MemoryCache) Heartbeat() ([]Instance, error) {
+	if coord, ok := c.backend.(Coordinator); ok {
+		return coord.Heartbeat()
+	}
+	return nil, nil
+}
+
+// Claim passes through to the backend. Without one that coordinates, every
+// claim succeeds.
+func (c *MemoryCache) Claim(key string, ttl time.Duration) (bool, error) {
+	if coord, ok := c.backend.(Coordinator); ok {
+		return coord.Claim(key, ttl)
+	}
+	return true, nil
+}
+
+// Release passes through to the backend, if it coordinates instances
+func (c *MemoryCache) Release(key string) error {
+	if coord, ok := c.backend.(Coordinator); ok {
+		return coord.Release(key)
+	}
+	return nil
+}
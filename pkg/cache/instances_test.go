@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSQLiteCoordinator(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+	a, err := New(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close()
+	b, err := New(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+	coordA, coordB := a.(Coordinator), b.(Coordinator)
+
+	if others, err := coordA.Heartbeat(); err != nil || len(others) != 0 {
+		t.Fatalf("first Heartbeat() = %+v, %v; want no others", others, err)
+	}
+	others, err := coordB.Heartbeat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(others) != 1 || others[0].PID == 0 {
+		t.Fatalf("second Heartbeat() = %+v, want the first instance", others)
+	}
+
+	if ok, err := coordA.Claim("pr", time.Minute); err != nil || !ok {
+		t.Fatalf("Claim() = %v, %v; want the claim", ok, err)
+	}
+	if ok, _ := coordA.Claim("pr", time.Minute); !ok {
+		t.Error("claim holder couldn't extend its claim")
+	}
+	if ok, _ := coordB.Claim("pr", time.Minute); ok {
+		t.Error("second instance took a held claim")
+	}
+	if err := coordA.Release("pr"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := coordB.Claim("pr", time.Minute); !ok {
+		t.Error("second instance couldn't take a released claim")
+	}
+
+	// An expired claim can be taken over
+	if ok, _ := coordB.Claim("expired", -time.Second); !ok {
+		t.Fatal("couldn't claim expired")
+	}
+	if ok, _ := coordA.Claim("expired", time.Minute); !ok {
+		t.Error("couldn't take over an expired claim")
+	}
+
+	// Closing leaves, releasing claims
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if others, _ := coordA.Heartbeat(); len(others) != 0 {
+		t.Errorf("Heartbeat() after the other closed = %+v", others)
+	}
+	if ok, _ := coordA.Claim("pr", time.Minute); !ok {
+		t.Error("claim of a closed instance wasn't released")
+	}
+}
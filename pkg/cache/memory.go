@@ -0,0 +1,246 @@
+package cache
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/metrics"
+)
+
+// Ensure MemoryCache implements Cache interface
+var _ Cache = (*MemoryCache)(nil)
+
+// memoryTTL bounds how long an entry may be served from memory. It only needs
+// to cover a burst of filtering/refresh reads, and keeping it short means a
+// promoted backend row can never outlive the backend's own max age by much.
+const memoryTTL = 5 * time.Minute
+
+// MemoryCache is an in-process LRU that sits in front of another Cache.
+// Hot keys (reviews, check status) are read repeatedly while filtering and
+// refreshing, so keeping them in memory avoids a database round trip per
+// keystroke on large queues.
+type MemoryCache struct {
+	backend  Cache
+	capacity int
+	ttl      time.Duration
+
+	mu       sync.Mutex
+	entries  map[string]*list.Element
+	order    *list.List // front is most recently used
+	inflight map[string]*inflightKey
+}
+
+// memoryEntry is a single LRU slot. Values are stored JSON-encoded so callers
+// always receive their own copy, matching the semantics of the SQLite cache.
+type memoryEntry struct {
+	key       string
+	data      []byte
+	expiresAt time.Time
+}
+
+// inflightKey tracks backend operations in progress for a key. Writes bump
+// gen, and an operation may only populate memory if gen is unchanged since it
+// started, so a slow read can't resurrect a value that was deleted or
+// overwritten underneath it.
+type inflightKey struct {
+	refs int
+	gen  uint64
+}
+
+// NewMemoryCache wraps backend with an LRU holding up to capacity entries
+func NewMemoryCache(backend Cache, capacity int, maxAge time.Duration) Cache {
+	ttl := min(memoryTTL, maxAge)
+	slog.Debug("Creating memory cache", "capacity", capacity, "ttl", ttl)
+
+	return &MemoryCache{
+		backend:  backend,
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		inflight: make(map[string]*inflightKey),
+	}
+}
+
+// Get retrieves a value from memory, falling back to the backend on a miss
+func (c *MemoryCache) Get(key string, dest interface{}) error {
+	if data, ok := c.lookup(key); ok {
+		if err := json.Unmarshal(data, dest); err != nil {
+			return fmt.Errorf("failed to unmarshal cached data: %w", err)
+		}
+		metrics.CacheLookups.Inc("memory", "hit")
+		slog.Debug("Memory cache hit", slog.String("key", key))
+		return nil
+	}
+	metrics.CacheLookups.Inc("memory", "miss")
+
+	gen := c.begin(key, false)
+	if err := c.backend.Get(key, dest); err != nil {
+		c.finish(key, gen, nil, false)
+		return err
+	}
+
+	// Promote the backend hit so the next read stays in memory
+	data, err := json.Marshal(dest)
+	if err != nil {
+		data = nil
+	}
+	c.finish(key, gen, data, false)
+	return nil
+}
+
+// Set writes through to the backend and then updates memory
+func (c *MemoryCache) Set(key string, value interface{}) error {
+	gen := c.begin(key, true)
+	if err := c.backend.Set(key, value); err != nil {
+		c.finish(key, gen, nil, true)
+		return err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		c.finish(key, gen, nil, true)
+		return fmt.Errorf("failed to marshal cache data: %w", err)
+	}
+	c.finish(key, gen, data, true)
+	return nil
+}
+
+// Delete removes a key from memory and the backend
+func (c *MemoryCache) Delete(key string) error {
+	gen := c.begin(key, true)
+	defer c.finish(key, gen, nil, true)
+
+	return c.backend.Delete(key)
+}
+
+// Cleanup drops expired in-memory entries and cleans up the backend
+func (c *MemoryCache) Cleanup() error {
+	now := time.Now()
+
+	c.mu.Lock()
+	for el := c.order.Back(); el != nil; {
+		prev := el.Prev()
+		if entry := el.Value.(*memoryEntry); !now.Before(entry.expiresAt) {
+			c.removeElement(el)
+		}
+		el = prev
+	}
+	c.mu.Unlock()
+
+	return c.backend.Cleanup()
+}
+
+// Close releases memory and closes the backend
+func (c *MemoryCache) Close() error {
+	c.mu.Lock()
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+	c.mu.Unlock()
+
+	return c.backend.Close()
+}
+
+// lookup returns the raw data for key if present and not expired
+func (c *MemoryCache) lookup(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*memoryEntry)
+	if !time.Now().Before(entry.expiresAt) {
+		c.removeElement(el)
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+	return entry.data, true
+}
+
+// begin registers a backend operation on key and returns its generation.
+// Writes drop the in-memory copy and invalidate every operation already in
+// flight for the key.
+func (c *MemoryCache) begin(key string, write bool) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f, ok := c.inflight[key]
+	if !ok {
+		f = &inflightKey{}
+		c.inflight[key] = f
+	}
+	f.refs++
+
+	if write {
+		f.gen++
+		c.removeKey(key)
+	}
+	return f.gen
+}
+
+// finish completes an operation started with begin. data is stored only if no
+// write raced with the operation; a finished write also invalidates reads that
+// started while it was running, since they may have seen the old value.
+func (c *MemoryCache) finish(key string, gen uint64, data []byte, write bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f := c.inflight[key]
+	if f.gen == gen && data != nil {
+		c.storeLocked(key, data)
+	} else if write {
+		c.removeKey(key)
+	}
+	if write {
+		f.gen++
+	}
+
+	f.refs--
+	if f.refs == 0 {
+		delete(c.inflight, key)
+	}
+}
+
+// storeLocked inserts or refreshes key, evicting the least recently used
+// entry when full; callers must hold c.mu
+func (c *MemoryCache) storeLocked(key string, data []byte) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	expiresAt := time.Now().Add(c.ttl)
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*memoryEntry)
+		entry.data = data
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&memoryEntry{key: key, data: data, expiresAt: expiresAt})
+
+	for c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// removeKey drops key from the LRU if present; callers must hold c.mu
+func (c *MemoryCache) removeKey(key string) {
+	if el, ok := c.entries[key]; ok {
+		c.removeElement(el)
+	}
+}
+
+// removeElement unlinks el from the LRU; callers must hold c.mu
+func (c *MemoryCache) removeElement(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*memoryEntry).key)
+}
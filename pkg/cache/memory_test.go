@@ -0,0 +1,237 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeCache is a map-backed Cache that counts reads and can run a hook in the
+// middle of Get to simulate a concurrent writer.
+type fakeCache struct {
+	data    map[string][]byte
+	gets    int
+	setErr  error
+	onGet   func()
+	cleaned bool
+}
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{data: make(map[string][]byte)}
+}
+
+func (f *fakeCache) Get(key string, dest interface{}) error {
+	f.gets++
+	data, ok := f.data[key]
+	if hook := f.onGet; hook != nil {
+		f.onGet = nil
+		hook()
+	}
+	if !ok {
+		return ErrCacheMiss
+	}
+	return json.Unmarshal(data, dest)
+}
+
+func (f *fakeCache) Set(key string, value interface{}) error {
+	if f.setErr != nil {
+		return f.setErr
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	f.data[key] = data
+	return nil
+}
+
+func (f *fakeCache) Delete(key string) error {
+	delete(f.data, key)
+	return nil
+}
+
+func (f *fakeCache) Cleanup() error {
+	f.cleaned = true
+	return nil
+}
+
+func (f *fakeCache) Close() error {
+	return nil
+}
+
+func TestMemoryCacheServesHotKeysFromMemory(t *testing.T) {
+	backend := newFakeCache()
+	c := NewMemoryCache(backend, 4, time.Hour)
+
+	if err := backend.Set("k", "v"); err != nil {
+		t.Fatal(err)
+	}
+
+	for range 3 {
+		var got string
+		if err := c.Get("k", &got); err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if got != "v" {
+			t.Fatalf("Get = %q, want %q", got, "v")
+		}
+	}
+
+	if backend.gets != 1 {
+		t.Errorf("backend reads = %d, want 1", backend.gets)
+	}
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	backend := newFakeCache()
+	c := NewMemoryCache(backend, 2, time.Hour)
+
+	for _, k := range []string{"a", "b"} {
+		if err := c.Set(k, k); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Touch "a" so "b" becomes the eviction candidate
+	var v string
+	if err := c.Get("a", &v); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Set("c", "c"); err != nil {
+		t.Fatal(err)
+	}
+
+	mc := c.(*MemoryCache)
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, ok := mc.lookup(key); ok != want {
+			t.Errorf("in memory %q = %v, want %v", key, ok, want)
+		}
+	}
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	backend := newFakeCache()
+	c := NewMemoryCache(backend, 4, time.Millisecond).(*MemoryCache)
+
+	if err := c.Set("k", "v"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.lookup("k"); ok {
+		t.Error("expired entry still served from memory")
+	}
+
+	if err := c.Set("k", "v"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if err := c.Cleanup(); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.entries) != 0 {
+		t.Errorf("Cleanup left %d entries", len(c.entries))
+	}
+	if !backend.cleaned {
+		t.Error("Cleanup did not reach backend")
+	}
+}
+
+func TestMemoryCacheTTLBoundedByMaxAge(t *testing.T) {
+	c := NewMemoryCache(newFakeCache(), 4, 7*24*time.Hour).(*MemoryCache)
+	if c.ttl != memoryTTL {
+		t.Errorf("ttl = %v, want %v", c.ttl, memoryTTL)
+	}
+}
+
+func TestMemoryCacheDeleteInvalidates(t *testing.T) {
+	backend := newFakeCache()
+	c := NewMemoryCache(backend, 4, time.Hour)
+
+	if err := c.Set("k", "v"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Delete("k"); err != nil {
+		t.Fatal(err)
+	}
+
+	var got string
+	if err := c.Get("k", &got); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Get after Delete = %v, want ErrCacheMiss", err)
+	}
+}
+
+func TestMemoryCacheSetFailureDropsStaleValue(t *testing.T) {
+	backend := newFakeCache()
+	c := NewMemoryCache(backend, 4, time.Hour).(*MemoryCache)
+
+	if err := c.Set("k", "old"); err != nil {
+		t.Fatal(err)
+	}
+
+	backend.setErr = errors.New("disk full")
+	if err := c.Set("k", "new"); err == nil {
+		t.Fatal("Set succeeded despite backend failure")
+	}
+
+	if _, ok := c.lookup("k"); ok {
+		t.Error("stale value kept in memory after failed Set")
+	}
+}
+
+func TestMemoryCacheReadRacingDeleteDoesNotResurrect(t *testing.T) {
+	backend := newFakeCache()
+	c := NewMemoryCache(backend, 4, time.Hour).(*MemoryCache)
+
+	if err := backend.Set("k", "old"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The backend read returns "old", but the key is deleted before the
+	// reader gets to promote it into memory.
+	backend.onGet = func() {
+		if err := c.Delete("k"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got string
+	if err := c.Get("k", &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := c.lookup("k"); ok {
+		t.Error("deleted value resurrected into memory")
+	}
+	if len(c.inflight) != 0 {
+		t.Errorf("inflight not released: %d keys", len(c.inflight))
+	}
+}
+
+func TestMemoryCacheReadRacingSetKeepsNewValue(t *testing.T) {
+	backend := newFakeCache()
+	c := NewMemoryCache(backend, 4, time.Hour)
+
+	if err := backend.Set("k", "old"); err != nil {
+		t.Fatal(err)
+	}
+	backend.onGet = func() {
+		if err := c.Set("k", "new"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got string
+	if err := c.Get("k", &got); err != nil {
+		t.Fatal(err)
+	}
+
+	var again string
+	if err := c.Get("k", &again); err != nil {
+		t.Fatal(err)
+	}
+	if again != "new" {
+		t.Errorf("Get = %q, want %q", again, "new")
+	}
+}
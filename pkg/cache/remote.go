@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/metrics"
+)
+
+// remoteTimeout bounds a request to the remote cache, so an unreachable
+// service slows analyses down by no more than this
+const remoteTimeout = 5 * time.Second
+
+// Remote is a team-hosted cache service that speedrun instances share
+// entries through, such as AI analyses. Entries are JSON documents read with
+// GET <url>/<key> and written with PUT <url>/<key>; a 404 is a miss. With a
+// token, requests carry it as a bearer token.
+type Remote struct {
+	base   *url.URL
+	token  string
+	client *http.Client
+}
+
+// NewRemote returns the remote cache at baseURL, reached over transport
+// (http.DefaultTransport if nil)
+func NewRemote(baseURL, token string, transport http.RoundTripper) (*Remote, error) {
+	base, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote cache URL: %w", err)
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, fmt.Errorf("invalid remote cache URL %q: want http or https", baseURL)
+	}
+	return &Remote{base: base, token: token, client: &http.Client{Transport: transport, Timeout: remoteTimeout}}, nil
+}
+
+// keyURL returns where key lives. Each /-separated part of the key is
+// escaped, so keys read as paths on the service.
+func (r *Remote) keyURL(key string) string {
+	parts := strings.Split(key, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return r.base.String() + "/" + strings.Join(parts, "/")
+}
+
+func (r *Remote) do(ctx context.Context, method, key string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, r.keyURL(key), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	return r.client.Do(req)
+}
+
+// Get reads key into dest, returning ErrCacheMiss if the service doesn't
+// have it
+func (r *Remote) Get(ctx context.Context, key string, dest any) error {
+	start := time.Now()
+	resp, err := r.do(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get remote cache entry: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		metrics.CacheLookups.Inc("remote", "miss")
+		slog.Debug("Remote cache miss", slog.String("key", key), slog.Duration("duration", time.Since(start)))
+		return ErrCacheMiss
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("failed to get remote cache entry: %s", resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return fmt.Errorf("failed to unmarshal remote cache entry: %w", err)
+	}
+	metrics.CacheLookups.Inc("remote", "hit")
+	slog.Debug("Remote cache hit", slog.String("key", key), slog.Duration("duration", time.Since(start)))
+	return nil
+}
+
+// Set stores value under key for everyone sharing the service
+func (r *Remote) Set(ctx context.Context, key string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal remote cache entry: %w", err)
+	}
+
+	start := time.Now()
+	resp, err := r.do(ctx, http.MethodPut, key, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to set remote cache entry: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to set remote cache entry: %s", resp.Status)
+	}
+	slog.Debug("Remote cache set", slog.String("key", key), slog.Duration("duration", time.Since(start)), slog.Int("data_size", len(data)))
+	return nil
+}
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestRemote(t *testing.T) {
+	var mu sync.Mutex
+	stored := make(map[string][]byte)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodGet:
+			data, ok := stored[r.URL.EscapedPath()]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write(data)
+		case http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			stored[r.URL.EscapedPath()] = data
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	remote, err := NewRemote(srv.URL+"/v1/", "secret", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	var got map[string]string
+	if err := remote.Get(ctx, "ai/acme/api/abc", &got); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("Get() before Set = %v, want a miss", err)
+	}
+	if err := remote.Set(ctx, "ai/acme/api/abc", map[string]string{"recommendation": "APPROVE"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Get(ctx, "ai/acme/api/abc", &got); err != nil || got["recommendation"] != "APPROVE" {
+		t.Errorf("Get() = %v, %v; want the stored entry", got, err)
+	}
+	if _, ok := stored["/v1/ai/acme/api/abc"]; !ok {
+		t.Errorf("entry stored at %v, want /v1/ai/acme/api/abc", stored)
+	}
+
+	unauthorized, _ := NewRemote(srv.URL+"/v1", "wrong", nil)
+	if err := unauthorized.Get(ctx, "ai/acme/api/abc", &got); err == nil || errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Get() with a bad token = %v, want an error that isn't a miss", err)
+	}
+
+	if _, err := NewRemote("ftp://cache.example", "", nil); err == nil {
+		t.Error("NewRemote() accepted a non-HTTP URL")
+	}
+}
@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// snapshotVersion is bumped whenever the snapshot format changes incompatibly
+const snapshotVersion = 1
+
+// Snapshot is a portable dump of cache entries, used to move cached AI
+// analyses and PR metadata between machines
+type Snapshot struct {
+	Version    int             `json:"version"`
+	ExportedAt time.Time       `json:"exported_at"`
+	Entries    []SnapshotEntry `json:"entries"`
+}
+
+// SnapshotEntry is a single exported cache entry. Data is kept as raw JSON so
+// snapshots stay human-readable.
+type SnapshotEntry struct {
+	Key       string          `json:"key"`
+	Data      json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"created_at"`
+	ExpiresAt time.Time       `json:"expires_at"`
+}
+
+// Export writes all unexpired entries whose key starts with prefix to w.
+// An empty prefix exports everything.
+func (c *SQLiteCache) Export(w io.Writer, prefix string) (int, error) {
+	rows, err := c.db.Query(`
+		SELECT key, data, created_at, expires_at
+		FROM cache_entries
+		WHERE expires_at > datetime('now')
+		ORDER BY key
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query cache entries: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Debug("Failed to close export rows", slog.Any("error", err))
+		}
+	}()
+
+	snapshot := Snapshot{
+		Version:    snapshotVersion,
+		ExportedAt: time.Now(),
+		Entries:    []SnapshotEntry{},
+	}
+
+	for rows.Next() {
+		var entry SnapshotEntry
+		var data []byte
+		if err := rows.Scan(&entry.Key, &data, &entry.CreatedAt, &entry.ExpiresAt); err != nil {
+			return 0, fmt.Errorf("failed to read cache entry: %w", err)
+		}
+		if !strings.HasPrefix(entry.Key, prefix) {
+			continue
+		}
+		if !json.Valid(data) {
+			slog.Debug("Skipping cache entry with invalid JSON", slog.String("key", entry.Key))
+			continue
+		}
+		entry.Data = data
+		snapshot.Entries = append(snapshot.Entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read cache entries: %w", err)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(snapshot); err != nil {
+		return 0, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	slog.Debug("Exported cache snapshot", slog.Int("entries", len(snapshot.Entries)), slog.String("prefix", prefix))
+	return len(snapshot.Entries), nil
+}
+
+// Import loads a snapshot produced by Export, replacing entries with the same
+// key. Entries that have already expired are skipped. It returns the number
+// of entries imported.
+func (c *SQLiteCache) Import(r io.Reader) (int, error) {
+	var snapshot Snapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return 0, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	if snapshot.Version != snapshotVersion {
+		return 0, fmt.Errorf("unsupported snapshot version %d (expected %d)", snapshot.Version, snapshotVersion)
+	}
+
+	tx, err := c.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin import: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback() // No-op after a successful commit
+	}()
+
+	now := time.Now()
+	imported := 0
+	for _, entry := range snapshot.Entries {
+		if !entry.ExpiresAt.After(now) {
+			continue
+		}
+		if _, err := tx.Stmt(c.setStmt).Exec(entry.Key, []byte(entry.Data), entry.CreatedAt, entry.ExpiresAt); err != nil {
+			return 0, fmt.Errorf("failed to import %s: %w", entry.Key, err)
+		}
+		imported++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit import: %w", err)
+	}
+
+	slog.Debug("Imported cache snapshot", slog.Int("entries", imported), slog.Int("skipped", len(snapshot.Entries)-imported))
+	return imported, nil
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	src, err := New(filepath.Join(t.TempDir(), "src.db"), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+
+	if err := src.Set("ai:o/r#1:abc", map[string]string{"Recommendation": "APPROVE"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Set("reviews:o/r#1", []string{"alice"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	count, err := src.(*SQLiteCache).Export(&buf, "ai:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("exported %d entries, want 1", count)
+	}
+
+	dst, err := New(filepath.Join(t.TempDir(), "dst.db"), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+
+	count, err = dst.(*SQLiteCache).Import(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("imported %d entries, want 1", count)
+	}
+
+	var got map[string]string
+	if err := dst.Get("ai:o/r#1:abc", &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["Recommendation"] != "APPROVE" {
+		t.Errorf("imported data = %v", got)
+	}
+	if err := dst.Get("reviews:o/r#1", &[]string{}); err != ErrCacheMiss {
+		t.Errorf("prefix filter leaked reviews entry: %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Ensure the SQLite and memory caches can serve stale entries
+var (
+	_ StaleReader = (*SQLiteCache)(nil)
+	_ StaleReader = (*MemoryCache)(nil)
+)
+
+// StaleReader reads entries whether or not they've expired, so there's
+// something to show while fresh data loads. Cleanup keeps entries for a day
+// after they expire.
+type StaleReader interface {
+	// GetStale reads key into dest and returns when the entry was cached
+	GetStale(key string, dest any) (time.Time, error)
+}
+
+// GetStale retrieves a cached value by key, ignoring its expiry
+func (c *SQLiteCache) GetStale(key string, dest any) (time.Time, error) {
+	var data []byte
+	var createdAt time.Time
+	err := c.db.QueryRow(`SELECT data, created_at FROM cache_entries WHERE key = ?`, key).Scan(&data, &createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, ErrCacheMiss
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get stale cache entry: %w", err)
+	}
+
+	if err := json.Unmarshal(data, dest); err != nil {
+		return time.Time{}, fmt.Errorf("failed to unmarshal cached data: %w", err)
+	}
+	return createdAt, nil
+}
+
+// GetStale reads from the backend, if it keeps stale entries
+func (c *MemoryCache) GetStale(key string, dest any) (time.Time, error) {
+	if stale, ok := c.backend.(StaleReader); ok {
+		return stale.GetStale(key, dest)
+	}
+	return time.Time{}, ErrCacheMiss
+}
@@ -0,0 +1,42 @@
+package checklist
+
+import (
+	"path"
+	"strings"
+)
+
+// Item is a review checklist question and the repositories it applies to
+type Item struct {
+	Pattern string // owner/repo pattern, e.g. "yourcompany/*"
+	Text    string
+}
+
+// Parse reads checklist entries of the form "pattern: question", e.g.
+// "yourcompany/api: Migration included?". Entries without a repository
+// pattern apply to every repository.
+func Parse(entries []string) []Item {
+	var items []Item
+	for _, entry := range entries {
+		pattern, text, ok := strings.Cut(entry, ":")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || (pattern != "*" && !strings.Contains(pattern, "/")) {
+			pattern, text = "*", entry
+		}
+		if text = strings.TrimSpace(text); text != "" {
+			items = append(items, Item{Pattern: pattern, Text: text})
+		}
+	}
+	return items
+}
+
+// For returns the questions that apply to owner/repo, in configured order
+func For(items []Item, owner, repo string) []string {
+	name := owner + "/" + repo
+	var questions []string
+	for _, item := range items {
+		if ok, _ := path.Match(item.Pattern, name); ok || item.Pattern == "*" {
+			questions = append(questions, item.Text)
+		}
+	}
+	return questions
+}
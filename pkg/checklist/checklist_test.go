@@ -0,0 +1,47 @@
+package checklist
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	items := Parse([]string{
+		"Tests cover the change?",
+		"yourcompany/api: Migration included?",
+		" yourcompany/* : Behind a feature flag? ",
+		"*: Rollback plan?",
+		"Docs: updated?",
+		"yourcompany/api:",
+	})
+
+	want := []Item{
+		{Pattern: "*", Text: "Tests cover the change?"},
+		{Pattern: "yourcompany/api", Text: "Migration included?"},
+		{Pattern: "yourcompany/*", Text: "Behind a feature flag?"},
+		{Pattern: "*", Text: "Rollback plan?"},
+		{Pattern: "*", Text: "Docs: updated?"},
+	}
+	if !slices.Equal(items, want) {
+		t.Errorf("Parse() = %+v, want %+v", items, want)
+	}
+}
+
+func TestFor(t *testing.T) {
+	items := Parse([]string{
+		"Tests cover the change?",
+		"yourcompany/api: Migration included?",
+		"yourcompany/*: Behind a feature flag?",
+	})
+
+	if got := For(items, "yourcompany", "api"); len(got) != 3 {
+		t.Errorf("For(yourcompany/api) = %v, want all three", got)
+	}
+	want := []string{"Tests cover the change?", "Behind a feature flag?"}
+	if got := For(items, "yourcompany", "web"); !slices.Equal(got, want) {
+		t.Errorf("For(yourcompany/web) = %v, want %v", got, want)
+	}
+	if got := For(items, "other", "repo"); !slices.Equal(got, want[:1]) {
+		t.Errorf("For(other/repo) = %v, want %v", got, want[:1])
+	}
+}
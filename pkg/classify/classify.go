@@ -0,0 +1,236 @@
+// Package classify decides what kind of change a PR is. Classifiers each
+// look at one kind of evidence (the AI's analysis, the changed files, the
+// author, the title, the size) and guess a type with a confidence. The most
+// confident guess wins, with ties going to the classifier listed first.
+package classify
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// PR types, matching the type filter's values
+const (
+	Docs         = "docs"
+	Code         = "code"
+	Dependencies = "dependencies"
+	Mixed        = "mixed"
+)
+
+// Classifier names, for configuring which run and in what order
+const (
+	AI       = "ai"
+	Files    = "files"
+	Author   = "author"
+	Keywords = "keywords"
+	Size     = "size"
+)
+
+// DefaultOrder runs every classifier, the more reliable ones first
+var DefaultOrder = []string{AI, Files, Author, Keywords, Size}
+
+// Default keyword lists and dependency bot logins
+var (
+	DefaultDependencyKeywords = []string{"bump ", "update ", "upgrade ", "dependencies", "snyk", "dependabot"}
+	DefaultDocsKeywords       = []string{"readme", "doc", "documentation", "guide", "rfc"}
+	DefaultDependencyAuthors  = []string{"dependabot[bot]", "renovate[bot]", "snyk-bot"}
+)
+
+// PR is the evidence classifiers go by. Fields not loaded yet are left empty.
+type PR struct {
+	Title  string
+	Author string
+	AIType string   // The AI's PR_TYPE: DOCUMENTATION, CODE, DEPENDENCY or MIXED
+	Files  []string // Changed paths, nil when not loaded
+
+	// Size, not counting binary and generated files
+	Sized     bool // Whether Lines and FileCount are known
+	Lines     int
+	FileCount int
+}
+
+// Result is a classifier's guess
+type Result struct {
+	Type       string
+	Confidence float64 // From 0 to 1
+	Source     string  // Name of the classifier that made the guess
+}
+
+// Classifier guesses a PR's type, or reports false if its evidence is missing
+// or says nothing
+type Classifier interface {
+	Name() string
+	Classify(pr PR) (Result, bool)
+}
+
+// Config adjusts the built-in classifiers
+type Config struct {
+	Order              []string // Classifier names in order of precedence; others don't run
+	DependencyKeywords []string // Lower-case title fragments that mark dependency updates
+	DocsKeywords       []string // Lower-case title fragments that mark documentation
+	DependencyAuthors  []string // Logins whose PRs are dependency updates
+}
+
+// Chain runs classifiers in order of precedence
+type Chain []Classifier
+
+// New builds the chain of built-in classifiers in the configured order. Empty
+// fields take their defaults.
+func New(cfg Config) (Chain, error) {
+	order := orDefault(cfg.Order, DefaultOrder)
+	dependencyKeywords := orDefault(cfg.DependencyKeywords, DefaultDependencyKeywords)
+	docsKeywords := orDefault(cfg.DocsKeywords, DefaultDocsKeywords)
+	authors := orDefault(cfg.DependencyAuthors, DefaultDependencyAuthors)
+
+	var chain Chain
+	for _, name := range order {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case AI:
+			chain = append(chain, aiClassifier{})
+		case Files:
+			chain = append(chain, fileClassifier{})
+		case Author:
+			chain = append(chain, authorClassifier{authors: authors})
+		case Keywords:
+			chain = append(chain, keywordClassifier{dependencies: dependencyKeywords, docs: docsKeywords})
+		case Size:
+			chain = append(chain, sizeClassifier{})
+		default:
+			return nil, fmt.Errorf("unknown PR classifier %q (want one of %s)", name, strings.Join(DefaultOrder, ", "))
+		}
+	}
+	return chain, nil
+}
+
+func orDefault(list, fallback []string) []string {
+	if len(list) == 0 {
+		return fallback
+	}
+	return list
+}
+
+// Classify returns the most confident guess, or Mixed if no classifier has
+// one
+func (c Chain) Classify(pr PR) Result {
+	best := Result{Type: Mixed}
+	for _, classifier := range c {
+		result, ok := classifier.Classify(pr)
+		if ok && result.Confidence > best.Confidence {
+			result.Source = classifier.Name()
+			best = result
+		}
+	}
+	return best
+}
+
+// aiClassifier trusts the AI's reading of the whole PR most
+type aiClassifier struct{}
+
+func (aiClassifier) Name() string { return AI }
+
+func (aiClassifier) Classify(pr PR) (Result, bool) {
+	types := map[string]string{"DOCUMENTATION": Docs, "CODE": Code, "DEPENDENCY": Dependencies, "MIXED": Mixed}
+	t, ok := types[strings.ToUpper(pr.AIType)]
+	return Result{Type: t, Confidence: 0.9}, ok
+}
+
+// authorClassifier knows the dependency update bots
+type authorClassifier struct {
+	authors []string
+}
+
+func (authorClassifier) Name() string { return Author }
+
+func (c authorClassifier) Classify(pr PR) (Result, bool) {
+	if pr.Author == "" || !slices.ContainsFunc(c.authors, func(a string) bool { return strings.EqualFold(a, pr.Author) }) {
+		return Result{}, false
+	}
+	return Result{Type: Dependencies, Confidence: 0.85}, true
+}
+
+// keywordClassifier looks for telling words in the title. Words like
+// "update" say little on their own, so the changed files outweigh it.
+type keywordClassifier struct {
+	dependencies, docs []string
+}
+
+func (keywordClassifier) Name() string { return Keywords }
+
+func (c keywordClassifier) Classify(pr PR) (Result, bool) {
+	title := strings.ToLower(pr.Title)
+	contains := func(keyword string) bool { return strings.Contains(title, strings.ToLower(keyword)) }
+	switch {
+	case slices.ContainsFunc(c.dependencies, contains):
+		return Result{Type: Dependencies, Confidence: 0.4}, true
+	case slices.ContainsFunc(c.docs, contains):
+		return Result{Type: Docs, Confidence: 0.4}, true
+	}
+	return Result{}, false
+}
+
+// sizeClassifier is the last resort: small changes are often docs or config,
+// large ones a mix
+type sizeClassifier struct{}
+
+func (sizeClassifier) Name() string { return Size }
+
+func (sizeClassifier) Classify(pr PR) (Result, bool) {
+	switch {
+	case !pr.Sized:
+		return Result{}, false
+	case pr.FileCount <= 2 && pr.Lines < 100:
+		return Result{Type: Docs, Confidence: 0.3}, true
+	case pr.FileCount > 20 || pr.Lines > 1000:
+		return Result{Type: Mixed, Confidence: 0.3}, true
+	}
+	return Result{Type: Code, Confidence: 0.3}, true
+}
+
+// fileClassifier sorts the changed paths into docs, dependency manifests and
+// the rest
+type fileClassifier struct{}
+
+// docExtensions are prose formats
+var docExtensions = []string{".md", ".mdx", ".rst", ".txt", ".adoc"}
+
+// manifests list a project's dependencies, or pin them
+var manifests = []string{
+	"go.mod", "go.sum", "package.json", "package-lock.json", "yarn.lock", "pnpm-lock.yaml",
+	"requirements.txt", "pyproject.toml", "poetry.lock", "Pipfile", "Pipfile.lock", "uv.lock",
+	"Gemfile", "Gemfile.lock", "Cargo.toml", "Cargo.lock", "composer.json", "composer.lock",
+	"pom.xml", "build.gradle", "build.gradle.kts", "gradle.lockfile", "mix.exs", "mix.lock",
+}
+
+func (fileClassifier) Name() string { return Files }
+
+func (fileClassifier) Classify(pr PR) (Result, bool) {
+	if len(pr.Files) == 0 {
+		return Result{}, false
+	}
+
+	var docs, deps, other int
+	for _, file := range pr.Files {
+		base := path.Base(file)
+		switch {
+		case slices.Contains(manifests, base) || strings.HasPrefix(file, "vendor/") || strings.Contains(file, "/vendor/"):
+			deps++
+		case slices.Contains(docExtensions, strings.ToLower(path.Ext(base))) || strings.HasPrefix(file, "docs/"):
+			docs++
+		default:
+			other++
+		}
+	}
+
+	switch {
+	case docs == len(pr.Files):
+		return Result{Type: Docs, Confidence: 0.8}, true
+	case deps+docs == len(pr.Files):
+		return Result{Type: Dependencies, Confidence: 0.8}, true
+	case deps == 0:
+		// Code with or without its docs
+		return Result{Type: Code, Confidence: 0.5}, true
+	}
+	return Result{Type: Mixed, Confidence: 0.5}, true
+}
@@ -0,0 +1,55 @@
+package classify
+
+import "testing"
+
+func TestClassify(t *testing.T) {
+	chain, err := New(Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		pr     PR
+		want   string
+		source string
+	}{
+		{"nothing loaded", PR{Title: "Refactor handlers"}, Mixed, ""},
+		{"title only", PR{Title: "Bump lodash from 4.17.20 to 4.17.21"}, Dependencies, Keywords},
+		{"small change", PR{Title: "Fix typo", Sized: true, Lines: 4, FileCount: 1}, Docs, Size},
+		{"update that is code", PR{Title: "Update retry handling", Files: []string{"pkg/retry.go", "pkg/retry_test.go"}}, Code, Files},
+		{"docs only", PR{Title: "Tweak wording", Files: []string{"README.md", "docs/setup/install.png"}}, Docs, Files},
+		{"manifests", PR{Title: "Tidy modules", Files: []string{"go.mod", "go.sum", "vendor/modules.txt"}}, Dependencies, Files},
+		{"bot author", PR{Author: "Renovate[bot]", Files: []string{"go.mod", "main.go"}}, Dependencies, Author},
+		{"ai wins", PR{Title: "Update docs", AIType: "CODE", Files: []string{"README.md"}}, Code, AI},
+	}
+	for _, tt := range tests {
+		got := chain.Classify(tt.pr)
+		if got.Type != tt.want || got.Source != tt.source {
+			t.Errorf("%s: Classify() = %s from %q, want %s from %q", tt.name, got.Type, got.Source, tt.want, tt.source)
+		}
+	}
+}
+
+func TestNewConfig(t *testing.T) {
+	chain, err := New(Config{Order: []string{"keywords", "files"}, DocsKeywords: []string{"adr"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chain) != 2 || chain[0].Name() != Keywords {
+		t.Errorf("New() order = %v", chain)
+	}
+
+	// The AI is left out, and the custom keyword replaces the defaults
+	got := chain.Classify(PR{Title: "ADR 12: queue backend", AIType: "CODE"})
+	if got.Type != Docs {
+		t.Errorf("Classify() = %+v, want docs", got)
+	}
+	if got := chain.Classify(PR{Title: "Reword the README"}); got.Source != "" {
+		t.Errorf("Classify() used a default keyword: %+v", got)
+	}
+
+	if _, err := New(Config{Order: []string{"ai", "astrology"}}); err == nil {
+		t.Error("New() accepted an unknown classifier")
+	}
+}
@@ -0,0 +1,115 @@
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Rule assigns owners to the paths matching a CODEOWNERS pattern
+type Rule struct {
+	Pattern string
+	Owners  []string
+
+	re *regexp.Regexp
+}
+
+// Ruleset is a parsed CODEOWNERS file. Later rules take precedence.
+type Ruleset []Rule
+
+// Parse reads a CODEOWNERS file
+func Parse(r io.Reader) (Ruleset, error) {
+	var rules Ruleset
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		re, err := compile(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pattern %q: %w", fields[0], err)
+		}
+		rules = append(rules, Rule{Pattern: fields[0], Owners: fields[1:], re: re})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read CODEOWNERS: %w", err)
+	}
+	return rules, nil
+}
+
+// Owners returns the owners of a repository path, or nil if no rule matches
+func (rs Ruleset) Owners(path string) []string {
+	for i := len(rs) - 1; i >= 0; i-- {
+		if rs[i].re.MatchString(path) {
+			return rs[i].Owners
+		}
+	}
+	return nil
+}
+
+// OwnedBy counts the paths owned by any of owners, e.g. "@octocat" or
+// "@acme/platform". Comparison ignores case.
+func (rs Ruleset) OwnedBy(paths []string, owners []string) int {
+	count := 0
+	for _, path := range paths {
+		for _, owner := range rs.Owners(path) {
+			if containsFold(owners, owner) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// compile converts a gitignore-style CODEOWNERS pattern to a regexp. Patterns
+// containing a slash other than a trailing one are relative to the repo root;
+// others match at any depth. Matching a directory matches everything in it.
+func compile(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+
+	var expr strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+
+	prefix := "^(?:.*/)?"
+	if anchored {
+		prefix = "^"
+	}
+	return regexp.Compile(prefix + expr.String() + "(?:/.*)?$")
+}
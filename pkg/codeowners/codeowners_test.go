@@ -0,0 +1,58 @@
+package codeowners
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+const testFile = `
+# Default owners
+*                @acme/everyone
+*.md             @docs-team  # docs anywhere
+/build/          @acme/release
+apps/            @acme/apps
+/api/**/handlers @octocat
+docs/*.txt       @writer
+`
+
+func TestOwners(t *testing.T) {
+	rules, err := Parse(strings.NewReader(testFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string][]string{
+		"main.go":                       {"@acme/everyone"},
+		"README.md":                     {"@docs-team"},
+		"pkg/sub/NOTES.md":              {"@docs-team"},
+		"build/ci/pipeline.yml":         {"@acme/release"},
+		"tools/build/x.go":              {"@acme/everyone"}, // /build/ is anchored
+		"services/apps/web/main.go":     {"@acme/apps"},
+		"api/v1/handlers/users.go":      {"@octocat"},
+		"api/handlers/users.go":         {"@octocat"},
+		"docs/guide.txt":                {"@writer"},
+		"docs/nested/guide.txt":         {"@acme/everyone"},
+		"services/api/v1/handlers/x.go": {"@acme/everyone"},
+	}
+	for path, want := range tests {
+		if got := rules.Owners(path); !slices.Equal(got, want) {
+			t.Errorf("Owners(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestOwnedBy(t *testing.T) {
+	rules, err := Parse(strings.NewReader(testFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths := []string{"main.go", "api/v1/handlers/users.go", "README.md", "build/Makefile"}
+	if got := rules.OwnedBy(paths, []string{"@OctoCat", "@acme/release"}); got != 2 {
+		t.Errorf("OwnedBy() = %d, want 2", got)
+	}
+	if got := (Ruleset{}).OwnedBy(paths, []string{"@octocat"}); got != 0 {
+		t.Errorf("empty ruleset OwnedBy() = %d", got)
+	}
+}
@@ -1,48 +1,137 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
 	"time"
 
 	backoffconfig "github.com/kennyp/speedrun/pkg/backoff"
+	"github.com/kennyp/speedrun/pkg/classify"
+	"github.com/kennyp/speedrun/pkg/freeze"
+	"github.com/kennyp/speedrun/pkg/hooks"
+	"github.com/kennyp/speedrun/pkg/policy"
 	"github.com/urfave/cli/v3"
 )
 
 // Config represents the complete speedrun configuration
 type Config struct {
-	GitHub  GitHubConfig
-	AI      AIConfig
-	Checks  ChecksConfig
-	Cache   CacheConfig
-	Log     LogConfig
-	Client  ClientConfig
-	Backoff backoffconfig.GlobalConfig
+	GitHub   GitHubConfig
+	AI       AIConfig
+	Checks   ChecksConfig
+	Cache    CacheConfig
+	History  HistoryConfig
+	Metrics  MetricsConfig
+	Serve    ServeConfig
+	Tracker  TrackerConfig
+	Review   ReviewConfig
+	Priority PriorityConfig
+	Classify ClassifyConfig
+	Policy   PolicyConfig
+	Freeze   FreezeConfig
+	Digest   DigestConfig
+	Remind   RemindConfig
+	Plugins  PluginsConfig
+	Hooks    HooksConfig
+	UI       UIConfig
+	Log      LogConfig
+	Client   ClientConfig
+	Backoff  backoffconfig.GlobalConfig
 }
 
 // GitHubConfig holds GitHub-related configuration
 type GitHubConfig struct {
 	Token               string               // GitHub personal access token
 	SearchQuery         string               // GitHub search query for PRs
+	SearchAPI           string               // API searches use: auto, rest or graphql
+	Queues              []string             // Extra queues shown as tabs, as name=query
+	MyPRs               bool                 // Add a queue of the PRs I opened
 	AutoMergeOnApproval string               // Auto-merge behavior on approval: "true", "false", or "ask"
+	AutoRefresh         time.Duration        // Refresh interval while idle (0 disables)
+	ReviewSLA           time.Duration        // PRs waiting longer than this are highlighted (0 disables)
+	RequireSigned       []string             // Repo patterns (owner/repo, owner/*) where unsigned commits need review
+	Teams               []string             // My teams (org/team) for CODEOWNERS matching
+	AllowedRepos        []string             // Repo patterns (org, owner/repo, owner/*) speedrun may act on (empty allows all)
+	DeniedRepos         []string             // Repo patterns speedrun never acts on, even if allowed
 	Backoff             backoffconfig.Config // GitHub-specific backoff overrides
 	Client              ClientTimeoutConfig  // GitHub-specific client settings
+	DryRun              bool                 // Log write operations instead of performing them
 }
 
+// When AI analysis runs
+const (
+	AITriggerEager    = "eager"     // As soon as a PR's data loads
+	AITriggerOnSelect = "on_select" // When a PR is first selected
+)
+
 // AIConfig holds AI/LLM configuration
 type AIConfig struct {
-	Enabled         bool                 // Should AI Reivew the PR
-	BaseURL         string               // LLM Gateway or API base URL
-	APIKey          string               // API key for authentication
-	Model           string               // Model to use (e.g., gpt-4)
-	AnalysisTimeout time.Duration        // Timeout for entire AI analysis conversation
-	ToolTimeout     time.Duration        // Timeout for individual tool executions
-	Backoff         backoffconfig.Config // AI-specific backoff overrides
-	Client          ClientTimeoutConfig  // AI-specific client settings
+	Enabled          bool                 // Should AI Reivew the PR
+	Provider         string               // "openai" (or a gateway speaking its API), "azure" or "gemini"
+	BaseURL          string               // LLM Gateway or API base URL; the resource endpoint for Azure
+	Deployment       string               // Azure OpenAI deployment name
+	APIVersion       string               // Azure OpenAI API version
+	APIKey           string               // API key for authentication
+	Model            string               // Model to use (e.g., gpt-4)
+	ReasoningEffort  string               // "low", "medium" or "high" for reasoning models; empty for the model's default
+	Temperature      *float64             // Sampling temperature, nil for the model's default
+	TopP             *float64             // Nucleus sampling, nil for the model's default
+	MaxTokens        int                  // Most tokens a response can use (0 for the model's limit)
+	ExtraParams      []string             // Extra request body fields, as name=value
+	Headers          []string             // Extra request headers, as name=value, e.g. a gateway's tenant ID
+	AllowedRepos     []string             // Repo patterns whose PRs may be sent to the model (empty allows all)
+	DeniedRepos      []string             // Repo patterns whose PRs are never sent to the model
+	AnalysisTimeout  time.Duration        // Timeout for entire AI analysis conversation
+	ToolTimeout      time.Duration        // Timeout for individual tool executions
+	FetchMaxChars    int                  // Longest page text web_fetch returns (0 for no limit)
+	CircuitThreshold int                  // Consecutive failures before analysis pauses (0 disables)
+	CircuitCooldown  time.Duration        // How long analysis stays paused
+	Trigger          string               // When PRs are analyzed: "eager" as they load, or "on_select"
+	Backoff          backoffconfig.Config // AI-specific backoff overrides
+	Client           ClientTimeoutConfig  // AI-specific client settings
+
+	Tools map[string]AIToolConfig // By tool name, from [ai.tools.<name>]
+}
+
+// AITools are the tools the AI can be given, each tuned in
+// [ai.tools.<name>]
+var AITools = []string{"github_api", "web_fetch", "diff_analyzer", "dependency_changes", "code_search", "workflow_changes", "infra_changes"}
+
+// AIToolConfig tunes one of the AI's tools
+type AIToolConfig struct {
+	Enabled  bool          // Offered to the model
+	Timeout  time.Duration // 0 for ai.tool_timeout
+	MaxBytes int           // Longest result returned to the model (0 for no limit)
+}
+
+// AIToolFlag names the flag for one of a tool's settings, e.g.
+// ai-tools-web-fetch-timeout
+func AIToolFlag(tool, setting string) string {
+	return "ai-tools-" + strings.ReplaceAll(tool, "_", "-") + "-" + setting
+}
+
+// aiTools reads each tool's settings
+func aiTools(cmd *cli.Command) map[string]AIToolConfig {
+	tools := make(map[string]AIToolConfig, len(AITools))
+	for _, name := range AITools {
+		tools[name] = AIToolConfig{
+			Enabled:  cmd.Bool(AIToolFlag(name, "enabled")),
+			Timeout:  cmd.Duration(AIToolFlag(name, "timeout")),
+			MaxBytes: cmd.Int(AIToolFlag(name, "max-bytes")),
+		}
+	}
+	return tools
 }
 
 // ChecksConfig holds CI check filtering configuration
 type ChecksConfig struct {
-	Ignored  []string // Checks to ignore
-	Required []string // If set, only these checks matter
+	Ignored              []string // Checks to ignore
+	Required             []string // If set, only these checks matter
+	FromBranchProtection bool     // Without Required, use the checks the base branch requires
+	FlakyReruns          int      // Passes on rerun after which a check is likely flaky (0 disables)
+	ExcludeFlaky         bool     // Leave failures of likely flaky checks out of the overall state
 }
 
 // CacheConfig holds cache-related configuration
@@ -50,6 +139,177 @@ type CacheConfig struct {
 	Enabled bool          // Whether caching is enabled
 	Path    string        // Cache directory path
 	MaxAge  time.Duration // Maximum age of cache entries (e.g., 7*24*time.Hour)
+
+	MemoryEntries int // Size of the in-process LRU in front of SQLite (0 disables)
+
+	ContentMaxAge time.Duration // How long fetched release notes and other immutable pages are kept
+	ContentMaxMB  int           // Size limit of stored immutable pages (0 disables)
+
+	RemoteURL   string // Team cache service AI analyses are shared through (empty disables)
+	RemoteToken string // Bearer token for the team cache service
+}
+
+// HistoryConfig holds review history configuration
+type HistoryConfig struct {
+	Enabled bool   // Whether review activity is recorded
+	Path    string // History database file path
+}
+
+// MetricsConfig holds metrics export configuration
+type MetricsConfig struct {
+	Listen string // Address for the Prometheus endpoint (empty disables)
+}
+
+// ServeConfig holds `speedrun serve` configuration
+type ServeConfig struct {
+	Listen   string        // Address for the REST API
+	Tokens   []string      // Bearer tokens API clients authenticate with
+	Interval time.Duration // Time between queue refreshes
+
+	SlackSigningSecret string   // Verifies /speedrun slash commands; empty disables them
+	SlackApprovers     []string // Slack user IDs allowed to approve, empty for no one
+}
+
+// TrackerConfig holds issue tracker integration configuration
+type TrackerConfig struct {
+	Type     string   // "jira", "github", or empty to disable
+	URL      string   // Jira base URL
+	User     string   // Jira user for basic auth (empty sends Token as a bearer token)
+	Token    string   // Jira API token
+	Projects []string // Jira project keys to recognise (empty matches any)
+}
+
+// ReviewConfig holds review policy configuration
+type ReviewConfig struct {
+	MaxLines         int      // PRs changing more lines than this are tagged (0 disables)
+	MaxFiles         int      // PRs touching more files than this are tagged (0 disables)
+	EnforceSize      bool     // Whether oversized PRs always need a human review
+	Checklist        []string // Review questions, optionally scoped: "owner/repo: question"
+	RequireChecklist bool     // Whether the checklist must be complete before approving
+	RereviewChanged  bool     // Whether PRs changed since my approval count as unreviewed
+	TemplateCheck    bool     // Whether PR descriptions are checked against the repo's PR template
+	TemplatePrompt   bool     // Whether the AI is told how well the description fills out the template
+}
+
+// How the PR list is ordered
+const (
+	SortAttention = "attention" // Most in need of attention first
+	SortSearch    = "search"    // As the search returns them
+)
+
+// PriorityConfig holds how the list is ordered and how the attention score
+// weighs what's known about a PR
+type PriorityConfig struct {
+	Sort      string  // List order: attention or search
+	Risk      float64 // Weight of the AI's risk level
+	Checks    float64 // Weight of failing checks
+	Staleness float64 // Weight of time waiting for review, up to the review SLA
+	Size      float64 // Weight of the PR's size, up to the size budget
+}
+
+// ClassifyConfig holds how PR types are detected
+type ClassifyConfig struct {
+	Order              []string // Classifiers in order of precedence: ai, files, author, keywords, size
+	DependencyKeywords []string // Title fragments that mark dependency updates
+	DocsKeywords       []string // Title fragments that mark documentation
+	DependencyAuthors  []string // Logins whose PRs are dependency updates
+}
+
+// Classifier builds the PR classifier chain
+func (c ClassifyConfig) Classifier() (classify.Chain, error) {
+	return classify.New(classify.Config{
+		Order:              c.Order,
+		DependencyKeywords: c.DependencyKeywords,
+		DocsKeywords:       c.DocsKeywords,
+		DependencyAuthors:  c.DependencyAuthors,
+	})
+}
+
+// PolicyConfig holds merge gating rules
+type PolicyConfig struct {
+	Rules         []string // Rules such as "block merge if files ~ db/** and not business_hours"
+	BusinessHours string   // Weekday window for business_hours, e.g. "09:00-17:00"
+}
+
+// FreezeConfig holds deploy freeze windows
+type FreezeConfig struct {
+	Windows []string // Windows such as "Fri" or "owner/*: 2025-12-20..2026-01-04; Holiday freeze"
+}
+
+// DigestConfig holds where `speedrun digest` emails the queue
+type DigestConfig struct {
+	SMTPAddr     string   // SMTP server host:port
+	SMTPUsername string   // Empty for servers without authentication
+	SMTPPassword string   // SMTP password
+	From         string   // Sender address
+	To           []string // Recipient addresses
+}
+
+// RemindConfig holds how "remind me" reminders are created
+type RemindConfig struct {
+	Method     string // "ics" for a calendar file or "remind" for remind(1)
+	Dir        string // Where .ics files are written
+	RemindFile string // File remind(1) reads (empty for ~/.reminders)
+	Open       bool   // Open .ics files with the system handler to import them
+}
+
+// PluginsConfig holds how speedrun-<name> plugins on the PATH are run
+type PluginsConfig struct {
+	Enabled bool          // Offer plugins in the actions menu
+	Timeout time.Duration // How long a plugin may run
+}
+
+// HooksConfig holds shell commands run on PR lifecycle events
+type HooksConfig struct {
+	OnApprove          string
+	OnMerge            string // Run when a PR is merged or auto-merge is enabled
+	OnAnalysisComplete string // Run after a fresh (not cached) AI analysis
+	Timeout            time.Duration
+}
+
+// Hooks returns the commands to run on each event
+func (c HooksConfig) Hooks() hooks.Hooks {
+	return hooks.Hooks{
+		Commands: map[hooks.Event]string{
+			hooks.Approve:          c.OnApprove,
+			hooks.Merge:            c.OnMerge,
+			hooks.AnalysisComplete: c.OnAnalysisComplete,
+		},
+		Timeout: c.Timeout,
+	}
+}
+
+// Params returns the extra request body fields. Values that are JSON, like
+// numbers and true/false, are sent as such; anything else as a string.
+func (c AIConfig) Params() map[string]any {
+	params := make(map[string]any, len(c.ExtraParams))
+	for _, entry := range c.ExtraParams {
+		name, value, _ := strings.Cut(entry, "=")
+		var v any
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			v = value
+		}
+		params[strings.TrimSpace(name)] = v
+	}
+	return params
+}
+
+// RequestHeaders returns the extra request headers
+func (c AIConfig) RequestHeaders() map[string]string {
+	headers := make(map[string]string, len(c.Headers))
+	for _, entry := range c.Headers {
+		name, value, _ := strings.Cut(entry, "=")
+		headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return headers
+}
+
+// UIConfig holds terminal interface configuration
+type UIConfig struct {
+	Accessible    bool   // Text labels instead of emoji and color-only cues
+	ReducedMotion bool   // No spinner animation and fewer redraws, for slow links
+	ShowReasoning bool   // Show reasoning summaries in the detail popup to start with
+	StatePath     string // Where layout preferences, like the detail pane size, are kept
 }
 
 // LogConfig holds logging configuration
@@ -60,7 +320,12 @@ type LogConfig struct {
 
 // ClientConfig holds global client configuration
 type ClientConfig struct {
-	Timeout time.Duration // Global client timeout for HTTP requests
+	Timeout            time.Duration // Global client timeout for HTTP requests
+	CABundle           string        // PEM file of extra CAs to trust, e.g. a proxy's
+	InsecureSkipVerify bool          // Skip TLS certificate verification
+	Record             string        // Directory to record responses into as fixtures
+	Replay             string        // Directory of fixtures to serve instead of the network
+	Demo               bool          // Serve the built-in demo PRs instead of the network
 }
 
 // ClientTimeoutConfig holds service-specific client timeout configuration
@@ -104,6 +369,12 @@ func LoadFromCLI(cmd *cli.Command) *Config {
 	githubClientTimeout := getDurationWithFallback(cmd, "github-client-timeout", globalClientTimeout)
 	aiClientTimeout := getDurationWithFallback(cmd, "ai-client-timeout", globalClientTimeout)
 
+	// NO_COLOR (https://no-color.org) turns on accessible mode unless the flag says otherwise
+	accessible := os.Getenv("NO_COLOR") != ""
+	if cmd.IsSet("no-emoji") {
+		accessible = cmd.Bool("no-emoji")
+	}
+
 	checksIgnored := cmd.StringSlice("checks-ignored")
 	checksRequired := cmd.StringSlice("checks-required")
 
@@ -111,35 +382,158 @@ func LoadFromCLI(cmd *cli.Command) *Config {
 		GitHub: GitHubConfig{
 			Token:               cmd.String("github-token"),
 			SearchQuery:         cmd.String("github-search-query"),
+			SearchAPI:           cmd.String("github-search-api"),
+			Queues:              cmd.StringSlice("github-queues"),
+			MyPRs:               cmd.Bool("github-my-prs"),
 			AutoMergeOnApproval: cmd.String("auto-merge-on-approval"),
+			AutoRefresh:         cmd.Duration("github-auto-refresh"),
+			ReviewSLA:           cmd.Duration("review-sla"),
+			RequireSigned:       cmd.StringSlice("require-signed-repos"),
+			Teams:               cmd.StringSlice("github-teams"),
+			AllowedRepos:        cmd.StringSlice("github-allowed-repos"),
+			DeniedRepos:         cmd.StringSlice("github-denied-repos"),
 			Backoff:             githubBackoff,
 			Client:              ClientTimeoutConfig{Timeout: githubClientTimeout},
+			DryRun:              cmd.Bool("dry-run"),
 		},
 		AI: AIConfig{
-			Enabled:         cmd.Bool("ai-enabled"),
-			BaseURL:         cmd.String("ai-base-url"),
-			APIKey:          cmd.String("ai-api-key"),
-			Model:           cmd.String("ai-model"),
-			AnalysisTimeout: cmd.Duration("ai-analysis-timeout"),
-			ToolTimeout:     cmd.Duration("ai-tool-timeout"),
-			Backoff:         aiBackoff,
-			Client:          ClientTimeoutConfig{Timeout: aiClientTimeout},
+			Enabled:          cmd.Bool("ai-enabled"),
+			Provider:         cmd.String("ai-provider"),
+			BaseURL:          cmd.String("ai-base-url"),
+			Deployment:       cmd.String("ai-deployment"),
+			APIVersion:       cmd.String("ai-api-version"),
+			APIKey:           cmd.String("ai-api-key"),
+			Model:            cmd.String("ai-model"),
+			ReasoningEffort:  cmd.String("ai-reasoning-effort"),
+			Temperature:      optionalFloat64(cmd, "ai-temperature"),
+			TopP:             optionalFloat64(cmd, "ai-top-p"),
+			MaxTokens:        cmd.Int("ai-max-tokens"),
+			ExtraParams:      cmd.StringSlice("ai-extra-params"),
+			Headers:          cmd.StringSlice("ai-headers"),
+			AllowedRepos:     cmd.StringSlice("ai-allowed-repos"),
+			DeniedRepos:      cmd.StringSlice("ai-denied-repos"),
+			AnalysisTimeout:  cmd.Duration("ai-analysis-timeout"),
+			ToolTimeout:      cmd.Duration("ai-tool-timeout"),
+			Tools:            aiTools(cmd),
+			FetchMaxChars:    cmd.Int("ai-fetch-max-chars"),
+			CircuitThreshold: cmd.Int("ai-circuit-threshold"),
+			CircuitCooldown:  cmd.Duration("ai-circuit-cooldown"),
+			Trigger:          cmd.String("ai-trigger"),
+			Backoff:          aiBackoff,
+			Client:           ClientTimeoutConfig{Timeout: aiClientTimeout},
 		},
 		Checks: ChecksConfig{
-			Ignored:  checksIgnored,
-			Required: checksRequired,
+			Ignored:              checksIgnored,
+			Required:             checksRequired,
+			FromBranchProtection: cmd.Bool("checks-from-branch-protection"),
+			FlakyReruns:          cmd.Int("checks-flaky-reruns"),
+			ExcludeFlaky:         cmd.Bool("checks-exclude-flaky"),
 		},
 		Cache: CacheConfig{
 			Enabled: cmd.Bool("cache-enabled"),
 			Path:    cmd.String("cache-path"),
 			MaxAge:  cmd.Duration("cache-max-age"),
+
+			MemoryEntries: cmd.Int("cache-memory-entries"),
+
+			ContentMaxAge: cmd.Duration("cache-content-max-age"),
+			ContentMaxMB:  cmd.Int("cache-content-max-mb"),
+
+			RemoteURL:   cmd.String("cache-remote-url"),
+			RemoteToken: cmd.String("cache-remote-token"),
+		},
+		History: HistoryConfig{
+			Enabled: cmd.Bool("history-enabled"),
+			Path:    cmd.String("history-path"),
+		},
+		Metrics: MetricsConfig{
+			Listen: cmd.String("metrics-listen"),
+		},
+		Serve: ServeConfig{
+			Listen:   cmd.String("serve-listen"),
+			Tokens:   cmd.StringSlice("serve-tokens"),
+			Interval: cmd.Duration("serve-interval"),
+
+			SlackSigningSecret: cmd.String("serve-slack-signing-secret"),
+			SlackApprovers:     cmd.StringSlice("serve-slack-approvers"),
+		},
+		Tracker: TrackerConfig{
+			Type:     cmd.String("tracker-type"),
+			URL:      cmd.String("tracker-url"),
+			User:     cmd.String("tracker-user"),
+			Token:    cmd.String("tracker-token"),
+			Projects: cmd.StringSlice("tracker-projects"),
+		},
+		Review: ReviewConfig{
+			MaxLines:         cmd.Int("review-max-lines"),
+			MaxFiles:         cmd.Int("review-max-files"),
+			EnforceSize:      cmd.Bool("review-enforce-size"),
+			Checklist:        cmd.StringSlice("review-checklist"),
+			RequireChecklist: cmd.Bool("review-require-checklist"),
+			RereviewChanged:  cmd.Bool("review-rereview-changed"),
+			TemplateCheck:    cmd.Bool("review-template-check"),
+			TemplatePrompt:   cmd.Bool("review-template-prompt"),
+		},
+		Priority: PriorityConfig{
+			Sort:      cmd.String("priority-sort"),
+			Risk:      cmd.Float("priority-risk"),
+			Checks:    cmd.Float("priority-checks"),
+			Staleness: cmd.Float("priority-staleness"),
+			Size:      cmd.Float("priority-size"),
+		},
+		Classify: ClassifyConfig{
+			Order:              cmd.StringSlice("classify-order"),
+			DependencyKeywords: cmd.StringSlice("classify-dependency-keywords"),
+			DocsKeywords:       cmd.StringSlice("classify-docs-keywords"),
+			DependencyAuthors:  cmd.StringSlice("classify-dependency-authors"),
+		},
+		Policy: PolicyConfig{
+			Rules:         cmd.StringSlice("policy-rules"),
+			BusinessHours: cmd.String("policy-business-hours"),
+		},
+		Digest: DigestConfig{
+			SMTPAddr:     cmd.String("digest-smtp-addr"),
+			SMTPUsername: cmd.String("digest-smtp-username"),
+			SMTPPassword: cmd.String("digest-smtp-password"),
+			From:         cmd.String("digest-from"),
+			To:           cmd.StringSlice("digest-to"),
+		},
+		Freeze: FreezeConfig{
+			Windows: cmd.StringSlice("freeze-windows"),
+		},
+		Remind: RemindConfig{
+			Method:     cmd.String("remind-method"),
+			Dir:        cmd.String("remind-dir"),
+			RemindFile: cmd.String("remind-file"),
+			Open:       cmd.Bool("remind-open"),
+		},
+		Plugins: PluginsConfig{
+			Enabled: cmd.Bool("plugins"),
+			Timeout: cmd.Duration("plugins-timeout"),
+		},
+		Hooks: HooksConfig{
+			OnApprove:          cmd.String("hooks-on-approve"),
+			OnMerge:            cmd.String("hooks-on-merge"),
+			OnAnalysisComplete: cmd.String("hooks-on-analysis-complete"),
+			Timeout:            cmd.Duration("hooks-timeout"),
+		},
+		UI: UIConfig{
+			Accessible:    accessible,
+			ReducedMotion: cmd.Bool("reduced-motion"),
+			ShowReasoning: cmd.Bool("ui-show-reasoning"),
+			StatePath:     cmd.String("ui-state-path"),
 		},
 		Log: LogConfig{
 			Level: cmd.String("log-level"),
 			Path:  cmd.String("log-path"),
 		},
 		Client: ClientConfig{
-			Timeout: globalClientTimeout,
+			Timeout:            globalClientTimeout,
+			CABundle:           cmd.String("client-ca-bundle"),
+			InsecureSkipVerify: cmd.Bool("client-insecure-skip-verify"),
+			Record:             cmd.String("record"),
+			Replay:             cmd.String("replay"),
+			Demo:               cmd.Bool("demo"),
 		},
 		Backoff: backoffconfig.GlobalConfig{
 			Default: globalBackoff,
@@ -165,8 +559,141 @@ func getFloat64WithFallback(cmd *cli.Command, flagName string, fallback float64)
 	return fallback
 }
 
+// optionalFloat64 returns the CLI value if set, otherwise nil
+func optionalFloat64(cmd *cli.Command, flagName string) *float64 {
+	if !cmd.IsSet(flagName) {
+		return nil
+	}
+	v := cmd.Float64(flagName)
+	return &v
+}
+
+// UseDemo sets up a session on the built-in demo PRs. It needs no
+// credentials, and nothing is cached, written or sent to a real tracker.
+func (c *Config) UseDemo() {
+	c.GitHub.Token = "demo"
+	c.GitHub.SearchAPI = "rest"
+	c.GitHub.Queues, c.GitHub.MyPRs = nil, false
+	c.GitHub.AllowedRepos, c.GitHub.DeniedRepos = nil, nil
+	c.GitHub.DryRun = true
+	c.AI.Enabled = true
+	c.AI.Provider, c.AI.APIKey, c.AI.BaseURL = "openai", "demo", ""
+	c.Cache.Enabled = false
+	c.Tracker = TrackerConfig{Type: "github"}
+}
+
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
-	// Validation will be added as needed
+	switch c.Tracker.Type {
+	case "", "github":
+	case "jira":
+		if c.Tracker.URL == "" {
+			return fmt.Errorf("tracker.url is required for the jira tracker")
+		}
+	default:
+		return fmt.Errorf("unknown tracker type %q (want jira or github)", c.Tracker.Type)
+	}
+
+	switch c.GitHub.SearchAPI {
+	case "", "auto", "rest", "graphql":
+	default:
+		return fmt.Errorf("unknown github.search_api %q (want auto, rest or graphql)", c.GitHub.SearchAPI)
+	}
+
+	switch c.AI.Provider {
+	case "", "openai":
+	case "azure":
+		if c.AI.Enabled && (c.AI.BaseURL == "" || c.AI.Deployment == "") {
+			return fmt.Errorf("ai.base_url and ai.deployment are required for the azure provider")
+		}
+	case "gemini":
+		if c.AI.Enabled && strings.HasPrefix(c.AI.Model, "gpt-") {
+			return fmt.Errorf("ai.model %q isn't a Gemini model (e.g., gemini-2.5-flash)", c.AI.Model)
+		}
+	default:
+		return fmt.Errorf("unknown ai.provider %q (want openai, azure or gemini)", c.AI.Provider)
+	}
+
+	switch c.AI.ReasoningEffort {
+	case "", "low", "medium", "high":
+	default:
+		return fmt.Errorf("unknown ai.reasoning_effort %q (want low, medium or high)", c.AI.ReasoningEffort)
+	}
+
+	if t := c.AI.Temperature; t != nil && (*t < 0 || *t > 2) {
+		return fmt.Errorf("ai.temperature must be between 0 and 2")
+	}
+	if p := c.AI.TopP; p != nil && (*p < 0 || *p > 1) {
+		return fmt.Errorf("ai.top_p must be between 0 and 1")
+	}
+	if c.AI.MaxTokens < 0 {
+		return fmt.Errorf("ai.max_tokens must not be negative")
+	}
+	for _, entry := range c.AI.ExtraParams {
+		if name, _, ok := strings.Cut(entry, "="); !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("invalid ai.extra_params entry %q (want name=value)", entry)
+		}
+	}
+	for _, entry := range c.AI.Headers {
+		if name, _, ok := strings.Cut(entry, "="); !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("invalid ai.headers entry %q (want name=value)", entry)
+		}
+	}
+	for name, tool := range c.AI.Tools {
+		if tool.Timeout < 0 {
+			return fmt.Errorf("ai.tools.%s.timeout must not be negative", name)
+		}
+		if tool.MaxBytes < 0 {
+			return fmt.Errorf("ai.tools.%s.max_bytes must not be negative", name)
+		}
+	}
+
+	switch c.AI.Trigger {
+	case "", AITriggerEager, AITriggerOnSelect:
+	default:
+		return fmt.Errorf("unknown ai.trigger %q (want eager or on_select)", c.AI.Trigger)
+	}
+
+	switch c.Priority.Sort {
+	case "", SortAttention, SortSearch:
+	default:
+		return fmt.Errorf("unknown priority.sort %q (want attention or search)", c.Priority.Sort)
+	}
+	if c.Priority.Risk < 0 || c.Priority.Checks < 0 || c.Priority.Staleness < 0 || c.Priority.Size < 0 {
+		return fmt.Errorf("priority weights must not be negative")
+	}
+
+	if c.Cache.RemoteURL != "" {
+		if u, err := url.Parse(c.Cache.RemoteURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("invalid cache.remote_url %q (want an http or https URL)", c.Cache.RemoteURL)
+		}
+	}
+
+	if c.Serve.Interval < 0 {
+		return fmt.Errorf("serve.interval must not be negative")
+	}
+
+	if c.Review.MaxLines < 0 || c.Review.MaxFiles < 0 {
+		return fmt.Errorf("review.max_lines and review.max_files must not be negative")
+	}
+
+	if _, err := c.Classify.Classifier(); err != nil {
+		return fmt.Errorf("invalid classify.order: %w", err)
+	}
+
+	if _, err := policy.New(c.Policy.Rules, c.Policy.BusinessHours); err != nil {
+		return fmt.Errorf("invalid policy: %w", err)
+	}
+	if _, err := freeze.Parse(c.Freeze.Windows); err != nil {
+		return err
+	}
+
+	if c.Client.Record != "" && c.Client.Replay != "" {
+		return fmt.Errorf("--record and --replay can't be used together")
+	}
+	if c.Client.Demo && (c.Client.Record != "" || c.Client.Replay != "") {
+		return fmt.Errorf("--demo can't be used with --record or --replay")
+	}
+
 	return nil
 }
@@ -2,9 +2,12 @@ package config
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"maps"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -79,6 +82,22 @@ func (ovs *OpValueSource) Lookup() (string, bool) {
 			return strings.Join(ss, ","), ok
 		}
 
+		// Try to unmarshal as a table, for name=value lists like ai.headers.
+		// Values that aren't strings are written as JSON.
+		vvm := struct{ Value map[string]any }{}
+		if err := toml.Unmarshal(raw, &vvm); err == nil && vvm.Value != nil {
+			pairs := make([]string, 0, len(vvm.Value))
+			for _, name := range slices.Sorted(maps.Keys(vvm.Value)) {
+				value, isString := vvm.Value[name].(string)
+				if !isString {
+					data, _ := json.Marshal(vvm.Value[name])
+					value = string(data)
+				}
+				pairs = append(pairs, name+"="+value)
+			}
+			return strings.Join(pairs, ","), ok
+		}
+
 		// Fall back to standard string representation for non-slice types
 		return fmt.Sprintf("%[1]v", v), ok
 	}
@@ -86,6 +105,11 @@ func (ovs *OpValueSource) Lookup() (string, bool) {
 	return "", false
 }
 
+// Key returns the config file key the value is read from, such as ai.model
+func (ovs *OpValueSource) Key() string {
+	return ovs.key
+}
+
 // opTOMLUnmarshal processes 1Password references in TOML data and then uses
 // the official toml.Unmarshal for proper type handling including slices.
 func opTOMLUnmarshal(data []byte, v any) error {
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Connection pool settings for the shared transport. Hydrating a queue makes
+// many concurrent requests to the same few hosts, and the default of 2 idle
+// connections per host means a new TLS handshake for most of them.
+const (
+	maxIdleConns        = 100
+	maxIdleConnsPerHost = 32
+	idleConnTimeout     = 90 * time.Second
+)
+
+// Transport returns the HTTP transport shared by every client speedrun makes,
+// pooling keep-alive connections and using HTTP/2 where the server offers it.
+// It goes through the proxy from HTTP_PROXY, HTTPS_PROXY and NO_PROXY and
+// trusts CABundle on top of the system roots, for corporate TLS-intercepting
+// proxies.
+func (c ClientConfig) Transport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	transport.ForceAttemptHTTP2 = true
+	transport.MaxIdleConns = maxIdleConns
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	transport.IdleConnTimeout = idleConnTimeout
+
+	if c.CABundle == "" && !c.InsecureSkipVerify {
+		return transport, nil
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: c.InsecureSkipVerify} //nolint:gosec // Opted into by the user
+	if c.CABundle != "" {
+		pem, err := os.ReadFile(c.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", c.CABundle)
+		}
+		tlsConfig.RootCAs = roots
+	}
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
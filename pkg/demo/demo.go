@@ -0,0 +1,443 @@
+// Package demo serves a synthetic GitHub and AI backend, so speedrun can be
+// tried without a token and its UI captured without touching real PRs. The
+// demo PRs go through the same clients as real ones; only the transport
+// underneath them is fake.
+package demo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// logHost serves Actions job logs, which GitHub redirects to outside the API
+const logHost = "https://logs.demo.invalid"
+
+// Transport answers the GitHub and OpenAI requests speedrun makes with the
+// demo PRs. Anything it doesn't know is a 404.
+type Transport struct {
+	mux    *http.ServeMux
+	prs    map[string]*PR
+	heads  map[string]*PR // By owner/repo@head SHA
+	issues map[string]*Issue
+	now    time.Time
+}
+
+// NewTransport serves PRs and Issues, with times relative to now
+func NewTransport() *Transport {
+	return NewTransportFor(PRs)
+}
+
+// NewTransportFor serves prs, such as a Synthetic load, instead of the demo
+// PRs
+func NewTransportFor(prs []PR) *Transport {
+	t := &Transport{
+		mux:    http.NewServeMux(),
+		prs:    make(map[string]*PR),
+		heads:  make(map[string]*PR),
+		issues: make(map[string]*Issue),
+		now:    time.Now().Truncate(time.Minute),
+	}
+	for i := range prs {
+		pr := &prs[i]
+		t.prs[pr.Ref()] = pr
+		t.heads[pr.Owner+"/"+pr.Repo+"@"+pr.HeadSHA()] = pr
+	}
+	for i := range Issues {
+		issue := &Issues[i]
+		t.issues[fmt.Sprintf("%s/%s#%d", issue.Owner, issue.Repo, issue.Number)] = issue
+	}
+
+	t.mux.HandleFunc("GET /user", t.user)
+	t.mux.HandleFunc("GET /users/{login}", t.user)
+	t.mux.HandleFunc("GET /orgs/{org}/repos", empty)
+	t.mux.HandleFunc("GET /search/issues", t.search)
+	t.mux.HandleFunc("GET /repos/{owner}/{repo}/issues/{number}", t.issue)
+	t.mux.HandleFunc("GET /repos/{owner}/{repo}/issues/{number}/comments", empty)
+	t.mux.HandleFunc("GET /repos/{owner}/{repo}/issues/{number}/timeline", empty)
+	t.mux.HandleFunc("GET /repos/{owner}/{repo}/pulls/{number}", t.pull)
+	t.mux.HandleFunc("GET /repos/{owner}/{repo}/pulls/{number}/files", t.files)
+	t.mux.HandleFunc("GET /repos/{owner}/{repo}/pulls/{number}/commits", t.commits)
+	t.mux.HandleFunc("GET /repos/{owner}/{repo}/pulls/{number}/reviews", t.reviews)
+	t.mux.HandleFunc("GET /repos/{owner}/{repo}/pulls/{number}/comments", empty)
+	t.mux.HandleFunc("GET /repos/{owner}/{repo}/pulls/{number}/requested_reviewers", t.requestedReviewers)
+	t.mux.HandleFunc("GET /repos/{owner}/{repo}/commits/{sha}/check-runs", t.checkRuns)
+	t.mux.HandleFunc("GET /repos/{owner}/{repo}/commits/{sha}/status", t.status)
+	t.mux.HandleFunc("GET /repos/{owner}/{repo}/contents/{path...}", contents)
+	t.mux.HandleFunc("GET /repos/{owner}/{repo}/rules/branches/{branch...}", empty)
+	t.mux.HandleFunc("GET /repos/{owner}/{repo}/dependabot/alerts", empty)
+	t.mux.HandleFunc("GET /repos/{owner}/{repo}/actions/jobs/{id}/logs", t.jobLogURL)
+	t.mux.HandleFunc("GET /logs/{owner}/{repo}/{id}", t.jobLog)
+	t.mux.HandleFunc("POST /v1/chat/completions", t.chat)
+	t.mux.HandleFunc("/", notFound)
+	return t
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The mux records the matched pattern on the request, which isn't ours
+	req = req.Clone(req.Context())
+	rec := httptest.NewRecorder()
+	t.mux.ServeHTTP(rec, req)
+	resp := rec.Result()
+	resp.Request = req
+	return resp, nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func notFound(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = io.WriteString(w, `{"message":"Not Found"}`)
+}
+
+func empty(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, []any{})
+}
+
+// lookup finds the demo PR a request is about, answering 404 if there isn't one
+func (t *Transport) lookup(w http.ResponseWriter, r *http.Request) (*PR, bool) {
+	pr, ok := t.prs[fmt.Sprintf("%s/%s#%s", r.PathValue("owner"), r.PathValue("repo"), r.PathValue("number"))]
+	if !ok {
+		notFound(w, r)
+	}
+	return pr, ok
+}
+
+func (t *Transport) user(w http.ResponseWriter, r *http.Request) {
+	login := r.PathValue("login")
+	if login == "" {
+		login = User
+		w.Header().Set("X-OAuth-Scopes", "repo, read:org, security_events")
+	}
+	writeJSON(w, map[string]any{"login": login, "type": "User"})
+}
+
+// contents serves the demo PR templates; there are no other files
+func contents(w http.ResponseWriter, r *http.Request) {
+	tmpl, ok := Templates[r.PathValue("owner")+"/"+r.PathValue("repo")]
+	if !ok || r.PathValue("path") != ".github/pull_request_template.md" {
+		notFound(w, r)
+		return
+	}
+	writeJSON(w, map[string]any{
+		"type":     "file",
+		"path":     r.PathValue("path"),
+		"encoding": "base64",
+		"content":  base64.StdEncoding.EncodeToString([]byte(tmpl)),
+	})
+}
+
+func (t *Transport) search(w http.ResponseWriter, r *http.Request) {
+	prs := make([]*PR, 0, len(t.prs))
+	for _, pr := range t.prs {
+		prs = append(prs, pr)
+	}
+	sort.Slice(prs, func(i, j int) bool { return prs[i].Age < prs[j].Age })
+
+	items := make([]any, len(prs))
+	for i, pr := range prs {
+		items[i] = t.issueJSON(pr)
+	}
+	writeJSON(w, map[string]any{"total_count": len(items), "incomplete_results": false, "items": items})
+}
+
+func (t *Transport) issue(w http.ResponseWriter, r *http.Request) {
+	key := fmt.Sprintf("%s/%s#%s", r.PathValue("owner"), r.PathValue("repo"), r.PathValue("number"))
+	if pr, ok := t.prs[key]; ok {
+		writeJSON(w, t.issueJSON(pr))
+		return
+	}
+	issue, ok := t.issues[key]
+	if !ok {
+		notFound(w, r)
+		return
+	}
+	writeJSON(w, map[string]any{
+		"number":   issue.Number,
+		"title":    issue.Title,
+		"state":    issue.State,
+		"url":      fmt.Sprintf("https://api.github.com/repos/%s/%s/issues/%d", issue.Owner, issue.Repo, issue.Number),
+		"html_url": fmt.Sprintf("https://github.com/%s/%s/issues/%d", issue.Owner, issue.Repo, issue.Number),
+	})
+}
+
+// issueJSON is a PR as the search and issues APIs return it
+func (t *Transport) issueJSON(pr *PR) map[string]any {
+	labels := make([]any, len(pr.Labels))
+	for i, label := range pr.Labels {
+		labels[i] = map[string]any{"name": label}
+	}
+	return map[string]any{
+		"number":     pr.Number,
+		"title":      pr.Title,
+		"body":       pr.Body,
+		"state":      "open",
+		"url":        fmt.Sprintf("https://api.github.com/repos/%s/%s/issues/%d", pr.Owner, pr.Repo, pr.Number),
+		"html_url":   fmt.Sprintf("https://github.com/%s/%s/pull/%d", pr.Owner, pr.Repo, pr.Number),
+		"user":       map[string]any{"login": pr.Author},
+		"labels":     labels,
+		"created_at": t.now.Add(-pr.Age),
+		"updated_at": t.now.Add(-pr.Idle),
+		"pull_request": map[string]any{
+			"url": fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls/%d", pr.Owner, pr.Repo, pr.Number),
+		},
+	}
+}
+
+func (t *Transport) pull(w http.ResponseWriter, r *http.Request) {
+	pr, ok := t.lookup(w, r)
+	if !ok {
+		return
+	}
+	if strings.Contains(r.Header.Get("Accept"), "diff") {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = io.WriteString(w, diff(pr))
+		return
+	}
+
+	repo := map[string]any{"full_name": pr.Owner + "/" + pr.Repo}
+	writeJSON(w, map[string]any{
+		"number":        pr.Number,
+		"title":         pr.Title,
+		"body":          pr.Body,
+		"state":         "open",
+		"html_url":      fmt.Sprintf("https://github.com/%s/%s/pull/%d", pr.Owner, pr.Repo, pr.Number),
+		"user":          map[string]any{"login": pr.Author},
+		"head":          map[string]any{"sha": pr.HeadSHA(), "ref": "demo/" + strconv.Itoa(pr.Number), "repo": repo},
+		"base":          map[string]any{"ref": "main", "repo": repo},
+		"additions":     pr.additions(),
+		"deletions":     pr.deletions(),
+		"changed_files": len(pr.Files),
+		"commits":       len(pr.Commits),
+		"mergeable":     true,
+		"created_at":    t.now.Add(-pr.Age),
+		"updated_at":    t.now.Add(-pr.Idle),
+	})
+}
+
+// diff renders a demo PR's files as a unified diff
+func diff(pr *PR) string {
+	var b strings.Builder
+	for _, f := range pr.Files {
+		fmt.Fprintf(&b, "diff --git a/%s b/%s\n--- a/%s\n+++ b/%s\n", f.Path, f.Path, f.Path, f.Path)
+		if f.Patch != "" {
+			b.WriteString(f.Patch + "\n")
+		}
+	}
+	return b.String()
+}
+
+func (t *Transport) files(w http.ResponseWriter, r *http.Request) {
+	pr, ok := t.lookup(w, r)
+	if !ok {
+		return
+	}
+	files := make([]any, len(pr.Files))
+	for i, f := range pr.Files {
+		status := "modified"
+		if f.Deletions == 0 {
+			status = "added"
+		}
+		files[i] = map[string]any{
+			"filename":  f.Path,
+			"status":    status,
+			"additions": f.Additions,
+			"deletions": f.Deletions,
+			"changes":   f.Additions + f.Deletions,
+			"patch":     f.Patch,
+		}
+	}
+	writeJSON(w, files)
+}
+
+func (t *Transport) commits(w http.ResponseWriter, r *http.Request) {
+	pr, ok := t.lookup(w, r)
+	if !ok {
+		return
+	}
+	commits := make([]any, len(pr.Commits))
+	for i, c := range pr.Commits {
+		reason := "valid"
+		if !c.Verified {
+			reason = "unsigned"
+		}
+		commits[i] = map[string]any{
+			"sha":    pr.commitSHA(i),
+			"author": map[string]any{"login": pr.Author},
+			"commit": map[string]any{
+				"message":      c.Message,
+				"verification": map[string]any{"verified": c.Verified, "reason": reason},
+			},
+		}
+	}
+	writeJSON(w, commits)
+}
+
+func (t *Transport) reviews(w http.ResponseWriter, r *http.Request) {
+	pr, ok := t.lookup(w, r)
+	if !ok {
+		return
+	}
+	reviews := make([]any, len(pr.Reviews))
+	for i, review := range pr.Reviews {
+		reviews[i] = map[string]any{
+			"id":        pr.Number*100 + i,
+			"user":      map[string]any{"login": review.User},
+			"state":     review.State,
+			"body":      review.Body,
+			"commit_id": pr.HeadSHA(),
+		}
+	}
+	writeJSON(w, reviews)
+}
+
+func (t *Transport) requestedReviewers(w http.ResponseWriter, r *http.Request) {
+	if _, ok := t.lookup(w, r); !ok {
+		return
+	}
+	writeJSON(w, map[string]any{"users": []any{map[string]any{"login": User}}, "teams": []any{}})
+}
+
+// headPR finds the demo PR whose head commit a request is about
+func (t *Transport) headPR(w http.ResponseWriter, r *http.Request) (*PR, bool) {
+	pr, ok := t.heads[r.PathValue("owner")+"/"+r.PathValue("repo")+"@"+r.PathValue("sha")]
+	if !ok {
+		notFound(w, r)
+	}
+	return pr, ok
+}
+
+func (t *Transport) checkRuns(w http.ResponseWriter, r *http.Request) {
+	pr, ok := t.headPR(w, r)
+	if !ok {
+		return
+	}
+	runs := make([]any, len(pr.Checks))
+	for i, check := range pr.Checks {
+		id := int64(pr.Number)*100 + int64(i)
+		run := map[string]any{
+			"id":         id,
+			"name":       check.Name,
+			"status":     check.Status,
+			"output":     map[string]any{"summary": check.Summary},
+			"html_url":   fmt.Sprintf("https://github.com/%s/%s/actions/runs/%d", pr.Owner, pr.Repo, id),
+			"app":        map[string]any{"slug": "github-actions"},
+			"started_at": t.now.Add(-pr.Idle),
+		}
+		if check.Status == "completed" {
+			run["conclusion"] = check.Conclusion
+		}
+		runs[i] = run
+	}
+	writeJSON(w, map[string]any{"total_count": len(runs), "check_runs": runs})
+}
+
+func (t *Transport) status(w http.ResponseWriter, r *http.Request) {
+	if _, ok := t.headPR(w, r); !ok {
+		return
+	}
+	writeJSON(w, map[string]any{"state": "success", "statuses": []any{}})
+}
+
+// jobCheck finds the check a job ID was made from
+func (t *Transport) jobCheck(owner, repo, id string) (*Check, bool) {
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, false
+	}
+	pr, ok := t.prs[fmt.Sprintf("%s/%s#%d", owner, repo, n/100)]
+	if !ok || n%100 >= len(pr.Checks) {
+		return nil, false
+	}
+	return &pr.Checks[n%100], true
+}
+
+func (t *Transport) jobLogURL(w http.ResponseWriter, r *http.Request) {
+	if _, ok := t.jobCheck(r.PathValue("owner"), r.PathValue("repo"), r.PathValue("id")); !ok {
+		notFound(w, r)
+		return
+	}
+	w.Header().Set("Location", fmt.Sprintf("%s/logs/%s/%s/%s", logHost, r.PathValue("owner"), r.PathValue("repo"), r.PathValue("id")))
+	w.WriteHeader(http.StatusFound)
+}
+
+func (t *Transport) jobLog(w http.ResponseWriter, r *http.Request) {
+	check, ok := t.jobCheck(r.PathValue("owner"), r.PathValue("repo"), r.PathValue("id"))
+	if !ok {
+		notFound(w, r)
+		return
+	}
+	stamp := t.now.UTC().Format("2006-01-02T15:04:05.0000000Z")
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	for line := range strings.SplitSeq(check.Log, "\n") {
+		fmt.Fprintf(w, "%s %s\n", stamp, line)
+	}
+}
+
+// promptPR matches the PR the review prompt is about
+var promptPR = regexp.MustCompile(`github\.com/([^/\s]+)/([^/\s]+)/pull/(\d+)`)
+
+// chat answers an analysis request with the PR's canned analysis. The model
+// never calls tools.
+func (t *Transport) chat(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Model    string `json:"model"`
+		Messages []struct {
+			Content json.RawMessage `json:"content"`
+		} `json:"messages"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	answer := Analysis{
+		Recommendation: "REVIEW", Risk: "MEDIUM", Type: "CODE",
+		Reasoning: "This PR isn't part of the demo, so there's no canned analysis for it.",
+	}
+	for _, message := range req.Messages {
+		var text string
+		if err := json.Unmarshal(message.Content, &text); err != nil {
+			text = string(message.Content) // Content parts
+		}
+		match := promptPR.FindStringSubmatch(text)
+		if match == nil {
+			continue
+		}
+		if pr, ok := t.prs[fmt.Sprintf("%s/%s#%s", match[1], match[2], match[3])]; ok {
+			answer = pr.Analysis
+		}
+		break
+	}
+
+	content := answer.text()
+	message := map[string]any{"role": "assistant", "content": content}
+	if answer.Thinking != "" {
+		message["reasoning_content"] = answer.Thinking
+	}
+	writeJSON(w, map[string]any{
+		"id":      "chatcmpl-demo",
+		"object":  "chat.completion",
+		"created": t.now.Unix(),
+		"model":   req.Model,
+		"choices": []any{map[string]any{
+			"index":         0,
+			"finish_reason": "stop",
+			"message":       message,
+		}},
+		"usage": map[string]any{"prompt_tokens": 0, "completion_tokens": len(content) / 4, "total_tokens": len(content) / 4},
+	})
+}
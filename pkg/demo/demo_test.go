@@ -0,0 +1,109 @@
+package demo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/agent"
+	backoffconfig "github.com/kennyp/speedrun/pkg/backoff"
+	"github.com/kennyp/speedrun/pkg/cache"
+	"github.com/kennyp/speedrun/pkg/github"
+)
+
+func TestDemoPRsLoad(t *testing.T) {
+	ctx := context.Background()
+	client, err := github.NewClient(ctx, "demo", "is:pr is:open", cache.NewNoOpCache(), backoffconfig.Config{MaxElapsedTime: time.Second}, github.ChecksConfig{}, 0, NewTransport())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user, err := client.AuthenticatedUser(ctx)
+	if err != nil || user != User {
+		t.Fatalf("AuthenticatedUser() = %q, %v, want %q", user, err, User)
+	}
+
+	prs, err := client.SearchPullRequests(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prs) != len(PRs) {
+		t.Fatalf("found %d PRs, want %d", len(prs), len(PRs))
+	}
+
+	states := make(map[string]bool)
+	for _, pr := range prs {
+		if pr.HeadSHA == "" {
+			t.Errorf("%s/%s#%d has no head commit", pr.Owner, pr.Repo, pr.Number)
+		}
+		status, err := pr.GetCheckStatus(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		states[status.State] = true
+		if _, err := pr.GetDiffStats(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := pr.GetCommitSignatures(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, state := range []string{"success", "pending", "failure"} {
+		if !states[state] {
+			t.Errorf("no demo PR has %s checks", state)
+		}
+	}
+}
+
+func TestSyntheticPRsLoad(t *testing.T) {
+	ctx := context.Background()
+	client, err := github.NewClient(ctx, "demo", "is:pr is:open", cache.NewNoOpCache(), backoffconfig.Config{MaxElapsedTime: time.Second}, github.ChecksConfig{}, 0, NewTransportFor(Synthetic(50)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prs, err := client.SearchPullRequests(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prs) != 50 {
+		t.Fatalf("found %d PRs, want 50", len(prs))
+	}
+
+	// Copies of a demo PR are told apart by number, head commit included
+	last := prs[len(prs)-1]
+	if _, err := last.GetCheckStatus(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDemoFailedCheckLogs(t *testing.T) {
+	ctx := context.Background()
+	client, err := github.NewClient(ctx, "demo", "", cache.NewNoOpCache(), backoffconfig.Config{MaxElapsedTime: time.Second}, github.ChecksConfig{}, 0, NewTransport())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The failing test check of acme/api#1293
+	tail, err := client.GetJobLogTail(ctx, "acme", "api", 1293*100+1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "FAIL\nFAIL\tgithub.com/acme/api/internal/session\t2.214s\n##[error]Process completed with exit code 1."
+	if tail != want {
+		t.Errorf("log tail = %q, want %q", tail, want)
+	}
+}
+
+func TestDemoAnalysis(t *testing.T) {
+	ai := agent.NewAgent(agent.Endpoint{APIKey: "demo", Transport: NewTransport()}, "demo-model", backoffconfig.Config{MaxElapsedTime: time.Second}, nil, time.Second, 0, nil)
+
+	for _, pr := range PRs {
+		analysis, err := ai.AnalyzePR(context.Background(), agent.PRData{Snapshot: github.Snapshot{PR: &github.PullRequest{Owner: pr.Owner, Repo: pr.Repo, Number: pr.Number, Title: pr.Title}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(analysis.Recommendation) != pr.Analysis.Recommendation || analysis.RiskLevel != pr.Analysis.Risk || analysis.Reasoning != pr.Analysis.Reasoning {
+			t.Errorf("%s analysis = %+v, want %+v", pr.Ref(), analysis, pr.Analysis)
+		}
+	}
+}
@@ -0,0 +1,417 @@
+package demo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// User is who a demo session runs as
+const User = "demo-user"
+
+// PR is a synthetic pull request
+type PR struct {
+	Owner    string
+	Repo     string
+	Number   int
+	Title    string
+	Body     string
+	Author   string
+	Labels   []string
+	Age      time.Duration // Since it was opened
+	Idle     time.Duration // Since it was last updated
+	Files    []File
+	Checks   []Check
+	Commits  []Commit
+	Reviews  []Review
+	Analysis Analysis
+}
+
+// File is a file a demo PR changes
+type File struct {
+	Path      string
+	Additions int
+	Deletions int
+	Patch     string
+}
+
+// Check is a check run on a demo PR's head commit
+type Check struct {
+	Name       string
+	Status     string // queued, in_progress or completed
+	Conclusion string // success, failure, ... when completed
+	Summary    string
+	Log        string // Job log, for Actions checks
+}
+
+// Commit is a commit on a demo PR
+type Commit struct {
+	Message  string
+	Verified bool
+}
+
+// Review is a review left on a demo PR
+type Review struct {
+	User  string
+	State string // APPROVED, CHANGES_REQUESTED or COMMENTED
+	Body  string
+}
+
+// Analysis is the canned AI analysis of a demo PR, in the model's answer
+// format
+type Analysis struct {
+	Recommendation string // APPROVE, REVIEW or DEEP_REVIEW
+	Risk           string
+	Type           string
+	DocType        string
+	Reasoning      string
+	Thinking       string // Reasoning summary, as reasoning models return alongside the answer
+}
+
+// Issue is an issue demo PRs link to
+type Issue struct {
+	Owner  string
+	Repo   string
+	Number int
+	Title  string
+	State  string
+}
+
+// Ref returns the PR's owner/repo#number
+func (pr PR) Ref() string {
+	return fmt.Sprintf("%s/%s#%d", pr.Owner, pr.Repo, pr.Number)
+}
+
+// Synthetic returns n PRs for load testing: copies of the demo PRs, numbered
+// so that none of them clash
+func Synthetic(n int) []PR {
+	prs := make([]PR, n)
+	for i := range prs {
+		pr := PRs[i%len(PRs)]
+		pr.Number += 100000 * (i / len(PRs))
+		prs[i] = pr
+	}
+	return prs
+}
+
+// HeadSHA returns the PR's made-up head commit
+func (pr PR) HeadSHA() string {
+	return pr.commitSHA(len(pr.Commits) - 1)
+}
+
+func (pr PR) commitSHA(i int) string {
+	sha := fmt.Sprintf("%x", fmt.Sprintf("%s/%s/%d/%d", pr.Owner, pr.Repo, pr.Number, i))
+	for len(sha) < 40 {
+		sha += sha
+	}
+	return sha[:40]
+}
+
+func (pr PR) additions() (n int) {
+	for _, f := range pr.Files {
+		n += f.Additions
+	}
+	return n
+}
+
+func (pr PR) deletions() (n int) {
+	for _, f := range pr.Files {
+		n += f.Deletions
+	}
+	return n
+}
+
+// text renders the analysis the way the model answers
+func (a Analysis) text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "RECOMMENDATION: %s\nRISK_LEVEL: %s\nPR_TYPE: %s\n", a.Recommendation, a.Risk, a.Type)
+	if a.DocType != "" {
+		fmt.Fprintf(&b, "DOC_TYPE: %s\n", a.DocType)
+	}
+	fmt.Fprintf(&b, "REASONING: %s\n", a.Reasoning)
+	return b.String()
+}
+
+// hunk makes a patch of one hunk from +, - and context lines
+func hunk(lines ...string) string {
+	var removed, added int
+	for _, line := range lines {
+		switch line[0] {
+		case '-':
+			removed++
+		case '+':
+			added++
+		default:
+			removed++
+			added++
+		}
+	}
+	return fmt.Sprintf("@@ -1,%d +1,%d @@\n%s", removed, added, strings.Join(lines, "\n"))
+}
+
+const signoff = "\n\nSigned-off-by: "
+
+// PRs are the demo queue: a spread of sizes, check states, review states and
+// recommendations
+var PRs = []PR{
+	{
+		Owner: "acme", Repo: "web", Number: 412,
+		Title:  "docs: fix typos in the contributing guide",
+		Body:   "A few typos I tripped over while setting up.",
+		Author: "alice", Labels: []string{"documentation"},
+		Age: 3 * time.Hour, Idle: 2 * time.Hour,
+		Files: []File{{Path: "CONTRIBUTING.md", Additions: 2, Deletions: 2, Patch: hunk(
+			" ## Getting started",
+			"-Clone the repo and run `npm instal`.",
+			"+Clone the repo and run `npm install`.",
+			"-Tests run with `npm test` before evey push.",
+			"+Tests run with `npm test` before every push.",
+		)}},
+		Checks:  []Check{{Name: "lint", Status: "completed", Conclusion: "success", Summary: "No problems"}, {Name: "build", Status: "completed", Conclusion: "success", Summary: "Built in 41s"}},
+		Commits: []Commit{{Message: "docs: fix typos in the contributing guide" + signoff + "Alice <alice@acme.test>", Verified: true}},
+		Analysis: Analysis{
+			Recommendation: "APPROVE", Risk: "LOW", Type: "DOCUMENTATION", DocType: "GENERAL",
+			Reasoning: "Two spelling fixes in CONTRIBUTING.md with no change in meaning; checks pass.",
+		},
+	},
+	{
+		Owner: "acme", Repo: "api", Number: 1287,
+		Title:  "build(deps): bump golang.org/x/net from 0.23.0 to 0.25.0",
+		Body:   "Bumps [golang.org/x/net](https://github.com/golang/net) from 0.23.0 to 0.25.0.",
+		Author: "dependabot[bot]", Labels: []string{"dependencies", "go"},
+		Age: 26 * time.Hour, Idle: 20 * time.Hour,
+		Files: []File{
+			{Path: "go.mod", Additions: 1, Deletions: 1, Patch: hunk(
+				" require (",
+				"-\tgolang.org/x/net v0.23.0",
+				"+\tgolang.org/x/net v0.25.0",
+			)},
+			{Path: "go.sum", Additions: 2, Deletions: 2, Patch: hunk(
+				"-golang.org/x/net v0.23.0 h1:7EYJ93RZ9vYSZAIb2x3lnuvqO5zneoD6IvWjuhfxjTs=",
+				"-golang.org/x/net v0.23.0/go.mod h1:JKghWKKOSdJwpW2GEx0Ja7fmaKnMsbu+MWVZTokSYmg=",
+				"+golang.org/x/net v0.25.0 h1:d/OCCoBEUq33pjydKrGQhw7IlUPI2Oylr+8qLx49kac=",
+				"+golang.org/x/net v0.25.0/go.mod h1:JkAGAh7GEvH74S6FOH42FLoXpXbE/aqXSrIQjXgsiwM=",
+			)},
+		},
+		Checks:  []Check{{Name: "test", Status: "completed", Conclusion: "success", Summary: "412 passed"}, {Name: "lint", Status: "completed", Conclusion: "success", Summary: "No problems"}},
+		Commits: []Commit{{Message: "build(deps): bump golang.org/x/net from 0.23.0 to 0.25.0" + signoff + "dependabot[bot] <support@github.com>", Verified: true}},
+		Analysis: Analysis{
+			Recommendation: "APPROVE", Risk: "LOW", Type: "DEPENDENCY",
+			Reasoning: "Minor version bump of golang.org/x/net with security fixes for HTTP/2; only go.mod and go.sum change and tests pass.",
+		},
+	},
+	{
+		Owner: "acme", Repo: "api", Number: 1290,
+		Title:  "Add rate limiting to the public search endpoint",
+		Body:   "## Summary\n\nAdds a token bucket per API key in front of /v1/search, configurable per plan.\n\nFixes #1201\n\n## Testing done\n\nUnit tests for the bucket, and a load test on staging at twice the Pro limit.\n\n## Checklist\n\n- [x] Tests added or updated\n- [x] Docs updated",
+		Author: "bob", Labels: []string{"enhancement"},
+		Age: 30 * time.Hour, Idle: 40 * time.Minute,
+		Files: []File{
+			{Path: "internal/ratelimit/bucket.go", Additions: 96, Patch: hunk(
+				"+package ratelimit",
+				"+",
+				"+// Bucket is a token bucket refilled at a steady rate",
+				"+type Bucket struct {",
+				"+\tmu       sync.Mutex",
+				"+\ttokens   float64",
+				"+\tcapacity float64",
+				"+\trate     float64 // Tokens per second",
+				"+\tlast     time.Time",
+				"+}",
+			)},
+			{Path: "internal/ratelimit/bucket_test.go", Additions: 88, Patch: hunk(
+				"+func TestBucketRefills(t *testing.T) {",
+				"+\tb := NewBucket(2, 1)",
+				"+\tb.Take(2)",
+			)},
+			{Path: "internal/search/handler.go", Additions: 31, Deletions: 12, Patch: hunk(
+				" func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {",
+				"+\tif !h.limiter.Allow(apiKey(r)) {",
+				"+\t\thttp.Error(w, \"rate limit exceeded\", http.StatusTooManyRequests)",
+				"+\t\treturn",
+				"+\t}",
+			)},
+			{Path: "internal/config/plans.go", Additions: 18, Deletions: 9, Patch: hunk(
+				" type Plan struct {",
+				"-\tName string",
+				"+\tName          string",
+				"+\tSearchPerMin  int",
+			)},
+			{Path: "docs/api/search.md", Additions: 7, Deletions: 10, Patch: hunk(
+				" ## Limits",
+				"-Search is not rate limited.",
+				"+Search allows 60 requests a minute on the free plan.",
+			)},
+		},
+		Checks: []Check{
+			{Name: "lint", Status: "completed", Conclusion: "success", Summary: "No problems"},
+			{Name: "test", Status: "in_progress", Summary: "Running"},
+			{Name: "integration", Status: "queued"},
+		},
+		Commits: []Commit{
+			{Message: "Add a token bucket rate limiter" + signoff + "Bob <bob@acme.test>", Verified: true},
+			{Message: "Limit search requests per API key" + signoff + "Bob <bob@acme.test>", Verified: true},
+		},
+		Analysis: Analysis{
+			Recommendation: "REVIEW", Risk: "MEDIUM", Type: "CODE",
+			Reasoning: "New rate limiting on a public endpoint changes behavior for every client. The bucket is tested, but the per-plan limits and the 429 response deserve a human look, and tests are still running.",
+		},
+	},
+	{
+		Owner: "acme", Repo: "api", Number: 1293,
+		Title:  "Rewrite session token storage",
+		Body:   "Moves session tokens from the sessions table to Redis with hashed keys, and drops the old table.",
+		Author: "carol", Labels: []string{"security"},
+		Age: 4 * 24 * time.Hour, Idle: 5 * time.Hour,
+		Files: []File{
+			{Path: "internal/session/store.go", Additions: 312, Deletions: 201, Patch: hunk(
+				"-func (s *Store) Get(ctx context.Context, token string) (*Session, error) {",
+				"-\trow := s.db.QueryRowContext(ctx, \"SELECT user_id, expires FROM sessions WHERE token = $1\", token)",
+				"+func (s *Store) Get(ctx context.Context, token string) (*Session, error) {",
+				"+\tkey := \"session:\" + hashToken(token)",
+				"+\tdata, err := s.redis.Get(ctx, key).Bytes()",
+			)},
+			{Path: "internal/session/store_test.go", Additions: 180, Deletions: 122},
+			{Path: "internal/session/hash.go", Additions: 41, Patch: hunk(
+				"+// hashToken keeps raw tokens out of Redis",
+				"+func hashToken(token string) string {",
+				"+\tsum := sha256.Sum256([]byte(token))",
+				"+\treturn hex.EncodeToString(sum[:])",
+				"+}",
+			)},
+			{Path: "internal/auth/middleware.go", Additions: 64, Deletions: 58},
+			{Path: "migrations/0042_drop_sessions.sql", Additions: 3, Patch: hunk(
+				"+DROP TABLE sessions;",
+			)},
+			{Path: "cmd/api/main.go", Additions: 22, Deletions: 9},
+			{Path: "deploy/redis.yaml", Additions: 48},
+		},
+		Checks: []Check{
+			{Name: "lint", Status: "completed", Conclusion: "success", Summary: "No problems"},
+			{Name: "test", Status: "completed", Conclusion: "failure", Summary: "2 of 418 tests failed", Log: strings.Join([]string{
+				"=== RUN   TestSessionExpiry",
+				"    store_test.go:88: session still valid 2s after expiry",
+				"--- FAIL: TestSessionExpiry (2.01s)",
+				"=== RUN   TestSessionRevokeAll",
+				"    store_test.go:131: 3 sessions left after RevokeAll, want 0",
+				"--- FAIL: TestSessionRevokeAll (0.02s)",
+				"FAIL",
+				"FAIL\tgithub.com/acme/api/internal/session\t2.214s",
+				"##[error]Process completed with exit code 1.",
+			}, "\n")},
+			{Name: "security/scan", Status: "completed", Conclusion: "success", Summary: "No findings"},
+		},
+		Commits: []Commit{
+			{Message: "Store sessions in Redis" + signoff + "Carol <carol@acme.test>", Verified: true},
+			{Message: "Hash session keys" + signoff + "Carol <carol@acme.test>", Verified: true},
+			{Message: "Drop the sessions table" + signoff + "Carol <carol@acme.test>", Verified: true},
+		},
+		Reviews: []Review{{User: "erin", State: "COMMENTED", Body: "What happens to sessions created before the migration?"}},
+		Analysis: Analysis{
+			Recommendation: "DEEP_REVIEW", Risk: "HIGH", Type: "CODE",
+			Reasoning: "Rewrites authentication storage and drops a table irreversibly. Session expiry and revocation tests fail, which for session storage is a security issue, and existing sessions aren't migrated.",
+		},
+	},
+	{
+		Owner: "acme", Repo: "infra", Number: 88,
+		Title:  "Bump the staging node pool to n2-standard-8",
+		Body:   "Staging has been memory bound since the search indexer moved there.",
+		Author: "dave", Labels: []string{"infrastructure"},
+		Age: 7 * time.Hour, Idle: 6 * time.Hour,
+		Files: []File{{Path: "terraform/staging/gke.tf", Additions: 1, Deletions: 1, Patch: hunk(
+			" resource \"google_container_node_pool\" \"default\" {",
+			"-  machine_type = \"n2-standard-4\"",
+			"+  machine_type = \"n2-standard-8\"",
+		)}},
+		Checks:  []Check{{Name: "terraform plan", Status: "completed", Conclusion: "success", Summary: "1 to change, 0 to add, 0 to destroy"}},
+		Commits: []Commit{{Message: "Bump the staging node pool", Verified: false}},
+		Reviews: []Review{{User: "frank", State: "APPROVED", Body: "Plan looks right."}},
+		Analysis: Analysis{
+			Recommendation: "APPROVE", Risk: "LOW", Type: "CODE",
+			Reasoning: "One-line machine type change in staging with a clean plan and an approval. The commit is unsigned and has no DCO sign-off.",
+		},
+	},
+	{
+		Owner: "acme", Repo: "web", Number: 415,
+		Title:  "Add a dark mode toggle to the settings page",
+		Body:   "Adds a theme switch that follows the system setting by default.",
+		Author: "erin", Labels: []string{"enhancement", "ui"},
+		Age: 2 * 24 * time.Hour, Idle: 26 * time.Hour,
+		Files: []File{
+			{Path: "src/settings/ThemeToggle.tsx", Additions: 64, Patch: hunk(
+				"+export function ThemeToggle() {",
+				"+  const [theme, setTheme] = useTheme();",
+				"+  return <Switch checked={theme === \"dark\"} onChange={toggle} />;",
+				"+}",
+			)},
+			{Path: "src/settings/SettingsPage.tsx", Additions: 5, Deletions: 1},
+			{Path: "src/styles/theme.css", Additions: 48, Deletions: 3},
+		},
+		Checks:  []Check{{Name: "lint", Status: "completed", Conclusion: "success", Summary: "No problems"}, {Name: "build", Status: "completed", Conclusion: "success", Summary: "Built in 58s"}},
+		Commits: []Commit{{Message: "Add a dark mode toggle" + signoff + "Erin <erin@acme.test>", Verified: true}},
+		Reviews: []Review{{User: "alice", State: "CHANGES_REQUESTED", Body: "The toggle loses its label in high contrast mode."}},
+		Analysis: Analysis{
+			Recommendation: "REVIEW", Risk: "MEDIUM", Type: "CODE",
+			Reasoning: "Self-contained UI change with passing checks, but a reviewer requested changes for accessibility that haven't been addressed.",
+		},
+	},
+	{
+		Owner: "acme", Repo: "web", Number: 416,
+		Title:  "RFC: move the frontend build to Vite",
+		Body:   "Proposes replacing webpack with Vite. Looking for feedback before any code changes.",
+		Author: "frank", Labels: []string{"rfc"},
+		Age: 5 * 24 * time.Hour, Idle: 3 * 24 * time.Hour,
+		Files: []File{{Path: "docs/rfcs/0007-vite.md", Additions: 142, Patch: hunk(
+			"+# RFC 0007: Move the frontend build to Vite",
+			"+",
+			"+## Motivation",
+			"+",
+			"+Cold builds take four minutes and dev server reloads take ten seconds.",
+		)}},
+		Checks:  []Check{{Name: "lint", Status: "completed", Conclusion: "success", Summary: "No problems"}},
+		Commits: []Commit{{Message: "Add RFC 0007" + signoff + "Frank <frank@acme.test>", Verified: true}},
+		Analysis: Analysis{
+			Recommendation: "REVIEW", Risk: "LOW", Type: "DOCUMENTATION", DocType: "RFC",
+			Reasoning: "An RFC asking for a decision on the build tool; merging it signals agreement, so it needs a reader rather than a rubber stamp.",
+		},
+	},
+	{
+		Owner: "acme", Repo: "api", Number: 1295,
+		Title:  "Regenerate the API client from the OpenAPI spec",
+		Body:   "Picks up the new search parameters. Everything under client/ is generated.",
+		Author: "bob", Labels: []string{"generated"},
+		Age: 50 * time.Minute, Idle: 45 * time.Minute,
+		Files: []File{
+			{Path: "client/api.gen.go", Additions: 2890, Deletions: 2410, Patch: hunk(
+				"+// Code generated by oapi-codegen. DO NOT EDIT.",
+				" package client",
+			)},
+			{Path: "client/types.gen.go", Additions: 512, Deletions: 488, Patch: hunk(
+				"+// Code generated by oapi-codegen. DO NOT EDIT.",
+				" package client",
+			)},
+			{Path: "openapi.yaml", Additions: 24, Deletions: 2, Patch: hunk(
+				"       parameters:",
+				"+        - name: sort",
+				"+          in: query",
+			)},
+		},
+		Checks:  []Check{{Name: "test", Status: "completed", Conclusion: "success", Summary: "418 passed"}, {Name: "generate", Status: "completed", Conclusion: "success", Summary: "Generated code is up to date"}},
+		Commits: []Commit{{Message: "Regenerate the API client" + signoff + "Bob <bob@acme.test>", Verified: true}},
+		Analysis: Analysis{
+			Recommendation: "APPROVE", Risk: "LOW", Type: "CODE",
+			Reasoning: "Nearly all of the diff is generated client code; the hand-written change is two new optional query parameters in openapi.yaml, and the generate check confirms the code matches the spec.",
+			Thinking:  "Set the generated files aside and read openapi.yaml, where both new parameters are optional, so existing callers keep working.",
+		},
+	},
+}
+
+// Templates are the demo repositories' PR templates, by owner/repo
+var Templates = map[string]string{
+	"acme/api": "## Summary\n\n<!-- What does this change, and why? -->\n\n## Testing done\n\n<!-- How do you know it works? -->\n\n## Checklist\n\n- [ ] Tests added or updated\n- [ ] Docs updated\n",
+}
+
+// Issues are the issues demo PRs link to
+var Issues = []Issue{
+	{Owner: "acme", Repo: "api", Number: 1201, Title: "Search endpoint can be scraped without limits", State: "open"},
+}
@@ -0,0 +1,82 @@
+package deps
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// bumpPatterns match the titles of automated dependency update PRs. Each has
+// a "pkg" and a "version" group; "version" is the target version.
+var bumpPatterns = []*regexp.Regexp{
+	// Dependabot: "Bump lodash from 4.17.19 to 4.17.21", "chore(deps): bump X from Y to Z in /dir"
+	regexp.MustCompile(`(?i)\bbump (?P<pkg>\S+) from \S+ to (?P<version>\S+)`),
+	// Renovate: "Update dependency react to v18.2.0", "chore(deps): update module github.com/x/y to v1.2.3"
+	regexp.MustCompile(`(?i)\bupdate (?:dependency |module |package )?(?P<pkg>\S+) to (?P<version>v?\d\S*)`),
+}
+
+// Bump identifies a dependency update by package and target version
+type Bump struct {
+	Package string
+	Version string
+}
+
+// Key groups PRs making the same update, e.g. lodash@4.17.21
+func (b Bump) Key() string {
+	return b.Package + "@" + b.Version
+}
+
+// ParseTitle extracts the dependency bump from a PR title
+func ParseTitle(title string) (Bump, bool) {
+	for _, pattern := range bumpPatterns {
+		match := pattern.FindStringSubmatch(title)
+		if match == nil {
+			continue
+		}
+
+		bump := Bump{
+			Package: match[pattern.SubexpIndex("pkg")],
+			Version: strings.TrimRight(match[pattern.SubexpIndex("version")], ".,;:)"),
+		}
+		// Dependabot and Renovate disagree on the v prefix
+		bump.Version = strings.TrimPrefix(bump.Version, "v")
+		return bump, true
+	}
+	return Bump{}, false
+}
+
+// CompareVersions orders two versions by their dot-separated numeric parts,
+// returning -1, 0 or 1. Pre-release and build suffixes are ignored.
+func CompareVersions(a, b string) int {
+	as, bs := versionParts(a), versionParts(b)
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		var x, y int
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(version string) []int {
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	var parts []int
+	for _, field := range strings.Split(version, ".") {
+		n, _ := strconv.Atoi(field)
+		parts = append(parts, n)
+	}
+	return parts
+}
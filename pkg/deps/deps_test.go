@@ -0,0 +1,50 @@
+package deps
+
+import "testing"
+
+func TestParseTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  Bump
+		ok    bool
+	}{
+		{"Bump lodash from 4.17.19 to 4.17.21", Bump{"lodash", "4.17.21"}, true},
+		{"chore(deps): bump golang.org/x/net from 0.20.0 to 0.23.0 in /tools", Bump{"golang.org/x/net", "0.23.0"}, true},
+		{"[Security] Bump axios from 0.21.0 to 0.21.1.", Bump{"axios", "0.21.1"}, true},
+		{"Update dependency react to v18.2.0", Bump{"react", "18.2.0"}, true},
+		{"chore(deps): update module github.com/stretchr/testify to v1.9.0", Bump{"github.com/stretchr/testify", "1.9.0"}, true},
+		{"Update README to explain setup", Bump{}, false},
+		{"Fix login redirect", Bump{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseTitle(tt.title)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseTitle(%q) = %+v, %v; want %+v, %v", tt.title, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	a, _ := ParseTitle("Bump lodash from 4.17.19 to 4.17.21")
+	b, _ := ParseTitle("Update dependency lodash to v4.17.21")
+	if a.Key() != b.Key() {
+		t.Errorf("keys differ: %s vs %s", a.Key(), b.Key())
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"1.2.3", "1.10.0", -1},
+		{"v2.0.0", "1.99", 1},
+		{"1.2", "1.2.0", 0},
+		{"1.2.3-rc.1", "1.2.3", 0},
+	}
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Package digest renders the PR queue as a markdown or HTML report for the
+// on-call handoff, and emails it.
+package digest
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+)
+
+// Entry is one PR in the digest
+type Entry struct {
+	Ref       string   // owner/repo#number
+	Title     string   // PR title
+	URL       string   // Link to the PR
+	Labels    []string // Review state and flags such as "overdue" or "security"
+	Summary   string   // Age, diff, checks, reviews and AI recommendation
+	Reasoning string   // AI reasoning, empty without analysis
+}
+
+// Digest is a snapshot of the queue
+type Digest struct {
+	User      string
+	Generated time.Time
+	Entries   []Entry
+}
+
+// Subject is the default email subject
+func (d Digest) Subject() string {
+	return fmt.Sprintf("PR queue for %s: %d open (%s)", d.User, len(d.Entries), d.Generated.Format("Mon Jan 2"))
+}
+
+// Counts summarizes how many PRs carry each label, in order of first use
+func (d Digest) Counts() string {
+	var order []string
+	counts := make(map[string]int)
+	for _, entry := range d.Entries {
+		for _, label := range entry.Labels {
+			if counts[label] == 0 {
+				order = append(order, label)
+			}
+			counts[label]++
+		}
+	}
+
+	parts := []string{fmt.Sprintf("%d open", len(d.Entries))}
+	for _, label := range order {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[label], label))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Markdown renders the digest as markdown
+func (d Digest) Markdown() string {
+	var out strings.Builder
+	out.WriteString(fmt.Sprintf("# PR queue for %s\n\n", d.User))
+	out.WriteString(fmt.Sprintf("_%s — %s_\n", d.Generated.Format("Mon Jan 2, 2006 15:04 MST"), d.Counts()))
+
+	if len(d.Entries) == 0 {
+		out.WriteString("\nNo pull requests.\n")
+	}
+	for _, entry := range d.Entries {
+		out.WriteString(fmt.Sprintf("\n## [%s](%s): %s\n\n", entry.Ref, entry.URL, entry.Title))
+		if len(entry.Labels) > 0 {
+			out.WriteString(fmt.Sprintf("- **Status:** %s\n", strings.Join(entry.Labels, ", ")))
+		}
+		if entry.Summary != "" {
+			out.WriteString(fmt.Sprintf("- **Details:** %s\n", entry.Summary))
+		}
+		if reasoning := strings.TrimSpace(entry.Reasoning); reasoning != "" {
+			out.WriteString("\n> " + strings.ReplaceAll(reasoning, "\n", "\n> ") + "\n")
+		}
+	}
+	return out.String()
+}
+
+var htmlTemplate = template.Must(template.New("digest").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Subject}}</title></head>
+<body style="font-family: sans-serif">
+<h1>PR queue for {{.User}}</h1>
+<p><em>{{.Generated.Format "Mon Jan 2, 2006 15:04 MST"}} — {{.Counts}}</em></p>
+{{- if not .Entries}}
+<p>No pull requests.</p>
+{{- else}}
+<table cellpadding="6" style="border-collapse: collapse">
+<tr style="text-align: left"><th>PR</th><th>Title</th><th>Status</th></tr>
+{{- range .Entries}}
+<tr style="border-top: 1px solid #ddd; vertical-align: top">
+<td><a href="{{.URL}}">{{.Ref}}</a></td>
+<td>{{.Title}}{{if .Summary}}<br><small>{{.Summary}}</small>{{end}}</td>
+<td>{{range $i, $label := .Labels}}{{if $i}}, {{end}}{{$label}}{{end}}</td>
+</tr>
+{{- if .Reasoning}}
+<tr><td></td><td colspan="2"><small style="white-space: pre-wrap">{{.Reasoning}}</small></td></tr>
+{{- end}}
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+// HTML renders the digest as an HTML page suitable for email
+func (d Digest) HTML() (string, error) {
+	var out bytes.Buffer
+	if err := htmlTemplate.Execute(&out, d); err != nil {
+		return "", fmt.Errorf("failed to render digest: %w", err)
+	}
+	return out.String(), nil
+}
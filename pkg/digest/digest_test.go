@@ -0,0 +1,56 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func testDigest() Digest {
+	return Digest{
+		User:      "alice",
+		Generated: time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC),
+		Entries: []Entry{
+			{
+				Ref: "acme/api#12", Title: "Add <script> tags", URL: "https://github.com/acme/api/pull/12",
+				Labels: []string{"overdue", "reviewed"}, Summary: "waiting 2d | AI: APPROVE (LOW Risk)",
+				Reasoning: "Small change.\nTests pass.",
+			},
+			{Ref: "acme/web#3", Title: "Bump lodash", URL: "https://github.com/acme/web/pull/3", Labels: []string{"overdue"}},
+		},
+	}
+}
+
+func TestCounts(t *testing.T) {
+	if got, want := testDigest().Counts(), "2 open, 2 overdue, 1 reviewed"; got != want {
+		t.Errorf("Counts() = %q, want %q", got, want)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	got := testDigest().Markdown()
+	for _, want := range []string{
+		"# PR queue for alice\n",
+		"## [acme/api#12](https://github.com/acme/api/pull/12): Add <script> tags\n",
+		"- **Status:** overdue, reviewed\n",
+		"- **Details:** waiting 2d | AI: APPROVE (LOW Risk)\n",
+		"> Small change.\n> Tests pass.\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Markdown() missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestHTMLEscapes(t *testing.T) {
+	got, err := testDigest().HTML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(got, "<script>") || !strings.Contains(got, "Add &lt;script&gt; tags") {
+		t.Errorf("HTML() doesn't escape titles:\n%s", got)
+	}
+	if !strings.Contains(got, `<a href="https://github.com/acme/api/pull/12">acme/api#12</a>`) {
+		t.Errorf("HTML() missing PR link:\n%s", got)
+	}
+}
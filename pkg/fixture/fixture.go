@@ -0,0 +1,172 @@
+// Package fixture records HTTP responses to a directory and serves them back,
+// so speedrun can run offline against a captured session for demos and
+// deterministic tests.
+package fixture
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrNoFixture is returned in replay mode for requests that weren't recorded
+var ErrNoFixture = errors.New("no recorded fixture")
+
+// keptHeaders are the response headers worth replaying; the rest, like
+// cookies and request IDs, are left out of fixtures
+var keptHeaders = []string{
+	"Content-Type",
+	"Link",
+	"Location",
+	"X-Github-Request-Id",
+	"X-Oauth-Scopes",
+	"X-Ratelimit-Limit",
+	"X-Ratelimit-Remaining",
+	"X-Ratelimit-Reset",
+	"X-Ratelimit-Resource",
+}
+
+// fixture is one recorded response. The request is kept to make fixtures
+// readable; they are looked up by key.
+type fixture struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+}
+
+// key identifies a request by method, URL and body. Request headers, which
+// carry the tokens, don't count.
+func key(req *http.Request, body []byte) string {
+	sum := sha256.New()
+	fmt.Fprintf(sum, "%s %s\n", req.Method, req.URL.String())
+	sum.Write(body)
+	return hex.EncodeToString(sum.Sum(nil))[:20]
+}
+
+// readBody reads a request's body and puts it back for the next reader
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// Recorder saves every response that goes through it as a fixture in Dir
+type Recorder struct {
+	Base http.RoundTripper
+	Dir  string
+
+	mu sync.Mutex
+}
+
+// NewRecorder records the responses base returns into dir
+func NewRecorder(base http.RoundTripper, dir string) *Recorder {
+	return &Recorder{Base: base, Dir: dir}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.Base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	f := fixture{Method: req.Method, URL: req.URL.String(), Status: resp.StatusCode, Header: http.Header{}, Body: string(body)}
+	for _, name := range keptHeaders {
+		if values := resp.Header.Values(name); len(values) > 0 {
+			f.Header[name] = values
+		}
+	}
+	if err := r.save(key(req, reqBody), f); err != nil {
+		slog.Warn("Failed to record fixture", slog.String("url", f.URL), slog.Any("error", err))
+	}
+	return resp, nil
+}
+
+func (r *Recorder) save(name string, f fixture) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := os.MkdirAll(r.Dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(r.Dir, name+".json"), data, 0644)
+}
+
+// Replayer serves the fixtures in Dir instead of going to the network
+type Replayer struct {
+	Dir string
+}
+
+// NewReplayer serves responses recorded into dir
+func NewReplayer(dir string) *Replayer {
+	return &Replayer{Dir: dir}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(r.Dir, key(req, body)+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w for %s %s", ErrNoFixture, req.Method, req.URL)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture for %s %s: %w", req.Method, req.URL, err)
+	}
+
+	header := f.Header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.Status, http.StatusText(f.Status)),
+		StatusCode:    f.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(f.Body))),
+		ContentLength: int64(len(f.Body)),
+		Request:       req,
+	}, nil
+}
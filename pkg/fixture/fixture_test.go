@@ -0,0 +1,63 @@
+package fixture
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"path":"`+r.URL.Path+`","got":"`+string(body)+`"}`)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	recording := &http.Client{Transport: NewRecorder(http.DefaultTransport, dir)}
+	want := fetch(t, recording, srv.URL+"/graphql", "query")
+
+	replaying := &http.Client{Transport: NewReplayer(dir)}
+	resp, err := replaying.Post(srv.URL+"/graphql", "application/json", strings.NewReader("query"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := io.ReadAll(resp.Body)
+	if string(got) != want || resp.StatusCode != http.StatusCreated {
+		t.Errorf("replayed %d %s, want 201 %s", resp.StatusCode, got, want)
+	}
+	if resp.Header.Get("Content-Type") != "application/json" || resp.Header.Get("Set-Cookie") != "" {
+		t.Errorf("replayed headers %v", resp.Header)
+	}
+	if calls != 1 {
+		t.Errorf("server called %d times, want once while recording", calls)
+	}
+
+	// A different body is a different request
+	_, err = replaying.Post(srv.URL+"/graphql", "application/json", strings.NewReader("other"))
+	if !errors.Is(err, ErrNoFixture) {
+		t.Errorf("unrecorded request error = %v, want %v", err, ErrNoFixture)
+	}
+}
+
+func fetch(t *testing.T, client *http.Client, url, body string) string {
+	t.Helper()
+	resp, err := client.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,198 @@
+// Package freeze parses deploy freeze windows during which merges are off.
+//
+// A window is "[owner/repo pattern:] schedule [; reason]" where the schedule
+// is a weekday or weekday range with an optional time of day, or a date or
+// inclusive date range:
+//
+//	Fri; No merges on Fridays
+//	yourcompany/api: Mon-Thu 17:00-24:00
+//	2025-12-20..2026-01-04; Holiday freeze
+package freeze
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// Window is a recurring or one-off period when merging is frozen
+type Window struct {
+	Pattern string // owner/repo pattern, "*" for every repository
+	Reason  string // Shown when a merge is refused, may be empty
+
+	text string
+
+	// Weekly windows
+	days       [7]bool
+	start, end int // Minutes since midnight, end exclusive
+
+	// Date windows, from the start of from to the end of to
+	from, to time.Time
+}
+
+// Parse reads freeze windows
+func Parse(entries []string) ([]Window, error) {
+	var windows []Window
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		w, err := parseWindow(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid freeze window %q: %w", entry, err)
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+// Active returns the first window freezing owner/repo at t
+func Active(windows []Window, owner, repo string, t time.Time) (Window, bool) {
+	name := owner + "/" + repo
+	for _, w := range windows {
+		if ok, _ := path.Match(w.Pattern, name); !ok && w.Pattern != "*" {
+			continue
+		}
+		if w.contains(t) {
+			return w, true
+		}
+	}
+	return Window{}, false
+}
+
+// String returns the window as configured, without the reason
+func (w Window) String() string {
+	return w.text
+}
+
+// Message explains the freeze to someone whose merge was refused
+func (w Window) Message() string {
+	if w.Reason != "" {
+		return fmt.Sprintf("merging is frozen (%s): %s", w.text, w.Reason)
+	}
+	return fmt.Sprintf("merging is frozen (%s)", w.text)
+}
+
+func (w Window) contains(t time.Time) bool {
+	if !w.from.IsZero() {
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		return !day.Before(w.from) && !day.After(w.to)
+	}
+	minute := t.Hour()*60 + t.Minute()
+	return w.days[t.Weekday()] && minute >= w.start && minute < w.end
+}
+
+func parseWindow(entry string) (Window, error) {
+	w := Window{Pattern: "*"}
+
+	spec, reason, _ := strings.Cut(entry, ";")
+	w.Reason = strings.TrimSpace(reason)
+
+	// Only a prefix that looks like a repository is a pattern; schedules
+	// contain colons too
+	if pattern, rest, ok := strings.Cut(spec, ":"); ok {
+		if pattern = strings.TrimSpace(pattern); pattern == "*" || strings.Contains(pattern, "/") {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return Window{}, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			}
+			w.Pattern, spec = pattern, rest
+		}
+	}
+	spec = strings.TrimSpace(spec)
+	w.text = spec
+
+	if spec == "" {
+		return Window{}, fmt.Errorf("missing schedule")
+	}
+	if spec[0] >= '0' && spec[0] <= '9' {
+		return w, w.parseDates(spec)
+	}
+	return w, w.parseWeekly(spec)
+}
+
+// parseDates reads "2006-01-02" or "2006-01-02..2006-01-02"
+func (w *Window) parseDates(spec string) error {
+	from, to, ok := strings.Cut(spec, "..")
+	if !ok {
+		to = from
+	}
+	var err error
+	if w.from, err = time.ParseInLocation(time.DateOnly, strings.TrimSpace(from), time.Local); err != nil {
+		return fmt.Errorf("invalid date %q", from)
+	}
+	if w.to, err = time.ParseInLocation(time.DateOnly, strings.TrimSpace(to), time.Local); err != nil {
+		return fmt.Errorf("invalid date %q", to)
+	}
+	if w.to.Before(w.from) {
+		return fmt.Errorf("date range ends before it starts")
+	}
+	return nil
+}
+
+// parseWeekly reads "Fri", "Sat-Sun" or "Mon-Fri 17:00-24:00"
+func (w *Window) parseWeekly(spec string) error {
+	fields := strings.Fields(spec)
+	if len(fields) > 2 {
+		return fmt.Errorf("want \"<days> [HH:MM-HH:MM]\"")
+	}
+
+	first, last, ok := strings.Cut(fields[0], "-")
+	if !ok {
+		last = first
+	}
+	start, err := parseDay(first)
+	if err != nil {
+		return err
+	}
+	end, err := parseDay(last)
+	if err != nil {
+		return err
+	}
+	for day := start; ; day = (day + 1) % 7 {
+		w.days[day] = true
+		if day == end {
+			break
+		}
+	}
+
+	w.start, w.end = 0, 24*60
+	if len(fields) == 2 {
+		from, to, ok := strings.Cut(fields[1], "-")
+		if !ok {
+			return fmt.Errorf("invalid time range %q", fields[1])
+		}
+		if w.start, err = parseClock(from); err != nil {
+			return err
+		}
+		if w.end, err = parseClock(to); err != nil {
+			return err
+		}
+		if w.end <= w.start {
+			return fmt.Errorf("time range %q ends before it starts", fields[1])
+		}
+	}
+	return nil
+}
+
+func parseDay(s string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(s, day.String()[:3]) || strings.EqualFold(s, day.String()) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown day %q", s)
+}
+
+// parseClock reads HH:MM as minutes since midnight, allowing 24:00
+func parseClock(s string) (int, error) {
+	if s == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,82 @@
+package freeze
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestActive(t *testing.T) {
+	windows, err := Parse([]string{
+		"Fri; No merges on Fridays",
+		"yourcompany/api: Mon-Thu 17:00-24:00",
+		"yourcompany/*: 2025-12-20..2026-01-04; Holiday freeze",
+		"Sat-Sun",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	at := func(s string) time.Time {
+		tm, err := time.ParseInLocation("2006-01-02 15:04", s, time.Local)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+
+	tests := []struct {
+		repo   string
+		time   string
+		frozen string // Schedule of the active window, "" if none
+	}{
+		{"other/web", "2025-07-11 10:00", "Fri"}, // Friday
+		{"other/web", "2025-07-10 18:00", ""},    // Thursday evening, not api
+		{"yourcompany/api", "2025-07-10 18:00", "Mon-Thu 17:00-24:00"},
+		{"yourcompany/api", "2025-07-10 16:59", ""},
+		{"yourcompany/web", "2025-12-31 12:00", "2025-12-20..2026-01-04"},
+		{"yourcompany/web", "2026-01-04 23:59", "2025-12-20..2026-01-04"},
+		{"yourcompany/web", "2026-01-05 09:00", ""},
+		{"other/web", "2025-07-13 09:00", "Sat-Sun"}, // Sunday
+	}
+	for _, tt := range tests {
+		owner, repo, _ := strings.Cut(tt.repo, "/")
+		w, ok := Active(windows, owner, repo, at(tt.time))
+		if got := w.String(); ok != (tt.frozen != "") || got != tt.frozen {
+			t.Errorf("Active(%s at %s) = %q, %v; want %q", tt.repo, tt.time, got, ok, tt.frozen)
+		}
+	}
+
+	w, _ := Active(windows, "other", "web", at("2025-07-11 10:00"))
+	if want := "merging is frozen (Fri): No merges on Fridays"; w.Message() != want {
+		t.Errorf("Message() = %q, want %q", w.Message(), want)
+	}
+}
+
+func TestParseWrapsWeek(t *testing.T) {
+	windows, err := Parse([]string{"Fri-Mon"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for day, want := range []bool{true, true, false, false, false, true, true} {
+		if windows[0].days[day] != want {
+			t.Errorf("%s frozen = %v, want %v", time.Weekday(day), windows[0].days[day], want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, entry := range []string{
+		"Someday",
+		"Fri 17:00",
+		"Fri 17:00-09:00",
+		"Fri 9am-5pm",
+		"2025-13-01",
+		"2026-01-04..2025-12-20",
+		"owner/repo:",
+	} {
+		if _, err := Parse([]string{entry}); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", entry)
+		}
+	}
+}
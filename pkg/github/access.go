@@ -0,0 +1,55 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ErrRepoNotAllowed is returned by write operations on repositories outside
+// the allowed list or on the denied list
+var ErrRepoNotAllowed = errors.New("speedrun is not allowed to act on this repository")
+
+// SetRepoAccess limits write operations to repositories matching allowed
+// (all when empty) and not matching denied. Patterns are owner/repo globs
+// such as "yourcompany/*"; a bare org name matches all of its repositories.
+func (c *Client) SetRepoAccess(allowed, denied []string) {
+	c.allowedRepos, c.deniedRepos = allowed, denied
+}
+
+// RepoAllowed reports whether write operations on owner/repo are allowed
+func (c *Client) RepoAllowed(owner, repo string) bool {
+	return RepoAccessible(owner, repo, c.allowedRepos, c.deniedRepos)
+}
+
+// RepoAccessible reports whether owner/repo matches allowed (or allowed is
+// empty) and doesn't match denied, with the patterns SetRepoAccess takes
+func RepoAccessible(owner, repo string, allowed, denied []string) bool {
+	name := owner + "/" + repo
+	if matchesRepo(name, denied) {
+		return false
+	}
+	return len(allowed) == 0 || matchesRepo(name, allowed)
+}
+
+// checkRepoAccess returns ErrRepoNotAllowed for repositories speedrun must
+// not act on
+func (c *Client) checkRepoAccess(owner, repo string) error {
+	if !c.RepoAllowed(owner, repo) {
+		return fmt.Errorf("%w: %s/%s", ErrRepoNotAllowed, owner, repo)
+	}
+	return nil
+}
+
+func matchesRepo(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if !strings.Contains(pattern, "/") {
+			pattern += "/*"
+		}
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(name)); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRepoAllowed(t *testing.T) {
+	c := &Client{}
+	c.SetRepoAccess([]string{"acme", "partner/shared-*"}, []string{"acme/infra", "*/secrets"})
+
+	tests := map[string]bool{
+		"acme/api":           true,
+		"ACME/Web":           true,
+		"acme/infra":         false, // denied wins
+		"acme/secrets":       false,
+		"partner/shared-lib": true,
+		"partner/private":    false,
+		"other/api":          false,
+	}
+	for name, want := range tests {
+		owner, repo, _ := strings.Cut(name, "/")
+		if got := c.RepoAllowed(owner, repo); got != want {
+			t.Errorf("RepoAllowed(%s) = %v, want %v", name, got, want)
+		}
+	}
+
+	if !(&Client{}).RepoAllowed("any", "repo") {
+		t.Error("empty lists should allow every repository")
+	}
+}
+
+func TestWritesRespectRepoAccess(t *testing.T) {
+	c := &Client{health: &health{}}
+	c.SetRepoAccess(nil, []string{"acme/infra"})
+	pr := &PullRequest{Owner: "acme", Repo: "infra", Number: 1, client: c}
+
+	if err := pr.Approve(context.Background()); !errors.Is(err, ErrRepoNotAllowed) {
+		t.Errorf("Approve() = %v, want ErrRepoNotAllowed", err)
+	}
+	if err := pr.Merge(context.Background(), "SQUASH"); !errors.Is(err, ErrRepoNotAllowed) {
+		t.Errorf("Merge() = %v, want ErrRepoNotAllowed", err)
+	}
+}
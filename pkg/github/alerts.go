@@ -0,0 +1,86 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/go-github/v73/github"
+	"github.com/kennyp/speedrun/pkg/deps"
+	"github.com/kennyp/speedrun/pkg/tracing"
+)
+
+// SecurityAlert is an open Dependabot alert on a repository dependency
+type SecurityAlert struct {
+	Number         int
+	Package        string
+	Severity       string
+	GHSAID         string
+	Summary        string
+	PatchedVersion string // First version without the vulnerability, "" if none
+	URL            string
+}
+
+// GetSecurityAlerts lists the open Dependabot alerts for a repository
+func (c *Client) GetSecurityAlerts(ctx context.Context, owner, repo string) ([]*SecurityAlert, error) {
+	ctx, span := tracing.Start(ctx, "github.GetSecurityAlerts", tracing.String("github.repo", owner+"/"+repo))
+	defer span.End()
+
+	start := time.Now()
+	var ghAlerts []*github.DependabotAlert
+	operation := func() error {
+		var err error
+		var resp *github.Response
+		ghAlerts, resp, err = c.client.Dependabot.ListRepoAlerts(ctx, owner, repo, &github.ListAlertsOptions{
+			State:       github.Ptr("open"),
+			ListOptions: github.ListOptions{PerPage: 100},
+		})
+		if resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+			// Alerts are disabled for the repo or the token can't read them
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+
+	exponentialBackoff := c.backoffConfig.ToExponentialBackoff()
+	if err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx)); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list security alerts: %w", err)
+	}
+
+	alerts := make([]*SecurityAlert, 0, len(ghAlerts))
+	for _, alert := range ghAlerts {
+		vuln := alert.GetSecurityVulnerability()
+		alerts = append(alerts, &SecurityAlert{
+			Number:         alert.GetNumber(),
+			Package:        vuln.GetPackage().GetName(),
+			Severity:       alert.GetSecurityAdvisory().GetSeverity(),
+			GHSAID:         alert.GetSecurityAdvisory().GetGHSAID(),
+			Summary:        alert.GetSecurityAdvisory().GetSummary(),
+			PatchedVersion: vuln.GetFirstPatchedVersion().GetIdentifier(),
+			URL:            alert.GetHTMLURL(),
+		})
+	}
+
+	slog.Debug("GitHub API security alerts completed", slog.String("repo", owner+"/"+repo),
+		slog.Int("alerts", len(alerts)), slog.Duration("duration", time.Since(start)))
+	return alerts, nil
+}
+
+// FixedAlerts returns the alerts resolved by updating pkg to version
+func FixedAlerts(alerts []*SecurityAlert, pkg, version string) []*SecurityAlert {
+	var fixed []*SecurityAlert
+	for _, alert := range alerts {
+		if alert.PatchedVersion == "" || !strings.EqualFold(alert.Package, pkg) {
+			continue
+		}
+		if deps.CompareVersions(version, alert.PatchedVersion) >= 0 {
+			fixed = append(fixed, alert)
+		}
+	}
+	return fixed
+}
@@ -0,0 +1,69 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	backoffconfig "github.com/kennyp/speedrun/pkg/backoff"
+)
+
+func TestFixedAlerts(t *testing.T) {
+	alerts := []*SecurityAlert{
+		{Number: 1, Package: "lodash", PatchedVersion: "4.17.21"},
+		{Number: 2, Package: "lodash", PatchedVersion: "5.0.0"},
+		{Number: 3, Package: "axios", PatchedVersion: "0.21.1"},
+		{Number: 4, Package: "lodash"}, // no fix released
+	}
+
+	fixed := FixedAlerts(alerts, "lodash", "4.17.21")
+	if len(fixed) != 1 || fixed[0].Number != 1 {
+		t.Errorf("FixedAlerts() = %+v, want alert 1", fixed)
+	}
+	if fixed := FixedAlerts(alerts, "react", "18.0.0"); len(fixed) != 0 {
+		t.Errorf("FixedAlerts() for unrelated package = %+v", fixed)
+	}
+}
+
+func TestGetSecurityAlerts(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/app/dependabot/alerts", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("state") != "open" {
+			t.Errorf("state = %q, want open", r.URL.Query().Get("state"))
+		}
+		_, _ = w.Write([]byte(`[{"number": 7, "html_url": "https://github.com/acme/app/security/dependabot/7",
+			"security_advisory": {"ghsa_id": "GHSA-1234", "summary": "Prototype pollution", "severity": "high"},
+			"security_vulnerability": {"package": {"name": "lodash"}, "first_patched_version": {"identifier": "4.17.21"}}}]`))
+	})
+	mux.HandleFunc("/repos/acme/private/dependabot/alerts", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Resource not accessible by integration"}`, http.StatusForbidden)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(srv.Client())
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &Client{
+		client:        gh,
+		backoffConfig: backoffconfig.Config{MaxElapsedTime: time.Second, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 1},
+		health:        &health{},
+	}
+
+	alerts, err := c.GetSecurityAlerts(context.Background(), "acme", "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := SecurityAlert{Number: 7, Package: "lodash", Severity: "high", GHSAID: "GHSA-1234", Summary: "Prototype pollution",
+		PatchedVersion: "4.17.21", URL: "https://github.com/acme/app/security/dependabot/7"}
+	if len(alerts) != 1 || *alerts[0] != want {
+		t.Errorf("GetSecurityAlerts() = %+v", alerts)
+	}
+
+	if _, err := c.GetSecurityAlerts(context.Background(), "acme", "private"); err == nil {
+		t.Error("expected an error for a repo without alert access")
+	}
+}
@@ -0,0 +1,115 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	"github.com/kennyp/speedrun/pkg/tracing"
+)
+
+// Dependency update bots recognised by Bot
+const (
+	BotDependabot = "dependabot"
+	BotRenovate   = "renovate"
+)
+
+// BotAction is a bot-native operation on a dependency update PR
+type BotAction string
+
+// Bot actions. Dependabot runs them from "@dependabot <action>" comments;
+// Renovate only supports rebase (via its PR body checkbox) and close.
+const (
+	BotRebase      BotAction = "rebase"
+	BotRecreate    BotAction = "recreate"
+	BotMerge       BotAction = "merge"
+	BotSquashMerge BotAction = "squash and merge"
+	BotClose       BotAction = "close"
+)
+
+// renovateRebaseCheckbox is the unticked checkbox Renovate watches in its PR
+// bodies to trigger a rebase
+const renovateRebaseCheckbox = "- [ ] <!-- rebase-check -->"
+
+// Bot returns the dependency update bot that opened the PR, or "" if a
+// person did
+func (pr *PullRequest) Bot() string {
+	switch strings.TrimSuffix(strings.ToLower(pr.GetAuthor()), "[bot]") {
+	case "dependabot", "dependabot-preview":
+		return BotDependabot
+	case "renovate", "renovate-bot":
+		return BotRenovate
+	}
+	return ""
+}
+
+// BotActions lists the actions the PR's bot supports
+func (pr *PullRequest) BotActions() []BotAction {
+	switch pr.Bot() {
+	case BotDependabot:
+		return []BotAction{BotRebase, BotRecreate, BotMerge, BotSquashMerge, BotClose}
+	case BotRenovate:
+		if strings.Contains(pr.GetBody(), renovateRebaseCheckbox) {
+			return []BotAction{BotRebase, BotClose}
+		}
+		return []BotAction{BotClose}
+	}
+	return nil
+}
+
+// RunBotAction asks the PR's bot to perform an action
+func (pr *PullRequest) RunBotAction(ctx context.Context, action BotAction) error {
+	if pr.client.Health().Degraded {
+		return ErrStaleMode
+	}
+	if err := pr.client.checkRepoAccess(pr.Owner, pr.Repo); err != nil {
+		return err
+	}
+	if pr.client.skipWrite("run bot action", slog.Any("pr", pr), slog.String("action", string(action))) {
+		return nil
+	}
+
+	ctx, span := tracing.Start(ctx, "github.RunBotAction", append(pr.spanAttributes(), tracing.String("bot.action", string(action)))...)
+	defer span.End()
+
+	start := time.Now()
+	var err error
+	switch bot := pr.Bot(); {
+	case bot == BotDependabot:
+		comment := &github.IssueComment{Body: github.Ptr("@dependabot " + string(action))}
+		_, _, err = pr.client.client.Issues.CreateComment(ctx, pr.Owner, pr.Repo, pr.Number, comment)
+	case bot == BotRenovate && action == BotRebase:
+		body, ok := tickRebaseCheckbox(pr.GetBody())
+		if !ok {
+			return fmt.Errorf("renovate PR has no rebase checkbox")
+		}
+		_, _, err = pr.client.client.PullRequests.Edit(ctx, pr.Owner, pr.Repo, pr.Number, &github.PullRequest{Body: &body})
+	case bot == BotRenovate && action == BotClose:
+		// Renovate won't reopen a PR for the same update once it's closed
+		_, _, err = pr.client.client.PullRequests.Edit(ctx, pr.Owner, pr.Repo, pr.Number, &github.PullRequest{State: github.Ptr("closed")})
+	default:
+		return fmt.Errorf("%q is not supported for this PR", action)
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		slog.Error("GitHub API bot action failed", slog.Any("pr", pr), slog.String("action", string(action)), slog.Duration("duration", time.Since(start)), slog.Any("error", err))
+		return fmt.Errorf("failed to run bot action: %w", err)
+	}
+
+	slog.Info("GitHub API bot action completed", slog.Any("pr", pr), slog.String("action", string(action)), slog.Duration("duration", time.Since(start)))
+	pr.invalidateCache()
+
+	return nil
+}
+
+// tickRebaseCheckbox ticks Renovate's rebase checkbox in a PR body
+func tickRebaseCheckbox(body string) (string, bool) {
+	if !strings.Contains(body, renovateRebaseCheckbox) {
+		return body, false
+	}
+	return strings.Replace(body, renovateRebaseCheckbox, "- [x] <!-- rebase-check -->", 1), true
+}
@@ -0,0 +1,84 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/google/go-github/v73/github"
+	"github.com/kennyp/speedrun/pkg/cache"
+)
+
+func botPR(c *Client, login, body string) *PullRequest {
+	return &PullRequest{
+		Owner:  "acme",
+		Repo:   "app",
+		Number: 9,
+		client: c,
+		ghi:    &github.Issue{User: &github.User{Login: github.Ptr(login)}, Body: github.Ptr(body)},
+	}
+}
+
+func TestBotActions(t *testing.T) {
+	if got := botPR(nil, "octocat", "").BotActions(); got != nil {
+		t.Errorf("human PR has bot actions: %v", got)
+	}
+
+	dependabot := botPR(nil, "dependabot[bot]", "")
+	if dependabot.Bot() != BotDependabot || !slices.Contains(dependabot.BotActions(), BotSquashMerge) {
+		t.Errorf("dependabot actions = %v", dependabot.BotActions())
+	}
+
+	renovate := botPR(nil, "renovate[bot]", "Update foo\n\n"+renovateRebaseCheckbox+" If you want to rebase/retry this PR, check this box")
+	if got := renovate.BotActions(); !slices.Equal(got, []BotAction{BotRebase, BotClose}) {
+		t.Errorf("renovate actions = %v", got)
+	}
+	if got := botPR(nil, "renovate[bot]", "Update foo").BotActions(); !slices.Equal(got, []BotAction{BotClose}) {
+		t.Errorf("renovate without checkbox actions = %v", got)
+	}
+}
+
+func TestRunBotAction(t *testing.T) {
+	var comment, body string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/app/issues/9/comments", func(w http.ResponseWriter, r *http.Request) {
+		var req github.IssueComment
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		comment = req.GetBody()
+		_ = json.NewEncoder(w).Encode(req)
+	})
+	mux.HandleFunc("/repos/acme/app/pulls/9", func(w http.ResponseWriter, r *http.Request) {
+		var req github.PullRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		body = req.GetBody()
+		_ = json.NewEncoder(w).Encode(req)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(srv.Client())
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &Client{client: gh, cache: cache.NewNoOpCache(), health: &health{}}
+
+	if err := botPR(c, "dependabot[bot]", "").RunBotAction(context.Background(), BotRebase); err != nil {
+		t.Fatal(err)
+	}
+	if comment != "@dependabot rebase" {
+		t.Errorf("comment = %q", comment)
+	}
+
+	if err := botPR(c, "renovate[bot]", renovateRebaseCheckbox+" rebase").RunBotAction(context.Background(), BotRebase); err != nil {
+		t.Fatal(err)
+	}
+	if body != "- [x] <!-- rebase-check --> rebase" {
+		t.Errorf("body = %q", body)
+	}
+
+	if err := botPR(c, "renovate[bot]", "").RunBotAction(context.Background(), BotMerge); err == nil {
+		t.Error("expected unsupported action to fail")
+	}
+}
@@ -4,20 +4,27 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os/exec"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/google/go-github/v73/github"
 	backoffconfig "github.com/kennyp/speedrun/pkg/backoff"
 	"github.com/kennyp/speedrun/pkg/cache"
+	"github.com/kennyp/speedrun/pkg/metrics"
+	"github.com/kennyp/speedrun/pkg/tracing"
 )
 
 // ChecksConfig holds CI check filtering configuration
 type ChecksConfig struct {
-	Ignored  []string // Checks to ignore
-	Required []string // If set, only these checks matter
+	Ignored              []string // Checks to ignore
+	Required             []string // If set, only these checks matter
+	FromBranchProtection bool     // Without Required, only the checks the base branch requires matter
+	FlakyReruns          int      // Passes on rerun after which a check is likely flaky (0 disables)
+	ExcludeFlaky         bool     // Leave failures of likely flaky checks out of the overall state
 }
 
 // Client wraps the GitHub API client
@@ -25,14 +32,31 @@ type Client struct {
 	client        *github.Client
 	graphqlClient *GraphQLClient
 	searchQuery   string
+	queryMu       sync.RWMutex // Guards searchQuery, which the query builder changes
 	token         string
 	cache         cache.Cache
+	shared        *cache.Remote // Team cache AI analyses are shared through, nil for none
+	aiVersion     string        // Part of AI analysis cache keys, see SetAIAnalysisVersion
 	backoffConfig backoffconfig.Config
 	checksConfig  ChecksConfig
+	timeout       time.Duration     // Per-request HTTP timeout (0 for none)
+	transport     http.RoundTripper // Underneath every request, http.DefaultTransport if nil
+	health        *health
+	dryRun        bool         // Log write operations instead of performing them
+	searchAPI     string       // SearchAPIAuto, SearchAPIREST or SearchAPIGraphQL
+	searchLimit   *searchLimit // When the REST search quota resets
+
+	// Repository patterns write operations are limited to
+	allowedRepos []string
+	deniedRepos  []string
+
+	tokenReport *TokenReport // What the token can't do, from VerifyToken
 }
 
-// NewClient creates a new GitHub client
-func NewClient(ctx context.Context, token, searchQuery string, c cache.Cache, backoffConfig backoffconfig.Config, checksConfig ChecksConfig) (*Client, error) {
+// NewClient creates a new GitHub client whose requests, REST and GraphQL,
+// go over transport (http.DefaultTransport if nil) and each time out after
+// timeout (0 for no limit)
+func NewClient(ctx context.Context, token, searchQuery string, c cache.Cache, backoffConfig backoffconfig.Config, checksConfig ChecksConfig, timeout time.Duration, transport http.RoundTripper) (*Client, error) {
 	// If no token provided, try to get it from gh CLI
 	if token == "" {
 		ghToken, err := getGHToken(ctx)
@@ -42,8 +66,11 @@ func NewClient(ctx context.Context, token, searchQuery string, c cache.Cache, ba
 		token = ghToken
 	}
 
-	client := github.NewClient(nil).WithAuthToken(token)
-	graphqlClient := NewGraphQLClient(token)
+	h := &health{}
+	instrumented := &healthTransport{base: metrics.NewTransport(tracing.NewTransport(transport)), health: h}
+
+	client := github.NewClient(&http.Client{Transport: instrumented, Timeout: timeout}).WithAuthToken(token)
+	graphqlClient := NewGraphQLClient(token, instrumented, timeout)
 
 	return &Client{
 		client:        client,
@@ -53,9 +80,21 @@ func NewClient(ctx context.Context, token, searchQuery string, c cache.Cache, ba
 		cache:         c,
 		backoffConfig: backoffConfig,
 		checksConfig:  checksConfig,
+		timeout:       timeout,
+		transport:     transport,
+		health:        h,
+		searchAPI:     SearchAPIAuto,
+		searchLimit:   &searchLimit{},
 	}, nil
 }
 
+// CallContext bounds a call of one or more requests to budget, stretched to
+// the configured request timeout if that's longer so the call doesn't cut
+// requests short
+func (c *Client) CallContext(ctx context.Context, budget time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, max(budget, c.timeout))
+}
+
 // getGHToken gets the GitHub token from the gh CLI
 func getGHToken(ctx context.Context) (string, error) {
 	cmd := exec.CommandContext(ctx, "gh", "auth", "token")
@@ -84,21 +123,62 @@ func (c *Client) AuthenticatedUser(ctx context.Context) (string, error) {
 	return username, nil
 }
 
-// SearchPullRequests searches for pull requests matching the configured query
-// cacheKey generates a cache key for search results
-func (c *Client) searchCacheKey() string {
-	return fmt.Sprintf("search:%s", c.searchQuery)
+// Query returns the search query for PRs
+func (c *Client) Query() string {
+	c.queryMu.RLock()
+	defer c.queryMu.RUnlock()
+	return c.searchQuery
+}
+
+// SetQuery changes the search query for PRs
+func (c *Client) SetQuery(query string) {
+	c.queryMu.Lock()
+	defer c.queryMu.Unlock()
+	c.searchQuery = query
+}
+
+// WithQuery returns a client searching for query that shares this client's
+// connections, cache, health and settings
+func (c *Client) WithQuery(query string) *Client {
+	return &Client{
+		client:        c.client,
+		graphqlClient: c.graphqlClient,
+		searchQuery:   query,
+		token:         c.token,
+		cache:         c.cache,
+		backoffConfig: c.backoffConfig,
+		checksConfig:  c.checksConfig,
+		timeout:       c.timeout,
+		transport:     c.transport,
+		health:        c.health,
+		dryRun:        c.dryRun,
+		searchAPI:     c.searchAPI,
+		searchLimit:   c.searchLimit,
+		allowedRepos:  c.allowedRepos,
+		deniedRepos:   c.deniedRepos,
+		tokenReport:   c.tokenReport,
+	}
 }
 
+// searchCacheKey generates a cache key for search results
+func searchCacheKey(query string) string {
+	return fmt.Sprintf("search:%s", query)
+}
+
+// SearchPullRequests searches for pull requests matching the configured query
 func (c *Client) SearchPullRequests(ctx context.Context) ([]*PullRequest, error) {
-	slog.Debug("Starting PR search", slog.String("query", c.searchQuery))
+	query := c.Query()
+	ctx, span := tracing.Start(ctx, "github.SearchPullRequests", tracing.String("github.query", query))
+	defer span.End()
+
+	slog.Debug("Starting PR search", slog.String("query", query))
 	start := time.Now()
 
-	cacheKey := c.searchCacheKey()
+	cacheKey := searchCacheKey(query)
 
 	// Try to get from cache first
 	var cachedPRs []*PullRequest
-	if err := c.cache.Get(cacheKey, &cachedPRs); err == nil {
+	if err := c.cacheGet(ctx, cacheKey, &cachedPRs); err == nil {
 		// Restore client field and populate HeadSHA for cached PRs
 		for _, pr := range cachedPRs {
 			pr.client = c
@@ -121,45 +201,30 @@ func (c *Client) SearchPullRequests(ctx context.Context) ([]*PullRequest, error)
 					slog.Debug("Failed to get HeadSHA for cached PR", slog.Any("pr", pr), slog.Duration("duration", headSHADuration), slog.Any("error", err))
 					// Continue with empty HeadSHA - it can be fetched later
 				} else {
-					pr.HeadSHA = prDetails.GetHead().GetSHA()
+					pr.setHead(prDetails)
 					headSHADuration := time.Since(headSHAStart)
 					slog.Debug("Successfully fetched HeadSHA for cached PR", slog.Any("pr", pr), slog.String("head_sha", pr.HeadSHA), slog.Duration("duration", headSHADuration))
 				}
 			}
 		}
 		duration := time.Since(start)
-		slog.Debug("Retrieved PRs from cache", slog.String("query", c.searchQuery), slog.Int("count", len(cachedPRs)), slog.Duration("duration", duration))
+		slog.Debug("Retrieved PRs from cache", slog.String("query", query), slog.Int("count", len(cachedPRs)), slog.Duration("duration", duration))
 		return cachedPRs, nil
 	}
 
-	opts := &github.SearchOptions{
-		Sort:  "created",
-		Order: "desc",
-		ListOptions: github.ListOptions{
-			PerPage: 100,
-		},
-	}
-
-	var result *github.IssuesSearchResult
-	operation := func() error {
-		var searchErr error
-		result, _, searchErr = c.client.Search.Issues(ctx, c.searchQuery, opts)
-		return searchErr
-	}
-
-	exponentialBackoff := c.backoffConfig.ToExponentialBackoff()
-	err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx))
+	issues, err := c.searchIssues(ctx, query)
 	duration := time.Since(start)
 
 	if err != nil {
-		slog.Error("GitHub API search failed", slog.String("query", c.searchQuery), slog.Duration("duration", duration), slog.Any("error", err))
+		span.RecordError(err)
+		slog.Error("GitHub API search failed", slog.String("query", query), slog.Duration("duration", duration), slog.Any("error", err))
 		return nil, fmt.Errorf("failed to search PRs: %w", err)
 	}
 
-	slog.Debug("GitHub API search completed", slog.String("query", c.searchQuery), slog.Int("raw_results", len(result.Issues)), slog.Duration("duration", duration))
+	slog.Debug("GitHub API search completed", slog.String("query", query), slog.Int("raw_results", len(issues)), slog.Duration("duration", duration))
 
 	var prs []*PullRequest
-	for _, issue := range result.Issues {
+	for _, issue := range issues {
 		// Skip if not a PR
 		if issue.PullRequestLinks == nil {
 			continue
@@ -179,11 +244,11 @@ func (c *Client) SearchPullRequests(ctx context.Context) ([]*PullRequest, error)
 		prs = append(prs, pr)
 	}
 
-	slog.Info("PR search results processed", slog.String("query", c.searchQuery), slog.Int("filtered_prs", len(prs)), slog.Duration("total_duration", time.Since(start)))
+	slog.Info("PR search results processed", slog.String("query", query), slog.Int("filtered_prs", len(prs)), slog.Duration("total_duration", time.Since(start)))
 
 	// Cache the results
-	if err := c.cache.Set(cacheKey, prs); err != nil {
-		slog.Debug("Failed to cache search results", slog.String("query", c.searchQuery), slog.Any("error", err))
+	if err := c.cacheSet(ctx, cacheKey, prs); err != nil {
+		slog.Debug("Failed to cache search results", slog.String("query", query), slog.Any("error", err))
 	}
 
 	return prs, nil
@@ -191,37 +256,38 @@ func (c *Client) SearchPullRequests(ctx context.Context) ([]*PullRequest, error)
 
 // SearchPullRequestsFresh searches for pull requests bypassing cache (for refresh)
 func (c *Client) SearchPullRequestsFresh(ctx context.Context) ([]*PullRequest, error) {
-	slog.Debug("Starting fresh PR search", slog.String("query", c.searchQuery))
-	start := time.Now()
+	query := c.Query()
+	ctx, span := tracing.Start(ctx, "github.SearchPullRequestsFresh", tracing.String("github.query", query))
+	defer span.End()
 
-	opts := &github.SearchOptions{
-		Sort:  "created",
-		Order: "desc",
-		ListOptions: github.ListOptions{
-			PerPage: 100,
-		},
-	}
-
-	var result *github.IssuesSearchResult
-	operation := func() error {
-		var searchErr error
-		result, _, searchErr = c.client.Search.Issues(ctx, c.searchQuery, opts)
-		return searchErr
-	}
+	slog.Debug("Starting fresh PR search", slog.String("query", query))
+	start := time.Now()
 
-	exponentialBackoff := c.backoffConfig.ToExponentialBackoff()
-	err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx))
+	issues, err := c.searchIssues(ctx, query)
 	duration := time.Since(start)
 
 	if err != nil {
-		slog.Error("GitHub API fresh search failed", slog.String("query", c.searchQuery), slog.Duration("duration", duration), slog.Any("error", err))
+		span.RecordError(err)
+		slog.Error("GitHub API fresh search failed", slog.String("query", query), slog.Duration("duration", duration), slog.Any("error", err))
+
+		// During an outage the last cached results beat an empty list
+		if c.Health().Degraded {
+			var cachedPRs []*PullRequest
+			if cacheErr := c.cacheGet(ctx, searchCacheKey(query), &cachedPRs); cacheErr == nil {
+				for _, pr := range cachedPRs {
+					pr.client = c
+				}
+				slog.Info("Serving cached PRs in stale mode", slog.Int("count", len(cachedPRs)))
+				return cachedPRs, nil
+			}
+		}
 		return nil, fmt.Errorf("failed to search PRs: %w", err)
 	}
 
-	slog.Debug("GitHub API fresh search completed", slog.String("query", c.searchQuery), slog.Int("raw_results", len(result.Issues)), slog.Duration("duration", duration))
+	slog.Debug("GitHub API fresh search completed", slog.String("query", query), slog.Int("raw_results", len(issues)), slog.Duration("duration", duration))
 
 	var prs []*PullRequest
-	for _, issue := range result.Issues {
+	for _, issue := range issues {
 		// Skip if not a PR
 		if issue.PullRequestLinks == nil {
 			continue
@@ -241,19 +307,20 @@ func (c *Client) SearchPullRequestsFresh(ctx context.Context) ([]*PullRequest, e
 		prs = append(prs, pr)
 	}
 
-	slog.Info("Fresh PR search results processed", slog.String("query", c.searchQuery), slog.Int("filtered_prs", len(prs)), slog.Duration("total_duration", time.Since(start)))
+	slog.Info("Fresh PR search results processed", slog.String("query", query), slog.Int("filtered_prs", len(prs)), slog.Duration("total_duration", time.Since(start)))
 
 	// Update the cache with fresh results
-	cacheKey := c.searchCacheKey()
-	if err := c.cache.Set(cacheKey, prs); err != nil {
-		slog.Debug("Failed to cache fresh search results", slog.String("query", c.searchQuery), slog.Any("error", err))
+	cacheKey := searchCacheKey(query)
+	if err := c.cacheSet(ctx, cacheKey, prs); err != nil {
+		slog.Debug("Failed to cache fresh search results", slog.String("query", query), slog.Any("error", err))
 	}
 
 	return prs, nil
 }
 
-// filterChecks filters check details based on configuration
-func (c *Client) filterChecks(details []CheckDetail) []CheckDetail {
+// filterChecks keeps only the required checks if there are any, and otherwise
+// drops the ignored ones
+func (c *Client) filterChecks(details []CheckDetail, required []string) []CheckDetail {
 	if len(details) == 0 {
 		return details
 	}
@@ -261,14 +328,14 @@ func (c *Client) filterChecks(details []CheckDetail) []CheckDetail {
 	slog.Debug("Filtering checks",
 		slog.Int("total_checks", len(details)),
 		slog.Any("ignored_config", c.checksConfig.Ignored),
-		slog.Any("required_config", c.checksConfig.Required),
+		slog.Any("required", required),
 	)
 
 	// If required checks are specified, only keep those
-	if len(c.checksConfig.Required) > 0 {
+	if len(required) > 0 {
 		var filtered []CheckDetail
 		requiredMap := make(map[string]bool)
-		for _, req := range c.checksConfig.Required {
+		for _, req := range required {
 			requiredMap[req] = true
 		}
 
@@ -314,6 +381,19 @@ func (c *Client) filterChecks(details []CheckDetail) []CheckDetail {
 
 // EnableAutoMerge enables auto-merge for a pull request
 func (c *Client) EnableAutoMerge(ctx context.Context, owner, repo string, number int, mergeMethod string) error {
+	if c.Health().Degraded {
+		return ErrStaleMode
+	}
+	if err := c.checkRepoAccess(owner, repo); err != nil {
+		return err
+	}
+	if c.skipWrite("enable auto-merge", "owner", owner, "repo", repo, "number", number, "merge_method", mergeMethod) {
+		return nil
+	}
+
+	ctx, span := tracing.Start(ctx, "github.EnableAutoMerge", tracing.String("github.repo", owner+"/"+repo), tracing.Int("github.pr", number))
+	defer span.End()
+
 	slog.Debug("Enabling auto-merge for PR", "owner", owner, "repo", repo, "number", number, "merge_method", mergeMethod)
 
 	// Get the GraphQL node ID for the pull request
@@ -334,6 +414,19 @@ func (c *Client) EnableAutoMerge(ctx context.Context, owner, repo string, number
 
 // Merge merges a pull request immediately using the REST API
 func (c *Client) Merge(ctx context.Context, owner, repo string, number int, mergeMethod string) error {
+	if c.Health().Degraded {
+		return ErrStaleMode
+	}
+	if err := c.checkRepoAccess(owner, repo); err != nil {
+		return err
+	}
+	if c.skipWrite("merge", "owner", owner, "repo", repo, "number", number, "merge_method", mergeMethod) {
+		return nil
+	}
+
+	ctx, span := tracing.Start(ctx, "github.Merge", tracing.String("github.repo", owner+"/"+repo), tracing.Int("github.pr", number))
+	defer span.End()
+
 	slog.Debug("Merging PR", "owner", owner, "repo", repo, "number", number, "merge_method", mergeMethod)
 
 	// Convert merge method to REST API format
@@ -387,8 +480,74 @@ func (c *Client) GetPRDetails(ctx context.Context, owner, repo string, number in
 	return details, nil
 }
 
-// GetPRDiff gets the diff for a pull request
+// Issue is the summary of a GitHub issue referenced from a PR
+type Issue struct {
+	Number int
+	Title  string
+	State  string // open or closed
+	URL    string
+}
+
+// GetIssue gets the summary of an issue
+func (c *Client) GetIssue(ctx context.Context, owner, repo string, number int) (*Issue, error) {
+	ctx, span := tracing.Start(ctx, "github.GetIssue", tracing.String("github.repo", owner+"/"+repo), tracing.Int("github.issue", number))
+	defer span.End()
+
+	var issue *github.Issue
+	operation := func() error {
+		var err error
+		var resp *github.Response
+		issue, resp, err = c.client.Issues.Get(ctx, owner, repo, number)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			// References in PR text are often not issues at all
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+
+	exponentialBackoff := c.backoffConfig.ToExponentialBackoff()
+	if err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx)); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+
+	return &Issue{
+		Number: issue.GetNumber(),
+		Title:  issue.GetTitle(),
+		State:  issue.GetState(),
+		URL:    issue.GetHTMLURL(),
+	}, nil
+}
+
+// GetPRDiff gets the diff for a pull request. Binary, minified and generated
+// files show only their headers. Large diffs keep every file's header and
+// hunk positions, with lockfiles and vendored code cut first.
 func (c *Client) GetPRDiff(ctx context.Context, owner, repo string, number int) (string, error) {
+	diff, err := c.getRawDiff(ctx, owner, repo, number)
+	if err != nil {
+		return "", err
+	}
+	attrs, err := c.getGeneratedAttributes(ctx, owner, repo)
+	if err != nil {
+		slog.Debug("Failed to read generated file attributes", slog.String("repo", owner+"/"+repo), slog.Any("error", err))
+	}
+	return truncateDiff(omitExcludedHunks(diff, attrs), diffBudget), nil
+}
+
+// GetPRFileDiff gets one file's diff in a pull request
+func (c *Client) GetPRFileDiff(ctx context.Context, owner, repo string, number int, filePath string) (string, error) {
+	diff, err := c.getRawDiff(ctx, owner, repo, number)
+	if err != nil {
+		return "", err
+	}
+	fileDiff, ok := fileDiffFrom(diff, filePath, diffBudget)
+	if !ok {
+		return "", fmt.Errorf("%s is not changed in PR #%d", filePath, number)
+	}
+	return fileDiff, nil
+}
+
+func (c *Client) getRawDiff(ctx context.Context, owner, repo string, number int) (string, error) {
 	var diff string
 	operation := func() error {
 		var err error
@@ -402,11 +561,6 @@ func (c *Client) GetPRDiff(ctx context.Context, owner, repo string, number int)
 	if err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx)); err != nil {
 		return "", fmt.Errorf("failed to get PR diff: %w", err)
 	}
-
-	// Truncate very large diffs to avoid overwhelming the model
-	if len(diff) > 8000 {
-		diff = diff[:8000] + "\n... (diff truncated due to size)"
-	}
 	return diff, nil
 }
 
@@ -0,0 +1,160 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/go-github/v73/github"
+	"github.com/kennyp/speedrun/pkg/codeowners"
+	"github.com/kennyp/speedrun/pkg/tracing"
+)
+
+// codeownersPaths are the locations GitHub reads CODEOWNERS from, in order
+var codeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// errNoFile marks a CODEOWNERS location that doesn't exist
+var errNoFile = errors.New("file not found")
+
+// GetCodeowners fetches and parses a repository's CODEOWNERS file from its
+// default branch. Returns nil if the repository has none.
+func (c *Client) GetCodeowners(ctx context.Context, owner, repo string) (codeowners.Ruleset, error) {
+	ctx, span := tracing.Start(ctx, "github.GetCodeowners", tracing.String("github.repo", owner+"/"+repo))
+	defer span.End()
+
+	for _, path := range codeownersPaths {
+		content, err := c.getFileContent(ctx, owner, repo, path, "")
+		if errors.Is(err, errNoFile) {
+			continue
+		}
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to get CODEOWNERS: %w", err)
+		}
+
+		rules, err := codeowners.Parse(strings.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		slog.Debug("Loaded CODEOWNERS", slog.String("repo", owner+"/"+repo), slog.String("path", path), slog.Int("rules", len(rules)))
+		return rules, nil
+	}
+
+	return nil, nil
+}
+
+// getFileContent reads a whole file at ref, or on the default branch when
+// ref is empty. A missing file is errNoFile.
+func (c *Client) getFileContent(ctx context.Context, owner, repo, path, ref string) (string, error) {
+	var file *github.RepositoryContent
+	operation := func() error {
+		var err error
+		var resp *github.Response
+		file, _, resp, err = c.client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return backoff.Permanent(errNoFile)
+		}
+		return err
+	}
+
+	exponentialBackoff := c.backoffConfig.ToExponentialBackoff()
+	if err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx)); err != nil {
+		return "", err
+	}
+	if file == nil {
+		return "", errNoFile // a directory
+	}
+	return file.GetContent()
+}
+
+func (pr *PullRequest) changedFilesCacheKey() string {
+	return fmt.Sprintf("changed:%s/%s#%d:%s", pr.Owner, pr.Repo, pr.Number, pr.HeadSHA)
+}
+
+// changedFile is a file a PR changes
+type changedFile struct {
+	Path    string
+	Changes int
+	Kind    string // Guessed from the name and patch, before .gitattributes
+}
+
+// GetChangedFiles lists the paths the PR changes. Results are cached per
+// head commit.
+func (pr *PullRequest) GetChangedFiles(ctx context.Context) ([]string, error) {
+	files, err := pr.listChangedFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = file.Path
+	}
+	return paths, nil
+}
+
+func (pr *PullRequest) listChangedFiles(ctx context.Context) ([]changedFile, error) {
+	if pr.client == nil {
+		return nil, fmt.Errorf("PR client is nil")
+	}
+
+	ctx, span := tracing.Start(ctx, "github.GetChangedFiles", pr.spanAttributes()...)
+	defer span.End()
+
+	cacheKey := pr.changedFilesCacheKey()
+	if pr.HeadSHA != "" {
+		var cached []changedFile
+		if err := pr.client.cacheGet(ctx, cacheKey, &cached); err == nil {
+			return cached, nil
+		}
+	}
+
+	start := time.Now()
+	var changed []changedFile
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		var files []*github.CommitFile
+		var resp *github.Response
+		operation := func() error {
+			var listErr error
+			files, resp, listErr = pr.client.client.PullRequests.ListFiles(ctx, pr.Owner, pr.Repo, pr.Number, opts)
+			return listErr
+		}
+
+		exponentialBackoff := pr.client.backoffConfig.ToExponentialBackoff()
+		if err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx)); err != nil {
+			span.RecordError(err)
+			slog.Error("GitHub API list files failed", slog.Any("pr", pr), slog.Duration("duration", time.Since(start)), slog.Any("error", err))
+			return nil, fmt.Errorf("failed to list PR files: %w", err)
+		}
+
+		for _, file := range files {
+			// GitHub sends no patch for binary files, nor for text files too
+			// large to diff, which do have line counts
+			binary := file.GetPatch() == "" && file.GetChanges() == 0 && file.GetStatus() != "renamed"
+			changed = append(changed, changedFile{
+				Path:    file.GetFilename(),
+				Changes: file.GetChanges(),
+				Kind:    guessFileKind(file.GetFilename(), binary, file.GetPatch()),
+			})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	slog.Debug("GitHub API list files completed", slog.Any("pr", pr), slog.Int("files", len(changed)), slog.Duration("duration", time.Since(start)))
+
+	if pr.HeadSHA != "" {
+		if err := pr.client.cacheSet(ctx, cacheKey, changed); err != nil {
+			slog.Debug("Failed to cache changed files", slog.Any("error", err))
+		}
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,54 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	backoffconfig "github.com/kennyp/speedrun/pkg/backoff"
+)
+
+func TestGetCodeowners(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/app/contents/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/app/contents/CODEOWNERS" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"type":     "file",
+			"encoding": "base64",
+			"content":  base64.StdEncoding.EncodeToString([]byte("/api/ @octocat\n")),
+		})
+	})
+	mux.HandleFunc("/repos/acme/empty/contents/", http.NotFound)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(srv.Client())
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &Client{
+		client:        gh,
+		backoffConfig: backoffconfig.Config{MaxElapsedTime: time.Second, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 1},
+		health:        &health{},
+	}
+
+	rules, err := c.GetCodeowners(context.Background(), "acme", "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rules.Owners("api/users.go"); len(got) != 1 || got[0] != "@octocat" {
+		t.Errorf("Owners() = %v", got)
+	}
+
+	rules, err = c.GetCodeowners(context.Background(), "acme", "empty")
+	if err != nil || rules != nil {
+		t.Errorf("GetCodeowners() without a file = %v, %v", rules, err)
+	}
+}
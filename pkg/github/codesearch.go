@@ -0,0 +1,68 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/go-github/v73/github"
+)
+
+// CodeMatch is a file a code search found
+type CodeMatch struct {
+	Repo      string   // owner/name
+	Path      string   // Path in the repository
+	Fragments []string // Excerpts of the file around the matches
+}
+
+// codeSearchScope finds qualifiers that would widen a code search beyond
+// the repository or owner it's scoped to
+var codeSearchScope = regexp.MustCompile(`(?i)(^|\s)-?(repo|org|user):`)
+
+// SearchCode finds code matching query on the default branches of
+// owner/repo, or of all the owner's repositories when repo is empty. It
+// returns up to limit files and how many matched in all. The query can use
+// GitHub's code search qualifiers (language:, path:, ...) except those
+// naming repositories, which the search sets.
+func (c *Client) SearchCode(ctx context.Context, owner, repo, query string, limit int) ([]CodeMatch, int, error) {
+	if codeSearchScope.MatchString(query) {
+		return nil, 0, fmt.Errorf("code search queries can't use repo:, org: or user:, the search is scoped by owner and repo")
+	}
+	scope := "user:" + owner
+	if repo != "" {
+		scope = "repo:" + owner + "/" + repo
+	}
+
+	var result *github.CodeSearchResult
+	operation := func() error {
+		var err error
+		result, _, err = c.client.Search.Code(ctx, query+" "+scope, &github.SearchOptions{
+			TextMatch:   true,
+			ListOptions: github.ListOptions{PerPage: limit},
+		})
+		if _, limited := searchRateLimitReset(err); limited {
+			// Code search allows few requests a minute; waiting out the
+			// limit would hold up the whole analysis
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+
+	exponentialBackoff := c.backoffConfig.ToExponentialBackoff()
+	if err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx)); err != nil {
+		return nil, 0, fmt.Errorf("failed to search code: %w", err)
+	}
+
+	matches := make([]CodeMatch, 0, len(result.CodeResults))
+	for _, code := range result.CodeResults {
+		match := CodeMatch{Repo: code.GetRepository().GetFullName(), Path: code.GetPath()}
+		for _, text := range code.TextMatches {
+			if text.GetProperty() == "content" && text.GetFragment() != "" {
+				match.Fragments = append(match.Fragments, text.GetFragment())
+			}
+		}
+		matches = append(matches, match)
+	}
+	return matches, result.GetTotal(), nil
+}
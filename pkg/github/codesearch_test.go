@@ -0,0 +1,58 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	backoffconfig "github.com/kennyp/speedrun/pkg/backoff"
+)
+
+func TestSearchCode(t *testing.T) {
+	var query string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search/code", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("q")
+		if accept := r.Header.Get("Accept"); accept != "application/vnd.github.v3.text-match+json" {
+			t.Errorf("Accept = %q, want text matches", accept)
+		}
+		_, _ = w.Write([]byte(`{"total_count": 7, "items": [
+			{"path": "client/auth.go", "repository": {"full_name": "acme/web"},
+			 "text_matches": [
+				{"property": "content", "fragment": "token := api.Login(user)"},
+				{"property": "path", "fragment": "client/auth.go"}
+			 ]}
+		]}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(srv.Client())
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &Client{client: gh, backoffConfig: backoffconfig.Config{MaxElapsedTime: time.Second}, health: &health{}}
+
+	matches, total, err := c.SearchCode(context.Background(), "acme", "", "api.Login language:go", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "api.Login language:go user:acme" {
+		t.Errorf("query = %q, want it scoped to the owner", query)
+	}
+	if total != 7 || len(matches) != 1 {
+		t.Fatalf("SearchCode() = %+v, %d", matches, total)
+	}
+	if m := matches[0]; m.Repo != "acme/web" || m.Path != "client/auth.go" || len(m.Fragments) != 1 || m.Fragments[0] != "token := api.Login(user)" {
+		t.Errorf("match = %+v", m)
+	}
+
+	if _, _, err := c.SearchCode(context.Background(), "acme", "api", "Login", 10); err != nil || query != "Login repo:acme/api" {
+		t.Errorf("repository search query = %q, %v", query, err)
+	}
+	if _, _, err := c.SearchCode(context.Background(), "acme", "api", "Login repo:other/secrets", 10); err == nil {
+		t.Error("SearchCode() allowed a query naming another repository")
+	}
+}
@@ -0,0 +1,97 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	"github.com/kennyp/speedrun/pkg/tracing"
+)
+
+// UpsertComment posts a comment on this PR, or updates the earlier comment
+// containing marker so repeated runs keep a single sticky comment. The marker
+// (typically an HTML comment) must appear in body.
+func (pr *PullRequest) UpsertComment(ctx context.Context, marker, body string) error {
+	if pr.client.Health().Degraded {
+		return ErrStaleMode
+	}
+	if err := pr.client.checkRepoAccess(pr.Owner, pr.Repo); err != nil {
+		return err
+	}
+	if pr.client.skipWrite("post comment", slog.Any("pr", pr), slog.String("body", body)) {
+		return nil
+	}
+
+	ctx, span := tracing.Start(ctx, "github.UpsertComment", pr.spanAttributes()...)
+	defer span.End()
+
+	start := time.Now()
+	existing, err := pr.findComment(ctx, marker)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	comment := &github.IssueComment{Body: github.Ptr(body)}
+	if existing != nil {
+		_, _, err = pr.client.client.Issues.EditComment(ctx, pr.Owner, pr.Repo, existing.GetID(), comment)
+	} else {
+		_, _, err = pr.client.client.Issues.CreateComment(ctx, pr.Owner, pr.Repo, pr.Number, comment)
+	}
+	if err != nil {
+		span.RecordError(err)
+		slog.Error("GitHub API comment failed", slog.Any("pr", pr), slog.Duration("duration", time.Since(start)), slog.Any("error", err))
+		return fmt.Errorf("failed to post comment: %w", err)
+	}
+
+	slog.Info("GitHub API comment posted", slog.Any("pr", pr), slog.Bool("updated", existing != nil), slog.Duration("duration", time.Since(start)))
+	return nil
+}
+
+// AddComment posts a new comment on this PR
+func (pr *PullRequest) AddComment(ctx context.Context, body string) error {
+	if pr.client.Health().Degraded {
+		return ErrStaleMode
+	}
+	if err := pr.client.checkRepoAccess(pr.Owner, pr.Repo); err != nil {
+		return err
+	}
+	if pr.client.skipWrite("post comment", slog.Any("pr", pr), slog.String("body", body)) {
+		return nil
+	}
+
+	ctx, span := tracing.Start(ctx, "github.AddComment", pr.spanAttributes()...)
+	defer span.End()
+
+	start := time.Now()
+	if _, _, err := pr.client.client.Issues.CreateComment(ctx, pr.Owner, pr.Repo, pr.Number, &github.IssueComment{Body: github.Ptr(body)}); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to post comment: %w", err)
+	}
+
+	slog.Info("GitHub API comment posted", slog.Any("pr", pr), slog.Duration("duration", time.Since(start)))
+	return nil
+}
+
+// findComment returns the first comment on this PR containing marker, or nil
+func (pr *PullRequest) findComment(ctx context.Context, marker string) (*github.IssueComment, error) {
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		comments, resp, err := pr.client.client.Issues.ListComments(ctx, pr.Owner, pr.Repo, pr.Number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list comments: %w", err)
+		}
+		for _, comment := range comments {
+			if strings.Contains(comment.GetBody(), marker) {
+				return comment, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
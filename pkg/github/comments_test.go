@@ -0,0 +1,77 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/google/go-github/v73/github"
+)
+
+func TestUpsertComment(t *testing.T) {
+	var comments []map[string]any
+	var calls []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/app/issues/5/comments", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(comments)
+			return
+		}
+		calls = append(calls, "create")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 1})
+	})
+	mux.HandleFunc("/repos/acme/app/issues/comments/42", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" 42")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 42})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(srv.Client())
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	pr := &PullRequest{Owner: "acme", Repo: "app", Number: 5, client: &Client{client: gh, health: &health{}}}
+
+	if err := pr.UpsertComment(context.Background(), "<!-- marker -->", "<!-- marker -->\nfirst"); err != nil {
+		t.Fatal(err)
+	}
+
+	comments = []map[string]any{
+		{"id": 41, "body": "unrelated"},
+		{"id": 42, "body": "<!-- marker -->\nfirst"},
+	}
+	if err := pr.UpsertComment(context.Background(), "<!-- marker -->", "<!-- marker -->\nsecond"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(calls, []string{"create", "PATCH 42"}) {
+		t.Errorf("calls = %v, want a new comment then an edit of the marked one", calls)
+	}
+}
+
+func TestAddComment(t *testing.T) {
+	var body string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/app/issues/5/comments", func(w http.ResponseWriter, r *http.Request) {
+		var comment github.IssueComment
+		_ = json.NewDecoder(r.Body).Decode(&comment)
+		body = comment.GetBody()
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 1})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(srv.Client())
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	pr := &PullRequest{Owner: "acme", Repo: "app", Number: 5, client: &Client{client: gh, health: &health{}}}
+
+	if err := pr.AddComment(context.Background(), "@alice ping"); err != nil {
+		t.Fatal(err)
+	}
+	if body != "@alice ping" {
+		t.Errorf("posted %q", body)
+	}
+}
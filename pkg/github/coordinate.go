@@ -0,0 +1,80 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/cache"
+)
+
+// aiClaimPoll is how often the claim on a PR's analysis is tried again while
+// another instance holds it
+var aiClaimPoll = 2 * time.Second
+
+// Heartbeat tells other speedrun instances sharing the cache that this one is
+// running, and returns them. Caches that can't coordinate have no others.
+func (c *Client) Heartbeat() ([]cache.Instance, error) {
+	coord, ok := c.cache.(cache.Coordinator)
+	if !ok {
+		return nil, nil
+	}
+	return coord.Heartbeat()
+}
+
+func (pr *PullRequest) aiClaimKey() string {
+	return "claim:" + pr.aiAnalysisCacheKey()
+}
+
+// ClaimAIAnalysis claims the PR's analysis for this instance for up to ttl,
+// so another speedrun sharing the cache doesn't pay for the same analysis.
+// While another instance holds the claim, it waits for that instance to let
+// go. If the analysis was cached meanwhile, it's read into dest and true is
+// reported; otherwise this instance holds the claim until ReleaseAIAnalysis.
+func (pr *PullRequest) ClaimAIAnalysis(ctx context.Context, ttl time.Duration, dest AIAnalysis) (bool, error) {
+	coord, ok := pr.client.cache.(cache.Coordinator)
+	if !ok {
+		return false, nil
+	}
+
+	key := pr.aiClaimKey()
+	waiting := false
+	for {
+		claimed, err := coord.Claim(key, ttl)
+		if err != nil {
+			// Coordination is best effort; an unreadable claim mustn't block analysis
+			slog.Debug("Failed to claim AI analysis", slog.Any("pr", pr), slog.Any("error", err))
+			return false, nil
+		}
+		if claimed {
+			// The analysis may have been cached by an instance that just let go
+			if err := pr.GetCachedAIAnalysis(dest); err == nil {
+				pr.ReleaseAIAnalysis()
+				return true, nil
+			}
+			return false, nil
+		}
+		if !waiting {
+			slog.Info("Another instance is analyzing PR, waiting for its result", slog.Any("pr", pr))
+			waiting = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, fmt.Errorf("failed waiting for another instance's analysis: %w", ctx.Err())
+		case <-time.After(aiClaimPoll):
+		}
+	}
+}
+
+// ReleaseAIAnalysis gives up this instance's claim on the PR's analysis
+func (pr *PullRequest) ReleaseAIAnalysis() {
+	coord, ok := pr.client.cache.(cache.Coordinator)
+	if !ok {
+		return
+	}
+	if err := coord.Release(pr.aiClaimKey()); err != nil {
+		slog.Debug("Failed to release AI analysis claim", slog.Any("pr", pr), slog.Any("error", err))
+	}
+}
@@ -0,0 +1,70 @@
+package github
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/cache"
+)
+
+// testAnalysis is an AIAnalysis for tests, which can't import the agent
+type testAnalysis struct {
+	Recommendation string
+}
+
+func (a *testAnalysis) GetRecommendation() string { return a.Recommendation }
+func (a *testAnalysis) GetReasoning() string      { return "" }
+func (a *testAnalysis) GetRiskLevel() string      { return "" }
+func (a *testAnalysis) GetPRType() string         { return "" }
+func (a *testAnalysis) GetDocType() string        { return "" }
+
+func TestClaimAIAnalysisWaitsForOtherInstance(t *testing.T) {
+	defer func(poll time.Duration) { aiClaimPoll = poll }(aiClaimPoll)
+	aiClaimPoll = 10 * time.Millisecond
+
+	path := filepath.Join(t.TempDir(), "cache.db")
+	open := func() *PullRequest {
+		store, err := cache.New(path, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = store.Close() })
+		return &PullRequest{Owner: "acme", Repo: "api", Number: 7, HeadSHA: "abc", client: &Client{cache: store}}
+	}
+	first, second := open(), open()
+
+	var dest testAnalysis
+	if waited, err := first.ClaimAIAnalysis(context.Background(), time.Minute, &dest); err != nil || waited {
+		t.Fatalf("first ClaimAIAnalysis() = %v, %v; want the claim", waited, err)
+	}
+
+	done := make(chan bool)
+	go func() {
+		waited, err := second.ClaimAIAnalysis(context.Background(), time.Minute, &dest)
+		if err != nil {
+			t.Error(err)
+		}
+		done <- waited
+	}()
+
+	// The first instance finishes its analysis
+	time.Sleep(5 * aiClaimPoll)
+	if err := first.SetCachedAIAnalysis(&testAnalysis{Recommendation: "APPROVE"}); err != nil {
+		t.Fatal(err)
+	}
+	first.ReleaseAIAnalysis()
+
+	if waited := <-done; !waited || dest.Recommendation != "APPROVE" {
+		t.Errorf("second ClaimAIAnalysis() = %v with %+v, want the first instance's analysis", waited, dest)
+	}
+
+	// With no one else holding it, the claim is taken without waiting
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	other := &PullRequest{Owner: "acme", Repo: "api", Number: 8, HeadSHA: "def", client: second.client}
+	if _, err := other.ClaimAIAnalysis(ctx, time.Minute, &dest); err != nil {
+		t.Errorf("unclaimed ClaimAIAnalysis() = %v", err)
+	}
+}
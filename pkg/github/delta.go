@@ -0,0 +1,125 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	"github.com/kennyp/speedrun/pkg/tracing"
+)
+
+// deltaOverlap widens the updated:> window to absorb clock skew between us
+// and GitHub and search indexing lag. Re-fetching a few unchanged PRs is cheap.
+const deltaOverlap = time.Minute
+
+// searchTimeFormat is the timestamp layout GitHub search qualifiers accept
+const searchTimeFormat = "2006-01-02T15:04:05Z"
+
+// PRRef identifies a pull request across repositories
+type PRRef struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+// Ref returns the PR's identity
+func (pr *PullRequest) Ref() PRRef {
+	return PRRef{Owner: pr.Owner, Repo: pr.Repo, Number: pr.Number}
+}
+
+// SearchPullRequestsSince refreshes existing by fetching only PRs updated
+// after since. Of the rest, a state lookup that skips the search finds those
+// that closed or merged, and those updated since without being found, which
+// stopped matching the query. The merged list keeps the order of existing,
+// with new PRs appended.
+func (c *Client) SearchPullRequestsSince(ctx context.Context, existing []*PullRequest, since time.Time) ([]*PullRequest, error) {
+	searchQuery := c.Query()
+	cutoff := since.Add(-deltaOverlap)
+	query := fmt.Sprintf("%s updated:>%s", searchQuery, cutoff.UTC().Format(searchTimeFormat))
+
+	ctx, span := tracing.Start(ctx, "github.SearchPullRequestsSince", tracing.String("github.query", query))
+	defer span.End()
+
+	slog.Debug("Starting delta PR search", slog.String("query", query), slog.Int("existing", len(existing)))
+	start := time.Now()
+
+	changedIssues, err := c.searchIssues(ctx, query)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to search updated PRs: %w", err)
+	}
+
+	changed := make(map[PRRef]*PullRequest, len(changedIssues))
+	var changedOrder []*PullRequest // Search order, for appending new PRs
+	for _, issue := range changedIssues {
+		if !isOpenPullRequest(issue) {
+			continue
+		}
+
+		pr, err := newPullRequestFromIssue(ctx, c, issue)
+		if err != nil {
+			slog.Debug("Failed to create PR from issue", slog.Int("issue_number", issue.GetNumber()), slog.Any("error", err))
+			continue
+		}
+		changed[pr.Ref()] = pr
+		changedOrder = append(changedOrder, pr)
+	}
+
+	var unchanged []PRRef
+	for _, pr := range existing {
+		if _, ok := changed[pr.Ref()]; !ok {
+			unchanged = append(unchanged, pr.Ref())
+		}
+	}
+	statuses := make(map[PRRef]PRStatus)
+	if len(unchanged) > 0 {
+		statuses, err = c.graphqlClient.GetPullRequestStates(ctx, unchanged)
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to check for closed PRs: %w", err)
+		}
+	}
+
+	prs := make([]*PullRequest, 0, len(existing)+len(changed))
+	seen := make(map[PRRef]bool, len(existing))
+	removed := 0
+	for _, pr := range existing {
+		ref := pr.Ref()
+		seen[ref] = true
+
+		if fresh, ok := changed[ref]; ok {
+			prs = append(prs, fresh)
+			continue
+		}
+		if status, ok := statuses[ref]; !ok || status.State != PRStateOpen || status.UpdatedAt.After(cutoff) {
+			removed++
+			continue
+		}
+		prs = append(prs, pr)
+	}
+
+	added := 0
+	for _, pr := range changedOrder {
+		if !seen[pr.Ref()] {
+			prs = append(prs, pr)
+			added++
+		}
+	}
+
+	span.SetAttributes(tracing.Int("github.changed", len(changed)), tracing.Int("github.removed", removed))
+	slog.Info("Delta PR search processed", slog.Int("changed", len(changed)), slog.Int("added", added),
+		slog.Int("removed", removed), slog.Int("total", len(prs)), slog.Duration("duration", time.Since(start)))
+
+	if err := c.cacheSet(ctx, searchCacheKey(searchQuery), prs); err != nil {
+		slog.Debug("Failed to cache delta search results", slog.String("query", searchQuery), slog.Any("error", err))
+	}
+
+	return prs, nil
+}
+
+// isOpenPullRequest reports whether a search result is an unmerged PR
+func isOpenPullRequest(issue *github.Issue) bool {
+	return issue.PullRequestLinks != nil && issue.PullRequestLinks.MergedAt == nil
+}
@@ -0,0 +1,107 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	backoffconfig "github.com/kennyp/speedrun/pkg/backoff"
+	"github.com/kennyp/speedrun/pkg/cache"
+)
+
+func searchIssue(number int) map[string]any {
+	return map[string]any{
+		"number":       number,
+		"title":        fmt.Sprintf("PR %d", number),
+		"url":          fmt.Sprintf("https://api.github.com/repos/acme/app/issues/%d", number),
+		"pull_request": map[string]any{"url": "https://api.github.com/repos/acme/app/pulls"},
+	}
+}
+
+func TestSearchPullRequestsSince(t *testing.T) {
+	var queries []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("q"))
+		items := []map[string]any{searchIssue(4), searchIssue(2)} // 2 got a push, 4 is new
+		_ = json.NewEncoder(w).Encode(map[string]any{"total_count": len(items), "items": items})
+	})
+	mux.HandleFunc("/repos/acme/app/pulls/", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"head": map[string]any{"sha": "new-sha"}})
+	})
+	var looked []any
+	mux.HandleFunc("POST /graphql", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]any `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		for i := 0; req.Variables[fmt.Sprintf("n%d", i)] != nil; i++ {
+			looked = append(looked, req.Variables[fmt.Sprintf("n%d", i)])
+		}
+		// 1 is untouched, 3 was closed, 5 stopped matching and 6 was deleted
+		pr := func(state, updatedAt string) map[string]any {
+			return map[string]any{"pullRequest": map[string]any{"state": state, "updatedAt": updatedAt}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{
+				"pr0": pr("OPEN", "2025-02-01T00:00:00Z"),
+				"pr1": pr("CLOSED", "2025-03-01T12:30:00Z"),
+				"pr2": pr("OPEN", "2025-03-01T12:30:00Z"),
+				"pr3": map[string]any{"pullRequest": nil},
+			},
+			"errors": []map[string]any{{"type": "NOT_FOUND", "message": "Could not resolve to a PullRequest with the number of 6."}},
+		})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(srv.Client())
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &Client{
+		client:        gh,
+		searchQuery:   "is:pr is:open",
+		cache:         cache.NewNoOpCache(),
+		backoffConfig: backoffconfig.Config{MaxElapsedTime: time.Millisecond, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 1},
+		health:        &health{},
+		graphqlClient: &GraphQLClient{endpoint: srv.URL + "/graphql", httpClient: srv.Client()},
+	}
+
+	existing := []*PullRequest{
+		{Owner: "acme", Repo: "app", Number: 1, HeadSHA: "sha-1", client: c},
+		{Owner: "acme", Repo: "app", Number: 2, HeadSHA: "sha-2", client: c},
+		{Owner: "acme", Repo: "app", Number: 3, HeadSHA: "sha-3", client: c},
+		{Owner: "acme", Repo: "app", Number: 5, HeadSHA: "sha-5", client: c},
+		{Owner: "acme", Repo: "app", Number: 6, HeadSHA: "sha-6", client: c},
+	}
+	since := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	prs, err := c.SearchPullRequestsSince(context.Background(), existing, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "is:pr is:open updated:>2025-03-01T11:59:00Z"; len(queries) != 1 || queries[0] != want {
+		t.Errorf("searches = %q, want just %q", queries, want)
+	}
+	if want := "[1 3 5 6]"; fmt.Sprint(looked) != want {
+		t.Errorf("looked up the states of %v, want %s", looked, want)
+	}
+
+	var got []string
+	for _, pr := range prs {
+		got = append(got, fmt.Sprintf("%d:%s", pr.Number, pr.HeadSHA))
+	}
+	if want := "1:sha-1 2:new-sha 4:new-sha"; strings.Join(got, " ") != want {
+		t.Errorf("prs = %v, want %s", got, want)
+	}
+	if prs[0] != existing[0] {
+		t.Error("unchanged PR was refetched")
+	}
+}
@@ -0,0 +1,196 @@
+package github
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// diffBudget is about how many characters of a PR's diff the agent gets
+const diffBudget = 8000
+
+// lockfiles are generated from manifests, so their hunks say little
+var lockfiles = map[string]bool{
+	"go.sum": true, "package-lock.json": true, "yarn.lock": true, "pnpm-lock.yaml": true,
+	"Cargo.lock": true, "Gemfile.lock": true, "poetry.lock": true, "composer.lock": true,
+	"Pipfile.lock": true, "uv.lock": true, "mix.lock": true, "flake.lock": true,
+}
+
+// vendoredDirs hold copies of other projects' code
+var vendoredDirs = []string{"vendor/", "node_modules/", "third_party/", "third-party/"}
+
+// fileDiff is one file's part of a unified diff
+type fileDiff struct {
+	Path   string
+	Header string   // From the diff --git line up to the first hunk
+	Hunks  []string // Each from its @@ line
+}
+
+// isVendored reports whether the file is a lockfile or vendored code, whose
+// hunks go first when a diff has to be cut
+func (f fileDiff) isVendored() bool {
+	if lockfiles[path.Base(f.Path)] || strings.HasSuffix(f.Path, ".min.js") {
+		return true
+	}
+	for _, dir := range vendoredDirs {
+		if strings.HasPrefix(f.Path, dir) || strings.Contains(f.Path, "/"+dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitDiff splits a unified diff into its files
+func splitDiff(diff string) []fileDiff {
+	var files []fileDiff
+	var header, hunk strings.Builder
+	var current *fileDiff
+	flushHunk := func() {
+		if current != nil && hunk.Len() > 0 {
+			current.Hunks = append(current.Hunks, hunk.String())
+			hunk.Reset()
+		}
+	}
+	flushFile := func() {
+		flushHunk()
+		if current != nil {
+			if current.Header == "" {
+				current.Header = header.String()
+			}
+			files = append(files, *current)
+		}
+		header.Reset()
+	}
+
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flushFile()
+			current = &fileDiff{Path: diffPath(line)}
+			header.WriteString(line)
+		case current == nil:
+			continue
+		case strings.HasPrefix(line, "@@"):
+			if current.Header == "" {
+				current.Header = header.String()
+			}
+			flushHunk()
+			hunk.WriteString(line)
+		case hunk.Len() > 0:
+			hunk.WriteString(line)
+		default:
+			header.WriteString(line)
+		}
+	}
+	flushFile()
+	return files
+}
+
+// diffPath takes the new path from a "diff --git a/x b/y" line
+func diffPath(line string) string {
+	line = strings.TrimSpace(strings.TrimPrefix(line, "diff --git "))
+	if i := strings.LastIndex(line, " b/"); i >= 0 {
+		return line[i+3:]
+	}
+	return line
+}
+
+// hunkSummary is a hunk's @@ line with the lines it adds and removes
+func hunkSummary(hunk string) string {
+	atLine, _, _ := strings.Cut(hunk, "\n")
+	added, removed := countChanges(hunk)
+	return fmt.Sprintf("%s (+%d -%d, omitted)\n", atLine, added, removed)
+}
+
+// countChanges counts the added and removed lines in hunks
+func countChanges(hunks string) (added, removed int) {
+	for _, line := range strings.Split(hunks, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			added++
+		case strings.HasPrefix(line, "-"):
+			removed++
+		}
+	}
+	return added, removed
+}
+
+// truncateDiff fits a diff into about budget characters. Every file keeps its
+// header and every hunk at least its @@ line; whole hunks are then filled in
+// file by file, with lockfiles and vendored code last. If even the headers
+// don't fit, each file is listed on one line.
+func truncateDiff(diff string, budget int) string {
+	if len(diff) <= budget {
+		return diff
+	}
+	files := splitDiff(diff)
+	if len(files) == 0 {
+		return diff[:budget] + "\n... (diff truncated due to size)"
+	}
+
+	// Start from the skeleton of headers and hunk summaries
+	full := make([][]bool, len(files))
+	size := 0
+	for i, file := range files {
+		full[i] = make([]bool, len(file.Hunks))
+		size += len(file.Header)
+		for _, hunk := range file.Hunks {
+			size += len(hunkSummary(hunk))
+		}
+	}
+	if size > budget {
+		return listDiffFiles(files)
+	}
+
+	// Spend what's left on whole hunks, vendored files last
+	for _, vendored := range []bool{false, true} {
+		for i, file := range files {
+			if file.isVendored() != vendored {
+				continue
+			}
+			for j, hunk := range file.Hunks {
+				if extra := len(hunk) - len(hunkSummary(hunk)); size+extra <= budget {
+					full[i][j] = true
+					size += extra
+				}
+			}
+		}
+	}
+
+	var out strings.Builder
+	omitted := 0
+	for i, file := range files {
+		out.WriteString(file.Header)
+		for j, hunk := range file.Hunks {
+			if full[i][j] {
+				out.WriteString(hunk)
+			} else {
+				out.WriteString(hunkSummary(hunk))
+				omitted++
+			}
+		}
+	}
+	fmt.Fprintf(&out, "\n... (%d hunks omitted due to size; get_pr_diff with a path shows one file's whole diff)", omitted)
+	return out.String()
+}
+
+// listDiffFiles describes each file of a diff in one line
+func listDiffFiles(files []fileDiff) string {
+	var out strings.Builder
+	for _, file := range files {
+		added, removed := countChanges(strings.Join(file.Hunks, ""))
+		fmt.Fprintf(&out, "%s: %d hunks, +%d -%d\n", file.Path, len(file.Hunks), added, removed)
+	}
+	out.WriteString("\n... (diff too large to show; get_pr_diff with a path shows one file's whole diff)")
+	return out.String()
+}
+
+// fileDiffFrom returns one file's part of a diff, fitted into budget
+func fileDiffFrom(diff, filePath string, budget int) (string, bool) {
+	for _, file := range splitDiff(diff) {
+		if file.Path == filePath {
+			return truncateDiff(file.Header+strings.Join(file.Hunks, ""), budget), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,75 @@
+package github
+
+import (
+	"strings"
+	"testing"
+)
+
+const testDiff = `diff --git a/main.go b/main.go
+index 111..222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,4 @@ package main
+ import "fmt"
++import "os"
+@@ -10,2 +11,2 @@ func main() {
+-	fmt.Println("hi")
++	fmt.Fprintln(os.Stderr, "hi")
+diff --git a/go.sum b/go.sum
+index 333..444 100644
+--- a/go.sum
++++ b/go.sum
+@@ -1,2 +1,2 @@
+-example.com/lib v1.0.0 h1:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa=
++example.com/lib v1.1.0 h1:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb=
+`
+
+func TestSplitDiff(t *testing.T) {
+	files := splitDiff(testDiff)
+	if len(files) != 2 || files[0].Path != "main.go" || files[1].Path != "go.sum" {
+		t.Fatalf("splitDiff() = %+v", files)
+	}
+	if len(files[0].Hunks) != 2 || !strings.HasPrefix(files[0].Header, "diff --git") || !strings.HasSuffix(files[0].Header, "+++ b/main.go\n") {
+		t.Errorf("main.go = %+v", files[0])
+	}
+	if files[0].isVendored() || !files[1].isVendored() {
+		t.Error("only go.sum should count as vendored")
+	}
+	if !(fileDiff{Path: "internal/vendor/x.go"}).isVendored() || (fileDiff{Path: "vendors.go"}).isVendored() {
+		t.Error("vendored directories are matched by path segment")
+	}
+}
+
+func TestTruncateDiff(t *testing.T) {
+	if got := truncateDiff(testDiff, len(testDiff)); got != testDiff {
+		t.Error("a diff within budget is returned whole")
+	}
+
+	// Room for the code hunks but not the lockfile's
+	got := truncateDiff(testDiff, len(testDiff)-100)
+	if !strings.Contains(got, `+	fmt.Fprintln(os.Stderr, "hi")`) {
+		t.Errorf("code hunks were cut before the lockfile:\n%s", got)
+	}
+	if strings.Contains(got, "h1:bbbb") || !strings.Contains(got, "@@ -1,2 +1,2 @@ (+1 -1, omitted)") {
+		t.Errorf("lockfile hunk not reduced to its summary:\n%s", got)
+	}
+	if !strings.Contains(got, "diff --git a/go.sum b/go.sum") {
+		t.Error("the lockfile's header was dropped")
+	}
+
+	// Too small for even the headers
+	got = truncateDiff(testDiff, 50)
+	if !strings.HasPrefix(got, "main.go: 2 hunks, +2 -1\ngo.sum: 1 hunks, +1 -1\n") {
+		t.Errorf("truncateDiff() listing = %q", got)
+	}
+}
+
+func TestFileDiffFrom(t *testing.T) {
+	got, ok := fileDiffFrom(testDiff, "go.sum", diffBudget)
+	if !ok || !strings.HasPrefix(got, "diff --git a/go.sum") || strings.Contains(got, "main.go") {
+		t.Errorf("fileDiffFrom() = %q, %v", got, ok)
+	}
+	if _, ok := fileDiffFrom(testDiff, "missing.go", diffBudget); ok {
+		t.Error("fileDiffFrom() found a file the diff doesn't change")
+	}
+}
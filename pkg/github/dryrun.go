@@ -0,0 +1,23 @@
+package github
+
+import "log/slog"
+
+// SetDryRun makes write operations log what they would do and succeed
+// without calling GitHub
+func (c *Client) SetDryRun(dryRun bool) {
+	c.dryRun = dryRun
+}
+
+// DryRun reports whether write operations are only logged
+func (c *Client) DryRun() bool {
+	return c.dryRun
+}
+
+// skipWrite logs a write operation and reports whether dry-run mode skips it
+func (c *Client) skipWrite(action string, attrs ...any) bool {
+	if !c.dryRun {
+		return false
+	}
+	slog.Info("Dry run: would "+action, attrs...)
+	return true
+}
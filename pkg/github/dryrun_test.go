@@ -0,0 +1,44 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v73/github"
+)
+
+func TestDryRunSkipsWrites(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	gh := github.NewClient(srv.Client())
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	client := &Client{client: gh, health: &health{}}
+	client.SetDryRun(true)
+	pr := &PullRequest{Owner: "acme", Repo: "app", Number: 5, client: client}
+
+	ctx := context.Background()
+	writes := map[string]func() error{
+		"approve":    func() error { return pr.Approve(ctx) },
+		"auto-merge": func() error { return pr.EnableAutoMerge(ctx, "SQUASH") },
+		"merge":      func() error { return pr.Merge(ctx, "SQUASH") },
+		"comment":    func() error { return pr.UpsertComment(ctx, "<!-- m -->", "<!-- m -->\nhi") },
+		"reassign":   func() error { return pr.ReassignReviewer(ctx, "alice", "bob") },
+		"bot":        func() error { return pr.RunBotAction(ctx, BotRebase) },
+	}
+	for name, write := range writes {
+		if err := write(); err != nil {
+			t.Errorf("%s in dry run: %v", name, err)
+		}
+	}
+	if len(requests) > 0 {
+		t.Errorf("dry run sent %v", requests)
+	}
+}
@@ -0,0 +1,111 @@
+package github
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	"github.com/kennyp/speedrun/pkg/cache"
+)
+
+// flakyWindow is how far back check history counts towards flakiness
+const flakyWindow = 30 * 24 * time.Hour
+
+// checkHistory returns the cache's check history, or nil if it doesn't keep one
+func (c *Client) checkHistory() cache.CheckHistory {
+	if c.checksConfig.FlakyReruns <= 0 {
+		return nil
+	}
+	history, _ := c.cache.(cache.CheckHistory)
+	return history
+}
+
+// recordCheckRuns stores the finished runs of a commit's checks. Cancelled
+// runs say nothing about flakiness and are skipped.
+func (c *Client) recordCheckRuns(owner, repo, sha string, runs []*github.CheckRun) {
+	history := c.checkHistory()
+	if history == nil {
+		return
+	}
+
+	var finished []cache.CheckRun
+	for _, run := range runs {
+		if run.GetStatus() != "completed" || run.GetConclusion() == "cancelled" {
+			continue
+		}
+		finished = append(finished, cache.CheckRun{
+			ID:     run.GetID(),
+			Repo:   owner + "/" + repo,
+			Name:   run.GetName(),
+			SHA:    sha,
+			Passed: convertCheckRunStatus(run.GetStatus(), run.GetConclusion()) == "success",
+			At:     run.GetCompletedAt().Time,
+		})
+	}
+	if err := history.RecordCheckRuns(finished); err != nil {
+		slog.Debug("Failed to record check runs", slog.String("repo", owner+"/"+repo), slog.Any("error", err))
+	}
+}
+
+// latestCheckRuns keeps the most recent run of each check, in the order the
+// checks were listed
+func latestCheckRuns(runs []*github.CheckRun) []*github.CheckRun {
+	latest := make(map[string]int)
+	var kept []*github.CheckRun
+	for _, run := range runs {
+		if i, ok := latest[run.GetName()]; ok {
+			if run.GetID() > kept[i].GetID() {
+				kept[i] = run
+			}
+			continue
+		}
+		latest[run.GetName()] = len(kept)
+		kept = append(kept, run)
+	}
+	return kept
+}
+
+// isFlaky reports whether a check passed on rerun often enough, and for at
+// least half of its failures, to be likely flaky
+func isFlaky(stats cache.CheckStats, minReruns int) bool {
+	return minReruns > 0 && stats.PassedOnRerun >= minReruns && stats.PassedOnRerun*2 >= stats.Failures
+}
+
+// markFlakyChecks tags the checks whose recent history in the repository
+// looks flaky
+func (c *Client) markFlakyChecks(owner, repo string, details []CheckDetail) {
+	history := c.checkHistory()
+	if history == nil || len(details) == 0 {
+		return
+	}
+
+	stats, err := history.CheckStats(owner+"/"+repo, time.Now().Add(-flakyWindow))
+	if err != nil {
+		slog.Debug("Failed to read check history", slog.String("repo", owner+"/"+repo), slog.Any("error", err))
+		return
+	}
+	for i := range details {
+		details[i].Flaky = isFlaky(stats[details[i].Name], c.checksConfig.FlakyReruns)
+	}
+}
+
+// countedChecks returns the checks that make up the overall state. With
+// ExcludeFlaky, failures of likely flaky checks don't count, unless nothing
+// else is left.
+func (c *Client) countedChecks(details []CheckDetail) []CheckDetail {
+	if !c.checksConfig.ExcludeFlaky {
+		return details
+	}
+
+	var counted []CheckDetail
+	for _, detail := range details {
+		if detail.Flaky && (detail.Status == "failure" || detail.Status == "error") {
+			continue
+		}
+		counted = append(counted, detail)
+	}
+	if len(counted) == 0 {
+		return details
+	}
+	return counted
+}
@@ -0,0 +1,77 @@
+package github
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	"github.com/kennyp/speedrun/pkg/cache"
+)
+
+func TestLatestCheckRuns(t *testing.T) {
+	runs := []*github.CheckRun{
+		{ID: github.Ptr(int64(3)), Name: github.Ptr("test"), Conclusion: github.Ptr("success")},
+		{ID: github.Ptr(int64(2)), Name: github.Ptr("lint")},
+		{ID: github.Ptr(int64(1)), Name: github.Ptr("test"), Conclusion: github.Ptr("failure")},
+	}
+
+	got := latestCheckRuns(runs)
+	if len(got) != 2 || got[0].GetID() != 3 || got[1].GetID() != 2 {
+		t.Errorf("latestCheckRuns() kept %v", got)
+	}
+}
+
+func TestIsFlaky(t *testing.T) {
+	tests := []struct {
+		stats cache.CheckStats
+		want  bool
+	}{
+		{cache.CheckStats{Failures: 4, PassedOnRerun: 3}, true},
+		{cache.CheckStats{Failures: 6, PassedOnRerun: 3}, true},
+		{cache.CheckStats{Failures: 10, PassedOnRerun: 3}, false}, // Mostly real failures
+		{cache.CheckStats{Failures: 2, PassedOnRerun: 2}, false},  // Too few reruns to tell
+	}
+	for _, tt := range tests {
+		if got := isFlaky(tt.stats, 3); got != tt.want {
+			t.Errorf("isFlaky(%+v) = %v, want %v", tt.stats, got, tt.want)
+		}
+	}
+	if isFlaky(cache.CheckStats{Failures: 5, PassedOnRerun: 5}, 0) {
+		t.Error("flaky detection is disabled at 0")
+	}
+}
+
+func TestFlakyChecks(t *testing.T) {
+	store, err := cache.New(filepath.Join(t.TempDir(), "cache.db"), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	c := &Client{cache: store, checksConfig: ChecksConfig{FlakyReruns: 2, ExcludeFlaky: true}}
+
+	// "e2e" fails and then passes on a rerun on two commits
+	completed := github.Ptr("completed")
+	for i, sha := range []string{"a", "b"} {
+		id := int64(i * 10)
+		c.recordCheckRuns("acme", "app", sha, []*github.CheckRun{
+			{ID: github.Ptr(id + 1), Name: github.Ptr("e2e"), Status: completed, Conclusion: github.Ptr("failure"), CompletedAt: &github.Timestamp{Time: time.Now().Add(-time.Hour)}},
+			{ID: github.Ptr(id + 2), Name: github.Ptr("e2e"), Status: completed, Conclusion: github.Ptr("success"), CompletedAt: &github.Timestamp{Time: time.Now()}},
+			{ID: github.Ptr(id + 3), Name: github.Ptr("unit"), Status: completed, Conclusion: github.Ptr("failure"), CompletedAt: &github.Timestamp{Time: time.Now()}},
+		})
+	}
+
+	details := []CheckDetail{{Name: "e2e", Status: "failure"}, {Name: "unit", Status: "success"}}
+	c.markFlakyChecks("acme", "app", details)
+	if !details[0].Flaky || details[1].Flaky {
+		t.Fatalf("Flaky = %v, %v; want e2e only", details[0].Flaky, details[1].Flaky)
+	}
+	if state := aggregateCheckStates(c.countedChecks(details)); state != "success" {
+		t.Errorf("state = %q, want the flaky failure left out", state)
+	}
+
+	c.checksConfig.ExcludeFlaky = false
+	if state := aggregateCheckStates(c.countedChecks(details)); state != "failure" {
+		t.Errorf("state = %q, want the flaky failure counted", state)
+	}
+}
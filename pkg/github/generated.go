@@ -0,0 +1,196 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/kennyp/speedrun/pkg/codeowners"
+)
+
+// Kinds of changed files that aren't hand-written source. They don't count
+// towards PR size heuristics and their hunks aren't sent to the AI.
+const (
+	FileBinary    = "binary"
+	FileMinified  = "minified"
+	FileGenerated = "generated"
+)
+
+// ExcludedFile is a changed file that isn't hand-written source
+type ExcludedFile struct {
+	Path    string
+	Kind    string // FileBinary, FileMinified or FileGenerated
+	Changes int    // Lines added plus lines removed
+}
+
+// binaryExtensions are file types that are never text
+var binaryExtensions = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".ico": true, ".webp": true, ".bmp": true,
+	".pdf": true, ".zip": true, ".gz": true, ".tgz": true, ".jar": true, ".war": true, ".whl": true,
+	".woff": true, ".woff2": true, ".ttf": true, ".otf": true, ".eot": true,
+	".exe": true, ".dll": true, ".so": true, ".dylib": true, ".a": true, ".wasm": true, ".class": true, ".pyc": true,
+	".mp3": true, ".mp4": true, ".mov": true, ".wav": true,
+}
+
+// generatedSuffixes end the names of files that code generators write
+var generatedSuffixes = []string{
+	".pb.go", ".pb.gw.go", "_pb2.py", "_pb2_grpc.py", ".pb.cc", ".pb.h", "_generated.go", ".gen.go",
+	".g.dart", ".freezed.dart", ".designer.cs", ".js.map", ".css.map", ".snap",
+}
+
+// generatedMarkers appear near the top of generated files, e.g. Go's
+// "Code generated ... DO NOT EDIT."
+var generatedMarkers = []string{"DO NOT EDIT", "@generated", "<auto-generated"}
+
+// minifiedLineLength is how long a JavaScript or CSS line gets before the
+// file is taken to be minified
+const minifiedLineLength = 1000
+
+// guessFileKind classifies a changed file from its name and patch. Returns
+// "" for source.
+func guessFileKind(filePath string, binary bool, patch string) string {
+	base := path.Base(filePath)
+	ext := strings.ToLower(path.Ext(base))
+	switch {
+	case binary || binaryExtensions[ext]:
+		return FileBinary
+	case strings.HasSuffix(base, ".min.js") || strings.HasSuffix(base, ".min.css") ||
+		(slices.Contains([]string{".js", ".mjs", ".cjs", ".css"}, ext) && hasLongLine(patch)):
+		return FileMinified
+	case lockfiles[base] || strings.HasPrefix(base, "zz_generated") ||
+		slices.ContainsFunc(generatedSuffixes, func(suffix string) bool { return strings.HasSuffix(base, suffix) }) ||
+		hasGeneratedHeader(patch):
+		return FileGenerated
+	}
+	return ""
+}
+
+// hasLongLine reports whether a patch adds a line too long to be written by hand
+func hasLongLine(patch string) bool {
+	for _, line := range strings.Split(patch, "\n") {
+		if strings.HasPrefix(line, "+") && len(line) > minifiedLineLength {
+			return true
+		}
+	}
+	return false
+}
+
+// hasGeneratedHeader reports whether a patch shows a generated-code marker in
+// the first lines of the file
+func hasGeneratedHeader(patch string) bool {
+	if !strings.HasPrefix(patch, "@@ -0,0 +1") && !strings.HasPrefix(patch, "@@ -1,") {
+		return false
+	}
+	lines := strings.Split(patch, "\n")
+	for _, line := range lines[1:min(len(lines), 11)] {
+		if slices.ContainsFunc(generatedMarkers, func(marker string) bool { return strings.Contains(line, marker) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// fileKind applies the repository's linguist-generated attributes to a
+// guessed kind. Binary files stay binary; otherwise the attribute, set or
+// unset, wins over the guess.
+func fileKind(filePath, guess string, attrs codeowners.Ruleset) string {
+	if guess == FileBinary {
+		return guess
+	}
+	for _, attr := range attrs.Owners(filePath) {
+		switch attr {
+		case "linguist-generated", "linguist-generated=true":
+			return FileGenerated
+		case "-linguist-generated", "linguist-generated=false":
+			return ""
+		}
+	}
+	return guess
+}
+
+// parseGitattributes reads the linguist-generated lines of a .gitattributes
+// file. They're shaped like CODEOWNERS lines, pattern first and the last
+// match winning, so the CODEOWNERS matcher reads them with the attributes in
+// place of owners.
+func parseGitattributes(content string) (codeowners.Ruleset, error) {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if strings.Contains(line, "linguist-generated") {
+			lines = append(lines, line)
+		}
+	}
+	return codeowners.Parse(strings.NewReader(strings.Join(lines, "\n")))
+}
+
+// getGeneratedAttributes returns the linguist-generated patterns from a
+// repository's .gitattributes on its default branch, or nil if it has none
+func (c *Client) getGeneratedAttributes(ctx context.Context, owner, repo string) (codeowners.Ruleset, error) {
+	cacheKey := fmt.Sprintf("gitattributes:%s/%s", owner, repo)
+	var content string
+	if err := c.cacheGet(ctx, cacheKey, &content); err != nil {
+		content, err = c.getFileContent(ctx, owner, repo, ".gitattributes", "")
+		if err != nil && !errors.Is(err, errNoFile) {
+			return nil, fmt.Errorf("failed to get .gitattributes: %w", err)
+		}
+		if err := c.cacheSet(ctx, cacheKey, content); err != nil {
+			slog.Debug("Failed to cache .gitattributes", slog.Any("error", err))
+		}
+	}
+	return parseGitattributes(content)
+}
+
+// excludedFiles picks the binary, minified and generated files out of a PR's
+// changed files
+func (pr *PullRequest) excludedFiles(ctx context.Context, files []changedFile) ([]ExcludedFile, error) {
+	attrs, err := pr.client.getGeneratedAttributes(ctx, pr.Owner, pr.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var excluded []ExcludedFile
+	for _, file := range files {
+		if kind := fileKind(file.Path, file.Kind, attrs); kind != "" {
+			excluded = append(excluded, ExcludedFile{Path: file.Path, Kind: kind, Changes: file.Changes})
+		}
+	}
+	return excluded, nil
+}
+
+// omitExcludedHunks replaces the hunks of a diff's binary, minified and
+// generated files with a one-line note
+func omitExcludedHunks(diff string, attrs codeowners.Ruleset) string {
+	files := splitDiff(diff)
+	if len(files) == 0 {
+		return diff
+	}
+
+	var out strings.Builder
+	for _, file := range files {
+		out.WriteString(file.Header)
+		patch := strings.Join(file.Hunks, "")
+		binary := strings.Contains(file.Header, "\nBinary files ") || strings.Contains(file.Header, "\nGIT binary patch")
+		kind := fileKind(file.Path, guessFileKind(file.Path, binary, patch), attrs)
+		if kind == "" || patch == "" {
+			out.WriteString(patch)
+			continue
+		}
+		added, removed := countChanges(patch)
+		fmt.Fprintf(&out, "... (%s file, +%d -%d omitted)\n", kind, added, removed)
+	}
+	return out.String()
+}
+
+// Reviewable returns the lines and files changed, not counting binary,
+// minified and generated files
+func (ds *DiffStats) Reviewable() (lines, files int) {
+	lines, files = ds.Additions+ds.Deletions, ds.Files
+	for _, file := range ds.Excluded {
+		lines -= file.Changes
+		files--
+	}
+	return max(lines, 0), max(files, 0)
+}
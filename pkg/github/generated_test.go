@@ -0,0 +1,101 @@
+package github
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGuessFileKind(t *testing.T) {
+	long := "@@ -0,0 +1 @@\n+" + strings.Repeat("a;", minifiedLineLength)
+	tests := []struct {
+		path   string
+		binary bool
+		patch  string
+		want   string
+	}{
+		{"assets/logo.PNG", false, "", FileBinary},
+		{"bin/tool", true, "", FileBinary},
+		{"web/app.min.js", false, "", FileMinified},
+		{"web/bundle.js", false, long, FileMinified},
+		{"data/fixture.json", false, long, ""},
+		{"api/v1/service.pb.go", false, "", FileGenerated},
+		{"pkg/apis/zz_generated.deepcopy.go", false, "", FileGenerated},
+		{"go.sum", false, "", FileGenerated},
+		{"internal/mocks/store.go", false, "@@ -0,0 +1,3 @@\n+// Code generated by mockgen. DO NOT EDIT.\n+\n+package mocks", FileGenerated},
+		{"internal/store.go", false, "@@ -40,3 +40,3 @@\n+// DO NOT EDIT this list by hand", ""},
+		{"main.go", false, "@@ -1,2 +1,3 @@\n package main\n+import \"os\"", ""},
+	}
+	for _, tt := range tests {
+		if got := guessFileKind(tt.path, tt.binary, tt.patch); got != tt.want {
+			t.Errorf("guessFileKind(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestFileKindAttributes(t *testing.T) {
+	attrs, err := parseGitattributes(`*.go text eol=lf
+gen/** linguist-generated
+gen/keep.go -linguist-generated
+*.png binary
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path, guess, want string
+	}{
+		{"gen/client.go", "", FileGenerated},
+		{"gen/keep.go", "", ""},
+		{"gen/logo.png", FileBinary, FileBinary},
+		{"src/main.go", "", ""},
+		{"src/api.pb.go", FileGenerated, FileGenerated},
+	}
+	for _, tt := range tests {
+		if got := fileKind(tt.path, tt.guess, attrs); got != tt.want {
+			t.Errorf("fileKind(%q, %q) = %q, want %q", tt.path, tt.guess, got, tt.want)
+		}
+	}
+	if got := fileKind("api.pb.go", FileGenerated, nil); got != FileGenerated {
+		t.Errorf("fileKind() without attributes = %q", got)
+	}
+}
+
+func TestOmitExcludedHunks(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1 +1 @@
+-old
++new
+diff --git a/api.pb.go b/api.pb.go
+--- a/api.pb.go
++++ b/api.pb.go
+@@ -1,2 +1,2 @@
+-generated old
++generated new
+diff --git a/logo.png b/logo.png
+index 111..222 100644
+Binary files a/logo.png and b/logo.png differ
+`
+	got := omitExcludedHunks(diff, nil)
+	if !strings.Contains(got, "-old\n+new\n") {
+		t.Errorf("source hunk dropped:\n%s", got)
+	}
+	if strings.Contains(got, "generated new") || !strings.Contains(got, "+++ b/api.pb.go\n... (generated file, +1 -1 omitted)\n") {
+		t.Errorf("generated hunk not omitted:\n%s", got)
+	}
+	if !strings.Contains(got, "Binary files a/logo.png and b/logo.png differ") {
+		t.Errorf("binary file header dropped:\n%s", got)
+	}
+}
+
+func TestReviewable(t *testing.T) {
+	stats := &DiffStats{Additions: 900, Deletions: 150, Files: 5, Excluded: []ExcludedFile{
+		{Path: "go.sum", Kind: FileGenerated, Changes: 800},
+		{Path: "logo.png", Kind: FileBinary},
+	}}
+	if lines, files := stats.Reviewable(); lines != 250 || files != 3 {
+		t.Errorf("Reviewable() = %d, %d, want 250, 3", lines, files)
+	}
+}
@@ -8,21 +8,31 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strings"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/tracing"
 )
 
+// graphQLEndpoint is where GraphQL requests go
+const graphQLEndpoint = "https://api.github.com/graphql"
+
 // GraphQLClient handles GitHub GraphQL API requests for specific operations
 // that are not available in the REST API (like auto-merge)
 type GraphQLClient struct {
 	token      string
+	endpoint   string
 	httpClient *http.Client
 }
 
-// NewGraphQLClient creates a new GraphQL client
-func NewGraphQLClient(token string) *GraphQLClient {
+// NewGraphQLClient creates a new GraphQL client using transport for requests,
+// each timing out after timeout (0 for no limit)
+func NewGraphQLClient(token string, transport http.RoundTripper, timeout time.Duration) *GraphQLClient {
 	return &GraphQLClient{
 		token:      token,
-		httpClient: &http.Client{},
+		endpoint:   graphQLEndpoint,
+		httpClient: &http.Client{Transport: transport, Timeout: timeout},
 	}
 }
 
@@ -37,6 +47,7 @@ type GraphQLError struct {
 	Message   string                 `json:"message"`
 	Locations []GraphQLErrorLocation `json:"locations,omitempty"`
 	Path      []any                  `json:"path,omitempty"`
+	Type      string                 `json:"type,omitempty"` // Like NOT_FOUND
 }
 
 // GraphQLErrorLocation represents the location of a GraphQL error
@@ -71,6 +82,9 @@ type AutoMergeResponse struct {
 func (c *GraphQLClient) EnableAutoMerge(ctx context.Context, pullRequestID string, mergeMethod string) (*AutoMergeResponse, error) {
 	slog.Debug("Enabling auto-merge via GraphQL", "pr_id", pullRequestID, "merge_method", mergeMethod)
 
+	ctx, span := tracing.Start(ctx, "github.graphql.EnableAutoMerge", tracing.String("github.merge_method", mergeMethod))
+	defer span.End()
+
 	// Default to SQUASH if no method specified
 	if mergeMethod == "" {
 		mergeMethod = "SQUASH"
@@ -102,6 +116,7 @@ func (c *GraphQLClient) EnableAutoMerge(ctx context.Context, pullRequestID strin
 
 	response, err := c.executeQuery(ctx, mutation, variables)
 	if err != nil {
+		span.RecordError(err)
 		return nil, fmt.Errorf("failed to execute auto-merge mutation: %w", err)
 	}
 
@@ -119,6 +134,9 @@ func (c *GraphQLClient) EnableAutoMerge(ctx context.Context, pullRequestID strin
 func (c *GraphQLClient) GetPullRequestNodeID(ctx context.Context, owner, repo string, number int) (string, error) {
 	slog.Debug("Getting PR node ID via GraphQL", "owner", owner, "repo", repo, "number", number)
 
+	ctx, span := tracing.Start(ctx, "github.graphql.GetPullRequestNodeID", tracing.String("github.repo", owner+"/"+repo), tracing.Int("github.pr", number))
+	defer span.End()
+
 	query := `
 		query GetPullRequestNodeID($owner: String!, $repo: String!, $number: Int!) {
 			repository(owner: $owner, name: $repo) {
@@ -137,6 +155,7 @@ func (c *GraphQLClient) GetPullRequestNodeID(ctx context.Context, owner, repo st
 
 	response, err := c.executeQuery(ctx, query, variables)
 	if err != nil {
+		span.RecordError(err)
 		return "", fmt.Errorf("failed to get PR node ID: %w", err)
 	}
 
@@ -160,6 +179,66 @@ func (c *GraphQLClient) GetPullRequestNodeID(ctx context.Context, owner, repo st
 	return result.Repository.PullRequest.ID, nil
 }
 
+// prStatesBatch is how many PRs one GetPullRequestStates query asks about
+const prStatesBatch = 50
+
+// PRStatus is a PR's state and when it last changed
+type PRStatus struct {
+	State     PRState
+	UpdatedAt time.Time
+}
+
+// GetPullRequestStates looks up the status of each of refs, prStatesBatch
+// PRs a query. PRs that were deleted or can no longer be seen are left out.
+func (c *GraphQLClient) GetPullRequestStates(ctx context.Context, refs []PRRef) (map[PRRef]PRStatus, error) {
+	ctx, span := tracing.Start(ctx, "github.graphql.GetPullRequestStates", tracing.Int("github.prs", len(refs)))
+	defer span.End()
+
+	states := make(map[PRRef]PRStatus, len(refs))
+	for batch := range slices.Chunk(refs, prStatesBatch) {
+		var params, fields []string
+		variables := make(map[string]any, 3*len(batch))
+		for i, ref := range batch {
+			params = append(params, fmt.Sprintf("$o%d: String!, $r%d: String!, $n%d: Int!", i, i, i))
+			fields = append(fields, fmt.Sprintf("pr%d: repository(owner: $o%d, name: $r%d) { pullRequest(number: $n%d) { state updatedAt } }", i, i, i, i))
+			variables[fmt.Sprintf("o%d", i)] = ref.Owner
+			variables[fmt.Sprintf("r%d", i)] = ref.Repo
+			variables[fmt.Sprintf("n%d", i)] = ref.Number
+		}
+		query := fmt.Sprintf("query GetPullRequestStates(%s) {\n%s\n}", strings.Join(params, ", "), strings.Join(fields, "\n"))
+
+		response, err := c.execute(ctx, query, variables)
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to get PR states: %w", err)
+		}
+		// A PR or repository that's gone is an error for just its part
+		for _, e := range response.Errors {
+			if e.Type != "NOT_FOUND" {
+				err := fmt.Errorf("GraphQL errors: %+v", response.Errors)
+				span.RecordError(err)
+				return nil, fmt.Errorf("failed to get PR states: %w", err)
+			}
+		}
+
+		var result map[string]*struct {
+			PullRequest *struct {
+				State     string    `json:"state"` // OPEN, CLOSED or MERGED
+				UpdatedAt time.Time `json:"updatedAt"`
+			} `json:"pullRequest"`
+		}
+		if err := json.Unmarshal(response.Data, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse PR states response: %w", err)
+		}
+		for i, ref := range batch {
+			if repo := result[fmt.Sprintf("pr%d", i)]; repo != nil && repo.PullRequest != nil {
+				states[ref] = PRStatus{State: PRState(strings.ToLower(repo.PullRequest.State)), UpdatedAt: repo.PullRequest.UpdatedAt}
+			}
+		}
+	}
+	return states, nil
+}
+
 // formatGraphQLError converts common GraphQL error messages to user-friendly messages
 func formatGraphQLError(message string) string {
 	lowerMsg := strings.ToLower(message)
@@ -203,6 +282,28 @@ func formatGraphQLError(message string) string {
 
 // executeQuery executes a GraphQL query/mutation
 func (c *GraphQLClient) executeQuery(ctx context.Context, query string, variables map[string]any) (*GraphQLResponse, error) {
+	graphqlResp, err := c.execute(ctx, query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(graphqlResp.Errors) > 0 {
+		// Provide more user-friendly error messages for common auto-merge failures
+		for _, err := range graphqlResp.Errors {
+			if friendlyMsg := formatGraphQLError(err.Message); friendlyMsg != "" {
+				return nil, fmt.Errorf("%s", friendlyMsg)
+			}
+		}
+		// Fallback to generic error if no friendly message found
+		return nil, fmt.Errorf("GraphQL errors: %+v", graphqlResp.Errors)
+	}
+
+	return graphqlResp, nil
+}
+
+// execute sends a GraphQL query/mutation, returning its response even if it
+// has errors, as partial results come with errors for the parts that failed
+func (c *GraphQLClient) execute(ctx context.Context, query string, variables map[string]any) (*GraphQLResponse, error) {
 	payload := map[string]any{
 		"query":     query,
 		"variables": variables,
@@ -213,7 +314,7 @@ func (c *GraphQLClient) executeQuery(ctx context.Context, query string, variable
 		return nil, fmt.Errorf("failed to marshal GraphQL payload: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.github.com/graphql", bytes.NewBuffer(jsonPayload))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewBuffer(jsonPayload))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GraphQL request: %w", err)
 	}
@@ -249,17 +350,5 @@ func (c *GraphQLClient) executeQuery(ctx context.Context, query string, variable
 	if err := json.Unmarshal(body, &graphqlResp); err != nil {
 		return nil, fmt.Errorf("failed to parse GraphQL response: %w", err)
 	}
-
-	if len(graphqlResp.Errors) > 0 {
-		// Provide more user-friendly error messages for common auto-merge failures
-		for _, err := range graphqlResp.Errors {
-			if friendlyMsg := formatGraphQLError(err.Message); friendlyMsg != "" {
-				return nil, fmt.Errorf("%s", friendlyMsg)
-			}
-		}
-		// Fallback to generic error if no friendly message found
-		return nil, fmt.Errorf("GraphQL errors: %+v", graphqlResp.Errors)
-	}
-
 	return &graphqlResp, nil
 }
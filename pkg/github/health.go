@@ -0,0 +1,130 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+)
+
+// outageThreshold is how many consecutive failed GitHub requests it takes to
+// consider GitHub unavailable. A single flaky request shouldn't flip the UI
+// into stale mode.
+const outageThreshold = 5
+
+// ErrStaleMode is returned by write operations while GitHub is unavailable
+var ErrStaleMode = errors.New("GitHub is unavailable, write actions are disabled")
+
+// HealthStatus is a snapshot of GitHub API availability
+type HealthStatus struct {
+	Degraded  bool      // Sustained failures; serve cached data only
+	Since     time.Time // When the current run of failures started
+	LastError string    // Most recent failure
+}
+
+// health tracks consecutive GitHub API failures across all requests
+type health struct {
+	mu        sync.Mutex
+	failures  int
+	since     time.Time
+	lastError string
+}
+
+func (h *health) recordSuccess() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.failures >= outageThreshold {
+		slog.Info("GitHub API recovered", slog.Time("since", h.since), slog.Int("failures", h.failures))
+	}
+	h.failures = 0
+	h.since = time.Time{}
+	h.lastError = ""
+}
+
+func (h *health) recordFailure(reason string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.failures == 0 {
+		h.since = time.Now()
+	}
+	h.failures++
+	h.lastError = reason
+
+	if h.failures == outageThreshold {
+		slog.Warn("GitHub API unavailable, entering stale mode", slog.Int("failures", h.failures), slog.String("last_error", reason))
+	}
+}
+
+func (h *health) status() HealthStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return HealthStatus{
+		Degraded:  h.failures >= outageThreshold,
+		Since:     h.since,
+		LastError: h.lastError,
+	}
+}
+
+// healthTransport feeds request outcomes into health. Server errors and
+// network failures count against availability; any other response, including
+// 4xx, proves GitHub is reachable.
+type healthTransport struct {
+	base   http.RoundTripper
+	health *health
+}
+
+func (t *healthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+
+	switch {
+	case err != nil:
+		// Cancelled requests say nothing about GitHub
+		if req.Context().Err() == nil {
+			t.health.recordFailure(err.Error())
+		}
+	case resp.StatusCode >= 500:
+		t.health.recordFailure(fmt.Sprintf("HTTP %d", resp.StatusCode))
+	default:
+		t.health.recordSuccess()
+	}
+
+	return resp, err
+}
+
+// Health reports whether GitHub is currently considered unavailable
+func (c *Client) Health() HealthStatus {
+	return c.health.status()
+}
+
+// IsTransient reports whether a failed request is worth retrying later: the
+// network failed, GitHub had a server error, or a rate limit was hit. Other
+// failures, like missing PRs or permissions, won't go away by themselves.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var rateLimit *github.RateLimitError
+	var abuse *github.AbuseRateLimitError
+	if errors.As(err, &rateLimit) || errors.As(err, &abuse) {
+		return true
+	}
+
+	var resp *github.ErrorResponse
+	if errors.As(err, &resp) && resp.Response != nil {
+		return resp.Response.StatusCode >= 500 || resp.Response.StatusCode == http.StatusTooManyRequests
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,103 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v73/github"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestHealthTransportDetectsSustainedFailures(t *testing.T) {
+	status := http.StatusBadGateway
+	h := &health{}
+	transport := &healthTransport{
+		health: h,
+		base: roundTripFunc(func(*http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: status, Body: http.NoBody}, nil
+		}),
+	}
+
+	do := func(ctx context.Context) {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for range outageThreshold - 1 {
+		do(context.Background())
+	}
+	if h.status().Degraded {
+		t.Fatal("degraded before reaching the threshold")
+	}
+
+	do(context.Background())
+	if got := h.status(); !got.Degraded || got.LastError != "HTTP 502" || got.Since.IsZero() {
+		t.Fatalf("status = %+v, want degraded with HTTP 502", got)
+	}
+
+	// A client error still proves GitHub is reachable
+	status = http.StatusNotFound
+	do(context.Background())
+	if h.status().Degraded {
+		t.Error("still degraded after a successful round trip")
+	}
+}
+
+func TestHealthTransportIgnoresCancelledRequests(t *testing.T) {
+	h := &health{}
+	transport := &healthTransport{
+		health: h,
+		base: roundTripFunc(func(*http.Request) (*http.Response, error) {
+			return nil, errors.New("context canceled")
+		}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for range outageThreshold {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+		_, _ = transport.RoundTrip(req)
+	}
+
+	if h.status().Degraded {
+		t.Error("cancelled requests counted as an outage")
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	status := func(code int) error {
+		return fmt.Errorf("failed to get reviews: %w", &github.ErrorResponse{Response: &http.Response{StatusCode: code}})
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"server error", status(http.StatusBadGateway), true},
+		{"too many requests", status(http.StatusTooManyRequests), true},
+		{"not found", status(http.StatusNotFound), false},
+		{"forbidden", status(http.StatusForbidden), false},
+		{"rate limit", fmt.Errorf("wrapped: %w", &github.RateLimitError{}), true},
+		{"secondary rate limit", &github.AbuseRateLimitError{}, true},
+		{"network", &url.Error{Op: "Get", URL: "https://api.github.com", Err: errors.New("connection reset")}, true},
+		{"timeout", fmt.Errorf("failed: %w", context.DeadlineExceeded), true},
+		{"cancelled", fmt.Errorf("failed: %w", context.Canceled), false},
+		{"other", errors.New("invalid diff"), false},
+	}
+	for _, tt := range tests {
+		if got := IsTransient(tt.err); got != tt.want {
+			t.Errorf("IsTransient(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package github
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v73/github"
+)
+
+// What a PR does to an infrastructure resource
+const (
+	InfraCreate  = "create"
+	InfraUpdate  = "update"
+	InfraDestroy = "destroy"
+	InfraReplace = "replace" // Only plans can tell
+)
+
+// InfraResource is a Terraform or CloudFormation resource a PR changes
+type InfraResource struct {
+	Address string // Like aws_s3_bucket.logs or module.vpc, or a CloudFormation logical ID and type
+	Action  string // InfraCreate, InfraUpdate, InfraDestroy or InfraReplace
+}
+
+// InfraFile is a Terraform or CloudFormation file a PR changes, with the
+// resources its diff adds, changes and removes
+type InfraFile struct {
+	Path      string
+	Kind      string // "Terraform" or "CloudFormation"
+	Resources []InfraResource
+}
+
+// InfraPlan is a plan summary CI posted on a PR, in a check or a comment
+type InfraPlan struct {
+	Source    string // The check, or who commented
+	URL       string
+	Add       int
+	Change    int
+	Destroy   int
+	Resources []InfraResource // The resources the plan lists, if it does
+}
+
+// InfraChanges is what a PR changes in infrastructure code, and what CI's
+// plans say applying it will do
+type InfraChanges struct {
+	Files []InfraFile
+	Plans []InfraPlan
+}
+
+var (
+	terraformBlock = regexp.MustCompile(`^(resource|data|module)\s+"([^"]+)"(?:\s+"([^"]+)")?\s*\{`)
+	cfnKey         = regexp.MustCompile(`^(\s*)"?([A-Za-z0-9]+)"?\s*:\s*\{?\s*$`)
+	cfnType        = regexp.MustCompile(`^\s*"?Type"?\s*:\s*["']?((?:AWS|Alexa|Custom)::[A-Za-z0-9:]+)`)
+	planCount      = regexp.MustCompile(`(\d+) to (add|change|destroy)\b`)
+	planNoChanges  = regexp.MustCompile(`No changes\. (Your )?[Ii]nfrastructure matches`)
+	planResource   = regexp.MustCompile(`#\s+(\S+)\s+(will be created|will be updated in-place|will be destroyed|must be replaced)`)
+)
+
+// planActions maps how a plan describes a resource's change to its action
+var planActions = map[string]string{
+	"will be created":          InfraCreate,
+	"will be updated in-place": InfraUpdate,
+	"will be destroyed":        InfraDestroy,
+	"must be replaced":         InfraReplace,
+}
+
+// GetInfraChanges reads the Terraform and CloudFormation resources a PR adds,
+// changes and removes from its diff, and the plan summaries CI posted on it
+// as checks or comments, for the AI agent. Plans come only with infrastructure
+// changes.
+func (c *Client) GetInfraChanges(ctx context.Context, owner, repo string, number int) (*InfraChanges, error) {
+	diff, err := c.getRawDiff(ctx, owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	changes := &InfraChanges{}
+	for _, file := range splitDiff(diff) {
+		if kind := infraKind(file); kind != "" {
+			changes.Files = append(changes.Files, InfraFile{Path: file.Path, Kind: kind, Resources: infraResources(kind, file.Hunks)})
+		}
+	}
+	if len(changes.Files) == 0 {
+		return changes, nil
+	}
+
+	pr := &PullRequest{Owner: owner, Repo: repo, Number: number, client: c}
+	status, err := pr.GetCheckStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if status != nil {
+		for _, detail := range status.Details {
+			if plan, ok := parsePlan(detail.Description); ok {
+				plan.Source, plan.URL = "check "+detail.Name, detail.URL
+				changes.Plans = append(changes.Plans, plan)
+			}
+		}
+	}
+
+	// Plan bots update or repost their comment, so only each one's latest counts
+	comments, err := pr.listComments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for i := len(comments) - 1; i >= 0; i-- {
+		login := comments[i].GetUser().GetLogin()
+		if seen[login] {
+			continue
+		}
+		if plan, ok := parsePlan(comments[i].GetBody()); ok {
+			seen[login] = true
+			plan.Source, plan.URL = "comment by "+login, comments[i].GetHTMLURL()
+			changes.Plans = append(changes.Plans, plan)
+		}
+	}
+	return changes, nil
+}
+
+// listComments returns all of this PR's comments, oldest first
+func (pr *PullRequest) listComments(ctx context.Context) ([]*github.IssueComment, error) {
+	var all []*github.IssueComment
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		comments, resp, err := pr.client.client.Issues.ListComments(ctx, pr.Owner, pr.Repo, pr.Number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list comments: %w", err)
+		}
+		all = append(all, comments...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// infraKind returns whether a file is Terraform or a CloudFormation
+// template, or "" if it's neither. Templates are told from other YAML and
+// JSON by the AWS:: resource types on their diff's lines.
+func infraKind(file fileDiff) string {
+	switch path.Ext(file.Path) {
+	case ".tf", ".tofu":
+		return "Terraform"
+	case ".yaml", ".yml", ".json", ".template":
+		for _, hunk := range file.Hunks {
+			for _, line := range strings.Split(hunk, "\n") {
+				if line != "" && cfnType.MatchString(line[1:]) {
+					return "CloudFormation"
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// infraResources reads which resources a file's hunks add, remove and change.
+// A resource whose first line is added or removed is created or destroyed;
+// one with other lines changed is updated. Only the diff's text is read, so
+// a change to a variable or a module's inputs can reach further than this.
+func infraResources(kind string, hunks []string) []InfraResource {
+	changes := &resourceChanges{actions: make(map[string]string)}
+	for _, hunk := range hunks {
+		atLine, body, _ := strings.Cut(hunk, "\n")
+		// git names the block a hunk starts in after its @@ line, which for
+		// Terraform is the resource
+		current := ""
+		if _, section, ok := strings.Cut(strings.TrimPrefix(atLine, "@@"), "@@"); ok && kind == "Terraform" {
+			current, _ = terraformAddress(strings.TrimSpace(section))
+		}
+
+		// CloudFormation resources are keyed by logical ID, with their type on
+		// a later line. keys holds the last IDs seen, at indent, and typed
+		// whether their type has been: a key followed by a deeper one was a
+		// section, like Resources, and one deeper than a typed resource is a
+		// property.
+		var keys []cfnResourceKey
+		indent, typed := -1, false
+		for _, line := range strings.Split(body, "\n") {
+			if line == "" || line[0] == '\\' {
+				continue
+			}
+			sign, text := line[0], line[1:]
+			action := signAction(sign)
+
+			switch kind {
+			case "Terraform":
+				if address, ok := terraformAddress(text); ok {
+					current = address
+					if address != "" && action != "" {
+						changes.mark(address, action)
+					}
+					continue
+				}
+			case "CloudFormation":
+				if m := cfnType.FindStringSubmatch(text); m != nil && len(keys) > 0 {
+					// A renamed resource has both IDs, each created or
+					// destroyed, and a changed type both types
+					for _, k := range keys {
+						current = fmt.Sprintf("%s (%s)", k.name, m[1])
+						if a := cmp.Or(signAction(k.sign), action); a != "" {
+							changes.mark(current, a)
+						}
+					}
+					typed = true
+					continue
+				}
+				if m := cfnKey.FindStringSubmatch(text); m != nil && (indent < 0 || len(m[1]) <= indent || !typed) {
+					if len(m[1]) != indent || typed {
+						keys, indent, typed = nil, len(m[1]), false
+					}
+					keys = slices.DeleteFunc(keys, func(k cfnResourceKey) bool { return k.sign == ' ' })
+					keys = append(keys, cfnResourceKey{name: m[2], sign: sign})
+					current = ""
+					continue
+				}
+			}
+			if action != "" && current != "" && strings.TrimSpace(text) != "" {
+				changes.mark(current, InfraUpdate)
+			}
+		}
+	}
+
+	resources := make([]InfraResource, len(changes.order))
+	for i, address := range changes.order {
+		resources[i] = InfraResource{Address: address, Action: changes.actions[address]}
+	}
+	return resources
+}
+
+// cfnResourceKey is a CloudFormation logical ID on a diff line
+type cfnResourceKey struct {
+	name string
+	sign byte
+}
+
+// signAction returns what a diff line's sign does to a resource it starts
+func signAction(sign byte) string {
+	switch sign {
+	case '+':
+		return InfraCreate
+	case '-':
+		return InfraDestroy
+	}
+	return ""
+}
+
+// terraformAddress returns the address of the resource, data source or
+// module a line starts, or "" for another top-level line, like a variable,
+// an output or a block's closing brace. ok is false for lines inside a block.
+func terraformAddress(line string) (address string, ok bool) {
+	if line == "" || line[0] == ' ' || line[0] == '\t' || line[0] == '#' || strings.HasPrefix(line, "//") {
+		return "", false
+	}
+	m := terraformBlock.FindStringSubmatch(line)
+	switch {
+	case m == nil:
+		return "", true
+	case m[1] == "module":
+		return "module." + m[2], true
+	case m[1] == "data":
+		return "data." + m[2] + "." + m[3], true
+	default:
+		return m[2] + "." + m[3], true
+	}
+}
+
+// resourceChanges collects resources' actions in the order they're found
+type resourceChanges struct {
+	order   []string
+	actions map[string]string
+}
+
+// mark records an action on a resource. Lines changed in a resource being
+// created or destroyed are part of that, and one both added and removed,
+// like a rewritten or moved block, is updated.
+func (r *resourceChanges) mark(address, action string) {
+	old, ok := r.actions[address]
+	switch {
+	case !ok:
+		r.order = append(r.order, address)
+		r.actions[address] = action
+	case old != action && old != InfraUpdate && action != InfraUpdate:
+		r.actions[address] = InfraUpdate
+	}
+}
+
+// parsePlan reads a Terraform plan summary, like "Plan: 1 to add, 0 to
+// change, 0 to destroy", and the resources the plan lists from text. It
+// returns false if text has no summary.
+func parsePlan(text string) (InfraPlan, bool) {
+	var plan InfraPlan
+	found := planNoChanges.MatchString(text)
+	for _, line := range strings.Split(text, "\n") {
+		counts := planCount.FindAllStringSubmatch(line, -1)
+		if len(counts) < 2 {
+			continue
+		}
+		found = true
+		for _, m := range counts {
+			n, _ := strconv.Atoi(m[1])
+			switch m[2] {
+			case "add":
+				plan.Add += n
+			case "change":
+				plan.Change += n
+			case "destroy":
+				plan.Destroy += n
+			}
+		}
+	}
+	if !found {
+		return InfraPlan{}, false
+	}
+	for _, m := range planResource.FindAllStringSubmatch(text, -1) {
+		plan.Resources = append(plan.Resources, InfraResource{Address: m[1], Action: planActions[m[2]]})
+	}
+	return plan, true
+}
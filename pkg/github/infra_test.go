@@ -0,0 +1,154 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInfraResources(t *testing.T) {
+	tests := []struct {
+		name  string
+		kind  string
+		hunks []string
+		want  []InfraResource
+	}{
+		{
+			name: "terraform",
+			kind: "Terraform",
+			hunks: []string{
+				"@@ -1,12 +1,10 @@ resource \"aws_instance\" \"web\" {\n" +
+					"   ami           = \"ami-123\"\n" +
+					"-  instance_type = \"t3.small\"\n" +
+					"+  instance_type = \"t3.large\"\n" +
+					" }\n" +
+					" \n" +
+					"-resource \"aws_db_instance\" \"main\" {\n" +
+					"-  engine = \"postgres\"\n" +
+					"-}\n" +
+					"+module \"vpc\" {\n" +
+					"+  source = \"./vpc\"\n" +
+					"+}\n" +
+					" \n" +
+					" variable \"region\" {\n" +
+					"-  default = \"us-east-1\"\n" +
+					"+  default = \"us-west-2\"\n" +
+					" }\n",
+				"@@ -30,3 +28,4 @@\n" +
+					" data \"aws_iam_policy_document\" \"read\" {\n" +
+					"+  statement {}\n" +
+					" }\n",
+			},
+			want: []InfraResource{
+				{Address: "aws_instance.web", Action: InfraUpdate},
+				{Address: "aws_db_instance.main", Action: InfraDestroy},
+				{Address: "module.vpc", Action: InfraCreate},
+				{Address: "data.aws_iam_policy_document.read", Action: InfraUpdate},
+			},
+		},
+		{
+			name: "rewritten block",
+			kind: "Terraform",
+			hunks: []string{"@@ -1 +1 @@\n" +
+				"-resource \"aws_s3_bucket\" \"logs\"{\n" +
+				"+resource \"aws_s3_bucket\" \"logs\" {\n"},
+			want: []InfraResource{{Address: "aws_s3_bucket.logs", Action: InfraUpdate}},
+		},
+		{
+			name: "cloudformation yaml",
+			kind: "CloudFormation",
+			hunks: []string{"@@ -1,14 +1,14 @@\n" +
+				" Resources:\n" +
+				"   Bucket:\n" +
+				"     Type: AWS::S3::Bucket\n" +
+				"     Properties:\n" +
+				"-      VersioningConfiguration: {Status: Enabled}\n" +
+				"+      VersioningConfiguration: {Status: Suspended}\n" +
+				"-  OldQueue:\n" +
+				"+  NewQueue:\n" +
+				"     Type: AWS::SQS::Queue\n" +
+				"+  Topic:\n" +
+				"+    Type: AWS::SNS::Topic\n" +
+				" Outputs:\n" +
+				"   BucketName:\n" +
+				"-    Value: !Ref Bucket\n" +
+				"+    Value: !GetAtt Bucket.Arn\n"},
+			want: []InfraResource{
+				{Address: "Bucket (AWS::S3::Bucket)", Action: InfraUpdate},
+				{Address: "OldQueue (AWS::SQS::Queue)", Action: InfraDestroy},
+				{Address: "NewQueue (AWS::SQS::Queue)", Action: InfraCreate},
+				{Address: "Topic (AWS::SNS::Topic)", Action: InfraCreate},
+			},
+		},
+		{
+			name: "cloudformation json",
+			kind: "CloudFormation",
+			hunks: []string{"@@ -1,8 +1,8 @@\n" +
+				" {\n" +
+				"   \"Resources\": {\n" +
+				"     \"Table\": {\n" +
+				"-      \"Type\": \"AWS::DynamoDB::Table\",\n" +
+				"+      \"Type\": \"AWS::DynamoDB::GlobalTable\",\n" +
+				"       \"Properties\": {}\n" +
+				"     }\n"},
+			want: []InfraResource{
+				{Address: "Table (AWS::DynamoDB::Table)", Action: InfraDestroy},
+				{Address: "Table (AWS::DynamoDB::GlobalTable)", Action: InfraCreate},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := infraResources(tt.kind, tt.hunks); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("infraResources() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInfraKind(t *testing.T) {
+	tests := []struct {
+		file fileDiff
+		want string
+	}{
+		{fileDiff{Path: "infra/main.tf"}, "Terraform"},
+		{fileDiff{Path: "stack.yaml", Hunks: []string{"@@ -1 +1 @@\n+    Type: AWS::S3::Bucket\n"}}, "CloudFormation"},
+		{fileDiff{Path: "config.yaml", Hunks: []string{"@@ -1 +1 @@\n+    Type: web\n"}}, ""},
+		{fileDiff{Path: "main.go"}, ""},
+	}
+	for _, tt := range tests {
+		if got := infraKind(tt.file); got != tt.want {
+			t.Errorf("infraKind(%s) = %q, want %q", tt.file.Path, got, tt.want)
+		}
+	}
+}
+
+func TestParsePlan(t *testing.T) {
+	comment := "### Plan for `prod`\n```\n" +
+		"  # aws_instance.web must be replaced\n" +
+		"-/+ resource \"aws_instance\" \"web\" {\n" +
+		"  # aws_s3_bucket.old will be destroyed\n" +
+		"  # aws_s3_bucket.new will be created\n" +
+		"Plan: 2 to add, 0 to change, 2 to destroy.\n```"
+	plan, ok := parsePlan(comment)
+	if !ok {
+		t.Fatal("parsePlan() found no plan")
+	}
+	want := InfraPlan{Add: 2, Destroy: 2, Resources: []InfraResource{
+		{Address: "aws_instance.web", Action: InfraReplace},
+		{Address: "aws_s3_bucket.old", Action: InfraDestroy},
+		{Address: "aws_s3_bucket.new", Action: InfraCreate},
+	}}
+	if !reflect.DeepEqual(plan, want) {
+		t.Errorf("parsePlan() = %+v, want %+v", plan, want)
+	}
+
+	if plan, ok := parsePlan("1 to change, 0 to add, 0 to destroy"); !ok || plan.Change != 1 {
+		t.Errorf("parsePlan() of a check summary = %+v, %v", plan, ok)
+	}
+	if _, ok := parsePlan("No changes. Your infrastructure matches the configuration."); !ok {
+		t.Error("parsePlan() missed a plan with no changes")
+	}
+	if _, ok := parsePlan("I'd like 1 to add tests for this"); ok {
+		t.Error("parsePlan() took a remark for a plan")
+	}
+}
@@ -0,0 +1,97 @@
+package github
+
+import (
+	"path"
+	"slices"
+	"strings"
+)
+
+// languageExtensions maps file extensions to the language they're written in
+var languageExtensions = map[string]string{
+	".go":     "Go",
+	".py":     "Python",
+	".pyi":    "Python",
+	".js":     "JavaScript",
+	".jsx":    "JavaScript",
+	".mjs":    "JavaScript",
+	".cjs":    "JavaScript",
+	".ts":     "TypeScript",
+	".tsx":    "TypeScript",
+	".mts":    "TypeScript",
+	".rb":     "Ruby",
+	".rake":   "Ruby",
+	".rs":     "Rust",
+	".java":   "Java",
+	".kt":     "Kotlin",
+	".kts":    "Kotlin",
+	".scala":  "Scala",
+	".swift":  "Swift",
+	".m":      "Objective-C",
+	".c":      "C",
+	".h":      "C",
+	".cc":     "C++",
+	".cpp":    "C++",
+	".hpp":    "C++",
+	".cs":     "C#",
+	".php":    "PHP",
+	".ex":     "Elixir",
+	".exs":    "Elixir",
+	".erl":    "Erlang",
+	".hs":     "Haskell",
+	".clj":    "Clojure",
+	".dart":   "Dart",
+	".lua":    "Lua",
+	".sh":     "Shell",
+	".bash":   "Shell",
+	".zsh":    "Shell",
+	".sql":    "SQL",
+	".tf":     "Terraform",
+	".hcl":    "HCL",
+	".proto":  "Protobuf",
+	".css":    "CSS",
+	".scss":   "CSS",
+	".html":   "HTML",
+	".vue":    "Vue",
+	".svelte": "Svelte",
+}
+
+// languageFiles maps file names that have no telling extension
+var languageFiles = map[string]string{
+	"Dockerfile":  "Dockerfile",
+	"Makefile":    "Makefile",
+	"Rakefile":    "Ruby",
+	"Gemfile":     "Ruby",
+	"Jenkinsfile": "Groovy",
+}
+
+// Languages lists the languages of the given paths, the one with the most
+// files first. Paths in no known language, such as docs and config, are left
+// out.
+func Languages(paths []string) []string {
+	counts := make(map[string]int)
+	for _, p := range paths {
+		base := path.Base(p)
+		language, ok := languageFiles[base]
+		if !ok && strings.HasPrefix(base, "Dockerfile.") {
+			language, ok = "Dockerfile", true
+		}
+		if !ok {
+			language, ok = languageExtensions[strings.ToLower(path.Ext(base))]
+		}
+		if ok {
+			counts[language]++
+		}
+	}
+
+	languages := make([]string, 0, len(counts))
+	for language := range counts {
+		languages = append(languages, language)
+	}
+	slices.SortFunc(languages, func(a, b string) int {
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+		return strings.Compare(a, b)
+	})
+	return languages
+}
@@ -0,0 +1,21 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLanguages(t *testing.T) {
+	paths := []string{
+		"cmd/main.go", "pkg/api/api.go", "pkg/api/api_test.go",
+		"web/src/App.tsx", "web/src/index.ts", "web/vite.config.JS",
+		"build/Dockerfile.ci", "README.md", "go.sum",
+	}
+	want := []string{"Go", "TypeScript", "Dockerfile", "JavaScript"}
+	if got := Languages(paths); !reflect.DeepEqual(got, want) {
+		t.Errorf("Languages() = %v, want %v", got, want)
+	}
+	if got := Languages([]string{"docs/guide.md"}); len(got) != 0 {
+		t.Errorf("Languages() of docs = %v, want none", got)
+	}
+}
@@ -0,0 +1,177 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DependencyChange is a package added, removed or updated by a lockfile change
+type DependencyChange struct {
+	Package string
+	From    string // Empty when the package was added
+	To      string // Empty when the package was removed
+}
+
+// lockfileParsers read the package versions on a lockfile diff's lines, keyed
+// by the lockfile's name
+var lockfileParsers = map[string]func(hunks []string) (removed, added map[string][]string){
+	"go.sum":            goSumVersions,
+	"package-lock.json": packageLockVersions,
+	"poetry.lock":       poetryLockVersions,
+}
+
+var (
+	packageLockKey     = regexp.MustCompile(`^\s*"([^"]+)": \{$`)
+	packageLockVersion = regexp.MustCompile(`^\s*"version": "([^"]+)"`)
+	poetryLockName     = regexp.MustCompile(`^name = "([^"]+)"`)
+	poetryLockVersion  = regexp.MustCompile(`^version = "([^"]+)"`)
+)
+
+// GetDependencyChanges lists the packages a PR adds, removes and updates in
+// the lockfiles it changes, for the AI agent
+func (c *Client) GetDependencyChanges(ctx context.Context, owner, repo string, number int) (string, error) {
+	diff, err := c.getRawDiff(ctx, owner, repo, number)
+	if err != nil {
+		return "", err
+	}
+
+	var result strings.Builder
+	for _, file := range splitDiff(diff) {
+		parse, ok := lockfileParsers[path.Base(file.Path)]
+		if !ok {
+			continue
+		}
+		changes := dependencyChanges(parse(file.Hunks))
+		result.WriteString(fmt.Sprintf("%s: %d packages changed\n", file.Path, len(changes)))
+		for _, change := range changes {
+			switch {
+			case change.From == "":
+				result.WriteString(fmt.Sprintf("  added %s %s\n", change.Package, change.To))
+			case change.To == "":
+				result.WriteString(fmt.Sprintf("  removed %s %s\n", change.Package, change.From))
+			default:
+				result.WriteString(fmt.Sprintf("  updated %s %s -> %s\n", change.Package, change.From, change.To))
+			}
+		}
+		result.WriteString("\n")
+	}
+	if result.Len() == 0 {
+		return "No go.sum, package-lock.json or poetry.lock changes in this PR.", nil
+	}
+	return result.String(), nil
+}
+
+// dependencyChanges pairs the versions removed and added for each package.
+// A package that had several versions lists them comma-separated.
+func dependencyChanges(removed, added map[string][]string) []DependencyChange {
+	var changes []DependencyChange
+	for name, from := range removed {
+		changes = append(changes, DependencyChange{Package: name, From: strings.Join(from, ", "), To: strings.Join(added[name], ", ")})
+	}
+	for name, to := range added {
+		if _, ok := removed[name]; !ok {
+			changes = append(changes, DependencyChange{Package: name, To: strings.Join(to, ", ")})
+		}
+	}
+	slices.SortFunc(changes, func(a, b DependencyChange) int { return strings.Compare(a.Package, b.Package) })
+	return changes
+}
+
+// addVersion records a package version once
+func addVersion(versions map[string][]string, name, version string) {
+	if !slices.Contains(versions[name], version) {
+		versions[name] = append(versions[name], version)
+	}
+}
+
+// goSumVersions reads the module versions on go.sum lines. Lines for a
+// version's go.mod alone only feed module graph resolution, so they're
+// skipped, and a version on both sides (its hash changed) is no change.
+func goSumVersions(hunks []string) (removed, added map[string][]string) {
+	removed, added = make(map[string][]string), make(map[string][]string)
+	for _, hunk := range hunks {
+		for _, line := range strings.Split(hunk, "\n") {
+			if line == "" || (line[0] != '+' && line[0] != '-') {
+				continue
+			}
+			fields := strings.Fields(line[1:])
+			if len(fields) != 3 || strings.HasSuffix(fields[1], "/go.mod") {
+				continue
+			}
+			if line[0] == '+' {
+				addVersion(added, fields[0], fields[1])
+			} else {
+				addVersion(removed, fields[0], fields[1])
+			}
+		}
+	}
+	for name, versions := range removed {
+		for _, version := range versions {
+			if slices.Contains(added[name], version) {
+				removed[name] = slices.DeleteFunc(removed[name], func(v string) bool { return v == version })
+				added[name] = slices.DeleteFunc(added[name], func(v string) bool { return v == version })
+			}
+		}
+	}
+	for _, versions := range []map[string][]string{removed, added} {
+		for name, list := range versions {
+			if len(list) == 0 {
+				delete(versions, name)
+			}
+		}
+	}
+	return removed, added
+}
+
+// packageLockVersions reads the versions in package-lock.json, where each
+// package's object opens with its key (node_modules/name in lockfile v2 and
+// later) and its version comes first
+func packageLockVersions(hunks []string) (removed, added map[string][]string) {
+	return keyedVersions(hunks, packageLockKey, packageLockVersion, func(key string) string {
+		if i := strings.LastIndex(key, "node_modules/"); i >= 0 {
+			return key[i+len("node_modules/"):]
+		}
+		return key
+	})
+}
+
+// poetryLockVersions reads the versions in poetry.lock, where each package's
+// table has its name followed by its version
+func poetryLockVersions(hunks []string) (removed, added map[string][]string) {
+	return keyedVersions(hunks, poetryLockName, poetryLockVersion, func(name string) string { return name })
+}
+
+// keyedVersions reads versions from lockfiles that put a package's version
+// right after its name, on a line of its own. Diff context keeps the name in
+// view when only the version changed.
+func keyedVersions(hunks []string, key, version *regexp.Regexp, packageName func(string) string) (removed, added map[string][]string) {
+	removed, added = make(map[string][]string), make(map[string][]string)
+	for _, hunk := range hunks {
+		name := ""
+		for _, line := range strings.Split(hunk, "\n") {
+			if line == "" || strings.HasPrefix(line, "@@") {
+				continue
+			}
+			op, text := line[0], line[1:]
+			if m := key.FindStringSubmatch(text); m != nil {
+				name = packageName(m[1])
+				continue
+			}
+			m := version.FindStringSubmatch(text)
+			if m == nil || name == "" {
+				continue
+			}
+			switch op {
+			case '+':
+				addVersion(added, name, m[1])
+			case '-':
+				addVersion(removed, name, m[1])
+			}
+		}
+	}
+	return removed, added
+}
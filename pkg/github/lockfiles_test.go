@@ -0,0 +1,86 @@
+package github
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGoSumVersions(t *testing.T) {
+	hunks := []string{`@@ -1,6 +1,6 @@
+ example.com/kept v1.0.0 h1:aaa=
+ example.com/kept v1.0.0/go.mod h1:bbb=
+-golang.org/x/net v0.22.0 h1:ccc=
++golang.org/x/net v0.23.0 h1:ddd=
++golang.org/x/net v0.23.0/go.mod h1:eee=
+-example.com/old v0.1.0 h1:fff=
++example.com/graph v2.0.0/go.mod h1:ggg=
++example.com/new v1.2.0 h1:hhh=
+`}
+	got := dependencyChanges(goSumVersions(hunks))
+	want := []DependencyChange{
+		{Package: "example.com/new", To: "v1.2.0"},
+		{Package: "example.com/old", From: "v0.1.0"},
+		{Package: "golang.org/x/net", From: "v0.22.0", To: "v0.23.0"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("go.sum changes = %+v, want %+v", got, want)
+	}
+}
+
+func TestPackageLockVersions(t *testing.T) {
+	hunks := []string{`@@ -10,7 +10,7 @@
+     "node_modules/lodash": {
+-      "version": "4.17.20",
+-      "resolved": "https://registry.npmjs.org/lodash/-/lodash-4.17.20.tgz",
++      "version": "4.17.21",
++      "resolved": "https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz",
+       "license": "MIT"
+     },
+@@ -40,4 +40,9 @@
+     },
++    "node_modules/@scope/pkg/node_modules/semver": {
++      "version": "7.6.0",
++      "license": "ISC"
++    },
+`}
+	got := dependencyChanges(packageLockVersions(hunks))
+	want := []DependencyChange{
+		{Package: "lodash", From: "4.17.20", To: "4.17.21"},
+		{Package: "semver", To: "7.6.0"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("package-lock.json changes = %+v, want %+v", got, want)
+	}
+}
+
+func TestPoetryLockVersions(t *testing.T) {
+	hunks := []string{`@@ -1,8 +1,8 @@
+ [[package]]
+ name = "requests"
+-version = "2.31.0"
++version = "2.32.3"
+ description = "Python HTTP for Humans."
+@@ -20,6 +20,0 @@
+-[[package]]
+-name = "chardet"
+-version = "5.2.0"
+`}
+	got := dependencyChanges(poetryLockVersions(hunks))
+	want := []DependencyChange{
+		{Package: "chardet", From: "5.2.0"},
+		{Package: "requests", From: "2.31.0", To: "2.32.3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("poetry.lock changes = %+v, want %+v", got, want)
+	}
+}
+
+func TestDependencyChangesSummary(t *testing.T) {
+	removed := map[string][]string{"a": {"1.0"}}
+	added := map[string][]string{"a": {"1.1", "2.0"}}
+	got := dependencyChanges(removed, added)
+	if len(got) != 1 || got[0].To != "1.1, 2.0" || !strings.Contains(got[0].From, "1.0") {
+		t.Errorf("dependencyChanges() = %+v", got)
+	}
+}
@@ -0,0 +1,154 @@
+package github
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/kennyp/speedrun/pkg/tracing"
+)
+
+// LogTailLines is how many lines are kept from the end of a failing job's log
+const LogTailLines = 50
+
+// CheckLog is the end of a failing check's log
+type CheckLog struct {
+	Name string
+	URL  string
+	Tail string
+}
+
+// logTimestamp matches the timestamp Actions puts in front of every log line
+var logTimestamp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T[0-9:.]+Z `)
+
+// GetJobLogTail returns the last lines of a GitHub Actions job's log, without
+// their timestamps
+func (c *Client) GetJobLogTail(ctx context.Context, owner, repo string, jobID int64, lines int) (string, error) {
+	ctx, span := tracing.Start(ctx, "github.GetJobLogTail", tracing.String("github.repo", owner+"/"+repo), tracing.Int64("github.job_id", jobID))
+	defer span.End()
+
+	start := time.Now()
+	var logURL string
+	operation := func() error {
+		u, _, err := c.client.Actions.GetWorkflowJobLogs(ctx, owner, repo, jobID, 3)
+		if err != nil {
+			return err
+		}
+		logURL = u.String()
+		return nil
+	}
+
+	exponentialBackoff := c.backoffConfig.ToExponentialBackoff()
+	if err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx)); err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to get job log URL: %w", err)
+	}
+
+	// The log itself is served from a signed URL outside the API, so it goes
+	// without the token but with the same timeout
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create job log request: %w", err)
+	}
+	logClient := &http.Client{Transport: tracing.NewTransport(c.transport), Timeout: c.timeout}
+	resp, err := logClient.Do(req)
+	if err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to download job log: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download job log: %s", resp.Status)
+	}
+
+	tail, err := tailLines(resp.Body, lines)
+	if err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to read job log: %w", err)
+	}
+
+	slog.Debug("GitHub API job log fetched", slog.String("repo", owner+"/"+repo), slog.Int64("job_id", jobID), slog.Duration("duration", time.Since(start)))
+	return tail, nil
+}
+
+// tailLines returns the last n lines of r, without Actions timestamps
+func tailLines(r io.Reader, n int) (string, error) {
+	ring := make([]string, 0, n)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := logTimestamp.ReplaceAllString(strings.TrimRight(scanner.Text(), "\r"), "")
+		if len(ring) == n {
+			ring = append(ring[1:], line)
+		} else {
+			ring = append(ring, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return strings.Join(ring, "\n"), nil
+}
+
+func jobLogCacheKey(owner, repo string, jobID int64) string {
+	return fmt.Sprintf("joblog:%s/%s:%d", owner, repo, jobID)
+}
+
+// GetFailedCheckLogs returns the end of the log of each failing GitHub Actions
+// check in status. Other checks have no log to fetch and are skipped. A
+// finished job's log doesn't change, so tails are cached by job.
+func (pr *PullRequest) GetFailedCheckLogs(ctx context.Context, status *CheckStatus) ([]CheckLog, error) {
+	if status == nil {
+		return nil, nil
+	}
+
+	var logs []CheckLog
+	for _, detail := range status.Details {
+		if detail.Status != "failure" || detail.JobID == 0 {
+			continue
+		}
+		cacheKey := jobLogCacheKey(pr.Owner, pr.Repo, detail.JobID)
+		var tail string
+		if err := pr.client.cacheGet(ctx, cacheKey, &tail); err != nil {
+			tail, err = pr.client.GetJobLogTail(ctx, pr.Owner, pr.Repo, detail.JobID, LogTailLines)
+			if err != nil {
+				return logs, fmt.Errorf("failed to get log for %s: %w", detail.Name, err)
+			}
+			if err := pr.client.cacheSet(ctx, cacheKey, tail); err != nil {
+				slog.Debug("Failed to cache job log", slog.Int64("job_id", detail.JobID), slog.Any("error", err))
+			}
+		}
+		logs = append(logs, CheckLog{Name: detail.Name, URL: detail.URL, Tail: tail})
+	}
+	return logs, nil
+}
+
+// GetCheckLogs describes the failing checks of a PR with the end of each
+// one's log, for the AI agent
+func (c *Client) GetCheckLogs(ctx context.Context, owner, repo string, number int) (string, error) {
+	pr := &PullRequest{Owner: owner, Repo: repo, Number: number, client: c}
+	status, err := pr.GetCheckStatus(ctx)
+	if err != nil {
+		return "", err
+	}
+	logs, err := pr.GetFailedCheckLogs(ctx, status)
+	if err != nil {
+		return "", err
+	}
+	if len(logs) == 0 {
+		return "No failing GitHub Actions checks on this PR.", nil
+	}
+
+	var result strings.Builder
+	for _, log := range logs {
+		result.WriteString(fmt.Sprintf("=== %s (last %d lines) ===\n%s\n\n", log.Name, LogTailLines, log.Tail))
+	}
+	return result.String(), nil
+}
@@ -0,0 +1,82 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	"github.com/kennyp/speedrun/pkg/cache"
+)
+
+func TestTailLines(t *testing.T) {
+	var log strings.Builder
+	for i := 1; i <= 60; i++ {
+		fmt.Fprintf(&log, "2025-01-02T03:04:05.1234567Z line %d\r\n", i)
+	}
+
+	got, err := tailLines(strings.NewReader(log.String()), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "line 58\nline 59\nline 60"; got != want {
+		t.Errorf("tailLines() = %q, want %q", got, want)
+	}
+
+	if got, _ := tailLines(strings.NewReader("only\n"), 3); got != "only" {
+		t.Errorf("short log = %q", got)
+	}
+}
+
+func TestGetFailedCheckLogs(t *testing.T) {
+	mux := http.NewServeMux()
+	var srv *httptest.Server
+	fetches := 0
+	mux.HandleFunc("/repos/acme/app/actions/jobs/42/logs", func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		http.Redirect(w, r, srv.URL+"/raw/42", http.StatusFound)
+	})
+	mux.HandleFunc("/raw/42", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "2025-01-02T03:04:05.1234567Z go test ./...\n2025-01-02T03:04:06.1234567Z FAIL\n")
+	})
+	srv = httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(srv.Client())
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	store, err := cache.New(filepath.Join(t.TempDir(), "cache.db"), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	pr := &PullRequest{Owner: "acme", Repo: "app", Number: 5, client: &Client{client: gh, cache: store, health: &health{}}}
+
+	status := &CheckStatus{Details: []CheckDetail{
+		{Name: "test", Status: "failure", JobID: 42, URL: "https://github.com/acme/app/actions/runs/1/job/42"},
+		{Name: "lint", Status: "success", JobID: 43},
+		{Name: "ci/circleci", Status: "failure"}, // Not an Actions job
+	}}
+
+	logs, err := pr.GetFailedCheckLogs(context.Background(), status)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 1 || logs[0].Name != "test" || logs[0].Tail != "go test ./...\nFAIL" {
+		t.Errorf("GetFailedCheckLogs() = %+v", logs)
+	}
+
+	// Reopening the logs reads them back from the cache
+	again, err := pr.GetFailedCheckLogs(context.Background(), status)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fetches != 1 || len(again) != 1 || again[0].Tail != logs[0].Tail {
+		t.Errorf("second GetFailedCheckLogs() = %+v after %d fetches, want the cached tail", again, fetches)
+	}
+}
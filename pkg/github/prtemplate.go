@@ -0,0 +1,61 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/kennyp/speedrun/pkg/prtemplate"
+	"github.com/kennyp/speedrun/pkg/tracing"
+)
+
+// prTemplatePaths are the locations GitHub reads the default PR template from
+var prTemplatePaths = []string{
+	".github/pull_request_template.md",
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"pull_request_template.md",
+	"PULL_REQUEST_TEMPLATE.md",
+	"docs/pull_request_template.md",
+	"docs/PULL_REQUEST_TEMPLATE.md",
+}
+
+// GetPRTemplate fetches and parses a repository's default PR template from
+// its default branch. Returns a zero Template if the repository has none.
+func (c *Client) GetPRTemplate(ctx context.Context, owner, repo string) (prtemplate.Template, error) {
+	ctx, span := tracing.Start(ctx, "github.GetPRTemplate", tracing.String("github.repo", owner+"/"+repo))
+	defer span.End()
+
+	for _, path := range prTemplatePaths {
+		content, err := c.getFileContent(ctx, owner, repo, path, "")
+		if errors.Is(err, errNoFile) {
+			continue
+		}
+		if err != nil {
+			span.RecordError(err)
+			return prtemplate.Template{}, fmt.Errorf("failed to get PR template: %w", err)
+		}
+
+		tmpl := prtemplate.Parse(content)
+		slog.Debug("Loaded PR template", slog.String("repo", owner+"/"+repo), slog.String("path", path),
+			slog.Int("sections", len(tmpl.Sections)), slog.Int("checklist", len(tmpl.Checklist)))
+		return tmpl, nil
+	}
+
+	return prtemplate.Template{}, nil
+}
+
+// GetTemplateCompliance checks the PR's description against its repository's
+// PR template. Returns nil if the repository has none.
+func (pr *PullRequest) GetTemplateCompliance(ctx context.Context) (*prtemplate.Compliance, error) {
+	if pr.client == nil {
+		return nil, fmt.Errorf("PR client is nil")
+	}
+
+	tmpl, err := pr.client.GetPRTemplate(ctx, pr.Owner, pr.Repo)
+	if err != nil || tmpl.IsZero() {
+		return nil, err
+	}
+	compliance := prtemplate.Check(tmpl, pr.GetBody())
+	return &compliance, nil
+}
@@ -0,0 +1,56 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	backoffconfig "github.com/kennyp/speedrun/pkg/backoff"
+)
+
+func TestGetTemplateCompliance(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/app/contents/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/app/contents/docs/pull_request_template.md" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"type":     "file",
+			"encoding": "base64",
+			"content":  base64.StdEncoding.EncodeToString([]byte("## Testing done\n\n- [ ] Tests added\n")),
+		})
+	})
+	mux.HandleFunc("/repos/acme/empty/contents/", http.NotFound)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(srv.Client())
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &Client{
+		client:        gh,
+		backoffConfig: backoffconfig.Config{MaxElapsedTime: time.Second, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 1},
+		health:        &health{},
+	}
+
+	pr := &PullRequest{Owner: "acme", Repo: "app", Number: 1, client: c, ghi: &github.Issue{Body: github.Ptr("## Testing done\nRan it locally.\n")}}
+	compliance, err := pr.GetTemplateCompliance(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if compliance == nil || len(compliance.Empty) != 0 || !slices.Equal(compliance.Unticked, []string{"Tests added"}) {
+		t.Errorf("GetTemplateCompliance() = %+v, want only the checklist item missing", compliance)
+	}
+
+	pr = &PullRequest{Owner: "acme", Repo: "empty", Number: 1, client: c}
+	if compliance, err := pr.GetTemplateCompliance(context.Background()); err != nil || compliance != nil {
+		t.Errorf("GetTemplateCompliance() without a template = %+v, %v", compliance, err)
+	}
+}
@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/url"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/google/go-github/v73/github"
+	"github.com/kennyp/speedrun/pkg/cache"
+	"github.com/kennyp/speedrun/pkg/tracing"
 )
 
 // PullRequest represents a GitHub pull request
@@ -20,8 +23,11 @@ type PullRequest struct {
 	Owner     string
 	Repo      string
 	URL       *url.URL
+	CreatedAt time.Time
 	UpdatedAt time.Time
 	HeadSHA   string
+	BaseRef   string // Branch the PR merges into
+	HeadRef   string // Branch the PR merges from, "" if it's in a fork
 
 	client *Client
 	ghi    *github.Issue
@@ -55,14 +61,6 @@ func (pr *PullRequest) GetBody() string {
 	return ""
 }
 
-// GetRequestedReviewers returns the requested reviewers for the PR
-// Note: This requires a separate API call as it's not included in the Issue object
-func (pr *PullRequest) GetRequestedReviewers(ctx context.Context) ([]string, error) {
-	// For now, return empty slice - can be implemented later with full PR fetch
-	// The AI can use the github_api tool to get this information if needed
-	return []string{}, nil
-}
-
 // LogValue implements slog.LogValuer for structured logging
 func (pr *PullRequest) LogValue() slog.Value {
 	return slog.GroupValue(
@@ -88,7 +86,11 @@ func (pr *PullRequest) reviewsCacheKey() string {
 }
 
 func (pr *PullRequest) aiAnalysisCacheKey() string {
-	return fmt.Sprintf("ai:%s/%s#%d:%s", pr.Owner, pr.Repo, pr.Number, pr.HeadSHA)
+	key := fmt.Sprintf("ai:%s/%s#%d:%s", pr.Owner, pr.Repo, pr.Number, pr.HeadSHA)
+	if pr.client.aiVersion != "" {
+		key += ":" + pr.client.aiVersion
+	}
+	return key
 }
 
 // invalidateCache removes all cached data for this PR
@@ -132,10 +134,25 @@ type AIAnalysis interface {
 	GetDocType() string
 }
 
-// GetCachedAIAnalysis retrieves cached AI analysis for this PR
+// SetAIAnalysisVersion keys cached AI analyses by version, e.g. a hash of
+// the prompts, so analyses made before the version changed are no longer
+// found and the PRs get analyzed again
+func (c *Client) SetAIAnalysisVersion(version string) {
+	c.aiVersion = version
+}
+
+// GetCachedAIAnalysis retrieves cached AI analysis for this PR, from the
+// shared cache if it's not cached locally
 func (pr *PullRequest) GetCachedAIAnalysis(dest AIAnalysis) error {
 	cacheKey := pr.aiAnalysisCacheKey()
 	if err := pr.client.cache.Get(cacheKey, dest); err != nil {
+		sharedErr := pr.getSharedAIAnalysis(dest)
+		if sharedErr == nil {
+			return nil
+		}
+		if !errors.Is(sharedErr, cache.ErrCacheMiss) {
+			slog.Debug("Shared AI analysis unavailable", slog.Any("pr", pr), slog.Any("error", sharedErr))
+		}
 		return err
 	}
 
@@ -143,7 +160,8 @@ func (pr *PullRequest) GetCachedAIAnalysis(dest AIAnalysis) error {
 	return nil
 }
 
-// SetCachedAIAnalysis stores AI analysis in cache for this PR
+// SetCachedAIAnalysis stores AI analysis in cache for this PR, and shares it
+// through the shared cache if there is one
 func (pr *PullRequest) SetCachedAIAnalysis(analysis any) error {
 
 	// Only cache valid AI analysis (not nil)
@@ -152,15 +170,70 @@ func (pr *PullRequest) SetCachedAIAnalysis(analysis any) error {
 		return fmt.Errorf("cannot cache nil AI analysis")
 	}
 
+	pr.shareAIAnalysis(analysis)
 	cacheKey := pr.aiAnalysisCacheKey()
 	return pr.client.cache.Set(cacheKey, analysis)
 }
 
+// CachedData is what's cached about a PR, however old. Parts that aren't
+// cached are nil.
+type CachedData struct {
+	DiffStats   *DiffStats
+	CheckStatus *CheckStatus
+	Reviews     []*Review
+	CachedAt    time.Time // When the oldest part was cached
+}
+
+// GetStaleData reads whatever is cached about the PR, including entries past
+// the cache's max age, without calling GitHub
+func (pr *PullRequest) GetStaleData() CachedData {
+	var data CachedData
+	stale, ok := pr.client.cache.(cache.StaleReader)
+	if !ok {
+		return data
+	}
+
+	read := func(key string, dest any) bool {
+		at, err := stale.GetStale(key, dest)
+		if err != nil {
+			if !errors.Is(err, cache.ErrCacheMiss) {
+				slog.Debug("Failed to read stale cache entry", slog.String("key", key), slog.Any("error", err))
+			}
+			return false
+		}
+		if data.CachedAt.IsZero() || at.Before(data.CachedAt) {
+			data.CachedAt = at
+		}
+		return true
+	}
+	if !read(pr.diffStatsCacheKey(), &data.DiffStats) {
+		data.DiffStats = nil
+	}
+	if !read(pr.checkStatusCacheKey(), &data.CheckStatus) {
+		data.CheckStatus = nil
+	}
+	if !read(pr.reviewsCacheKey(), &data.Reviews) {
+		data.Reviews = nil
+	}
+	return data
+}
+
+// GetStaleAIAnalysis reads the AI analysis of the PR's head commit, even if
+// it's past the cache's max age, and returns when it was cached
+func (pr *PullRequest) GetStaleAIAnalysis(dest AIAnalysis) (time.Time, error) {
+	stale, ok := pr.client.cache.(cache.StaleReader)
+	if !ok || pr.HeadSHA == "" {
+		return time.Time{}, cache.ErrCacheMiss
+	}
+	return stale.GetStale(pr.aiAnalysisCacheKey(), dest)
+}
+
 // newPullRequestFromIssue creates a PullRequest from a GitHub Issue
 func newPullRequestFromIssue(ctx context.Context, client *Client, issue *github.Issue) (*PullRequest, error) {
 	pr := &PullRequest{
 		Number:    issue.GetNumber(),
 		Title:     issue.GetTitle(),
+		CreatedAt: issue.GetCreatedAt().Time,
 		UpdatedAt: issue.GetUpdatedAt().Time,
 		client:    client,
 		ghi:       issue,
@@ -173,14 +246,10 @@ func newPullRequestFromIssue(ctx context.Context, client *Client, issue *github.
 	}
 	pr.URL = issueURL
 
-	// Extract owner and repo from URL path
-	// URL format: https://api.github.com/repos/OWNER/REPO/issues/NUMBER
-	parts := strings.Split(issueURL.Path, "/")
-	if len(parts) < 5 {
-		return nil, fmt.Errorf("unexpected URL format: %s", issueURL.Path)
+	pr.Owner, pr.Repo, err = parseIssueURL(issue.GetURL())
+	if err != nil {
+		return nil, err
 	}
-	pr.Owner = parts[2]
-	pr.Repo = parts[3]
 
 	// Fetch HeadSHA immediately to enable proper AI analysis caching
 	// This ensures AI cache keys are available from the start
@@ -203,19 +272,55 @@ func newPullRequestFromIssue(ctx context.Context, client *Client, issue *github.
 		return pr, nil
 	}
 
-	pr.HeadSHA = prDetails.GetHead().GetSHA()
+	pr.setHead(prDetails)
 	duration := time.Since(start)
 	slog.Debug("Successfully fetched HeadSHA during PR creation", slog.Any("pr", pr), slog.String("head_sha", pr.HeadSHA), slog.Duration("duration", duration))
 
 	return pr, nil
 }
 
+// CallContext bounds a call about this PR like Client.CallContext
+func (pr *PullRequest) CallContext(ctx context.Context, budget time.Duration) (context.Context, context.CancelFunc) {
+	if pr.client == nil {
+		return context.WithTimeout(ctx, budget)
+	}
+	return pr.client.CallContext(ctx, budget)
+}
+
+// setHead records the head commit and the branches the PR merges between
+func (pr *PullRequest) setHead(details *github.PullRequest) {
+	pr.HeadSHA = details.GetHead().GetSHA()
+	pr.BaseRef = details.GetBase().GetRef()
+	// A fork's branch can't be the base of another PR in this repo
+	if strings.EqualFold(details.GetHead().GetRepo().GetFullName(), pr.Owner+"/"+pr.Repo) {
+		pr.HeadRef = details.GetHead().GetRef()
+	}
+}
+
+// parseIssueURL extracts the owner and repo from an issue API URL of the
+// form https://api.github.com/repos/OWNER/REPO/issues/NUMBER
+func parseIssueURL(rawURL string) (owner, repo string, err error) {
+	issueURL, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse issue URL: %w", err)
+	}
+
+	parts := strings.Split(issueURL.Path, "/")
+	if len(parts) < 5 {
+		return "", "", fmt.Errorf("unexpected URL format: %s", issueURL.Path)
+	}
+	return parts[2], parts[3], nil
+}
+
 // GetReviews returns the reviews for this PR
 func (pr *PullRequest) GetReviews(ctx context.Context) ([]*Review, error) {
 	if pr.client == nil {
 		return nil, fmt.Errorf("PR client is nil")
 	}
 
+	ctx, span := tracing.Start(ctx, "github.GetReviews", pr.spanAttributes()...)
+	defer span.End()
+
 	slog.Debug("Getting PR reviews", slog.Any("pr", pr))
 	start := time.Now()
 
@@ -223,7 +328,7 @@ func (pr *PullRequest) GetReviews(ctx context.Context) ([]*Review, error) {
 
 	// Try to get from cache first
 	var cachedReviews []*Review
-	if err := pr.client.cache.Get(cacheKey, &cachedReviews); err == nil {
+	if err := pr.client.cacheGet(ctx, cacheKey, &cachedReviews); err == nil {
 		// Validate cached data - if it's nil, delete the bad cache entry and fetch fresh
 		if cachedReviews != nil {
 			duration := time.Since(start)
@@ -232,7 +337,7 @@ func (pr *PullRequest) GetReviews(ctx context.Context) ([]*Review, error) {
 		} else {
 			// Bad cached data (nil) - delete it and fetch fresh
 			slog.Debug("Deleting invalid cached reviews (nil)", slog.Any("pr", pr))
-			if err := pr.client.cache.Delete(cacheKey); err != nil {
+			if err := pr.client.cacheDelete(ctx, cacheKey); err != nil {
 				slog.Debug("Failed to delete invalid reviews cache", slog.Any("error", err))
 			}
 			// Fall through to fresh API call
@@ -251,6 +356,7 @@ func (pr *PullRequest) GetReviews(ctx context.Context) ([]*Review, error) {
 	duration := time.Since(start)
 
 	if err != nil {
+		span.RecordError(err)
 		slog.Error("GitHub API get reviews failed", slog.Any("pr", pr), slog.Duration("duration", duration), slog.Any("error", err))
 		return nil, fmt.Errorf("failed to get reviews: %w", err)
 	}
@@ -258,9 +364,10 @@ func (pr *PullRequest) GetReviews(ctx context.Context) ([]*Review, error) {
 	result := make([]*Review, 0)
 	for _, review := range reviews {
 		result = append(result, &Review{
-			State: review.GetState(),
-			User:  review.GetUser().GetLogin(),
-			Body:  review.GetBody(),
+			State:    review.GetState(),
+			User:     review.GetUser().GetLogin(),
+			Body:     review.GetBody(),
+			CommitID: review.GetCommitID(),
 		})
 	}
 
@@ -268,7 +375,7 @@ func (pr *PullRequest) GetReviews(ctx context.Context) ([]*Review, error) {
 
 	// Cache the results - only cache valid reviews (not nil)
 	if result != nil {
-		if err := pr.client.cache.Set(cacheKey, result); err != nil {
+		if err := pr.client.cacheSet(ctx, cacheKey, result); err != nil {
 			slog.Debug("Failed to cache reviews", slog.Any("error", err))
 		}
 	}
@@ -291,12 +398,27 @@ func (pr *PullRequest) HasUserReviewed(ctx context.Context, username string) (bo
 	return false, nil
 }
 
+// ApprovedCommit returns the head commit of username's latest approval, or ""
+// if they haven't approved or GitHub didn't say which commit they approved
+func ApprovedCommit(reviews []*Review, username string) string {
+	commit := ""
+	for _, review := range reviews {
+		if review.User == username && review.State == "APPROVED" {
+			commit = review.CommitID
+		}
+	}
+	return commit
+}
+
 // GetCheckStatus returns the combined check status for this PR
 func (pr *PullRequest) GetCheckStatus(ctx context.Context) (*CheckStatus, error) {
 	if pr.client == nil {
 		return nil, fmt.Errorf("PR client is nil")
 	}
 
+	ctx, span := tracing.Start(ctx, "github.GetCheckStatus", pr.spanAttributes()...)
+	defer span.End()
+
 	slog.Debug("Getting PR check status", slog.Any("pr", pr))
 	start := time.Now()
 
@@ -304,7 +426,7 @@ func (pr *PullRequest) GetCheckStatus(ctx context.Context) (*CheckStatus, error)
 
 	// Try to get from cache first
 	var cachedStatus *CheckStatus
-	if err := pr.client.cache.Get(cacheKey, &cachedStatus); err == nil {
+	if err := pr.client.cacheGet(ctx, cacheKey, &cachedStatus); err == nil {
 		// Validate cached data - if it's nil or has invalid state, delete and fetch fresh
 		if cachedStatus != nil && cachedStatus.State != "" && cachedStatus.Description != "" {
 			duration := time.Since(start)
@@ -322,7 +444,7 @@ func (pr *PullRequest) GetCheckStatus(ctx context.Context) (*CheckStatus, error)
 
 				exponentialBackoff := pr.client.backoffConfig.ToExponentialBackoff()
 				if err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx)); err == nil {
-					pr.HeadSHA = prDetails.GetHead().GetSHA()
+					pr.setHead(prDetails)
 					slog.Debug("Retrieved PR details for HeadSHA", slog.Any("pr", pr), slog.String("head_sha", pr.HeadSHA))
 				} else {
 					slog.Debug("Failed to get PR details for HeadSHA", slog.Any("pr", pr), slog.Any("error", err))
@@ -333,7 +455,7 @@ func (pr *PullRequest) GetCheckStatus(ctx context.Context) (*CheckStatus, error)
 		} else {
 			// Bad cached data (nil or invalid state/description) - delete it and fetch fresh
 			slog.Debug("Deleting invalid cached check status", slog.Any("pr", pr), slog.Any("status", cachedStatus))
-			if err := pr.client.cache.Delete(cacheKey); err != nil {
+			if err := pr.client.cacheDelete(ctx, cacheKey); err != nil {
 				slog.Debug("Failed to delete invalid check status cache", slog.Any("error", err))
 			}
 			// Fall through to fresh API call
@@ -352,21 +474,24 @@ func (pr *PullRequest) GetCheckStatus(ctx context.Context) (*CheckStatus, error)
 	err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx))
 	if err != nil {
 		duration := time.Since(start)
+		span.RecordError(err)
 		slog.Error("GitHub API get PR details failed", slog.Any("pr", pr), slog.Duration("duration", duration), slog.Any("error", err))
 		return nil, fmt.Errorf("failed to get PR details: %w", err)
 	}
 
-	pr.HeadSHA = prDetails.GetHead().GetSHA()
+	pr.setHead(prDetails)
 	slog.Debug("Retrieved PR details", slog.Any("pr", pr), slog.String("head_sha", pr.HeadSHA))
 
 	// Get both check runs (modern) and statuses (legacy)
 	var checkRuns *github.ListCheckRunsResults
 	var statuses *github.CombinedStatus
 
-	// Get check runs with retry
+	// Get check runs with retry, including earlier attempts so reruns show
+	// up in the flaky check history
 	checkOperation := func() error {
 		var checkErr error
-		checkRuns, _, checkErr = pr.client.client.Checks.ListCheckRunsForRef(ctx, pr.Owner, pr.Repo, pr.HeadSHA, nil)
+		opts := &github.ListCheckRunsOptions{Filter: github.Ptr("all"), ListOptions: github.ListOptions{PerPage: 100}}
+		checkRuns, _, checkErr = pr.client.client.Checks.ListCheckRunsForRef(ctx, pr.Owner, pr.Repo, pr.HeadSHA, opts)
 		return checkErr
 	}
 	if err := backoff.Retry(checkOperation, backoff.WithContext(pr.client.backoffConfig.ToExponentialBackoff(), ctx)); err != nil {
@@ -389,13 +514,18 @@ func (pr *PullRequest) GetCheckStatus(ctx context.Context) (*CheckStatus, error)
 
 	// Process check runs
 	if checkRuns != nil {
-		for _, run := range checkRuns.CheckRuns {
+		pr.client.recordCheckRuns(pr.Owner, pr.Repo, pr.HeadSHA, checkRuns.CheckRuns)
+		for _, run := range latestCheckRuns(checkRuns.CheckRuns) {
 			detail := CheckDetail{
 				Name:        run.GetName(),
 				Status:      convertCheckRunStatus(run.GetStatus(), run.GetConclusion()),
 				Description: run.GetOutput().GetSummary(),
 				URL:         run.GetHTMLURL(),
 			}
+			// An Actions check run shares its ID with the job, whose log can be fetched
+			if run.GetApp().GetSlug() == "github-actions" {
+				detail.JobID = run.GetID()
+			}
 			status.Details = append(status.Details, detail)
 		}
 	}
@@ -416,14 +546,17 @@ func (pr *PullRequest) GetCheckStatus(ctx context.Context) (*CheckStatus, error)
 	// Apply check filtering based on configuration
 	var filteredDetails []CheckDetail
 	if status.Details != nil {
-		filteredDetails = pr.client.filterChecks(status.Details)
+		required := pr.client.requiredChecks(ctx, pr.Owner, pr.Repo, pr.BaseRef)
+		filteredDetails = pr.client.filterChecks(status.Details, required)
 	}
 
-	// Determine overall status
+	// Determine overall status, optionally leaving out failures of flaky checks
+	pr.client.markFlakyChecks(pr.Owner, pr.Repo, filteredDetails)
+	counted := pr.client.countedChecks(filteredDetails)
 	//nolint:staticcheck // status is never nil, initialized above
-	status.State = aggregateCheckStates(filteredDetails)
+	status.State = aggregateCheckStates(counted)
 	//nolint:staticcheck // status is never nil, initialized above
-	status.Description = formatCheckDescription(filteredDetails)
+	status.Description = formatCheckDescription(counted)
 	//nolint:staticcheck // status is never nil, initialized above
 	status.Details = filteredDetails
 
@@ -432,7 +565,7 @@ func (pr *PullRequest) GetCheckStatus(ctx context.Context) (*CheckStatus, error)
 	// Cache the results - only cache valid status (not nil and has state/description)
 	//nolint:staticcheck // status is never nil, initialized above
 	if status != nil && status.State != "" && status.Description != "" {
-		if err := pr.client.cache.Set(cacheKey, status); err != nil {
+		if err := pr.client.cacheSet(ctx, cacheKey, status); err != nil {
 			slog.Debug("Failed to cache check status", slog.Any("error", err))
 		}
 	}
@@ -440,12 +573,27 @@ func (pr *PullRequest) GetCheckStatus(ctx context.Context) (*CheckStatus, error)
 	return status, nil
 }
 
+// RefreshCheckStatus returns the check status straight from GitHub, for
+// watching checks that are still running
+func (pr *PullRequest) RefreshCheckStatus(ctx context.Context) (*CheckStatus, error) {
+	if pr.client == nil {
+		return nil, fmt.Errorf("PR client is nil")
+	}
+	if err := pr.client.cacheDelete(ctx, pr.checkStatusCacheKey()); err != nil {
+		slog.Debug("Failed to delete check status cache", slog.Any("error", err))
+	}
+	return pr.GetCheckStatus(ctx)
+}
+
 // GetDiffStats returns the diff statistics for this PR
 func (pr *PullRequest) GetDiffStats(ctx context.Context) (*DiffStats, error) {
 	if pr.client == nil {
 		return nil, fmt.Errorf("PR client is nil")
 	}
 
+	ctx, span := tracing.Start(ctx, "github.GetDiffStats", pr.spanAttributes()...)
+	defer span.End()
+
 	slog.Debug("Getting PR diff stats", slog.Any("pr", pr))
 	start := time.Now()
 
@@ -453,7 +601,7 @@ func (pr *PullRequest) GetDiffStats(ctx context.Context) (*DiffStats, error) {
 
 	// Try to get from cache first
 	var cachedStats *DiffStats
-	if err := pr.client.cache.Get(cacheKey, &cachedStats); err == nil {
+	if err := pr.client.cacheGet(ctx, cacheKey, &cachedStats); err == nil {
 		// Validate cached data - if it's nil or has invalid values, delete and fetch fresh
 		if cachedStats != nil && cachedStats.Additions >= 0 && cachedStats.Deletions >= 0 && cachedStats.Files >= 0 {
 			duration := time.Since(start)
@@ -462,7 +610,7 @@ func (pr *PullRequest) GetDiffStats(ctx context.Context) (*DiffStats, error) {
 		} else {
 			// Bad cached data (nil or invalid values) - delete it and fetch fresh
 			slog.Debug("Deleting invalid cached diff stats", slog.Any("pr", pr), slog.Any("stats", cachedStats))
-			if err := pr.client.cache.Delete(cacheKey); err != nil {
+			if err := pr.client.cacheDelete(ctx, cacheKey); err != nil {
 				slog.Debug("Failed to delete invalid diff stats cache", slog.Any("error", err))
 			}
 			// Fall through to fresh API call
@@ -481,6 +629,7 @@ func (pr *PullRequest) GetDiffStats(ctx context.Context) (*DiffStats, error) {
 	duration := time.Since(start)
 
 	if err != nil {
+		span.RecordError(err)
 		slog.Error("GitHub API get diff stats failed", slog.Any("pr", pr), slog.Duration("duration", duration), slog.Any("error", err))
 		return nil, fmt.Errorf("failed to get PR details: %w", err)
 	}
@@ -492,11 +641,18 @@ func (pr *PullRequest) GetDiffStats(ctx context.Context) (*DiffStats, error) {
 		Files:     prDetails.GetChangedFiles(),
 	}
 
+	// Without the file list, everything counts as source
+	if files, err := pr.listChangedFiles(ctx); err != nil {
+		slog.Debug("Failed to classify changed files", slog.Any("pr", pr), slog.Any("error", err))
+	} else if stats.Excluded, err = pr.excludedFiles(ctx, files); err != nil {
+		slog.Debug("Failed to classify changed files", slog.Any("pr", pr), slog.Any("error", err))
+	}
+
 	slog.Debug("GitHub API get diff stats completed", slog.Any("pr", pr), slog.Any("stats", stats), slog.Duration("duration", time.Since(start)))
 
 	// Cache the results - only cache valid stats (not nil and has non-negative values)
 	if stats != nil && stats.Additions >= 0 && stats.Deletions >= 0 && stats.Files >= 0 {
-		if err := pr.client.cache.Set(cacheKey, stats); err != nil {
+		if err := pr.client.cacheSet(ctx, cacheKey, stats); err != nil {
 			slog.Debug("Failed to cache diff stats", slog.Any("error", err))
 		}
 	}
@@ -506,18 +662,37 @@ func (pr *PullRequest) GetDiffStats(ctx context.Context) (*DiffStats, error) {
 
 // Approve approves this PR
 func (pr *PullRequest) Approve(ctx context.Context) error {
+	return pr.ApproveWithBody(ctx, "LGTM")
+}
+
+// ApproveWithBody approves this PR with the given review body
+func (pr *PullRequest) ApproveWithBody(ctx context.Context, body string) error {
+	if pr.client.Health().Degraded {
+		return ErrStaleMode
+	}
+	if err := pr.client.checkRepoAccess(pr.Owner, pr.Repo); err != nil {
+		return err
+	}
+	if pr.client.skipWrite("approve", slog.Any("pr", pr), slog.String("body", body)) {
+		return nil
+	}
+
+	ctx, span := tracing.Start(ctx, "github.Approve", pr.spanAttributes()...)
+	defer span.End()
+
 	slog.Debug("Approving PR", slog.Any("pr", pr))
 	start := time.Now()
 
 	review := &github.PullRequestReviewRequest{
 		Event: github.Ptr("APPROVE"),
-		Body:  github.Ptr("LGTM"),
+		Body:  github.Ptr(body),
 	}
 
 	_, _, err := pr.client.client.PullRequests.CreateReview(ctx, pr.Owner, pr.Repo, pr.Number, review)
 	duration := time.Since(start)
 
 	if err != nil {
+		span.RecordError(err)
 		slog.Error("GitHub API approve PR failed", slog.Any("pr", pr), slog.Duration("duration", duration), slog.Any("error", err))
 		return fmt.Errorf("failed to approve PR: %w", err)
 	}
@@ -620,3 +795,24 @@ func (pr *PullRequest) Merge(ctx context.Context, mergeMethod string) error {
 
 	return pr.client.Merge(ctx, pr.Owner, pr.Repo, pr.Number, mergeMethod)
 }
+
+// GetState fetches whether this pull request is still open, closed or merged
+func (pr *PullRequest) GetState(ctx context.Context) (PRState, error) {
+	ctx, span := tracing.Start(ctx, "github.GetState", pr.spanAttributes()...)
+	defer span.End()
+
+	details, _, err := pr.client.client.PullRequests.Get(ctx, pr.Owner, pr.Repo, pr.Number)
+	if err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to get PR state: %w", err)
+	}
+
+	switch {
+	case details.GetMerged():
+		return PRStateMerged, nil
+	case details.GetState() == "closed":
+		return PRStateClosed, nil
+	default:
+		return PRStateOpen, nil
+	}
+}
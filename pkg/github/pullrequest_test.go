@@ -0,0 +1,95 @@
+package github
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/cache"
+)
+
+func TestApprovedCommit(t *testing.T) {
+	reviews := []*Review{
+		{User: "me", State: "APPROVED", CommitID: "aaa"},
+		{User: "other", State: "APPROVED", CommitID: "ccc"},
+		{User: "me", State: "COMMENTED", CommitID: "bbb"},
+	}
+	if got := ApprovedCommit(reviews, "me"); got != "aaa" {
+		t.Errorf("ApprovedCommit() = %q, want aaa", got)
+	}
+
+	reviews = append(reviews, &Review{User: "me", State: "APPROVED", CommitID: "ddd"})
+	if got := ApprovedCommit(reviews, "me"); got != "ddd" {
+		t.Errorf("ApprovedCommit() after re-approving = %q, want ddd", got)
+	}
+	if got := ApprovedCommit(reviews, "nobody"); got != "" {
+		t.Errorf("ApprovedCommit() without approval = %q, want empty", got)
+	}
+}
+
+func TestCallContext(t *testing.T) {
+	deadlineIn := func(ctx context.Context) time.Duration {
+		deadline, _ := ctx.Deadline()
+		return time.Until(deadline).Round(time.Second)
+	}
+
+	pr := &PullRequest{client: &Client{timeout: time.Minute}}
+	ctx, cancel := pr.CallContext(context.Background(), 10*time.Second)
+	defer cancel()
+	if got := deadlineIn(ctx); got != time.Minute {
+		t.Errorf("deadline in %v, want the longer request timeout", got)
+	}
+
+	pr.client.timeout = time.Second
+	ctx, cancel = pr.CallContext(context.Background(), 10*time.Second)
+	defer cancel()
+	if got := deadlineIn(ctx); got != 10*time.Second {
+		t.Errorf("deadline in %v, want the call budget", got)
+	}
+}
+
+func TestGetStaleData(t *testing.T) {
+	// Entries are written already expired
+	store, err := cache.New(filepath.Join(t.TempDir(), "cache.db"), -time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	pr := &PullRequest{Number: 7, Owner: "acme", Repo: "api", client: &Client{cache: store}}
+	if data := pr.GetStaleData(); data.DiffStats != nil || data.CheckStatus != nil || data.Reviews != nil {
+		t.Fatalf("GetStaleData() with nothing cached = %+v", data)
+	}
+
+	if err := store.Set(pr.diffStatsCacheKey(), &DiffStats{Additions: 3, Deletions: 1, Files: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set(pr.reviewsCacheKey(), []*Review{{User: "me", State: "APPROVED"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	var diff *DiffStats
+	if err := store.Get(pr.diffStatsCacheKey(), &diff); err == nil {
+		t.Fatal("diff stats haven't expired")
+	}
+
+	data := pr.GetStaleData()
+	if data.DiffStats == nil || data.DiffStats.Additions != 3 {
+		t.Errorf("DiffStats = %+v, want the expired entry", data.DiffStats)
+	}
+	if len(data.Reviews) != 1 || data.Reviews[0].User != "me" {
+		t.Errorf("Reviews = %+v, want the expired entry", data.Reviews)
+	}
+	if data.CheckStatus != nil {
+		t.Errorf("CheckStatus = %+v, want nil", data.CheckStatus)
+	}
+	if data.CachedAt.IsZero() || time.Since(data.CachedAt) > time.Minute {
+		t.Errorf("CachedAt = %v, want when the entries were cached", data.CachedAt)
+	}
+
+	pr.client.cache = cache.NewNoOpCache()
+	if data := pr.GetStaleData(); data.DiffStats != nil {
+		t.Errorf("GetStaleData() without a stale reader = %+v", data)
+	}
+}
@@ -0,0 +1,159 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v73/github"
+)
+
+// SearchQuery is a PR search query split into the qualifiers the query
+// builder edits
+type SearchQuery struct {
+	Orgs            []string // org: qualifiers, matched as any of
+	Team            string   // team-review-requested: (org/team), at most one
+	Labels          []string // A single label: qualifier, matched as any of
+	ExcludeDrafts   bool     // draft:false
+	ReviewRequested bool     // review-requested:@me
+	Other           []string // Everything else, kept as written
+}
+
+// ParseSearchQuery splits a search query into its editable qualifiers
+func ParseSearchQuery(query string) SearchQuery {
+	var q SearchQuery
+	labelsSeen := false
+	for _, term := range searchTerms(query) {
+		switch {
+		case strings.HasPrefix(term, "org:"):
+			q.Orgs = append(q.Orgs, unquote(strings.TrimPrefix(term, "org:")))
+		case strings.HasPrefix(term, "team-review-requested:") && q.Team == "":
+			q.Team = unquote(strings.TrimPrefix(term, "team-review-requested:"))
+		case strings.HasPrefix(term, "label:") && !labelsSeen:
+			// Separate label: qualifiers must all match, so only the first
+			// one is editable
+			labelsSeen = true
+			for label := range strings.SplitSeq(strings.TrimPrefix(term, "label:"), ",") {
+				q.Labels = append(q.Labels, unquote(label))
+			}
+		case term == "draft:false":
+			q.ExcludeDrafts = true
+		case term == "review-requested:@me":
+			q.ReviewRequested = true
+		default:
+			q.Other = append(q.Other, term)
+		}
+	}
+	return q
+}
+
+// String renders the query, adding is:open is:pr when neither is present
+func (q SearchQuery) String() string {
+	var terms []string
+	if !slices.Contains(q.Other, "is:pr") && !slices.Contains(q.Other, "type:pr") {
+		terms = append(terms, "is:open", "is:pr")
+		terms = slices.DeleteFunc(terms, func(term string) bool { return slices.Contains(q.Other, term) })
+	}
+	terms = append(terms, q.Other...)
+
+	for _, org := range q.Orgs {
+		terms = append(terms, "org:"+quote(org))
+	}
+	if q.Team != "" {
+		terms = append(terms, "team-review-requested:"+q.Team)
+	}
+	if len(q.Labels) > 0 {
+		labels := make([]string, len(q.Labels))
+		for i, label := range q.Labels {
+			labels[i] = quote(label)
+		}
+		terms = append(terms, "label:"+strings.Join(labels, ","))
+	}
+	if q.ExcludeDrafts {
+		terms = append(terms, "draft:false")
+	}
+	if q.ReviewRequested {
+		terms = append(terms, "review-requested:@me")
+	}
+	return strings.Join(terms, " ")
+}
+
+// searchTerms splits a query on spaces outside double quotes
+func searchTerms(query string) []string {
+	var terms []string
+	var term strings.Builder
+	quoted := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			term.WriteRune(r)
+		case r == ' ' && !quoted:
+			if term.Len() > 0 {
+				terms = append(terms, term.String())
+				term.Reset()
+			}
+		default:
+			term.WriteRune(r)
+		}
+	}
+	if term.Len() > 0 {
+		terms = append(terms, term.String())
+	}
+	return terms
+}
+
+func quote(s string) string {
+	if strings.ContainsAny(s, " ,") {
+		return `"` + s + `"`
+	}
+	return s
+}
+
+func unquote(s string) string {
+	return strings.Trim(s, `"`)
+}
+
+// ListMyOrgs returns the logins of the organizations the user belongs to
+func (c *Client) ListMyOrgs(ctx context.Context) ([]string, error) {
+	var orgs []string
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := c.client.Organizations.List(ctx, "", opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list organizations: %w", err)
+		}
+		for _, org := range page {
+			orgs = append(orgs, org.GetLogin())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	slog.Debug("Listed organizations", slog.Int("count", len(orgs)))
+	return orgs, nil
+}
+
+// ListMyTeams returns the teams the user belongs to as org/team slugs
+func (c *Client) ListMyTeams(ctx context.Context) ([]string, error) {
+	var teams []string
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := c.client.Teams.ListUserTeams(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list teams: %w", err)
+		}
+		for _, team := range page {
+			teams = append(teams, team.GetOrganization().GetLogin()+"/"+team.GetSlug())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	slog.Debug("Listed teams", slog.Int("count", len(teams)))
+	return teams, nil
+}
@@ -0,0 +1,76 @@
+package github
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	q := ParseSearchQuery(`is:open is:pr org:acme org:widgets label:on-call,"needs review" label:backend team-review-requested:acme/sre draft:false review-requested:@me -author:app/dependabot`)
+
+	if !slices.Equal(q.Orgs, []string{"acme", "widgets"}) {
+		t.Errorf("Orgs = %v", q.Orgs)
+	}
+	if q.Team != "acme/sre" {
+		t.Errorf("Team = %q", q.Team)
+	}
+	if !slices.Equal(q.Labels, []string{"on-call", "needs review"}) {
+		t.Errorf("Labels = %v", q.Labels)
+	}
+	if !q.ExcludeDrafts || !q.ReviewRequested {
+		t.Errorf("ExcludeDrafts = %v, ReviewRequested = %v", q.ExcludeDrafts, q.ReviewRequested)
+	}
+	// A second label: qualifier is ANDed with the first, so it's kept as written
+	if want := []string{"is:open", "is:pr", "label:backend", "-author:app/dependabot"}; !slices.Equal(q.Other, want) {
+		t.Errorf("Other = %v, want %v", q.Other, want)
+	}
+}
+
+func TestSearchQueryString(t *testing.T) {
+	tests := []struct {
+		name  string
+		query SearchQuery
+		want  string
+	}{
+		{
+			name:  "empty",
+			query: SearchQuery{},
+			want:  "is:open is:pr",
+		},
+		{
+			name: "picked qualifiers",
+			query: SearchQuery{
+				Orgs:          []string{"acme"},
+				Team:          "acme/sre",
+				Labels:        []string{"on-call", "needs review"},
+				ExcludeDrafts: true,
+			},
+			want: `is:open is:pr org:acme team-review-requested:acme/sre label:on-call,"needs review" draft:false`,
+		},
+		{
+			name:  "keeps other terms",
+			query: SearchQuery{Other: []string{"is:pr", "is:closed"}, ReviewRequested: true},
+			want:  "is:pr is:closed review-requested:@me",
+		},
+		{
+			name:  "adds is:pr only",
+			query: SearchQuery{Other: []string{"is:open", "repo:acme/api"}},
+			want:  "is:pr is:open repo:acme/api",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchQueryRoundTrip(t *testing.T) {
+	query := `is:open is:pr org:acme label:"on call",bug draft:false`
+	if got := ParseSearchQuery(query).String(); got != query {
+		t.Errorf("round trip = %q, want %q", got, query)
+	}
+}
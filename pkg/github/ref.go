@@ -0,0 +1,75 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/go-github/v73/github"
+	"github.com/kennyp/speedrun/pkg/tracing"
+)
+
+// shortRefPattern matches owner/repo#number
+var shortRefPattern = regexp.MustCompile(`^([\w.-]+)/([\w.-]+)#(\d+)$`)
+
+// ParsePRRef parses a PR URL (https://github.com/owner/repo/pull/123) or a
+// short reference (owner/repo#123)
+func ParsePRRef(ref string) (owner, repo string, number int, err error) {
+	ref = strings.TrimSpace(ref)
+	if m := shortRefPattern.FindStringSubmatch(ref); m != nil {
+		number, _ = strconv.Atoi(m[3])
+		return m[1], m[2], number, nil
+	}
+
+	if !strings.Contains(ref, "://") {
+		ref = "https://" + ref
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to parse PR reference %q: %w", ref, err)
+	}
+
+	// owner/repo/pull/123, possibly followed by /files, /commits, ...
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 || parts[2] != "pull" {
+		return "", "", 0, fmt.Errorf("not a pull request reference: %s (want a PR URL or owner/repo#number)", ref)
+	}
+	number, err = strconv.Atoi(parts[3])
+	if err != nil || number <= 0 {
+		return "", "", 0, fmt.Errorf("invalid pull request number in %s", ref)
+	}
+	return parts[0], parts[1], number, nil
+}
+
+// GetPullRequest fetches a single pull request by number
+func (c *Client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "github.GetPullRequest", tracing.String("github.repo", owner+"/"+repo), tracing.Int("github.pr", number))
+	defer span.End()
+
+	var issue *github.Issue
+	operation := func() error {
+		var err error
+		var resp *github.Response
+		issue, resp, err = c.client.Issues.Get(ctx, owner, repo, number)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+
+	exponentialBackoff := c.backoffConfig.ToExponentialBackoff()
+	if err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx)); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get pull request: %w", err)
+	}
+	if issue.PullRequestLinks == nil {
+		return nil, fmt.Errorf("%s/%s#%d is an issue, not a pull request", owner, repo, number)
+	}
+
+	return newPullRequestFromIssue(ctx, c, issue)
+}
@@ -0,0 +1,87 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	backoffconfig "github.com/kennyp/speedrun/pkg/backoff"
+	"github.com/kennyp/speedrun/pkg/cache"
+)
+
+func TestParsePRRef(t *testing.T) {
+	tests := []struct {
+		ref     string
+		owner   string
+		repo    string
+		number  int
+		wantErr bool
+	}{
+		{ref: "https://github.com/acme/app/pull/42", owner: "acme", repo: "app", number: 42},
+		{ref: "https://github.com/acme/app/pull/42/files", owner: "acme", repo: "app", number: 42},
+		{ref: "github.com/acme/my.app/pull/7", owner: "acme", repo: "my.app", number: 7},
+		{ref: "acme/app#42", owner: "acme", repo: "app", number: 42},
+		{ref: " acme/app#42\n", owner: "acme", repo: "app", number: 42},
+		{ref: "https://github.com/acme/app/issues/42", wantErr: true},
+		{ref: "https://github.com/acme/app/pull/abc", wantErr: true},
+		{ref: "acme/app", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		owner, repo, number, err := ParsePRRef(tt.ref)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParsePRRef(%q) succeeded, want error", tt.ref)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePRRef(%q) error: %v", tt.ref, err)
+			continue
+		}
+		if owner != tt.owner || repo != tt.repo || number != tt.number {
+			t.Errorf("ParsePRRef(%q) = %s/%s#%d, want %s/%s#%d", tt.ref, owner, repo, number, tt.owner, tt.repo, tt.number)
+		}
+	}
+}
+
+func TestGetPullRequest(t *testing.T) {
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/app/issues/5", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"number": 5, "title": "Fix login", "url": "` + srv.URL + `/repos/acme/app/issues/5", "pull_request": {}}`))
+	})
+	mux.HandleFunc("/repos/acme/app/pulls/5", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"number": 5, "head": {"sha": "abc123"}}`))
+	})
+	mux.HandleFunc("/repos/acme/app/issues/6", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"number": 6, "title": "Login broken", "url": "` + srv.URL + `/repos/acme/app/issues/6"}`))
+	})
+	srv = httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(srv.Client())
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &Client{
+		client:        gh,
+		cache:         cache.NewNoOpCache(),
+		backoffConfig: backoffconfig.Config{MaxElapsedTime: time.Second, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 1},
+		health:        &health{},
+	}
+
+	pr, err := c.GetPullRequest(context.Background(), "acme", "app", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr.Owner != "acme" || pr.Repo != "app" || pr.Title != "Fix login" || pr.HeadSHA != "abc123" {
+		t.Errorf("GetPullRequest() = %+v", pr)
+	}
+
+	if _, err := c.GetPullRequest(context.Background(), "acme", "app", 6); err == nil {
+		t.Error("GetPullRequest() on an issue succeeded, want error")
+	}
+}
@@ -0,0 +1,110 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/go-github/v73/github"
+	"github.com/kennyp/speedrun/pkg/tracing"
+)
+
+// errNoProtection marks a branch without protection, or one whose protection
+// the token can't read
+var errNoProtection = errors.New("branch protection not available")
+
+func requiredChecksCacheKey(owner, repo, branch string) string {
+	return fmt.Sprintf("required-checks:%s/%s:%s", owner, repo, branch)
+}
+
+// GetRequiredChecks returns the status checks a branch requires, from both
+// its branch protection and any rulesets that apply to it. Reading branch
+// protection needs admin access, so without it only rulesets are used.
+// Results are cached per branch.
+func (c *Client) GetRequiredChecks(ctx context.Context, owner, repo, branch string) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "github.GetRequiredChecks", tracing.String("github.repo", owner+"/"+repo), tracing.String("github.branch", branch))
+	defer span.End()
+
+	cacheKey := requiredChecksCacheKey(owner, repo, branch)
+	var cached []string
+	if err := c.cacheGet(ctx, cacheKey, &cached); err == nil {
+		return cached, nil
+	}
+
+	var required []string
+	var protection *github.RequiredStatusChecks
+	operation := func() error {
+		var err error
+		var resp *github.Response
+		protection, resp, err = c.client.Repositories.GetRequiredStatusChecks(ctx, owner, repo, branch)
+		if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden) {
+			return backoff.Permanent(errNoProtection)
+		}
+		return err
+	}
+	err := backoff.Retry(operation, backoff.WithContext(c.backoffConfig.ToExponentialBackoff(), ctx))
+	switch {
+	case errors.Is(err, errNoProtection):
+		slog.Debug("No readable branch protection", slog.String("repo", owner+"/"+repo), slog.String("branch", branch))
+	case err != nil:
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get required status checks: %w", err)
+	default:
+		for _, check := range protection.GetChecks() {
+			required = appendCheck(required, check.Context)
+		}
+		for _, name := range protection.GetContexts() {
+			required = appendCheck(required, name)
+		}
+	}
+
+	var rules *github.BranchRules
+	operation = func() error {
+		var err error
+		rules, _, err = c.client.Repositories.GetRulesForBranch(ctx, owner, repo, branch, nil)
+		return err
+	}
+	if err := backoff.Retry(operation, backoff.WithContext(c.backoffConfig.ToExponentialBackoff(), ctx)); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get branch rules: %w", err)
+	}
+	if rules != nil {
+		for _, rule := range rules.RequiredStatusChecks {
+			for _, check := range rule.Parameters.RequiredStatusChecks {
+				required = appendCheck(required, check.Context)
+			}
+		}
+	}
+
+	slog.Debug("Loaded required checks", slog.String("repo", owner+"/"+repo), slog.String("branch", branch), slog.Any("checks", required))
+	if err := c.cacheSet(ctx, cacheKey, required); err != nil {
+		slog.Debug("Failed to cache required checks", slog.Any("error", err))
+	}
+	return required, nil
+}
+
+func appendCheck(checks []string, name string) []string {
+	if name == "" || slices.Contains(checks, name) {
+		return checks
+	}
+	return append(checks, name)
+}
+
+// requiredChecks returns the checks that matter for a PR into branch. The
+// configured list takes precedence over what the branch requires.
+func (c *Client) requiredChecks(ctx context.Context, owner, repo, branch string) []string {
+	if len(c.checksConfig.Required) > 0 || !c.checksConfig.FromBranchProtection || branch == "" {
+		return c.checksConfig.Required
+	}
+
+	required, err := c.GetRequiredChecks(ctx, owner, repo, branch)
+	if err != nil {
+		slog.Debug("Failed to get required checks, falling back to all checks", slog.String("repo", owner+"/"+repo), slog.Any("error", err))
+		return nil
+	}
+	return required
+}
@@ -0,0 +1,88 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	backoffconfig "github.com/kennyp/speedrun/pkg/backoff"
+	"github.com/kennyp/speedrun/pkg/cache"
+)
+
+func TestGetRequiredChecks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/app/branches/main/protection/required_status_checks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"strict":   true,
+			"contexts": []string{"build", "lint"},
+			"checks":   []map[string]any{{"context": "build"}, {"context": "lint"}},
+		})
+	})
+	mux.HandleFunc("/repos/acme/app/rules/branches/main", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]map[string]any{{
+			"type":                "required_status_checks",
+			"ruleset_source_type": "Repository",
+			"ruleset_source":      "acme/app",
+			"ruleset_id":          1,
+			"parameters": map[string]any{
+				"required_status_checks":               []map[string]any{{"context": "lint"}, {"context": "e2e"}},
+				"strict_required_status_checks_policy": false,
+			},
+		}})
+	})
+	// Without admin access branch protection is forbidden
+	mux.HandleFunc("/repos/acme/lib/branches/main/protection/required_status_checks", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Resource not accessible by integration"}`, http.StatusForbidden)
+	})
+	mux.HandleFunc("/repos/acme/lib/rules/branches/main", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("[]"))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(srv.Client())
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &Client{
+		client:        gh,
+		cache:         cache.NewNoOpCache(),
+		backoffConfig: backoffconfig.Config{MaxElapsedTime: time.Second, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 1},
+		health:        &health{},
+	}
+
+	got, err := c.GetRequiredChecks(context.Background(), "acme", "app", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"build", "lint", "e2e"}; !slices.Equal(got, want) {
+		t.Errorf("GetRequiredChecks() = %v, want %v", got, want)
+	}
+
+	got, err = c.GetRequiredChecks(context.Background(), "acme", "lib", "main")
+	if err != nil || len(got) != 0 {
+		t.Errorf("GetRequiredChecks() without admin access = %v, %v", got, err)
+	}
+}
+
+func TestRequiredChecksOverride(t *testing.T) {
+	c := &Client{checksConfig: ChecksConfig{Required: []string{"build"}, FromBranchProtection: true}}
+	// The configured list wins without asking GitHub
+	if got := c.requiredChecks(context.Background(), "acme", "app", "main"); !slices.Equal(got, []string{"build"}) {
+		t.Errorf("requiredChecks() = %v, want the configured list", got)
+	}
+
+	c.checksConfig = ChecksConfig{}
+	if got := c.requiredChecks(context.Background(), "acme", "app", "main"); got != nil {
+		t.Errorf("requiredChecks() = %v, want nil when not derived", got)
+	}
+
+	details := []CheckDetail{{Name: "build"}, {Name: "docs"}}
+	if got := c.filterChecks(details, []string{"build"}); len(got) != 1 || got[0].Name != "build" {
+		t.Errorf("filterChecks() = %v, want only build", got)
+	}
+}
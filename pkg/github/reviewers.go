@@ -0,0 +1,176 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/go-github/v73/github"
+	"github.com/kennyp/speedrun/pkg/tracing"
+)
+
+// GetRequestedReviewers returns the logins of users whose review is still
+// requested on the PR. Team requests are not included.
+func (pr *PullRequest) GetRequestedReviewers(ctx context.Context) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "github.GetRequestedReviewers", pr.spanAttributes()...)
+	defer span.End()
+
+	var reviewers *github.Reviewers
+	operation := func() error {
+		var err error
+		reviewers, _, err = pr.client.client.PullRequests.ListReviewers(ctx, pr.Owner, pr.Repo, pr.Number, &github.ListOptions{PerPage: 100})
+		return err
+	}
+
+	exponentialBackoff := pr.client.backoffConfig.ToExponentialBackoff()
+	if err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx)); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list requested reviewers: %w", err)
+	}
+
+	logins := make([]string, 0, len(reviewers.Users))
+	for _, user := range reviewers.Users {
+		logins = append(logins, user.GetLogin())
+	}
+	return logins, nil
+}
+
+// ReassignReviewer requests a review from one user in place of another
+func (pr *PullRequest) ReassignReviewer(ctx context.Context, from, to string) error {
+	if pr.client.Health().Degraded {
+		return ErrStaleMode
+	}
+	if err := pr.client.checkRepoAccess(pr.Owner, pr.Repo); err != nil {
+		return err
+	}
+	if pr.client.skipWrite("reassign reviewer", slog.Any("pr", pr), slog.String("from", from), slog.String("to", to)) {
+		return nil
+	}
+
+	ctx, span := tracing.Start(ctx, "github.ReassignReviewer", pr.spanAttributes()...)
+	defer span.End()
+
+	start := time.Now()
+
+	// Request the new reviewer first so the PR is never left without one
+	if _, _, err := pr.client.client.PullRequests.RequestReviewers(ctx, pr.Owner, pr.Repo, pr.Number, github.ReviewersRequest{Reviewers: []string{to}}); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to request reviewer %s: %w", to, err)
+	}
+	if _, err := pr.client.client.PullRequests.RemoveReviewers(ctx, pr.Owner, pr.Repo, pr.Number, github.ReviewersRequest{Reviewers: []string{from}}); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to remove reviewer %s: %w", from, err)
+	}
+
+	slog.Info("GitHub API reviewer reassigned", slog.Any("pr", pr), slog.String("from", from), slog.String("to", to), slog.Duration("duration", time.Since(start)))
+	pr.invalidateCache()
+	return nil
+}
+
+// RequestReviews asks users to review the PR, including users who already
+// reviewed it, which re-requests their review
+func (pr *PullRequest) RequestReviews(ctx context.Context, users []string) error {
+	if pr.client.Health().Degraded {
+		return ErrStaleMode
+	}
+	if err := pr.client.checkRepoAccess(pr.Owner, pr.Repo); err != nil {
+		return err
+	}
+	if pr.client.skipWrite("request reviews", slog.Any("pr", pr), slog.Any("users", users)) {
+		return nil
+	}
+
+	ctx, span := tracing.Start(ctx, "github.RequestReviews", pr.spanAttributes()...)
+	defer span.End()
+
+	start := time.Now()
+	if _, _, err := pr.client.client.PullRequests.RequestReviewers(ctx, pr.Owner, pr.Repo, pr.Number, github.ReviewersRequest{Reviewers: users}); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to request reviews: %w", err)
+	}
+
+	slog.Info("GitHub API reviews requested", slog.Any("pr", pr), slog.Any("users", users), slog.Duration("duration", time.Since(start)))
+	pr.invalidateCache()
+	return nil
+}
+
+// ReviewPending is the state of a reviewer whose review is requested
+const ReviewPending = "PENDING"
+
+// ReviewerState is where one reviewer stands on a PR
+type ReviewerState struct {
+	User  string
+	State string // APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED or ReviewPending
+}
+
+// ReviewerStates returns each reviewer's standing, in order of their first
+// review, followed by requested reviewers who haven't reviewed yet. A comment
+// doesn't replace an earlier approval or change request, and a requested
+// reviewer is pending whatever they said before. The author is left out.
+func ReviewerStates(reviews []*Review, requested []string, author string) []ReviewerState {
+	var states []ReviewerState
+	index := make(map[string]int)
+	set := func(user, state string) {
+		if i, ok := index[user]; ok {
+			states[i].State = state
+			return
+		}
+		index[user] = len(states)
+		states = append(states, ReviewerState{User: user, State: state})
+	}
+
+	for _, review := range reviews {
+		if review.User == author || review.State == ReviewPending {
+			continue
+		}
+		if i, ok := index[review.User]; ok && review.State == "COMMENTED" && states[i].State != "COMMENTED" {
+			continue
+		}
+		set(review.User, review.State)
+	}
+	for _, user := range requested {
+		set(user, ReviewPending)
+	}
+	return states
+}
+
+// GetTeamMembers lists the logins of a team's members. The team is given as
+// org/team-slug.
+func (c *Client) GetTeamMembers(ctx context.Context, team string) ([]string, error) {
+	org, slug, ok := strings.Cut(strings.TrimPrefix(team, "@"), "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid team %q, want org/team", team)
+	}
+
+	ctx, span := tracing.Start(ctx, "github.GetTeamMembers", tracing.String("github.team", team))
+	defer span.End()
+
+	var logins []string
+	opts := &github.TeamListTeamMembersOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		var members []*github.User
+		var resp *github.Response
+		operation := func() error {
+			var err error
+			members, resp, err = c.client.Teams.ListTeamMembersBySlug(ctx, org, slug, opts)
+			return err
+		}
+
+		exponentialBackoff := c.backoffConfig.ToExponentialBackoff()
+		if err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx)); err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to list team members: %w", err)
+		}
+
+		for _, member := range members {
+			logins = append(logins, member.GetLogin())
+		}
+		if resp.NextPage == 0 {
+			return logins, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
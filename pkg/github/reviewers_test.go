@@ -0,0 +1,103 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	backoffconfig "github.com/kennyp/speedrun/pkg/backoff"
+	"github.com/kennyp/speedrun/pkg/cache"
+)
+
+func TestReviewers(t *testing.T) {
+	var calls []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/app/pulls/5/requested_reviewers", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"users": []map[string]any{{"login": "alice"}, {"login": "bob"}},
+				"teams": []map[string]any{{"slug": "platform"}},
+			})
+			return
+		}
+		var req github.ReviewersRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		calls = append(calls, r.Method+" "+req.Reviewers[0])
+		_ = json.NewEncoder(w).Encode(map[string]any{"number": 5})
+	})
+	mux.HandleFunc("/orgs/acme/teams/platform/members", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]map[string]any{{"login": "alice"}, {"login": "carol"}})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(srv.Client())
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &Client{
+		client:        gh,
+		cache:         cache.NewNoOpCache(),
+		backoffConfig: backoffconfig.Config{MaxElapsedTime: time.Second, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 1},
+		health:        &health{},
+	}
+	pr := &PullRequest{Owner: "acme", Repo: "app", Number: 5, client: c}
+
+	reviewers, err := pr.GetRequestedReviewers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(reviewers, []string{"alice", "bob"}) {
+		t.Errorf("GetRequestedReviewers() = %v", reviewers)
+	}
+
+	if err := pr.ReassignReviewer(context.Background(), "alice", "carol"); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(calls, []string{"POST carol", "DELETE alice"}) {
+		t.Errorf("calls = %v, want the new reviewer requested before the old one is removed", calls)
+	}
+
+	calls = nil
+	if err := pr.RequestReviews(context.Background(), []string{"bob"}); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(calls, []string{"POST bob"}) {
+		t.Errorf("calls = %v, want a review request for bob", calls)
+	}
+
+	members, err := c.GetTeamMembers(context.Background(), "acme/platform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(members, []string{"alice", "carol"}) {
+		t.Errorf("GetTeamMembers() = %v", members)
+	}
+}
+
+func TestReviewerStates(t *testing.T) {
+	reviews := []*Review{
+		{User: "alice", State: "APPROVED"},
+		{User: "me", State: "COMMENTED"},
+		{User: "bob", State: "CHANGES_REQUESTED"},
+		{User: "alice", State: "COMMENTED"}, // Doesn't undo the approval
+		{User: "carol", State: "COMMENTED"},
+		{User: "carol", State: "APPROVED"},
+		{User: "bob", State: "COMMENTED"},
+	}
+
+	got := ReviewerStates(reviews, []string{"bob", "dave"}, "me")
+	want := []ReviewerState{
+		{User: "alice", State: "APPROVED"},
+		{User: "bob", State: ReviewPending}, // Re-requested
+		{User: "carol", State: "APPROVED"},
+		{User: "dave", State: ReviewPending},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("ReviewerStates() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,125 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v73/github"
+)
+
+// Capability is a feature the token doesn't allow, and why
+type Capability struct {
+	Name   string
+	Reason string
+}
+
+// TokenReport describes what the token can't do
+type TokenReport struct {
+	Scopes      []string          // Classic OAuth scopes; nil when the token doesn't report them
+	Unavailable []Capability      // Features that will fail
+	SSO         map[string]string // Orgs needing SSO authorization, with the URL to authorize at
+}
+
+// writeCapabilities are the features that need the repo scope
+var writeCapabilities = []string{"approve", "merge", "auto-merge", "bot actions", "reviewer reassignment"}
+
+// VerifyToken checks the token's scopes and its SSO authorization for orgs,
+// so missing permissions are reported up front instead of failing per PR.
+// Fine-grained tokens don't report scopes, so only SSO is checked for them.
+// teams says whether team features are configured and need read:org.
+func (c *Client) VerifyToken(ctx context.Context, orgs []string, teams bool) (*TokenReport, error) {
+	_, resp, err := c.client.Users.Get(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify token: %w", err)
+	}
+
+	report := &TokenReport{SSO: make(map[string]string)}
+	if values := resp.Header.Values("X-OAuth-Scopes"); values != nil {
+		report.Scopes = []string{}
+		for scope := range strings.SplitSeq(strings.Join(values, ","), ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				report.Scopes = append(report.Scopes, scope)
+			}
+		}
+		report.Unavailable = missingCapabilities(report.Scopes, teams)
+	}
+
+	for _, org := range orgs {
+		_, resp, err := c.client.Repositories.ListByOrg(ctx, org, &github.RepositoryListByOrgOptions{ListOptions: github.ListOptions{PerPage: 1}})
+		if resp == nil {
+			slog.Debug("Failed to check SSO authorization", slog.String("org", org), slog.Any("error", err))
+			continue
+		}
+		if url, ok := ssoRequired(resp.Header.Get("X-GitHub-SSO")); ok {
+			report.SSO[org] = url
+		}
+	}
+
+	c.tokenReport = report
+	return report, nil
+}
+
+// TokenReport returns the result of VerifyToken, or nil if it hasn't run
+func (c *Client) TokenReport() *TokenReport {
+	return c.tokenReport
+}
+
+// missingCapabilities lists the features classic token scopes don't allow
+func missingCapabilities(scopes []string, teams bool) []Capability {
+	has := func(names ...string) bool {
+		return slices.ContainsFunc(names, func(name string) bool { return slices.Contains(scopes, name) })
+	}
+
+	var missing []Capability
+	switch {
+	case has("repo"):
+	case has("public_repo"):
+		for _, name := range writeCapabilities {
+			missing = append(missing, Capability{Name: name + " on private repos", Reason: "token has public_repo but not repo scope"})
+		}
+	default:
+		for _, name := range writeCapabilities {
+			missing = append(missing, Capability{Name: name, Reason: "token lacks the repo scope"})
+		}
+	}
+
+	if !has("security_events") {
+		missing = append(missing, Capability{Name: "Dependabot alert matching", Reason: "token lacks the security_events scope"})
+	}
+	if teams && !has("read:org", "write:org", "admin:org") {
+		missing = append(missing, Capability{Name: "team review load", Reason: "token lacks the read:org scope"})
+	}
+	return missing
+}
+
+// ssoRequired parses an X-GitHub-SSO header such as
+// "required; url=https://github.com/orgs/acme/sso?authorization_request=..."
+func ssoRequired(header string) (string, bool) {
+	status, params, _ := strings.Cut(header, ";")
+	if strings.TrimSpace(status) != "required" {
+		return "", false
+	}
+	url, _ := strings.CutPrefix(strings.TrimSpace(params), "url=")
+	return url, true
+}
+
+// SearchOrgs returns the owners named by org: and repo: qualifiers in a
+// search query
+func SearchOrgs(query string) []string {
+	var orgs []string
+	for field := range strings.FieldsSeq(query) {
+		var owner string
+		if org, ok := strings.CutPrefix(field, "org:"); ok {
+			owner = org
+		} else if repo, ok := strings.CutPrefix(field, "repo:"); ok {
+			owner, _, _ = strings.Cut(repo, "/")
+		}
+		if owner != "" && !slices.Contains(orgs, owner) {
+			orgs = append(orgs, owner)
+		}
+	}
+	return orgs
+}
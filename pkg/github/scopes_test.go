@@ -0,0 +1,76 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/google/go-github/v73/github"
+)
+
+func TestVerifyToken(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-OAuth-Scopes", "public_repo, security_events")
+		_, _ = w.Write([]byte(`{"login":"octocat"}`))
+	})
+	mux.HandleFunc("/orgs/acme/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-GitHub-SSO", "required; url=https://github.com/orgs/acme/sso?authorization_request=abc")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Resource protected by organization SAML enforcement."}`))
+	})
+	mux.HandleFunc("/orgs/open/repos", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(srv.Client())
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &Client{client: gh, health: &health{}}
+
+	report, err := c.VerifyToken(context.Background(), []string{"acme", "open"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(report.Scopes, []string{"public_repo", "security_events"}) {
+		t.Errorf("Scopes = %v", report.Scopes)
+	}
+	var names []string
+	for _, capability := range report.Unavailable {
+		names = append(names, capability.Name)
+	}
+	want := []string{
+		"approve on private repos", "merge on private repos", "auto-merge on private repos",
+		"bot actions on private repos", "reviewer reassignment on private repos", "team review load",
+	}
+	if !slices.Equal(names, want) {
+		t.Errorf("Unavailable = %v, want %v", names, want)
+	}
+	if got := report.SSO; len(got) != 1 || got["acme"] != "https://github.com/orgs/acme/sso?authorization_request=abc" {
+		t.Errorf("SSO = %v, want only acme", got)
+	}
+	if c.TokenReport() != report {
+		t.Error("TokenReport() doesn't return the verified report")
+	}
+}
+
+func TestMissingCapabilitiesFullScopes(t *testing.T) {
+	if missing := missingCapabilities([]string{"repo", "security_events", "read:org"}, true); len(missing) != 0 {
+		t.Errorf("missingCapabilities() = %v, want none", missing)
+	}
+	if missing := missingCapabilities([]string{"repo", "security_events"}, false); len(missing) != 0 {
+		t.Errorf("missingCapabilities() without teams = %v, want none", missing)
+	}
+}
+
+func TestSearchOrgs(t *testing.T) {
+	got := SearchOrgs("is:open is:pr org:acme repo:partner/lib repo:acme/api label:on-call")
+	if !slices.Equal(got, []string{"acme", "partner"}) {
+		t.Errorf("SearchOrgs() = %v", got)
+	}
+}
@@ -0,0 +1,256 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/go-github/v73/github"
+	"github.com/kennyp/speedrun/pkg/tracing"
+)
+
+// Search APIs PR queries can use
+const (
+	SearchAPIAuto    = "auto"    // REST, switching to GraphQL while the REST search quota is used up
+	SearchAPIREST    = "rest"    // REST only
+	SearchAPIGraphQL = "graphql" // GraphQL only
+)
+
+// searchLimitFallback is how long GraphQL stands in for REST search when
+// GitHub doesn't say when the quota resets
+const searchLimitFallback = time.Minute
+
+// searchLimit tracks when the REST search quota resets. Clients for other
+// queries share it, since the quota is per token.
+type searchLimit struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// active reports whether the REST search quota is still used up
+func (l *searchLimit) active() bool {
+	if l == nil {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return time.Now().Before(l.until)
+}
+
+// set records when the REST search quota resets
+func (l *searchLimit) set(until time.Time) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.until = until
+}
+
+// SetSearchAPI picks the API PR searches go through: SearchAPIAuto (the
+// default), SearchAPIREST or SearchAPIGraphQL
+func (c *Client) SetSearchAPI(api string) {
+	c.searchAPI = api
+}
+
+// useGraphQLSearch reports whether searches should skip REST for now
+func (c *Client) useGraphQLSearch() bool {
+	switch c.searchAPI {
+	case SearchAPIGraphQL:
+		return true
+	case SearchAPIREST:
+		return false
+	}
+	return c.searchLimit.active()
+}
+
+// searchRateLimitReset reports whether err is a rate limit and when it lifts
+func searchRateLimitReset(err error) (time.Time, bool) {
+	var rateErr *github.RateLimitError
+	if errors.As(err, &rateErr) {
+		if reset := rateErr.Rate.Reset.Time; !reset.IsZero() {
+			return reset, true
+		}
+		return time.Now().Add(searchLimitFallback), true
+	}
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		if retryAfter := abuseErr.GetRetryAfter(); retryAfter > 0 {
+			return time.Now().Add(retryAfter), true
+		}
+		return time.Now().Add(searchLimitFallback), true
+	}
+	return time.Time{}, false
+}
+
+// searchIssues runs a single page issue search with retries. In auto mode a
+// used up REST search quota switches searches to GraphQL until it resets.
+func (c *Client) searchIssues(ctx context.Context, query string) ([]*github.Issue, error) {
+	if c.useGraphQLSearch() {
+		return c.searchIssuesGraphQL(ctx, query)
+	}
+
+	issues, err := c.searchIssuesREST(ctx, query)
+	if reset, limited := searchRateLimitReset(err); limited && c.searchAPI != SearchAPIREST {
+		slog.Warn("REST search rate limited, searching through GraphQL", slog.Time("until", reset))
+		c.searchLimit.set(reset)
+		return c.searchIssuesGraphQL(ctx, query)
+	}
+	return issues, err
+}
+
+// searchIssuesREST searches through the REST search API, which has its own
+// low rate limit
+func (c *Client) searchIssuesREST(ctx context.Context, query string) ([]*github.Issue, error) {
+	opts := &github.SearchOptions{
+		Sort:  "created",
+		Order: "desc",
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	var result *github.IssuesSearchResult
+	operation := func() error {
+		var searchErr error
+		result, _, searchErr = c.client.Search.Issues(ctx, query, opts)
+		// Retrying won't help until the quota resets
+		if _, limited := searchRateLimitReset(searchErr); limited {
+			return backoff.Permanent(searchErr)
+		}
+		return searchErr
+	}
+
+	exponentialBackoff := c.backoffConfig.ToExponentialBackoff()
+	if err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx)); err != nil {
+		return nil, err
+	}
+	return result.Issues, nil
+}
+
+// graphQLSearchResult is the part of a GraphQL PR search speedrun uses
+type graphQLSearchResult struct {
+	Search struct {
+		Nodes []struct {
+			Number    int        `json:"number"`
+			Title     string     `json:"title"`
+			Body      string     `json:"body"`
+			URL       string     `json:"url"`
+			CreatedAt time.Time  `json:"createdAt"`
+			UpdatedAt time.Time  `json:"updatedAt"`
+			MergedAt  *time.Time `json:"mergedAt"`
+			Author    *struct {
+				Login string `json:"login"`
+			} `json:"author"`
+			Labels struct {
+				Nodes []struct {
+					Name string `json:"name"`
+				} `json:"nodes"`
+			} `json:"labels"`
+			Repository struct {
+				NameWithOwner string `json:"nameWithOwner"`
+			} `json:"repository"`
+		} `json:"nodes"`
+	} `json:"search"`
+}
+
+// searchIssuesGraphQL searches through GraphQL, which draws on the GraphQL
+// rate limit instead of the REST search one. Results are shaped like REST
+// search results.
+func (c *Client) searchIssuesGraphQL(ctx context.Context, query string) ([]*github.Issue, error) {
+	ctx, span := tracing.Start(ctx, "github.graphql.Search", tracing.String("github.query", query))
+	defer span.End()
+
+	// REST search is sorted by the options; GraphQL only by qualifier
+	if !strings.Contains(query, "sort:") {
+		query += " sort:created-desc"
+	}
+
+	gql := `
+		query SearchPullRequests($query: String!) {
+			search(query: $query, type: ISSUE, first: 100) {
+				nodes {
+					... on PullRequest {
+						number
+						title
+						body
+						url
+						createdAt
+						updatedAt
+						mergedAt
+						author {
+							login
+						}
+						labels(first: 50) {
+							nodes {
+								name
+							}
+						}
+						repository {
+							nameWithOwner
+						}
+					}
+				}
+			}
+		}
+	`
+
+	var response *GraphQLResponse
+	operation := func() error {
+		var err error
+		response, err = c.graphqlClient.executeQuery(ctx, gql, map[string]any{"query": query})
+		return err
+	}
+
+	exponentialBackoff := c.backoffConfig.ToExponentialBackoff()
+	if err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx)); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to search through GraphQL: %w", err)
+	}
+
+	var result graphQLSearchResult
+	if err := json.Unmarshal(response.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse GraphQL search response: %w", err)
+	}
+
+	issues := make([]*github.Issue, 0, len(result.Search.Nodes))
+	for _, node := range result.Search.Nodes {
+		// Issues match the query too but come back empty
+		if node.Number == 0 {
+			continue
+		}
+
+		apiURL := fmt.Sprintf("%srepos/%s/issues/%d", c.client.BaseURL, node.Repository.NameWithOwner, node.Number)
+		issue := &github.Issue{
+			Number:    github.Ptr(node.Number),
+			Title:     github.Ptr(node.Title),
+			Body:      github.Ptr(node.Body),
+			URL:       github.Ptr(apiURL),
+			HTMLURL:   github.Ptr(node.URL),
+			CreatedAt: &github.Timestamp{Time: node.CreatedAt},
+			UpdatedAt: &github.Timestamp{Time: node.UpdatedAt},
+			PullRequestLinks: &github.PullRequestLinks{
+				HTMLURL: github.Ptr(node.URL),
+			},
+		}
+		if node.MergedAt != nil {
+			issue.PullRequestLinks.MergedAt = &github.Timestamp{Time: *node.MergedAt}
+		}
+		if node.Author != nil {
+			issue.User = &github.User{Login: github.Ptr(node.Author.Login)}
+		}
+		for _, label := range node.Labels.Nodes {
+			issue.Labels = append(issue.Labels, &github.Label{Name: github.Ptr(label.Name)})
+		}
+		issues = append(issues, issue)
+	}
+
+	slog.Debug("GitHub GraphQL search completed", slog.String("query", query), slog.Int("results", len(issues)))
+	return issues, nil
+}
@@ -0,0 +1,82 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	backoffconfig "github.com/kennyp/speedrun/pkg/backoff"
+)
+
+func TestSearchFallsBackToGraphQL(t *testing.T) {
+	restSearches, graphQLSearches := 0, 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		restSearches++
+		w.Header().Set("X-RateLimit-Limit", "30")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprint(time.Now().Add(time.Minute).Unix()))
+		w.Header().Set("X-RateLimit-Resource", "search")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"API rate limit exceeded"}`))
+	})
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		graphQLSearches++
+		_, _ = w.Write([]byte(`{"data":{"search":{"nodes":[
+			{"number":5,"title":"Fix login","url":"https://github.com/acme/app/pull/5",
+			 "createdAt":"2026-01-02T03:04:05Z","updatedAt":"2026-01-03T03:04:05Z",
+			 "author":{"login":"octocat"},"labels":{"nodes":[{"name":"bug"}]},
+			 "repository":{"nameWithOwner":"acme/app"}},
+			{}
+		]}}}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(srv.Client())
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &Client{
+		client:        gh,
+		graphqlClient: &GraphQLClient{endpoint: srv.URL + "/graphql", httpClient: srv.Client()},
+		backoffConfig: backoffconfig.Config{MaxElapsedTime: time.Second, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 1},
+		health:        &health{},
+		searchAPI:     SearchAPIAuto,
+		searchLimit:   &searchLimit{},
+	}
+
+	issues, err := c.searchIssues(context.Background(), "is:open is:pr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("searchIssues() returned %d issues, want the one PR", len(issues))
+	}
+	issue := issues[0]
+	owner, repo, err := parseIssueURL(issue.GetURL())
+	if err != nil || owner != "acme" || repo != "app" || !isOpenPullRequest(issue) {
+		t.Errorf("issue = %s (%s/%s, %v), want an open PR in acme/app", issue.GetURL(), owner, repo, err)
+	}
+	if issue.GetUser().GetLogin() != "octocat" || len(issue.Labels) != 1 || issue.Labels[0].GetName() != "bug" {
+		t.Errorf("issue author %q labels %v", issue.GetUser().GetLogin(), issue.Labels)
+	}
+
+	// Until the quota resets, REST search is skipped
+	if _, err := c.searchIssues(context.Background(), "is:open is:pr"); err != nil {
+		t.Fatal(err)
+	}
+	if restSearches != 1 || graphQLSearches != 2 {
+		t.Errorf("REST searches = %d, GraphQL searches = %d; want 1 and 2", restSearches, graphQLSearches)
+	}
+
+	// REST only surfaces the rate limit instead
+	c.searchAPI = SearchAPIREST
+	if _, err := c.searchIssues(context.Background(), "is:open is:pr"); err == nil || !strings.Contains(err.Error(), "rate limit") {
+		t.Errorf("searchIssues() with REST only = %v, want the rate limit error", err)
+	}
+}
@@ -0,0 +1,53 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/kennyp/speedrun/pkg/cache"
+)
+
+// SetSharedCache shares AI analyses with everyone using remote, so a PR's
+// head commit is only analyzed once across the team. Nil stops sharing.
+func (c *Client) SetSharedCache(remote *cache.Remote) {
+	c.shared = remote
+}
+
+// sharedAnalysisKey identifies the analysis of the PR's head commit on the
+// shared cache. PR numbers are left out: the commit is what's analyzed. The
+// version keeps teammates on other prompts from sharing analyses.
+func (pr *PullRequest) sharedAnalysisKey() string {
+	key := fmt.Sprintf("ai/%s/%s/%s", pr.Owner, pr.Repo, pr.HeadSHA)
+	if pr.client.aiVersion != "" {
+		key += "/" + pr.client.aiVersion
+	}
+	return key
+}
+
+// getSharedAIAnalysis reads the PR's analysis from the shared cache, keeping
+// a local copy
+func (pr *PullRequest) getSharedAIAnalysis(dest AIAnalysis) error {
+	if pr.client.shared == nil || pr.HeadSHA == "" {
+		return cache.ErrCacheMiss
+	}
+	if err := pr.client.shared.Get(context.Background(), pr.sharedAnalysisKey(), dest); err != nil {
+		return err
+	}
+	if err := pr.client.cache.Set(pr.aiAnalysisCacheKey(), dest); err != nil {
+		slog.Debug("Failed to cache shared AI analysis", slog.Any("pr", pr), slog.Any("error", err))
+	}
+	slog.Debug("AI analysis retrieved from shared cache", slog.Any("pr", pr), slog.String("recommendation", dest.GetRecommendation()))
+	return nil
+}
+
+// shareAIAnalysis puts the PR's analysis on the shared cache. Sharing is best
+// effort; failures are only logged.
+func (pr *PullRequest) shareAIAnalysis(analysis any) {
+	if pr.client.shared == nil || pr.HeadSHA == "" {
+		return
+	}
+	if err := pr.client.shared.Set(context.Background(), pr.sharedAnalysisKey(), analysis); err != nil {
+		slog.Warn("Failed to share AI analysis", slog.Any("pr", pr), slog.Any("error", err))
+	}
+}
@@ -0,0 +1,87 @@
+package github
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/cache"
+)
+
+func TestSharedAIAnalysis(t *testing.T) {
+	var mu sync.Mutex
+	stored := make(map[string][]byte)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodPut {
+			stored[r.URL.Path], _ = io.ReadAll(r.Body)
+			return
+		}
+		data, ok := stored[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	defer srv.Close()
+
+	remote, err := cache.NewRemote(srv.URL, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newPR := func(number int) *PullRequest {
+		client := &Client{cache: cache.NewMemoryCache(cache.NewNoOpCache(), 10, time.Hour)}
+		client.SetSharedCache(remote)
+		return &PullRequest{Owner: "acme", Repo: "api", Number: number, HeadSHA: "abc", client: client}
+	}
+
+	// One engineer analyzes the PR
+	if err := newPR(7).SetCachedAIAnalysis(&testAnalysis{Recommendation: "APPROVE"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := stored["/ai/acme/api/abc"]; !ok {
+		t.Fatalf("analysis not shared: %v", stored)
+	}
+
+	// Another, with nothing cached locally, gets the same commit's analysis
+	other := newPR(7)
+	var dest testAnalysis
+	if err := other.GetCachedAIAnalysis(&dest); err != nil || dest.Recommendation != "APPROVE" {
+		t.Fatalf("GetCachedAIAnalysis() = %+v, %v; want the shared analysis", dest, err)
+	}
+
+	// and keeps a local copy
+	other.client.SetSharedCache(nil)
+	dest = testAnalysis{}
+	if err := other.GetCachedAIAnalysis(&dest); err != nil || dest.Recommendation != "APPROVE" {
+		t.Errorf("GetCachedAIAnalysis() without sharing = %+v, %v; want the local copy", dest, err)
+	}
+}
+
+func TestAIAnalysisVersion(t *testing.T) {
+	client := &Client{cache: cache.NewMemoryCache(cache.NewNoOpCache(), 10, time.Hour)}
+	pr := &PullRequest{Owner: "acme", Repo: "api", Number: 7, HeadSHA: "abc", client: client}
+
+	client.SetAIAnalysisVersion("v1")
+	if err := pr.SetCachedAIAnalysis(&testAnalysis{Recommendation: "APPROVE"}); err != nil {
+		t.Fatal(err)
+	}
+	var dest testAnalysis
+	if err := pr.GetCachedAIAnalysis(&dest); err != nil || dest.Recommendation != "APPROVE" {
+		t.Fatalf("GetCachedAIAnalysis() = %+v, %v; want the cached analysis", dest, err)
+	}
+
+	// Once the prompts change, the old analysis is stale
+	client.SetAIAnalysisVersion("v2")
+	if err := pr.GetCachedAIAnalysis(&testAnalysis{}); err == nil {
+		t.Error("GetCachedAIAnalysis() found an analysis from another version")
+	}
+	if key := pr.sharedAnalysisKey(); key != "ai/acme/api/abc/v2" {
+		t.Errorf("sharedAnalysisKey() = %q", key)
+	}
+}
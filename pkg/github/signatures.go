@@ -0,0 +1,140 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/go-github/v73/github"
+	"github.com/kennyp/speedrun/pkg/tracing"
+)
+
+// signoffPattern matches a DCO Signed-off-by trailer
+var signoffPattern = regexp.MustCompile(`(?m)^Signed-off-by: .+<.+>\s*$`)
+
+// DCO states reported by DCOStatus
+const (
+	DCOPassed  = "passed"
+	DCOFailed  = "failed"
+	DCOPending = "pending"
+)
+
+// CommitSignatures summarises signing and DCO sign-off across a PR's commits
+type CommitSignatures struct {
+	Commits    int
+	Unverified int // Commits without a verified signature
+	NoSignoff  int // Commits without a Signed-off-by trailer
+}
+
+// LogValue implements slog.LogValuer for structured logging
+func (cs *CommitSignatures) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("commits", cs.Commits),
+		slog.Int("unverified", cs.Unverified),
+		slog.Int("no_signoff", cs.NoSignoff),
+	)
+}
+
+func (pr *PullRequest) signaturesCacheKey() string {
+	return fmt.Sprintf("signatures:%s/%s#%d:%s", pr.Owner, pr.Repo, pr.Number, pr.HeadSHA)
+}
+
+// GetCommitSignatures reports how many of the PR's commits are signed and
+// signed off. Results are cached per head commit.
+func (pr *PullRequest) GetCommitSignatures(ctx context.Context) (*CommitSignatures, error) {
+	if pr.client == nil {
+		return nil, fmt.Errorf("PR client is nil")
+	}
+
+	ctx, span := tracing.Start(ctx, "github.GetCommitSignatures", pr.spanAttributes()...)
+	defer span.End()
+
+	cacheKey := pr.signaturesCacheKey()
+	if pr.HeadSHA != "" {
+		var cached CommitSignatures
+		if err := pr.client.cacheGet(ctx, cacheKey, &cached); err == nil {
+			return &cached, nil
+		}
+	}
+
+	start := time.Now()
+	var commits []*github.RepositoryCommit
+	operation := func() error {
+		var listErr error
+		commits, _, listErr = pr.client.client.PullRequests.ListCommits(ctx, pr.Owner, pr.Repo, pr.Number, &github.ListOptions{PerPage: 100})
+		return listErr
+	}
+
+	exponentialBackoff := pr.client.backoffConfig.ToExponentialBackoff()
+	if err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx)); err != nil {
+		span.RecordError(err)
+		slog.Error("GitHub API list commits failed", slog.Any("pr", pr), slog.Duration("duration", time.Since(start)), slog.Any("error", err))
+		return nil, fmt.Errorf("failed to list PR commits: %w", err)
+	}
+
+	sigs := &CommitSignatures{Commits: len(commits)}
+	for _, commit := range commits {
+		if !commit.GetCommit().GetVerification().GetVerified() {
+			sigs.Unverified++
+		}
+		if !signoffPattern.MatchString(commit.GetCommit().GetMessage()) {
+			sigs.NoSignoff++
+		}
+	}
+
+	slog.Debug("GitHub API commit signatures completed", slog.Any("pr", pr), slog.Any("signatures", sigs), slog.Duration("duration", time.Since(start)))
+
+	if pr.HeadSHA != "" {
+		if err := pr.client.cacheSet(ctx, cacheKey, sigs); err != nil {
+			slog.Debug("Failed to cache commit signatures", slog.Any("error", err))
+		}
+	}
+
+	return sigs, nil
+}
+
+// DCOStatus reports whether the PR passes DCO. A CI check named DCO is
+// authoritative; without one, every commit needs a Signed-off-by trailer.
+// Returns "" if neither source is available.
+func DCOStatus(checks *CheckStatus, sigs *CommitSignatures) string {
+	if checks != nil {
+		for _, detail := range checks.Details {
+			if !strings.Contains(strings.ToLower(detail.Name), "dco") {
+				continue
+			}
+			switch detail.Status {
+			case "success":
+				return DCOPassed
+			case "failure", "error":
+				return DCOFailed
+			default:
+				return DCOPending
+			}
+		}
+	}
+
+	if sigs == nil {
+		return ""
+	}
+	if sigs.NoSignoff > 0 {
+		return DCOFailed
+	}
+	return DCOPassed
+}
+
+// RequiresSignedCommits reports whether owner/repo matches any of the
+// patterns, e.g. "yourcompany/api" or "yourcompany/*"
+func RequiresSignedCommits(owner, repo string, patterns []string) bool {
+	name := owner + "/" + repo
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package github
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDCOStatus(t *testing.T) {
+	signedOff := &CommitSignatures{Commits: 2}
+	missing := &CommitSignatures{Commits: 2, NoSignoff: 1}
+	withCheck := func(status string) *CheckStatus {
+		return &CheckStatus{Details: []CheckDetail{{Name: "build", Status: "success"}, {Name: "DCO", Status: status}}}
+	}
+
+	tests := []struct {
+		name   string
+		checks *CheckStatus
+		sigs   *CommitSignatures
+		want   string
+	}{
+		{"unknown", nil, nil, ""},
+		{"all signed off", nil, signedOff, DCOPassed},
+		{"missing sign-off", &CheckStatus{}, missing, DCOFailed},
+		{"check overrides trailers", withCheck("success"), missing, DCOPassed},
+		{"failed check", withCheck("failure"), signedOff, DCOFailed},
+		{"pending check", withCheck("pending"), signedOff, DCOPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DCOStatus(tt.checks, tt.sigs); got != tt.want {
+				t.Errorf("DCOStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequiresSignedCommits(t *testing.T) {
+	patterns := []string{"acme/api", "secure/*"}
+
+	for repo, want := range map[string]bool{
+		"acme/api":     true,
+		"acme/web":     false,
+		"secure/vault": true,
+		"other/secure": false,
+	} {
+		owner, name, _ := strings.Cut(repo, "/")
+		if got := RequiresSignedCommits(owner, name, patterns); got != want {
+			t.Errorf("RequiresSignedCommits(%s) = %v, want %v", repo, got, want)
+		}
+	}
+}
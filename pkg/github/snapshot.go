@@ -0,0 +1,54 @@
+package github
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/kennyp/speedrun/pkg/prtemplate"
+)
+
+// Snapshot is what's been loaded about a PR: the UI lists it and the AI
+// analyzes it. Parts not loaded yet are nil.
+type Snapshot struct {
+	PR           *PullRequest
+	DiffStats    *DiffStats
+	CheckStatus  *CheckStatus
+	Reviews      []*Review
+	Signatures   *CommitSignatures
+	ChangedFiles []string // Paths the PR changes
+
+	// How well the description fills out the repository's PR template, nil
+	// if it has none
+	Template *prtemplate.Compliance
+}
+
+// HTMLURL returns the PR's page on GitHub
+func (s Snapshot) HTMLURL() string {
+	return fmt.Sprintf("https://github.com/%s/%s/pull/%d", s.PR.Owner, s.PR.Repo, s.PR.Number)
+}
+
+// Languages returns the languages of the changed files, most files first,
+// leaving out binary, minified and generated files
+func (s Snapshot) Languages() []string {
+	files := s.ChangedFiles
+	if s.DiffStats != nil && len(s.DiffStats.Excluded) > 0 {
+		files = slices.DeleteFunc(slices.Clone(files), func(path string) bool {
+			return slices.ContainsFunc(s.DiffStats.Excluded, func(file ExcludedFile) bool { return file.Path == path })
+		})
+	}
+	return Languages(files)
+}
+
+// DCOStatus reports whether the PR passes DCO, or "" if unknown
+func (s Snapshot) DCOStatus() string {
+	return DCOStatus(s.CheckStatus, s.Signatures)
+}
+
+// UnverifiedCommits returns how many commits lack a verified signature, or 0
+// if signatures aren't loaded
+func (s Snapshot) UnverifiedCommits() int {
+	if s.Signatures == nil {
+		return 0
+	}
+	return s.Signatures.Unverified
+}
@@ -0,0 +1,26 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSnapshotLanguages(t *testing.T) {
+	s := Snapshot{
+		PR:           &PullRequest{Owner: "acme", Repo: "api", Number: 4},
+		ChangedFiles: []string{"api/v1/service.pb.go", "web/app.ts", "web/form.ts", "main.go"},
+		DiffStats:    &DiffStats{Excluded: []ExcludedFile{{Path: "api/v1/service.pb.go", Kind: FileGenerated}}},
+	}
+	if got, want := s.Languages(), []string{"TypeScript", "Go"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Languages() = %v, want %v", got, want)
+	}
+	if len(s.ChangedFiles) != 4 {
+		t.Error("Languages() changed ChangedFiles")
+	}
+	if got := s.HTMLURL(); got != "https://github.com/acme/api/pull/4" {
+		t.Errorf("HTMLURL() = %q", got)
+	}
+	if got := s.UnverifiedCommits(); got != 0 {
+		t.Errorf("UnverifiedCommits() without signatures = %d", got)
+	}
+}
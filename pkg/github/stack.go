@@ -0,0 +1,53 @@
+package github
+
+import "strings"
+
+// Stacks finds stacked PRs, where a PR's base branch is the head branch of
+// another open PR in the same repository. Each stack is ordered bottom-up,
+// starting from the PR that targets a regular branch. PRs that aren't stacked
+// are left out, and where several PRs build on the same one, each gets its
+// own stack sharing the PRs below.
+func Stacks(prs []*PullRequest) [][]*PullRequest {
+	heads := make(map[string]*PullRequest)
+	for _, pr := range prs {
+		if pr.HeadRef != "" {
+			heads[branchKey(pr.Owner, pr.Repo, pr.HeadRef)] = pr
+		}
+	}
+
+	below := make(map[*PullRequest]*PullRequest)
+	hasAbove := make(map[*PullRequest]bool)
+	for _, pr := range prs {
+		if pr.BaseRef == "" {
+			continue
+		}
+		if parent, ok := heads[branchKey(pr.Owner, pr.Repo, pr.BaseRef)]; ok && parent != pr {
+			below[pr] = parent
+			hasAbove[parent] = true
+		}
+	}
+
+	var stacks [][]*PullRequest
+	for _, pr := range prs {
+		if _, stacked := below[pr]; !stacked || hasAbove[pr] {
+			continue
+		}
+
+		// Walk down from the top, stopping if branches loop back on themselves
+		stack := []*PullRequest{pr}
+		seen := map[*PullRequest]bool{pr: true}
+		for next, ok := below[pr]; ok && !seen[next]; next, ok = below[next] {
+			stack = append(stack, next)
+			seen[next] = true
+		}
+		for i, j := 0, len(stack)-1; i < j; i, j = i+1, j-1 {
+			stack[i], stack[j] = stack[j], stack[i]
+		}
+		stacks = append(stacks, stack)
+	}
+	return stacks
+}
+
+func branchKey(owner, repo, ref string) string {
+	return strings.ToLower(owner+"/"+repo) + ":" + ref
+}
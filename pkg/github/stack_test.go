@@ -0,0 +1,63 @@
+package github
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-github/v73/github"
+)
+
+func stackPR(repo string, number int, base, head string) *PullRequest {
+	return &PullRequest{Owner: "acme", Repo: repo, Number: number, BaseRef: base, HeadRef: head}
+}
+
+func numbers(prs []*PullRequest) []int {
+	var nums []int
+	for _, pr := range prs {
+		nums = append(nums, pr.Number)
+	}
+	return nums
+}
+
+func TestStacks(t *testing.T) {
+	prs := []*PullRequest{
+		stackPR("app", 3, "feature-b", "feature-c"),
+		stackPR("app", 1, "main", "feature-a"),
+		stackPR("app", 2, "feature-a", "feature-b"),
+		stackPR("app", 4, "feature-a", "feature-d"), // Second branch off #1
+		stackPR("app", 5, "main", "lonely"),
+		stackPR("api", 6, "feature-a", "x"), // Same branch name, other repo
+		stackPR("app", 7, "loop-b", "loop-a"),
+		stackPR("app", 8, "loop-a", "loop-b"),
+	}
+
+	got := Stacks(prs)
+	want := [][]int{{1, 2, 3}, {1, 4}}
+	if len(got) != len(want) {
+		t.Fatalf("Stacks() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if nums := numbers(got[i]); !slices.Equal(nums, want[i]) {
+			t.Errorf("stack %d = %v, want %v", i, nums, want[i])
+		}
+	}
+}
+
+func TestSetHead(t *testing.T) {
+	details := &github.PullRequest{
+		Head: &github.PullRequestBranch{SHA: github.Ptr("abc"), Ref: github.Ptr("feature"), Repo: &github.Repository{FullName: github.Ptr("Acme/App")}},
+		Base: &github.PullRequestBranch{Ref: github.Ptr("main")},
+	}
+	pr := &PullRequest{Owner: "acme", Repo: "app"}
+	pr.setHead(details)
+	if pr.HeadSHA != "abc" || pr.BaseRef != "main" || pr.HeadRef != "feature" {
+		t.Errorf("setHead() = %q %q %q", pr.HeadSHA, pr.BaseRef, pr.HeadRef)
+	}
+
+	details.Head.Repo.FullName = github.Ptr("someone/app")
+	fork := &PullRequest{Owner: "acme", Repo: "app"}
+	fork.setHead(details)
+	if fork.HeadRef != "" {
+		t.Errorf("fork HeadRef = %q, want none", fork.HeadRef)
+	}
+}
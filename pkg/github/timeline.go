@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/go-github/v73/github"
+	"github.com/kennyp/speedrun/pkg/tracing"
+)
+
+// Timeline event kinds shown in the details popup
+const (
+	EventOpened      = "opened"
+	EventCommitted   = "committed"
+	EventForcePushed = "head_ref_force_pushed"
+	EventReviewed    = "reviewed"
+	EventLabeled     = "labeled"
+	EventUnlabeled   = "unlabeled"
+	EventReady       = "ready_for_review"
+	EventDraft       = "convert_to_draft"
+	EventClosed      = "closed"
+	EventReopened    = "reopened"
+	EventMerged      = "merged"
+)
+
+// timelineKinds are the issue timeline events worth showing; the rest
+// (mentions, references, subscriptions...) are noise for a reviewer
+var timelineKinds = map[string]bool{
+	EventCommitted:   true,
+	EventForcePushed: true,
+	EventReviewed:    true,
+	EventLabeled:     true,
+	EventUnlabeled:   true,
+	EventReady:       true,
+	EventDraft:       true,
+	EventClosed:      true,
+	EventReopened:    true,
+	EventMerged:      true,
+}
+
+// TimelineEvent is a key event in a PR's history
+type TimelineEvent struct {
+	Kind   string
+	Actor  string
+	At     time.Time
+	Detail string // Commit subject, review state or label name
+}
+
+// GetTimeline returns the PR's key events, oldest first, starting with it
+// being opened
+func (pr *PullRequest) GetTimeline(ctx context.Context) ([]TimelineEvent, error) {
+	if pr.client == nil {
+		return nil, fmt.Errorf("PR client is nil")
+	}
+
+	ctx, span := tracing.Start(ctx, "github.GetTimeline", pr.spanAttributes()...)
+	defer span.End()
+
+	start := time.Now()
+	events := []TimelineEvent{{Kind: EventOpened, Actor: pr.GetAuthor(), At: pr.CreatedAt}}
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		var page []*github.Timeline
+		var resp *github.Response
+		operation := func() error {
+			var err error
+			page, resp, err = pr.client.client.Issues.ListIssueTimeline(ctx, pr.Owner, pr.Repo, pr.Number, opts)
+			return err
+		}
+
+		exponentialBackoff := pr.client.backoffConfig.ToExponentialBackoff()
+		if err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx)); err != nil {
+			span.RecordError(err)
+			slog.Error("GitHub API list timeline failed", slog.Any("pr", pr), slog.Duration("duration", time.Since(start)), slog.Any("error", err))
+			return nil, fmt.Errorf("failed to list timeline: %w", err)
+		}
+
+		for _, item := range page {
+			if event, ok := timelineEvent(item); ok {
+				events = append(events, event)
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	slog.Debug("GitHub API list timeline completed", slog.Any("pr", pr), slog.Int("count", len(events)), slog.Duration("duration", time.Since(start)))
+	return events, nil
+}
+
+// timelineEvent converts a timeline item, reporting false for kinds that
+// aren't shown
+func timelineEvent(item *github.Timeline) (TimelineEvent, bool) {
+	kind := item.GetEvent()
+	if !timelineKinds[kind] {
+		return TimelineEvent{}, false
+	}
+
+	event := TimelineEvent{Kind: kind, Actor: item.GetActor().GetLogin(), At: item.GetCreatedAt().Time}
+	switch kind {
+	case EventCommitted:
+		// Commits carry git identities rather than a GitHub actor
+		event.Actor = item.GetAuthor().GetName()
+		event.At = item.GetCommitter().GetDate().Time
+		event.Detail, _, _ = strings.Cut(item.GetMessage(), "\n")
+	case EventReviewed:
+		event.Actor = item.GetUser().GetLogin()
+		event.At = item.GetSubmittedAt().Time
+		event.Detail = item.GetState()
+	case EventLabeled, EventUnlabeled:
+		event.Detail = item.GetLabel().GetName()
+	}
+	return event, true
+}
+
+// ForcePushedSinceApproval reports whether the branch was force-pushed after
+// the most recent approval, so the approved code may no longer be what's there
+func ForcePushedSinceApproval(events []TimelineEvent) bool {
+	approved, pushed := false, false
+	for _, event := range events {
+		switch {
+		case event.Kind == EventReviewed && event.Detail == "approved":
+			approved, pushed = true, false
+		case event.Kind == EventForcePushed && approved:
+			pushed = true
+		}
+	}
+	return pushed
+}
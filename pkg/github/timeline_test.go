@@ -0,0 +1,79 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+)
+
+func TestGetTimeline(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/app/issues/5/timeline", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"event":"committed","sha":"abc","message":"Add widget\n\nLonger description","author":{"name":"Alice"},"committer":{"date":"2025-03-01T10:00:00Z"}},
+			{"event":"mentioned","actor":{"login":"bob"},"created_at":"2025-03-01T10:30:00Z"},
+			{"event":"reviewed","state":"approved","user":{"login":"bob"},"submitted_at":"2025-03-01T11:00:00Z"},
+			{"event":"labeled","actor":{"login":"alice"},"label":{"name":"ready"},"created_at":"2025-03-01T11:30:00Z"},
+			{"event":"head_ref_force_pushed","actor":{"login":"alice"},"created_at":"2025-03-01T12:00:00Z"}
+		]`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(srv.Client())
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	opened := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	pr := &PullRequest{
+		Owner: "acme", Repo: "app", Number: 5, CreatedAt: opened,
+		ghi:    &github.Issue{User: &github.User{Login: github.Ptr("alice")}},
+		client: &Client{client: gh, health: &health{}},
+	}
+
+	events, err := pr.GetTimeline(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []TimelineEvent{
+		{Kind: EventOpened, Actor: "alice", At: opened},
+		{Kind: EventCommitted, Actor: "Alice", At: time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC), Detail: "Add widget"},
+		{Kind: EventReviewed, Actor: "bob", At: time.Date(2025, 3, 1, 11, 0, 0, 0, time.UTC), Detail: "approved"},
+		{Kind: EventLabeled, Actor: "alice", At: time.Date(2025, 3, 1, 11, 30, 0, 0, time.UTC), Detail: "ready"},
+		{Kind: EventForcePushed, Actor: "alice", At: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("GetTimeline() = %+v, want %+v", events, want)
+	}
+	for i := range want {
+		if events[i].Kind != want[i].Kind || events[i].Actor != want[i].Actor || !events[i].At.Equal(want[i].At) || events[i].Detail != want[i].Detail {
+			t.Errorf("event %d = %+v, want %+v", i, events[i], want[i])
+		}
+	}
+}
+
+func TestForcePushedSinceApproval(t *testing.T) {
+	approve := TimelineEvent{Kind: EventReviewed, Detail: "approved"}
+	comment := TimelineEvent{Kind: EventReviewed, Detail: "commented"}
+	push := TimelineEvent{Kind: EventForcePushed}
+
+	tests := []struct {
+		name   string
+		events []TimelineEvent
+		want   bool
+	}{
+		{"no approval", []TimelineEvent{push}, false},
+		{"push before approval", []TimelineEvent{push, approve}, false},
+		{"push after approval", []TimelineEvent{approve, comment, push}, true},
+		{"approved again", []TimelineEvent{approve, push, approve}, false},
+	}
+	for _, tt := range tests {
+		if got := ForcePushedSinceApproval(tt.events); got != tt.want {
+			t.Errorf("%s: ForcePushedSinceApproval() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package github
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kennyp/speedrun/pkg/cache"
+	"github.com/kennyp/speedrun/pkg/tracing"
+)
+
+// spanAttributes identifies this PR on trace spans
+func (pr *PullRequest) spanAttributes() []tracing.Attribute {
+	return []tracing.Attribute{
+		tracing.String("github.repo", pr.Owner+"/"+pr.Repo),
+		tracing.Int("github.pr", pr.Number),
+	}
+}
+
+// cacheGet reads from the cache inside a span so cache time shows up in traces
+func (c *Client) cacheGet(ctx context.Context, key string, dest any) error {
+	_, span := tracing.Start(ctx, "cache.get", tracing.String("cache.key", key))
+	defer span.End()
+
+	err := c.cache.Get(key, dest)
+	span.SetAttributes(tracing.Bool("cache.hit", err == nil))
+	if err != nil && !errors.Is(err, cache.ErrCacheMiss) {
+		span.RecordError(err)
+	}
+	return err
+}
+
+// cacheSet writes to the cache inside a span
+func (c *Client) cacheSet(ctx context.Context, key string, value any) error {
+	_, span := tracing.Start(ctx, "cache.set", tracing.String("cache.key", key))
+	defer span.End()
+
+	err := c.cache.Set(key, value)
+	span.RecordError(err)
+	return err
+}
+
+// cacheDelete removes a key from the cache inside a span
+func (c *Client) cacheDelete(ctx context.Context, key string) error {
+	_, span := tracing.Start(ctx, "cache.delete", tracing.String("cache.key", key))
+	defer span.End()
+
+	err := c.cache.Delete(key)
+	span.RecordError(err)
+	return err
+}
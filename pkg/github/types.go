@@ -2,11 +2,21 @@ package github
 
 import "log/slog"
 
+// PRState is a pull request's lifecycle state
+type PRState string
+
+const (
+	PRStateOpen   PRState = "open"
+	PRStateClosed PRState = "closed"
+	PRStateMerged PRState = "merged"
+)
+
 // Review represents a PR review
 type Review struct {
-	State string
-	User  string
-	Body  string
+	State    string
+	User     string
+	Body     string
+	CommitID string // Head commit when the review was submitted
 }
 
 // LogValue implements slog.LogValuer for structured logging
@@ -14,6 +24,7 @@ func (r *Review) LogValue() slog.Value {
 	return slog.GroupValue(
 		slog.String("state", r.State),
 		slog.String("user", r.User),
+		slog.String("commit_id", r.CommitID),
 		slog.String("body_preview", truncateString(r.Body, 50)),
 	)
 }
@@ -40,6 +51,8 @@ type CheckDetail struct {
 	Status      string
 	Description string
 	URL         string
+	JobID       int64 // GitHub Actions job behind the check, 0 for other checks
+	Flaky       bool  // Often fails and then passes on a rerun
 }
 
 // LogValue implements slog.LogValuer for structured logging
@@ -57,6 +70,7 @@ type DiffStats struct {
 	Deletions int
 	Changes   int
 	Files     int
+	Excluded  []ExcludedFile // Binary, minified and generated files among Files
 }
 
 // LogValue implements slog.LogValuer for structured logging
@@ -0,0 +1,198 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/go-github/v73/github"
+)
+
+// Risk levels of workflow findings
+const (
+	WorkflowRiskHigh   = "HIGH"
+	WorkflowRiskMedium = "MEDIUM"
+)
+
+// WorkflowChange is a GitHub Actions workflow a PR changes
+type WorkflowChange struct {
+	Path   string
+	Before string // At the PR's base, empty for a new workflow
+	After  string // At the PR's head, empty for a removed workflow
+	Diff   string // The file's part of the PR's diff
+}
+
+// WorkflowRisk is something a workflow change lets CI do that it couldn't
+// before
+type WorkflowRisk struct {
+	Level  string // WorkflowRiskHigh or WorkflowRiskMedium
+	Reason string
+}
+
+// isWorkflow reports whether a file is a GitHub Actions workflow
+func isWorkflow(file string) bool {
+	ext := path.Ext(file)
+	return strings.HasPrefix(file, ".github/workflows/") && (ext == ".yml" || ext == ".yaml")
+}
+
+// GetWorkflowChanges returns the workflows a PR changes, as they are at its
+// base and head, for the AI agent
+func (c *Client) GetWorkflowChanges(ctx context.Context, owner, repo string, number int) ([]WorkflowChange, error) {
+	diff, err := c.getRawDiff(ctx, owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	var changes []WorkflowChange
+	for _, file := range splitDiff(diff) {
+		if isWorkflow(file.Path) {
+			changes = append(changes, WorkflowChange{Path: file.Path, Diff: file.Header + strings.Join(file.Hunks, "")})
+		}
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	var pr *github.PullRequest
+	operation := func() error {
+		var err error
+		pr, _, err = c.client.PullRequests.Get(ctx, owner, repo, number)
+		return err
+	}
+	exponentialBackoff := c.backoffConfig.ToExponentialBackoff()
+	if err := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, ctx)); err != nil {
+		return nil, fmt.Errorf("failed to get PR details: %w", err)
+	}
+
+	for i, change := range changes {
+		if changes[i].Before, err = c.workflowAt(ctx, owner, repo, change.Path, pr.GetBase().GetSHA()); err != nil {
+			return nil, err
+		}
+		if changes[i].After, err = c.workflowAt(ctx, owner, repo, change.Path, pr.GetHead().GetSHA()); err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+// workflowAt reads a workflow at ref, or returns "" if it isn't there
+func (c *Client) workflowAt(ctx context.Context, owner, repo, file, ref string) (string, error) {
+	content, err := c.getFileContent(ctx, owner, repo, file, ref)
+	if errors.Is(err, errNoFile) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get workflow %s: %w", file, err)
+	}
+	return content, nil
+}
+
+var (
+	workflowTrigger    = regexp.MustCompile(`\b(pull_request_target|workflow_run)\b`)
+	workflowWriteAll   = regexp.MustCompile(`(?m)^\s*permissions:\s*(write-all)\b`)
+	workflowWrite      = regexp.MustCompile(`(?m)^\s*(actions|attestations|checks|contents|deployments|discussions|id-token|issues|packages|pages|pull-requests|repository-projects|security-events|statuses):\s*write\b`)
+	workflowSecret     = regexp.MustCompile(`\bsecrets\.([A-Za-z_][A-Za-z0-9_]*)`)
+	workflowInherit    = regexp.MustCompile(`(?m)^\s*secrets:\s*(inherit)\b`)
+	workflowPRCheckout = regexp.MustCompile(`(github\.event\.pull_request\.head\.(sha|ref)|github\.head_ref|refs/pull/)`)
+	workflowInjection  = regexp.MustCompile(`\$\{\{\s*(github\.event\.(pull_request|issue|comment|review|review_comment|discussion)\.(title|body)|github\.event\.head_commit\.message|github\.head_ref)\s*\}\}`)
+	workflowUses       = regexp.MustCompile(`(?m)^[\s-]*uses:\s*["']?([^@\s"']+@[^\s"'#]+)`)
+	workflowSelfHosted = regexp.MustCompile(`\b(self-hosted)\b`)
+	commitSHA          = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+// Risks finds what the change lets the workflow do that it couldn't before:
+// privileged triggers, wider token permissions and secrets are high risk,
+// as are PRs' code running with them. Untrusted text in scripts, actions not
+// pinned to a commit and self-hosted runners are medium. Findings come from
+// the workflow's text, not a YAML parse, so they're leads to check rather
+// than certainties.
+func (w WorkflowChange) Risks() []WorkflowRisk {
+	if w.After == "" {
+		return nil
+	}
+	before, after := stripYAMLComments(w.Before), stripYAMLComments(w.After)
+	added := func(re *regexp.Regexp) []string {
+		old := matchSet(re, before)
+		var found []string
+		for _, m := range slices.Sorted(maps.Keys(matchSet(re, after))) {
+			if !old[m] {
+				found = append(found, m)
+			}
+		}
+		return found
+	}
+
+	var risks []WorkflowRisk
+	high := func(format string, args ...any) {
+		risks = append(risks, WorkflowRisk{Level: WorkflowRiskHigh, Reason: fmt.Sprintf(format, args...)})
+	}
+	medium := func(format string, args ...any) {
+		risks = append(risks, WorkflowRisk{Level: WorkflowRiskMedium, Reason: fmt.Sprintf(format, args...)})
+	}
+
+	for _, trigger := range added(workflowTrigger) {
+		high("runs on %s, with the base repository's secrets and a write token even for PRs from forks", trigger)
+	}
+	privileged := matchSet(workflowTrigger, after)["pull_request_target"]
+	if privileged && len(matchSet(workflowPRCheckout, after)) > 0 &&
+		(!matchSet(workflowTrigger, before)["pull_request_target"] || len(matchSet(workflowPRCheckout, before)) == 0) {
+		high("checks out or refers to the PR's own code in a pull_request_target workflow, so a fork's code could run with its secrets")
+	}
+	if len(added(workflowWriteAll)) > 0 {
+		high("grants the token write access to everything (permissions: write-all)")
+	}
+	if scopes := added(workflowWrite); len(scopes) > 0 {
+		high("grants the token write access to %s", strings.Join(scopes, ", "))
+	}
+	if secrets := added(workflowSecret); len(secrets) > 0 {
+		high("uses secrets it didn't before: %s", strings.Join(secrets, ", "))
+	}
+	if len(added(workflowInherit)) > 0 {
+		high("passes all of the caller's secrets to a reusable workflow (secrets: inherit)")
+	}
+	if inputs := added(workflowInjection); len(inputs) > 0 {
+		medium("puts text anyone opening a PR or issue controls straight into the workflow (%s), where it can inject commands into a run step", strings.Join(inputs, ", "))
+	}
+	var unpinned []string
+	for _, action := range added(workflowUses) {
+		name, ref, _ := strings.Cut(action, "@")
+		owner, _, _ := strings.Cut(name, "/")
+		if strings.HasPrefix(name, "./") || strings.HasPrefix(name, "docker://") || owner == "actions" || owner == "github" || commitSHA.MatchString(ref) {
+			continue
+		}
+		unpinned = append(unpinned, action)
+	}
+	if len(unpinned) > 0 {
+		medium("uses third-party actions not pinned to a commit SHA, which their owners can change under it: %s", strings.Join(unpinned, ", "))
+	}
+	if len(added(workflowSelfHosted)) > 0 {
+		medium("runs on self-hosted runners, which may keep state or network access between jobs")
+	}
+	return risks
+}
+
+// matchSet returns the first group of each of re's matches in text
+func matchSet(re *regexp.Regexp, text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, m := range re.FindAllStringSubmatch(text, -1) {
+		set[m[1]] = true
+	}
+	return set
+}
+
+// stripYAMLComments drops comment lines, so commented out steps don't count
+func stripYAMLComments(text string) string {
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
@@ -0,0 +1,138 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	backoffconfig "github.com/kennyp/speedrun/pkg/backoff"
+)
+
+const baseWorkflow = `name: CI
+on: [pull_request]
+permissions:
+  contents: read
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: make test
+        env:
+          TOKEN: ${{ secrets.NPM_TOKEN }}
+`
+
+func TestWorkflowRisks(t *testing.T) {
+	after := `name: CI
+on: [pull_request_target]
+permissions:
+  contents: write
+  id-token: write
+jobs:
+  test:
+    runs-on: [self-hosted, linux]
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          ref: ${{ github.event.pull_request.head.sha }}
+      - uses: someone/deploy-action@main
+      - uses: someone/pinned-action@0123456789abcdef0123456789abcdef01234567
+      - run: echo "${{ github.event.pull_request.title }}"
+      - run: make test
+        env:
+          TOKEN: ${{ secrets.NPM_TOKEN }}
+          DEPLOY: ${{ secrets.DEPLOY_KEY }}
+      # - run: echo ${{ secrets.COMMENTED_OUT }}
+`
+	risks := WorkflowChange{Path: ".github/workflows/ci.yml", Before: baseWorkflow, After: after}.Risks()
+
+	var got []string
+	for _, risk := range risks {
+		got = append(got, risk.Level+": "+risk.Reason)
+	}
+	joined := strings.Join(got, "\n")
+	for _, want := range []string{
+		"HIGH: runs on pull_request_target",
+		"HIGH: checks out or refers to the PR's own code",
+		"HIGH: grants the token write access to contents, id-token",
+		"HIGH: uses secrets it didn't before: DEPLOY_KEY\n",
+		"MEDIUM: puts text anyone opening a PR or issue controls straight into the workflow (github.event.pull_request.title)",
+		"MEDIUM: uses third-party actions not pinned to a commit SHA, which their owners can change under it: someone/deploy-action@main\n",
+		"MEDIUM: runs on self-hosted runners",
+	} {
+		if !strings.Contains(joined+"\n", want) {
+			t.Errorf("risks missing %q:\n%s", want, joined)
+		}
+	}
+	if len(risks) != 7 {
+		t.Errorf("got %d risks, want 7:\n%s", len(risks), joined)
+	}
+
+	// What the workflow could already do isn't flagged again
+	if risks := (WorkflowChange{Before: after, After: after + "      - run: make lint\n"}).Risks(); len(risks) != 0 {
+		t.Errorf("unchanged privileges flagged: %+v", risks)
+	}
+	if risks := (WorkflowChange{Before: baseWorkflow}).Risks(); len(risks) != 0 {
+		t.Errorf("removed workflow flagged: %+v", risks)
+	}
+}
+
+func TestGetWorkflowChanges(t *testing.T) {
+	diff := `diff --git a/.github/workflows/release.yml b/.github/workflows/release.yml
+new file mode 100644
+--- /dev/null
++++ b/.github/workflows/release.yml
+@@ -0,0 +1,2 @@
++on: workflow_run
++jobs: {}
+diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1 +1 @@
+-package old
++package main
+`
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/app/pulls/9", func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.Header.Get("Accept"), "diff") {
+			fmt.Fprint(w, diff)
+			return
+		}
+		fmt.Fprint(w, `{"number": 9, "base": {"sha": "base1"}, "head": {"sha": "head1"}}`)
+	})
+	mux.HandleFunc("/repos/acme/app/contents/.github/workflows/release.yml", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ref") != "head1" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"type": "file", "encoding": "base64", "content": %q}`, base64.StdEncoding.EncodeToString([]byte("on: workflow_run\njobs: {}\n")))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(srv.Client())
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &Client{client: gh, backoffConfig: backoffconfig.Config{MaxElapsedTime: time.Second}, health: &health{}}
+
+	changes, err := c.GetWorkflowChanges(context.Background(), "acme", "app", 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("GetWorkflowChanges() = %+v, want the one workflow", changes)
+	}
+	change := changes[0]
+	if change.Path != ".github/workflows/release.yml" || change.Before != "" || change.After != "on: workflow_run\njobs: {}\n" || !strings.Contains(change.Diff, "+on: workflow_run") {
+		t.Errorf("change = %+v", change)
+	}
+	if risks := change.Risks(); len(risks) != 1 || risks[0].Level != WorkflowRiskHigh {
+		t.Errorf("risks = %+v, want the workflow_run trigger", risks)
+	}
+}
@@ -0,0 +1,239 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// Outcome identifies the kind of review activity recorded
+type Outcome string
+
+const (
+	Approved         Outcome = "approved"
+	AutoMergeEnabled Outcome = "auto_merge_enabled"
+	Merged           Outcome = "merged"
+	Dismissed        Outcome = "dismissed"
+	AIRecommendation Outcome = "ai_recommendation"
+	Session          Outcome = "session" // A TUI session ended; Duration is its length
+)
+
+// Event is a single entry in the review history
+type Event struct {
+	ID        int64     `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	User      string    `json:"user"`
+	Repo      string    `json:"repo"` // owner/repo
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	Outcome   Outcome   `json:"outcome"`
+	Detail    string    `json:"detail,omitempty"` // e.g. AI recommendation and risk level
+
+	// Time spent on the PR, from selecting it to the action, or the length
+	// of a session
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// LogValue implements slog.LogValuer for structured logging
+func (e Event) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("repo", e.Repo),
+		slog.Int("number", e.Number),
+		slog.String("outcome", string(e.Outcome)),
+		slog.String("detail", e.Detail),
+		slog.Duration("duration", e.Duration),
+	)
+}
+
+// Recorder records review activity
+type Recorder interface {
+	Record(ctx context.Context, event Event) error
+	Close() error
+}
+
+// Query filters history lookups. Zero values match everything.
+type Query struct {
+	Repo    string
+	Outcome Outcome
+	Since   time.Time
+	Until   time.Time
+	Limit   int
+}
+
+// Ensure Store implements Recorder interface
+var _ Recorder = (*Store)(nil)
+
+// Store is a SQLite-backed history of review activity
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens (creating if needed) the history database at dbPath
+func Open(dbPath string) (*Store, error) {
+	slog.Debug("Opening history database", "path", dbPath)
+
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath+"?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history database: %w", err)
+	}
+
+	query := `
+		CREATE TABLE IF NOT EXISTS events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			timestamp INTEGER NOT NULL,
+			user TEXT NOT NULL,
+			repo TEXT NOT NULL,
+			number INTEGER NOT NULL,
+			title TEXT NOT NULL,
+			outcome TEXT NOT NULL,
+			detail TEXT NOT NULL DEFAULT '',
+			duration_ms INTEGER NOT NULL DEFAULT 0
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_events_timestamp ON events(timestamp);
+		CREATE INDEX IF NOT EXISTS idx_events_repo ON events(repo);
+	`
+	if _, err := db.Exec(query); err != nil {
+		_ = db.Close() // Ignore close error since we're already in error state
+		return nil, fmt.Errorf("failed to create history table: %w", err)
+	}
+	if err := migrate(db); err != nil {
+		_ = db.Close() // Ignore close error since we're already in error state
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// migrate adds the columns that databases created by older versions lack
+func migrate(db *sql.DB) error {
+	var found int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('events') WHERE name = 'duration_ms'`).Scan(&found); err != nil {
+		return fmt.Errorf("failed to inspect history table: %w", err)
+	}
+	if found == 0 {
+		if _, err := db.Exec(`ALTER TABLE events ADD COLUMN duration_ms INTEGER NOT NULL DEFAULT 0`); err != nil {
+			return fmt.Errorf("failed to add duration to history table: %w", err)
+		}
+	}
+	return nil
+}
+
+// Record appends an event. A zero Timestamp is replaced with the current time.
+func (s *Store) Record(ctx context.Context, event Event) error {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO events (timestamp, user, repo, number, title, outcome, detail, duration_ms)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, event.Timestamp.UnixMilli(), event.User, event.Repo, event.Number, event.Title, string(event.Outcome), event.Detail, event.Duration.Milliseconds())
+	if err != nil {
+		return fmt.Errorf("failed to record history event: %w", err)
+	}
+
+	slog.Debug("Recorded history event", slog.Any("event", event))
+	return nil
+}
+
+// Query returns events matching q, newest first
+func (s *Store) Query(ctx context.Context, q Query) ([]Event, error) {
+	var where []string
+	var args []any
+
+	if q.Repo != "" {
+		where = append(where, "repo = ?")
+		args = append(args, q.Repo)
+	}
+	if q.Outcome != "" {
+		where = append(where, "outcome = ?")
+		args = append(args, string(q.Outcome))
+	}
+	if !q.Since.IsZero() {
+		where = append(where, "timestamp >= ?")
+		args = append(args, q.Since.UnixMilli())
+	}
+	if !q.Until.IsZero() {
+		where = append(where, "timestamp < ?")
+		args = append(args, q.Until.UnixMilli())
+	}
+
+	query := "SELECT id, timestamp, user, repo, number, title, outcome, detail, duration_ms FROM events"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY timestamp DESC, id DESC"
+	if q.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", q.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Debug("Failed to close history rows", slog.Any("error", err))
+		}
+	}()
+
+	var events []Event
+	for rows.Next() {
+		var e Event
+		var ts, durationMS int64
+		var outcome string
+		if err := rows.Scan(&e.ID, &ts, &e.User, &e.Repo, &e.Number, &e.Title, &outcome, &e.Detail, &durationMS); err != nil {
+			return nil, fmt.Errorf("failed to read history event: %w", err)
+		}
+		e.Timestamp = time.UnixMilli(ts)
+		e.Duration = time.Duration(durationMS) * time.Millisecond
+		e.Outcome = Outcome(outcome)
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	return events, nil
+}
+
+// Close closes the history database
+func (s *Store) Close() error {
+	if s.db != nil {
+		return s.db.Close()
+	}
+	return nil
+}
+
+// NoOpRecorder discards all events. Used when history is disabled.
+type NoOpRecorder struct{}
+
+// NewNoOpRecorder creates a recorder that does nothing
+func NewNoOpRecorder() Recorder {
+	return &NoOpRecorder{}
+}
+
+// Record always succeeds without storing anything
+func (n *NoOpRecorder) Record(ctx context.Context, event Event) error {
+	return nil
+}
+
+// Close always succeeds without doing anything
+func (n *NoOpRecorder) Close() error {
+	return nil
+}
+
+// Ensure NoOpRecorder implements Recorder interface
+var _ Recorder = (*NoOpRecorder)(nil)
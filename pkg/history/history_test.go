@@ -0,0 +1,108 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoreQueryFilters(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	events := []Event{
+		{Timestamp: now.Add(-48 * time.Hour), Repo: "o/a", Number: 1, Outcome: Approved},
+		{Timestamp: now.Add(-2 * time.Hour), Repo: "o/a", Number: 2, Outcome: Merged},
+		{Timestamp: now.Add(-1 * time.Hour), Repo: "o/b", Number: 3, Outcome: Approved},
+	}
+	for _, e := range events {
+		if err := store.Record(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query Query
+		want  []int
+	}{
+		{"all newest first", Query{}, []int{3, 2, 1}},
+		{"by repo", Query{Repo: "o/a"}, []int{2, 1}},
+		{"by outcome", Query{Outcome: Approved}, []int{3, 1}},
+		{"since", Query{Since: now.Add(-24 * time.Hour)}, []int{3, 2}},
+		{"until", Query{Until: now.Add(-24 * time.Hour)}, []int{1}},
+		{"limit", Query{Limit: 1}, []int{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.Query(ctx, tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var numbers []int
+			for _, e := range got {
+				numbers = append(numbers, e.Number)
+			}
+			if len(numbers) != len(tt.want) {
+				t.Fatalf("got %v, want %v", numbers, tt.want)
+			}
+			for i := range numbers {
+				if numbers[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", numbers, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestStoreDuration(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "history.db")
+
+	// A database from before durations were kept
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`
+		CREATE TABLE events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			timestamp INTEGER NOT NULL,
+			user TEXT NOT NULL,
+			repo TEXT NOT NULL,
+			number INTEGER NOT NULL,
+			title TEXT NOT NULL,
+			outcome TEXT NOT NULL,
+			detail TEXT NOT NULL DEFAULT ''
+		);
+		INSERT INTO events (timestamp, user, repo, number, title, outcome) VALUES (1, 'me', 'o/a', 1, 'old', 'approved');
+	`); err != nil {
+		t.Fatal(err)
+	}
+	_ = db.Close()
+
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	if err := store.Record(ctx, Event{Repo: "o/a", Number: 2, Outcome: Approved, Duration: 90 * time.Second}); err != nil {
+		t.Fatal(err)
+	}
+	events, err := store.Query(ctx, Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Duration != 90*time.Second || events[1].Duration != 0 {
+		t.Errorf("Query() = %+v, want the new event's duration and none for the old one", events)
+	}
+}
@@ -0,0 +1,96 @@
+// Package hooks runs user-configured shell commands when something happens
+// to a PR, with the PR described in SPEEDRUN_* environment variables.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is a point in a PR's review that a hook can run on
+type Event string
+
+const (
+	Approve          Event = "on_approve"
+	Merge            Event = "on_merge" // Merged, or auto-merge enabled
+	AnalysisComplete Event = "on_analysis_complete"
+)
+
+// Hooks are the commands to run on each event
+type Hooks struct {
+	Commands map[Event]string
+	Timeout  time.Duration
+}
+
+// PR describes the PR a hook runs for
+type PR struct {
+	Owner          string
+	Repo           string
+	Number         int
+	Title          string
+	URL            string
+	Author         string
+	HeadSHA        string
+	User           string // Who is running speedrun
+	Recommendation string // The AI's recommendation, if analyzed
+	Risk           string
+}
+
+// Env returns the hook's environment variables for an event
+func (pr PR) Env(event Event) []string {
+	return []string{
+		"SPEEDRUN_EVENT=" + string(event),
+		"SPEEDRUN_PR_OWNER=" + pr.Owner,
+		"SPEEDRUN_PR_REPO=" + pr.Repo,
+		"SPEEDRUN_PR_NUMBER=" + strconv.Itoa(pr.Number),
+		"SPEEDRUN_PR_TITLE=" + pr.Title,
+		"SPEEDRUN_PR_URL=" + pr.URL,
+		"SPEEDRUN_PR_AUTHOR=" + pr.Author,
+		"SPEEDRUN_PR_HEAD_SHA=" + pr.HeadSHA,
+		"SPEEDRUN_USER=" + pr.User,
+		"SPEEDRUN_RECOMMENDATION=" + pr.Recommendation,
+		"SPEEDRUN_RISK=" + pr.Risk,
+	}
+}
+
+// Run runs the event's command, if one is configured, through the shell. A
+// command that exits non-zero fails with its output.
+func (h Hooks) Run(ctx context.Context, event Event, pr PR) error {
+	command := h.Commands[event]
+	if command == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.Timeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Env = append(os.Environ(), pr.Env(event)...)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	start := time.Now()
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(output.String()); msg != "" {
+			return fmt.Errorf("%s hook failed: %w: %s", event, err, msg)
+		}
+		return fmt.Errorf("%s hook failed: %w", event, err)
+	}
+
+	slog.Debug("Hook ran", slog.String("event", string(event)), slog.String("pr", fmt.Sprintf("%s/%s#%d", pr.Owner, pr.Repo, pr.Number)), slog.Duration("duration", time.Since(start)))
+	return nil
+}
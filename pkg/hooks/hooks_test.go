@@ -0,0 +1,46 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks in tests are shell commands")
+	}
+	out := filepath.Join(t.TempDir(), "out")
+	h := Hooks{
+		Commands: map[Event]string{
+			Approve: `echo "$SPEEDRUN_EVENT $SPEEDRUN_PR_OWNER/$SPEEDRUN_PR_REPO#$SPEEDRUN_PR_NUMBER by $SPEEDRUN_USER" > ` + out,
+			Merge:   `echo "deploy blocked" >&2; exit 3`,
+		},
+		Timeout: 5 * time.Second,
+	}
+	pr := PR{Owner: "acme", Repo: "api", Number: 42, User: "me"}
+
+	if err := h.Run(context.Background(), Approve, pr); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(string(got)) != "on_approve acme/api#42 by me" {
+		t.Errorf("hook saw %q", got)
+	}
+
+	err = h.Run(context.Background(), Merge, pr)
+	if err == nil || !strings.Contains(err.Error(), "deploy blocked") {
+		t.Errorf("failing hook error = %v", err)
+	}
+
+	if err := h.Run(context.Background(), AnalysisComplete, pr); err != nil {
+		t.Errorf("unconfigured hook failed: %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package logbuffer
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry is a single captured log line
+type Entry struct {
+	Time  time.Time
+	Level slog.Level
+	Line  string // Formatted by slog.TextHandler, without the trailing newline
+}
+
+// Buffer keeps the most recent log lines in memory so they can be shown
+// inside the TUI
+type Buffer struct {
+	mu       sync.Mutex
+	entries  []Entry
+	next     int    // Index the next entry is written to once full
+	written  uint64 // Total entries ever written, used to detect changes
+	capacity int
+}
+
+// New creates a buffer holding up to capacity lines
+func New(capacity int) *Buffer {
+	return &Buffer{
+		entries:  make([]Entry, 0, capacity),
+		capacity: capacity,
+	}
+}
+
+// Write implements io.Writer for slog.TextHandler output. Each call carries
+// exactly one formatted record.
+func (b *Buffer) Write(p []byte) (int, error) {
+	line := strings.TrimRight(string(p), "\n")
+	entry := Entry{
+		Time:  time.Now(),
+		Level: parseLevel(line),
+		Line:  line,
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.entries) < b.capacity {
+		b.entries = append(b.entries, entry)
+	} else if b.capacity > 0 {
+		b.entries[b.next] = entry
+		b.next = (b.next + 1) % b.capacity
+	}
+	b.written++
+
+	return len(p), nil
+}
+
+// Entries returns the captured lines at or above minLevel, oldest first
+func (b *Buffer) Entries(minLevel slog.Level) []Entry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	out := make([]Entry, 0, len(b.entries))
+	for i := range b.entries {
+		entry := b.entries[(b.next+i)%len(b.entries)]
+		if entry.Level >= minLevel {
+			out = append(out, entry)
+		}
+	}
+	return out
+}
+
+// Written returns the total number of lines ever written
+func (b *Buffer) Written() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.written
+}
+
+// Wrap returns a handler that sends records to h and also captures every
+// record (including debug) in the buffer, regardless of h's level
+func (b *Buffer) Wrap(h slog.Handler) slog.Handler {
+	return &teeHandler{
+		primary: h,
+		capture: slog.NewTextHandler(b, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	}
+}
+
+// teeHandler fans records out to the configured handler and the buffer
+type teeHandler struct {
+	primary slog.Handler
+	capture slog.Handler
+}
+
+func (t *teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return t.primary.Enabled(ctx, level) || t.capture.Enabled(ctx, level)
+}
+
+func (t *teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var err error
+	if t.primary.Enabled(ctx, r.Level) {
+		err = t.primary.Handle(ctx, r.Clone())
+	}
+	if t.capture.Enabled(ctx, r.Level) {
+		_ = t.capture.Handle(ctx, r) // The buffer never fails
+	}
+	return err
+}
+
+func (t *teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &teeHandler{primary: t.primary.WithAttrs(attrs), capture: t.capture.WithAttrs(attrs)}
+}
+
+func (t *teeHandler) WithGroup(name string) slog.Handler {
+	return &teeHandler{primary: t.primary.WithGroup(name), capture: t.capture.WithGroup(name)}
+}
+
+// parseLevel extracts the level=... field written by slog.TextHandler
+func parseLevel(line string) slog.Level {
+	_, rest, ok := strings.Cut(line, "level=")
+	if !ok {
+		return slog.LevelInfo
+	}
+	if i := strings.IndexByte(rest, ' '); i >= 0 {
+		rest = rest[:i]
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText(bytes.TrimSpace([]byte(rest))); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
@@ -0,0 +1,52 @@
+package logbuffer
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestBufferKeepsMostRecentLines(t *testing.T) {
+	b := New(3)
+	for _, line := range []string{"a", "b", "c", "d", "e"} {
+		if _, err := b.Write([]byte("level=INFO msg=" + line + "\n")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got []string
+	for _, e := range b.Entries(slog.LevelDebug) {
+		got = append(got, strings.TrimPrefix(e.Line, "level=INFO msg="))
+	}
+	if strings.Join(got, ",") != "c,d,e" {
+		t.Errorf("entries = %v, want [c d e]", got)
+	}
+	if b.Written() != 5 {
+		t.Errorf("Written = %d, want 5", b.Written())
+	}
+}
+
+func TestWrapCapturesDebugBelowPrimaryLevel(t *testing.T) {
+	var file bytes.Buffer
+	b := New(10)
+	logger := slog.New(b.Wrap(slog.NewTextHandler(&file, &slog.HandlerOptions{Level: slog.LevelInfo})))
+
+	logger.Debug("hidden from file")
+	logger.With("k", "v").Warn("visible everywhere")
+
+	if strings.Contains(file.String(), "hidden from file") {
+		t.Error("debug record reached the info-level handler")
+	}
+	if !strings.Contains(file.String(), "visible everywhere") {
+		t.Error("warn record missing from primary handler")
+	}
+
+	if got := len(b.Entries(slog.LevelDebug)); got != 2 {
+		t.Fatalf("captured %d entries, want 2", got)
+	}
+	warn := b.Entries(slog.LevelWarn)
+	if len(warn) != 1 || !strings.Contains(warn[0].Line, "k=v") {
+		t.Errorf("warn entries = %+v", warn)
+	}
+}
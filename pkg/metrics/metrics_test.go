@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCounterWrite(t *testing.T) {
+	c := &Counter{name: "test_total", help: "Test counter.", labels: []string{"kind"}, values: make(map[string]float64)}
+	c.Inc("b")
+	c.Add(2, `a"x`)
+
+	var buf bytes.Buffer
+	if err := c.write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP test_total Test counter.
+# TYPE test_total counter
+test_total{kind="a\"x"} 2
+test_total{kind="b"} 1
+`
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestHistogramWrite(t *testing.T) {
+	h := &Histogram{name: "test_seconds", help: "Test histogram.", buckets: []float64{0.5, 1}, series: make(map[string]*histogramSeries)}
+	h.Observe(0.2)
+	h.Observe(0.7)
+	h.Observe(3)
+
+	var buf bytes.Buffer
+	if err := h.write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{
+		`test_seconds_bucket{le="0.5"} 1`,
+		`test_seconds_bucket{le="1"} 2`,
+		`test_seconds_bucket{le="+Inf"} 3`,
+		`test_seconds_sum 3.9`,
+		`test_seconds_count 3`,
+	} {
+		if !strings.Contains(buf.String(), line+"\n") {
+			t.Errorf("missing %q in:\n%s", line, buf.String())
+		}
+	}
+}
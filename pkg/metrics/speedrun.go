@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Metrics exported by speedrun. They are always collected; they're only
+// exposed when a metrics listen address is configured.
+var (
+	GitHubRequests = NewCounter("speedrun_github_requests_total",
+		"GitHub API requests by HTTP method and response status.", "method", "status")
+	GitHubRequestDuration = NewHistogram("speedrun_github_request_duration_seconds",
+		"GitHub API request latency.", DefaultBuckets, "method")
+
+	AIRequests = NewCounter("speedrun_ai_requests_total",
+		"AI chat completion requests by outcome.", "status")
+	AIRequestDuration = NewHistogram("speedrun_ai_request_duration_seconds",
+		"AI chat completion latency.", DefaultBuckets)
+	AITokens = NewCounter("speedrun_ai_tokens_total",
+		"AI tokens consumed by type. Reasoning tokens are counted in completion too.", "type")
+
+	CacheLookups = NewCounter("speedrun_cache_lookups_total",
+		"Cache lookups by layer and result.", "layer", "result")
+
+	PRsProcessed = NewCounter("speedrun_prs_processed_total",
+		"Pull requests processed by action.", "action")
+)
+
+// Transport records GitHub request metrics for every round trip
+type Transport struct {
+	Base http.RoundTripper
+}
+
+// NewTransport wraps base (http.DefaultTransport if nil) with request metrics
+func NewTransport(base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{Base: base}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.Base.RoundTrip(req)
+	GitHubRequestDuration.ObserveSince(start, req.Method)
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	GitHubRequests.Inc(req.Method, status)
+
+	return resp, err
+}
@@ -0,0 +1,110 @@
+// Package plugin runs custom team actions on a PR. Like kubectl plugins, a
+// plugin is any executable named speedrun-<name> on the PATH; it gets the PR
+// as JSON on stdin and what it prints is shown as the result.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Prefix starts the name of every plugin executable
+const Prefix = "speedrun-"
+
+// Plugin is a discovered plugin executable
+type Plugin struct {
+	Name string // Name without the prefix, e.g. "page-owner"
+	Path string
+}
+
+// PR is what a plugin receives on stdin
+type PR struct {
+	Owner          string   `json:"owner"`
+	Repo           string   `json:"repo"`
+	Number         int      `json:"number"`
+	Title          string   `json:"title"`
+	URL            string   `json:"url"`
+	Author         string   `json:"author"`
+	Labels         []string `json:"labels"`
+	Body           string   `json:"body"`
+	HeadSHA        string   `json:"head_sha"`
+	BaseRef        string   `json:"base_ref"`
+	HeadRef        string   `json:"head_ref"`
+	Additions      int      `json:"additions"`
+	Deletions      int      `json:"deletions"`
+	ChangedFiles   []string `json:"changed_files"`
+	Checks         string   `json:"checks,omitempty"`         // success, failure, pending or error
+	Recommendation string   `json:"recommendation,omitempty"` // The AI's recommendation, if analyzed
+	Risk           string   `json:"risk,omitempty"`
+	User           string   `json:"user"`    // Who is running speedrun
+	DryRun         bool     `json:"dry_run"` // speedrun is in dry-run mode; plugins should only say what they would do
+}
+
+// Discover finds the plugins in a PATH-style list of directories. Where
+// several directories have a plugin of the same name, the first wins, as it
+// would when run from a shell.
+func Discover(path string) []Plugin {
+	seen := make(map[string]bool)
+	var plugins []Plugin
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := strings.CutPrefix(entry.Name(), Prefix)
+			if !ok || name == "" || seen[name] {
+				continue
+			}
+			full := filepath.Join(dir, entry.Name())
+			if info, err := os.Stat(full); err != nil || info.IsDir() || info.Mode()&0111 == 0 {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, Plugin{Name: name, Path: full})
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+// Run runs the plugin on a PR and returns its trimmed output. A plugin that
+// exits non-zero fails with what it wrote to stderr.
+func (p Plugin) Run(ctx context.Context, pr PR, timeout time.Duration) (string, error) {
+	input, err := json.Marshal(pr)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode PR for plugin %s: %w", p.Name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("plugin %s failed: %s", p.Name, msg)
+		}
+		return "", fmt.Errorf("plugin %s failed: %w", p.Name, err)
+	}
+
+	slog.Debug("Plugin ran", slog.String("plugin", p.Name), slog.String("pr", fmt.Sprintf("%s/%s#%d", pr.Owner, pr.Repo, pr.Number)), slog.Duration("duration", time.Since(start)))
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,64 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writePlugin(t *testing.T, dir, name, script string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	writePlugin(t, first, "speedrun-page-owner", "echo first")
+	writePlugin(t, second, "speedrun-page-owner", "echo second")
+	writePlugin(t, second, "speedrun-create-ticket", "true")
+	writePlugin(t, second, "other-tool", "true")
+	if err := os.WriteFile(filepath.Join(second, "speedrun-notes.txt"), []byte("not executable"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	plugins := Discover(first + string(os.PathListSeparator) + second)
+	var names []string
+	for _, p := range plugins {
+		names = append(names, p.Name)
+	}
+	if got := strings.Join(names, ","); got != "create-ticket,page-owner" {
+		t.Fatalf("Discover() = %s", got)
+	}
+	if dir := filepath.Dir(plugins[1].Path); dir != first {
+		t.Errorf("page-owner found in %s, want the first directory", dir)
+	}
+}
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins in tests are shell scripts")
+	}
+	dir := t.TempDir()
+	writePlugin(t, dir, "speedrun-echo", `grep -o '"number":[0-9]*'`)
+	writePlugin(t, dir, "speedrun-fail", `echo "no on-call for acme/api" >&2; exit 1`)
+	pr := PR{Owner: "acme", Repo: "api", Number: 42}
+
+	out, err := Plugin{Name: "echo", Path: filepath.Join(dir, "speedrun-echo")}.Run(context.Background(), pr, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != `"number":42` {
+		t.Errorf("Run() = %q", out)
+	}
+
+	_, err = Plugin{Name: "fail", Path: filepath.Join(dir, "speedrun-fail")}.Run(context.Background(), pr, 5*time.Second)
+	if err == nil || err.Error() != "plugin fail failed: no on-call for acme/api" {
+		t.Errorf("Run() error = %v", err)
+	}
+}
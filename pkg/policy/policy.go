@@ -0,0 +1,346 @@
+// Package policy evaluates merge gating rules written in a small DSL, e.g.
+//
+//	block merge if files ~ db/migrations/** and not business_hours
+//	allow approve|merge if author = dependabot[bot]
+//	block approve if risk = HIGH
+//
+// Rules are checked in order and the first rule that applies to an action
+// and whose conditions all hold decides; actions no rule matches are allowed.
+package policy
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Action is something a policy can gate
+type Action string
+
+const (
+	Approve Action = "approve"
+	Merge   Action = "merge"
+)
+
+// Facts describes the PR an action is about to be taken on
+type Facts struct {
+	Repo      string   // owner/repo
+	Author    string   // PR author login
+	Labels    []string // PR labels
+	Files     []string // Changed paths, nil when not loaded
+	Lines     int      // Lines added plus deleted
+	FileCount int      // Number of changed files
+	Risk      string   // AI risk level, empty when not analyzed
+	Checks    string   // Combined check state (success, failure, pending)
+	Time      time.Time
+}
+
+// Policy is a parsed list of rules
+type Policy struct {
+	rules []rule
+	hours hours
+}
+
+type rule struct {
+	text       string
+	block      bool
+	actions    []Action // nil applies to every action
+	conditions []condition
+}
+
+// condition reports whether it holds for facts, or an error when the facts
+// it needs are missing
+type condition func(f Facts, h hours) (bool, error)
+
+// hours is a daily window, as minutes since midnight, on weekdays
+type hours struct {
+	start, end int
+}
+
+// BlockedError is returned when a rule blocks an action
+type BlockedError struct {
+	Action Action
+	Rule   string
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("%s blocked by policy %q", e.Action, e.Rule)
+}
+
+// DefaultBusinessHours is used when no business hours are configured
+const DefaultBusinessHours = "09:00-17:00"
+
+// New parses rules and a business hours window such as "09:00-17:00"
+func New(rules []string, businessHours string) (*Policy, error) {
+	p := &Policy{}
+	if businessHours == "" {
+		businessHours = DefaultBusinessHours
+	}
+
+	var err error
+	if p.hours, err = parseHours(businessHours); err != nil {
+		return nil, err
+	}
+
+	for _, text := range rules {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		r, err := parseRule(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy %q: %w", text, err)
+		}
+		p.rules = append(p.rules, r)
+	}
+	return p, nil
+}
+
+// Check returns a *BlockedError if the policy blocks action, or an error if a
+// deciding rule needs facts that aren't available yet
+func (p *Policy) Check(action Action, f Facts) error {
+	if p == nil {
+		return nil
+	}
+	if f.Time.IsZero() {
+		f.Time = time.Now()
+	}
+
+	for _, r := range p.rules {
+		if r.actions != nil && !slices.Contains(r.actions, action) {
+			continue
+		}
+		ok, err := r.matches(f, p.hours)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate policy %q: %w", r.text, err)
+		}
+		if !ok {
+			continue
+		}
+		if r.block {
+			return &BlockedError{Action: action, Rule: r.text}
+		}
+		return nil
+	}
+	return nil
+}
+
+func (r rule) matches(f Facts, h hours) (bool, error) {
+	for _, cond := range r.conditions {
+		ok, err := cond(f, h)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// parseRule parses "<block|allow> <actions> [if <condition> [and ...]]"
+func parseRule(text string) (rule, error) {
+	head, conds, _ := strings.Cut(text, " if ")
+	fields := strings.Fields(head)
+	if len(fields) != 2 {
+		return rule{}, fmt.Errorf("want \"block|allow <actions> if <conditions>\"")
+	}
+
+	r := rule{text: text}
+	switch fields[0] {
+	case "block":
+		r.block = true
+	case "allow":
+	default:
+		return rule{}, fmt.Errorf("unknown effect %q (want block or allow)", fields[0])
+	}
+
+	if fields[1] != "*" {
+		for name := range strings.SplitSeq(fields[1], "|") {
+			action := Action(name)
+			if action != Approve && action != Merge {
+				return rule{}, fmt.Errorf("unknown action %q (want approve, merge or *)", name)
+			}
+			r.actions = append(r.actions, action)
+		}
+	}
+
+	if strings.TrimSpace(conds) == "" {
+		return r, nil
+	}
+	for term := range strings.SplitSeq(conds, " and ") {
+		cond, err := parseCondition(strings.TrimSpace(term))
+		if err != nil {
+			return rule{}, err
+		}
+		r.conditions = append(r.conditions, cond)
+	}
+	return r, nil
+}
+
+func parseCondition(term string) (condition, error) {
+	if rest, ok := strings.CutPrefix(term, "not "); ok {
+		cond, err := parseCondition(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, err
+		}
+		return func(f Facts, h hours) (bool, error) {
+			ok, err := cond(f, h)
+			return !ok, err
+		}, nil
+	}
+
+	switch term {
+	case "business_hours":
+		return func(f Facts, h hours) (bool, error) {
+			return h.contains(f.Time), nil
+		}, nil
+	case "weekend":
+		return func(f Facts, _ hours) (bool, error) {
+			return isWeekend(f.Time), nil
+		}, nil
+	}
+
+	fields := strings.Fields(term)
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("can't parse condition %q", term)
+	}
+	field, op, value := fields[0], fields[1], fields[2]
+
+	switch field {
+	case "files":
+		if op != "~" {
+			return nil, fmt.Errorf("files only supports ~")
+		}
+		re, err := compileGlob(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", value, err)
+		}
+		return func(f Facts, _ hours) (bool, error) {
+			if f.Files == nil {
+				return false, fmt.Errorf("changed files are not loaded yet")
+			}
+			return slices.ContainsFunc(f.Files, re.MatchString), nil
+		}, nil
+	case "repo":
+		if op != "~" && op != "=" {
+			return nil, fmt.Errorf("repo only supports ~ and =")
+		}
+		if _, err := path.Match(value, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", value, err)
+		}
+		return func(f Facts, _ hours) (bool, error) {
+			ok, _ := path.Match(value, f.Repo)
+			return ok, nil
+		}, nil
+	case "author", "label", "risk", "checks":
+		if op != "=" {
+			return nil, fmt.Errorf("%s only supports =", field)
+		}
+		return func(f Facts, _ hours) (bool, error) {
+			switch field {
+			case "author":
+				return strings.EqualFold(f.Author, value), nil
+			case "label":
+				return slices.ContainsFunc(f.Labels, func(l string) bool { return strings.EqualFold(l, value) }), nil
+			case "risk":
+				return strings.EqualFold(f.Risk, value), nil
+			default:
+				return strings.EqualFold(f.Checks, value), nil
+			}
+		}, nil
+	case "lines", "changed_files":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s needs a number, got %q", field, value)
+		}
+		compare, err := comparison(op)
+		if err != nil {
+			return nil, err
+		}
+		return func(f Facts, _ hours) (bool, error) {
+			if field == "lines" {
+				return compare(f.Lines, n), nil
+			}
+			return compare(f.FileCount, n), nil
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown field %q", field)
+}
+
+func comparison(op string) (func(a, b int) bool, error) {
+	switch op {
+	case ">":
+		return func(a, b int) bool { return a > b }, nil
+	case ">=":
+		return func(a, b int) bool { return a >= b }, nil
+	case "<":
+		return func(a, b int) bool { return a < b }, nil
+	case "<=":
+		return func(a, b int) bool { return a <= b }, nil
+	case "=":
+		return func(a, b int) bool { return a == b }, nil
+	}
+	return nil, fmt.Errorf("unknown operator %q", op)
+}
+
+// compileGlob converts a path glob, where ** matches across directories, to
+// an anchored regexp
+func compileGlob(pattern string) (*regexp.Regexp, error) {
+	var expr strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	return regexp.Compile("^" + expr.String() + "$")
+}
+
+func parseHours(window string) (hours, error) {
+	from, to, ok := strings.Cut(window, "-")
+	if !ok {
+		return hours{}, fmt.Errorf("invalid business hours %q (want HH:MM-HH:MM)", window)
+	}
+	start, err := parseClock(from)
+	if err != nil {
+		return hours{}, fmt.Errorf("invalid business hours %q: %w", window, err)
+	}
+	end, err := parseClock(to)
+	if err != nil {
+		return hours{}, fmt.Errorf("invalid business hours %q: %w", window, err)
+	}
+	return hours{start: start, end: end}, nil
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// contains reports whether t falls on a weekday within the window
+func (h hours) contains(t time.Time) bool {
+	if isWeekend(t) {
+		return false
+	}
+	minute := t.Hour()*60 + t.Minute()
+	return minute >= h.start && minute < h.end
+}
+
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
@@ -0,0 +1,87 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	p, err := New([]string{
+		"allow approve|merge if author = dependabot[bot]",
+		"block merge if files ~ db/migrations/** and not business_hours",
+		"block approve if risk = HIGH and repo ~ acme/*",
+		"block * if label = do-not-merge",
+		"block merge if lines > 1000",
+	}, "09:00-17:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tuesdayNoon := time.Date(2025, 7, 8, 12, 0, 0, 0, time.Local)
+	tuesdayNight := time.Date(2025, 7, 8, 22, 0, 0, 0, time.Local)
+	saturdayNoon := time.Date(2025, 7, 12, 12, 0, 0, 0, time.Local)
+	migration := []string{"app/main.go", "db/migrations/0042_users.sql"}
+
+	tests := []struct {
+		name    string
+		action  Action
+		facts   Facts
+		blocked bool
+	}{
+		{"migration in hours", Merge, Facts{Files: migration, Time: tuesdayNoon}, false},
+		{"migration at night", Merge, Facts{Files: migration, Time: tuesdayNight}, true},
+		{"migration on weekend", Merge, Facts{Files: migration, Time: saturdayNoon}, true},
+		{"migration approve at night", Approve, Facts{Files: migration, Time: tuesdayNight}, false},
+		{"dependabot migration at night", Merge, Facts{Author: "dependabot[bot]", Files: migration, Time: tuesdayNight}, false},
+		{"high risk in scope", Approve, Facts{Repo: "acme/api", Risk: "HIGH", Files: []string{}}, true},
+		{"high risk out of scope", Approve, Facts{Repo: "other/api", Risk: "HIGH", Files: []string{}}, false},
+		{"label blocks everything", Approve, Facts{Labels: []string{"Do-Not-Merge"}, Files: []string{}}, true},
+		{"too big to merge", Merge, Facts{Lines: 1001, Files: []string{}, Time: tuesdayNoon}, true},
+		{"small enough", Merge, Facts{Lines: 1000, Files: []string{}, Time: tuesdayNoon}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.Check(tt.action, tt.facts)
+			var blocked *BlockedError
+			if got := errors.As(err, &blocked); got != tt.blocked {
+				t.Errorf("Check() = %v, want blocked %v", err, tt.blocked)
+			}
+		})
+	}
+}
+
+func TestCheckMissingFiles(t *testing.T) {
+	p, err := New([]string{"block merge if files ~ db/**"}, "09:00-17:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = p.Check(Merge, Facts{})
+	var blocked *BlockedError
+	if err == nil || errors.As(err, &blocked) {
+		t.Errorf("Check() without files = %v, want evaluation error", err)
+	}
+	if err := p.Check(Approve, Facts{}); err != nil {
+		t.Errorf("Check() for unrelated action = %v", err)
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	for _, rule := range []string{
+		"deny merge",
+		"block deploy",
+		"block merge if size > 3",
+		"block merge if lines > many",
+		"block merge if files = db/**",
+		"block merge if lines >> 3",
+	} {
+		if _, err := New([]string{rule}, "09:00-17:00"); err == nil {
+			t.Errorf("New(%q) succeeded, want error", rule)
+		}
+	}
+
+	if _, err := New(nil, "9am-5pm"); err == nil {
+		t.Error("New() with invalid business hours succeeded")
+	}
+}
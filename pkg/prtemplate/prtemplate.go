@@ -0,0 +1,149 @@
+// Package prtemplate checks whether a PR description fills out the
+// repository's pull request template: its sections aren't left empty and its
+// checklist items are ticked.
+package prtemplate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	commentPattern    = regexp.MustCompile(`(?s)<!--.*?-->`)
+	headingPattern    = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*$`)
+	checkboxPattern   = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX])\]\s+(.+)$`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// Template is a repository's PR template
+type Template struct {
+	Sections  []Section // Sections to fill in, in template order
+	Checklist []string  // Checklist items to tick
+}
+
+// Section is a template heading and the placeholder text under it
+type Section struct {
+	Heading     string
+	Placeholder string // Text the template puts there, without comments
+}
+
+// Parse reads a markdown PR template. Sections holding only checklist items
+// are left for the checklist to cover.
+func Parse(markdown string) Template {
+	var t Template
+	for _, s := range sections(markdown) {
+		t.Checklist = append(t.Checklist, s.items...)
+		if s.heading != "" && (s.text != "" || len(s.items) == 0) {
+			t.Sections = append(t.Sections, Section{Heading: s.heading, Placeholder: s.text})
+		}
+	}
+	return t
+}
+
+// IsZero reports whether the template asks for nothing
+func (t Template) IsZero() bool {
+	return len(t.Sections) == 0 && len(t.Checklist) == 0
+}
+
+// Compliance is how well a PR description fills out the template
+type Compliance struct {
+	Sections  int      // Sections the template asks for
+	Empty     []string // Sections missing or left as the template has them
+	Checklist int      // Checklist items the template asks for
+	Unticked  []string // Checklist items missing or left unticked
+}
+
+// Complete reports whether every section is filled in and every item ticked
+func (c Compliance) Complete() bool {
+	return len(c.Empty) == 0 && len(c.Unticked) == 0
+}
+
+// String summarises what's missing, e.g. "Testing done empty, 1/3 unticked"
+func (c Compliance) String() string {
+	if c.Complete() {
+		return "complete"
+	}
+	var missing []string
+	if len(c.Empty) > 0 {
+		missing = append(missing, strings.Join(c.Empty, ", ")+" empty")
+	}
+	if len(c.Unticked) > 0 {
+		missing = append(missing, fmt.Sprintf("%d/%d unticked", len(c.Unticked), c.Checklist))
+	}
+	return strings.Join(missing, ", ")
+}
+
+// Check compares a PR description with the template. Headings and checklist
+// items are matched ignoring case and spacing.
+func Check(t Template, body string) Compliance {
+	c := Compliance{Sections: len(t.Sections), Checklist: len(t.Checklist)}
+
+	filled := make(map[string]string)
+	ticked := make(map[string]bool)
+	for _, s := range sections(body) {
+		filled[normalize(s.heading)] += s.text
+		for _, item := range s.ticked {
+			ticked[normalize(item)] = true
+		}
+	}
+
+	for _, s := range t.Sections {
+		text, ok := filled[normalize(s.Heading)]
+		if !ok || text == "" || normalize(text) == normalize(s.Placeholder) {
+			c.Empty = append(c.Empty, s.Heading)
+		}
+	}
+	for _, item := range t.Checklist {
+		if !ticked[normalize(item)] {
+			c.Unticked = append(c.Unticked, item)
+		}
+	}
+	return c
+}
+
+// section is the text under one heading, or before the first
+type section struct {
+	heading string
+	text    string   // Prose, without comments or checklist items
+	items   []string // Every checklist item
+	ticked  []string // Ticked checklist items
+}
+
+func sections(markdown string) []section {
+	markdown = commentPattern.ReplaceAllString(strings.ReplaceAll(markdown, "\r\n", "\n"), "")
+
+	all := []section{{}}
+	current := &all[0]
+	var text []string
+	flush := func() {
+		current.text = strings.TrimSpace(strings.Join(text, "\n"))
+		text = nil
+	}
+	for _, line := range strings.Split(markdown, "\n") {
+		if m := headingPattern.FindStringSubmatch(line); m != nil {
+			flush()
+			all = append(all, section{heading: m[1]})
+			current = &all[len(all)-1]
+			continue
+		}
+		if m := checkboxPattern.FindStringSubmatch(line); m != nil {
+			item := strings.TrimSpace(m[2])
+			current.items = append(current.items, item)
+			if m[1] != " " {
+				current.ticked = append(current.ticked, item)
+			}
+			continue
+		}
+		text = append(text, line)
+	}
+	flush()
+	return all
+}
+
+// normalize makes headings and items comparable
+func normalize(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.TrimRight(s, ":")
+	return whitespacePattern.ReplaceAllString(s, " ")
+}
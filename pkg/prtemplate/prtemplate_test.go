@@ -0,0 +1,94 @@
+package prtemplate
+
+import (
+	"slices"
+	"testing"
+)
+
+const template = `<!-- Thanks for contributing! -->
+## Summary
+
+<!-- What does this change and why? -->
+
+## Testing done
+
+Describe how you tested this.
+
+## Checklist
+
+- [ ] Tests added or updated
+- [ ] Docs updated
+`
+
+func TestParse(t *testing.T) {
+	tmpl := Parse(template)
+
+	want := []Section{{Heading: "Summary"}, {Heading: "Testing done", Placeholder: "Describe how you tested this."}}
+	if !slices.Equal(tmpl.Sections, want) {
+		t.Errorf("Sections = %+v, want %+v", tmpl.Sections, want)
+	}
+	if want := []string{"Tests added or updated", "Docs updated"}; !slices.Equal(tmpl.Checklist, want) {
+		t.Errorf("Checklist = %v, want %v", tmpl.Checklist, want)
+	}
+	if !Parse("Just some text").IsZero() {
+		t.Error("a template without headings or checklist items should ask for nothing")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tmpl := Parse(template)
+
+	tests := []struct {
+		name     string
+		body     string
+		empty    []string
+		unticked []string
+	}{
+		{
+			name: "filled out",
+			body: "## Summary\nAdds rate limiting.\n\n## Testing done:\nLoad tested on staging.\n\n## Checklist\n- [x] Tests added or updated\n- [X] docs  updated\n",
+		},
+		{
+			name:     "untouched template",
+			body:     template,
+			empty:    []string{"Summary", "Testing done"},
+			unticked: []string{"Tests added or updated", "Docs updated"},
+		},
+		{
+			name:     "no template at all",
+			body:     "Fixes the thing.",
+			empty:    []string{"Summary", "Testing done"},
+			unticked: []string{"Tests added or updated", "Docs updated"},
+		},
+		{
+			name:     "testing left out",
+			body:     "### summary\nAdds rate limiting.\n<!-- What does this change and why? -->\n## Testing done\n<!-- none -->\n- [x] Tests added or updated\n- [ ] Docs updated\n",
+			empty:    []string{"Testing done"},
+			unticked: []string{"Docs updated"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Check(tmpl, tt.body)
+			if !slices.Equal(c.Empty, tt.empty) {
+				t.Errorf("Empty = %v, want %v", c.Empty, tt.empty)
+			}
+			if !slices.Equal(c.Unticked, tt.unticked) {
+				t.Errorf("Unticked = %v, want %v", c.Unticked, tt.unticked)
+			}
+			if c.Complete() != (len(tt.empty) == 0 && len(tt.unticked) == 0) {
+				t.Errorf("Complete() = %v", c.Complete())
+			}
+		})
+	}
+}
+
+func TestComplianceString(t *testing.T) {
+	c := Compliance{Sections: 2, Empty: []string{"Testing done"}, Checklist: 3, Unticked: []string{"Docs updated"}}
+	if got, want := c.String(), "Testing done empty, 1/3 unticked"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := (Compliance{Sections: 2}).String(); got != "complete" {
+		t.Errorf("String() = %q, want complete", got)
+	}
+}
@@ -0,0 +1,192 @@
+// Package remind creates local reminders to revisit a PR, either as an
+// iCalendar file for the system calendar or as a line for remind(1).
+package remind
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Reminder methods
+const (
+	MethodICS    = "ics"
+	MethodRemind = "remind"
+)
+
+// Reminder is a note to revisit a PR at a time
+type Reminder struct {
+	Ref   string // owner/repo#number
+	Title string // PR title
+	URL   string // Link to the PR
+	At    time.Time
+}
+
+// Config says how reminders are created
+type Config struct {
+	Method     string // MethodICS or MethodRemind
+	Dir        string // Where .ics files are written
+	RemindFile string // File remind(1) reads, e.g. ~/.reminders
+	Open       bool   // Open .ics files with the system handler to import them
+}
+
+// Preset is a reminder time offered in the menu
+type Preset struct {
+	Label string
+	At    time.Time
+}
+
+// Presets returns the reminder times offered at now: in an hour, in four
+// hours, tomorrow morning and next Monday morning
+func Presets(now time.Time) []Preset {
+	morning := func(days int) time.Time {
+		d := now.AddDate(0, 0, days)
+		return time.Date(d.Year(), d.Month(), d.Day(), 9, 0, 0, 0, now.Location())
+	}
+	untilMonday := (int(time.Monday) - int(now.Weekday()) + 7) % 7
+	if untilMonday == 0 {
+		untilMonday = 7
+	}
+
+	return []Preset{
+		{Label: "In 1 hour", At: now.Add(time.Hour).Truncate(time.Minute)},
+		{Label: "In 4 hours", At: now.Add(4 * time.Hour).Truncate(time.Minute)},
+		{Label: "Tomorrow at 9:00", At: morning(1)},
+		{Label: "Monday at 9:00", At: morning(untilMonday)},
+	}
+}
+
+// Create records the reminder and returns where it went
+func Create(cfg Config, r Reminder) (string, error) {
+	switch cfg.Method {
+	case MethodICS, "":
+		return createICS(cfg, r)
+	case MethodRemind:
+		return createRemind(cfg, r)
+	}
+	return "", fmt.Errorf("unknown reminder method %q (want ics or remind)", cfg.Method)
+}
+
+func createICS(cfg Config, r Reminder) (string, error) {
+	dir, err := expandHome(cfg.Dir)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create reminder directory: %w", err)
+	}
+
+	name := strings.NewReplacer("/", "-", "#", "-").Replace(r.Ref)
+	path := filepath.Join(dir, fmt.Sprintf("speedrun-%s-%s.ics", name, r.At.Format("20060102-1504")))
+	if err := os.WriteFile(path, []byte(ICS(r, time.Now())), 0644); err != nil {
+		return "", fmt.Errorf("failed to write reminder: %w", err)
+	}
+
+	if cfg.Open {
+		if err := exec.Command("open", path).Run(); err != nil {
+			return path, fmt.Errorf("failed to open reminder: %w", err)
+		}
+	}
+	return path, nil
+}
+
+func createRemind(cfg Config, r Reminder) (string, error) {
+	path := cfg.RemindFile
+	if path == "" {
+		path = "~/.reminders"
+	}
+	path, err := expandHome(path)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return "", fmt.Errorf("failed to open reminders file: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if _, err := f.WriteString(RemindLine(r) + "\n"); err != nil {
+		return "", fmt.Errorf("failed to write reminder: %w", err)
+	}
+	return path, nil
+}
+
+// expandHome replaces a leading ~/ with the home directory
+func expandHome(path string) (string, error) {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, rest), nil
+}
+
+// ICS renders the reminder as a 15 minute iCalendar event with an alarm
+func ICS(r Reminder, now time.Time) string {
+	const stamp = "20060102T150405Z"
+	summary := fmt.Sprintf("Review %s: %s", r.Ref, r.Title)
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//kennyp//speedrun//EN",
+		"BEGIN:VEVENT",
+		fmt.Sprintf("UID:%s-%d@speedrun", strings.NewReplacer("/", "-", "#", "-").Replace(r.Ref), r.At.Unix()),
+		"DTSTAMP:" + now.UTC().Format(stamp),
+		"DTSTART:" + r.At.UTC().Format(stamp),
+		"DTEND:" + r.At.Add(15*time.Minute).UTC().Format(stamp),
+		"SUMMARY:" + escapeText(summary),
+		"DESCRIPTION:" + escapeText(r.URL),
+		"URL:" + r.URL,
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"DESCRIPTION:" + escapeText(summary),
+		"TRIGGER:PT0S",
+		"END:VALARM",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}
+
+	var out strings.Builder
+	for _, line := range lines {
+		out.WriteString(fold(line) + "\r\n")
+	}
+	return out.String()
+}
+
+// escapeText escapes an iCalendar TEXT value
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// fold splits lines longer than 75 octets, without breaking UTF-8 sequences
+func fold(line string) string {
+	var out strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > 75 {
+			out.WriteString("\r\n ")
+			width = 1
+		}
+		out.WriteRune(r)
+		width += size
+	}
+	return out.String()
+}
+
+// RemindLine renders the reminder as a remind(1) REM line
+func RemindLine(r Reminder) string {
+	msg := fmt.Sprintf("Review %s: %s %s", r.Ref, r.Title, r.URL)
+	msg = strings.NewReplacer("%", "%%", "[", `["["]`, "\n", " ").Replace(msg)
+	return fmt.Sprintf("REM %s AT %s MSG %s", r.At.Format("2006-01-02"), r.At.Format("15:04"), msg)
+}
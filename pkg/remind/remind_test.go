@@ -0,0 +1,104 @@
+package remind
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testReminder() Reminder {
+	return Reminder{
+		Ref:   "acme/api#12",
+		Title: "Fix 100% CPU; add [retry], backoff",
+		URL:   "https://github.com/acme/api/pull/12",
+		At:    time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestPresets(t *testing.T) {
+	// Wednesday afternoon
+	now := time.Date(2025, 3, 5, 14, 30, 45, 0, time.UTC)
+	want := []string{"2025-03-05 15:30", "2025-03-05 18:30", "2025-03-06 09:00", "2025-03-10 09:00"}
+
+	presets := Presets(now)
+	if len(presets) != len(want) {
+		t.Fatalf("Presets() returned %d presets, want %d", len(presets), len(want))
+	}
+	for i, preset := range presets {
+		if got := preset.At.Format("2006-01-02 15:04"); got != want[i] {
+			t.Errorf("%s = %s, want %s", preset.Label, got, want[i])
+		}
+	}
+
+	// On a Monday, "Monday" is next week
+	monday := Presets(time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC))[3].At
+	if got := monday.Format("2006-01-02"); got != "2025-03-17" {
+		t.Errorf("Monday preset on a Monday = %s, want 2025-03-17", got)
+	}
+}
+
+func TestICS(t *testing.T) {
+	got := ICS(testReminder(), time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC))
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART:20250304T090000Z\r\n",
+		"DTEND:20250304T091500Z\r\n",
+		`SUMMARY:Review acme/api#12: Fix 100% CPU\; add [retry]\, backoff` + "\r\n",
+		"URL:https://github.com/acme/api/pull/12\r\n",
+		"TRIGGER:PT0S\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ICS() missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("é", 60)
+	for _, part := range strings.Split(fold(line), "\r\n") {
+		if len(part) > 75 {
+			t.Errorf("folded line is %d octets: %q", len(part), part)
+		}
+	}
+	if got := strings.ReplaceAll(fold(line), "\r\n ", ""); got != line {
+		t.Errorf("unfolding gave %q, want %q", got, line)
+	}
+}
+
+func TestRemindLine(t *testing.T) {
+	want := `REM 2025-03-04 AT 09:00 MSG Review acme/api#12: Fix 100%% CPU; add ["["]retry], backoff https://github.com/acme/api/pull/12`
+	if got := RemindLine(testReminder()); got != want {
+		t.Errorf("RemindLine() = %q, want %q", got, want)
+	}
+}
+
+func TestCreateRemindAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reminders")
+	cfg := Config{Method: MethodRemind, RemindFile: path}
+	for range 2 {
+		if _, err := Create(cfg, testReminder()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 2 {
+		t.Errorf("reminders file has %d lines, want 2", lines)
+	}
+}
+
+func TestCreateICS(t *testing.T) {
+	path, err := Create(Config{Method: MethodICS, Dir: t.TempDir()}, testReminder())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := filepath.Base(path); got != "speedrun-acme-api-12-20250304-0900.ics" {
+		t.Errorf("Create() wrote %s", got)
+	}
+}
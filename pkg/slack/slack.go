@@ -0,0 +1,145 @@
+// Package slack verifies and answers Slack slash commands, so speedrun
+// serve can take /speedrun commands from a Slack app.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSkew is how old a request's timestamp can be, so captured requests
+// can't be replayed later
+const maxSkew = 5 * time.Minute
+
+// maxBody bounds a slash command request; Slack's are a few hundred bytes
+const maxBody = 64 << 10
+
+// ErrBadSignature is returned for requests that aren't signed by Slack with
+// the app's signing secret
+var ErrBadSignature = errors.New("invalid Slack request signature")
+
+// Command is a slash command invocation, e.g. /speedrun approve <url>
+type Command struct {
+	Command     string // The slash command, e.g. /speedrun
+	Text        string // Everything after the command
+	UserID      string
+	UserName    string
+	ChannelID   string
+	ResponseURL string // Where to send delayed responses
+}
+
+// Response answers a command, either in the HTTP response or later at its
+// response URL
+type Response struct {
+	ResponseType string `json:"response_type,omitempty"` // ephemeral (the default) or in_channel
+	Text         string `json:"text"`
+}
+
+// Ephemeral returns a response only the user who ran the command sees
+func Ephemeral(format string, args ...any) Response {
+	return Response{ResponseType: "ephemeral", Text: fmt.Sprintf(format, args...)}
+}
+
+// InChannel returns a response the whole channel sees
+func InChannel(format string, args ...any) Response {
+	return Response{ResponseType: "in_channel", Text: fmt.Sprintf(format, args...)}
+}
+
+// ParseCommand reads a slash command request, checking that Slack signed it
+// with secret
+func ParseCommand(r *http.Request, secret string, now time.Time) (Command, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBody))
+	if err != nil {
+		return Command{}, fmt.Errorf("failed to read Slack request: %w", err)
+	}
+	if err := Verify(secret, r.Header, body, now); err != nil {
+		return Command{}, err
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return Command{}, fmt.Errorf("failed to parse Slack request: %w", err)
+	}
+	return Command{
+		Command:     form.Get("command"),
+		Text:        strings.TrimSpace(form.Get("text")),
+		UserID:      form.Get("user_id"),
+		UserName:    form.Get("user_name"),
+		ChannelID:   form.Get("channel_id"),
+		ResponseURL: form.Get("response_url"),
+	}, nil
+}
+
+// Verify checks a request's X-Slack-Signature, which is v0= and the hex
+// HMAC-SHA256 of "v0:<timestamp>:<body>" keyed with the signing secret
+func Verify(secret string, header http.Header, body []byte, now time.Time) error {
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: missing timestamp", ErrBadSignature)
+	}
+	if skew := now.Sub(time.Unix(unix, 0)); skew > maxSkew || skew < -maxSkew {
+		return fmt.Errorf("%w: timestamp is %s off", ErrBadSignature, skew.Round(time.Second))
+	}
+
+	got, ok := strings.CutPrefix(header.Get("X-Slack-Signature"), "v0=")
+	if !ok {
+		return fmt.Errorf("%w: missing v0 signature", ErrBadSignature)
+	}
+	gotMAC, err := hex.DecodeString(got)
+	if err != nil || !hmac.Equal(gotMAC, sign(secret, timestamp, body)) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// Sign returns the X-Slack-Signature header value for a request body, as
+// Slack computes it
+func Sign(secret, timestamp string, body []byte) string {
+	return "v0=" + hex.EncodeToString(sign(secret, timestamp, body))
+}
+
+func sign(secret, timestamp string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%s:", timestamp)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// Respond sends a delayed response to a command's response URL over
+// transport (http.DefaultTransport if nil). Slack takes these for 30 minutes
+// after the command.
+func Respond(ctx context.Context, transport http.RoundTripper, responseURL string, resp Response) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Slack response: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, responseURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to send Slack response: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Transport: transport, Timeout: 10 * time.Second}
+	res, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Slack response: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to send Slack response: %s", res.Status)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package slack
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func signedRequest(secret string, at time.Time, form url.Values) *http.Request {
+	body := form.Encode()
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/slack/commands", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", Sign(secret, timestamp, []byte(body)))
+	return req
+}
+
+func TestParseCommand(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	form := url.Values{
+		"command":      {"/speedrun"},
+		"text":         {" approve acme/api#7 "},
+		"user_id":      {"U123"},
+		"user_name":    {"alice"},
+		"response_url": {"https://hooks.slack.com/commands/1"},
+	}
+
+	cmd, err := ParseCommand(signedRequest("shh", now, form), "shh", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd.Command != "/speedrun" || cmd.Text != "approve acme/api#7" || cmd.UserID != "U123" || cmd.ResponseURL != "https://hooks.slack.com/commands/1" {
+		t.Errorf("ParseCommand() = %+v", cmd)
+	}
+}
+
+func TestParseCommandRejectsBadSignatures(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	form := url.Values{"command": {"/speedrun"}, "text": {"queue"}}
+
+	tests := []struct {
+		name string
+		req  *http.Request
+	}{
+		{"wrong secret", signedRequest("other", now, form)},
+		{"stale", signedRequest("shh", now.Add(-10*time.Minute), form)},
+		{"unsigned", httptest.NewRequest(http.MethodPost, "/slack/commands", strings.NewReader(form.Encode()))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCommand(tt.req, "shh", now); !errors.Is(err, ErrBadSignature) {
+				t.Errorf("ParseCommand() error = %v, want %v", err, ErrBadSignature)
+			}
+		})
+	}
+
+	tampered := signedRequest("shh", now, form)
+	tampered.Body = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("command=%2Fspeedrun&text=approve")).Body
+	if _, err := ParseCommand(tampered, "shh", now); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("ParseCommand() of a tampered body error = %v, want %v", err, ErrBadSignature)
+	}
+}
+
+func TestRespond(t *testing.T) {
+	var got Response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	if err := Respond(t.Context(), nil, server.URL, InChannel("approved %s", "acme/api#7")); err != nil {
+		t.Fatal(err)
+	}
+	if got.ResponseType != "in_channel" || got.Text != "approved acme/api#7" {
+		t.Errorf("Respond() sent %+v", got)
+	}
+}
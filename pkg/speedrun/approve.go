@@ -0,0 +1,233 @@
+package speedrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/agent"
+	"github.com/kennyp/speedrun/pkg/checklist"
+	"github.com/kennyp/speedrun/pkg/freeze"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/history"
+	"github.com/kennyp/speedrun/pkg/hooks"
+	"github.com/kennyp/speedrun/pkg/metrics"
+	"github.com/kennyp/speedrun/pkg/policy"
+)
+
+// ApproveOptions controls how Approve approves, and whether it merges
+type ApproveOptions struct {
+	Body        string // Review body
+	Merge       bool   // Enable auto-merge after approving, or merge now if nothing is pending
+	MergeMethod string // MERGE, SQUASH or REBASE
+	Force       bool   // Skip the safety checks; policy rules still apply
+
+	// Confirm is asked before merging, unless auto_merge_on_approval is true.
+	// Nil merges without asking.
+	Confirm func(question string) (bool, error)
+}
+
+// Approve approves a PR and, if asked or configured to, merges it. It refuses
+// PRs outside the allowed repositories, merges during a deploy freeze, and
+// anything the policy rules block. Without Force it also refuses unsafe PRs.
+// It returns what it did, in order; in a dry run nothing is recorded.
+func (e *Engine) Approve(ctx context.Context, pr *github.PullRequest, opts ApproveOptions) ([]history.Outcome, error) {
+	ref := fmt.Sprintf("%s/%s#%d", pr.Owner, pr.Repo, pr.Number)
+	if !e.github.RepoAllowed(pr.Owner, pr.Repo) {
+		return nil, fmt.Errorf("refusing to approve %s: %w", ref, github.ErrRepoNotAllowed)
+	}
+
+	merge := opts.Merge || e.cfg.GitHub.AutoMergeOnApproval == "true"
+	if merge && e.cfg.GitHub.AutoMergeOnApproval == "false" {
+		return nil, fmt.Errorf("auto-merge is disabled in configuration")
+	}
+	if merge {
+		if err := e.checkFreeze(pr); err != nil {
+			return nil, fmt.Errorf("refusing to merge %s: %w", ref, err)
+		}
+	}
+
+	if !opts.Force {
+		if err := e.checkApprovalSafety(ctx, pr); err != nil {
+			return nil, fmt.Errorf("refusing to approve %s: %w (use --force to override)", ref, err)
+		}
+	}
+
+	// Policy rules can't be forced; check merge too so nothing is half done
+	actions := []policy.Action{policy.Approve}
+	if merge {
+		actions = append(actions, policy.Merge)
+	}
+	if err := e.checkPolicy(ctx, pr, actions...); err != nil {
+		return nil, fmt.Errorf("refusing to approve %s: %w", ref, err)
+	}
+
+	// Confirm before merging, as the TUI does, unless told not to ask
+	if merge && e.cfg.GitHub.AutoMergeOnApproval != "true" && opts.Confirm != nil {
+		ok, err := opts.Confirm(fmt.Sprintf("Approve and merge %s (%s) with %s?", ref, pr.Title, strings.ToLower(opts.MergeMethod)))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("merge of %s not confirmed", ref)
+		}
+	}
+
+	if err := pr.ApproveWithBody(ctx, opts.Body); err != nil {
+		return nil, err
+	}
+	done := []history.Outcome{history.Approved}
+	e.recordEvent(ctx, pr, history.Approved)
+
+	if !merge {
+		return done, nil
+	}
+
+	err := pr.EnableAutoMerge(ctx, opts.MergeMethod)
+	if err == nil {
+		e.recordEvent(ctx, pr, history.AutoMergeEnabled)
+		return append(done, history.AutoMergeEnabled), nil
+	}
+	// GitHub refuses auto-merge when nothing is pending; merge directly instead
+	if !strings.Contains(err.Error(), "pull request has no failing checks to resolve") {
+		return done, err
+	}
+	if err := pr.Merge(ctx, opts.MergeMethod); err != nil {
+		return done, err
+	}
+	e.recordEvent(ctx, pr, history.Merged)
+	return append(done, history.Merged), nil
+}
+
+// checkApprovalSafety refuses PRs with failing checks, a failed DCO sign-off,
+// unsigned commits in repositories that require signing, or a required
+// review checklist
+func (e *Engine) checkApprovalSafety(ctx context.Context, pr *github.PullRequest) error {
+	checks, err := pr.GetCheckStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get check status: %w", err)
+	}
+	if checks.State == "failure" {
+		return fmt.Errorf("checks are failing: %s", checks.Description)
+	}
+
+	sigs, err := pr.GetCommitSignatures(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get commit signatures: %w", err)
+	}
+	if github.DCOStatus(checks, sigs) == github.DCOFailed {
+		return errors.New("DCO sign-off failed")
+	}
+	if sigs.Unverified > 0 && github.RequiresSignedCommits(pr.Owner, pr.Repo, e.cfg.GitHub.RequireSigned) {
+		return fmt.Errorf("%d unsigned commits in a repository that requires signing", sigs.Unverified)
+	}
+	if e.cfg.Review.RequireChecklist && len(checklist.For(checklist.Parse(e.cfg.Review.Checklist), pr.Owner, pr.Repo)) > 0 {
+		return errors.New("the review checklist must be completed in the TUI first")
+	}
+	return nil
+}
+
+// checkFreeze refuses merges during a deploy freeze
+func (e *Engine) checkFreeze(pr *github.PullRequest) error {
+	windows, err := freeze.Parse(e.cfg.Freeze.Windows)
+	if err != nil {
+		return err
+	}
+	if w, ok := freeze.Active(windows, pr.Owner, pr.Repo, time.Now()); ok {
+		return errors.New(w.Message())
+	}
+	return nil
+}
+
+// checkPolicy evaluates the configured policy rules for each action
+func (e *Engine) checkPolicy(ctx context.Context, pr *github.PullRequest, actions ...policy.Action) error {
+	if len(e.cfg.Policy.Rules) == 0 {
+		return nil
+	}
+	gate, err := policy.New(e.cfg.Policy.Rules, e.cfg.Policy.BusinessHours)
+	if err != nil {
+		return err
+	}
+
+	facts := policy.Facts{
+		Repo:   pr.Owner + "/" + pr.Repo,
+		Author: pr.GetAuthor(),
+		Labels: pr.GetLabels(),
+	}
+	files, err := pr.GetChangedFiles(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get changed files: %w", err)
+	}
+	facts.Files = append([]string{}, files...) // Non-nil: loaded, even if empty
+	stats, err := pr.GetDiffStats(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get diff stats: %w", err)
+	}
+	facts.Lines, facts.FileCount = stats.Additions+stats.Deletions, stats.Files
+	checks, err := pr.GetCheckStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get check status: %w", err)
+	}
+	facts.Checks = checks.State
+	// Only a cached analysis is used; rules on risk don't match unanalyzed PRs
+	var analysis agent.Analysis
+	if err := pr.GetCachedAIAnalysis(&analysis); err == nil {
+		facts.Risk = analysis.RiskLevel
+	}
+
+	for _, action := range actions {
+		if err := gate.Check(action, facts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordEvent adds an approval or merge to the review history and runs its
+// hook
+func (e *Engine) recordEvent(ctx context.Context, pr *github.PullRequest, outcome history.Outcome) {
+	if e.github.DryRun() {
+		return
+	}
+	metrics.PRsProcessed.Inc(string(outcome))
+	event := history.Event{
+		User:    e.username,
+		Repo:    pr.Owner + "/" + pr.Repo,
+		Number:  pr.Number,
+		Title:   pr.Title,
+		Outcome: outcome,
+	}
+	if err := e.history.Record(ctx, event); err != nil {
+		slog.Warn("Failed to record history event", slog.Any("event", event), slog.Any("error", err))
+	}
+
+	hook := hooks.Approve
+	if outcome != history.Approved {
+		hook = hooks.Merge
+	}
+	e.runHook(ctx, hook, pr, nil)
+}
+
+// runHook runs an event's hook, logging rather than returning a failure as
+// hooks run after the fact
+func (e *Engine) runHook(ctx context.Context, event hooks.Event, pr *github.PullRequest, analysis *agent.Analysis) {
+	input := hooks.PR{
+		Owner:   pr.Owner,
+		Repo:    pr.Repo,
+		Number:  pr.Number,
+		Title:   pr.Title,
+		URL:     github.Snapshot{PR: pr}.HTMLURL(),
+		Author:  pr.GetAuthor(),
+		HeadSHA: pr.HeadSHA,
+		User:    e.username,
+	}
+	if analysis != nil {
+		input.Recommendation, input.Risk = string(analysis.Recommendation), analysis.RiskLevel
+	}
+	if err := e.cfg.Hooks.Hooks().Run(ctx, event, input); err != nil {
+		slog.Warn("Hook failed", slog.String("event", string(event)), slog.Any("error", err))
+	}
+}
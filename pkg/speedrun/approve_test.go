@@ -0,0 +1,54 @@
+package speedrun
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/cache"
+	"github.com/kennyp/speedrun/pkg/config"
+	"github.com/kennyp/speedrun/pkg/github"
+)
+
+func testEngine(t *testing.T, cfg *config.Config) *Engine {
+	t.Helper()
+	client, err := github.NewClient(context.Background(), "test-token", "is:pr", cache.NewNoOpCache(), cfg.GitHub.Backoff, github.ChecksConfig{}, time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetRepoAccess(cfg.GitHub.AllowedRepos, cfg.GitHub.DeniedRepos)
+	return &Engine{cfg: cfg, github: client, username: "me"}
+}
+
+func TestApproveRefusesDeniedRepo(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.GitHub.DeniedRepos = []string{"acme/prod"}
+	e := testEngine(t, cfg)
+
+	done, err := e.Approve(context.Background(), &github.PullRequest{Owner: "acme", Repo: "prod", Number: 1}, ApproveOptions{Body: "LGTM"})
+	if !errors.Is(err, github.ErrRepoNotAllowed) {
+		t.Errorf("err = %v, want %v", err, github.ErrRepoNotAllowed)
+	}
+	if len(done) != 0 {
+		t.Errorf("done = %v, want nothing", done)
+	}
+}
+
+func TestApproveRefusesDisabledMerge(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.GitHub.AutoMergeOnApproval = "false"
+	e := testEngine(t, cfg)
+
+	_, err := e.Approve(context.Background(), &github.PullRequest{Owner: "acme", Repo: "web", Number: 2}, ApproveOptions{Merge: true, MergeMethod: "SQUASH"})
+	if err == nil || err.Error() != "auto-merge is disabled in configuration" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestAnalyzeWithoutAI(t *testing.T) {
+	e := testEngine(t, &config.Config{})
+	if _, err := e.Analyze(context.Background(), &PR{Snapshot: github.Snapshot{PR: &github.PullRequest{Number: 3}}}); err == nil {
+		t.Error("Analyze() without an AI agent succeeded")
+	}
+}
@@ -0,0 +1,292 @@
+// Package speedrun runs the review pipeline behind the speedrun CLI (search,
+// hydrate, analyze, approve) so other tools can embed it without the TUI.
+package speedrun
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/agent"
+	"github.com/kennyp/speedrun/pkg/cache"
+	"github.com/kennyp/speedrun/pkg/config"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/history"
+	"github.com/kennyp/speedrun/pkg/tracker"
+)
+
+// actionsBot is who the workflow GITHUB_TOKEN acts as
+const actionsBot = "github-actions[bot]"
+
+// Engine holds the clients a speedrun session works with
+type Engine struct {
+	cfg       *config.Config
+	transport http.RoundTripper // Underneath every client's requests
+	cache     cache.Cache
+	github    *github.Client
+	ai        *agent.Agent    // Nil when AI is disabled
+	tracker   tracker.Tracker // Nil when issue linking is disabled
+	history   history.Recorder
+	username  string
+}
+
+// New sets up the cache, GitHub client, AI agent, issue tracker and review
+// history for a validated configuration, and authenticates with GitHub.
+// Every client they make sends its requests over transport, which is
+// http.DefaultTransport if nil. Progress messages go to progress, which may
+// be nil. Close the engine when done.
+func New(ctx context.Context, cfg *config.Config, transport http.RoundTripper, progress io.Writer) (*Engine, error) {
+	if progress == nil {
+		progress = io.Discard
+	}
+	e := &Engine{cfg: cfg, transport: transport}
+
+	// Initialize cache
+	if cfg.Cache.Enabled {
+		slog.Debug("Initializing cache", "path", cfg.Cache.Path, "max_age", cfg.Cache.MaxAge)
+		c, err := cache.New(cfg.Cache.Path, cfg.Cache.MaxAge)
+		if err != nil {
+			slog.Error("Failed to initialize cache", "error", err)
+			return nil, fmt.Errorf("failed to initialize cache: %w", err)
+		}
+		e.cache = c
+		if cfg.Cache.MemoryEntries > 0 {
+			e.cache = cache.NewMemoryCache(c, cfg.Cache.MemoryEntries, cfg.Cache.MaxAge)
+		}
+
+		// Cleanup expired cache entries on startup
+		slog.Debug("Cleaning up expired cache entries...")
+		if err := e.cache.Cleanup(); err != nil {
+			slog.Warn("Failed to cleanup cache", "error", err)
+			fmt.Fprintf(progress, "Warning: failed to cleanup cache: %v\n", err)
+		}
+		fmt.Fprintf(progress, "💾 Cache enabled at %s\n", cfg.Cache.Path)
+		e.warnOtherInstances(progress)
+	} else {
+		slog.Debug("Cache disabled")
+		fmt.Fprintf(progress, "💾 Cache disabled\n")
+		e.cache = cache.NewNoOpCache()
+	}
+
+	if err := e.connect(ctx, progress); err != nil {
+		e.Close()
+		return nil, err
+	}
+	return e, nil
+}
+
+// warnOtherInstances registers this instance with the cache and warns if
+// others are already sharing it
+func (e *Engine) warnOtherInstances(progress io.Writer) {
+	coord, ok := e.cache.(cache.Coordinator)
+	if !ok {
+		return
+	}
+	others, err := coord.Heartbeat()
+	if err != nil {
+		slog.Debug("Failed to register with the cache", "error", err)
+		return
+	}
+	for _, other := range others {
+		slog.Warn("Another speedrun instance is sharing the cache", "pid", other.PID, "host", other.Host, "started", other.StartedAt)
+		fmt.Fprintf(progress, "⚠️ Another speedrun (pid %d on %s, started %s) shares this cache; AI analyses are split between them\n",
+			other.PID, other.Host, other.StartedAt.Format(time.Kitchen))
+	}
+}
+
+// connect creates the GitHub client and the clients built on it
+func (e *Engine) connect(ctx context.Context, progress io.Writer) error {
+	cfg := e.cfg
+
+	// Create GitHub client
+	slog.Debug("Creating GitHub client", "search_query", cfg.GitHub.SearchQuery)
+	githubChecksConfig := github.ChecksConfig{
+		Ignored:              cfg.Checks.Ignored,
+		Required:             cfg.Checks.Required,
+		FromBranchProtection: cfg.Checks.FromBranchProtection,
+		FlakyReruns:          cfg.Checks.FlakyReruns,
+		ExcludeFlaky:         cfg.Checks.ExcludeFlaky,
+	}
+	slog.Debug("GitHub checks configuration",
+		slog.Any("ignored", githubChecksConfig.Ignored),
+		slog.Any("required", githubChecksConfig.Required),
+		slog.Int("ignored_len", len(githubChecksConfig.Ignored)),
+	)
+	githubClient, err := github.NewClient(ctx, cfg.GitHub.Token, cfg.GitHub.SearchQuery, e.cache, cfg.GitHub.Backoff, githubChecksConfig, cfg.GitHub.Client.Timeout, e.transport)
+	if err != nil {
+		slog.Error("Failed to create GitHub client", "error", err)
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+	githubClient.SetRepoAccess(cfg.GitHub.AllowedRepos, cfg.GitHub.DeniedRepos)
+	githubClient.SetSearchAPI(cfg.GitHub.SearchAPI)
+	if cfg.Cache.RemoteURL != "" {
+		remote, err := cache.NewRemote(cfg.Cache.RemoteURL, cfg.Cache.RemoteToken, e.transport)
+		if err != nil {
+			return err
+		}
+		githubClient.SetSharedCache(remote)
+		fmt.Fprintf(progress, "🌐 Sharing AI analyses through %s\n", cfg.Cache.RemoteURL)
+	}
+	if cfg.GitHub.DryRun {
+		githubClient.SetDryRun(true)
+		fmt.Fprintf(progress, "🧪 Dry run: write actions are logged, not performed\n")
+	}
+	e.github = githubClient
+
+	// Get authenticated user
+	slog.Debug("Getting authenticated user...")
+	username, err := githubClient.AuthenticatedUser(ctx)
+	switch {
+	case err != nil && os.Getenv("GITHUB_ACTIONS") == "true":
+		// The workflow GITHUB_TOKEN can't read /user; it acts as the Actions bot
+		slog.Warn("Failed to get authenticated user, assuming the GitHub Actions bot", "error", err)
+		username = actionsBot
+	case err != nil:
+		slog.Error("Failed to get authenticated user", "error", err)
+		return fmt.Errorf("failed to get authenticated user: %w", err)
+	default:
+		slog.Info("Successfully authenticated with GitHub", "username", username)
+	}
+	e.username = username
+
+	fmt.Fprintf(progress, "🚀 Starting speedrun for %s...\n", username)
+
+	// Report missing permissions now rather than as failures on each PR. The
+	// Actions bot token can't read /user, so there's nothing to check.
+	if username != actionsBot {
+		e.verifyToken(ctx, progress)
+	}
+	fmt.Fprintf(progress, "📍 Search query: %s\n", cfg.GitHub.SearchQuery)
+
+	// Create AI agent if configured
+	if cfg.AI.Enabled {
+		slog.Debug("Creating AI agent", "model", cfg.AI.Model, "base_url", cfg.AI.BaseURL)
+
+		// Create tool registry for agent
+		toolRegistry := agent.NewToolRegistry(githubClient, e.cache, agent.WebFetchConfig{
+			MaxChars: cfg.AI.FetchMaxChars,
+			Content: cache.ContentLimits{
+				MaxAge:   cfg.Cache.ContentMaxAge,
+				MaxBytes: int64(cfg.Cache.ContentMaxMB) << 20,
+			},
+			Transport: e.transport,
+		})
+
+		for name, tool := range cfg.AI.Tools {
+			if !tool.Enabled {
+				toolRegistry.Disable(name)
+				continue
+			}
+			toolRegistry.Configure(name, agent.ToolConfig{Timeout: tool.Timeout, MaxBytes: tool.MaxBytes})
+		}
+
+		breaker := agent.NewCircuitBreaker(cfg.AI.CircuitThreshold, cfg.AI.CircuitCooldown)
+		endpoint := agent.Endpoint{
+			Provider:   cfg.AI.Provider,
+			BaseURL:    cfg.AI.BaseURL,
+			APIKey:     cfg.AI.APIKey,
+			Deployment: cfg.AI.Deployment,
+			APIVersion: cfg.AI.APIVersion,
+			Headers:    cfg.AI.RequestHeaders(),
+			Transport:  e.transport,
+		}
+		e.ai = agent.NewAgent(endpoint, cfg.AI.Model, cfg.AI.Backoff, toolRegistry, cfg.AI.ToolTimeout, cfg.AI.Client.Timeout, breaker)
+		e.ai.SetReasoningEffort(cfg.AI.ReasoningEffort)
+		// Analyses made with other prompts, by an older speedrun or before
+		// the prompts were edited, are stale
+		githubClient.SetAIAnalysisVersion(agent.PromptVersion())
+		e.ai.SetRepoAccess(cfg.AI.AllowedRepos, cfg.AI.DeniedRepos)
+		e.ai.SetParams(agent.Params{
+			Temperature: cfg.AI.Temperature,
+			TopP:        cfg.AI.TopP,
+			MaxTokens:   int64(cfg.AI.MaxTokens),
+			Extra:       cfg.AI.Params(),
+		})
+		if cfg.AI.Provider == agent.ProviderAzure {
+			fmt.Fprintf(progress, "🤖 AI analysis enabled with Azure OpenAI deployment: %s\n", cfg.AI.Deployment)
+		} else {
+			fmt.Fprintf(progress, "🤖 AI analysis enabled with model: %s\n", cfg.AI.Model)
+		}
+		slog.Info("AI agent initialized", "provider", cfg.AI.Provider, "model", cfg.AI.Model, "deployment", cfg.AI.Deployment, "reasoning_effort", cfg.AI.ReasoningEffort)
+	} else {
+		fmt.Fprintf(progress, "🤖 AI analysis disabled\n")
+		slog.Debug("AI analysis disabled")
+	}
+
+	// Open review history
+	e.history = history.NewNoOpRecorder()
+	if cfg.History.Enabled {
+		store, err := history.Open(cfg.History.Path)
+		if err != nil {
+			slog.Warn("Failed to open history database, history disabled", "path", cfg.History.Path, "error", err)
+		} else {
+			e.history = store
+		}
+	} else {
+		slog.Debug("History disabled")
+	}
+
+	// Look up issues linked from PRs
+	switch cfg.Tracker.Type {
+	case "jira":
+		e.tracker = tracker.NewJira(cfg.Tracker.URL, cfg.Tracker.User, cfg.Tracker.Token, cfg.Tracker.Projects, e.transport)
+	case "github":
+		e.tracker = tracker.NewGitHub(githubClient)
+	}
+	return nil
+}
+
+// verifyToken reports the capabilities the GitHub token's scopes or missing
+// SSO authorizations rule out
+func (e *Engine) verifyToken(ctx context.Context, progress io.Writer) {
+	report, err := e.github.VerifyToken(ctx, github.SearchOrgs(e.cfg.GitHub.SearchQuery), len(e.cfg.GitHub.Teams) > 0)
+	if err != nil {
+		slog.Warn("Failed to verify token permissions", "error", err)
+		return
+	}
+
+	for _, capability := range report.Unavailable {
+		slog.Warn("Capability unavailable", "capability", capability.Name, "reason", capability.Reason)
+		fmt.Fprintf(progress, "⚠️  %s unavailable: %s\n", capability.Name, capability.Reason)
+	}
+	for _, org := range slices.Sorted(maps.Keys(report.SSO)) {
+		slog.Warn("Token not authorized for SSO", "org", org, "url", report.SSO[org])
+		fmt.Fprintf(progress, "⚠️  %s requires SSO authorization, actions there will fail: %s\n", org, report.SSO[org])
+	}
+}
+
+// Close closes the review history and the cache
+func (e *Engine) Close() {
+	if e.history != nil {
+		if err := e.history.Close(); err != nil {
+			slog.Error("Failed to close history", slog.Any("error", err))
+		}
+	}
+	if err := e.cache.Close(); err != nil {
+		slog.Error("Failed to close cache", slog.Any("error", err))
+	}
+}
+
+// Config returns the engine's configuration
+func (e *Engine) Config() *config.Config { return e.cfg }
+
+// GitHub returns the GitHub client
+func (e *Engine) GitHub() *github.Client { return e.github }
+
+// AI returns the AI agent, or nil when AI is disabled
+func (e *Engine) AI() *agent.Agent { return e.ai }
+
+// Tracker returns the issue tracker, or nil when issue linking is disabled
+func (e *Engine) Tracker() tracker.Tracker { return e.tracker }
+
+// History returns where approvals and merges are recorded
+func (e *Engine) History() history.Recorder { return e.history }
+
+// Username returns the GitHub user speedrun acts as
+func (e *Engine) Username() string { return e.username }
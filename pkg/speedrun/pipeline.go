@@ -0,0 +1,122 @@
+package speedrun
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/agent"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/hooks"
+	"github.com/kennyp/speedrun/pkg/tracker"
+)
+
+// PR is a pull request with everything speedrun loads about it
+type PR struct {
+	github.Snapshot
+	Issues []*tracker.Issue // Linked tracker issues, nil without a tracker
+}
+
+// Search returns the PRs matching the configured search query
+func (e *Engine) Search(ctx context.Context) ([]*github.PullRequest, error) {
+	prs, err := e.github.SearchPullRequests(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search pull requests: %w", err)
+	}
+	return prs, nil
+}
+
+// Hydrate loads a PR's diff stats, checks, reviews, commit signatures,
+// changed files, PR template compliance and linked issues. Template
+// compliance and linked issues are best effort; anything else failing to
+// load is an error.
+func (e *Engine) Hydrate(ctx context.Context, pr *github.PullRequest) (*PR, error) {
+	hydrated := &PR{Snapshot: github.Snapshot{PR: pr}}
+
+	var err error
+	if hydrated.DiffStats, err = pr.GetDiffStats(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get diff stats: %w", err)
+	}
+	if hydrated.CheckStatus, err = pr.GetCheckStatus(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get check status: %w", err)
+	}
+	if hydrated.Reviews, err = pr.GetReviews(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get reviews: %w", err)
+	}
+	if hydrated.Signatures, err = pr.GetCommitSignatures(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get commit signatures: %w", err)
+	}
+	if hydrated.ChangedFiles, err = pr.GetChangedFiles(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get changed files: %w", err)
+	}
+	if e.cfg.Review.TemplateCheck || e.cfg.Review.TemplatePrompt {
+		if hydrated.Template, err = pr.GetTemplateCompliance(ctx); err != nil {
+			slog.Debug("PR template unavailable", slog.Any("pr", pr), slog.Any("error", err))
+		}
+	}
+
+	if e.tracker != nil {
+		issueCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+		defer cancel()
+		hydrated.Issues, err = tracker.LinkedIssues(issueCtx, e.tracker, pr.Owner, pr.Repo, pr.Title, pr.GetBody())
+		if err != nil {
+			slog.Debug("Linked issues failed", slog.Any("pr", pr), slog.Any("error", err))
+		}
+	}
+	return hydrated, nil
+}
+
+// Analyze returns the AI's recommendation for a hydrated PR, from the cache
+// if the head commit has been analyzed before
+func (e *Engine) Analyze(ctx context.Context, pr *PR) (*agent.Analysis, error) {
+	if e.ai == nil {
+		return nil, fmt.Errorf("AI analysis is disabled")
+	}
+	if !e.ai.RepoAllowed(pr.PR.Owner, pr.PR.Repo) {
+		return nil, fmt.Errorf("%w: %s/%s", agent.ErrRepoNotAllowed, pr.PR.Owner, pr.PR.Repo)
+	}
+
+	var cached agent.Analysis
+	if err := pr.PR.GetCachedAIAnalysis(&cached); err == nil {
+		return &cached, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, e.cfg.AI.AnalysisTimeout)
+	defer cancel()
+
+	// Another speedrun sharing the cache may be analyzing the PR already
+	waited, err := pr.PR.ClaimAIAnalysis(ctx, e.cfg.AI.AnalysisTimeout, &cached)
+	if err != nil {
+		return nil, err
+	}
+	if waited {
+		return &cached, nil
+	}
+	defer pr.PR.ReleaseAIAnalysis()
+
+	analysis, err := e.ai.AnalyzePR(ctx, agent.PRData{
+		Snapshot:           pr.Snapshot,
+		LinkedIssues:       pr.Issues,
+		SignaturesRequired: github.RequiresSignedCommits(pr.PR.Owner, pr.PR.Repo, e.cfg.GitHub.RequireSigned),
+		SizeBudget:         e.SizeBudget(),
+		TemplateSignal:     e.cfg.Review.TemplatePrompt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := pr.PR.SetCachedAIAnalysis(analysis); err != nil {
+		slog.Debug("Failed to cache AI analysis", slog.Any("pr", pr.PR), slog.Any("error", err))
+	}
+	e.runHook(ctx, hooks.AnalysisComplete, pr.PR, analysis)
+	return analysis, nil
+}
+
+// SizeBudget returns the configured limits on PR size
+func (e *Engine) SizeBudget() agent.SizeBudget {
+	return agent.SizeBudget{
+		MaxLines: e.cfg.Review.MaxLines,
+		MaxFiles: e.cfg.Review.MaxFiles,
+		Enforce:  e.cfg.Review.EnforceSize,
+	}
+}
@@ -0,0 +1,421 @@
+package speedrun
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/agent"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/history"
+)
+
+// serveWorkers is how many PRs a server refresh hydrates and analyzes at once
+const serveWorkers = 4
+
+// maxRequestBody bounds the JSON an API client can send
+const maxRequestBody = 1 << 20
+
+// Server keeps the review queue hydrated and analyzed in the background and
+// serves it over a REST API, so dashboards and chat bots can read the queue
+// and approve PRs through the same checks as the CLI
+type Server struct {
+	engine   *Engine
+	tokens   []string
+	interval time.Duration
+
+	slackSecret    string   // Signing secret of the Slack app, empty to disable /slack/commands
+	slackApprovers []string // Slack user IDs allowed to approve, empty for no one
+
+	mu      sync.RWMutex
+	queue   []QueuedPR
+	byRef   map[string]*github.PullRequest
+	updated time.Time // Zero until the first refresh finishes
+	lastErr error
+}
+
+// QueuedPR is a PR as the API serves it
+type QueuedPR struct {
+	Repo      string    `json:"repo"` // owner/repo
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	Author    string    `json:"author"`
+	HeadSHA   string    `json:"head_sha"`
+	CreatedAt time.Time `json:"created_at"`
+	Additions int       `json:"additions"`
+	Deletions int       `json:"deletions"`
+	Files     int       `json:"files"`
+	Checks    string    `json:"checks,omitempty"` // success, failure, pending or error
+	Approvals int       `json:"approvals"`
+
+	Analysis *QueuedAnalysis `json:"analysis,omitempty"`
+	Error    string          `json:"error,omitempty"` // Why loading or analyzing the PR failed
+}
+
+// QueuedAnalysis is the AI's recommendation for a queued PR
+type QueuedAnalysis struct {
+	Recommendation string `json:"recommendation"`
+	Risk           string `json:"risk"`
+	Type           string `json:"type"`
+	Reasoning      string `json:"reasoning"`
+}
+
+// approveRequest is the body of an approve call. Every field is optional.
+type approveRequest struct {
+	Body        string `json:"body"`
+	Merge       bool   `json:"merge"`
+	MergeMethod string `json:"merge_method"`
+}
+
+// NewServer returns a server for the engine's queue, refreshed every
+// serve.interval. API clients must send one of serve.tokens.
+func NewServer(e *Engine) (*Server, error) {
+	var tokens []string
+	for _, token := range e.cfg.Serve.Tokens {
+		if token = strings.TrimSpace(token); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("serve.tokens must have at least one token for API clients")
+	}
+	interval := e.cfg.Serve.Interval
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	return &Server{
+		engine:         e,
+		tokens:         tokens,
+		interval:       interval,
+		slackSecret:    e.cfg.Serve.SlackSigningSecret,
+		slackApprovers: e.cfg.Serve.SlackApprovers,
+	}, nil
+}
+
+// ListenAndServe refreshes the queue and serves the API on addr until ctx is
+// done
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go s.Run(ctx)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			slog.Warn("Failed to shut down API server", slog.Any("error", err))
+		}
+	}()
+
+	slog.Info("Serving API", slog.String("addr", addr))
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve API: %w", err)
+	}
+	return nil
+}
+
+// Run refreshes the queue now and then every interval until ctx is done
+func (s *Server) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.Refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh searches for the queue, then hydrates and analyzes each PR. A
+// failed search keeps the previous queue.
+func (s *Server) Refresh(ctx context.Context) {
+	start := time.Now()
+	prs, err := s.engine.Search(ctx)
+	if err != nil {
+		slog.Error("Failed to refresh queue", slog.Any("error", err))
+		s.mu.Lock()
+		s.lastErr = err
+		s.mu.Unlock()
+		return
+	}
+
+	queue := make([]QueuedPR, len(prs))
+	byRef := make(map[string]*github.PullRequest, len(prs))
+	sem := make(chan struct{}, serveWorkers)
+	var wg sync.WaitGroup
+	for i, pr := range prs {
+		byRef[prRef(pr.Owner, pr.Repo, pr.Number)] = pr
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			queue[i] = s.load(ctx, pr)
+		}()
+	}
+	wg.Wait()
+
+	s.mu.Lock()
+	s.queue, s.byRef, s.updated, s.lastErr = queue, byRef, time.Now(), nil
+	s.mu.Unlock()
+	slog.Info("Refreshed queue", slog.Int("prs", len(queue)), slog.Duration("duration", time.Since(start)))
+}
+
+// load hydrates and, with AI enabled, analyzes one PR
+func (s *Server) load(ctx context.Context, pr *github.PullRequest) QueuedPR {
+	q := QueuedPR{
+		Repo:      pr.Owner + "/" + pr.Repo,
+		Number:    pr.Number,
+		Title:     pr.Title,
+		URL:       github.Snapshot{PR: pr}.HTMLURL(),
+		Author:    pr.GetAuthor(),
+		HeadSHA:   pr.HeadSHA,
+		CreatedAt: pr.CreatedAt,
+	}
+
+	hydrated, err := s.engine.Hydrate(ctx, pr)
+	if err != nil {
+		q.Error = err.Error()
+		return q
+	}
+	q.Additions, q.Deletions, q.Files = hydrated.DiffStats.Additions, hydrated.DiffStats.Deletions, hydrated.DiffStats.Files
+	if hydrated.CheckStatus != nil {
+		q.Checks = hydrated.CheckStatus.State
+	}
+	for _, review := range hydrated.Reviews {
+		if review.State == "APPROVED" {
+			q.Approvals++
+		}
+	}
+
+	// PRs the AI may not see are served without an analysis, as with AI off
+	if s.engine.ai == nil || !s.engine.ai.RepoAllowed(pr.Owner, pr.Repo) {
+		return q
+	}
+	analysis, err := s.engine.Analyze(ctx, hydrated)
+	if err != nil {
+		q.Error = err.Error()
+		return q
+	}
+	q.Analysis = queuedAnalysis(analysis)
+	return q
+}
+
+func queuedAnalysis(a *agent.Analysis) *QueuedAnalysis {
+	return &QueuedAnalysis{
+		Recommendation: string(a.Recommendation),
+		Risk:           a.RiskLevel,
+		Type:           a.PRType,
+		Reasoning:      a.Reasoning,
+	}
+}
+
+// prRef identifies a PR as owner/repo#number
+func prRef(owner, repo string, number int) string {
+	return fmt.Sprintf("%s/%s#%d", owner, repo, number)
+}
+
+// Handler returns the API:
+//
+//	GET  /healthz                                      liveness, no token needed
+//	GET  /v1/queue                                     the queue, as last refreshed
+//	GET  /v1/prs/{owner}/{repo}/{number}               one queued PR
+//	POST /v1/prs/{owner}/{repo}/{number}/approve       approve, and optionally merge
+//
+// /v1 calls need an Authorization: Bearer header with one of the tokens. With
+// a Slack signing secret, POST /slack/commands takes /speedrun slash
+// commands, which Slack signs instead.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.health)
+	mux.Handle("GET /v1/queue", s.authenticated(s.getQueue))
+	mux.Handle("GET /v1/prs/{owner}/{repo}/{number}", s.authenticated(s.getPR))
+	mux.Handle("POST /v1/prs/{owner}/{repo}/{number}/approve", s.authenticated(s.approve))
+	if s.slackSecret != "" {
+		mux.HandleFunc("POST /slack/commands", s.slackCommand)
+	}
+	return mux
+}
+
+// authenticated only lets requests with a known bearer token through
+func (s *Server) authenticated(next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || !s.validToken(token) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="speedrun"`)
+			writeError(w, http.StatusUnauthorized, "missing or unknown bearer token")
+			return
+		}
+		next(w, r)
+	})
+}
+
+// validToken compares in constant time, so response times don't leak tokens
+func (s *Server) validToken(token string) bool {
+	valid := false
+	for _, t := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
+
+// health answers without a token, so it leaves out why a refresh failed,
+// which can name private repos; /v1/queue has that
+func (s *Server) health(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	body := map[string]any{"status": "ok"}
+	if !s.updated.IsZero() {
+		body["updated_at"] = s.updated
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+func (s *Server) getQueue(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.updated.IsZero() {
+		writeError(w, http.StatusServiceUnavailable, "the queue hasn't loaded yet")
+		return
+	}
+	body := map[string]any{
+		"user":       s.engine.username,
+		"updated_at": s.updated,
+		"prs":        s.queue,
+	}
+	if s.lastErr != nil {
+		body["error"] = s.lastErr.Error() // The last refresh failed, so the queue is older
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+func (s *Server) getPR(w http.ResponseWriter, r *http.Request) {
+	owner, repo, number, ok := pathPR(w, r)
+	if !ok {
+		return
+	}
+	q, ok := s.queued(owner, repo, number)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s isn't in the queue", prRef(owner, repo, number)))
+		return
+	}
+	writeJSON(w, http.StatusOK, q)
+}
+
+// queued returns the PR as of the last refresh
+func (s *Server) queued(owner, repo string, number int) (QueuedPR, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, q := range s.queue {
+		if q.Repo == owner+"/"+repo && q.Number == number {
+			return q, true
+		}
+	}
+	return QueuedPR{}, false
+}
+
+// approve approves a PR with the engine's checks. Unlike the CLI it can't
+// force past the safety checks, and merges aren't confirmed.
+func (s *Server) approve(w http.ResponseWriter, r *http.Request) {
+	owner, repo, number, ok := pathPR(w, r)
+	if !ok {
+		return
+	}
+	var req approveRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBody)).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+			return
+		}
+	}
+	req.MergeMethod = strings.ToUpper(req.MergeMethod)
+	switch req.MergeMethod {
+	case "":
+		req.MergeMethod = "SQUASH"
+	case "MERGE", "SQUASH", "REBASE":
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown merge_method %q (want merge, squash or rebase)", req.MergeMethod))
+		return
+	}
+
+	pr, err := s.lookup(r.Context(), owner, repo, number)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	done, err := s.engine.Approve(r.Context(), pr, ApproveOptions{Body: req.Body, Merge: req.Merge, MergeMethod: req.MergeMethod})
+	body := map[string]any{"done": outcomes(done)}
+	if err != nil {
+		slog.Warn("API approval failed", slog.Any("pr", pr), slog.Any("error", err))
+		body["error"] = err.Error()
+		status := http.StatusConflict
+		if errors.Is(err, github.ErrRepoNotAllowed) {
+			status = http.StatusForbidden
+		}
+		writeJSON(w, status, body)
+		return
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+// lookup returns the queued PR, or fetches it if it's arrived since the last
+// refresh or isn't in the queue. A queued PR is copied, as checking its
+// status records its head on it and other requests may be reading it.
+func (s *Server) lookup(ctx context.Context, owner, repo string, number int) (*github.PullRequest, error) {
+	s.mu.RLock()
+	queued := s.byRef[prRef(owner, repo, number)]
+	s.mu.RUnlock()
+	if queued != nil {
+		pr := *queued
+		return &pr, nil
+	}
+	return s.engine.github.GetPullRequest(ctx, owner, repo, number)
+}
+
+// outcomes lists what was done, never as null
+func outcomes(done []history.Outcome) []string {
+	names := make([]string, 0, len(done))
+	for _, outcome := range done {
+		names = append(names, string(outcome))
+	}
+	return names
+}
+
+// pathPR reads the PR from the request path, answering 400 if it's invalid
+func pathPR(w http.ResponseWriter, r *http.Request) (owner, repo string, number int, ok bool) {
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil || number <= 0 {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid PR number %q", r.PathValue("number")))
+		return "", "", 0, false
+	}
+	return r.PathValue("owner"), r.PathValue("repo"), number, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Debug("Failed to write API response", slog.Any("error", err))
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,135 @@
+package speedrun
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/config"
+	"github.com/kennyp/speedrun/pkg/github"
+)
+
+func testServer(t *testing.T, cfg *config.Config) *Server {
+	t.Helper()
+	cfg.Serve.Tokens = []string{"secret"}
+	s, err := NewServer(testEngine(t, cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func serveRequest(s *Server, method, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewServerNeedsTokens(t *testing.T) {
+	if _, err := NewServer(testEngine(t, &config.Config{})); err == nil {
+		t.Error("NewServer() without tokens succeeded")
+	}
+}
+
+func TestServerAuthentication(t *testing.T) {
+	s := testServer(t, &config.Config{})
+
+	if rec := serveRequest(s, "GET", "/healthz", "", ""); rec.Code != http.StatusOK {
+		t.Errorf("GET /healthz = %d, want %d", rec.Code, http.StatusOK)
+	}
+	for _, token := range []string{"", "wrong"} {
+		if rec := serveRequest(s, "GET", "/v1/queue", token, ""); rec.Code != http.StatusUnauthorized {
+			t.Errorf("GET /v1/queue with token %q = %d, want %d", token, rec.Code, http.StatusUnauthorized)
+		}
+	}
+	if rec := serveRequest(s, "GET", "/v1/queue", "secret", ""); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("GET /v1/queue before a refresh = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+
+	// Why a refresh failed is only for clients with a token
+	s.updated, s.lastErr = time.Now(), errors.New("failed to search acme/secret-project")
+	if rec := serveRequest(s, "GET", "/healthz", "", ""); strings.Contains(rec.Body.String(), "secret-project") {
+		t.Errorf("GET /healthz = %s, leaks the refresh error", rec.Body)
+	}
+	if rec := serveRequest(s, "GET", "/v1/queue", "secret", ""); !strings.Contains(rec.Body.String(), "secret-project") {
+		t.Errorf("GET /v1/queue = %s, want the refresh error", rec.Body)
+	}
+}
+
+func TestServerQueue(t *testing.T) {
+	s := testServer(t, &config.Config{})
+	s.queue = []QueuedPR{{Repo: "acme/api", Number: 7, Title: "Fix login", Analysis: &QueuedAnalysis{Recommendation: "APPROVE", Risk: "LOW"}}}
+	s.updated = time.Now()
+
+	rec := serveRequest(s, "GET", "/v1/queue", "secret", "")
+	var queue struct {
+		User string     `json:"user"`
+		PRs  []QueuedPR `json:"prs"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &queue); err != nil {
+		t.Fatalf("GET /v1/queue = %d %s: %v", rec.Code, rec.Body, err)
+	}
+	if queue.User != "me" || len(queue.PRs) != 1 || queue.PRs[0].Analysis.Recommendation != "APPROVE" {
+		t.Errorf("GET /v1/queue = %+v", queue)
+	}
+
+	if rec := serveRequest(s, "GET", "/v1/prs/acme/api/7", "secret", ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Fix login") {
+		t.Errorf("GET /v1/prs/acme/api/7 = %d %s", rec.Code, rec.Body)
+	}
+	if rec := serveRequest(s, "GET", "/v1/prs/acme/api/8", "secret", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("GET /v1/prs/acme/api/8 = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	if rec := serveRequest(s, "GET", "/v1/prs/acme/api/seven", "secret", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("GET /v1/prs/acme/api/seven = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestServerApprove(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.GitHub.DeniedRepos = []string{"acme/prod"}
+	cfg.GitHub.AutoMergeOnApproval = "false"
+	s := testServer(t, cfg)
+	s.byRef = map[string]*github.PullRequest{
+		"acme/prod#1": {Owner: "acme", Repo: "prod", Number: 1},
+		"acme/web#2":  {Owner: "acme", Repo: "web", Number: 2},
+	}
+
+	tests := []struct {
+		path, body string
+		want       int
+	}{
+		{"/v1/prs/acme/prod/1/approve", "", http.StatusForbidden},
+		{"/v1/prs/acme/web/2/approve", `{"merge": true}`, http.StatusConflict},
+		{"/v1/prs/acme/web/2/approve", `{"merge_method": "octopus"}`, http.StatusBadRequest},
+		{"/v1/prs/acme/web/2/approve", `{`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := serveRequest(s, "POST", tt.path, "secret", tt.body)
+		if rec.Code != tt.want {
+			t.Errorf("POST %s %s = %d %s, want %d", tt.path, tt.body, rec.Code, rec.Body, tt.want)
+		}
+	}
+}
+
+func TestLookupCopiesQueuedPR(t *testing.T) {
+	s := testServer(t, &config.Config{})
+	queued := &github.PullRequest{Owner: "acme", Repo: "api", Number: 7, HeadSHA: "abc123"}
+	s.byRef = map[string]*github.PullRequest{prRef("acme", "api", 7): queued}
+
+	// Requests check the PR's status, which records its head on it
+	pr, err := s.lookup(t.Context(), "acme", "api", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr == queued || pr.HeadSHA != "abc123" {
+		t.Errorf("lookup() = %p %+v, want a copy of the queued %p", pr, pr, queued)
+	}
+}
@@ -0,0 +1,193 @@
+package speedrun
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/slack"
+)
+
+// slackTimeout bounds the work behind a slash command that's answered later,
+// well inside the 30 minutes Slack accepts responses for
+const slackTimeout = 5 * time.Minute
+
+// slackQueueLimit is how many PRs /speedrun queue lists
+const slackQueueLimit = 15
+
+const slackUsage = "Usage: `/speedrun queue`, `/speedrun analyze <PR>` or `/speedrun approve <PR>`, where <PR> is a URL or owner/repo#number"
+
+// slackCommand answers /speedrun slash commands. Slack wants an answer within
+// three seconds, so analyses and approvals are acknowledged right away and
+// answered at the command's response URL.
+func (s *Server) slackCommand(w http.ResponseWriter, r *http.Request) {
+	cmd, err := slack.ParseCommand(r, s.slackSecret, time.Now())
+	if err != nil {
+		slog.Warn("Rejected Slack command", slog.Any("error", err))
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	slog.Info("Slack command", slog.String("user", cmd.UserName), slog.String("text", cmd.Text))
+
+	verb, ref, _ := strings.Cut(cmd.Text, " ")
+	ref = strings.TrimSpace(ref)
+	switch strings.ToLower(verb) {
+	case "queue":
+		writeJSON(w, http.StatusOK, s.slackQueue())
+	case "analyze":
+		s.slackLater(w, r, cmd, func(ctx context.Context) slack.Response {
+			return s.slackAnalyze(ctx, ref)
+		})
+	case "approve":
+		if len(s.slackApprovers) == 0 {
+			writeJSON(w, http.StatusOK, slack.Ephemeral("🚫 Approving through Slack is off; list who may approve in serve.slack_approvers"))
+			return
+		}
+		if !s.slackMayApprove(cmd) {
+			writeJSON(w, http.StatusOK, slack.Ephemeral("🚫 You're not allowed to approve PRs through speedrun"))
+			return
+		}
+		s.slackLater(w, r, cmd, func(ctx context.Context) slack.Response {
+			return s.slackApprove(ctx, cmd, ref)
+		})
+	default:
+		writeJSON(w, http.StatusOK, slack.Ephemeral(slackUsage))
+	}
+}
+
+// slackLater acknowledges the command now and sends what work returns to
+// the command's response URL
+func (s *Server) slackLater(w http.ResponseWriter, r *http.Request, cmd slack.Command, work func(ctx context.Context) slack.Response) {
+	if cmd.ResponseURL == "" {
+		writeJSON(w, http.StatusOK, slack.Ephemeral("Slack didn't send a response URL to answer at"))
+		return
+	}
+	writeJSON(w, http.StatusOK, slack.Ephemeral("⏳ On it…"))
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), slackTimeout)
+	go func() {
+		defer cancel()
+		if err := slack.Respond(ctx, s.engine.transport, cmd.ResponseURL, work(ctx)); err != nil {
+			slog.Warn("Failed to answer Slack command", slog.String("text", cmd.Text), slog.Any("error", err))
+		}
+	}()
+}
+
+// slackMayApprove reports whether the user is one of the configured
+// approvers. Only the user ID counts: users can change their names.
+func (s *Server) slackMayApprove(cmd slack.Command) bool {
+	return slices.Contains(s.slackApprovers, cmd.UserID)
+}
+
+// slackQueue lists the queue as last refreshed
+func (s *Server) slackQueue() slack.Response {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.updated.IsZero() {
+		return slack.Ephemeral("The queue hasn't loaded yet, try again in a minute")
+	}
+	if len(s.queue) == 0 {
+		return slack.Ephemeral("🎉 Nothing waiting for review")
+	}
+
+	lines := []string{fmt.Sprintf("*%d PRs waiting for review* (as of %s)", len(s.queue), s.updated.Format("15:04"))}
+	for i, q := range s.queue {
+		if i == slackQueueLimit {
+			lines = append(lines, fmt.Sprintf("…and %d more", len(s.queue)-slackQueueLimit))
+			break
+		}
+		lines = append(lines, "• "+slackQueueLine(q))
+	}
+	return slack.Ephemeral("%s", strings.Join(lines, "\n"))
+}
+
+// slackQueueLine describes a queued PR in one line
+func slackQueueLine(q QueuedPR) string {
+	line := fmt.Sprintf("<%s|%s#%d> %s", q.URL, q.Repo, q.Number, slackEscape(q.Title))
+	switch {
+	case q.Analysis != nil:
+		line += fmt.Sprintf(" — %s, %s risk", q.Analysis.Recommendation, strings.ToLower(q.Analysis.Risk))
+	case q.Error != "":
+		line += " — failed to load"
+	}
+	if q.Checks == "failure" || q.Checks == "error" {
+		line += ", checks failing"
+	}
+	return line
+}
+
+// slackAnalyze returns the AI's analysis of a PR, from the queue if it's
+// been analyzed already. Only whoever asked sees it, as it can quote a
+// private repo to a channel that can't.
+func (s *Server) slackAnalyze(ctx context.Context, ref string) slack.Response {
+	owner, repo, number, err := github.ParsePRRef(ref)
+	if err != nil {
+		return slack.Ephemeral("%s", slackUsage)
+	}
+
+	if q, ok := s.queued(owner, repo, number); ok && q.Analysis != nil {
+		return slack.Ephemeral("%s", slackAnalysis(q))
+	}
+
+	if s.engine.ai == nil {
+		return slack.Ephemeral("AI analysis is disabled on this server")
+	}
+	if !s.engine.ai.RepoAllowed(owner, repo) {
+		return slack.Ephemeral("🔒 %s/%s isn't sent for AI analysis on this server", owner, repo)
+	}
+	pr, err := s.lookup(ctx, owner, repo, number)
+	if err != nil {
+		return slack.Ephemeral("❌ Couldn't find %s: %v", prRef(owner, repo, number), err)
+	}
+	q := s.load(ctx, pr)
+	if q.Analysis == nil {
+		return slack.Ephemeral("❌ Couldn't analyze %s: %s", prRef(owner, repo, number), q.Error)
+	}
+	return slack.Ephemeral("%s", slackAnalysis(q))
+}
+
+// slackAnalysis formats a PR's analysis as a message
+func slackAnalysis(q QueuedPR) string {
+	return fmt.Sprintf("*<%s|%s#%d>* %s\n*%s*, %s risk\n> %s",
+		q.URL, q.Repo, q.Number, slackEscape(q.Title),
+		q.Analysis.Recommendation, strings.ToLower(q.Analysis.Risk),
+		strings.ReplaceAll(slackEscape(q.Analysis.Reasoning), "\n", "\n> "))
+}
+
+// slackApprove approves a PR with the engine's checks, as the API does. It
+// merges only if auto_merge_on_approval is true.
+func (s *Server) slackApprove(ctx context.Context, cmd slack.Command, ref string) slack.Response {
+	owner, repo, number, err := github.ParsePRRef(ref)
+	if err != nil {
+		return slack.Ephemeral("%s", slackUsage)
+	}
+	pr, err := s.lookup(ctx, owner, repo, number)
+	if err != nil {
+		return slack.Ephemeral("❌ Couldn't find %s: %v", prRef(owner, repo, number), err)
+	}
+
+	body := fmt.Sprintf("Approved from Slack by @%s", cmd.UserName)
+	done, err := s.engine.Approve(ctx, pr, ApproveOptions{Body: body, MergeMethod: "SQUASH"})
+	if err != nil {
+		slog.Warn("Slack approval failed", slog.Any("pr", pr), slog.String("user", cmd.UserName), slog.Any("error", err))
+		if len(done) == 0 {
+			return slack.Ephemeral("❌ %v", err)
+		}
+		return slack.InChannel("⚠️ <@%s> approved <%s|%s> (%s) but: %v", cmd.UserID,
+			github.Snapshot{PR: pr}.HTMLURL(), prRef(owner, repo, number), strings.Join(outcomes(done), ", "), err)
+	}
+	return slack.InChannel("✅ <@%s> approved <%s|%s> %s (%s)", cmd.UserID,
+		github.Snapshot{PR: pr}.HTMLURL(), prRef(owner, repo, number), slackEscape(pr.Title), strings.Join(outcomes(done), ", "))
+}
+
+// slackEscaper escapes the characters Slack reads as markup
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func slackEscape(text string) string {
+	return slackEscaper.Replace(text)
+}
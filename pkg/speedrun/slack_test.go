@@ -0,0 +1,113 @@
+package speedrun
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/config"
+	"github.com/kennyp/speedrun/pkg/github"
+	"github.com/kennyp/speedrun/pkg/slack"
+)
+
+// slackRequest sends a signed /speedrun command and returns the immediate
+// response
+func slackRequest(t *testing.T, s *Server, secret string, form url.Values) (int, slack.Response) {
+	t.Helper()
+	body := form.Encode()
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/slack/commands", strings.NewReader(body))
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", slack.Sign(secret, timestamp, []byte(body)))
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	var resp slack.Response
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	return rec.Code, resp
+}
+
+func TestSlackCommands(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Serve.SlackSigningSecret = "shh"
+	cfg.Serve.SlackApprovers = []string{"U1"}
+	cfg.GitHub.DeniedRepos = []string{"acme/prod"}
+	s := testServer(t, cfg)
+	s.queue = []QueuedPR{{Repo: "acme/api", Number: 7, Title: "Fix <login>", URL: "https://github.com/acme/api/pull/7", Analysis: &QueuedAnalysis{Recommendation: "APPROVE", Risk: "LOW"}}}
+	s.byRef = map[string]*github.PullRequest{"acme/prod#1": {Owner: "acme", Repo: "prod", Number: 1}}
+	s.updated = time.Now()
+
+	if code, _ := slackRequest(t, s, "wrong", url.Values{"text": {"queue"}}); code != http.StatusUnauthorized {
+		t.Errorf("unsigned command = %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	_, resp := slackRequest(t, s, "shh", url.Values{"text": {"queue"}})
+	if !strings.Contains(resp.Text, "<https://github.com/acme/api/pull/7|acme/api#7> Fix &lt;login&gt; — APPROVE, low risk") {
+		t.Errorf("/speedrun queue = %q", resp.Text)
+	}
+
+	// Analyses can quote private repos, so only whoever asked sees them
+	if resp := s.slackAnalyze(t.Context(), "acme/api#7"); resp.ResponseType != "ephemeral" || !strings.Contains(resp.Text, "APPROVE") {
+		t.Errorf("/speedrun analyze = %+v, want the analysis just for the asker", resp)
+	}
+
+	_, resp = slackRequest(t, s, "shh", url.Values{"text": {"approve acme/prod#1"}, "user_id": {"U2"}})
+	if !strings.Contains(resp.Text, "not allowed") {
+		t.Errorf("/speedrun approve by a non-approver = %q", resp.Text)
+	}
+	// Anyone can take an approver's name
+	_, resp = slackRequest(t, s, "shh", url.Values{"text": {"approve acme/prod#1"}, "user_id": {"U2"}, "user_name": {"U1"}})
+	if !strings.Contains(resp.Text, "not allowed") {
+		t.Errorf("/speedrun approve by a non-approver named after an approver = %q", resp.Text)
+	}
+
+	// Without approvers no one can approve
+	s.slackApprovers = nil
+	_, resp = slackRequest(t, s, "shh", url.Values{"text": {"approve acme/prod#1"}, "user_id": {"U1"}})
+	if !strings.Contains(resp.Text, "off") {
+		t.Errorf("/speedrun approve without approvers = %q", resp.Text)
+	}
+
+	_, resp = slackRequest(t, s, "shh", url.Values{"text": {"dance"}})
+	if !strings.HasPrefix(resp.Text, "Usage:") {
+		t.Errorf("/speedrun dance = %q", resp.Text)
+	}
+}
+
+func TestSlackApproveRespondsLater(t *testing.T) {
+	responses := make(chan slack.Response, 1)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp slack.Response
+		if err := json.NewDecoder(r.Body).Decode(&resp); err != nil {
+			t.Error(err)
+		}
+		responses <- resp
+	}))
+	defer hook.Close()
+
+	cfg := &config.Config{}
+	cfg.Serve.SlackSigningSecret = "shh"
+	cfg.Serve.SlackApprovers = []string{"U1"}
+	cfg.GitHub.DeniedRepos = []string{"acme/prod"}
+	s := testServer(t, cfg)
+	s.byRef = map[string]*github.PullRequest{"acme/prod#1": {Owner: "acme", Repo: "prod", Number: 1}}
+
+	_, ack := slackRequest(t, s, "shh", url.Values{"text": {"approve acme/prod#1"}, "user_id": {"U1"}, "response_url": {hook.URL}})
+	if ack.ResponseType != "ephemeral" {
+		t.Errorf("acknowledgement = %+v, want ephemeral", ack)
+	}
+
+	select {
+	case resp := <-responses:
+		if resp.ResponseType != "ephemeral" || !strings.Contains(resp.Text, github.ErrRepoNotAllowed.Error()) {
+			t.Errorf("delayed response = %+v, want the refusal", resp)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no delayed response")
+	}
+}
@@ -0,0 +1,306 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/version"
+)
+
+const (
+	batchSize     = 256
+	maxQueueSize  = 2048
+	flushInterval = 5 * time.Second
+	exportTimeout = 10 * time.Second
+)
+
+// Exporter batches finished spans and pushes them to an OTLP/HTTP collector
+// using the JSON encoding
+type Exporter struct {
+	endpoint       string
+	headers        map[string]string
+	serviceName    string
+	serviceVersion string
+	httpClient     *http.Client
+
+	mu      sync.Mutex
+	queue   []*Span
+	dropped int
+
+	flush chan struct{}
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+// Init starts exporting spans when OTEL_EXPORTER_OTLP_ENDPOINT (or
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT) is set. The returned function flushes
+// pending spans and stops the exporter; it is safe to call when tracing is
+// disabled. Spans are exported over transport, http.DefaultTransport if nil.
+func Init(transport http.RoundTripper) func(context.Context) error {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	if endpoint == "" {
+		base := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+		if base == "" {
+			slog.Debug("Tracing disabled")
+			return func(context.Context) error { return nil }
+		}
+		endpoint = strings.TrimRight(base, "/") + "/v1/traces"
+	}
+
+	serviceName := os.Getenv("OTEL_SERVICE_NAME")
+	if serviceName == "" {
+		serviceName = "speedrun"
+	}
+
+	exp := NewExporter(endpoint, parseHeaders(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS")), serviceName, transport)
+	active.Store(exp)
+	slog.Info("Tracing enabled", slog.String("endpoint", endpoint), slog.String("service", serviceName))
+
+	return func(ctx context.Context) error {
+		active.CompareAndSwap(exp, nil)
+		return exp.Shutdown(ctx)
+	}
+}
+
+// NewExporter creates an exporter posting to endpoint over transport
+// (http.DefaultTransport if nil) and starts its flush loop
+func NewExporter(endpoint string, headers map[string]string, serviceName string, transport http.RoundTripper) *Exporter {
+	e := &Exporter{
+		endpoint:       endpoint,
+		headers:        headers,
+		serviceName:    serviceName,
+		serviceVersion: version.Get(),
+		httpClient:     &http.Client{Transport: transport, Timeout: exportTimeout},
+		flush:          make(chan struct{}, 1),
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+	go e.run()
+	return e
+}
+
+// Shutdown exports any queued spans and stops the flush loop
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	close(e.stop)
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return e.export(ctx)
+}
+
+func (e *Exporter) enqueue(span *Span) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.queue) >= maxQueueSize {
+		e.dropped++
+		return
+	}
+	e.queue = append(e.queue, span)
+
+	if len(e.queue) >= batchSize {
+		select {
+		case e.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (e *Exporter) run() {
+	defer close(e.done)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.stop:
+			return
+		case <-ticker.C:
+		case <-e.flush:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		if err := e.export(ctx); err != nil {
+			slog.Debug("Failed to export spans", slog.Any("error", err))
+		}
+		cancel()
+	}
+}
+
+// export sends everything currently queued in a single request
+func (e *Exporter) export(ctx context.Context) error {
+	e.mu.Lock()
+	spans := e.queue
+	dropped := e.dropped
+	e.queue = nil
+	e.dropped = 0
+	e.mu.Unlock()
+
+	if dropped > 0 {
+		slog.Debug("Dropped spans, export queue full", slog.Int("dropped", dropped))
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(e.encode(spans))
+	if err != nil {
+		return fmt.Errorf("failed to encode spans: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create export request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to export spans: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.Debug("Failed to close export response body", slog.Any("error", err))
+		}
+	}()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("span export failed with status %d", resp.StatusCode)
+	}
+
+	slog.Debug("Exported spans", slog.Int("count", len(spans)))
+	return nil
+}
+
+// OTLP JSON payload types. Only the fields speedrun populates are modelled.
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+	}
+	otlpSpan struct {
+		TraceID           string         `json:"traceId"`
+		SpanID            string         `json:"spanId"`
+		ParentSpanID      string         `json:"parentSpanId,omitempty"`
+		Name              string         `json:"name"`
+		Kind              int            `json:"kind"`
+		StartTimeUnixNano string         `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+		Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+		Status            *otlpStatus    `json:"status,omitempty"`
+	}
+	otlpStatus struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	}
+	otlpKeyValue struct {
+		Key   string         `json:"key"`
+		Value map[string]any `json:"value"`
+	}
+)
+
+// statusError is the OTLP STATUS_CODE_ERROR value
+const statusError = 2
+
+func (e *Exporter) encode(spans []*Span) otlpRequest {
+	out := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		s.mu.Lock()
+		span := otlpSpan{
+			TraceID:           hex.EncodeToString(s.traceID[:]),
+			SpanID:            hex.EncodeToString(s.spanID[:]),
+			Name:              s.name,
+			Kind:              int(s.kind),
+			StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+			Attributes:        encodeAttributes(s.attrs),
+		}
+		if s.parentID != [8]byte{} {
+			span.ParentSpanID = hex.EncodeToString(s.parentID[:])
+		}
+		if s.errMsg != "" {
+			span.Status = &otlpStatus{Code: statusError, Message: s.errMsg}
+		}
+		s.mu.Unlock()
+		out = append(out, span)
+	}
+
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: encodeAttributes([]Attribute{
+			String("service.name", e.serviceName),
+			String("service.version", e.serviceVersion),
+		})},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "github.com/kennyp/speedrun", Version: e.serviceVersion},
+			Spans: out,
+		}},
+	}}}
+}
+
+func encodeAttributes(attrs []Attribute) []otlpKeyValue {
+	out := make([]otlpKeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		var value map[string]any
+		switch v := a.Value.(type) {
+		case int64:
+			value = map[string]any{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			value = map[string]any{"doubleValue": v}
+		case bool:
+			value = map[string]any{"boolValue": v}
+		default:
+			value = map[string]any{"stringValue": fmt.Sprint(v)}
+		}
+		out = append(out, otlpKeyValue{Key: a.Key, Value: value})
+	}
+	return out
+}
+
+// parseHeaders parses OTEL_EXPORTER_OTLP_HEADERS ("k1=v1,k2=v2", URL-encoded values)
+func parseHeaders(raw string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		if decoded, err := url.QueryUnescape(strings.TrimSpace(value)); err == nil {
+			value = decoded
+		}
+		headers[strings.TrimSpace(key)] = value
+	}
+	return headers
+}
@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStartDisabledReturnsNilSpan(t *testing.T) {
+	ctx, span := Start(context.Background(), "noop")
+	if span != nil {
+		t.Fatal("expected nil span when tracing is disabled")
+	}
+	// All methods must be safe on a nil span
+	span.SetAttributes(String("k", "v"))
+	span.RecordError(errors.New("boom"))
+	span.End()
+	if FromContext(ctx) != nil {
+		t.Error("disabled Start stored a span in the context")
+	}
+}
+
+func TestExporterSendsParentedSpans(t *testing.T) {
+	var got otlpRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			t.Errorf("path = %s, want /v1/traces", r.URL.Path)
+		}
+		if r.Header.Get("X-Api-Key") != "secret" {
+			t.Errorf("missing configured header")
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", server.URL)
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "X-Api-Key=secret")
+	shutdown := Init(nil)
+
+	ctx, parent := Start(context.Background(), "parent", Int("n", 1))
+	_, child := Start(ctx, "child")
+	child.RecordError(errors.New("boom"))
+	child.End()
+	parent.End()
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if Enabled() {
+		t.Error("tracing still enabled after shutdown")
+	}
+
+	if len(got.ResourceSpans) != 1 || len(got.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("unexpected payload shape: %+v", got)
+	}
+	spans := got.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+
+	childSpan, parentSpan := spans[0], spans[1]
+	if childSpan.TraceID != parentSpan.TraceID {
+		t.Error("child span is in a different trace")
+	}
+	if childSpan.ParentSpanID != parentSpan.SpanID {
+		t.Errorf("child parent = %s, want %s", childSpan.ParentSpanID, parentSpan.SpanID)
+	}
+	if parentSpan.ParentSpanID != "" {
+		t.Error("root span has a parent")
+	}
+	if childSpan.Status == nil || childSpan.Status.Code != statusError {
+		t.Error("child error status not exported")
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	h := parseHeaders("a=1, b = x%20y ,bad")
+	if h["a"] != "1" || h["b"] != "x y" || len(h) != 2 {
+		t.Errorf("parseHeaders = %v", h)
+	}
+}
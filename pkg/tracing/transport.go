@@ -0,0 +1,41 @@
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Transport wraps each outgoing HTTP request in a client span
+type Transport struct {
+	Base http.RoundTripper
+}
+
+// NewTransport wraps base (http.DefaultTransport if nil) with client spans
+func NewTransport(base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{Base: base}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	_, span := StartKind(req.Context(), "HTTP "+req.Method, KindClient,
+		String("http.request.method", req.Method),
+		String("server.address", req.URL.Host),
+		String("url.path", req.URL.Path),
+	)
+	defer span.End()
+
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		return resp, err
+	}
+
+	span.SetAttributes(Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.RecordError(fmt.Errorf("HTTP %d", resp.StatusCode))
+	}
+	return resp, nil
+}
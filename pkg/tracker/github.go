@@ -0,0 +1,64 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/kennyp/speedrun/pkg/github"
+)
+
+// githubRefPattern matches #123 and owner/repo#123 references
+var githubRefPattern = regexp.MustCompile(`(?:\b([\w.-]+/[\w.-]+))?#([1-9][0-9]*)\b`)
+
+// githubKeyPattern splits a normalised owner/repo#123 key
+var githubKeyPattern = regexp.MustCompile(`^([\w.-]+)/([\w.-]+)#([0-9]+)$`)
+
+// Ensure GitHub implements Tracker
+var _ Tracker = (*GitHub)(nil)
+
+// GitHub looks up GitHub issues referenced from PRs
+type GitHub struct {
+	client *github.Client
+}
+
+// NewGitHub creates a tracker backed by GitHub issues
+func NewGitHub(client *github.Client) *GitHub {
+	return &GitHub{client: client}
+}
+
+// Keys implements Tracker. Bare #123 references resolve to the PR's own
+// repository, so keys are always owner/repo#123.
+func (g *GitHub) Keys(owner, repo, text string) []string {
+	var keys []string
+	for _, match := range githubRefPattern.FindAllStringSubmatch(text, -1) {
+		repoPath := match[1]
+		if repoPath == "" {
+			repoPath = owner + "/" + repo
+		}
+		keys = append(keys, repoPath+"#"+match[2])
+	}
+	return dedupe(keys)
+}
+
+// Lookup implements Tracker
+func (g *GitHub) Lookup(ctx context.Context, key string) (*Issue, error) {
+	match := githubKeyPattern.FindStringSubmatch(key)
+	if match == nil {
+		return nil, fmt.Errorf("invalid GitHub issue key %q", key)
+	}
+	number, _ := strconv.Atoi(match[3])
+
+	issue, err := g.client.GetIssue(ctx, match[1], match[2], number)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Issue{
+		Key:     key,
+		Summary: issue.Title,
+		Status:  issue.State,
+		URL:     issue.URL,
+	}, nil
+}
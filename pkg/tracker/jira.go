@@ -0,0 +1,102 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kennyp/speedrun/pkg/tracing"
+)
+
+// jiraKeyPattern matches Jira issue keys such as PROJ-123
+var jiraKeyPattern = regexp.MustCompile(`\b([A-Z][A-Z0-9_]+)-([1-9][0-9]*)\b`)
+
+// Ensure Jira implements Tracker
+var _ Tracker = (*Jira)(nil)
+
+// Jira looks up issues through the Jira REST API
+type Jira struct {
+	baseURL  string
+	user     string
+	token    string
+	projects []string
+	client   *http.Client
+}
+
+// NewJira creates a Jira tracker. With a user the token is sent as basic
+// auth (Jira Cloud API tokens), otherwise as a bearer token (Data Center
+// personal access tokens). If projects is non-empty only keys in those
+// projects are recognised, which avoids false positives like UTF-8.
+// Requests go over transport, http.DefaultTransport if nil.
+func NewJira(baseURL, user, token string, projects []string, transport http.RoundTripper) *Jira {
+	return &Jira{
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		user:     user,
+		token:    token,
+		projects: projects,
+		client:   &http.Client{Transport: tracing.NewTransport(transport), Timeout: 15 * time.Second},
+	}
+}
+
+// Keys implements Tracker
+func (j *Jira) Keys(_, _, text string) []string {
+	var keys []string
+	for _, match := range jiraKeyPattern.FindAllStringSubmatch(text, -1) {
+		if len(j.projects) > 0 && !slices.Contains(j.projects, match[1]) {
+			continue
+		}
+		keys = append(keys, match[0])
+	}
+	return dedupe(keys)
+}
+
+// Lookup implements Tracker
+func (j *Jira) Lookup(ctx context.Context, key string) (*Issue, error) {
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s?fields=summary,status", j.baseURL, url.PathEscape(key))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if j.user != "" {
+		req.SetBasicAuth(j.user, j.token)
+	} else if j.token != "" {
+		req.Header.Set("Authorization", "Bearer "+j.token)
+	}
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issue: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jira returned HTTP %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Key    string `json:"key"`
+		Fields struct {
+			Summary string `json:"summary"`
+			Status  struct {
+				Name string `json:"name"`
+			} `json:"status"`
+		} `json:"fields"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode issue: %w", err)
+	}
+
+	return &Issue{
+		Key:     body.Key,
+		Summary: body.Fields.Summary,
+		Status:  body.Fields.Status.Name,
+		URL:     fmt.Sprintf("%s/browse/%s", j.baseURL, body.Key),
+	}, nil
+}
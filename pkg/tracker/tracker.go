@@ -0,0 +1,68 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// maxIssues caps how many referenced issues are looked up per PR
+const maxIssues = 5
+
+// Issue is a tracker issue referenced from a PR
+type Issue struct {
+	Key     string // e.g. PROJ-123 or owner/repo#456
+	Summary string
+	Status  string
+	URL     string
+}
+
+// Tracker looks up issues referenced from PR titles and descriptions
+type Tracker interface {
+	// Keys extracts the issue references this tracker understands. owner and
+	// repo identify the PR's repository, for trackers with repo-relative keys.
+	Keys(owner, repo, text string) []string
+
+	// Lookup fetches a single issue by key
+	Lookup(ctx context.Context, key string) (*Issue, error)
+}
+
+// LinkedIssues looks up the issues referenced in a PR's title and body.
+// Issues that fail to load are skipped, so an error is only returned when
+// none of them could be loaded.
+func LinkedIssues(ctx context.Context, t Tracker, owner, repo, title, body string) ([]*Issue, error) {
+	keys := t.Keys(owner, repo, title+"\n"+body)
+	if len(keys) > maxIssues {
+		keys = keys[:maxIssues]
+	}
+
+	var issues []*Issue
+	var lastErr error
+	for _, key := range keys {
+		issue, err := t.Lookup(ctx, key)
+		if err != nil {
+			slog.Debug("Failed to look up linked issue", slog.String("key", key), slog.Any("error", err))
+			lastErr = fmt.Errorf("failed to look up %s: %w", key, err)
+			continue
+		}
+		issues = append(issues, issue)
+	}
+
+	if len(issues) == 0 {
+		return nil, lastErr
+	}
+	return issues, nil
+}
+
+// dedupe returns keys in first-seen order without repeats
+func dedupe(keys []string) []string {
+	seen := make(map[string]bool, len(keys))
+	out := keys[:0]
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			out = append(out, key)
+		}
+	}
+	return out
+}
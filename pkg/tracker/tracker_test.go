@@ -0,0 +1,62 @@
+package tracker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestJiraKeys(t *testing.T) {
+	text := "PROJ-12: fix UTF-8 handling\n\nFollow-up to PROJ-12 and OPS-7, see #45"
+
+	if got, want := NewJira("", "", "", nil, nil).Keys("", "", text), []string{"PROJ-12", "UTF-8", "OPS-7"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+	if got, want := NewJira("", "", "", []string{"PROJ", "OPS"}, nil).Keys("", "", text), []string{"PROJ-12", "OPS-7"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() with projects = %v, want %v", got, want)
+	}
+}
+
+func TestGitHubKeys(t *testing.T) {
+	text := "Fixes #45 and other/lib#7; refs #45 again (PROJ-1)"
+
+	got := NewGitHub(nil).Keys("acme", "app", text)
+	want := []string{"acme/app#45", "other/lib#7"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+}
+
+func TestJiraLookup(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "me@example.com" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/rest/api/2/issue/PROJ-12":
+			_, _ = w.Write([]byte(`{"key":"PROJ-12","fields":{"summary":"Broken login","status":{"name":"In Progress"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	jira := NewJira(srv.URL+"/", "me@example.com", "secret", nil, nil)
+
+	issues, err := LinkedIssues(context.Background(), jira, "acme", "app", "PROJ-12: fix login", "Also NOPE-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*Issue{{Key: "PROJ-12", Summary: "Broken login", Status: "In Progress", URL: srv.URL + "/browse/PROJ-12"}}
+	if !reflect.DeepEqual(issues, want) {
+		t.Errorf("LinkedIssues() = %+v, want %+v", issues[0], want[0])
+	}
+
+	if _, err := LinkedIssues(context.Background(), jira, "acme", "app", "NOPE-1", ""); err == nil {
+		t.Error("expected an error when no issue could be loaded")
+	}
+}
@@ -0,0 +1,108 @@
+package workload
+
+import (
+	"slices"
+	"sort"
+)
+
+// PR is an open pull request with its requested reviewers
+type PR struct {
+	ID        int64
+	Author    string
+	Reviewers []string
+}
+
+// Reassignment moves a review request on a PR from one teammate to another
+type Reassignment struct {
+	PRID int64
+	From string
+	To   string
+}
+
+// Counts returns how many PRs each teammate is requested on. Every teammate
+// is present, including those with no requests.
+func Counts(prs []PR, team []string) map[string]int {
+	counts := make(map[string]int, len(team))
+	for _, member := range team {
+		counts[member] = 0
+	}
+	for _, pr := range prs {
+		for _, reviewer := range pr.Reviewers {
+			if _, ok := counts[reviewer]; ok {
+				counts[reviewer]++
+			}
+		}
+	}
+	return counts
+}
+
+// Suggest proposes reassignments that even out review load across the team,
+// moving requests from the busiest teammates to the least busy ones until no
+// two teammates differ by more than one PR. Authors are never asked to review
+// their own PRs and nobody is requested twice on the same PR.
+func Suggest(prs []PR, team []string) []Reassignment {
+	counts := Counts(prs, team)
+	reviewers := make(map[int64][]string, len(prs))
+	for _, pr := range prs {
+		reviewers[pr.ID] = slices.Clone(pr.Reviewers)
+	}
+
+	var moves []Reassignment
+	stuck := make(map[string]bool) // Busy teammates with nothing left to hand off
+	for {
+		members := byLoad(counts)
+		var from string
+		for i := len(members) - 1; i >= 0; i-- {
+			if !stuck[members[i]] {
+				from = members[i]
+				break
+			}
+		}
+		if from == "" || counts[from]-counts[members[0]] <= 1 {
+			return moves
+		}
+
+		move, ok := findMove(prs, reviewers, members, counts, from)
+		if !ok {
+			stuck[from] = true
+			continue
+		}
+
+		moves = append(moves, move)
+		reviewers[move.PRID] = append(slices.DeleteFunc(reviewers[move.PRID], func(r string) bool { return r == from }), move.To)
+		counts[from]--
+		counts[move.To]++
+	}
+}
+
+// findMove picks a PR to hand from the given teammate to whoever is least
+// busy and able to take it
+func findMove(prs []PR, reviewers map[int64][]string, members []string, counts map[string]int, from string) (Reassignment, bool) {
+	for _, to := range members {
+		if counts[from]-counts[to] <= 1 {
+			break
+		}
+		for _, pr := range prs {
+			current := reviewers[pr.ID]
+			if slices.Contains(current, from) && !slices.Contains(current, to) && pr.Author != to {
+				return Reassignment{PRID: pr.ID, From: from, To: to}, true
+			}
+		}
+	}
+	return Reassignment{}, false
+}
+
+// byLoad returns teammates from least to most busy, by name within a load
+func byLoad(counts map[string]int) []string {
+	members := make([]string, 0, len(counts))
+	for member := range counts {
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if counts[members[i]] != counts[members[j]] {
+			return counts[members[i]] < counts[members[j]]
+		}
+		return members[i] < members[j]
+	})
+	return members
+}
@@ -0,0 +1,68 @@
+package workload
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestCounts(t *testing.T) {
+	prs := []PR{
+		{ID: 1, Reviewers: []string{"alice", "outsider"}},
+		{ID: 2, Reviewers: []string{"alice", "bob"}},
+	}
+
+	want := map[string]int{"alice": 2, "bob": 1, "carol": 0}
+	if got := Counts(prs, []string{"alice", "bob", "carol"}); !maps.Equal(got, want) {
+		t.Errorf("Counts() = %v, want %v", got, want)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	team := []string{"alice", "bob", "carol"}
+	prs := []PR{
+		{ID: 1, Author: "dave", Reviewers: []string{"alice"}},
+		{ID: 2, Author: "carol", Reviewers: []string{"alice"}},
+		{ID: 3, Author: "dave", Reviewers: []string{"alice", "bob"}},
+		{ID: 4, Author: "dave", Reviewers: []string{"alice"}},
+	}
+
+	moves := Suggest(prs, team)
+
+	// Apply the moves and check the result is balanced and valid
+	for _, move := range moves {
+		for i := range prs {
+			if prs[i].ID != move.PRID {
+				continue
+			}
+			if prs[i].Author == move.To {
+				t.Errorf("move %+v asks the author to review", move)
+			}
+			var reviewers []string
+			for _, r := range prs[i].Reviewers {
+				if r == move.To {
+					t.Errorf("move %+v requests a reviewer twice", move)
+				}
+				if r != move.From {
+					reviewers = append(reviewers, r)
+				}
+			}
+			prs[i].Reviewers = append(reviewers, move.To)
+		}
+	}
+
+	counts := Counts(prs, team)
+	lo, hi := len(prs), 0
+	for _, n := range counts {
+		lo, hi = min(lo, n), max(hi, n)
+	}
+	if hi-lo > 1 {
+		t.Errorf("load still unbalanced after %d moves: %v", len(moves), counts)
+	}
+}
+
+func TestSuggestBalanced(t *testing.T) {
+	prs := []PR{{ID: 1, Reviewers: []string{"alice"}}, {ID: 2, Reviewers: []string{"bob"}}}
+	if moves := Suggest(prs, []string{"alice", "bob"}); len(moves) != 0 {
+		t.Errorf("Suggest() on balanced team = %v", moves)
+	}
+}